@@ -555,11 +555,18 @@ type DestroyApplicationsParams struct {
 	// will wait before forcing the next step to kick-off. This parameter
 	// only makes sense in combination with 'force' set to 'true'.
 	MaxWait *time.Duration
+
+	// Cascade controls whether or not subordinate applications and
+	// machines which are left unused by removing the applications
+	// will be removed as well.
+	Cascade bool
 }
 
-// DestroyApplications destroys the given applications.
-func (c *Client) DestroyApplications(in DestroyApplicationsParams) ([]params.DestroyApplicationResult, error) {
-	argsV5 := params.DestroyApplicationsParams{
+// destroyApplicationsArgs returns the arguments for destroying the valid
+// applications in the given params, along with their indexes in the
+// params and results for the invalid ones.
+func destroyApplicationsArgs(in DestroyApplicationsParams) (params.DestroyApplicationsParams, []int, []params.DestroyApplicationResult) {
+	args := params.DestroyApplicationsParams{
 		Applications: make([]params.DestroyApplicationParams, 0, len(in.Applications)),
 	}
 	allResults := make([]params.DestroyApplicationResult, len(in.Applications))
@@ -572,13 +579,46 @@ func (c *Client) DestroyApplications(in DestroyApplicationsParams) ([]params.Des
 			continue
 		}
 		index = append(index, i)
-		argsV5.Applications = append(argsV5.Applications, params.DestroyApplicationParams{
+		args.Applications = append(args.Applications, params.DestroyApplicationParams{
 			ApplicationTag: names.NewApplicationTag(name).String(),
 			DestroyStorage: in.DestroyStorage,
 			Force:          in.Force,
 			MaxWait:        in.MaxWait,
+			Cascade:        in.Cascade,
 		})
 	}
+	return args, index, allResults
+}
+
+// PlanDestroyApplications returns what destroying the given applications
+// would affect, without destroying them.
+func (c *Client) PlanDestroyApplications(in DestroyApplicationsParams) ([]params.DestroyApplicationResult, error) {
+	if c.BestAPIVersion() < 15 {
+		return nil, errors.NotSupportedf("planning the removal of applications by this controller")
+	}
+	args, index, allResults := destroyApplicationsArgs(in)
+	if len(args.Applications) == 0 {
+		return allResults, nil
+	}
+	var result params.DestroyApplicationResults
+	if err := c.facade.FacadeCall("PlanDestroyApplication", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(result.Results); n != len(args.Applications) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(args.Applications), n)
+	}
+	for i, result := range result.Results {
+		allResults[index[i]] = result
+	}
+	return allResults, nil
+}
+
+// DestroyApplications destroys the given applications.
+func (c *Client) DestroyApplications(in DestroyApplicationsParams) ([]params.DestroyApplicationResult, error) {
+	if in.Cascade && c.BestAPIVersion() < 15 {
+		return nil, errors.NotSupportedf("cascading the removal of applications by this controller")
+	}
+	argsV5, index, allResults := destroyApplicationsArgs(in)
 	if len(argsV5.Applications) == 0 {
 		return allResults, nil
 	}
@@ -1128,3 +1168,47 @@ func unitInfoFromParams(in params.UnitInfoResult) UnitInfo {
 	}
 	return info
 }
+
+// RemoteRelationInfo returns information about the cross model relations
+// with the given ids, and the cross model relations of the named
+// applications, to help diagnose relations that are not progressing.
+func (c *Client) RemoteRelationInfo(relationIds []int, applications []string) ([]params.RemoteRelationInfoResult, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 13 {
+		return nil, errors.NotSupportedf("RemoteRelationInfo for Application facade v%v", apiVersion)
+	}
+	in := params.RemoteRelationInfoArgs{
+		RelationIds:  relationIds,
+		Applications: applications,
+	}
+	var out params.RemoteRelationInfoResults
+	if err := c.facade.FacadeCall("RemoteRelationInfo", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Results, nil
+}
+
+// AutoscaleHistory returns the changes made, or attempted, by the
+// autoscaler to the number of units of the given application, oldest
+// first.
+func (c *Client) AutoscaleHistory(application string) ([]params.AutoscaleHistoryEntry, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 16 {
+		return nil, errors.NotSupportedf("AutoscaleHistory for Application facade v%v", apiVersion)
+	}
+	if !names.IsValidApplication(application) {
+		return nil, errors.NotValidf("application name %q", application)
+	}
+	in := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var out params.AutoscaleHistoryResults
+	if err := c.facade.FacadeCall("AutoscaleHistory", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(out.Results))
+	}
+	if err := out.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Results[0].Entries, nil
+}
@@ -428,6 +428,53 @@ func (s *applicationSuite) TestDestroyApplicationsInvalidIds(c *gc.C) {
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
 
+func (s *applicationSuite) TestPlanDestroyApplications(c *gc.C) {
+	expectedResults := []params.DestroyApplicationResult{{
+		Info: &params.DestroyApplicationInfo{
+			DestroyedUnits:        []params.Entity{{Tag: "unit-foo-0"}},
+			DestroyedRelations:    []params.Entity{{Tag: "relation-foo.db#bar.db"}},
+			DestroyedApplications: []params.Entity{{Tag: "application-logging"}},
+			DestroyedMachines:     []params.Entity{{Tag: "machine-0"}},
+		},
+	}}
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "PlanDestroyApplication")
+			c.Assert(a, jc.DeepEquals, params.DestroyApplicationsParams{
+				Applications: []params.DestroyApplicationParams{
+					{ApplicationTag: "application-foo", Cascade: true},
+				},
+			})
+			out := response.(*params.DestroyApplicationResults)
+			*out = params.DestroyApplicationResults{expectedResults}
+			return nil
+		},
+		BestVersion: 15,
+	})
+	results, err := client.PlanDestroyApplications(application.DestroyApplicationsParams{
+		Applications: []string{"foo"},
+		Cascade:      true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expectedResults)
+}
+
+func (s *applicationSuite) TestPlanDestroyApplicationsNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %q", request)
+		return nil
+	})
+	_, err := client.PlanDestroyApplications(application.DestroyApplicationsParams{
+		Applications: []string{"foo"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.DestroyApplications(application.DestroyApplicationsParams{
+		Applications: []string{"foo"},
+		Cascade:      true,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *applicationSuite) TestDestroyConsumedApplications(c *gc.C) {
 	expectedResults := []params.ErrorResult{{
 		Error: &params.Error{Message: "boo"},
@@ -1705,3 +1752,100 @@ func (s *applicationSuite) TestUnitssInfoResultMismatch(c *gc.C) {
 	)
 	c.Assert(err, gc.ErrorMatches, "expected 2 results, got 3")
 }
+
+func (s *applicationSuite) TestRemoteRelationInfoNotSupported(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			return nil
+		},
+	)
+	client := application.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 12})
+	_, err := client.RemoteRelationInfo([]int{1}, nil)
+	c.Assert(err, gc.ErrorMatches, "RemoteRelationInfo for Application facade v12 not supported")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *applicationSuite) TestRemoteRelationInfo(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "RemoteRelationInfo")
+			c.Assert(a, jc.DeepEquals, params.RemoteRelationInfoArgs{
+				RelationIds:  []int{1},
+				Applications: []string{"mysql"},
+			})
+			result, ok := response.(*params.RemoteRelationInfoResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.RemoteRelationInfoResult{
+				{Result: &params.RemoteRelationInfo{Id: 1, Key: "wordpress:db mysql:server"}},
+				{Error: &params.Error{Message: "boom"}},
+			}
+			return nil
+		},
+	)
+	client := application.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 13})
+	results, err := client.RemoteRelationInfo([]int{1}, []string{"mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(results, jc.DeepEquals, []params.RemoteRelationInfoResult{
+		{Result: &params.RemoteRelationInfo{Id: 1, Key: "wordpress:db mysql:server"}},
+		{Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *applicationSuite) TestAutoscaleHistoryNotSupported(c *gc.C) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			return nil
+		},
+	)
+	client := application.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 15})
+	_, err := client.AutoscaleHistory("mysql")
+	c.Assert(err, gc.ErrorMatches, "AutoscaleHistory for Application facade v15 not supported")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *applicationSuite) TestAutoscaleHistory(c *gc.C) {
+	when := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "AutoscaleHistory")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-mysql"}},
+			})
+			result, ok := response.(*params.AutoscaleHistoryResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.AutoscaleHistoryResult{{
+				Entries: []params.AutoscaleHistoryEntry{{Time: when, FromUnits: 1, ToUnits: 2}},
+			}}
+			return nil
+		},
+	)
+	client := application.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 16})
+	entries, err := client.AutoscaleHistory("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(entries, jc.DeepEquals, []params.AutoscaleHistoryEntry{{Time: when, FromUnits: 1, ToUnits: 2}})
+}
+
+func (s *applicationSuite) TestAutoscaleHistoryError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			result := response.(*params.AutoscaleHistoryResults)
+			result.Results = []params.AutoscaleHistoryResult{{
+				Error: &params.Error{Message: `application "mysql" not found`},
+			}}
+			return nil
+		},
+	)
+	client := application.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 16})
+	_, err := client.AutoscaleHistory("mysql")
+	c.Assert(err, gc.ErrorMatches, `application "mysql" not found`)
+}
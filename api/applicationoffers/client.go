@@ -4,6 +4,8 @@
 package applicationoffers
 
 import (
+	"time"
+
 	"github.com/juju/charm/v7"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -318,3 +320,76 @@ func (c *Client) DestroyOffers(force bool, offerURLs ...string) error {
 	}
 	return result.Combine()
 }
+
+// CreateOfferToken creates a token which allows the specified offer to be
+// consumed by a user who is unknown to the offer's controller. The token
+// expires at the given time, and may be redeemed at most maxUses times,
+// or any number of times if maxUses is 0.
+func (c *Client) CreateOfferToken(offerURL string, expires time.Time, maxUses int) (params.CreateOfferTokenResult, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return params.CreateOfferTokenResult{}, errors.NotSupportedf("offer tokens (need v3+, have v%d)", bestVer)
+	}
+	if _, err := crossmodel.ParseOfferURL(offerURL); err != nil {
+		return params.CreateOfferTokenResult{}, errors.Trace(err)
+	}
+	args := params.CreateOfferTokensArgs{
+		Args: []params.CreateOfferTokenArg{{
+			OfferURL: offerURL,
+			Expires:  expires,
+			MaxUses:  maxUses,
+		}},
+	}
+	var results params.CreateOfferTokenResults
+	if err := c.facade.FacadeCall("CreateOfferTokens", args, &results); err != nil {
+		return params.CreateOfferTokenResult{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.CreateOfferTokenResult{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.CreateOfferTokenResult{}, errors.Trace(err)
+	}
+	return results.Results[0], nil
+}
+
+// ListOfferTokens returns the tokens created for the specified offer.
+func (c *Client) ListOfferTokens(offerURL string) ([]params.OfferToken, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return nil, errors.NotSupportedf("offer tokens (need v3+, have v%d)", bestVer)
+	}
+	if _, err := crossmodel.ParseOfferURL(offerURL); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results params.OfferTokensResults
+	args := params.OfferURLs{OfferURLs: []string{offerURL}}
+	if err := c.facade.FacadeCall("ListOfferTokens", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].Tokens, nil
+}
+
+// RevokeOfferToken revokes the token with the given id for the specified
+// offer, so it can no longer be redeemed. Relations made by consuming the
+// offer with the token are suspended.
+func (c *Client) RevokeOfferToken(offerURL, id string) error {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return errors.NotSupportedf("offer tokens (need v3+, have v%d)", bestVer)
+	}
+	if _, err := crossmodel.ParseOfferURL(offerURL); err != nil {
+		return errors.Trace(err)
+	}
+	args := params.OfferTokenArgs{
+		Args: []params.OfferTokenArg{{OfferURL: offerURL, Id: id}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RevokeOfferTokens", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -701,3 +701,123 @@ func (s *crossmodelMockSuite) TestDestroyOffersForce(c *gc.C) {
 
 	c.Assert(err, gc.ErrorMatches, "DestroyOffers\\(\\).* not implemented")
 }
+
+func (s *crossmodelMockSuite) TestCreateOfferToken(c *gc.C) {
+	expires := time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC)
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				called = true
+				c.Assert(request, gc.Equals, "CreateOfferTokens")
+				c.Assert(a, jc.DeepEquals, params.CreateOfferTokensArgs{
+					Args: []params.CreateOfferTokenArg{{
+						OfferURL: "fred/prod.db",
+						Expires:  expires,
+						MaxUses:  2,
+					}},
+				})
+				if results, ok := result.(*params.CreateOfferTokenResults); ok {
+					results.Results = []params.CreateOfferTokenResult{{
+						Id:        "token-id",
+						SecretKey: "secret",
+						ModelTag:  testing.ModelTag.String(),
+						OfferURL:  "fred/prod.db",
+					}}
+				}
+				return nil
+			},
+		),
+		BestVersion: 3,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	result, err := client.CreateOfferToken("fred/prod.db", expires, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, params.CreateOfferTokenResult{
+		Id:        "token-id",
+		SecretKey: "secret",
+		ModelTag:  testing.ModelTag.String(),
+		OfferURL:  "fred/prod.db",
+	})
+}
+
+func (s *crossmodelMockSuite) TestCreateOfferTokenNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fail()
+				return nil
+			},
+		),
+		BestVersion: 2,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	_, err := client.CreateOfferToken("fred/prod.db", time.Now(), 0)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *crossmodelMockSuite) TestListOfferTokens(c *gc.C) {
+	tokens := []params.OfferToken{{
+		Id:        "token-id",
+		OfferURL:  "fred/prod.db",
+		CreatedBy: "fred",
+		Uses:      1,
+	}}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Assert(request, gc.Equals, "ListOfferTokens")
+				c.Assert(a, jc.DeepEquals, params.OfferURLs{OfferURLs: []string{"fred/prod.db"}})
+				if results, ok := result.(*params.OfferTokensResults); ok {
+					results.Results = []params.OfferTokensResult{{Tokens: tokens}}
+				}
+				return nil
+			},
+		),
+		BestVersion: 3,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	result, err := client.ListOfferTokens("fred/prod.db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, tokens)
+}
+
+func (s *crossmodelMockSuite) TestRevokeOfferToken(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Assert(request, gc.Equals, "RevokeOfferTokens")
+				c.Assert(a, jc.DeepEquals, params.OfferTokenArgs{
+					Args: []params.OfferTokenArg{{OfferURL: "fred/prod.db", Id: "token-id"}},
+				})
+				if results, ok := result.(*params.ErrorResults); ok {
+					results.Results = []params.ErrorResult{{
+						Error: &params.Error{Message: "fail"},
+					}}
+				}
+				return nil
+			},
+		),
+		BestVersion: 3,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	err := client.RevokeOfferToken("fred/prod.db", "token-id")
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
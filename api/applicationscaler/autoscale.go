@@ -0,0 +1,108 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationscaler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
+)
+
+// AutoscaleCandidate describes an application whose autoscale policy is
+// enabled.
+type AutoscaleCandidate struct {
+	Application names.ApplicationTag
+	Policy      application.AutoscalePolicy
+
+	// Units is the number of units the application has.
+	Units int
+
+	// Value is the latest measurement of the policy's source, valid
+	// only if HasValue is true.
+	Value    float64
+	HasValue bool
+
+	// LastScaled is the time the application was last scaled by the
+	// autoscaler, or the zero time if it never has been.
+	LastScaled time.Time
+}
+
+// AutoscaleDecision describes a change to the number of units of an
+// application.
+type AutoscaleDecision struct {
+	Application names.ApplicationTag
+	FromUnits   int
+	ToUnits     int
+	Source      application.AutoscaleSource
+	Value       float64
+	Reason      string
+}
+
+// AutoscaleCandidates returns the applications whose autoscale policy is
+// enabled.
+func (api *API) AutoscaleCandidates() ([]AutoscaleCandidate, error) {
+	var result params.AutoscaleCandidatesResult
+	if err := api.caller.FacadeCall("AutoscaleCandidates", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	candidates := make([]AutoscaleCandidate, len(result.Candidates))
+	for i, in := range result.Candidates {
+		tag, err := names.ParseApplicationTag(in.ApplicationTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		source, err := application.ParseAutoscaleSource(in.Source)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		candidate := AutoscaleCandidate{
+			Application: tag,
+			Policy: application.AutoscalePolicy{
+				Source:             source,
+				MinUnits:           in.MinUnits,
+				MaxUnits:           in.MaxUnits,
+				ScaleUpThreshold:   in.ScaleUpThreshold,
+				ScaleDownThreshold: in.ScaleDownThreshold,
+				Cooldown:           in.Cooldown,
+			},
+			Units: in.Units,
+		}
+		if in.Value != nil {
+			candidate.Value = *in.Value
+			candidate.HasValue = true
+		}
+		if in.LastScaled != nil {
+			candidate.LastScaled = *in.LastScaled
+		}
+		candidates[i] = candidate
+	}
+	return candidates, nil
+}
+
+// ScaleApplication changes the number of units of an application as
+// decided by the autoscaler.
+func (api *API) ScaleApplication(decision AutoscaleDecision) error {
+	args := params.AutoscaleDecisions{
+		Decisions: []params.AutoscaleDecision{{
+			ApplicationTag: decision.Application.String(),
+			FromUnits:      decision.FromUnits,
+			ToUnits:        decision.ToUnits,
+			Source:         decision.Source.String(),
+			Value:          decision.Value,
+			Reason:         decision.Reason,
+		}},
+	}
+	var results params.ErrorResults
+	if err := api.caller.FacadeCall("ScaleApplications", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.OneError())
+}
@@ -0,0 +1,136 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationscaler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/applicationscaler"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/status"
+)
+
+type AutoscaleSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&AutoscaleSuite{})
+
+func (s *AutoscaleSuite) TestAutoscaleCandidates(c *gc.C) {
+	value := 0.9
+	lastScaled := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "AutoscaleCandidates")
+		c.Check(arg, gc.IsNil)
+		*result.(*params.AutoscaleCandidatesResult) = params.AutoscaleCandidatesResult{
+			Candidates: []params.AutoscaleCandidate{{
+				ApplicationTag:     "application-metered",
+				Units:              2,
+				Source:             "metric:load",
+				Value:              &value,
+				MinUnits:           1,
+				MaxUnits:           3,
+				ScaleUpThreshold:   0.8,
+				ScaleDownThreshold: 0.2,
+				Cooldown:           time.Minute,
+				LastScaled:         &lastScaled,
+			}, {
+				ApplicationTag: "application-busy",
+				Units:          1,
+				Source:         "status:maintenance",
+				MaxUnits:       2,
+			}},
+		}
+		return nil
+	})
+	api := applicationscaler.NewAPI(caller, nil)
+
+	candidates, err := api.AutoscaleCandidates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(candidates, jc.DeepEquals, []applicationscaler.AutoscaleCandidate{{
+		Application: names.NewApplicationTag("metered"),
+		Policy: application.AutoscalePolicy{
+			Source:             application.AutoscaleSource{Metric: "load"},
+			MinUnits:           1,
+			MaxUnits:           3,
+			ScaleUpThreshold:   0.8,
+			ScaleDownThreshold: 0.2,
+			Cooldown:           time.Minute,
+		},
+		Units:      2,
+		Value:      0.9,
+		HasValue:   true,
+		LastScaled: lastScaled,
+	}, {
+		Application: names.NewApplicationTag("busy"),
+		Policy: application.AutoscalePolicy{
+			Source:   application.AutoscaleSource{Status: status.Maintenance},
+			MaxUnits: 2,
+		},
+		Units: 1,
+	}})
+}
+
+func (s *AutoscaleSuite) TestAutoscaleCandidatesError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		*result.(*params.AutoscaleCandidatesResult) = params.AutoscaleCandidatesResult{
+			Error: &params.Error{Message: "blam"},
+		}
+		return nil
+	})
+	api := applicationscaler.NewAPI(caller, nil)
+
+	_, err := api.AutoscaleCandidates()
+	c.Check(err, gc.ErrorMatches, "blam")
+}
+
+func (s *AutoscaleSuite) TestScaleApplication(c *gc.C) {
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "ScaleApplications")
+		c.Check(arg, jc.DeepEquals, params.AutoscaleDecisions{
+			Decisions: []params.AutoscaleDecision{{
+				ApplicationTag: "application-metered",
+				FromUnits:      2,
+				ToUnits:        3,
+				Source:         "metric:load",
+				Value:          0.9,
+				Reason:         "metric:load 0.9 above 0.8",
+			}},
+		})
+		*result.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "no machines"}}},
+		}
+		return nil
+	})
+	api := applicationscaler.NewAPI(caller, nil)
+
+	err := api.ScaleApplication(applicationscaler.AutoscaleDecision{
+		Application: names.NewApplicationTag("metered"),
+		FromUnits:   2,
+		ToUnits:     3,
+		Source:      application.AutoscaleSource{Metric: "load"},
+		Value:       0.9,
+		Reason:      "metric:load 0.9 above 0.8",
+	})
+	c.Check(err, gc.ErrorMatches, "no machines")
+}
+
+func (s *AutoscaleSuite) TestScaleApplicationCallError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, _ interface{}) error {
+		return errors.New("snorble flip")
+	})
+	api := applicationscaler.NewAPI(caller, nil)
+
+	err := api.ScaleApplication(applicationscaler.AutoscaleDecision{
+		Application: names.NewApplicationTag("metered"),
+	})
+	c.Check(err, gc.ErrorMatches, "snorble flip")
+}
@@ -78,3 +78,40 @@ func (c *Client) ExportBundle() (string, error) {
 
 	return result.Result, nil
 }
+
+// ExportedBundle holds a bundle exported from a model by ExportFullBundle.
+type ExportedBundle struct {
+	// Bundle holds the YAML-encoded bundle.
+	Bundle string
+
+	// Overlay holds the YAML-encoded overlay for the offers of the
+	// model, the offers it consumes and the relations to them, or is
+	// empty if there are none.
+	Overlay string
+
+	// ModelConfig holds the config set on the model.
+	ModelConfig map[string]interface{}
+}
+
+// ExportFullBundle exports the current model as a bundle, overlay and model
+// config from which the model can be reproduced.
+func (c *Client) ExportFullBundle() (ExportedBundle, error) {
+	var result params.ExportBundleResult
+	if bestVer := c.BestAPIVersion(); bestVer < 5 {
+		return ExportedBundle{}, errors.Errorf("this controller version does not support full bundle export")
+	}
+
+	if err := c.facade.FacadeCall("ExportFullBundle", nil, &result); err != nil {
+		return ExportedBundle{}, errors.Trace(err)
+	}
+
+	if result.Error != nil {
+		return ExportedBundle{}, errors.Trace(result.Error)
+	}
+
+	return ExportedBundle{
+		Bundle:      result.Bundle,
+		Overlay:     result.Overlay,
+		ModelConfig: result.ModelConfig,
+	}, nil
+}
@@ -361,3 +361,47 @@ func (s *bundleMockSuite) TestExportBundleFailNoParamsErrorv2(c *gc.C) {
 	c.Assert(result, jc.DeepEquals, "")
 	c.Check(err.Error(), gc.Matches, "foo")
 }
+
+func (s *bundleMockSuite) TestFailExportFullBundlev4(c *gc.C) {
+	client := newClient(
+		func(objType string, version int,
+			id,
+			request string,
+			args,
+			response interface{},
+		) error {
+			c.Fatalf("unexpected call to %q", request)
+			return nil
+		}, 4,
+	)
+	_, err := client.ExportFullBundle()
+	c.Assert(err, gc.ErrorMatches, "this controller version does not support full bundle export")
+}
+
+func (s *bundleMockSuite) TestExportFullBundlev5(c *gc.C) {
+	client := newClient(
+		func(objType string, version int,
+			id,
+			request string,
+			args,
+			response interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Bundle")
+			c.Check(request, gc.Equals, "ExportFullBundle")
+			c.Assert(args, gc.Equals, nil)
+			*(response.(*params.ExportBundleResult)) = params.ExportBundleResult{
+				Bundle:      "applications: {}\n",
+				Overlay:     "saas: {}\n",
+				ModelConfig: map[string]interface{}{"logging-config": "<root>=DEBUG"},
+			}
+			return nil
+		}, 5,
+	)
+	result, err := client.ExportFullBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, bundle.ExportedBundle{
+		Bundle:      "applications: {}\n",
+		Overlay:     "saas: {}\n",
+		ModelConfig: map[string]interface{}{"logging-config": "<root>=DEBUG"},
+	})
+}
@@ -4,8 +4,13 @@
 package charmrevisionupdater
 
 import (
+	"github.com/juju/charm/v7"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 )
 
 // Client provides access to a worker's view of the state.
@@ -31,3 +36,81 @@ func (st *Client) UpdateLatestRevisions() error {
 	}
 	return nil
 }
+
+// CharmUpgradeCandidate describes an application whose charm may be
+// upgraded automatically according to its charm update policy.
+type CharmUpgradeCandidate struct {
+	// Application identifies the application.
+	Application names.ApplicationTag
+
+	// CharmURL is the URL of the application's current charm.
+	CharmURL *charm.URL
+
+	// LatestCharmURL is the URL of the latest revision of the charm in
+	// the application's channel.
+	LatestCharmURL *charm.URL
+
+	// MaintenanceWindow restricts when the upgrade may take place.
+	MaintenanceWindow application.MaintenanceWindow
+}
+
+// CharmUpgradeCandidates returns the applications whose charms are to be
+// upgraded automatically, and for which a later revision is available.
+func (st *Client) CharmUpgradeCandidates() ([]CharmUpgradeCandidate, error) {
+	var result params.CharmUpgradeCandidatesResult
+	if err := st.facade.FacadeCall("CharmUpgradeCandidates", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	candidates := make([]CharmUpgradeCandidate, len(result.Candidates))
+	for i, candidate := range result.Candidates {
+		tag, err := names.ParseApplicationTag(candidate.ApplicationTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		curl, err := charm.ParseURL(candidate.CharmURL)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		latest, err := charm.ParseURL(candidate.LatestCharmURL)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		window, err := application.ParseMaintenanceWindow(candidate.MaintenanceWindow)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		candidates[i] = CharmUpgradeCandidate{
+			Application:       tag,
+			CharmURL:          curl,
+			LatestCharmURL:    latest,
+			MaintenanceWindow: window,
+		}
+	}
+	return candidates, nil
+}
+
+// UpgradeCharm upgrades the application to the given revision of its
+// charm, returning the id of the operation recording the upgrade.
+func (st *Client) UpgradeCharm(app names.ApplicationTag, curl *charm.URL) (string, error) {
+	args := params.CharmUpgradeArgs{
+		Args: []params.CharmUpgradeArg{{
+			ApplicationTag: app.String(),
+			CharmURL:       curl.String(),
+		}},
+	}
+	var results params.CharmUpgradeResults
+	if err := st.facade.FacadeCall("UpgradeCharms", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return result.OperationId, result.Error
+	}
+	return result.OperationId, nil
+}
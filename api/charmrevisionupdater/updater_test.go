@@ -4,11 +4,17 @@
 package charmrevisionupdater_test
 
 import (
+	"time"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/charmrevisionupdater"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -36,3 +42,88 @@ func (s *versionUpdaterSuite) TestUpdateRevisions(c *gc.C) {
 	err := client.UpdateLatestRevisions()
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
+
+func (s *versionUpdaterSuite) TestCharmUpgradeCandidates(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CharmRevisionUpdater")
+		c.Check(request, gc.Equals, "CharmUpgradeCandidates")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.CharmUpgradeCandidatesResult{})
+		*(result.(*params.CharmUpgradeCandidatesResult)) = params.CharmUpgradeCandidatesResult{
+			Candidates: []params.CharmUpgradeCandidate{{
+				ApplicationTag:    "application-mysql",
+				CharmURL:          "cs:quantal/mysql-22",
+				LatestCharmURL:    "cs:quantal/mysql-23",
+				MaintenanceWindow: "sat 02:00-04:00",
+			}},
+		}
+		return nil
+	})
+
+	client := charmrevisionupdater.NewClient(apiCaller)
+	candidates, err := client.CharmUpgradeCandidates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(candidates, jc.DeepEquals, []charmrevisionupdater.CharmUpgradeCandidate{{
+		Application:    names.NewApplicationTag("mysql"),
+		CharmURL:       charm.MustParseURL("cs:quantal/mysql-22"),
+		LatestCharmURL: charm.MustParseURL("cs:quantal/mysql-23"),
+		MaintenanceWindow: application.MaintenanceWindow{
+			Days:     []time.Weekday{time.Saturday},
+			Start:    2 * time.Hour,
+			Duration: 2 * time.Hour,
+		},
+	}})
+}
+
+func (s *versionUpdaterSuite) TestCharmUpgradeCandidatesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.CharmUpgradeCandidatesResult)) = params.CharmUpgradeCandidatesResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+
+	client := charmrevisionupdater.NewClient(apiCaller)
+	_, err := client.CharmUpgradeCandidates()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *versionUpdaterSuite) TestUpgradeCharm(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CharmRevisionUpdater")
+		c.Check(request, gc.Equals, "UpgradeCharms")
+		c.Check(arg, jc.DeepEquals, params.CharmUpgradeArgs{
+			Args: []params.CharmUpgradeArg{{
+				ApplicationTag: "application-mysql",
+				CharmURL:       "cs:quantal/mysql-23",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.CharmUpgradeResults{})
+		*(result.(*params.CharmUpgradeResults)) = params.CharmUpgradeResults{
+			Results: []params.CharmUpgradeResult{{OperationId: "42"}},
+		}
+		return nil
+	})
+
+	client := charmrevisionupdater.NewClient(apiCaller)
+	operationID, err := client.UpgradeCharm(names.NewApplicationTag("mysql"), charm.MustParseURL("cs:quantal/mysql-23"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operationID, gc.Equals, "42")
+}
+
+func (s *versionUpdaterSuite) TestUpgradeCharmError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.CharmUpgradeResults)) = params.CharmUpgradeResults{
+			Results: []params.CharmUpgradeResult{{
+				OperationId: "42",
+				Error:       &params.Error{Message: "boom"},
+			}},
+		}
+		return nil
+	})
+
+	client := charmrevisionupdater.NewClient(apiCaller)
+	operationID, err := client.UpgradeCharm(names.NewApplicationTag("mysql"), charm.MustParseURL("cs:quantal/mysql-23"))
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(operationID, gc.Equals, "42")
+}
@@ -18,12 +18,12 @@ var facadeVersions = map[string]int{
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  12,
-	"ApplicationOffers":            2,
-	"ApplicationScaler":            1,
+	"Application":                  16,
+	"ApplicationOffers":            3,
+	"ApplicationScaler":            2,
 	"Backups":                      2,
 	"Block":                        2,
-	"Bundle":                       4,
+	"Bundle":                       5,
 	"CAASAgent":                    1,
 	"CAASAdmission":                1,
 	"CAASFirewaller":               1,
@@ -33,7 +33,7 @@ var facadeVersions = map[string]int{
 	"CAASOperatorUpgrader":         1,
 	"CAASUnitProvisioner":          1,
 	"CharmHub":                     1,
-	"CharmRevisionUpdater":         2,
+	"CharmRevisionUpdater":         3,
 	"Charms":                       2,
 	"Cleaner":                      2,
 	"Client":                       2,
@@ -61,11 +61,13 @@ var facadeVersions = map[string]int{
 	"KeyManager":                   1,
 	"KeyUpdater":                   1,
 	"LeadershipService":            2,
+	"LeakDetector":                 1,
 	"LifeFlag":                     1,
 	"LogForwarding":                1,
 	"Logger":                       1,
 	"MachineActions":               1,
 	"MachineManager":               6,
+	"MachinePool":                  1,
 	"MachineUndertaker":            1,
 	"Machiner":                     4,
 	"MeterStatus":                  2,
@@ -78,8 +80,8 @@ var facadeVersions = map[string]int{
 	"MigrationStatusWatcher":       1,
 	"MigrationTarget":              1,
 	"ModelConfig":                  2,
-	"ModelGeneration":              4,
-	"ModelManager":                 8,
+	"ModelGeneration":              5,
+	"ModelManager":                 9,
 	"ModelSummaryWatcher":          1,
 	"ModelUpgrader":                1,
 	"NotifyWatcher":                1,
@@ -96,6 +98,7 @@ var facadeVersions = map[string]int{
 	"RemoteRelationWatcher":        1,
 	"Resources":                    1,
 	"ResourcesHookContext":         1,
+	"ResourceTagger":               1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
 	"Singular":                     2,
@@ -108,7 +111,7 @@ var facadeVersions = map[string]int{
 	"Subnets":                      4,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       16,
+	"Uniter":                       17,
 	"Upgrader":                     1,
 	"UpgradeSeries":                3,
 	"UpgradeSteps":                 2,
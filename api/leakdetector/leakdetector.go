@@ -0,0 +1,70 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package leakdetector provides the API client used to find cloud
+// resources that have leaked from a controller's models.
+package leakdetector
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Resource describes a cloud resource that is tagged as belonging to
+// the controller, but which no model or machine refers to.
+type Resource struct {
+	// Kind is the kind of resource, e.g. "instance" or "volume".
+	Kind string
+
+	// Id is the provider id of the resource.
+	Id string
+
+	// ModelUUID is the UUID of the model the resource is tagged with.
+	ModelUUID string
+
+	// Reason describes why the resource is considered leaked.
+	Reason string
+
+	// Released is true if the resource was released during cleanup.
+	Released bool
+
+	// Error holds any error that occurred releasing the resource.
+	Error error
+}
+
+// API makes calls to the LeakDetector facade.
+type API struct {
+	caller base.FacadeCaller
+}
+
+// NewAPI returns a new API using the supplied caller.
+func NewAPI(caller base.APICaller) *API {
+	return &API{caller: base.NewFacadeCaller(caller, "LeakDetector")}
+}
+
+// DetectLeaks returns the cloud resources that have leaked from the
+// controller's models. If cleanup is true, the controller also tries
+// to release them; the outcome is recorded in each Resource.
+func (api *API) DetectLeaks(cleanup bool) ([]Resource, error) {
+	var result params.LeakedResourcesResult
+	args := params.DetectLeaksArgs{Cleanup: cleanup}
+	if err := api.caller.FacadeCall("DetectLeaks", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	resources := make([]Resource, len(result.Resources))
+	for i, r := range result.Resources {
+		resources[i] = Resource{
+			Kind:      r.Kind,
+			Id:        r.Id,
+			ModelUUID: r.ModelUUID,
+			Reason:    r.Reason,
+			Released:  r.Released,
+		}
+		if r.Error != nil {
+			resources[i].Error = r.Error
+		}
+	}
+	return resources, nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leakdetector_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/leakdetector"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type LeakDetectorSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&LeakDetectorSuite{})
+
+func apiCaller(c *gc.C, check func(request string, arg, result interface{}) error) base.APICaller {
+	return apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "LeakDetector")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		return check(request, arg, result)
+	})
+}
+
+func (s *LeakDetectorSuite) TestDetectLeaks(c *gc.C) {
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "DetectLeaks")
+		c.Check(arg, jc.DeepEquals, params.DetectLeaksArgs{Cleanup: true})
+		*result.(*params.LeakedResourcesResult) = params.LeakedResourcesResult{
+			Resources: []params.LeakedResource{{
+				Kind:      "instance",
+				Id:        "i-0",
+				ModelUUID: "some-uuid",
+				Reason:    "model no longer exists",
+				Released:  true,
+			}, {
+				Kind:      "volume",
+				Id:        "vol-0",
+				ModelUUID: "some-uuid",
+				Reason:    "model no longer exists",
+				Error:     &params.Error{Message: "volume in use"},
+			}},
+		}
+		return nil
+	})
+	resources, err := leakdetector.NewAPI(caller).DetectLeaks(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 2)
+	c.Check(resources[0], jc.DeepEquals, leakdetector.Resource{
+		Kind:      "instance",
+		Id:        "i-0",
+		ModelUUID: "some-uuid",
+		Reason:    "model no longer exists",
+		Released:  true,
+	})
+	c.Check(resources[1].Id, gc.Equals, "vol-0")
+	c.Check(resources[1].Released, jc.IsFalse)
+	c.Check(resources[1].Error, gc.ErrorMatches, "volume in use")
+}
+
+func (s *LeakDetectorSuite) TestDetectLeaksCallError(c *gc.C) {
+	caller := apiCaller(c, func(string, interface{}, interface{}) error {
+		return errors.New("boom")
+	})
+	_, err := leakdetector.NewAPI(caller).DetectLeaks(false)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
@@ -14,6 +14,7 @@ import (
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -118,6 +119,43 @@ func (s *MachinemanagerSuite) TestAddMachinesResultCountInvalid(c *gc.C) {
 	}
 }
 
+func (s *MachinemanagerSuite) TestInstanceTypes(c *gc.C) {
+	cons := constraints.MustParse("mem=4G")
+	apiResult := params.InstanceTypesResult{
+		InstanceTypes: []params.InstanceType{{Name: "m.medium", Memory: 4096, Cost: 80}},
+		CostUnit:      "$USD/hour",
+		CostCurrency:  "USD",
+		CostDivisor:   1000,
+	}
+	st := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "MachineManager")
+		c.Check(request, gc.Equals, "InstanceTypes")
+		c.Check(arg, jc.DeepEquals, params.ModelInstanceTypesConstraints{
+			Constraints: []params.ModelInstanceTypesConstraint{{Value: &cons}},
+		})
+		*(result.(*params.InstanceTypesResults)) = params.InstanceTypesResults{
+			Results: []params.InstanceTypesResult{apiResult},
+		}
+		return nil
+	})
+	result, err := st.InstanceTypes(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, apiResult)
+}
+
+func (s *MachinemanagerSuite) TestInstanceTypesError(c *gc.C) {
+	st := newClient(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.InstanceTypesResults)) = params.InstanceTypesResults{
+			Results: []params.InstanceTypesResult{{
+				Error: &params.Error{Message: "no instance types in us-east-1 matching constraints"},
+			}},
+		}
+		return nil
+	})
+	_, err := st.InstanceTypes(constraints.Value{})
+	c.Assert(err, gc.ErrorMatches, "no instance types in us-east-1 matching constraints")
+}
+
 func (s *MachinemanagerSuite) TestDestroyMachines(c *gc.C) {
 	s.testDestroyMachines(c, "DestroyMachine", (*machinemanager.Client).DestroyMachines)
 }
@@ -0,0 +1,57 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package machinepool provides the API client used by the machine pool
+// worker.
+package machinepool
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// MaintainResult describes the changes made to a model's machine pool.
+type MaintainResult struct {
+	// Size is the number of idle machines the pool is to hold.
+	Size int
+
+	// Machines holds the ids of the idle machines in the pool.
+	Machines []string
+
+	// Added and Removed hold the ids of the machines added to and
+	// removed from the pool.
+	Added   []string
+	Removed []string
+}
+
+// API makes calls to the MachinePool facade.
+type API struct {
+	caller base.FacadeCaller
+}
+
+// NewAPI returns a new API using the supplied caller.
+func NewAPI(caller base.APICaller) *API {
+	return &API{
+		caller: base.NewFacadeCaller(caller, "MachinePool"),
+	}
+}
+
+// MaintainMachinePool adds machines to, or removes them from, the
+// model's machine pool so that it matches the model's config.
+func (api *API) MaintainMachinePool() (MaintainResult, error) {
+	var result params.MachinePoolResult
+	if err := api.caller.FacadeCall("MaintainMachinePool", nil, &result); err != nil {
+		return MaintainResult{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return MaintainResult{}, errors.Trace(result.Error)
+	}
+	return MaintainResult{
+		Size:     result.Size,
+		Machines: result.Machines,
+		Added:    result.Added,
+		Removed:  result.Removed,
+	}, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinepool_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/machinepool"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type MachinePoolSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&MachinePoolSuite{})
+
+func apiCaller(c *gc.C, check func(request string, arg, result interface{}) error) base.APICaller {
+	return apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "MachinePool")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		return check(request, arg, result)
+	})
+}
+
+func (s *MachinePoolSuite) TestMaintainMachinePool(c *gc.C) {
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "MaintainMachinePool")
+		c.Check(arg, gc.IsNil)
+		*result.(*params.MachinePoolResult) = params.MachinePoolResult{
+			Size:     2,
+			Machines: []string{"1", "3"},
+			Added:    []string{"3"},
+			Removed:  []string{"2"},
+		}
+		return nil
+	})
+	result, err := machinepool.NewAPI(caller).MaintainMachinePool()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, machinepool.MaintainResult{
+		Size:     2,
+		Machines: []string{"1", "3"},
+		Added:    []string{"3"},
+		Removed:  []string{"2"},
+	})
+}
+
+func (s *MachinePoolSuite) TestMaintainMachinePoolCallError(c *gc.C) {
+	caller := apiCaller(c, func(string, interface{}, interface{}) error {
+		return errors.New("crunch")
+	})
+	_, err := machinepool.NewAPI(caller).MaintainMachinePool()
+	c.Check(err, gc.ErrorMatches, "crunch")
+}
+
+func (s *MachinePoolSuite) TestMaintainMachinePoolResultError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		*result.(*params.MachinePoolResult) = params.MachinePoolResult{
+			Error: &params.Error{Message: "splat"},
+		}
+		return nil
+	})
+	_, err := machinepool.NewAPI(caller).MaintainMachinePool()
+	c.Check(err, gc.ErrorMatches, "splat")
+}
@@ -69,6 +69,28 @@ func (c *Client) CommitBranch(branchName string) (int, error) {
 	return result.Result, nil
 }
 
+// CommitBranchWithResolution commits the branch with the input name to the
+// model as for CommitBranch, resolving conflicts with changes made to master
+// since the branch changed the same settings as indicated.
+func (c *Client) CommitBranchWithResolution(branchName string, resolution model.BranchConflictResolution) (int, error) {
+	if c.facade.BestAPIVersion() < 5 {
+		return 0, errors.NotSupportedf("committing a branch with conflict resolution on this version of Juju")
+	}
+	var result params.IntResult
+	arg := params.BranchCommitArg{
+		BranchName: branchName,
+		OnConflict: string(resolution),
+	}
+	err := c.facade.FacadeCall("CommitBranchWithResolution", arg, &result)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return 0, errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
 // ListCommits returns the details of all committed model branches.
 func (c *Client) ListCommits() (model.GenerationCommits, error) {
 	var result params.BranchResults
@@ -148,10 +170,16 @@ func (c *Client) HasActiveBranch(branchName string) (bool, error) {
 // If a non-empty string is supplied for branch name,
 // then only information for that branch is returned.
 // Supplying true for detailed returns extra unit detail for the branch.
+// Where supported by the controller, each configuration change is compared
+// with its value in master when the branch changed it, and the current
+// master value.
 func (c *Client) BranchInfo(
 	branchName string, detailed bool, formatTime func(time.Time) string,
 ) (model.GenerationSummaries, error) {
-	arg := params.BranchInfoArgs{Detailed: detailed}
+	arg := params.BranchInfoArgs{
+		Detailed: detailed,
+		ThreeWay: c.facade.BestAPIVersion() >= 5,
+	}
 	if branchName != "" {
 		arg.BranchNames = []string{branchName}
 	}
@@ -184,6 +212,8 @@ func generationInfoFromResult(
 				ApplicationName: a.ApplicationName,
 				UnitProgress:    a.UnitProgress,
 				ConfigChanges:   a.ConfigChanges,
+				Charm:           a.CharmURL,
+				Resources:       a.Resources,
 			}
 			if detailed {
 				bApp.UnitDetail = &model.GenerationUnits{
@@ -191,6 +221,17 @@ func generationInfoFromResult(
 					UnitsPending:  a.UnitsPending,
 				}
 			}
+			if len(a.ConfigDiff) > 0 {
+				bApp.ConfigDiff = make(map[string]model.ConfigItemDiff, len(a.ConfigDiff))
+				for _, d := range a.ConfigDiff {
+					bApp.ConfigDiff[d.Key] = model.ConfigItemDiff{
+						Base:     d.Base,
+						Branch:   d.Branch,
+						Master:   d.Master,
+						Conflict: d.Conflict,
+					}
+				}
+			}
 			appDeltas[i] = bApp
 		}
 		summaries[res.BranchName] = model.Generation{
@@ -223,6 +264,8 @@ func generationCommitFromResult(result params.GenerationResult) model.Generation
 			ApplicationName: a.ApplicationName,
 			ConfigChanges:   a.ConfigChanges,
 			UnitDetail:      &model.GenerationUnits{UnitsTracking: a.UnitsTracking},
+			Charm:           a.CharmURL,
+			Resources:       a.Resources,
 		}
 		appChanges[i] = app
 	}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -19,13 +20,15 @@ import (
 type modelGenerationSuite struct {
 	fCaller *mocks.MockFacadeCaller
 
-	branchName string
+	branchName    string
+	facadeVersion int
 }
 
 var _ = gc.Suite(&modelGenerationSuite{})
 
 func (s *modelGenerationSuite) SetUpTest(c *gc.C) {
 	s.branchName = "new-branch"
+	s.facadeVersion = 4
 }
 
 func (s *modelGenerationSuite) TearDownTest(c *gc.C) {
@@ -40,6 +43,7 @@ func (s *modelGenerationSuite) setUpMocks(c *gc.C) *gomock.Controller {
 
 	s.fCaller = mocks.NewMockFacadeCaller(ctrl)
 	s.fCaller.EXPECT().RawAPICaller().Return(caller).AnyTimes()
+	s.fCaller.EXPECT().BestAPIVersion().DoAndReturn(func() int { return s.facadeVersion }).AnyTimes()
 
 	return ctrl
 }
@@ -111,6 +115,28 @@ func (s *modelGenerationSuite) TestCommitBranch(c *gc.C) {
 	c.Check(newGenID, gc.Equals, 2)
 }
 
+func (s *modelGenerationSuite) TestCommitBranchWithResolution(c *gc.C) {
+	s.facadeVersion = 5
+	defer s.setUpMocks(c).Finish()
+
+	resultSource := params.IntResult{Result: 2}
+	arg := params.BranchCommitArg{BranchName: s.branchName, OnConflict: "master"}
+	s.fCaller.EXPECT().FacadeCall("CommitBranchWithResolution", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	newGenID, err := api.CommitBranchWithResolution("new-branch", model.ConflictMasterWins)
+	c.Assert(err, gc.IsNil)
+	c.Check(newGenID, gc.Equals, 2)
+}
+
+func (s *modelGenerationSuite) TestCommitBranchWithResolutionNotSupported(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	_, err := api.CommitBranchWithResolution("new-branch", model.ConflictAbort)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *modelGenerationSuite) TestHasActiveBranch(c *gc.C) {
 	defer s.setUpMocks(c).Finish()
 
@@ -172,3 +198,53 @@ func (s *modelGenerationSuite) TestBranchInfo(c *gc.C) {
 		},
 	})
 }
+
+func (s *modelGenerationSuite) TestBranchInfoThreeWay(c *gc.C) {
+	s.facadeVersion = 5
+	defer s.setUpMocks(c).Finish()
+
+	resultSource := params.BranchResults{Generations: []params.Generation{{
+		BranchName: "new-branch",
+		Created:    time.Time{}.Unix(),
+		CreatedBy:  "test-user",
+		Applications: []params.GenerationApplication{
+			{
+				ApplicationName: "redis",
+				UnitProgress:    "1/2",
+				ConfigChanges:   map[string]interface{}{"databases": 8},
+				ConfigDiff: []params.ConfigItemDiff{
+					{Key: "databases", Base: 4, Branch: 8, Master: 6, Conflict: true},
+				},
+			},
+		},
+	}}}
+	arg := params.BranchInfoArgs{
+		BranchNames: []string{s.branchName},
+		ThreeWay:    true,
+	}
+
+	s.fCaller.EXPECT().FacadeCall("BranchInfo", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+
+	formatTime := func(t time.Time) string {
+		return t.UTC().Format("2006-01-02 15:04:05")
+	}
+
+	apps, err := api.BranchInfo(s.branchName, false, formatTime)
+	c.Assert(err, gc.IsNil)
+	c.Check(apps, jc.DeepEquals, map[string]model.Generation{
+		s.branchName: {
+			Created:   "0001-01-01 00:00:00",
+			CreatedBy: "test-user",
+			Applications: []model.GenerationApplication{{
+				ApplicationName: "redis",
+				UnitProgress:    "1/2",
+				ConfigChanges:   map[string]interface{}{"databases": 8},
+				ConfigDiff: map[string]model.ConfigItemDiff{
+					"databases": {Base: 4, Branch: 8, Master: 6, Conflict: true},
+				},
+			}},
+		},
+	})
+}
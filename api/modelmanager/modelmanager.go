@@ -48,6 +48,28 @@ func (c *Client) CreateModel(
 	name, owner, cloud, cloudRegion string,
 	cloudCredential names.CloudCredentialTag,
 	config map[string]interface{},
+) (base.ModelInfo, error) {
+	return c.createModel("", name, owner, cloud, cloudRegion, cloudCredential, config)
+}
+
+// CreateModelFromTemplate creates a new model with the settings of the
+// named model template, along with the model config, cloud region and
+// credential specified in the args.
+func (c *Client) CreateModelFromTemplate(
+	template, name, owner, cloud, cloudRegion string,
+	cloudCredential names.CloudCredentialTag,
+	config map[string]interface{},
+) (base.ModelInfo, error) {
+	if c.BestAPIVersion() < 9 {
+		return base.ModelInfo{}, errors.NotSupportedf("model templates by this controller")
+	}
+	return c.createModel(template, name, owner, cloud, cloudRegion, cloudCredential, config)
+}
+
+func (c *Client) createModel(
+	template, name, owner, cloud, cloudRegion string,
+	cloudCredential names.CloudCredentialTag,
+	config map[string]interface{},
 ) (base.ModelInfo, error) {
 	var result base.ModelInfo
 	if !names.IsValidUser(owner) {
@@ -71,6 +93,7 @@ func (c *Client) CreateModel(
 		CloudTag:           cloudTag,
 		CloudRegion:        cloudRegion,
 		CloudCredentialTag: cloudCredentialTag,
+		Template:           template,
 	}
 	var modelInfo params.ModelInfo
 	err := c.facade.FacadeCall("CreateModel", createArgs, &modelInfo)
@@ -603,3 +626,39 @@ func (c *Client) ChangeModelCredential(model names.ModelTag, credential names.Cl
 	}
 	return out.OneError()
 }
+
+// CreateModelTemplate adds a model template to the controller.
+func (c *Client) CreateModelTemplate(template params.ModelTemplate) error {
+	return c.setModelTemplate("CreateModelTemplates", template)
+}
+
+// UpdateModelTemplate replaces the settings of an existing model template.
+func (c *Client) UpdateModelTemplate(template params.ModelTemplate) error {
+	return c.setModelTemplate("UpdateModelTemplates", template)
+}
+
+func (c *Client) setModelTemplate(method string, template params.ModelTemplate) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("model templates by this controller")
+	}
+	args := params.ModelTemplates{
+		Templates: []params.ModelTemplate{template},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ListModelTemplates returns the model templates on the controller.
+func (c *Client) ListModelTemplates() ([]params.ModelTemplate, error) {
+	if c.BestAPIVersion() < 9 {
+		return nil, errors.NotSupportedf("model templates by this controller")
+	}
+	var result params.ModelTemplates
+	if err := c.facade.FacadeCall("ListModelTemplates", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Templates, nil
+}
@@ -101,6 +101,94 @@ func (s *modelmanagerSuite) TestCreateModel(c *gc.C) {
 	})
 }
 
+func (s *modelmanagerSuite) TestCreateModelFromTemplate(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(request, gc.Equals, "CreateModel")
+			c.Check(arg, jc.DeepEquals, params.ModelCreateArgs{
+				Name:     "new-model",
+				OwnerTag: "user-bob",
+				Config:   map[string]interface{}{"abc": 123},
+				Template: "web",
+			})
+			out := result.(*params.ModelInfo)
+			out.Name = "new-model"
+			out.Type = "iaas"
+			out.CloudTag = "cloud-nimbus"
+			out.OwnerTag = "user-bob"
+			out.Life = "alive"
+			return nil
+		},
+		BestVersion: 9,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	newModel, err := client.CreateModelFromTemplate(
+		"web", "new-model", "bob", "", "", names.CloudCredentialTag{}, map[string]interface{}{"abc": 123},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newModel.Name, gc.Equals, "new-model")
+}
+
+func (s *modelmanagerSuite) TestCreateModelFromTemplateNotSupported(c *gc.C) {
+	client := modelmanager.NewClient(basetesting.BestVersionCaller{BestVersion: 8})
+	_, err := client.CreateModelFromTemplate("web", "new-model", "bob", "", "", names.CloudCredentialTag{}, nil)
+	c.Assert(err, gc.ErrorMatches, "model templates by this controller not supported")
+}
+
+func (s *modelmanagerSuite) TestCreateModelTemplate(c *gc.C) {
+	template := params.ModelTemplate{Name: "web", Spaces: []string{"db"}}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(request, gc.Equals, "CreateModelTemplates")
+			c.Check(arg, jc.DeepEquals, params.ModelTemplates{Templates: []params.ModelTemplate{template}})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+			}
+			return nil
+		},
+		BestVersion: 9,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.CreateModelTemplate(template)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *modelmanagerSuite) TestUpdateModelTemplate(c *gc.C) {
+	template := params.ModelTemplate{Name: "web", Bundle: "cs:wordpress-simple"}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(request, gc.Equals, "UpdateModelTemplates")
+			c.Check(arg, jc.DeepEquals, params.ModelTemplates{Templates: []params.ModelTemplate{template}})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{Results: []params.ErrorResult{{}}}
+			return nil
+		},
+		BestVersion: 9,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.UpdateModelTemplate(template)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelmanagerSuite) TestListModelTemplates(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(request, gc.Equals, "ListModelTemplates")
+			c.Check(arg, gc.IsNil)
+			*(result.(*params.ModelTemplates)) = params.ModelTemplates{
+				Templates: []params.ModelTemplate{{Name: "web"}},
+			}
+			return nil
+		},
+		BestVersion: 9,
+	}
+	client := modelmanager.NewClient(apiCaller)
+	templates, err := client.ListModelTemplates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(templates, jc.DeepEquals, []params.ModelTemplate{{Name: "web"}})
+}
+
 func (s *modelmanagerSuite) TestListModelsBadUser(c *gc.C) {
 	client := modelmanager.NewClient(basetesting.BestVersionCaller{})
 	_, err := client.ListModels("not a user")
@@ -0,0 +1,92 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package resourcetagger provides the API client used by the resource
+// tagger worker.
+package resourcetagger
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+)
+
+// Targets holds the tags to set on a model's cloud resources, and the
+// resources to set them on.
+type Targets struct {
+	// Tags holds the tags computed from the model's config.
+	Tags map[string]string
+
+	// InstanceIds holds the ids of the model's provisioned instances.
+	InstanceIds []instance.Id
+
+	// VolumeIds holds the provider ids of the model's provisioned
+	// volumes.
+	VolumeIds []string
+}
+
+// API makes calls to the ResourceTagger facade.
+type API struct {
+	*common.ModelWatcher
+	caller base.FacadeCaller
+}
+
+// NewAPI returns a new API using the supplied caller.
+func NewAPI(caller base.APICaller) *API {
+	facadeCaller := base.NewFacadeCaller(caller, "ResourceTagger")
+	return &API{
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		caller:       facadeCaller,
+	}
+}
+
+// ResourceTagTargets returns the tags to set on the model's cloud
+// resources, and the instances and volumes to set them on.
+func (api *API) ResourceTagTargets() (Targets, error) {
+	var result params.ResourceTagTargetsResult
+	if err := api.caller.FacadeCall("ResourceTagTargets", nil, &result); err != nil {
+		return Targets{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return Targets{}, errors.Trace(result.Error)
+	}
+	targets := Targets{
+		Tags:        result.Tags,
+		InstanceIds: make([]instance.Id, len(result.InstanceIds)),
+		VolumeIds:   result.VolumeIds,
+	}
+	for i, id := range result.InstanceIds {
+		targets.InstanceIds[i] = instance.Id(id)
+	}
+	return targets, nil
+}
+
+// AppliedResourceTags returns the tags last recorded as set on the
+// model's cloud resources, or nil if none have been recorded.
+func (api *API) AppliedResourceTags() (map[string]string, error) {
+	var result params.AppliedResourceTagsResult
+	if err := api.caller.FacadeCall("AppliedResourceTags", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Tags, nil
+}
+
+// SetAppliedResourceTags records the tags set on the model's cloud
+// resources.
+func (api *API) SetAppliedResourceTags(tags map[string]string) error {
+	var result params.ErrorResult
+	args := params.SetAppliedResourceTags{Tags: tags}
+	if err := api.caller.FacadeCall("SetAppliedResourceTags", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
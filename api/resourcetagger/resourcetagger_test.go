@@ -0,0 +1,120 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/resourcetagger"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+)
+
+type ResourceTaggerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ResourceTaggerSuite{})
+
+func apiCaller(c *gc.C, check func(request string, arg, result interface{}) error) base.APICaller {
+	return apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "ResourceTagger")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		return check(request, arg, result)
+	})
+}
+
+func (s *ResourceTaggerSuite) TestResourceTagTargets(c *gc.C) {
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "ResourceTagTargets")
+		c.Check(arg, gc.IsNil)
+		*result.(*params.ResourceTagTargetsResult) = params.ResourceTagTargetsResult{
+			Tags:        map[string]string{"cost-centre": "research"},
+			InstanceIds: []string{"i-0", "i-1"},
+			VolumeIds:   []string{"vol-0"},
+		}
+		return nil
+	})
+	targets, err := resourcetagger.NewAPI(caller).ResourceTagTargets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(targets, jc.DeepEquals, resourcetagger.Targets{
+		Tags:        map[string]string{"cost-centre": "research"},
+		InstanceIds: []instance.Id{"i-0", "i-1"},
+		VolumeIds:   []string{"vol-0"},
+	})
+}
+
+func (s *ResourceTaggerSuite) TestResourceTagTargetsCallError(c *gc.C) {
+	caller := apiCaller(c, func(string, interface{}, interface{}) error {
+		return errors.New("boom")
+	})
+	_, err := resourcetagger.NewAPI(caller).ResourceTagTargets()
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ResourceTaggerSuite) TestResourceTagTargetsResultError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		*result.(*params.ResourceTagTargetsResult) = params.ResourceTagTargetsResult{
+			Error: &params.Error{Message: "bad"},
+		}
+		return nil
+	})
+	_, err := resourcetagger.NewAPI(caller).ResourceTagTargets()
+	c.Check(err, gc.ErrorMatches, "bad")
+}
+
+func (s *ResourceTaggerSuite) TestAppliedResourceTags(c *gc.C) {
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "AppliedResourceTags")
+		c.Check(arg, gc.IsNil)
+		*result.(*params.AppliedResourceTagsResult) = params.AppliedResourceTagsResult{
+			Tags: map[string]string{"cost-centre": "research"},
+		}
+		return nil
+	})
+	tags, err := resourcetagger.NewAPI(caller).AppliedResourceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(tags, jc.DeepEquals, map[string]string{"cost-centre": "research"})
+}
+
+func (s *ResourceTaggerSuite) TestAppliedResourceTagsResultError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		*result.(*params.AppliedResourceTagsResult) = params.AppliedResourceTagsResult{
+			Error: &params.Error{Message: "bad"},
+		}
+		return nil
+	})
+	_, err := resourcetagger.NewAPI(caller).AppliedResourceTags()
+	c.Check(err, gc.ErrorMatches, "bad")
+}
+
+func (s *ResourceTaggerSuite) TestSetAppliedResourceTags(c *gc.C) {
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "SetAppliedResourceTags")
+		c.Check(arg, jc.DeepEquals, params.SetAppliedResourceTags{
+			Tags: map[string]string{"cost-centre": "research"},
+		})
+		*result.(*params.ErrorResult) = params.ErrorResult{}
+		return nil
+	})
+	err := resourcetagger.NewAPI(caller).SetAppliedResourceTags(map[string]string{"cost-centre": "research"})
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ResourceTaggerSuite) TestSetAppliedResourceTagsResultError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		*result.(*params.ErrorResult) = params.ErrorResult{
+			Error: &params.Error{Message: "bad"},
+		}
+		return nil
+	})
+	err := resourcetagger.NewAPI(caller).SetAppliedResourceTags(nil)
+	c.Check(err, gc.ErrorMatches, "bad")
+}
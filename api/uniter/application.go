@@ -5,6 +5,7 @@ package uniter
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/charm/v7"
 	"github.com/juju/errors"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
@@ -86,6 +88,32 @@ func (s *Application) CharmModifiedVersion() (int, error) {
 	return result.Result, nil
 }
 
+// UpdateStatusHookInterval returns the update status hook interval
+// configured for the application, or zero if the application uses the
+// model's interval. Controllers that do not support per-application
+// intervals always report zero.
+func (s *Application) UpdateStatusHookInterval() (time.Duration, error) {
+	if s.st.BestAPIVersion() < 17 {
+		return 0, nil
+	}
+	var results params.StringResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("UpdateStatusHookIntervals", args, &results)
+	if err != nil {
+		return 0, err
+	}
+	if len(results.Results) != 1 {
+		return 0, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return application.ParseUpdateStatusHookInterval(result.Result)
+}
+
 // CharmURL returns the application's charm URL, and whether units should
 // upgrade to the charm with that URL even if they are in an error
 // state (force flag).
@@ -77,6 +77,14 @@ func (s *applicationSuite) apiCallerFunc(c *gc.C) basetesting.APICallerFunc {
 					Result: 1,
 				}},
 			}
+		case "UpdateStatusHookIntervals":
+			c.Assert(arg, jc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "application-mysql"}}})
+			c.Assert(result, gc.FitsTypeOf, &params.StringResults{})
+			*(result.(*params.StringResults)) = params.StringResults{
+				Results: []params.StringResult{{
+					Result: "30s",
+				}},
+			}
 		case "ApplicationStatus":
 			c.Assert(arg, jc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "unit-mysql-0"}}})
 			c.Assert(result, gc.FitsTypeOf, &params.ApplicationStatusResults{})
@@ -173,6 +181,30 @@ func (s *applicationSuite) TestCharmModifiedVersion(c *gc.C) {
 	c.Assert(ver, gc.Equals, 1)
 }
 
+func (s *applicationSuite) TestUpdateStatusHookInterval(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: s.apiCallerFunc(c),
+		BestVersion:   17,
+	}
+	client := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	app, err := client.Application(names.NewApplicationTag("mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	interval, err := app.UpdateStatusHookInterval()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(interval, gc.Equals, 30*time.Second)
+}
+
+func (s *applicationSuite) TestUpdateStatusHookIntervalOldController(c *gc.C) {
+	client := uniter.NewState(s.apiCallerFunc(c), names.NewUnitTag("mysql/0"))
+	app, err := client.Application(names.NewApplicationTag("mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	interval, err := app.UpdateStatusHookInterval()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(interval, gc.Equals, time.Duration(0))
+}
+
 func (s *applicationSuite) TestSetApplicationStatus(c *gc.C) {
 	client := uniter.NewState(s.apiCallerFunc(c), names.NewUnitTag("mysql/0"))
 	app, err := client.Application(names.NewApplicationTag("mysql"))
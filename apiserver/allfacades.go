@@ -88,14 +88,17 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/firewaller"
 	"github.com/juju/juju/apiserver/facades/controller/imagemetadata"
 	"github.com/juju/juju/apiserver/facades/controller/instancepoller"
+	"github.com/juju/juju/apiserver/facades/controller/leakdetector"
 	"github.com/juju/juju/apiserver/facades/controller/lifeflag"
 	"github.com/juju/juju/apiserver/facades/controller/logfwd"
+	"github.com/juju/juju/apiserver/facades/controller/machinepool"
 	"github.com/juju/juju/apiserver/facades/controller/machineundertaker"
 	"github.com/juju/juju/apiserver/facades/controller/metricsmanager"
 	"github.com/juju/juju/apiserver/facades/controller/migrationmaster"
 	"github.com/juju/juju/apiserver/facades/controller/migrationtarget"
 	"github.com/juju/juju/apiserver/facades/controller/modelupgrader"
 	"github.com/juju/juju/apiserver/facades/controller/remoterelations"
+	"github.com/juju/juju/apiserver/facades/controller/resourcetagger"
 	"github.com/juju/juju/apiserver/facades/controller/resumer"
 	"github.com/juju/juju/apiserver/facades/controller/singular"
 	"github.com/juju/juju/apiserver/facades/controller/statushistory"
@@ -156,10 +159,16 @@ func AllFacades() *facade.Registry {
 	reg("Application", 10, application.NewFacadeV10) // --force and --no-wait parameters
 	reg("Application", 11, application.NewFacadeV11) // Get call returns the endpoint bindings
 	reg("Application", 12, application.NewFacadeV12) // Adds UnitsInfo()
+	reg("Application", 13, application.NewFacadeV13) // Adds RemoteRelationInfo()
+	reg("Application", 14, application.NewFacadeV14) // SetCharm and GetCharmURL honour branches
+	reg("Application", 15, application.NewFacadeV15) // Adds PlanDestroyApplication() and cascading removal
+	reg("Application", 16, application.NewFacadeV16) // Adds AutoscaleHistory()
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPIV2)
-	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
+	reg("ApplicationOffers", 3, applicationoffers.NewOffersAPIV3) // Adds CreateOfferTokens, ListOfferTokens and RevokeOfferTokens
+	reg("ApplicationScaler", 1, applicationscaler.NewAPIV1)
+	reg("ApplicationScaler", 2, applicationscaler.NewAPI) // Adds AutoscaleCandidates and ScaleApplications
 	reg("Backups", 1, backups.NewFacade)
 	reg("Backups", 2, backups.NewFacadeV2)
 	reg("Block", 2, block.NewAPI)
@@ -167,8 +176,10 @@ func AllFacades() *facade.Registry {
 	reg("Bundle", 2, bundle.NewFacadeV2)
 	reg("Bundle", 3, bundle.NewFacadeV3)
 	reg("Bundle", 4, bundle.NewFacadeV4)
+	reg("Bundle", 5, bundle.NewFacadeV5)
 	reg("CharmHub", 1, charmhub.NewFacade)
-	reg("CharmRevisionUpdater", 2, charmrevisionupdater.NewCharmRevisionUpdaterAPI)
+	reg("CharmRevisionUpdater", 2, charmrevisionupdater.NewCharmRevisionUpdaterAPIV2)
+	reg("CharmRevisionUpdater", 3, charmrevisionupdater.NewCharmRevisionUpdaterAPI) // Adds CharmUpgradeCandidates and UpgradeCharms
 	reg("Charms", 2, charms.NewFacade)
 	reg("Cleaner", 2, cleaner.NewCleanerAPI)
 	reg("Client", 1, client.NewFacadeV1)
@@ -230,6 +241,7 @@ func AllFacades() *facade.Registry {
 	reg("KeyUpdater", 1, keyupdater.NewKeyUpdaterAPI)
 
 	reg("LeadershipService", 2, leadership.NewLeadershipServiceFacade)
+	reg("LeakDetector", 1, leakdetector.NewAPI)
 
 	reg("LifeFlag", 1, lifeflag.NewExternalFacade)
 	reg("Logger", 1, loggerapi.NewLoggerAPI)
@@ -242,6 +254,7 @@ func AllFacades() *facade.Registry {
 	reg("MachineManager", 5, machinemanager.NewFacadeV5) // Adds UpgradeSeriesPrepare, removes UpdateMachineSeries.
 	reg("MachineManager", 6, machinemanager.NewFacadeV6) // DestroyMachinesWithParams gains maxWait.
 
+	reg("MachinePool", 1, machinepool.NewAPI)
 	reg("MachineUndertaker", 1, machineundertaker.NewFacade)
 	reg("Machiner", 1, machine.NewMachinerAPIV1)
 	reg("Machiner", 2, machine.NewMachinerAPIV2) // Adds RecordAgentStartTime.
@@ -266,6 +279,7 @@ func AllFacades() *facade.Registry {
 	reg("ModelGeneration", 2, modelgeneration.NewModelGenerationFacadeV2)
 	reg("ModelGeneration", 3, modelgeneration.NewModelGenerationFacadeV3)
 	reg("ModelGeneration", 4, modelgeneration.NewModelGenerationFacadeV4)
+	reg("ModelGeneration", 5, modelgeneration.NewModelGenerationFacadeV5) // Adds CommitBranchWithResolution and three-way BranchInfo
 	reg("ModelManager", 2, modelmanager.NewFacadeV2)
 	reg("ModelManager", 3, modelmanager.NewFacadeV3)
 	reg("ModelManager", 4, modelmanager.NewFacadeV4)
@@ -273,6 +287,7 @@ func AllFacades() *facade.Registry {
 	reg("ModelManager", 6, modelmanager.NewFacadeV6) // Adds cloud specific default config
 	reg("ModelManager", 7, modelmanager.NewFacadeV7) // DestroyModels gains 'force' and max-wait' parameters.
 	reg("ModelManager", 8, modelmanager.NewFacadeV8) // ModelInfo gains credential validity in return.
+	reg("ModelManager", 9, modelmanager.NewFacadeV9) // Adds model templates.
 	reg("ModelUpgrader", 1, modelupgrader.NewStateFacade)
 
 	reg("Payloads", 1, payloads.NewFacade)
@@ -301,6 +316,7 @@ func AllFacades() *facade.Registry {
 
 	reg("Resources", 1, resources.NewPublicFacade)
 	reg("ResourcesHookContext", 1, resourceshookcontext.NewStateFacade)
+	reg("ResourceTagger", 1, resourcetagger.NewAPI)
 
 	reg("Resumer", 2, resumer.NewResumerAPI)
 	reg("RetryStrategy", 1, retrystrategy.NewRetryStrategyAPI)
@@ -342,7 +358,8 @@ func AllFacades() *facade.Registry {
 	reg("Uniter", 13, uniter.NewUniterAPIV13)
 	reg("Uniter", 14, uniter.NewUniterAPIV14)
 	reg("Uniter", 15, uniter.NewUniterAPIV15)
-	reg("Uniter", 16, uniter.NewUniterAPI)
+	reg("Uniter", 16, uniter.NewUniterAPIV16)
+	reg("Uniter", 17, uniter.NewUniterAPI)
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 
@@ -693,6 +693,7 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	}
 	backupHandler := &backupHandler{ctxt: httpCtxt}
 	registerHandler := &registerUserHandler{ctxt: httpCtxt}
+	offerTokenHandler := &offerTokenHandler{ctxt: httpCtxt}
 	guiArchiveHandler := &guiArchiveHandler{ctxt: httpCtxt}
 	guiVersionHandler := &guiVersionHandler{ctxt: httpCtxt}
 
@@ -802,6 +803,10 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		pattern:         "/register",
 		handler:         registerHandler,
 		unauthenticated: true,
+	}, {
+		pattern:         "/offer-token",
+		handler:         offerTokenHandler,
+		unauthenticated: true,
 	}, {
 		pattern:    "/tools",
 		handler:    modelToolsUploadHandler,
@@ -19,12 +19,43 @@ import (
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.common.crossmodel")
 
 // PublishRelationChange applies the relation change event to the specified backend.
 func PublishRelationChange(backend Backend, relationTag names.Tag, change params.RemoteRelationChangeEvent) error {
+	err := publishRelationChange(backend, relationTag, change)
+	recordRelationChange(backend, relationTag.Id(), change, err)
+	return err
+}
+
+// recordRelationChange records the change received for the relation, and
+// the error applying it if any, so that it can be shown to operators
+// diagnosing the relation. It is done on a best effort basis, as the
+// change itself has already been applied.
+func recordRelationChange(backend Backend, relationKey string, change params.RemoteRelationChangeEvent, applyErr error) {
+	event := state.RemoteRelationEvent{
+		ApplicationToken:           change.ApplicationToken,
+		Life:                       change.Life,
+		Suspended:                  change.Suspended,
+		DepartedUnits:              change.DepartedUnits,
+		ApplicationSettingsChanged: change.ApplicationSettings != nil,
+	}
+	for _, unit := range change.ChangedUnits {
+		event.ChangedUnits = append(event.ChangedUnits, unit.UnitId)
+	}
+	if applyErr != nil {
+		event.Error = applyErr.Error()
+	}
+	err := backend.SetRemoteRelationEvent(relationKey, event)
+	if err != nil && !errors.IsNotFound(err) {
+		logger.Warningf("cannot record change received for relation %v: %v", relationKey, err)
+	}
+}
+
+func publishRelationChange(backend Backend, relationTag names.Tag, change params.RemoteRelationChangeEvent) error {
 	logger.Debugf("publish into model %v change for %v: %+v", backend.ModelUUID(), relationTag, change)
 
 	dyingOrDead := change.Life != "" && change.Life != life.Alive
@@ -97,6 +97,10 @@ type Backend interface {
 
 	// ApplyOperation applies a model operation to the state.
 	ApplyOperation(op state.ModelOperation) error
+
+	// SetRemoteRelationEvent records the last change received from the
+	// remote model for the relation with the given key.
+	SetRemoteRelationEvent(relationKey string, event state.RemoteRelationEvent) error
 }
 
 // Relation provides access a relation in global state.
@@ -80,6 +80,10 @@ type ModelManagerBackend interface {
 	DumpAll() (map[string]interface{}, error)
 	Close() error
 	HAPrimaryMachine() (names.MachineTag, error)
+	AddModelTemplate(state.ModelTemplate) error
+	UpdateModelTemplate(state.ModelTemplate) error
+	ModelTemplate(name string) (state.ModelTemplate, error)
+	ModelTemplates() ([]state.ModelTemplate, error)
 
 	// Methods required by the metricsender package.
 	MetricsManager() (*state.MetricsManager, error)
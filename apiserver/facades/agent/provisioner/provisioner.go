@@ -769,11 +769,34 @@ func controllerMachineIds(st *state.State, m *state.Machine) ([]string, error) {
 }
 
 // commonApplicationMachineId returns a slice of machine.Ids with
-// applications in common with the specified machine.
+// applications in common with the specified machine, along with those
+// hosting applications that the machine's applications may never share
+// a machine with, so that new machines are kept away from them too.
 func commonApplicationMachineId(st *state.State, m *state.Machine) ([]string, error) {
-	applications := m.Principals()
+	applications := set.NewStrings(m.Principals()...)
+	for _, unitName := range m.Principals() {
+		appName, err := names.UnitApplication(unitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		app, err := st.Application(appName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cfg, err := app.ApplicationConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		policy, err := cfg.PlacementPolicy()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		applications = applications.Union(set.NewStrings(policy.Avoid...))
+	}
 	union := set.NewStrings()
-	for _, app := range applications {
+	for _, app := range applications.SortedValues() {
 		machines, err := state.ApplicationMachines(st, app)
 		if err != nil {
 			return nil, err
@@ -18,8 +18,8 @@ import (
 	"github.com/juju/juju/state/watcher"
 )
 
-// Right now, these are defined as constants, but the plan is to maybe make
-// them configurable in the future
+// These are the defaults for the retry strategy; they may be overridden
+// for each application in its application config.
 const (
 	MinRetryTime    = 5 * time.Second
 	MaxRetryTime    = 5 * time.Minute
@@ -90,25 +90,82 @@ func (h *RetryStrategyAPI) RetryStrategy(args params.Entities) (params.RetryStra
 		}
 		err = apiservererrors.ErrPerm
 		if canAccess(tag) {
-			// Right now the only real configurable value is ShouldRetry,
-			// which is taken from the model.
-			// The rest are hardcoded.
-			results.Results[i].Result = &params.RetryStrategy{
-				ShouldRetry:     config.AutomaticallyRetryHooks(),
-				MinRetryTime:    MinRetryTime,
-				MaxRetryTime:    MaxRetryTime,
-				JitterRetryTime: JitterRetryTime,
-				RetryTimeFactor: RetryTimeFactor,
-			}
-			err = nil
+			var strategy *params.RetryStrategy
+			strategy, err = h.retryStrategy(tag, config.AutomaticallyRetryHooks())
+			results.Results[i].Result = strategy
 		}
 		results.Results[i].Error = apiservererrors.ServerError(err)
 	}
 	return results, nil
 }
 
-// WatchRetryStrategy watches for changes to the model. Currently we only allow
-// changes to the boolean that determines whether retries should be attempted or not.
+// retryStrategy returns the retry strategy for the given unit or
+// application agent: the model's strategy, overridden by any retry
+// policy in the application's config.
+func (h *RetryStrategyAPI) retryStrategy(tag names.Tag, shouldRetry bool) (*params.RetryStrategy, error) {
+	strategy := &params.RetryStrategy{
+		ShouldRetry:     shouldRetry,
+		MinRetryTime:    MinRetryTime,
+		MaxRetryTime:    MaxRetryTime,
+		JitterRetryTime: JitterRetryTime,
+		RetryTimeFactor: RetryTimeFactor,
+	}
+	app, err := h.application(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	policy, err := config.HookRetryPolicy()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if policy.ShouldRetry != nil {
+		strategy.ShouldRetry = *policy.ShouldRetry
+	}
+	if policy.Jitter != nil {
+		strategy.JitterRetryTime = *policy.Jitter
+	}
+	if policy.MinDelay > 0 {
+		strategy.MinRetryTime = policy.MinDelay
+	}
+	if policy.MaxDelay > 0 {
+		strategy.MaxRetryTime = policy.MaxDelay
+	}
+	if strategy.MinRetryTime > strategy.MaxRetryTime {
+		// Only one of the delays has been overridden; don't let
+		// it conflict with the default for the other.
+		if policy.MinDelay > 0 {
+			strategy.MaxRetryTime = strategy.MinRetryTime
+		} else {
+			strategy.MinRetryTime = strategy.MaxRetryTime
+		}
+	}
+	strategy.MaxRetryAttempts = policy.MaxAttempts
+	return strategy, nil
+}
+
+// application returns the application of the given unit or
+// application agent.
+func (h *RetryStrategyAPI) application(tag names.Tag) (*state.Application, error) {
+	switch tag := tag.(type) {
+	case names.UnitTag:
+		unit, err := h.st.Unit(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return unit.Application()
+	case names.ApplicationTag:
+		return h.st.Application(tag.Id())
+	}
+	return nil, errors.NotValidf("retry strategy for %q", names.ReadableString(tag))
+}
+
+// WatchRetryStrategy watches for changes to the model config, and to the
+// config of the agent's application, either of which may change the
+// retry strategy.
 func (h *RetryStrategyAPI) WatchRetryStrategy(args params.Entities) (params.NotifyWatchResults, error) {
 	results := params.NotifyWatchResults{
 		Results: make([]params.NotifyWatchResult, len(args.Entities)),
@@ -125,18 +182,27 @@ func (h *RetryStrategyAPI) WatchRetryStrategy(args params.Entities) (params.Noti
 		}
 		err = apiservererrors.ErrPerm
 		if canAccess(tag) {
-			watch := h.model.WatchForModelConfigChanges()
-			// Consume the initial event. Technically, API calls to Watch
-			// 'transmit' the initial event in the Watch response. But
-			// NotifyWatchers have no state to transmit.
-			if _, ok := <-watch.Changes(); ok {
-				results.Results[i].NotifyWatcherId = h.resources.Register(watch)
-				err = nil
-			} else {
-				err = watcher.EnsureErr(watch)
-			}
+			results.Results[i].NotifyWatcherId, err = h.watchRetryStrategy(tag)
 		}
 		results.Results[i].Error = apiservererrors.ServerError(err)
 	}
 	return results, nil
 }
+
+func (h *RetryStrategyAPI) watchRetryStrategy(tag names.Tag) (string, error) {
+	app, err := h.application(tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	watch := common.NewMultiNotifyWatcher(
+		h.model.WatchForModelConfigChanges(),
+		app.WatchApplicationConfig(),
+	)
+	// Consume the initial event. Technically, API calls to Watch
+	// 'transmit' the initial event in the Watch response. But
+	// NotifyWatchers have no state to transmit.
+	if _, ok := <-watch.Changes(); ok {
+		return h.resources.Register(watch), nil
+	}
+	return "", watcher.EnsureErr(watch)
+}
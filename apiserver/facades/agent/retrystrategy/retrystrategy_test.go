@@ -5,13 +5,17 @@
 package retrystrategy_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facades/agent/retrystrategy"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coreapplication "github.com/juju/juju/core/application"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
@@ -131,6 +135,43 @@ func (s *retryStrategySuite) assertRetryStrategy(c *gc.C, tag string) {
 	c.Assert(r.Results[0].Result, jc.DeepEquals, expected)
 }
 
+func (s *retryStrategySuite) TestRetryStrategyApplicationPolicy(c *gc.C) {
+	s.setApplicationRetryPolicy(c, coreapplication.ConfigAttributes{
+		coreapplication.HookRetryOptionName:            false,
+		coreapplication.HookRetryMaxAttemptsOptionName: 3,
+		coreapplication.HookRetryMinDelayOptionName:    "1m",
+		coreapplication.HookRetryJitterOptionName:      false,
+	})
+
+	args := params.Entities{Entities: []params.Entity{{Tag: s.unit.Tag().String()}}}
+	r, err := s.strategy.RetryStrategy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, gc.HasLen, 1)
+	c.Assert(r.Results[0].Error, gc.IsNil)
+	c.Assert(r.Results[0].Result, jc.DeepEquals, &params.RetryStrategy{
+		ShouldRetry:      false,
+		MinRetryTime:     time.Minute,
+		MaxRetryTime:     retrystrategy.MaxRetryTime,
+		JitterRetryTime:  false,
+		RetryTimeFactor:  retrystrategy.RetryTimeFactor,
+		MaxRetryAttempts: 3,
+	})
+}
+
+func (s *retryStrategySuite) setApplicationRetryPolicy(c *gc.C, attrs coreapplication.ConfigAttributes) {
+	app, err := s.unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	schema := environschema.Fields{
+		coreapplication.HookRetryOptionName:            {Type: environschema.Tbool},
+		coreapplication.HookRetryMaxAttemptsOptionName: {Type: environschema.Tint},
+		coreapplication.HookRetryMinDelayOptionName:    {Type: environschema.Tstring},
+		coreapplication.HookRetryMaxDelayOptionName:    {Type: environschema.Tstring},
+		coreapplication.HookRetryJitterOptionName:      {Type: environschema.Tbool},
+	}
+	err = app.UpdateApplicationConfig(attrs, nil, schema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *retryStrategySuite) setRetryStrategy(c *gc.C, automaticallyRetryHooks bool) {
 	err := s.Model.UpdateModelConfig(map[string]interface{}{"automatically-retry-hooks": automaticallyRetryHooks}, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -193,4 +234,9 @@ func (s *retryStrategySuite) TestWatchRetryStrategy(c *gc.C) {
 	s.setRetryStrategy(c, false)
 	c.Assert(err, jc.ErrorIsNil)
 	wc.AssertOneChange()
+
+	s.setApplicationRetryPolicy(c, coreapplication.ConfigAttributes{
+		coreapplication.HookRetryMaxAttemptsOptionName: 3,
+	})
+	wc.AssertOneChange()
 }
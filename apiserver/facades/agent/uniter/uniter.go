@@ -39,8 +39,8 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v16) of the Uniter API, which adds
-// LXDProfileAPIv2.
+// UniterAPI implements the latest version (v17) of the Uniter API, which adds
+// UpdateStatusHookIntervals.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -79,11 +79,17 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV16 implements version (v16) of the Uniter API, which adds
+// LXDProfileAPIv2.
+type UniterAPIV16 struct {
+	UniterAPI
+}
+
 // UniterAPIV15 implements version (v15) of the Uniter API, which adds
 // the State, CommitHookChanges, ReadLocalApplicationSettings calls and changes
 // WatchActionNotifications to notify on action changes.
 type UniterAPIV15 struct {
-	UniterAPI
+	UniterAPIV16
 }
 
 // UniterAPIV14 implements version (v14) of the Uniter API,
@@ -252,14 +258,25 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 	}, nil
 }
 
+// NewUniterAPIV16 creates an instance of the V16 uniter API.
+func NewUniterAPIV16(context facade.Context) (*UniterAPIV16, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV16{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV15 creates an instance of the V15 uniter API.
 func NewUniterAPIV15(context facade.Context) (*UniterAPIV15, error) {
-	uniterAPI, err := NewUniterAPI(context)
+	uniterAPI, err := NewUniterAPIV16(context)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV15{
-		UniterAPI: *uniterAPI,
+		UniterAPIV16: *uniterAPI,
 	}, nil
 }
 
@@ -860,10 +877,9 @@ func (u *UniterAPI) CharmURL(args params.Entities) (params.StringBoolResults, er
 			var unitOrApplication state.Entity
 			unitOrApplication, err = u.st.FindEntity(tag)
 			if err == nil {
-				charmURLer := unitOrApplication.(interface {
-					CharmURL() (*charm.URL, bool)
-				})
-				curl, ok := charmURLer.CharmURL()
+				var curl *charm.URL
+				var ok bool
+				curl, ok, err = u.charmURL(unitOrApplication)
 				if curl != nil {
 					result.Results[i].Result = curl.String()
 					result.Results[i].Ok = ok
@@ -875,6 +891,29 @@ func (u *UniterAPI) CharmURL(args params.Entities) (params.StringBoolResults, er
 	return result, nil
 }
 
+// charmURL returns the charm URL for the input unit or application.
+// When a unit asks for the charm URL of its own application, the URL of
+// any charm staged for the application under the branch that the unit is
+// tracking is returned.
+func (u *UniterAPI) charmURL(unitOrApplication state.Entity) (*charm.URL, bool, error) {
+	app, isApp := unitOrApplication.(*state.Application)
+	authTag, isUnit := u.auth.GetAuthTag().(names.UnitTag)
+	if isApp && isUnit {
+		appName, err := names.UnitApplication(authTag.Id())
+		if err != nil {
+			return nil, false, errors.Trace(err)
+		}
+		if appName == app.Name() {
+			return app.UnitCharmURL(authTag.Id())
+		}
+	}
+	charmURLer := unitOrApplication.(interface {
+		CharmURL() (*charm.URL, bool)
+	})
+	curl, ok := charmURLer.CharmURL()
+	return curl, ok, nil
+}
+
 // SetCharmURL sets the charm URL for each given unit. An error will
 // be returned if a unit is dead, or the charm URL is not known.
 func (u *UniterAPI) SetCharmURL(args params.EntitiesCharmURL) (params.ErrorResults, error) {
@@ -3358,6 +3397,54 @@ func (u *UniterAPI) watchOneUnitHashes(tag names.UnitTag, getWatcher func(u *sta
 	return "", nil, watcher.EnsureErr(w)
 }
 
+// UpdateStatusHookIntervals isn't on the v16 API.
+func (u *UniterAPIV16) UpdateStatusHookIntervals(_, _ struct{}) {}
+
+// UpdateStatusHookIntervals returns the update status hook interval
+// configured for each of the given applications, overriding the model's
+// update-status-hook-interval. An empty result means that the
+// application uses the model's interval.
+func (u *UniterAPI) UpdateStatusHookIntervals(args params.Entities) (params.StringResults, error) {
+	results := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessApplication()
+	if err != nil {
+		return results, err
+	}
+	for i, entity := range args.Entities {
+		interval, err := u.updateStatusHookInterval(entity.Tag, canAccess)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if interval > 0 {
+			results.Results[i].Result = interval.String()
+		}
+	}
+	return results, nil
+}
+
+func (u *UniterAPI) updateStatusHookInterval(tagStr string, canAccess common.AuthFunc) (time.Duration, error) {
+	tag, err := names.ParseApplicationTag(tagStr)
+	if err != nil {
+		return 0, apiservererrors.ErrPerm
+	}
+	if !canAccess(tag) {
+		return 0, apiservererrors.ErrPerm
+	}
+	app, err := u.st.Application(tag.Id())
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	interval, err := config.UpdateStatusHookInterval()
+	return interval, errors.Trace(err)
+}
+
 // CloudAPIVersion isn't on the v10 API.
 func (u *UniterAPIV10) CloudAPIVersion(_, _ struct{}) {}
 
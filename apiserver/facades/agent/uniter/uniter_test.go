@@ -1014,6 +1014,33 @@ func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestUpdateStatusHookIntervals(c *gc.C) {
+	schema := environschema.Fields{
+		coreapplication.UpdateStatusHookIntervalOptionName: environschema.Attr{Type: environschema.Tstring},
+	}
+	err := s.wordpress.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		coreapplication.UpdateStatusHookIntervalOptionName: "30s",
+	}, nil, schema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "application-mysql"},
+		{Tag: "application-wordpress"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-foo"},
+	}}
+	result, err := s.uniter.UpdateStatusHookIntervals(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: "30s"},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
 func (s *uniterSuite) TestOpenPorts(c *gc.C) {
 	openedPortsBySubnet, err := s.wordpressUnit.OpenedPortsBySubnet()
 	c.Assert(err, jc.ErrorIsNil)
@@ -100,6 +100,32 @@ type APIv11 struct {
 // APIv12 provides the Application API facade for version 12.
 // It adds the UnitsInfo method.
 type APIv12 struct {
+	*APIv13
+}
+
+// APIv13 provides the Application API facade for version 13.
+// It adds the RemoteRelationInfo method.
+type APIv13 struct {
+	*APIv14
+}
+
+// APIv14 provides the Application API facade for version 14.
+// The SetCharm and GetCharmURL calls honour the branch that they are
+// given, so that charm upgrades can be staged under a branch.
+type APIv14 struct {
+	*APIv15
+}
+
+// APIv15 provides the Application API facade for version 15.
+// It adds the PlanDestroyApplication method, and DestroyApplication
+// can cascade to orphaned subordinate applications and machines.
+type APIv15 struct {
+	*APIv16
+}
+
+// APIv16 provides the Application API facade for version 16.
+// It adds the AutoscaleHistory method.
+type APIv16 struct {
 	*APIBase
 }
 
@@ -207,13 +233,45 @@ func NewFacadeV11(ctx facade.Context) (*APIv11, error) {
 }
 
 func NewFacadeV12(ctx facade.Context) (*APIv12, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV13(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv12{api}, nil
 }
 
+func NewFacadeV13(ctx facade.Context) (*APIv13, error) {
+	api, err := NewFacadeV14(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv13{api}, nil
+}
+
+func NewFacadeV14(ctx facade.Context) (*APIv14, error) {
+	api, err := NewFacadeV15(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv14{api}, nil
+}
+
+func NewFacadeV15(ctx facade.Context) (*APIv15, error) {
+	api, err := NewFacadeV16(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv15{api}, nil
+}
+
+func NewFacadeV16(ctx facade.Context) (*APIv16, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv16{api}, nil
+}
+
 type caasBrokerInterface interface {
 	ValidateStorageClass(config map[string]interface{}) error
 	Version() (*version.Number, error)
@@ -693,6 +751,9 @@ func deployApplication(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := checkPlacementPolicy(args.ApplicationName, applicationConfig, args.Placement); err != nil {
+		return errors.Trace(err)
+	}
 
 	var settings = make(charm.Settings)
 	if len(charmYamlConfig) > 0 {
@@ -794,6 +855,36 @@ func checkMachinePlacement(backend Backend, args params.ApplicationDeploy) error
 	return nil
 }
 
+// checkPlacementPolicy validates the placement policy in the application
+// config of an application being deployed, and checks that the supplied
+// placement directives do not break it.
+func checkPlacementPolicy(appName string, cfg *application.Config, placement []*instance.Placement) error {
+	policy, err := cfg.Attributes().PlacementPolicy()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if policy.Avoids(appName) {
+		return errors.NotValidf("application %q avoiding itself", appName)
+	}
+	limit := policy.UnitsPerMachine()
+	if limit == 0 {
+		return nil
+	}
+	unitsPerMachine := make(map[string]int)
+	for _, p := range placement {
+		if p == nil || p.Scope != instance.MachineScope {
+			continue
+		}
+		unitsPerMachine[p.Directive]++
+		if unitsPerMachine[p.Directive] > limit {
+			return errors.Errorf(
+				"cannot place more than %d unit(s) of %q on machine %s", limit, appName, p.Directive,
+			)
+		}
+	}
+	return nil
+}
+
 // applicationSetSettingsStrings updates the settings for the given application,
 // taking the configuration from a map of strings.
 func applicationSetSettingsStrings(
@@ -847,6 +938,7 @@ func parseSettingsCompatible(charmConfig *charm.Config, settings map[string]stri
 type setCharmParams struct {
 	AppName               string
 	Application           Application
+	Generation            string
 	Channel               csparams.Channel
 	ConfigSettingsStrings map[string]string
 	ConfigSettingsYAML    string
@@ -1004,6 +1096,16 @@ func (api *APIBase) updateOneApplicationSeries(arg params.UpdateSeriesArg) error
 }
 
 // SetCharm sets the charm for a given for the application.
+// Prior to version 14, the charm is always set on master.
+func (api *APIv13) SetCharm(args params.ApplicationSetCharm) error {
+	args.Generation = model.GenerationMaster
+	return api.APIv14.SetCharm(args)
+}
+
+// SetCharm sets the charm for a given for the application.
+// If a branch other than master is given, the charm upgrade is staged
+// under the branch, so that only units tracking it are upgraded until
+// the branch is committed.
 func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
@@ -1023,6 +1125,7 @@ func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 		setCharmParams{
 			AppName:               args.ApplicationName,
 			Application:           oneApplication,
+			Generation:            args.Generation,
 			Channel:               channel,
 			ConfigSettingsStrings: args.ConfigSettings,
 			ConfigSettingsYAML:    args.ConfigSettingsYAML,
@@ -1167,6 +1270,13 @@ func (api *APIBase) applicationSetCharm(
 		StorageConstraints: stateStorageConstraints,
 		EndpointBindings:   params.EndpointBindings,
 	}
+	if params.Generation != "" && params.Generation != model.GenerationMaster {
+		branch, err := api.backend.Branch(params.Generation)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(branch.SetCharm(params.AppName, cfg))
+	}
 	return params.Application.SetCharm(cfg)
 }
 
@@ -1231,6 +1341,16 @@ func applicationSetCharmConfigYAML(
 
 // GetCharmURL returns the charm URL the given application is
 // running at present.
+// Prior to version 14, the charm URL on master is always returned.
+func (api *APIv13) GetCharmURL(args params.ApplicationGet) (params.StringResult, error) {
+	args.BranchName = model.GenerationMaster
+	return api.APIv14.GetCharmURL(args)
+}
+
+// GetCharmURL returns the charm URL the given application is
+// running at present.
+// If a branch other than master is given, the URL of any charm
+// staged for the application under the branch is returned.
 func (api *APIBase) GetCharmURL(args params.ApplicationGet) (params.StringResult, error) {
 	if err := api.checkCanWrite(); err != nil {
 		return params.StringResult{}, errors.Trace(err)
@@ -1239,6 +1359,15 @@ func (api *APIBase) GetCharmURL(args params.ApplicationGet) (params.StringResult
 	if err != nil {
 		return params.StringResult{}, errors.Trace(err)
 	}
+	if args.BranchName != "" && args.BranchName != model.GenerationMaster {
+		branch, err := api.backend.Branch(args.BranchName)
+		if err != nil {
+			return params.StringResult{}, errors.Trace(err)
+		}
+		if charmURL, ok := branch.CharmURL(args.ApplicationName); ok {
+			return params.StringResult{Result: charmURL.String()}, nil
+		}
+	}
 	charmURL, _ := oneApplication.CharmURL()
 	return params.StringResult{Result: charmURL.String()}, nil
 }
@@ -1618,86 +1747,284 @@ func (api *APIBase) DestroyApplication(args params.DestroyApplicationsParams) (p
 	if err := api.check.RemoveAllowed(); err != nil {
 		return params.DestroyApplicationResults{}, errors.Trace(err)
 	}
-	destroyApp := func(arg params.DestroyApplicationParams) (*params.DestroyApplicationInfo, error) {
-		tag, err := names.ParseApplicationTag(arg.ApplicationTag)
-		if err != nil {
-			return nil, err
+	return api.destroyApplications(args, false)
+}
+
+// PlanDestroyApplication isn't on the v14 API.
+func (u *APIv14) PlanDestroyApplication(_, _ struct{}) {}
+
+// PlanDestroyApplication returns what destroying the given set of
+// applications would affect, without destroying anything. As well as
+// the units and storage, it reports the relations, offers, subordinates
+// and machines that would be destroyed or left orphaned.
+func (api *APIBase) PlanDestroyApplication(args params.DestroyApplicationsParams) (params.DestroyApplicationResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.DestroyApplicationResults{}, err
+	}
+	return api.destroyApplications(args, true)
+}
+
+// applicationRemoval holds the plan for removing an application, along
+// with the plans for the subordinate applications which are removed
+// with it when cascading.
+type applicationRemoval struct {
+	state.ApplicationRemovalPlan
+	cascaded []state.ApplicationRemovalPlan
+}
+
+func (api *APIBase) destroyApplications(args params.DestroyApplicationsParams, dryRun bool) (params.DestroyApplicationResults, error) {
+	// The full plan is only needed to report it or to cascade.
+	var removals map[string]*applicationRemoval
+	planRemoval := dryRun
+	for _, arg := range args.Applications {
+		planRemoval = planRemoval || arg.Cascade
+	}
+	if planRemoval {
+		var err error
+		if removals, err = api.planApplicationsRemoval(args); err != nil {
+			return params.DestroyApplicationResults{}, errors.Trace(err)
 		}
-		var info params.DestroyApplicationInfo
-		app, err := api.backend.Application(tag.Id())
+	}
+	results := make([]params.DestroyApplicationResult, len(args.Applications))
+	for i, arg := range args.Applications {
+		var removal *applicationRemoval
+		if tag, err := names.ParseApplicationTag(arg.ApplicationTag); err == nil {
+			removal = removals[tag.Id()]
+		}
+		info, err := api.destroyApplication(arg, removal, dryRun)
 		if err != nil {
-			return nil, err
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
 		}
-		units, err := app.AllUnits()
+		results[i].Info = info
+	}
+	return params.DestroyApplicationResults{results}, nil
+}
+
+// planApplicationsRemoval returns the removal plans for the applications
+// in args, keyed by application name. Applications which can't be found
+// are left out, so that the error is reported when destroying them.
+func (api *APIBase) planApplicationsRemoval(args params.DestroyApplicationsParams) (map[string]*applicationRemoval, error) {
+	var appNames []string
+	cascade := make(map[string]bool)
+	for _, arg := range args.Applications {
+		tag, err := names.ParseApplicationTag(arg.ApplicationTag)
 		if err != nil {
-			return nil, err
+			continue
 		}
-		storageSeen := names.NewSet()
-		for _, unit := range units {
-			info.DestroyedUnits = append(
-				info.DestroyedUnits,
-				params.Entity{unit.UnitTag().String()},
-			)
-			unitStorage, err := storagecommon.UnitStorage(api.storageAccess, unit.UnitTag())
+		if _, err := api.backend.Application(tag.Id()); err != nil {
+			continue
+		}
+		appNames = append(appNames, tag.Id())
+		cascade[tag.Id()] = arg.Cascade
+	}
+	plans, err := api.backend.ApplicationsRemovalPlan(appNames...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	removals := make(map[string]*applicationRemoval)
+	owners := make(map[string]string)
+	var cascaded []string
+	for _, plan := range plans {
+		removals[plan.Application] = &applicationRemoval{ApplicationRemovalPlan: plan}
+		if !cascade[plan.Application] {
+			continue
+		}
+		for _, sub := range plan.OrphanedApplications {
+			owners[sub] = plan.Application
+			cascaded = append(cascaded, sub)
+		}
+	}
+	if len(cascaded) == 0 {
+		return removals, nil
+	}
+
+	// Plan again, removing the orphaned subordinates as well, to find
+	// out what else removing them affects.
+	plans, err = api.backend.ApplicationsRemovalPlan(append(appNames, cascaded...)...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, plan := range plans {
+		if owner, ok := owners[plan.Application]; ok {
+			removals[owner].cascaded = append(removals[owner].cascaded, plan)
+		} else {
+			removals[plan.Application].ApplicationRemovalPlan = plan
+		}
+	}
+	return removals, nil
+}
+
+// destroyApplication destroys the application, returning the details of
+// what that affects. If there is a removal plan for the application, the
+// details include everything in the plan, and on cascade the orphaned
+// subordinate applications and machines are destroyed too. With dryRun,
+// nothing is destroyed.
+func (api *APIBase) destroyApplication(
+	arg params.DestroyApplicationParams, removal *applicationRemoval, dryRun bool,
+) (*params.DestroyApplicationInfo, error) {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return nil, err
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return nil, err
+	}
+	info, err := api.unitsRemovalInfo(app, arg.DestroyStorage)
+	if err != nil {
+		return nil, err
+	}
+	var cascaded []Application
+	if removal != nil {
+		addRemovalPlanInfo(info, removal.ApplicationRemovalPlan, arg.Cascade)
+		for _, plan := range removal.cascaded {
+			subApp, err := api.backend.Application(plan.Application)
 			if err != nil {
 				return nil, err
 			}
+			subInfo, err := api.unitsRemovalInfo(subApp, arg.DestroyStorage)
+			if err != nil {
+				return nil, err
+			}
+			addRemovalPlanInfo(subInfo, plan, arg.Cascade)
+			info.DestroyedApplications = append(info.DestroyedApplications, params.Entity{Tag: names.NewApplicationTag(plan.Application).String()})
+			info.DestroyedUnits = append(info.DestroyedUnits, subInfo.DestroyedUnits...)
+			info.DestroyedStorage = append(info.DestroyedStorage, subInfo.DestroyedStorage...)
+			info.DetachedStorage = append(info.DetachedStorage, subInfo.DetachedStorage...)
+			info.DestroyedRelations = append(info.DestroyedRelations, subInfo.DestroyedRelations...)
+			info.DestroyedOffers = append(info.DestroyedOffers, subInfo.DestroyedOffers...)
+			info.OfferConnections = append(info.OfferConnections, subInfo.OfferConnections...)
+			cascaded = append(cascaded, subApp)
+		}
+	}
+	if dryRun {
+		return info, nil
+	}
 
-			// Filter out storage we've already seen. Shared
-			// storage may be attached to multiple units.
-			var unseen []state.StorageInstance
-			for _, stor := range unitStorage {
-				storageTag := stor.StorageTag()
-				if storageSeen.Contains(storageTag) {
-					continue
-				}
-				storageSeen.Add(storageTag)
-				unseen = append(unseen, stor)
+	if err := api.applyDestroyApplication(app, arg); err != nil {
+		return nil, err
+	}
+	for _, subApp := range cascaded {
+		if err := api.applyDestroyApplication(subApp, arg); err != nil {
+			return nil, errors.Annotatef(err, "destroying subordinate application %q", subApp.Name())
+		}
+	}
+	if removal != nil && arg.Cascade {
+		for _, id := range removal.OrphanedMachines {
+			m, err := api.backend.Machine(id)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, err
 			}
-			unitStorage = unseen
-
-			if arg.DestroyStorage {
-				for _, s := range unitStorage {
-					info.DestroyedStorage = append(
-						info.DestroyedStorage,
-						params.Entity{s.StorageTag().String()},
-					)
-				}
-			} else {
-				destroyed, detached, err := storagecommon.ClassifyDetachedStorage(
-					api.storageAccess.VolumeAccess(), api.storageAccess.FilesystemAccess(), unitStorage,
-				)
-				if err != nil {
-					return nil, err
-				}
-				info.DestroyedStorage = append(info.DestroyedStorage, destroyed...)
-				info.DetachedStorage = append(info.DetachedStorage, detached...)
+			if err := m.DestroyWhenUnused(); err != nil {
+				return nil, errors.Annotatef(err, "destroying machine %s", id)
 			}
 		}
-		op := app.DestroyOperation()
-		op.DestroyStorage = arg.DestroyStorage
-		op.Force = arg.Force
-		if arg.Force {
-			op.MaxWait = common.MaxWait(arg.MaxWait)
-		}
-		if err := api.backend.ApplyOperation(op); err != nil {
+	}
+	return info, nil
+}
+
+func (api *APIBase) applyDestroyApplication(app Application, arg params.DestroyApplicationParams) error {
+	op := app.DestroyOperation()
+	op.DestroyStorage = arg.DestroyStorage
+	op.Force = arg.Force
+	if arg.Force {
+		op.MaxWait = common.MaxWait(arg.MaxWait)
+	}
+	if err := api.backend.ApplyOperation(op); err != nil {
+		return err
+	}
+	if len(op.Errors) != 0 {
+		logger.Warningf("operational errors destroying application %v: %v", app.Name(), op.Errors)
+	}
+	return nil
+}
+
+// unitsRemovalInfo returns the units of the application that will be
+// destroyed, and the storage attached to them that will be destroyed
+// or detached.
+func (api *APIBase) unitsRemovalInfo(app Application, destroyStorage bool) (*params.DestroyApplicationInfo, error) {
+	var info params.DestroyApplicationInfo
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, err
+	}
+	storageSeen := names.NewSet()
+	for _, unit := range units {
+		info.DestroyedUnits = append(
+			info.DestroyedUnits,
+			params.Entity{unit.UnitTag().String()},
+		)
+		unitStorage, err := storagecommon.UnitStorage(api.storageAccess, unit.UnitTag())
+		if err != nil {
 			return nil, err
 		}
-		if len(op.Errors) != 0 {
-			logger.Warningf("operational errors destroying application %v: %v", tag.Id(), op.Errors)
+
+		// Filter out storage we've already seen. Shared
+		// storage may be attached to multiple units.
+		var unseen []state.StorageInstance
+		for _, stor := range unitStorage {
+			storageTag := stor.StorageTag()
+			if storageSeen.Contains(storageTag) {
+				continue
+			}
+			storageSeen.Add(storageTag)
+			unseen = append(unseen, stor)
+		}
+		unitStorage = unseen
+
+		if destroyStorage {
+			for _, s := range unitStorage {
+				info.DestroyedStorage = append(
+					info.DestroyedStorage,
+					params.Entity{s.StorageTag().String()},
+				)
+			}
+		} else {
+			destroyed, detached, err := storagecommon.ClassifyDetachedStorage(
+				api.storageAccess.VolumeAccess(), api.storageAccess.FilesystemAccess(), unitStorage,
+			)
+			if err != nil {
+				return nil, err
+			}
+			info.DestroyedStorage = append(info.DestroyedStorage, destroyed...)
+			info.DetachedStorage = append(info.DetachedStorage, detached...)
 		}
-		return &info, nil
 	}
-	results := make([]params.DestroyApplicationResult, len(args.Applications))
-	for i, arg := range args.Applications {
-		info, err := destroyApp(arg)
-		if err != nil {
-			results[i].Error = apiservererrors.ServerError(err)
-			continue
+	return &info, nil
+}
+
+// addRemovalPlanInfo adds the entities in the removal plan to info. The
+// orphaned machines are destroyed on cascade, and otherwise left orphaned.
+func addRemovalPlanInfo(info *params.DestroyApplicationInfo, plan state.ApplicationRemovalPlan, cascade bool) {
+	for _, name := range plan.Subordinates {
+		info.DestroyedSubordinates = append(info.DestroyedSubordinates, params.Entity{Tag: names.NewUnitTag(name).String()})
+	}
+	for _, key := range plan.Relations {
+		info.DestroyedRelations = append(info.DestroyedRelations, params.Entity{Tag: names.NewRelationTag(key).String()})
+	}
+	for _, name := range plan.Offers {
+		info.DestroyedOffers = append(info.DestroyedOffers, params.Entity{Tag: names.NewApplicationOfferTag(name).String()})
+	}
+	for _, key := range plan.OfferConnections {
+		info.OfferConnections = append(info.OfferConnections, params.Entity{Tag: names.NewRelationTag(key).String()})
+	}
+	for _, name := range plan.OrphanedApplications {
+		info.OrphanedApplications = append(info.OrphanedApplications, params.Entity{Tag: names.NewApplicationTag(name).String()})
+	}
+	for _, id := range plan.Machines {
+		info.DestroyedMachines = append(info.DestroyedMachines, params.Entity{Tag: names.NewMachineTag(id).String()})
+	}
+	for _, id := range plan.OrphanedMachines {
+		entity := params.Entity{Tag: names.NewMachineTag(id).String()}
+		if cascade {
+			info.DestroyedMachines = append(info.DestroyedMachines, entity)
+		} else {
+			info.OrphanedMachines = append(info.OrphanedMachines, entity)
 		}
-		results[i].Info = info
 	}
-	return params.DestroyApplicationResults{results}, nil
 }
 
 // DestroyConsumedApplications removes a given set of consumed (remote) applications.
@@ -2317,6 +2644,9 @@ func (api *APIBase) setApplicationConfig(arg params.ApplicationConfigSet) error
 	}
 
 	if len(appConfigAttrs) > 0 {
+		if err := validateApplicationConfig(app, arg.ApplicationName, appConfigAttrs, configSchema, defaults); err != nil {
+			return errors.Trace(err)
+		}
 		if err := app.UpdateApplicationConfig(appConfigAttrs, nil, configSchema, defaults); err != nil {
 			return errors.Annotate(err, "updating application config values")
 		}
@@ -2350,6 +2680,42 @@ func (api *APIBase) setApplicationConfig(arg params.ApplicationConfigSet) error
 	return nil
 }
 
+// validateApplicationConfig checks the policies held in the application
+// config that would result from applying the given changes.
+func validateApplicationConfig(
+	app Application, appName string, changes application.ConfigAttributes,
+	configSchema environschema.Fields, defaults schema.Defaults,
+) error {
+	current, err := app.ApplicationConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	attrs := make(application.ConfigAttributes)
+	for k, v := range current {
+		attrs[k] = v
+	}
+	for k, v := range changes {
+		attrs[k] = v
+	}
+	cfg, err := application.NewConfig(attrs, configSchema, defaults)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkPlacementPolicy(appName, cfg, nil); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := cfg.Attributes().AutoscalePolicy(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := cfg.Attributes().CharmUpdatePolicy(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := cfg.Attributes().CharmUpdateWindow(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 func (api *APIBase) addAppToBranch(branchName string, appName string) error {
 	gen, err := api.backend.Branch(branchName)
 	if err != nil {
@@ -17,6 +17,8 @@ import (
 	"github.com/juju/utils"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
+	"gopkg.in/macaroon.v2"
 
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/apiserver/common"
@@ -30,6 +32,7 @@ import (
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
@@ -59,7 +62,7 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv12
+	api          *application.APIv16
 	deployParams map[string]application.DeployApplicationParams
 }
 
@@ -90,7 +93,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.caasBroker,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv12{api}
+	s.api = &application.APIv16{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -336,6 +339,64 @@ func (s *ApplicationSuite) TestSetCAASCharmInvalid(c *gc.C) {
 	c.Assert(msg, gc.Matches, "Juju on k8s does not support updating deployment info.*")
 }
 
+func (s *ApplicationSuite) TestSetCharmBranch(c *gc.C) {
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		Generation:      "new-branch",
+		ForceUnits:      true,
+		ResourceIDs:     map[string]string{"data": "pending-id"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "Application", "Charm")
+	s.backend.generation.CheckCall(c, 0, "SetCharm", "postgresql", state.SetCharmConfig{
+		Charm:       &state.Charm{},
+		ForceUnits:  true,
+		ResourceIDs: map[string]string{"data": "pending-id"},
+	})
+	app := s.backend.applications["postgresql"]
+	for _, call := range app.Calls() {
+		c.Check(call.FuncName, gc.Not(gc.Equals), "SetCharm")
+	}
+}
+
+func (s *ApplicationSuite) TestSetCharmBranchV13UsesMaster(c *gc.C) {
+	api := &application.APIv13{&application.APIv14{&application.APIv15{s.api}}}
+	err := api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		Generation:      "new-branch",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "Application", "Charm")
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 2, "SetCharm", state.SetCharmConfig{
+		Charm: &state.Charm{},
+	})
+	c.Check(s.backend.generation, gc.IsNil)
+}
+
+func (s *ApplicationSuite) TestGetCharmURLBranch(c *gc.C) {
+	s.backend.generation = &mockGeneration{
+		charmURLs: map[string]*charm.URL{"postgresql": charm.MustParseURL("cs:postgresql-43")},
+	}
+	result, err := s.api.GetCharmURL(params.ApplicationGet{
+		ApplicationName: "postgresql",
+		BranchName:      "new-branch",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.Equals, "cs:postgresql-43")
+
+	// The application's charm is returned if none is staged under the branch.
+	s.backend.generation.charmURLs = nil
+	result, err = s.api.GetCharmURL(params.ApplicationGet{
+		ApplicationName: "postgresql",
+		BranchName:      "new-branch",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.Equals, "cs:postgresql-42")
+}
+
 func (s *ApplicationSuite) TestDeployCAASOperatorProtectedByFlag(c *gc.C) {
 	s.model.modelType = state.ModelTypeCAAS
 	s.setAPIUser(c, names.NewUserTag("admin"))
@@ -599,6 +660,142 @@ func (s *ApplicationSuite) TestDestroyApplicationNotFound(c *gc.C) {
 	})
 }
 
+func (s *ApplicationSuite) setRemovalPlans() {
+	s.backend.removalPlans = map[string]state.ApplicationRemovalPlan{
+		"postgresql": {
+			Units:                []string{"postgresql/0", "postgresql/1"},
+			Subordinates:         []string{"logging/0"},
+			Relations:            []string{"logging:info postgresql:juju-info", "postgresql:db wordpress:db"},
+			Offers:               []string{"hosted-db"},
+			OrphanedApplications: []string{"postgresql-subordinate"},
+			Machines:             []string{"0/lxd/0"},
+			OrphanedMachines:     []string{"0"},
+		},
+		"postgresql-subordinate": {
+			Units:     []string{"postgresql-subordinate/0", "postgresql-subordinate/1"},
+			Relations: []string{"nrpe:monitors postgresql-subordinate:monitors"},
+		},
+	}
+	s.backend.machines = map[string]*mockMachine{"0": {id: "0"}}
+}
+
+func (s *ApplicationSuite) TestPlanDestroyApplication(c *gc.C) {
+	s.setRemovalPlans()
+	results, err := s.api.PlanDestroyApplication(params.DestroyApplicationsParams{
+		Applications: []params.DestroyApplicationParams{
+			{ApplicationTag: "application-postgresql", DestroyStorage: true},
+			{ApplicationTag: "application-unknown"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.DestroyApplicationResult{{
+		Info: &params.DestroyApplicationInfo{
+			DestroyedUnits: []params.Entity{
+				{Tag: "unit-postgresql-0"},
+				{Tag: "unit-postgresql-1"},
+			},
+			DestroyedStorage: []params.Entity{
+				{Tag: "storage-pgdata-0"},
+				{Tag: "storage-pgdata-1"},
+			},
+			DestroyedSubordinates: []params.Entity{{Tag: "unit-logging-0"}},
+			DestroyedRelations: []params.Entity{
+				{Tag: "relation-logging.info#postgresql.juju-info"},
+				{Tag: "relation-postgresql.db#wordpress.db"},
+			},
+			DestroyedOffers:      []params.Entity{{Tag: "applicationoffer-hosted-db"}},
+			OrphanedApplications: []params.Entity{{Tag: "application-postgresql-subordinate"}},
+			DestroyedMachines:    []params.Entity{{Tag: "machine-0-lxd-0"}},
+			OrphanedMachines:     []params.Entity{{Tag: "machine-0"}},
+		},
+	}, {
+		Error: &params.Error{
+			Code:    params.CodeNotFound,
+			Message: `application "unknown" not found`,
+		},
+	}})
+	s.backend.CheckCall(c, 2, "ApplicationsRemovalPlan", []string{"postgresql"})
+	for _, call := range s.backend.Calls() {
+		c.Check(call.FuncName, gc.Not(gc.Equals), "ApplyOperation")
+	}
+}
+
+func (s *ApplicationSuite) TestPlanDestroyApplicationCascade(c *gc.C) {
+	s.setRemovalPlans()
+	results, err := s.api.PlanDestroyApplication(params.DestroyApplicationsParams{
+		Applications: []params.DestroyApplicationParams{
+			{ApplicationTag: "application-postgresql", DestroyStorage: true, Cascade: true},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	info := results.Results[0].Info
+	c.Assert(info, gc.NotNil)
+	c.Check(info.DestroyedApplications, jc.DeepEquals, []params.Entity{{Tag: "application-postgresql-subordinate"}})
+	c.Check(info.OrphanedApplications, gc.HasLen, 0)
+	c.Check(info.DestroyedUnits, jc.DeepEquals, []params.Entity{
+		{Tag: "unit-postgresql-0"},
+		{Tag: "unit-postgresql-1"},
+		{Tag: "unit-postgresql-subordinate-0"},
+		{Tag: "unit-postgresql-subordinate-1"},
+	})
+	c.Check(info.DestroyedRelations, jc.DeepEquals, []params.Entity{
+		{Tag: "relation-logging.info#postgresql.juju-info"},
+		{Tag: "relation-postgresql.db#wordpress.db"},
+		{Tag: "relation-nrpe.monitors#postgresql-subordinate.monitors"},
+	})
+	c.Check(info.DestroyedMachines, jc.DeepEquals, []params.Entity{
+		{Tag: "machine-0-lxd-0"},
+		{Tag: "machine-0"},
+	})
+	c.Check(info.OrphanedMachines, gc.HasLen, 0)
+	s.backend.CheckCall(c, 2, "ApplicationsRemovalPlan", []string{"postgresql", "postgresql-subordinate"})
+	s.backend.machines["0"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestDestroyApplicationCascade(c *gc.C) {
+	s.setRemovalPlans()
+	results, err := s.api.DestroyApplication(params.DestroyApplicationsParams{
+		Applications: []params.DestroyApplicationParams{
+			{ApplicationTag: "application-postgresql", DestroyStorage: true, Cascade: true},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[0].Info.DestroyedApplications, jc.DeepEquals, []params.Entity{
+		{Tag: "application-postgresql-subordinate"},
+	})
+
+	var applied []string
+	for _, call := range s.backend.Calls() {
+		if call.FuncName == "ApplyOperation" {
+			applied = append(applied, call.FuncName)
+		}
+	}
+	c.Check(applied, gc.HasLen, 2)
+	s.backend.applications["postgresql"].CheckCallNames(c, "AllUnits", "DestroyOperation")
+	s.backend.applications["postgresql-subordinate"].CheckCallNames(c, "AllUnits", "DestroyOperation")
+	s.backend.machines["0"].CheckCallNames(c, "DestroyWhenUnused")
+}
+
+func (s *ApplicationSuite) TestDestroyApplicationWithoutCascadeLeavesOrphans(c *gc.C) {
+	s.setRemovalPlans()
+	results, err := s.api.DestroyApplication(params.DestroyApplicationsParams{
+		Applications: []params.DestroyApplicationParams{
+			{ApplicationTag: "application-postgresql", DestroyStorage: true},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	for _, call := range s.backend.Calls() {
+		c.Check(call.FuncName, gc.Not(gc.Equals), "ApplicationsRemovalPlan")
+	}
+	s.backend.applications["postgresql-subordinate"].CheckNoCalls(c)
+	s.backend.machines["0"].CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestDestroyConsumedApplication(c *gc.C) {
 	results, err := s.api.DestroyConsumedApplications(params.DestroyConsumedApplicationsParams{
 		Applications: []params.DestroyConsumedApplicationParams{{ApplicationTag: "application-hosted-db2"}},
@@ -750,6 +947,38 @@ func (s *ApplicationSuite) TestDeployAttachStorage(c *gc.C) {
 	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"volume-baz-0" is not a valid volume tag`)
 }
 
+func (s *ApplicationSuite) TestDeployPlacementPolicy(c *gc.C) {
+	s.backend.machines = map[string]*mockMachine{"0": {id: "0"}}
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        2,
+			Config:          map[string]string{"placement-spread": "machine"},
+			Placement: []*instance.Placement{
+				{Scope: instance.MachineScope, Directive: "0"},
+				{Scope: instance.MachineScope, Directive: "0"},
+			},
+		}, {
+			ApplicationName: "bar",
+			CharmURL:        "local:bar-1",
+			NumUnits:        1,
+			Config:          map[string]string{"placement-avoid": "baz,bar"},
+		}, {
+			ApplicationName: "baz",
+			CharmURL:        "local:baz-2",
+			NumUnits:        1,
+			Config:          map[string]string{"placement-spread": "rack"},
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `cannot place more than 1 unit\(s\) of "foo" on machine 0`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "bar" avoiding itself not valid`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `placement-spread "rack" not valid`)
+}
+
 func (s *ApplicationSuite) TestDeployMinDeploymentVersionTooHigh(c *gc.C) {
 	s.model.modelType = state.ModelTypeCAAS
 	s.backend.charm = &mockCharm{
@@ -1660,7 +1889,7 @@ func (s *ApplicationSuite) testSetApplicationConfig(c *gc.C, branchName string)
 	c.Assert(result.OneError(), jc.ErrorIsNil)
 	s.backend.CheckCallNames(c, "Application")
 	app := s.backend.applications["postgresql"]
-	app.CheckCallNames(c, "UpdateApplicationConfig", "Charm", "UpdateCharmConfig")
+	app.CheckCallNames(c, "ApplicationConfig", "UpdateApplicationConfig", "Charm", "UpdateCharmConfig")
 
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
@@ -1668,10 +1897,10 @@ func (s *ApplicationSuite) testSetApplicationConfig(c *gc.C, branchName string)
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
-	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes{
+	app.CheckCall(c, 1, "UpdateApplicationConfig", coreapplication.ConfigAttributes{
 		"juju-external-hostname": "value",
 	}, []string(nil), schema, defaults)
-	app.CheckCall(c, 2, "UpdateCharmConfig", model.GenerationMaster, charm.Settings{"stringOption": "stringVal"})
+	app.CheckCall(c, 3, "UpdateCharmConfig", model.GenerationMaster, charm.Settings{"stringOption": "stringVal"})
 
 	// We should never have accessed the generation.
 	c.Check(s.backend.generation, gc.IsNil)
@@ -1692,7 +1921,7 @@ func (s *ApplicationSuite) TestSetApplicationConfigBranch(c *gc.C) {
 	c.Assert(result.OneError(), jc.ErrorIsNil)
 	s.backend.CheckCallNames(c, "Application")
 	app := s.backend.applications["postgresql"]
-	app.CheckCallNames(c, "UpdateApplicationConfig", "Charm", "UpdateCharmConfig")
+	app.CheckCallNames(c, "ApplicationConfig", "UpdateApplicationConfig", "Charm", "UpdateCharmConfig")
 
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
@@ -1700,14 +1929,67 @@ func (s *ApplicationSuite) TestSetApplicationConfigBranch(c *gc.C) {
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
-	app.CheckCall(c, 0, "UpdateApplicationConfig", coreapplication.ConfigAttributes{
+	app.CheckCall(c, 1, "UpdateApplicationConfig", coreapplication.ConfigAttributes{
 		"juju-external-hostname": "value",
 	}, []string(nil), schema, defaults)
-	app.CheckCall(c, 2, "UpdateCharmConfig", "new-branch", charm.Settings{"stringOption": "stringVal"})
+	app.CheckCall(c, 3, "UpdateCharmConfig", "new-branch", charm.Settings{"stringOption": "stringVal"})
 
 	s.backend.generation.CheckCall(c, 0, "AssignApplication", "postgresql")
 }
 
+func (s *ApplicationSuite) TestSetApplicationConfigPlacementPolicy(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{"placement-avoid": "mysql"}
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"placement-spread": "rack"},
+		}, {
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"placement-avoid": "mysql,postgresql"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `placement-spread "rack" not valid`)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `application "postgresql" avoiding itself not valid`)
+	app.CheckCallNames(c, "ApplicationConfig", "ApplicationConfig")
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigAutoscalePolicy(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{
+		"autoscale-source":               "status:blocked",
+		"autoscale-max-units":            3,
+		"autoscale-scale-up-threshold":   "0.8",
+		"autoscale-scale-down-threshold": "0.2",
+	}
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"autoscale-min-units": "4"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `autoscale-max-units 3 less than autoscale-min-units 4 not valid`)
+	app.CheckCallNames(c, "ApplicationConfig")
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigCharmUpdatePolicy(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"charm-update-policy": "sometimes"},
+		}, {
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"charm-update-window": "sat 02:00"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `charm update policy "sometimes" not valid`)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `maintenance window "sat 02:00": expected HH:MM-HH:MM not valid`)
+	app.CheckCallNames(c, "ApplicationConfig", "ApplicationConfig")
+}
+
 func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
 	s.blockChecker.SetErrors(errors.New("blocked"))
 	_, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{})
@@ -1974,3 +2256,245 @@ func (s *ApplicationSuite) TestUnitsInfo(c *gc.C) {
 		Message: `unit "mysql/0" not found`,
 	})
 }
+
+func (s *ApplicationSuite) setupRemoteRelations(c *gc.C) (consumed, offered *mockRelation) {
+	consumed = &mockRelation{
+		id:  7,
+		tag: names.NewRelationTag("postgresql:db hosted-db2:server"),
+		endpoints: []state.Endpoint{{
+			ApplicationName: "postgresql",
+			Relation:        charm.Relation{Name: "db"},
+		}, {
+			ApplicationName: "hosted-db2",
+			Relation:        charm.Relation{Name: "server"},
+		}},
+	}
+	offered = &mockRelation{
+		id:              8,
+		tag:             names.NewRelationTag("remote-consumer:db postgresql:db"),
+		suspended:       true,
+		suspendedReason: "paused",
+		endpoints: []state.Endpoint{{
+			ApplicationName: "remote-consumer",
+			Relation:        charm.Relation{Name: "db"},
+		}, {
+			ApplicationName: "postgresql",
+			Relation:        charm.Relation{Name: "db"},
+		}},
+	}
+	s.backend.relations[7] = consumed
+	s.backend.relations[8] = offered
+	s.backend.remoteApplications = map[string]application.RemoteApplication{
+		"hosted-db2": &mockRemoteApplication{
+			name:           "hosted-db2",
+			sourceModelTag: coretesting.ModelTag,
+			relations:      []application.Relation{consumed},
+		},
+		"remote-consumer": &mockRemoteApplication{
+			name:           "remote-consumer",
+			sourceModelTag: names.NewModelTag("consumer-uuid"),
+			consumerProxy:  true,
+			relations:      []application.Relation{offered},
+		},
+	}
+	s.backend.offerConnections["remote-consumer:db postgresql:db"] = &mockOfferConnection{
+		sourceModelUUID: "consumer-uuid",
+		username:        "fred",
+	}
+	s.backend.tokens = map[names.Tag]string{
+		consumed.Tag():                        "consumed-token",
+		names.NewApplicationTag("hosted-db2"): "db2-token",
+		offered.Tag():                         "offered-token",
+		names.NewApplicationTag("postgresql"): "postgresql-token",
+	}
+	mac, err := apitesting.NewMacaroon("test")
+	c.Assert(err, jc.ErrorIsNil)
+	expiry := time.Date(2020, 11, 4, 10, 0, 0, 0, time.UTC)
+	err = mac.AddFirstPartyCaveat([]byte(checkers.TimeBeforeCaveat(expiry).Condition))
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.macaroons = map[names.Tag]*macaroon.Macaroon{consumed.Tag(): mac}
+	s.backend.egressNetworks = map[string][]string{
+		"postgresql:db hosted-db2:server": {"10.0.0.0/24"},
+	}
+	s.backend.ingressNetworks = map[string][]string{
+		"remote-consumer:db postgresql:db": {"192.168.0.0/16"},
+	}
+	suspended := true
+	s.backend.remoteRelationEvents = map[string]state.RemoteRelationEvent{
+		"remote-consumer:db postgresql:db": {
+			Received:      time.Date(2020, 11, 3, 9, 0, 0, 0, time.UTC),
+			Life:          life.Alive,
+			Suspended:     &suspended,
+			DepartedUnits: []int{1},
+			Error:         "boom",
+		},
+	}
+	return consumed, offered
+}
+
+func (s *ApplicationSuite) TestRemoteRelationInfo(c *gc.C) {
+	s.setupRemoteRelations(c)
+	result, err := s.api.RemoteRelationInfo(params.RemoteRelationInfoArgs{
+		RelationIds:  []int{7},
+		Applications: []string{"remote-consumer"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+
+	expiry := time.Date(2020, 11, 4, 10, 0, 0, 0, time.UTC)
+	postgresqlUnits := map[string]params.RelationData{
+		"postgresql/0": {
+			InScope:  true,
+			UnitData: map[string]interface{}{"postgresql/0": "postgresql/0-setting"},
+		},
+		"postgresql/1": {
+			InScope:  true,
+			UnitData: map[string]interface{}{"postgresql/1": "postgresql/1-setting"},
+		},
+	}
+	c.Assert(*result.Results[0].Result, jc.DeepEquals, params.RemoteRelationInfo{
+		Id:              7,
+		Key:             "postgresql:db hosted-db2:server",
+		Token:           "consumed-token",
+		SourceModelUUID: coretesting.ModelTag.Id(),
+		MacaroonExpiry:  &expiry,
+		EgressNetworks:  []string{"10.0.0.0/24"},
+		Local: params.RemoteRelationEndpoint{
+			ApplicationName:  "postgresql",
+			Endpoint:         "db",
+			Token:            "postgresql-token",
+			ApplicationData:  map[string]interface{}{"app-postgresql": "setting"},
+			UnitRelationData: postgresqlUnits,
+		},
+		Remote: params.RemoteRelationEndpoint{
+			ApplicationName: "hosted-db2",
+			Endpoint:        "server",
+			Token:           "db2-token",
+			ApplicationData: map[string]interface{}{"app-hosted-db2": "setting"},
+			UnitRelationData: map[string]params.RelationData{
+				"hosted-db2/2": {
+					InScope:  true,
+					UnitData: map[string]interface{}{"hosted-db2/2": "hosted-db2/2-setting"},
+				},
+			},
+		},
+	})
+
+	suspended := true
+	c.Assert(*result.Results[1].Result, jc.DeepEquals, params.RemoteRelationInfo{
+		Id:              8,
+		Key:             "remote-consumer:db postgresql:db",
+		Token:           "offered-token",
+		Offered:         true,
+		SourceModelUUID: "consumer-uuid",
+		Username:        "fred",
+		Suspended:       true,
+		SuspendedReason: "paused",
+		IngressNetworks: []string{"192.168.0.0/16"},
+		LastEvent: &params.RemoteRelationEvent{
+			Received:      time.Date(2020, 11, 3, 9, 0, 0, 0, time.UTC),
+			Life:          life.Alive,
+			Suspended:     &suspended,
+			DepartedUnits: []int{1},
+			Error:         "boom",
+		},
+		Local: params.RemoteRelationEndpoint{
+			ApplicationName:  "postgresql",
+			Endpoint:         "db",
+			Token:            "postgresql-token",
+			ApplicationData:  map[string]interface{}{"app-postgresql": "setting"},
+			UnitRelationData: postgresqlUnits,
+		},
+		Remote: params.RemoteRelationEndpoint{
+			ApplicationName: "remote-consumer",
+			Endpoint:        "db",
+			ApplicationData: map[string]interface{}{"app-remote-consumer": "setting"},
+			UnitRelationData: map[string]params.RelationData{
+				"remote-consumer/2": {
+					InScope:  true,
+					UnitData: map[string]interface{}{"remote-consumer/2": "remote-consumer/2-setting"},
+				},
+			},
+		},
+	})
+}
+
+func (s *ApplicationSuite) TestRemoteRelationInfoOfferedApplication(c *gc.C) {
+	_, offered := s.setupRemoteRelations(c)
+	s.backend.applications["postgresql"].relations = []application.Relation{offered, &s.relation}
+
+	result, err := s.api.RemoteRelationInfo(params.RemoteRelationInfoArgs{
+		Applications: []string{"postgresql"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	// The relation that is not cross model is skipped.
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result.Id, gc.Equals, 8)
+}
+
+func (s *ApplicationSuite) TestRemoteRelationInfoErrors(c *gc.C) {
+	s.setupRemoteRelations(c)
+	s.backend.applications["redis"].relations = []application.Relation{}
+
+	result, err := s.api.RemoteRelationInfo(params.RemoteRelationInfoArgs{
+		RelationIds:  []int{123, 99},
+		Applications: []string{"redis", "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `relation "wordpress:db mysql:db" is not a cross model relation`)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `relation not found`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `cross model relations for application "redis" not found`)
+	c.Assert(result.Results[3].Error, gc.ErrorMatches, `application "missing" not found`)
+}
+
+func (s *ApplicationSuite) TestAutoscaleHistory(c *gc.C) {
+	when := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.autoscaleDecisions = map[string][]application.AutoscaleDecision{
+		"postgresql": {mockAutoscaleDecision{
+			time:      when,
+			fromUnits: 1,
+			toUnits:   2,
+			source:    "metric:load",
+			value:     0.9,
+			reason:    "metric:load 0.9 above 0.8",
+		}, mockAutoscaleDecision{
+			time:      when.Add(5 * time.Minute),
+			fromUnits: 2,
+			toUnits:   3,
+			source:    "metric:load",
+			value:     0.95,
+			reason:    "metric:load 0.95 above 0.8",
+			err:       "no machines",
+		}},
+	}
+	result, err := s.api.AutoscaleHistory(params.Entities{
+		Entities: []params.Entity{
+			{"application-postgresql"}, {"application-missing"}, {"machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Entries, jc.DeepEquals, []params.AutoscaleHistoryEntry{{
+		Time:      when,
+		FromUnits: 1,
+		ToUnits:   2,
+		Source:    "metric:load",
+		Value:     0.9,
+		Reason:    "metric:load 0.9 above 0.8",
+	}, {
+		Time:      when.Add(5 * time.Minute),
+		FromUnits: 2,
+		ToUnits:   3,
+		Source:    "metric:load",
+		Value:     0.95,
+		Reason:    "metric:load 0.95 above 0.8",
+		Error:     "no machines",
+	}})
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `application "missing" not found`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"machine-0" is not a valid application tag`)
+}
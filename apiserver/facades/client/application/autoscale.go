@@ -0,0 +1,61 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AutoscaleHistory isn't on the v15 API.
+func (u *APIv15) AutoscaleHistory(_, _ struct{}) {}
+
+// AutoscaleHistory returns the changes made, or attempted, by the
+// autoscaler to the number of units of each of the given applications,
+// oldest first.
+func (api *APIBase) AutoscaleHistory(args params.Entities) (params.AutoscaleHistoryResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.AutoscaleHistoryResults{}, errors.Trace(err)
+	}
+	results := make([]params.AutoscaleHistoryResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		entries, err := api.autoscaleHistory(arg.Tag)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results[i].Entries = entries
+	}
+	return params.AutoscaleHistoryResults{Results: results}, nil
+}
+
+func (api *APIBase) autoscaleHistory(tagString string) ([]params.AutoscaleHistoryEntry, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := api.backend.Application(tag.Id()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	decisions, err := api.backend.AutoscaleDecisions(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	entries := make([]params.AutoscaleHistoryEntry, len(decisions))
+	for i, d := range decisions {
+		entries[i] = params.AutoscaleHistoryEntry{
+			Time:      d.Time(),
+			FromUnits: d.FromUnits(),
+			ToUnits:   d.ToUnits(),
+			Source:    d.Source(),
+			Value:     d.Value(),
+			Reason:    d.Reason(),
+			Error:     d.Error(),
+		}
+	}
+	return entries, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/schema"
 	"github.com/juju/version"
 	"gopkg.in/juju/environschema.v1"
+	"gopkg.in/macaroon.v2"
 
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/controller"
@@ -34,6 +35,8 @@ type Backend interface {
 	AllModelUUIDs() ([]string, error)
 	Application(string) (Application, error)
 	ApplyOperation(state.ModelOperation) error
+	ApplicationsRemovalPlan(...string) ([]state.ApplicationRemovalPlan, error)
+	AutoscaleDecisions(string) ([]AutoscaleDecision, error)
 	AddApplication(state.AddApplicationArgs) (Application, error)
 	RemoteApplication(string) (RemoteApplication, error)
 	AddRemoteApplication(state.AddRemoteApplicationParams) (RemoteApplication, error)
@@ -51,10 +54,29 @@ type Backend interface {
 	Resources() (Resources, error)
 	OfferConnectionForRelation(string) (OfferConnection, error)
 	SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error)
+	IngressNetworks(relationKey string) (state.RelationNetworks, error)
+	EgressNetworks(relationKey string) (state.RelationNetworks, error)
+	GetToken(names.Tag) (string, error)
+	GetMacaroon(names.Tag) (*macaroon.Macaroon, error)
+	RemoteRelationEvent(relationKey string) (state.RemoteRelationEvent, error)
 	Branch(string) (Generation, error)
 	state.EndpointBinding
 }
 
+// AutoscaleDecision defines a subset of the functionality provided by the
+// state.AutoscaleDecision type, as required by the application facade.
+// For details on the methods, see the methods on state.AutoscaleDecision
+// with the same names.
+type AutoscaleDecision interface {
+	Time() time.Time
+	FromUnits() int
+	ToUnits() int
+	Source() string
+	Value() float64
+	Reason() string
+	Error() string
+}
+
 // BlockChecker defines the block-checking functionality required by
 // the application facade. This is implemented by
 // apiserver/common.BlockChecker.
@@ -124,6 +146,7 @@ type Charm interface {
 // the same names.
 type Machine interface {
 	PublicAddress() (network.SpaceAddress, error)
+	DestroyWhenUnused() error
 	IsLockedForSeriesUpgrade() (bool, error)
 	IsParentLockedForSeriesUpgrade() (bool, error)
 }
@@ -134,6 +157,7 @@ type Machine interface {
 // the same names.
 type Relation interface {
 	status.StatusSetter
+	Id() int
 	Tag() names.Tag
 	Destroy() error
 	DestroyWithForce(bool, time.Duration) ([]error, error)
@@ -201,6 +225,8 @@ type Resources interface {
 
 type Generation interface {
 	AssignApplication(string) error
+	CharmURL(string) (*charm.URL, bool)
+	SetCharm(string, state.SetCharmConfig) error
 }
 
 type stateShim struct {
@@ -280,6 +306,22 @@ func (s stateShim) Application(name string) (Application, error) {
 	return stateApplicationShim{a, s.State}, nil
 }
 
+func (s stateShim) AutoscaleDecisions(name string) ([]AutoscaleDecision, error) {
+	m, err := s.State.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	decisions, err := m.AutoscaleDecisions(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]AutoscaleDecision, len(decisions))
+	for i, d := range decisions {
+		result[i] = d
+	}
+	return result, nil
+}
+
 func (s stateShim) AddApplication(args state.AddApplicationArgs) (Application, error) {
 	a, err := s.State.AddApplication(args)
 	if err != nil {
@@ -290,6 +332,7 @@ func (s stateShim) AddApplication(args state.AddApplicationArgs) (Application, e
 
 type remoteApplicationShim struct {
 	*state.RemoteApplication
+	st *state.State
 }
 
 type RemoteApplication interface {
@@ -301,16 +344,30 @@ type RemoteApplication interface {
 	Spaces() []state.RemoteSpace
 	Destroy() error
 	DestroyOperation(force bool) *state.DestroyRemoteApplicationOperation
+	IsConsumerProxy() bool
+	Relations() ([]Relation, error)
 }
 
 func (s stateShim) RemoteApplication(name string) (RemoteApplication, error) {
 	app, err := s.State.RemoteApplication(name)
-	return &remoteApplicationShim{app}, err
+	return &remoteApplicationShim{app, s.State}, err
 }
 
 func (s stateShim) AddRemoteApplication(args state.AddRemoteApplicationParams) (RemoteApplication, error) {
 	app, err := s.State.AddRemoteApplication(args)
-	return &remoteApplicationShim{app}, err
+	return &remoteApplicationShim{app, s.State}, err
+}
+
+func (a *remoteApplicationShim) Relations() ([]Relation, error) {
+	rels, err := a.RemoteApplication.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]Relation, len(rels))
+	for i, r := range rels {
+		out[i] = stateRelationShim{r, a.st}
+	}
+	return out, nil
 }
 
 func (s stateShim) AddRelation(eps ...state.Endpoint) (Relation, error) {
@@ -326,6 +383,26 @@ func (s stateShim) SaveEgressNetworks(relationKey string, cidrs []string) (state
 	return api.Save(relationKey, false, cidrs)
 }
 
+func (s stateShim) IngressNetworks(relationKey string) (state.RelationNetworks, error) {
+	api := state.NewRelationIngressNetworks(s.State)
+	return api.Networks(relationKey)
+}
+
+func (s stateShim) EgressNetworks(relationKey string) (state.RelationNetworks, error) {
+	api := state.NewRelationEgressNetworks(s.State)
+	return api.Networks(relationKey)
+}
+
+func (s stateShim) GetToken(entity names.Tag) (string, error) {
+	r := s.State.RemoteEntities()
+	return r.GetToken(entity)
+}
+
+func (s stateShim) GetMacaroon(entity names.Tag) (*macaroon.Macaroon, error) {
+	r := s.State.RemoteEntities()
+	return r.GetMacaroon(entity)
+}
+
 func (s stateShim) Charm(curl *charm.URL) (Charm, error) {
 	ch, err := s.State.Charm(curl)
 	if err != nil {
@@ -382,7 +459,12 @@ func (s stateShim) Resources() (Resources, error) {
 	return s.State.Resources()
 }
 
-type OfferConnection interface{}
+// OfferConnection defines a subset of the functionality provided by the
+// state.OfferConnection type, as required by the application facade.
+type OfferConnection interface {
+	SourceModelUUID() string
+	UserName() string
+}
 
 func (s stateShim) OfferConnectionForRelation(key string) (OfferConnection, error) {
 	return s.State.OfferConnectionForRelation(key)
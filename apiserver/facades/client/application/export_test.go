@@ -3,11 +3,14 @@
 
 package application
 
-import "github.com/juju/juju/state"
+import (
+	"github.com/juju/juju/apiserver/common/charmscommon"
+	"github.com/juju/juju/state"
+)
 
 var (
 	ParseSettingsCompatible = parseSettingsCompatible
-	NewStateStorage         = &newStateStorage
+	NewStateStorage         = &charmscommon.NewStateStorage
 	GetStorageState         = getStorageState
 )
 
@@ -19,6 +22,6 @@ func GetModel(m *state.Model) Model {
 	return modelShim{m}
 }
 
-func SetModelType(api *APIv12, modelType state.ModelType) {
+func SetModelType(api *APIv16, modelType state.ModelType) {
 	api.modelType = modelType
 }
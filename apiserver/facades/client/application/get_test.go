@@ -29,7 +29,7 @@ import (
 type getSuite struct {
 	jujutesting.JujuConnSuite
 
-	applicationAPI *application.APIv12
+	applicationAPI *application.APIv16
 	authorizer     apiservertesting.FakeAuthorizer
 }
 
@@ -61,12 +61,12 @@ func (s *getSuite) SetUpTest(c *gc.C) {
 		nil, // CAAS Broker not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.applicationAPI = &application.APIv12{api}
+	s.applicationAPI = &application.APIv16{api}
 }
 
 func (s *getSuite) TestClientApplicationGetSmokeTestV4(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
-	v4 := &application.APIv4{&application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{s.applicationAPI}}}}}}}}
+	v4 := &application.APIv4{&application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{&application.APIv13{&application.APIv14{&application.APIv15{s.applicationAPI}}}}}}}}}}}}
 	results, err := v4.Get(params.ApplicationGet{ApplicationName: "wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ApplicationGetResults{
@@ -86,7 +86,7 @@ func (s *getSuite) TestClientApplicationGetSmokeTestV4(c *gc.C) {
 
 func (s *getSuite) TestClientApplicationGetSmokeTestV5(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
-	v5 := &application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{s.applicationAPI}}}}}}}
+	v5 := &application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{&application.APIv13{&application.APIv14{&application.APIv15{s.applicationAPI}}}}}}}}}}}
 	results, err := v5.Get(params.ApplicationGet{ApplicationName: "wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ApplicationGetResults{
@@ -129,6 +129,91 @@ func (s *getSuite) TestClientApplicationGetIAASModelSmokeTest(c *gc.C) {
 				"source":      "default",
 				"type":        environschema.Tbool,
 				"value":       false,
+			},
+			"charm-update-policy": map[string]interface{}{
+				"description": "What to do when a new revision of the charm is published to the application's channel: manual (pin the current revision), notify (report it in status) or auto (upgrade automatically)",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"charm-update-window": map[string]interface{}{
+				"description": "When automatic charm upgrades may take place, in the form \"[<day>,...] HH:MM-HH:MM\" in UTC, e.g. \"sat,sun 02:00-04:00\" (empty means at any time)",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"hook-retry": map[string]interface{}{
+				"description": "Whether failed hooks are automatically retried, overriding the model's automatically-retry-hooks",
+				"source":      "unset",
+				"type":        environschema.Tbool,
+			},
+			"hook-retry-jitter": map[string]interface{}{
+				"description": "Whether the time to wait between retries of a failed hook is randomised",
+				"source":      "unset",
+				"type":        environschema.Tbool,
+			},
+			"hook-retry-max-attempts": map[string]interface{}{
+				"description": "How many times a failed hook is automatically retried before giving up (0 means no limit)",
+				"source":      "unset",
+				"type":        environschema.Tint,
+			},
+			"hook-retry-max-delay": map[string]interface{}{
+				"description": "The longest time to wait between retries of a failed hook, in human-readable time format",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"hook-retry-min-delay": map[string]interface{}{
+				"description": "How long to wait before first retrying a failed hook, in human-readable time format",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"placement-avoid": map[string]interface{}{
+				"description": "A comma separated list of applications whose units must never share a machine with units of this application",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"placement-max-units-per-machine": map[string]interface{}{
+				"description": "The largest number of units of this application that may share a machine (0 means no limit)",
+				"source":      "unset",
+				"type":        environschema.Tint,
+			},
+			"placement-spread": map[string]interface{}{
+				"description": "What the units of this application are spread across: machine, zone, or label:<key> for the values of a machine annotation (empty means no spreading)",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"autoscale-cooldown": map[string]interface{}{
+				"description": "How long the autoscaler waits after scaling this application before scaling it again, in human-readable time format (default 5m)",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"autoscale-max-units": map[string]interface{}{
+				"description": "The most units the autoscaler scales this application up to",
+				"source":      "unset",
+				"type":        environschema.Tint,
+			},
+			"autoscale-min-units": map[string]interface{}{
+				"description": "The fewest units the autoscaler scales this application down to, and never fewer than its min-units (default 1)",
+				"source":      "unset",
+				"type":        environschema.Tint,
+			},
+			"autoscale-scale-down-threshold": map[string]interface{}{
+				"description": "The measurement of the autoscale source below which the autoscaler removes a unit",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"autoscale-scale-up-threshold": map[string]interface{}{
+				"description": "The measurement of the autoscale source above which the autoscaler adds a unit",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"autoscale-source": map[string]interface{}{
+				"description": "What the autoscaler measures to scale this application: metric:<key> for the mean of a charm metric across units, or status:<status> for the number of units with a workload status (empty disables autoscaling)",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"update-status-hook-interval": map[string]interface{}{
+				"description": "How often to run the charm update-status hook for this application, in human-readable time format, overriding the model's update-status-hook-interval (range 10s-60m)",
+				"source":      "unset",
+				"type":        environschema.Tstring,
 			}},
 		Series: "quantal",
 		EndpointBindings: map[string]string{
@@ -215,7 +300,7 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmokeTest(c *gc.C) {
 		nil, // CAAS Broker not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	apiV8 := &application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{api}}}}}
+	apiV8 := &application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{&application.APIv13{&application.APIv14{&application.APIv15{&application.APIv16{api}}}}}}}}}
 
 	results, err := apiV8.Get(params.ApplicationGet{ApplicationName: "dashboard4miner"})
 	c.Assert(err, jc.ErrorIsNil)
@@ -299,6 +384,91 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
+			"charm-update-policy": map[string]interface{}{
+				"description": "What to do when a new revision of the charm is published to the application's channel: manual (pin the current revision), notify (report it in status) or auto (upgrade automatically)",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"charm-update-window": map[string]interface{}{
+				"description": "When automatic charm upgrades may take place, in the form \"[<day>,...] HH:MM-HH:MM\" in UTC, e.g. \"sat,sun 02:00-04:00\" (empty means at any time)",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry": map[string]interface{}{
+				"description": "Whether failed hooks are automatically retried, overriding the model's automatically-retry-hooks",
+				"source":      "unset",
+				"type":        "bool",
+			},
+			"hook-retry-jitter": map[string]interface{}{
+				"description": "Whether the time to wait between retries of a failed hook is randomised",
+				"source":      "unset",
+				"type":        "bool",
+			},
+			"hook-retry-max-attempts": map[string]interface{}{
+				"description": "How many times a failed hook is automatically retried before giving up (0 means no limit)",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"hook-retry-max-delay": map[string]interface{}{
+				"description": "The longest time to wait between retries of a failed hook, in human-readable time format",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry-min-delay": map[string]interface{}{
+				"description": "How long to wait before first retrying a failed hook, in human-readable time format",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"placement-avoid": map[string]interface{}{
+				"description": "A comma separated list of applications whose units must never share a machine with units of this application",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"placement-max-units-per-machine": map[string]interface{}{
+				"description": "The largest number of units of this application that may share a machine (0 means no limit)",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"placement-spread": map[string]interface{}{
+				"description": "What the units of this application are spread across: machine, zone, or label:<key> for the values of a machine annotation (empty means no spreading)",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"autoscale-cooldown": map[string]interface{}{
+				"description": "How long the autoscaler waits after scaling this application before scaling it again, in human-readable time format (default 5m)",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"autoscale-max-units": map[string]interface{}{
+				"description": "The most units the autoscaler scales this application up to",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"autoscale-min-units": map[string]interface{}{
+				"description": "The fewest units the autoscaler scales this application down to, and never fewer than its min-units (default 1)",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"autoscale-scale-down-threshold": map[string]interface{}{
+				"description": "The measurement of the autoscale source below which the autoscaler removes a unit",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"autoscale-scale-up-threshold": map[string]interface{}{
+				"description": "The measurement of the autoscale source above which the autoscaler adds a unit",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"autoscale-source": map[string]interface{}{
+				"description": "What the autoscaler measures to scale this application: metric:<key> for the mean of a charm metric across units, or status:<status> for the number of units with a workload status (empty disables autoscaling)",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"update-status-hook-interval": map[string]interface{}{
+				"description": "How often to run the charm update-status hook for this application, in human-readable time format, overriding the model's update-status-hook-interval (range 10s-60m)",
+				"source":      "unset",
+				"type":        "string",
+			},
 		},
 		Series: "quantal",
 		EndpointBindings: map[string]string{
@@ -360,6 +530,91 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
+			"charm-update-policy": map[string]interface{}{
+				"description": "What to do when a new revision of the charm is published to the application's channel: manual (pin the current revision), notify (report it in status) or auto (upgrade automatically)",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"charm-update-window": map[string]interface{}{
+				"description": "When automatic charm upgrades may take place, in the form \"[<day>,...] HH:MM-HH:MM\" in UTC, e.g. \"sat,sun 02:00-04:00\" (empty means at any time)",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry": map[string]interface{}{
+				"description": "Whether failed hooks are automatically retried, overriding the model's automatically-retry-hooks",
+				"source":      "unset",
+				"type":        "bool",
+			},
+			"hook-retry-jitter": map[string]interface{}{
+				"description": "Whether the time to wait between retries of a failed hook is randomised",
+				"source":      "unset",
+				"type":        "bool",
+			},
+			"hook-retry-max-attempts": map[string]interface{}{
+				"description": "How many times a failed hook is automatically retried before giving up (0 means no limit)",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"hook-retry-max-delay": map[string]interface{}{
+				"description": "The longest time to wait between retries of a failed hook, in human-readable time format",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry-min-delay": map[string]interface{}{
+				"description": "How long to wait before first retrying a failed hook, in human-readable time format",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"placement-avoid": map[string]interface{}{
+				"description": "A comma separated list of applications whose units must never share a machine with units of this application",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"placement-max-units-per-machine": map[string]interface{}{
+				"description": "The largest number of units of this application that may share a machine (0 means no limit)",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"placement-spread": map[string]interface{}{
+				"description": "What the units of this application are spread across: machine, zone, or label:<key> for the values of a machine annotation (empty means no spreading)",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"autoscale-cooldown": map[string]interface{}{
+				"description": "How long the autoscaler waits after scaling this application before scaling it again, in human-readable time format (default 5m)",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"autoscale-max-units": map[string]interface{}{
+				"description": "The most units the autoscaler scales this application up to",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"autoscale-min-units": map[string]interface{}{
+				"description": "The fewest units the autoscaler scales this application down to, and never fewer than its min-units (default 1)",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"autoscale-scale-down-threshold": map[string]interface{}{
+				"description": "The measurement of the autoscale source below which the autoscaler removes a unit",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"autoscale-scale-up-threshold": map[string]interface{}{
+				"description": "The measurement of the autoscale source above which the autoscaler adds a unit",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"autoscale-source": map[string]interface{}{
+				"description": "What the autoscaler measures to scale this application: metric:<key> for the mean of a charm metric across units, or status:<status> for the number of units with a workload status (empty disables autoscaling)",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"update-status-hook-interval": map[string]interface{}{
+				"description": "How often to run the charm update-status hook for this application, in human-readable time format, overriding the model's update-status-hook-interval (range 10s-60m)",
+				"source":      "unset",
+				"type":        "string",
+			},
 		},
 		Series: "quantal",
 		EndpointBindings: map[string]string{
@@ -380,6 +635,91 @@ var getTests = []struct {
 				"source":      "default",
 				"type":        "bool",
 			},
+			"charm-update-policy": map[string]interface{}{
+				"description": "What to do when a new revision of the charm is published to the application's channel: manual (pin the current revision), notify (report it in status) or auto (upgrade automatically)",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"charm-update-window": map[string]interface{}{
+				"description": "When automatic charm upgrades may take place, in the form \"[<day>,...] HH:MM-HH:MM\" in UTC, e.g. \"sat,sun 02:00-04:00\" (empty means at any time)",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry": map[string]interface{}{
+				"description": "Whether failed hooks are automatically retried, overriding the model's automatically-retry-hooks",
+				"source":      "unset",
+				"type":        "bool",
+			},
+			"hook-retry-jitter": map[string]interface{}{
+				"description": "Whether the time to wait between retries of a failed hook is randomised",
+				"source":      "unset",
+				"type":        "bool",
+			},
+			"hook-retry-max-attempts": map[string]interface{}{
+				"description": "How many times a failed hook is automatically retried before giving up (0 means no limit)",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"hook-retry-max-delay": map[string]interface{}{
+				"description": "The longest time to wait between retries of a failed hook, in human-readable time format",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry-min-delay": map[string]interface{}{
+				"description": "How long to wait before first retrying a failed hook, in human-readable time format",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"placement-avoid": map[string]interface{}{
+				"description": "A comma separated list of applications whose units must never share a machine with units of this application",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"placement-max-units-per-machine": map[string]interface{}{
+				"description": "The largest number of units of this application that may share a machine (0 means no limit)",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"placement-spread": map[string]interface{}{
+				"description": "What the units of this application are spread across: machine, zone, or label:<key> for the values of a machine annotation (empty means no spreading)",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"autoscale-cooldown": map[string]interface{}{
+				"description": "How long the autoscaler waits after scaling this application before scaling it again, in human-readable time format (default 5m)",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"autoscale-max-units": map[string]interface{}{
+				"description": "The most units the autoscaler scales this application up to",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"autoscale-min-units": map[string]interface{}{
+				"description": "The fewest units the autoscaler scales this application down to, and never fewer than its min-units (default 1)",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"autoscale-scale-down-threshold": map[string]interface{}{
+				"description": "The measurement of the autoscale source below which the autoscaler removes a unit",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"autoscale-scale-up-threshold": map[string]interface{}{
+				"description": "The measurement of the autoscale source above which the autoscaler adds a unit",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"autoscale-source": map[string]interface{}{
+				"description": "What the autoscaler measures to scale this application: metric:<key> for the mean of a charm metric across units, or status:<status> for the number of units with a workload status (empty disables autoscaling)",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"update-status-hook-interval": map[string]interface{}{
+				"description": "How often to run the charm update-status hook for this application, in human-readable time format, overriding the model's update-status-hook-interval (range 10s-60m)",
+				"source":      "unset",
+				"type":        "string",
+			},
 		},
 		EndpointBindings: map[string]string{
 			"":                  network.AlphaSpaceName,
@@ -104,6 +104,7 @@ type mockApplication struct {
 	exposed     bool
 	remote      bool
 	agentTools  *tools.Tools
+	relations   []application.Relation
 }
 
 func (m *mockApplication) Name() string {
@@ -256,6 +257,9 @@ func (a *mockApplication) AgentTools() (*tools.Tools, error) {
 
 func (a *mockApplication) Relations() ([]application.Relation, error) {
 	a.MethodCall(a, "Relations")
+	if a.relations != nil {
+		return a.relations, nil
+	}
 	return []application.Relation{
 		&mockRelation{},
 	}, nil
@@ -306,6 +310,16 @@ type mockRemoteApplication struct {
 	offerUUID      string
 	offerURL       string
 	mac            *macaroon.Macaroon
+	consumerProxy  bool
+	relations      []application.Relation
+}
+
+func (m *mockRemoteApplication) IsConsumerProxy() bool {
+	return m.consumerProxy
+}
+
+func (m *mockRemoteApplication) Relations() ([]application.Relation, error) {
+	return m.relations, nil
 }
 
 func (m *mockRemoteApplication) Name() string {
@@ -372,6 +386,120 @@ type mockBackend struct {
 	controllers                map[string]crossmodel.ControllerInfo
 	machines                   map[string]*mockMachine
 	generation                 *mockGeneration
+	tokens                     map[names.Tag]string
+	macaroons                  map[names.Tag]*macaroon.Macaroon
+	ingressNetworks            map[string][]string
+	egressNetworks             map[string][]string
+	remoteRelationEvents       map[string]state.RemoteRelationEvent
+	removalPlans               map[string]state.ApplicationRemovalPlan
+	autoscaleDecisions         map[string][]application.AutoscaleDecision
+}
+
+func (m *mockBackend) AutoscaleDecisions(name string) ([]application.AutoscaleDecision, error) {
+	m.MethodCall(m, "AutoscaleDecisions", name)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.autoscaleDecisions[name], nil
+}
+
+type mockAutoscaleDecision struct {
+	time      time.Time
+	fromUnits int
+	toUnits   int
+	source    string
+	value     float64
+	reason    string
+	err       string
+}
+
+func (d mockAutoscaleDecision) Time() time.Time { return d.time }
+func (d mockAutoscaleDecision) FromUnits() int  { return d.fromUnits }
+func (d mockAutoscaleDecision) ToUnits() int    { return d.toUnits }
+func (d mockAutoscaleDecision) Source() string  { return d.source }
+func (d mockAutoscaleDecision) Value() float64  { return d.value }
+func (d mockAutoscaleDecision) Reason() string  { return d.reason }
+func (d mockAutoscaleDecision) Error() string   { return d.err }
+
+func (m *mockBackend) ApplicationsRemovalPlan(appNames ...string) ([]state.ApplicationRemovalPlan, error) {
+	m.MethodCall(m, "ApplicationsRemovalPlan", appNames)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	removing := set.NewStrings(appNames...)
+	plans := make([]state.ApplicationRemovalPlan, len(appNames))
+	for i, name := range appNames {
+		plan := m.removalPlans[name]
+		plan.Application = name
+		// Subordinates which are being removed are not orphaned.
+		plan.OrphanedApplications = nil
+		for _, sub := range m.removalPlans[name].OrphanedApplications {
+			if !removing.Contains(sub) {
+				plan.OrphanedApplications = append(plan.OrphanedApplications, sub)
+			}
+		}
+		plans[i] = plan
+	}
+	return plans, nil
+}
+
+func (m *mockBackend) GetToken(entity names.Tag) (string, error) {
+	m.MethodCall(m, "GetToken", entity)
+	token, ok := m.tokens[entity]
+	if !ok {
+		return "", errors.NotFoundf("token for %v", entity)
+	}
+	return token, nil
+}
+
+func (m *mockBackend) GetMacaroon(entity names.Tag) (*macaroon.Macaroon, error) {
+	m.MethodCall(m, "GetMacaroon", entity)
+	mac, ok := m.macaroons[entity]
+	if !ok {
+		return nil, errors.NotFoundf("macaroon for %v", entity)
+	}
+	return mac, nil
+}
+
+func (m *mockBackend) IngressNetworks(relationKey string) (state.RelationNetworks, error) {
+	m.MethodCall(m, "IngressNetworks", relationKey)
+	cidrs, ok := m.ingressNetworks[relationKey]
+	if !ok {
+		return nil, errors.NotFoundf("ingress networks for %v", relationKey)
+	}
+	return &mockRelationNetworks{key: relationKey, cidrs: cidrs}, nil
+}
+
+func (m *mockBackend) EgressNetworks(relationKey string) (state.RelationNetworks, error) {
+	m.MethodCall(m, "EgressNetworks", relationKey)
+	cidrs, ok := m.egressNetworks[relationKey]
+	if !ok {
+		return nil, errors.NotFoundf("egress networks for %v", relationKey)
+	}
+	return &mockRelationNetworks{key: relationKey, cidrs: cidrs}, nil
+}
+
+func (m *mockBackend) RemoteRelationEvent(relationKey string) (state.RemoteRelationEvent, error) {
+	m.MethodCall(m, "RemoteRelationEvent", relationKey)
+	event, ok := m.remoteRelationEvents[relationKey]
+	if !ok {
+		return state.RemoteRelationEvent{}, errors.NotFoundf("remote event for relation %q", relationKey)
+	}
+	return event, nil
+}
+
+type mockRelationNetworks struct {
+	state.RelationNetworks
+	key   string
+	cidrs []string
+}
+
+func (m *mockRelationNetworks) RelationKey() string {
+	return m.key
+}
+
+func (m *mockRelationNetworks) CIDRS() []string {
+	return m.cidrs
 }
 
 type mockFilesystemAccess struct {
@@ -568,6 +696,11 @@ func (m *mockMachine) IsLockedForSeriesUpgrade() (bool, error) {
 	return false, m.NextErr()
 }
 
+func (m *mockMachine) DestroyWhenUnused() error {
+	m.MethodCall(m, "DestroyWhenUnused")
+	return m.NextErr()
+}
+
 func (m *mockMachine) IsParentLockedForSeriesUpgrade() (bool, error) {
 	m.MethodCall(m, "IsParentLockedForSeriesUpgrade")
 	return false, m.NextErr()
@@ -619,6 +752,16 @@ func (m *mockBackend) Relation(id int) (application.Relation, error) {
 
 type mockOfferConnection struct {
 	application.OfferConnection
+	sourceModelUUID string
+	username        string
+}
+
+func (m *mockOfferConnection) SourceModelUUID() string {
+	return m.sourceModelUUID
+}
+
+func (m *mockOfferConnection) UserName() string {
+	return m.username
 }
 
 func (m *mockBackend) OfferConnectionForRelation(key string) (application.OfferConnection, error) {
@@ -784,19 +927,28 @@ type mockRelation struct {
 	application.Relation
 	jtesting.Stub
 
+	id              int
 	tag             names.Tag
+	endpoints       []state.Endpoint
 	status          status.Status
 	message         string
 	suspended       bool
 	suspendedReason string
 }
 
+func (r *mockRelation) Id() int {
+	return r.id
+}
+
 func (r *mockRelation) Tag() names.Tag {
 	return r.tag
 }
 
 func (r *mockRelation) Endpoints() []state.Endpoint {
 	r.MethodCall(r, "Endpoints")
+	if r.endpoints != nil {
+		return r.endpoints
+	}
 	return []state.Endpoint{{
 		ApplicationName: "postgresql",
 	}, {
@@ -1133,6 +1285,7 @@ func (m *mockCaasBroker) Version() (*version.Number, error) {
 
 type mockGeneration struct {
 	jtesting.Stub
+	charmURLs map[string]*charm.URL
 }
 
 func (g *mockGeneration) AssignApplication(appName string) error {
@@ -1140,6 +1293,17 @@ func (g *mockGeneration) AssignApplication(appName string) error {
 	return g.NextErr()
 }
 
+func (g *mockGeneration) CharmURL(appName string) (*charm.URL, bool) {
+	g.MethodCall(g, "CharmURL", appName)
+	curl, ok := g.charmURLs[appName]
+	return curl, ok
+}
+
+func (g *mockGeneration) SetCharm(appName string, cfg state.SetCharmConfig) error {
+	g.MethodCall(g, "SetCharm", appName, cfg)
+	return g.NextErr()
+}
+
 type mockRepo struct {
 	charmrepo.Interface
 	*jtesting.CallMocker
@@ -0,0 +1,284 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// RemoteRelationInfo isn't on the v12 API.
+func (u *APIv12) RemoteRelationInfo(_, _ struct{}) {}
+
+// RemoteRelationInfo returns information about the given cross model
+// relations, and the cross model relations of the given applications,
+// to help diagnose relations that are not progressing. The applications
+// may be consumed (SAAS) applications, the applications consuming an
+// offer, or offered applications.
+func (api *APIBase) RemoteRelationInfo(args params.RemoteRelationInfoArgs) (params.RemoteRelationInfoResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.RemoteRelationInfoResults{}, errors.Trace(err)
+	}
+	var results []params.RemoteRelationInfoResult
+	addResult := func(info *params.RemoteRelationInfo, err error) {
+		results = append(results, params.RemoteRelationInfoResult{
+			Result: info,
+			Error:  apiservererrors.ServerError(err),
+		})
+	}
+	for _, id := range args.RelationIds {
+		rel, err := api.backend.Relation(id)
+		if err != nil {
+			addResult(nil, err)
+			continue
+		}
+		addResult(api.remoteRelationInfo(rel))
+	}
+	for _, name := range args.Applications {
+		rels, err := api.applicationRelations(name)
+		if err != nil {
+			addResult(nil, err)
+			continue
+		}
+		found := false
+		for _, rel := range rels {
+			info, err := api.remoteRelationInfo(rel)
+			if errors.IsNotValid(err) {
+				// Not a cross model relation.
+				continue
+			}
+			found = true
+			addResult(info, err)
+		}
+		if !found {
+			addResult(nil, errors.NotFoundf("cross model relations for application %q", name))
+		}
+	}
+	return params.RemoteRelationInfoResults{Results: results}, nil
+}
+
+// applicationRelations returns the relations of the named local or remote
+// application.
+func (api *APIBase) applicationRelations(name string) ([]Relation, error) {
+	app, err := api.backend.Application(name)
+	if err == nil {
+		return app.Relations()
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	remoteApp, err := api.backend.RemoteApplication(name)
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("application %q", name)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return remoteApp.Relations()
+}
+
+// remoteRelationInfo returns information about the relation, or a NotValid
+// error if it is not a cross model relation.
+func (api *APIBase) remoteRelationInfo(rel Relation) (*params.RemoteRelationInfo, error) {
+	relationTag, ok := rel.Tag().(names.RelationTag)
+	if !ok {
+		return nil, errors.NotValidf("relation tag %q", rel.Tag())
+	}
+	var (
+		localEp, remoteEp state.Endpoint
+		remoteApp         RemoteApplication
+	)
+	for _, ep := range rel.Endpoints() {
+		app, err := api.backend.RemoteApplication(ep.ApplicationName)
+		if errors.IsNotFound(err) {
+			localEp = ep
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		remoteEp, remoteApp = ep, app
+	}
+	if remoteApp == nil {
+		return nil, errors.NewNotValid(nil, fmt.Sprintf("relation %q is not a cross model relation", relationTag.Id()))
+	}
+
+	info := &params.RemoteRelationInfo{
+		Id:              rel.Id(),
+		Key:             relationTag.Id(),
+		Offered:         remoteApp.IsConsumerProxy(),
+		SourceModelUUID: remoteApp.SourceModel().Id(),
+		Suspended:       rel.Suspended(),
+		SuspendedReason: rel.SuspendedReason(),
+		Local: params.RemoteRelationEndpoint{
+			ApplicationName: localEp.ApplicationName,
+			Endpoint:        localEp.Name,
+		},
+		Remote: params.RemoteRelationEndpoint{
+			ApplicationName: remoteEp.ApplicationName,
+			Endpoint:        remoteEp.Name,
+		},
+	}
+	var err error
+	if info.Token, err = api.remoteToken(relationTag); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if info.Local.Token, err = api.remoteToken(names.NewApplicationTag(localEp.ApplicationName)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if info.Remote.Token, err = api.remoteToken(names.NewApplicationTag(remoteEp.ApplicationName)); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if info.Offered {
+		conn, err := api.backend.OfferConnectionForRelation(relationTag.Id())
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		if err == nil {
+			info.SourceModelUUID = conn.SourceModelUUID()
+			info.Username = conn.UserName()
+		}
+	} else {
+		// The macaroon used to make changes to the relation in the
+		// offering model is only held by the consuming model.
+		mac, err := api.backend.GetMacaroon(relationTag)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		if err == nil && mac != nil {
+			if expiry, ok := checkers.ExpiryTime(nil, mac.Caveats()); ok {
+				info.MacaroonExpiry = &expiry
+			}
+		}
+	}
+
+	if info.IngressNetworks, err = relationCIDRs(api.backend.IngressNetworks(relationTag.Id())); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if info.EgressNetworks, err = relationCIDRs(api.backend.EgressNetworks(relationTag.Id())); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	event, err := api.backend.RemoteRelationEvent(relationTag.Id())
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if err == nil {
+		info.LastEvent = &params.RemoteRelationEvent{
+			Received:                   event.Received,
+			Life:                       event.Life,
+			Suspended:                  event.Suspended,
+			ChangedUnits:               event.ChangedUnits,
+			DepartedUnits:              event.DepartedUnits,
+			ApplicationSettingsChanged: event.ApplicationSettingsChanged,
+			Error:                      event.Error,
+		}
+	}
+
+	if err := api.localRelationData(rel, &info.Local); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := api.remoteRelationData(rel, &info.Remote); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return info, nil
+}
+
+// remoteToken returns the token identifying the entity to the other model
+// of a cross model relation, or "" if it has none.
+func (api *APIBase) remoteToken(tag names.Tag) (string, error) {
+	token, err := api.backend.GetToken(tag)
+	if errors.IsNotFound(err) {
+		return "", nil
+	}
+	return token, errors.Trace(err)
+}
+
+func relationCIDRs(networks state.RelationNetworks, err error) ([]string, error) {
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return networks.CIDRS(), nil
+}
+
+// localRelationData fills in the relation settings of the local
+// application and its units.
+func (api *APIBase) localRelationData(rel Relation, ep *params.RemoteRelationEndpoint) error {
+	appSettings, err := rel.ApplicationSettings(ep.ApplicationName)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	ep.ApplicationData = appSettings
+	app, err := api.backend.Application(ep.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ep.UnitRelationData = make(map[string]params.RelationData)
+	for _, u := range units {
+		ru, err := rel.Unit(u.Name())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		urd, err := relationUnitData(ru)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ep.UnitRelationData[u.Name()] = urd
+	}
+	return nil
+}
+
+// remoteRelationData fills in the relation settings of the remote
+// application and its units, as last received from the other model.
+func (api *APIBase) remoteRelationData(rel Relation, ep *params.RemoteRelationEndpoint) error {
+	appSettings, err := rel.ApplicationSettings(ep.ApplicationName)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	ep.ApplicationData = appSettings
+	rus, err := rel.AllRemoteUnits(ep.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ep.UnitRelationData = make(map[string]params.RelationData)
+	for _, ru := range rus {
+		urd, err := relationUnitData(ru)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ep.UnitRelationData[ru.UnitName()] = urd
+	}
+	return nil
+}
+
+func relationUnitData(ru RelationUnit) (params.RelationData, error) {
+	inScope, err := ru.InScope()
+	if err != nil {
+		return params.RelationData{}, errors.Trace(err)
+	}
+	urd := params.RelationData{
+		InScope: inScope,
+	}
+	if inScope {
+		settings, err := ru.Settings()
+		if err != nil && !errors.IsNotFound(err) {
+			return params.RelationData{}, errors.Trace(err)
+		}
+		if err == nil {
+			urd.UnitData = settings
+		}
+	}
+	return urd, nil
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
 )
 
 // TrustConfigOptionName is the option name used to set trust level in application configuration.
@@ -19,6 +21,91 @@ var trustFields = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.JujuGroup,
 	},
+	application.AutoscaleCooldownOptionName: {
+		Description: "How long the autoscaler waits after scaling this application before scaling it again, in human-readable time format (default 5m)",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.AutoscaleMaxUnitsOptionName: {
+		Description: "The most units the autoscaler scales this application up to",
+		Type:        environschema.Tint,
+		Group:       environschema.JujuGroup,
+	},
+	application.AutoscaleMinUnitsOptionName: {
+		Description: "The fewest units the autoscaler scales this application down to, and never fewer than its min-units (default 1)",
+		Type:        environschema.Tint,
+		Group:       environschema.JujuGroup,
+	},
+	application.AutoscaleScaleDownThresholdOptionName: {
+		Description: "The measurement of the autoscale source below which the autoscaler removes a unit",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.AutoscaleScaleUpThresholdOptionName: {
+		Description: "The measurement of the autoscale source above which the autoscaler adds a unit",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.AutoscaleSourceOptionName: {
+		Description: "What the autoscaler measures to scale this application: metric:<key> for the mean of a charm metric across units, or status:<status> for the number of units with a workload status (empty disables autoscaling)",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.CharmUpdatePolicyOptionName: {
+		Description: "What to do when a new revision of the charm is published to the application's channel: manual (pin the current revision), notify (report it in status) or auto (upgrade automatically)",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.CharmUpdateWindowOptionName: {
+		Description: "When automatic charm upgrades may take place, in the form \"[<day>,...] HH:MM-HH:MM\" in UTC, e.g. \"sat,sun 02:00-04:00\" (empty means at any time)",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.HookRetryOptionName: {
+		Description: "Whether failed hooks are automatically retried, overriding the model's automatically-retry-hooks",
+		Type:        environschema.Tbool,
+		Group:       environschema.JujuGroup,
+	},
+	application.HookRetryMaxAttemptsOptionName: {
+		Description: "How many times a failed hook is automatically retried before giving up (0 means no limit)",
+		Type:        environschema.Tint,
+		Group:       environschema.JujuGroup,
+	},
+	application.HookRetryMinDelayOptionName: {
+		Description: "How long to wait before first retrying a failed hook, in human-readable time format",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.HookRetryMaxDelayOptionName: {
+		Description: "The longest time to wait between retries of a failed hook, in human-readable time format",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.HookRetryJitterOptionName: {
+		Description: "Whether the time to wait between retries of a failed hook is randomised",
+		Type:        environschema.Tbool,
+		Group:       environschema.JujuGroup,
+	},
+	application.PlacementSpreadOptionName: {
+		Description: "What the units of this application are spread across: machine, zone, or label:<key> for the values of a machine annotation (empty means no spreading)",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.PlacementMaxUnitsPerMachineOptionName: {
+		Description: "The largest number of units of this application that may share a machine (0 means no limit)",
+		Type:        environschema.Tint,
+		Group:       environschema.JujuGroup,
+	},
+	application.PlacementAvoidOptionName: {
+		Description: "A comma separated list of applications whose units must never share a machine with units of this application",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.UpdateStatusHookIntervalOptionName: {
+		Description: "How often to run the charm update-status hook for this application, in human-readable time format, overriding the model's update-status-hook-interval (range 10s-60m)",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
 }
 
 var trustDefaults = schema.Defaults{
@@ -20,6 +20,7 @@ import (
 	jujucrossmodel "github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
 )
 
@@ -40,6 +41,11 @@ type OffersAPIV2 struct {
 	*OffersAPI
 }
 
+// OffersAPIV3 implements the cross model interface V3.
+type OffersAPIV3 struct {
+	*OffersAPIV2
+}
+
 // createAPI returns a new application offers OffersAPI facade.
 func createOffersAPI(
 	getApplicationOffers func(interface{}) jujucrossmodel.ApplicationOffers,
@@ -72,10 +78,11 @@ func createOffersAPI(
 	return api, nil
 }
 
-// NewOffersAPIV2 returns a new application offers OffersAPIV2 facade.
-func NewOffersAPI(ctx facade.Context) (*OffersAPI, error) {
-	environFromModel := func(modelUUID string) (environs.Environ, error) {
-		st, err := ctx.StatePool().Get(modelUUID)
+// environFromModel returns a function which gets the environ
+// of a model in the given pool.
+func environFromModel(pool *state.StatePool) environFromModelFunc {
+	return func(modelUUID string) (environs.Environ, error) {
+		st, err := pool.Get(modelUUID)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -91,7 +98,10 @@ func NewOffersAPI(ctx facade.Context) (*OffersAPI, error) {
 		}
 		return env, nil
 	}
+}
 
+// NewOffersAPIV2 returns a new application offers OffersAPIV2 facade.
+func NewOffersAPI(ctx facade.Context) (*OffersAPI, error) {
 	st := ctx.State()
 	getControllerInfo := func() ([]string, string, error) {
 		return common.StateControllerInfo(st)
@@ -100,7 +110,7 @@ func NewOffersAPI(ctx facade.Context) (*OffersAPI, error) {
 	authContext := ctx.Resources().Get("offerAccessAuthContext").(common.ValueResource).Value
 	return createOffersAPI(
 		GetApplicationOffers,
-		environFromModel,
+		environFromModel(ctx.StatePool()),
 		getControllerInfo,
 		GetStateAccess(st),
 		GetStatePool(ctx.StatePool()),
@@ -119,6 +129,15 @@ func NewOffersAPIV2(ctx facade.Context) (*OffersAPIV2, error) {
 	return &OffersAPIV2{OffersAPI: apiV1}, nil
 }
 
+// NewOffersAPIV3 returns a new application offers OffersAPIV3 facade.
+func NewOffersAPIV3(ctx facade.Context) (*OffersAPIV3, error) {
+	apiV2, err := NewOffersAPIV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &OffersAPIV3{OffersAPIV2: apiV2}, nil
+}
+
 // Offer makes application endpoints available for consumption at a specified URL.
 func (api *OffersAPI) Offer(all params.AddApplicationOffers) (params.ErrorResults, error) {
 	result := make([]params.ErrorResult, len(all.Offers))
@@ -244,28 +263,8 @@ func (api *OffersAPI) modifyOneOfferAccess(modelUUID string, isControllerAdmin b
 	}
 	offerTag := names.NewApplicationOfferTag(url.ApplicationName)
 
-	canModifyOffer := isControllerAdmin
-	if !canModifyOffer {
-		if canModifyOffer, err = api.Authorizer.HasPermission(permission.AdminAccess, backend.ModelTag()); err != nil {
-			return errors.Trace(err)
-		}
-	}
-
-	if !canModifyOffer {
-		apiUser := api.Authorizer.GetAuthTag().(names.UserTag)
-		offer, err := backend.ApplicationOffer(offerTag.Id())
-		if err != nil {
-			return apiservererrors.ErrPerm
-		}
-		access, err := backend.GetOfferAccess(offer.OfferUUID, apiUser)
-		if err != nil && !errors.IsNotFound(err) {
-			return errors.Trace(err)
-		} else if err == nil {
-			canModifyOffer = access == permission.AdminAccess
-		}
-	}
-	if !canModifyOffer {
-		return apiservererrors.ErrPerm
+	if err := api.checkOfferAdmin(backend, isControllerAdmin, offerTag.Id()); err != nil {
+		return errors.Trace(err)
 	}
 
 	targetUserTag, err := names.ParseUserTag(arg.UserTag)
@@ -275,6 +274,34 @@ func (api *OffersAPI) modifyOneOfferAccess(modelUUID string, isControllerAdmin b
 	return api.changeOfferAccess(backend, offerTag, targetUserTag, arg.Action, offerAccess)
 }
 
+// checkOfferAdmin ensures that the logged in user is a controller or
+// model admin, or an admin of the named offer.
+func (api *OffersAPI) checkOfferAdmin(backend Backend, isControllerAdmin bool, offerName string) error {
+	if isControllerAdmin {
+		return nil
+	}
+	isModelAdmin, err := api.Authorizer.HasPermission(permission.AdminAccess, backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isModelAdmin {
+		return nil
+	}
+	apiUser := api.Authorizer.GetAuthTag().(names.UserTag)
+	offer, err := backend.ApplicationOffer(offerName)
+	if err != nil {
+		return apiservererrors.ErrPerm
+	}
+	access, err := backend.GetOfferAccess(offer.OfferUUID, apiUser)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if access != permission.AdminAccess {
+		return apiservererrors.ErrPerm
+	}
+	return nil
+}
+
 // changeOfferAccess performs the requested access grant or revoke action for the
 // specified user on the specified application offer.
 func (api *OffersAPI) changeOfferAccess(
@@ -58,6 +58,7 @@ func (s *baseSuite) SetUpTest(c *gc.C) {
 		spaces:            make(map[string]applicationoffers.Space),
 		relations:         make(map[string]crossmodel.Relation),
 		relationNetworks:  &mockRelationNetworks{},
+		offerTokens:       make(map[string]*mockOfferToken),
 	}
 	s.mockStatePool = &mockStatePool{map[string]applicationoffers.Backend{s.mockState.modelUUID: s.mockState}}
 }
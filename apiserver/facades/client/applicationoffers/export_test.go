@@ -3,6 +3,18 @@
 
 package applicationoffers
 
+import (
+	"gopkg.in/macaroon-bakery.v2/bakery"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
 var (
 	CreateOffersAPI = createOffersAPI
 )
+
+// RedeemOfferTokenForTest redeems an offer token using the given API's
+// auth context.
+func RedeemOfferTokenForTest(api *OffersAPI, backend Backend, req params.RedeemOfferTokenRequest) (*params.ConsumeOfferDetails, error) {
+	return api.redeemOfferToken(backend, api.authContext, req, bakery.LatestVersion)
+}
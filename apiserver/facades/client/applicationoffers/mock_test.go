@@ -6,6 +6,7 @@ package applicationoffers_test
 import (
 	stdcontet "context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/juju/charm/v7"
@@ -307,6 +308,7 @@ type mockState struct {
 	connections       []applicationoffers.OfferConnection
 	accessPerms       map[offerAccess]permission.Access
 	relationNetworks  state.RelationNetworks
+	offerTokens       map[string]*mockOfferToken
 }
 
 func (m *mockState) GetAddressAndCertGetter() common.AddressAndCertGetter {
@@ -469,6 +471,113 @@ func (m *mockState) AllSpaceInfos() (network.SpaceInfos, error) {
 	return nil, nil
 }
 
+func (m *mockState) AddOfferToken(args state.AddOfferTokenArgs) (applicationoffers.OfferToken, string, error) {
+	if _, ok := m.applicationOffers[args.OfferName]; !ok {
+		return nil, "", errors.NotFoundf("application offer %q", args.OfferName)
+	}
+	id := fmt.Sprintf("token%d", len(m.offerTokens))
+	token := &mockOfferToken{
+		id:        id,
+		offerName: args.OfferName,
+		createdBy: args.CreatedBy.Id(),
+		expires:   args.Expires,
+		maxUses:   args.MaxUses,
+	}
+	m.offerTokens[id] = token
+	return token, "secret-" + id, nil
+}
+
+func (m *mockState) OfferToken(id string) (applicationoffers.OfferToken, error) {
+	token, ok := m.offerTokens[id]
+	if !ok {
+		return nil, errors.NotFoundf("offer token %q", id)
+	}
+	return token, nil
+}
+
+func (m *mockState) OfferTokens(offerName string) ([]applicationoffers.OfferToken, error) {
+	var ids []string
+	for id := range m.offerTokens {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var result []applicationoffers.OfferToken
+	for _, id := range ids {
+		if token := m.offerTokens[id]; token.offerName == offerName {
+			result = append(result, token)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockState) RedeemOfferToken(id, secretKey string) (applicationoffers.OfferToken, error) {
+	token, ok := m.offerTokens[id]
+	if !ok || secretKey != "secret-"+id {
+		return nil, errors.Unauthorizedf("invalid offer token")
+	}
+	if token.revoked {
+		return nil, errors.Forbiddenf("offer token %q has been revoked", id)
+	}
+	token.uses++
+	return token, nil
+}
+
+func (m *mockState) RevokeOfferToken(id string) error {
+	token, ok := m.offerTokens[id]
+	if !ok {
+		return errors.NotFoundf("offer token %q", id)
+	}
+	token.revoked = true
+	return nil
+}
+
+type mockOfferToken struct {
+	id        string
+	offerName string
+	createdBy string
+	created   time.Time
+	expires   time.Time
+	maxUses   int
+	uses      int
+	revoked   bool
+}
+
+func (m *mockOfferToken) Id() string {
+	return m.id
+}
+
+func (m *mockOfferToken) OfferName() string {
+	return m.offerName
+}
+
+func (m *mockOfferToken) CreatedBy() string {
+	return m.createdBy
+}
+
+func (m *mockOfferToken) DateCreated() time.Time {
+	return m.created
+}
+
+func (m *mockOfferToken) Expires() time.Time {
+	return m.expires
+}
+
+func (m *mockOfferToken) MaxUses() int {
+	return m.maxUses
+}
+
+func (m *mockOfferToken) Uses() int {
+	return m.uses
+}
+
+func (m *mockOfferToken) Revoked() bool {
+	return m.revoked
+}
+
+func (m *mockOfferToken) UserTag() names.UserTag {
+	return names.NewUserTag("token-" + m.id + "@offer-token")
+}
+
 type mockStatePool struct {
 	st map[string]applicationoffers.Backend
 }
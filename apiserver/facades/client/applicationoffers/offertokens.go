@@ -0,0 +1,233 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationoffers
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+
+	"github.com/juju/juju/apiserver/common"
+	commoncrossmodel "github.com/juju/juju/apiserver/common/crossmodel"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	jujucrossmodel "github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/state"
+)
+
+// CreateOfferTokens creates tokens which allow the specified offers to be
+// consumed by users who are unknown to this controller. The secret key
+// needed to redeem each token is only returned here.
+func (api *OffersAPIV3) CreateOfferTokens(args params.CreateOfferTokensArgs) (params.CreateOfferTokenResults, error) {
+	results := make([]params.CreateOfferTokenResult, len(args.Args))
+	offerURLs := make([]string, len(args.Args))
+	for i, arg := range args.Args {
+		offerURLs[i] = arg.OfferURL
+	}
+	err := api.forEachAdminOffer(offerURLs, func(i int, backend Backend, offerURL string) error {
+		url, err := jujucrossmodel.ParseOfferURL(offerURL)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		token, secretKey, err := backend.AddOfferToken(state.AddOfferTokenArgs{
+			OfferName: url.ApplicationName,
+			CreatedBy: api.Authorizer.GetAuthTag().(names.UserTag),
+			Expires:   args.Args[i].Expires,
+			MaxUses:   args.Args[i].MaxUses,
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		results[i] = params.CreateOfferTokenResult{
+			Id:        token.Id(),
+			SecretKey: secretKey,
+			ModelTag:  backend.ModelTag().String(),
+			OfferURL:  offerURL,
+		}
+		return nil
+	}, func(i int, err error) {
+		results[i].Error = apiservererrors.ServerError(err)
+	})
+	return params.CreateOfferTokenResults{Results: results}, errors.Trace(err)
+}
+
+// ListOfferTokens returns the tokens for the specified offers.
+func (api *OffersAPIV3) ListOfferTokens(args params.OfferURLs) (params.OfferTokensResults, error) {
+	results := make([]params.OfferTokensResult, len(args.OfferURLs))
+	err := api.forEachAdminOffer(args.OfferURLs, func(i int, backend Backend, offerURL string) error {
+		url, err := jujucrossmodel.ParseOfferURL(offerURL)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		tokens, err := backend.OfferTokens(url.ApplicationName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, token := range tokens {
+			results[i].Tokens = append(results[i].Tokens, params.OfferToken{
+				Id:          token.Id(),
+				OfferURL:    offerURL,
+				CreatedBy:   token.CreatedBy(),
+				DateCreated: token.DateCreated(),
+				Expires:     token.Expires(),
+				MaxUses:     token.MaxUses(),
+				Uses:        token.Uses(),
+				Revoked:     token.Revoked(),
+			})
+		}
+		return nil
+	}, func(i int, err error) {
+		results[i].Error = apiservererrors.ServerError(err)
+	})
+	return params.OfferTokensResults{Results: results}, errors.Trace(err)
+}
+
+// RevokeOfferTokens revokes the specified offer tokens so they can no
+// longer be redeemed. Relations made by consuming the offers with the
+// tokens are suspended.
+func (api *OffersAPIV3) RevokeOfferTokens(args params.OfferTokenArgs) (params.ErrorResults, error) {
+	results := make([]params.ErrorResult, len(args.Args))
+	offerURLs := make([]string, len(args.Args))
+	for i, arg := range args.Args {
+		offerURLs[i] = arg.OfferURL
+	}
+	err := api.forEachAdminOffer(offerURLs, func(i int, backend Backend, offerURL string) error {
+		url, err := jujucrossmodel.ParseOfferURL(offerURL)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		id := args.Args[i].Id
+		token, err := backend.OfferToken(id)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if token.OfferName() != url.ApplicationName {
+			return errors.NotFoundf("token %q for offer %q", id, offerURL)
+		}
+		return errors.Trace(backend.RevokeOfferToken(id))
+	}, func(i int, err error) {
+		results[i].Error = apiservererrors.ServerError(err)
+	})
+	return params.ErrorResults{Results: results}, errors.Trace(err)
+}
+
+// forEachAdminOffer calls f with the backend of the model hosting each of
+// the offers with the given URLs, and the offer's full URL, having checked
+// that the logged in user is an admin of the offer. Any error for an offer
+// is passed to setError.
+func (api *OffersAPIV3) forEachAdminOffer(
+	offerURLs []string,
+	f func(i int, backend Backend, offerURL string) error,
+	setError func(i int, err error),
+) error {
+	isControllerAdmin, err := api.Authorizer.HasPermission(permission.SuperuserAccess, api.ControllerModel.ControllerTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	models, err := api.getModelsFromOffers(offerURLs...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	oneOffer := func(i int) error {
+		if models[i].err != nil {
+			return models[i].err
+		}
+		url, err := jujucrossmodel.ParseOfferURL(offerURLs[i])
+		if err != nil {
+			return errors.Trace(err)
+		}
+		backend, releaser, err := api.StatePool.Get(models[i].model.UUID())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer releaser()
+
+		if err := api.checkOfferAdmin(backend, isControllerAdmin, url.ApplicationName); err != nil {
+			return errors.Trace(err)
+		}
+		model := models[i].model
+		offerURL := jujucrossmodel.MakeURL(model.Owner().Name(), model.Name(), url.ApplicationName, "")
+		return f(i, backend, offerURL)
+	}
+	for i := range offerURLs {
+		if err := oneOffer(i); err != nil {
+			setError(i, err)
+		}
+	}
+	return nil
+}
+
+// RedeemOfferToken redeems the offer token with the given id and secret
+// key, returning the details needed to consume the offer as the user of
+// the token. It is used to serve requests from users who are unknown to
+// this controller, so there is no authenticated user.
+func RedeemOfferToken(
+	ctx context.Context,
+	pool *state.StatePool,
+	st *state.State,
+	authContext *commoncrossmodel.AuthContext,
+	req params.RedeemOfferTokenRequest,
+	bakeryVersion bakery.Version,
+) (*params.ConsumeOfferDetails, error) {
+	backend := GetStateAccess(st)
+	api := &BaseAPI{
+		ctx:             ctx,
+		ControllerModel: backend,
+		getEnviron:      environFromModel(pool),
+		getControllerInfo: func() ([]string, string, error) {
+			return common.StateControllerInfo(st)
+		},
+	}
+	return api.redeemOfferToken(backend, authContext, req, bakeryVersion)
+}
+
+func (api *BaseAPI) redeemOfferToken(
+	backend Backend,
+	authContext *commoncrossmodel.AuthContext,
+	req params.RedeemOfferTokenRequest,
+	bakeryVersion bakery.Version,
+) (*params.ConsumeOfferDetails, error) {
+	token, err := backend.RedeemOfferToken(req.TokenId, req.SecretKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offer, err := backend.ApplicationOffer(token.OfferName())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offerDetails, _, err := api.makeOfferParams(backend, offer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := backend.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offerDetails.OfferURL = jujucrossmodel.MakeURL(model.Owner().Name(), model.Name(), offer.OfferName, "")
+	offerDetails.Users = []params.OfferUserDetails{{
+		UserName: token.UserTag().Id(),
+		Access:   string(permission.ConsumeAccess),
+	}}
+
+	addrs, caCert, err := api.getControllerInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offerMacaroon, err := authContext.CreateConsumeOfferMacaroon(api.ctx, offerDetails, token.UserTag().Id(), bakeryVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.ConsumeOfferDetails{
+		Offer:    offerDetails,
+		Macaroon: offerMacaroon.M(),
+		ControllerInfo: &params.ExternalControllerInfo{
+			ControllerTag: backend.ControllerTag().String(),
+			Addrs:         addrs,
+			CACert:        caCert,
+		},
+	}, nil
+}
@@ -0,0 +1,228 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationoffers_test
+
+import (
+	"time"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/crossmodel"
+	"github.com/juju/juju/apiserver/facades/client/applicationoffers"
+	"github.com/juju/juju/apiserver/params"
+	jujucrossmodel "github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type offerTokensSuite struct {
+	baseSuite
+	api *applicationoffers.OffersAPIV3
+}
+
+var _ = gc.Suite(&offerTokensSuite{})
+
+func (s *offerTokensSuite) SetUpTest(c *gc.C) {
+	s.baseSuite.SetUpTest(c)
+	s.bakery = &mockBakeryService{caveats: make(map[string][]checkers.Caveat)}
+	getApplicationOffers := func(st interface{}) jujucrossmodel.ApplicationOffers {
+		return &mockApplicationOffers{st: st.(*mockState)}
+	}
+	resources := common.NewResources()
+	_ = resources.RegisterNamed("dataDir", common.StringResource(c.MkDir()))
+	getEnviron := func(modelUUID string) (environs.Environ, error) {
+		return s.env, nil
+	}
+	var err error
+	s.authContext, err = crossmodel.NewAuthContext(&mockCommonStatePool{s.mockStatePool}, bakery.MustGenerateKey(), s.bakery)
+	c.Assert(err, jc.ErrorIsNil)
+	apiV1, err := applicationoffers.CreateOffersAPI(
+		getApplicationOffers, getEnviron, getFakeControllerInfo,
+		s.mockState, s.mockStatePool, s.authorizer, resources, s.authContext,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = &applicationoffers.OffersAPIV3{OffersAPIV2: &applicationoffers.OffersAPIV2{OffersAPI: apiV1}}
+
+	s.mockState.model = &mockModel{uuid: testing.ModelTag.Id(), name: "prod", owner: "fred", modelType: state.ModelTypeIAAS}
+	s.mockState.applicationOffers["hosted-mysql"] = jujucrossmodel.ApplicationOffer{
+		ApplicationName:        "mysql",
+		ApplicationDescription: "a database",
+		OfferName:              "hosted-mysql",
+		OfferUUID:              "hosted-mysql-uuid",
+		Endpoints: map[string]charm.Relation{
+			"server": {Name: "database", Interface: "mysql", Role: "provider", Scope: "global"}},
+	}
+	s.mockState.applications = map[string]crossmodel.Application{
+		"mysql": &mockApplication{
+			name:     "mysql",
+			charm:    &mockCharm{meta: &charm.Meta{Description: "A pretty popular database"}},
+			bindings: map[string]string{"database": "myspace"},
+		},
+	}
+	s.mockState.spaces["myspace"] = &mockSpace{
+		name:       "myspace",
+		providerId: "juju-space-myspace",
+		subnets: []applicationoffers.Subnet{
+			&mockSubnet{cidr: "4.3.2.0/24", providerId: "juju-subnet-1", zones: []string{"az1"}},
+		},
+	}
+	s.env.spaceInfo = &environs.ProviderSpaceInfo{
+		SpaceInfo: network.SpaceInfo{
+			ID:         "1",
+			Name:       "myspace",
+			ProviderId: "juju-space-myspace",
+			Subnets: []network.SubnetInfo{{
+				CIDR:              "4.3.2.0/24",
+				ProviderId:        "juju-subnet-1",
+				AvailabilityZones: []string{"az1"},
+			}},
+		},
+	}
+	s.authorizer.Tag = names.NewUserTag("admin")
+}
+
+func (s *offerTokensSuite) TestCreateOfferTokens(c *gc.C) {
+	expires := time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC)
+	results, err := s.api.CreateOfferTokens(params.CreateOfferTokensArgs{
+		Args: []params.CreateOfferTokenArg{
+			{OfferURL: "fred/prod.hosted-mysql", Expires: expires, MaxUses: 3},
+			{OfferURL: "fred/prod.unknown", Expires: expires},
+			{OfferURL: "fred/other.hosted-mysql", Expires: expires},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.CreateOfferTokenResult{{
+		Id:        "token0",
+		SecretKey: "secret-token0",
+		ModelTag:  testing.ModelTag.String(),
+		OfferURL:  "fred/prod.hosted-mysql",
+	}, {
+		Error: &params.Error{Message: `application offer "unknown" not found`, Code: params.CodeNotFound},
+	}, {
+		Error: &params.Error{Message: `model "fred/other" not found`, Code: params.CodeNotFound},
+	}})
+	token := s.mockState.offerTokens["token0"]
+	c.Assert(token.offerName, gc.Equals, "hosted-mysql")
+	c.Assert(token.createdBy, gc.Equals, "admin")
+	c.Assert(token.expires, gc.Equals, expires)
+	c.Assert(token.maxUses, gc.Equals, 3)
+}
+
+func (s *offerTokensSuite) TestCreateOfferTokensPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary")
+	results, err := s.api.CreateOfferTokens(params.CreateOfferTokensArgs{
+		Args: []params.CreateOfferTokenArg{{OfferURL: "fred/prod.hosted-mysql", Expires: time.Now().Add(time.Hour)}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	c.Assert(s.mockState.offerTokens, gc.HasLen, 0)
+}
+
+func (s *offerTokensSuite) TestListOfferTokens(c *gc.C) {
+	expires := time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC)
+	s.mockState.offerTokens["token0"] = &mockOfferToken{
+		id: "token0", offerName: "hosted-mysql", createdBy: "admin", expires: expires, maxUses: 2, uses: 1,
+	}
+	s.mockState.offerTokens["token1"] = &mockOfferToken{
+		id: "token1", offerName: "hosted-mysql", createdBy: "admin", expires: expires, revoked: true,
+	}
+	s.mockState.offerTokens["token2"] = &mockOfferToken{id: "token2", offerName: "other"}
+
+	results, err := s.api.ListOfferTokens(params.OfferURLs{OfferURLs: []string{"fred/prod.hosted-mysql"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.OfferTokensResult{{
+		Tokens: []params.OfferToken{{
+			Id:        "token0",
+			OfferURL:  "fred/prod.hosted-mysql",
+			CreatedBy: "admin",
+			Expires:   expires,
+			MaxUses:   2,
+			Uses:      1,
+		}, {
+			Id:        "token1",
+			OfferURL:  "fred/prod.hosted-mysql",
+			CreatedBy: "admin",
+			Expires:   expires,
+			Revoked:   true,
+		}},
+	}})
+}
+
+func (s *offerTokensSuite) TestRevokeOfferTokens(c *gc.C) {
+	s.mockState.offerTokens["token0"] = &mockOfferToken{id: "token0", offerName: "hosted-mysql"}
+	s.mockState.offerTokens["token1"] = &mockOfferToken{id: "token1", offerName: "other"}
+
+	results, err := s.api.RevokeOfferTokens(params.OfferTokenArgs{
+		Args: []params.OfferTokenArg{
+			{OfferURL: "fred/prod.hosted-mysql", Id: "token0"},
+			{OfferURL: "fred/prod.hosted-mysql", Id: "token1"},
+			{OfferURL: "fred/prod.hosted-mysql", Id: "missing"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: `token "token1" for offer "fred/prod.hosted-mysql" not found`, Code: params.CodeNotFound}},
+		{Error: &params.Error{Message: `offer token "missing" not found`, Code: params.CodeNotFound}},
+	})
+	c.Assert(s.mockState.offerTokens["token0"].revoked, jc.IsTrue)
+	c.Assert(s.mockState.offerTokens["token1"].revoked, jc.IsFalse)
+}
+
+func (s *offerTokensSuite) TestRedeemOfferToken(c *gc.C) {
+	s.mockState.offerTokens["token0"] = &mockOfferToken{id: "token0", offerName: "hosted-mysql"}
+
+	details, err := applicationoffers.RedeemOfferTokenForTest(s.api.OffersAPI, s.mockState, params.RedeemOfferTokenRequest{
+		TokenId:   "token0",
+		SecretKey: "secret-token0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(details.Offer, jc.DeepEquals, &params.ApplicationOfferDetails{
+		SourceModelTag:         testing.ModelTag.String(),
+		OfferURL:               "fred/prod.hosted-mysql",
+		OfferName:              "hosted-mysql",
+		OfferUUID:              "hosted-mysql-uuid",
+		ApplicationDescription: "a database",
+		Endpoints:              []params.RemoteEndpoint{{Name: "server", Role: "provider", Interface: "mysql"}},
+		Bindings:               map[string]string{"database": "myspace"},
+		Spaces: []params.RemoteSpace{{
+			Name:       "myspace",
+			ProviderId: "juju-space-myspace",
+			Subnets:    []params.Subnet{{CIDR: "4.3.2.0/24", ProviderId: "juju-subnet-1", Zones: []string{"az1"}}},
+		}},
+		Users: []params.OfferUserDetails{
+			{UserName: "token-token0@offer-token", Access: "consume"},
+		},
+	})
+	c.Assert(details.ControllerInfo, jc.DeepEquals, &params.ExternalControllerInfo{
+		ControllerTag: testing.ControllerTag.String(),
+		Addrs:         []string{"192.168.1.1:17070"},
+		CACert:        testing.CACert,
+	})
+	cav := s.bakery.caveats[string(details.Macaroon.Id())]
+	c.Assert(cav, gc.HasLen, 4)
+	c.Check(cav[3].Condition, gc.Equals, "declared username token-token0@offer-token")
+	c.Assert(s.mockState.offerTokens["token0"].uses, gc.Equals, 1)
+}
+
+func (s *offerTokensSuite) TestRedeemOfferTokenInvalid(c *gc.C) {
+	s.mockState.offerTokens["token0"] = &mockOfferToken{id: "token0", offerName: "hosted-mysql"}
+
+	_, err := applicationoffers.RedeemOfferTokenForTest(s.api.OffersAPI, s.mockState, params.RedeemOfferTokenRequest{
+		TokenId:   "token0",
+		SecretKey: "wrong",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+	c.Assert(s.mockState.offerTokens["token0"].uses, gc.Equals, 0)
+}
@@ -4,6 +4,8 @@
 package applicationoffers
 
 import (
+	"time"
+
 	"github.com/juju/charm/v7"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -68,6 +70,12 @@ type Backend interface {
 	RemoveOfferAccess(offer names.ApplicationOfferTag, user names.UserTag) error
 	GetOfferUsers(offerUUID string) (map[string]permission.Access, error)
 
+	AddOfferToken(args state.AddOfferTokenArgs) (OfferToken, string, error)
+	OfferToken(id string) (OfferToken, error)
+	OfferTokens(offerName string) ([]OfferToken, error)
+	RedeemOfferToken(id, secretKey string) (OfferToken, error)
+	RevokeOfferToken(id string) error
+
 	// GetModelCallContext gets everything that is needed to make cloud calls on behalf of the state current model.
 	GetModelCallContext() context.ProviderCallContext
 
@@ -102,6 +110,46 @@ func (s stateShim) GetOfferUsers(offerUUID string) (map[string]permission.Access
 	return s.st.GetOfferUsers(offerUUID)
 }
 
+func (s stateShim) AddOfferToken(args state.AddOfferTokenArgs) (OfferToken, string, error) {
+	token, secretKey, err := s.st.AddOfferToken(args)
+	if err != nil {
+		return nil, "", err
+	}
+	return token, secretKey, nil
+}
+
+func (s stateShim) OfferToken(id string) (OfferToken, error) {
+	token, err := s.st.OfferToken(id)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (s stateShim) OfferTokens(offerName string) ([]OfferToken, error) {
+	tokens, err := s.st.OfferTokens(offerName)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]OfferToken, len(tokens))
+	for i, token := range tokens {
+		result[i] = token
+	}
+	return result, nil
+}
+
+func (s stateShim) RedeemOfferToken(id, secretKey string) (OfferToken, error) {
+	token, err := s.st.RedeemOfferToken(id, secretKey)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (s stateShim) RevokeOfferToken(id string) error {
+	return s.st.RevokeOfferToken(id)
+}
+
 func (s *stateShim) SpaceByName(name string) (Space, error) {
 	sp, err := s.st.SpaceByName(name)
 	return &spaceShim{sp}, err
@@ -223,3 +271,16 @@ func (s *stateShim) User(tag names.UserTag) (User, error) {
 type User interface {
 	DisplayName() string
 }
+
+// OfferToken provides the details of an offer token.
+type OfferToken interface {
+	Id() string
+	OfferName() string
+	CreatedBy() string
+	DateCreated() time.Time
+	Expires() time.Time
+	MaxUses() int
+	Uses() int
+	Revoked() bool
+	UserTag() names.UserTag
+}
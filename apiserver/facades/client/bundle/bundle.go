@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/bundlechanges"
 	"github.com/juju/charm/v7"
+	charmresource "github.com/juju/charm/v7/resource"
 	"github.com/juju/collections/set"
 	"github.com/juju/description/v2"
 	"github.com/juju/errors"
@@ -30,6 +31,7 @@ import (
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 )
@@ -60,6 +62,13 @@ type APIv4 struct {
 	*BundleAPI
 }
 
+// APIv5 provides the Bundle API facade for version 5. It is otherwise
+// identical to V4 with the exception that the V5 adds ExportFullBundle, which
+// exports a bundle from which the model can be reproduced.
+type APIv5 struct {
+	*BundleAPI
+}
+
 // BundleAPI implements the Bundle interface and is the concrete implementation
 // of the API end point.
 type BundleAPI struct {
@@ -109,6 +118,16 @@ func NewFacadeV4(ctx facade.Context) (*APIv4, error) {
 	return &APIv4{api}, nil
 }
 
+// NewFacadeV5 provides the signature required for facade registration
+// for version 5.
+func NewFacadeV5(ctx facade.Context) (*APIv5, error) {
+	api, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv5{api}, nil
+}
+
 // NewFacade provides the required signature for facade registration.
 func newFacade(ctx facade.Context) (*BundleAPI, error) {
 	authorizer := ctx.Auth()
@@ -371,7 +390,7 @@ func (b *BundleAPI) ExportBundle() (params.StringResult, error) {
 	}
 
 	// Fill it in charm.BundleData data structure.
-	bundleData, err := b.fillBundleData(model)
+	bundleData, err := b.fillBundleData(model, false)
 	if err != nil {
 		return fail(err)
 	}
@@ -450,7 +469,138 @@ func bundleOutputFromBundleData(bd *charm.BundleData) *bundleOutput {
 // Mask the new method from V1 API.
 func (u *APIv1) ExportBundle() (_, _ struct{}) { return }
 
-func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData, error) {
+// ExportFullBundle is not in V4 API or less.
+// Mask the new method from V4 API or less.
+func (u *APIv4) ExportFullBundle() (_, _ struct{}) { return }
+
+// ExportFullBundle is not in V4 API or less.
+// Mask the new method from V4 API or less.
+func (u *APIv3) ExportFullBundle() (_, _ struct{}) { return }
+
+// ExportFullBundle is not in V4 API or less.
+// Mask the new method from V4 API or less.
+func (u *APIv2) ExportFullBundle() (_, _ struct{}) { return }
+
+// ExportFullBundle exports the current model as a bundle from which it can
+// be reproduced. As well as what ExportBundle exports, the bundle records
+// the channel, storage and device directives, and charm store resource
+// revisions of each application. The offers, the applications consumed from
+// other models and the relations to them are returned separately as an
+// overlay, along with the config set on the model.
+func (b *BundleAPI) ExportFullBundle() (params.ExportBundleResult, error) {
+	fail := func(failErr error) (params.ExportBundleResult, error) {
+		return params.ExportBundleResult{}, apiservererrors.ServerError(failErr)
+	}
+
+	if err := b.checkCanRead(); err != nil {
+		return fail(err)
+	}
+
+	exportConfig := b.backend.GetExportConfig()
+	model, err := b.backend.ExportPartial(exportConfig)
+	if err != nil {
+		return fail(err)
+	}
+
+	bundleData, err := b.fillBundleData(model, true)
+	if err != nil {
+		return fail(err)
+	}
+	base, overlay := splitCrossModelParts(bundleData)
+
+	var result params.ExportBundleResult
+	data, err := yaml.Marshal(bundleOutputFromBundleData(base))
+	if err != nil {
+		return fail(err)
+	}
+	result.Bundle = string(data)
+	if len(overlay.Applications) > 0 || len(overlay.Saas) > 0 || len(overlay.Relations) > 0 {
+		if data, err = yaml.Marshal(bundleOutputFromBundleData(overlay)); err != nil {
+			return fail(err)
+		}
+		result.Overlay = string(data)
+	}
+
+	configValues, err := b.backend.ModelConfigValues()
+	if err != nil {
+		return fail(err)
+	}
+	result.ModelConfig = exportedModelConfig(configValues)
+	return result, nil
+}
+
+// splitCrossModelParts moves the offers, the consumed applications and the
+// relations to them from the bundle data to an overlay, so that the bundle
+// can be deployed on its own.
+func splitCrossModelParts(bd *charm.BundleData) (base, overlay *charm.BundleData) {
+	base = &charm.BundleData{
+		Type:         bd.Type,
+		Description:  bd.Description,
+		Series:       bd.Series,
+		Applications: bd.Applications,
+		Machines:     bd.Machines,
+	}
+	overlay = &charm.BundleData{
+		Saas:         bd.Saas,
+		Applications: make(map[string]*charm.ApplicationSpec),
+	}
+	for name, app := range bd.Applications {
+		if len(app.Offers) == 0 {
+			continue
+		}
+		overlay.Applications[name] = &charm.ApplicationSpec{Offers: app.Offers}
+		app.Offers = nil
+	}
+	for _, rel := range bd.Relations {
+		crossModel := false
+		for _, ep := range rel {
+			appName := strings.SplitN(ep, ":", 2)[0]
+			if _, ok := bd.Saas[appName]; ok {
+				crossModel = true
+			}
+		}
+		if crossModel {
+			overlay.Relations = append(overlay.Relations, rel)
+		} else {
+			base.Relations = append(base.Relations, rel)
+		}
+	}
+	return base, overlay
+}
+
+// exportedModelConfig returns the model config values that have been set on
+// the model, leaving out those that identify the model or cannot be set.
+func exportedModelConfig(values config.ConfigValues) map[string]interface{} {
+	result := make(map[string]interface{})
+	for key, value := range values {
+		if value.Source != config.JujuModelConfigSource || unexportedModelConfig.Contains(key) {
+			continue
+		}
+		result[key] = value.Value
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// unexportedModelConfig holds the model config keys that are not exported
+// with a bundle, as they identify the model, are fixed when it is created or
+// are managed by other commands.
+var unexportedModelConfig = set.NewStrings(
+	config.NameKey,
+	config.TypeKey,
+	config.UUIDKey,
+	config.AgentVersionKey,
+	config.AuthorizedKeysKey,
+	config.CharmhubURLKey,
+	"firewall-mode",
+)
+
+// fillBundleData returns the bundle data describing the model. If full is
+// true, the data also records what is needed to reproduce the applications
+// exactly.
+func (b *BundleAPI) fillBundleData(model description.Model, full bool) (*charm.BundleData, error) {
 	cfg := model.Config()
 	value, ok := cfg["default-series"]
 	if !ok {
@@ -553,6 +703,12 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData,
 			}
 		}
 
+		if full {
+			if err := b.fillFullApplicationSpec(newApplication, application); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+
 		// If this application has been trusted by the operator, set the
 		// Trust field of the ApplicationSpec to true
 		if appConfig := application.ApplicationConfig(); appConfig != nil {
@@ -599,7 +755,14 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData,
 		data.Machines[machine.Id()] = newMachine
 	}
 
+	consumerProxies := set.NewStrings()
 	for _, application := range model.RemoteApplications() {
+		// Applications consuming the offers of this model are not part of
+		// it, and are recreated when they consume the offers again.
+		if application.IsConsumerProxy() {
+			consumerProxies.Add(application.Name())
+			continue
+		}
 		newSaas := &charm.SaasSpec{
 			URL: application.URL(),
 		}
@@ -640,6 +803,10 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData,
 				continue
 			}
 			endpointRelation = append(endpointRelation, endpoint.ApplicationName()+":"+endpoint.Name())
+			if consumerProxies.Contains(endpoint.ApplicationName()) {
+				endpointRelation = nil
+				break
+			}
 		}
 		if len(endpointRelation) != 0 {
 			data.Relations = append(data.Relations, endpointRelation)
@@ -649,6 +816,62 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData,
 	return data, nil
 }
 
+// fillFullApplicationSpec records the channel, storage and device
+// directives, and the charm store resource revisions of the application in
+// its spec.
+func (b *BundleAPI) fillFullApplicationSpec(spec *charm.ApplicationSpec, application description.Application) error {
+	spec.Channel = application.Channel()
+
+	for name, cons := range application.StorageConstraints() {
+		if spec.Storage == nil {
+			spec.Storage = make(map[string]string)
+		}
+		var directive []string
+		if pool := cons.Pool(); pool != "" {
+			directive = append(directive, pool)
+		}
+		directive = append(directive, strconv.FormatUint(cons.Count(), 10))
+		if size := cons.Size(); size != 0 {
+			directive = append(directive, strconv.FormatUint(size, 10)+"M")
+		}
+		spec.Storage[name] = strings.Join(directive, ",")
+	}
+
+	deviceCons, err := b.backend.DeviceConstraints(application.Name())
+	if err != nil {
+		return errors.Annotatef(err, "getting device constraints for %q", application.Name())
+	}
+	for name, cons := range deviceCons {
+		if spec.Devices == nil {
+			spec.Devices = make(map[string]string)
+		}
+		directive := strconv.FormatInt(cons.Count, 10) + "," + string(cons.Type)
+		if len(cons.Attributes) > 0 {
+			attrs := make([]string, 0, len(cons.Attributes))
+			for k, v := range cons.Attributes {
+				attrs = append(attrs, k+"="+v)
+			}
+			sort.Strings(attrs)
+			directive += "," + strings.Join(attrs, ";")
+		}
+		spec.Devices[name] = directive
+	}
+
+	// Only resources from the charm store can be given by revision;
+	// uploaded resources must be uploaded again.
+	for _, res := range application.Resources() {
+		rev := res.ApplicationRevision()
+		if rev == nil || rev.Origin() != charmresource.OriginStore.String() {
+			continue
+		}
+		if spec.Resources == nil {
+			spec.Resources = make(map[string]interface{})
+		}
+		spec.Resources[res.Name()] = rev.Revision()
+	}
+	return nil
+}
+
 func (b *BundleAPI) printSpaceNamesInEndpointBindings(apps []description.Application) bool {
 	// Assumption: if all endpoint bindings in the bundle are in the
 	// same space, spaces aren't really in use and will "muddy the waters"
@@ -5,6 +5,7 @@ package bundle_test
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/description/v2"
 	"github.com/juju/names/v4"
@@ -16,6 +17,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -1872,3 +1875,185 @@ relations:
 	c.Assert(result, gc.Equals, expectedResult)
 	s.st.CheckCall(c, 0, "ExportPartial", s.st.GetExportConfig())
 }
+
+func (s *bundleSuite) makeAPIv5(c *gc.C) *bundle.APIv5 {
+	api, err := bundle.NewBundleAPI(
+		s.st,
+		s.auth,
+		s.modelTag,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return &bundle.APIv5{api}
+}
+
+func (s *bundleSuite) setupFullExportModel(c *gc.C) {
+	s.st.model = description.NewModel(description.ModelArgs{Owner: names.NewUserTag("magic"),
+		Config: map[string]interface{}{
+			"name":           "awesome",
+			"uuid":           "some-uuid",
+			"default-series": "focal",
+		},
+		CloudRegion: "some-region"})
+	s.st.configValues = config.ConfigValues{
+		"name":           {Value: "awesome", Source: config.JujuModelConfigSource},
+		"uuid":           {Value: "some-uuid", Source: config.JujuModelConfigSource},
+		"default-series": {Value: "focal", Source: config.JujuDefaultSource},
+		"logging-config": {Value: "<root>=DEBUG", Source: config.JujuModelConfigSource},
+		"http-proxy":     {Value: "http://proxy", Source: config.JujuControllerSource},
+	}
+	s.st.devices = map[string]map[string]state.DeviceConstraints{
+		"mysql": {
+			"bitcoin-miner": {
+				Type:       "nvidia.com/gpu",
+				Count:      2,
+				Attributes: map[string]string{"gpu": "nvidia-tesla-p100"},
+			},
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		s.st.model.AddMachine(description.MachineArgs{
+			Id:     names.NewMachineTag(fmt.Sprint(i)),
+			Series: "focal",
+		})
+	}
+
+	mysql := s.st.model.AddApplication(description.ApplicationArgs{
+		Tag:         names.NewApplicationTag("mysql"),
+		Type:        description.IAAS,
+		Series:      "focal",
+		CharmURL:    "cs:mysql-42",
+		Channel:     "candidate",
+		CharmConfig: map[string]interface{}{"flavour": "percona"},
+		StorageConstraints: map[string]description.StorageConstraintArgs{
+			"data": {Pool: "ebs", Size: 10240, Count: 1},
+			"logs": {Count: 2},
+		},
+	})
+	mysql.SetStatus(minimalStatusArgs())
+	mysql.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("mysql/0"),
+		Machine: names.NewMachineTag("0"),
+	}).SetAgentStatus(minimalStatusArgs())
+	mysql.AddResource(description.ResourceArgs{Name: "snap"}).SetApplicationRevision(description.ResourceRevisionArgs{
+		Revision: 3,
+		Origin:   "store",
+	})
+	mysql.AddResource(description.ResourceArgs{Name: "tuning"}).SetApplicationRevision(description.ResourceRevisionArgs{
+		Revision: 1,
+		Origin:   "upload",
+	})
+	mysql.AddOffer(description.ApplicationOfferArgs{
+		OfferName: "db",
+		Endpoints: map[string]string{"db": "db"},
+		ACL:       map[string]string{"admin": "admin"},
+	})
+
+	wordpress := s.st.model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("wordpress"),
+		Type:     description.IAAS,
+		Series:   "focal",
+		CharmURL: "cs:wordpress-7",
+		Channel:  "stable",
+		Exposed:  true,
+	})
+	wordpress.SetStatus(minimalStatusArgs())
+	wordpress.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("wordpress/0"),
+		Machine: names.NewMachineTag("1"),
+	}).SetAgentStatus(minimalStatusArgs())
+
+	s.st.model.AddRemoteApplication(description.RemoteApplicationArgs{
+		Tag: names.NewApplicationTag("logs"),
+		URL: "admin/logging.logs",
+	})
+	// The application consuming the db offer is not part of the model.
+	s.st.model.AddRemoteApplication(description.RemoteApplicationArgs{
+		Tag:             names.NewApplicationTag("remote-0123456789abcdef"),
+		IsConsumerProxy: true,
+	})
+
+	for i, endpoints := range [][]string{
+		{"wordpress:db", "mysql:db"},
+		{"mysql:juju-info", "logs:info"},
+		{"remote-0123456789abcdef:db", "mysql:db"},
+	} {
+		rel := s.st.model.AddRelation(description.RelationArgs{Id: i, Key: fmt.Sprint(i)})
+		for _, ep := range endpoints {
+			parts := strings.Split(ep, ":")
+			rel.AddEndpoint(description.EndpointArgs{ApplicationName: parts[0], Name: parts[1]})
+		}
+	}
+}
+
+const fullExportBundle = `
+series: focal
+applications:
+  mysql:
+    charm: cs:mysql-42
+    channel: candidate
+    resources:
+      snap: 3
+    num_units: 1
+    to:
+    - "0"
+    options:
+      flavour: percona
+    storage:
+      data: ebs,1,10240M
+      logs: "2"
+    devices:
+      bitcoin-miner: 2,nvidia.com/gpu,gpu=nvidia-tesla-p100
+  wordpress:
+    charm: cs:wordpress-7
+    channel: stable
+    num_units: 1
+    to:
+    - "1"
+    expose: true
+machines:
+  "0": {}
+  "1": {}
+relations:
+- - wordpress:db
+  - mysql:db
+`
+
+const fullExportOverlay = `
+saas:
+  logs:
+    url: admin/logging.logs
+applications:
+  mysql:
+    offers:
+      db:
+        endpoints:
+        - db
+        acl:
+          admin: admin
+relations:
+- - mysql:juju-info
+  - logs:info
+`
+
+func (s *bundleSuite) TestExportFullBundle(c *gc.C) {
+	s.setupFullExportModel(c)
+
+	result, err := s.makeAPIv5(c).ExportFullBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ExportBundleResult{
+		Bundle:      fullExportBundle[1:],
+		Overlay:     fullExportOverlay[1:],
+		ModelConfig: map[string]interface{}{"logging-config": "<root>=DEBUG"},
+	})
+	s.st.CheckCall(c, 0, "ExportPartial", s.st.GetExportConfig())
+}
+
+func (s *bundleSuite) TestExportFullBundleNotInV4(c *gc.C) {
+	api, err := bundle.NewBundleAPI(s.st, s.auth, s.modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := interface{}(&bundle.APIv4{api}).(interface {
+		ExportFullBundle() (params.ExportBundleResult, error)
+	})
+	c.Assert(ok, jc.IsFalse)
+}
@@ -9,14 +9,17 @@ import (
 
 	"github.com/juju/juju/apiserver/facades/client/bundle"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
 type mockState struct {
 	testing.Stub
 	bundle.Backend
-	model  description.Model
-	Spaces map[string]string
+	model        description.Model
+	Spaces       map[string]string
+	configValues config.ConfigValues
+	devices      map[string]map[string]state.DeviceConstraints
 }
 
 func (m *mockState) ExportPartial(config state.ExportConfig) (description.Model, error) {
@@ -40,6 +43,16 @@ func (m *mockState) GetExportConfig() state.ExportConfig {
 	}
 }
 
+func (m *mockState) ModelConfigValues() (config.ConfigValues, error) {
+	m.MethodCall(m, "ModelConfigValues")
+	return m.configValues, m.NextErr()
+}
+
+func (m *mockState) DeviceConstraints(application string) (map[string]state.DeviceConstraints, error) {
+	m.MethodCall(m, "DeviceConstraints", application)
+	return m.devices[application], m.NextErr()
+}
+
 func (m *mockState) AllSpaceInfos() (network.SpaceInfos, error) {
 	result := make(network.SpaceInfos, len(m.Spaces))
 	i := 0
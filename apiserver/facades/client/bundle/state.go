@@ -5,12 +5,17 @@ package bundle
 
 import (
 	"github.com/juju/description/v2"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
 type Backend interface {
 	ExportPartial(cfg state.ExportConfig) (description.Model, error)
 	GetExportConfig() state.ExportConfig
+	ModelConfigValues() (config.ConfigValues, error)
+	DeviceConstraints(application string) (map[string]state.DeviceConstraints, error)
 	state.EndpointBinding
 }
 
@@ -37,6 +42,24 @@ func (m *stateShim) GetExportConfig() state.ExportConfig {
 	return cfg
 }
 
+// ModelConfigValues implements Backend.ModelConfigValues.
+func (m *stateShim) ModelConfigValues() (config.ConfigValues, error) {
+	model, err := m.State.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return model.ModelConfigValues()
+}
+
+// DeviceConstraints implements Backend.DeviceConstraints.
+func (m *stateShim) DeviceConstraints(application string) (map[string]state.DeviceConstraints, error) {
+	app, err := m.State.Application(application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app.DeviceConstraints()
+}
+
 // NewStateShim creates new state shim to be used by bundle Facade.
 func NewStateShim(st *state.State) Backend {
 	return &stateShim{st}
@@ -18,13 +18,16 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/params"
 	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
@@ -246,6 +249,11 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		return noStatus, errors.Annotate(err, "could not fetch controller timestamp")
 	}
 	context.branches = fetchBranches(c.api.modelCache)
+	if context.model.Type() == state.ModelTypeIAAS {
+		if context.machinePool, err = fetchMachinePool(c.api.stateAccessor, cfg); err != nil {
+			return noStatus, errors.Annotate(err, "could not fetch machine pool")
+		}
+	}
 
 	logger.Tracef("Applications: %v", context.allAppsUnitsCharmBindings.applications)
 	logger.Tracef("Remote applications: %v", context.consumerRemoteApplications)
@@ -397,6 +405,7 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		Relations:           context.processRelations(),
 		ControllerTimestamp: context.controllerTimestamp,
 		Branches:            context.processBranches(),
+		MachinePool:         context.machinePool,
 	}, nil
 }
 
@@ -503,6 +512,9 @@ type applicationStatusInfo struct {
 
 	// lxdProfiles: lxd profile name -> lxd profile
 	lxdProfiles map[string]*charm.LXDProfile
+
+	// applicationConfigs: application name -> application config
+	applicationConfigs map[string]coreapplication.ConfigAttributes
 }
 
 type statusContext struct {
@@ -551,6 +563,9 @@ type statusContext struct {
 	leaders                   map[string]string
 	branches                  map[string]cache.Branch
 
+	// machinePool is nil unless the model's machine pool is enabled.
+	machinePool *params.MachinePoolStatus
+
 	primaryHAMachine *names.MachineTag
 }
 
@@ -768,6 +783,11 @@ func fetchAllApplicationsAndUnits(
 		allBindingsByApp[app] = bindingMap
 	}
 
+	applicationConfigs, err := model.AllApplicationConfigs()
+	if err != nil {
+		return applicationStatusInfo{}, errors.Trace(err)
+	}
+
 	lxdProfiles := make(map[string]*charm.LXDProfile)
 	for _, app := range applications {
 		appMap[app.Name()] = app
@@ -805,11 +825,12 @@ func fetchAllApplicationsAndUnits(
 	}
 
 	return applicationStatusInfo{
-		applications:     appMap,
-		units:            unitMap,
-		latestCharms:     latestCharms,
-		endpointBindings: allBindingsByApp,
-		lxdProfiles:      lxdProfiles,
+		applications:       appMap,
+		units:              unitMap,
+		latestCharms:       latestCharms,
+		endpointBindings:   allBindingsByApp,
+		lxdProfiles:        lxdProfiles,
+		applicationConfigs: applicationConfigs,
 	}, nil
 }
 
@@ -903,6 +924,30 @@ func fetchRelations(st Backend) (map[string][]*state.Relation, map[int]*state.Re
 	return out, outById, nil
 }
 
+// fetchMachinePool returns the status of the model's machine pool, or
+// nil if the pool is neither enabled nor holding machines.
+func fetchMachinePool(st Backend, cfg *config.Config) (*params.MachinePoolStatus, error) {
+	machines, err := st.MachinePool()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	size := cfg.MachinePoolSize()
+	if size == 0 && len(machines) == 0 {
+		return nil, nil
+	}
+	pool := &params.MachinePoolStatus{
+		Size:     size,
+		Machines: make([]string, len(machines)),
+	}
+	if cons := cfg.MachinePoolConstraints(); !constraints.IsEmpty(&cons) {
+		pool.Constraints = cons.String()
+	}
+	for i, m := range machines {
+		pool.Machines[i] = m.Id()
+	}
+	return pool, nil
+}
+
 func fetchBranches(m *cache.Model) map[string]cache.Branch {
 	// Unless you're using the generations feature flag,
 	// the model cache model will be nil.  See note in
@@ -1158,6 +1203,14 @@ func (context *statusContext) processApplications() map[string]params.Applicatio
 	return applicationsMap
 }
 
+// charmRevisionPinned returns true if the application's charm update
+// policy pins its charm revision, so that new revisions are not reported.
+func (context *statusContext) charmRevisionPinned(application *state.Application) bool {
+	cfg := context.allAppsUnitsCharmBindings.applicationConfigs[application.Name()]
+	policy, err := cfg.CharmUpdatePolicy()
+	return err == nil && policy == coreapplication.CharmUpdateManual
+}
+
 func (context *statusContext) processApplication(application *state.Application) params.ApplicationStatus {
 	applicationCharm, _, err := application.Charm()
 	if err != nil {
@@ -1181,7 +1234,7 @@ func (context *statusContext) processApplication(application *state.Application)
 	}
 
 	if latestCharm, ok := context.allAppsUnitsCharmBindings.latestCharms[*applicationCharm.URL().WithRevision(-1)]; ok && latestCharm != nil {
-		if latestCharm.Revision() > applicationCharm.URL().Revision {
+		if latestCharm.Revision() > applicationCharm.URL().Revision && !context.charmRevisionPinned(application) {
 			processedStatus.CanUpgradeTo = latestCharm.String()
 		}
 	}
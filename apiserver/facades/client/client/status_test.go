@@ -65,6 +65,7 @@ func (s *statusSuite) TestFullStatus(c *gc.C) {
 	c.Check(status.Machines, gc.HasLen, 1)
 	c.Check(status.ControllerTimestamp, gc.NotNil)
 	c.Check(status.Branches, gc.HasLen, 0)
+	c.Check(status.MachinePool, gc.IsNil)
 	resultMachine, ok := status.Machines[machine.Id()]
 	if !ok {
 		c.Fatalf("Missing machine with id %q", machine.Id())
@@ -74,6 +75,28 @@ func (s *statusSuite) TestFullStatus(c *gc.C) {
 	c.Check(resultMachine.LXDProfiles, gc.HasLen, 0)
 }
 
+func (s *statusSuite) TestFullStatusMachinePool(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"machine-pool-size":        2,
+		"machine-pool-constraints": "mem=4G",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.addMachine(c)
+	pooled, err := s.State.AddPoolMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.MachinePool, jc.DeepEquals, &params.MachinePoolStatus{
+		Size:        2,
+		Constraints: "mem=4096M",
+		Machines:    []string{pooled.Id()},
+	})
+}
+
 func (s *statusSuite) TestUnsupportedNoModelMeterStatus(c *gc.C) {
 	s.addMachine(c)
 	c.Assert(s.State.SetSLA("unsupported", "test-user", []byte("")), jc.ErrorIsNil)
@@ -8,6 +8,7 @@ import (
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
 )
 
@@ -47,8 +48,12 @@ type Generation interface {
 	AssignUnit(string) error
 	AssignedUnits() map[string][]string
 	Commit(string) (int, error)
+	CommitWithResolution(string, model.BranchConflictResolution) (int, error)
 	Abort(string) error
 	Config() map[string]settings.ItemChanges
+	ConfigDiff() (map[string][]settings.ItemDiff, error)
+	CharmURL(string) (*charm.URL, bool)
+	ResourceIDs(string) map[string]string
 	GenerationId() int
 }
 
@@ -5,14 +5,14 @@
 package mocks
 
 import (
-	reflect "reflect"
-
 	gomock "github.com/golang/mock/gomock"
-	charm_v6 "github.com/juju/charm/v7"
+	charm "github.com/juju/charm/v7"
 	modelgeneration "github.com/juju/juju/apiserver/facades/client/modelgeneration"
 	cache "github.com/juju/juju/core/cache"
+	model "github.com/juju/juju/core/model"
 	settings "github.com/juju/juju/core/settings"
-	names_v3 "github.com/juju/names/v4"
+	names "github.com/juju/names/v4"
+	reflect "reflect"
 )
 
 // MockState is a mock of State interface
@@ -54,10 +54,10 @@ func (mr *MockStateMockRecorder) Application(arg0 interface{}) *gomock.Call {
 }
 
 // ControllerTag mocks base method
-func (m *MockState) ControllerTag() names_v3.ControllerTag {
+func (m *MockState) ControllerTag() names.ControllerTag {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ControllerTag")
-	ret0, _ := ret[0].(names_v3.ControllerTag)
+	ret0, _ := ret[0].(names.ControllerTag)
 	return ret0
 }
 
@@ -159,7 +159,7 @@ func (m *MockModel) Generation(arg0 int) (modelgeneration.Generation, error) {
 }
 
 // Generation indicates an expected call of Generation
-func (mr *MockModelMockRecorder) CommittedBranch(arg0 interface{}) *gomock.Call {
+func (mr *MockModelMockRecorder) Generation(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generation", reflect.TypeOf((*MockModel)(nil).Generation), arg0)
 }
@@ -174,16 +174,16 @@ func (m *MockModel) Generations() ([]modelgeneration.Generation, error) {
 }
 
 // Generations indicates an expected call of Generations
-func (mr *MockModelMockRecorder) CommittedBranches() *gomock.Call {
+func (mr *MockModelMockRecorder) Generations() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generations", reflect.TypeOf((*MockModel)(nil).Generations))
 }
 
 // ModelTag mocks base method
-func (m *MockModel) ModelTag() names_v3.ModelTag {
+func (m *MockModel) ModelTag() names.ModelTag {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ModelTag")
-	ret0, _ := ret[0].(names_v3.ModelTag)
+	ret0, _ := ret[0].(names.ModelTag)
 	return ret0
 }
 
@@ -300,6 +300,21 @@ func (mr *MockGenerationMockRecorder) BranchName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchName", reflect.TypeOf((*MockGeneration)(nil).BranchName))
 }
 
+// CharmURL mocks base method
+func (m *MockGeneration) CharmURL(arg0 string) (*charm.URL, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CharmURL", arg0)
+	ret0, _ := ret[0].(*charm.URL)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// CharmURL indicates an expected call of CharmURL
+func (mr *MockGenerationMockRecorder) CharmURL(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CharmURL", reflect.TypeOf((*MockGeneration)(nil).CharmURL), arg0)
+}
+
 // Commit mocks base method
 func (m *MockGeneration) Commit(arg0 string) (int, error) {
 	m.ctrl.T.Helper()
@@ -315,6 +330,21 @@ func (mr *MockGenerationMockRecorder) Commit(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockGeneration)(nil).Commit), arg0)
 }
 
+// CommitWithResolution mocks base method
+func (m *MockGeneration) CommitWithResolution(arg0 string, arg1 model.BranchConflictResolution) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitWithResolution", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitWithResolution indicates an expected call of CommitWithResolution
+func (mr *MockGenerationMockRecorder) CommitWithResolution(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitWithResolution", reflect.TypeOf((*MockGeneration)(nil).CommitWithResolution), arg0, arg1)
+}
+
 // Completed mocks base method
 func (m *MockGeneration) Completed() int64 {
 	m.ctrl.T.Helper()
@@ -357,6 +387,21 @@ func (mr *MockGenerationMockRecorder) Config() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Config", reflect.TypeOf((*MockGeneration)(nil).Config))
 }
 
+// ConfigDiff mocks base method
+func (m *MockGeneration) ConfigDiff() (map[string][]settings.ItemDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigDiff")
+	ret0, _ := ret[0].(map[string][]settings.ItemDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfigDiff indicates an expected call of ConfigDiff
+func (mr *MockGenerationMockRecorder) ConfigDiff() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigDiff", reflect.TypeOf((*MockGeneration)(nil).ConfigDiff))
+}
+
 // Created mocks base method
 func (m *MockGeneration) Created() int64 {
 	m.ctrl.T.Helper()
@@ -399,6 +444,20 @@ func (mr *MockGenerationMockRecorder) GenerationId() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerationId", reflect.TypeOf((*MockGeneration)(nil).GenerationId))
 }
 
+// ResourceIDs mocks base method
+func (m *MockGeneration) ResourceIDs(arg0 string) map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceIDs", arg0)
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// ResourceIDs indicates an expected call of ResourceIDs
+func (mr *MockGenerationMockRecorder) ResourceIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceIDs", reflect.TypeOf((*MockGeneration)(nil).ResourceIDs), arg0)
+}
+
 // MockApplication is a mock of Application interface
 type MockApplication struct {
 	ctrl     *gomock.Controller
@@ -423,10 +482,10 @@ func (m *MockApplication) EXPECT() *MockApplicationMockRecorder {
 }
 
 // DefaultCharmConfig mocks base method
-func (m *MockApplication) DefaultCharmConfig() (charm_v6.Settings, error) {
+func (m *MockApplication) DefaultCharmConfig() (charm.Settings, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DefaultCharmConfig")
-	ret0, _ := ret[0].(charm_v6.Settings)
+	ret0, _ := ret[0].(charm.Settings)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
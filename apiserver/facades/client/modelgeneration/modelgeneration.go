@@ -5,6 +5,7 @@ package modelgeneration
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -17,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/settings"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.modelgeneration")
@@ -32,10 +34,14 @@ type API struct {
 	modelCache        ModelCache
 }
 
-type APIV3 struct {
+type APIV4 struct {
 	*API
 }
 
+type APIV3 struct {
+	*APIV4
+}
+
 type APIV2 struct {
 	*APIV3
 }
@@ -44,8 +50,8 @@ type APIV1 struct {
 	*APIV2
 }
 
-// NewModelGenerationFacadeV4 provides the signature required for facade registration.
-func NewModelGenerationFacadeV4(ctx facade.Context) (*API, error) {
+// NewModelGenerationFacadeV5 provides the signature required for facade registration.
+func NewModelGenerationFacadeV5(ctx facade.Context) (*API, error) {
 	authorizer := ctx.Auth()
 	st := &stateShim{State: ctx.State()}
 	m, err := st.Model()
@@ -59,6 +65,15 @@ func NewModelGenerationFacadeV4(ctx facade.Context) (*API, error) {
 	return NewModelGenerationAPI(st, authorizer, m, &modelCacheShim{Model: mc})
 }
 
+// NewModelGenerationFacadeV4 provides the signature required for facade registration.
+func NewModelGenerationFacadeV4(ctx facade.Context) (*APIV4, error) {
+	v5, err := NewModelGenerationFacadeV5(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV4{v5}, nil
+}
+
 // NewModelGenerationFacadeV3 provides the signature required for facade registration.
 func NewModelGenerationFacadeV3(ctx facade.Context) (*APIV3, error) {
 	v4, err := NewModelGenerationFacadeV4(ctx)
@@ -198,6 +213,31 @@ func (api *API) TrackBranch(arg params.BranchTrackArg) (params.ErrorResults, err
 // CommitBranch commits the input branch, making its changes applicable to
 // the whole model and marking it complete.
 func (api *API) CommitBranch(arg params.BranchArg) (params.IntResult, error) {
+	return api.commitBranch(arg.BranchName, func(branch Generation) (int, error) {
+		return branch.Commit(api.apiUser.Name())
+	})
+}
+
+// CommitBranchWithResolution is not available on V4 or earlier.
+func (*APIV4) CommitBranchWithResolution(_, _ struct{}) {}
+
+// CommitBranchWithResolution commits the input branch as for CommitBranch,
+// resolving conflicts with changes made to master since the branch changed
+// the same settings as indicated by the input argument.
+func (api *API) CommitBranchWithResolution(arg params.BranchCommitArg) (params.IntResult, error) {
+	resolution := model.ConflictBranchWins
+	if arg.OnConflict != "" {
+		resolution = model.BranchConflictResolution(arg.OnConflict)
+	}
+	return api.commitBranch(arg.BranchName, func(branch Generation) (int, error) {
+		if err := resolution.Validate(); err != nil {
+			return 0, errors.Trace(err)
+		}
+		return branch.CommitWithResolution(api.apiUser.Name(), resolution)
+	})
+}
+
+func (api *API) commitBranch(branchName string, commit func(Generation) (int, error)) (params.IntResult, error) {
 	result := params.IntResult{}
 
 	isModelAdmin, err := api.hasAdminAccess()
@@ -208,12 +248,12 @@ func (api *API) CommitBranch(arg params.BranchArg) (params.IntResult, error) {
 		return result, apiservererrors.ErrPerm
 	}
 
-	branch, err := api.model.Branch(arg.BranchName)
+	branch, err := api.model.Branch(branchName)
 	if err != nil {
 		return intResultsError(err)
 	}
 
-	if genId, err := branch.Commit(api.apiUser.Name()); err != nil {
+	if genId, err := commit(branch); err != nil {
 		result.Error = apiservererrors.ServerError(err)
 	} else {
 		result.Result = genId
@@ -247,6 +287,13 @@ func (api *API) AbortBranch(arg params.BranchArg) (params.ErrorResult, error) {
 	return result, nil
 }
 
+// BranchInfo will return details of branch identified by the input argument.
+// Three-way configuration comparisons are not supported on V4 or earlier.
+func (api *APIV4) BranchInfo(args params.BranchInfoArgs) (params.BranchResults, error) {
+	args.ThreeWay = false
+	return api.API.BranchInfo(args)
+}
+
 // BranchInfo will return details of branch identified by the input argument,
 // including units on the branch and the configuration disjoint with the
 // master generation.
@@ -282,7 +329,7 @@ func (api *API) BranchInfo(
 
 	results := make([]params.Generation, len(branches))
 	for i, b := range branches {
-		if results[i], err = api.oneBranchInfo(b, args.Detailed); err != nil {
+		if results[i], err = api.oneBranchInfo(b, args.Detailed, args.ThreeWay); err != nil {
 			return branchResultsError(err)
 		}
 	}
@@ -356,9 +403,17 @@ func (api *API) ListCommits() (params.BranchResults, error) {
 	return result, nil
 }
 
-func (api *API) oneBranchInfo(branch Generation, detailed bool) (params.Generation, error) {
+func (api *API) oneBranchInfo(branch Generation, detailed, threeWay bool) (params.Generation, error) {
 	deltas := branch.Config()
 
+	var diffs map[string][]settings.ItemDiff
+	if threeWay {
+		var err error
+		if diffs, err = branch.ConfigDiff(); err != nil {
+			return params.Generation{}, errors.Trace(err)
+		}
+	}
+
 	var apps []params.GenerationApplication
 	for appName, tracking := range branch.AssignedUnits() {
 		app, err := api.st.Application(appName)
@@ -382,9 +437,23 @@ func (api *API) oneBranchInfo(branch Generation, detailed bool) (params.Generati
 		}
 		branchApp.ConfigChanges = deltas[appName].EffectiveChanges(defaults)
 
-		// TODO (manadart 2019-04-12): Charm URL.
+		for _, diff := range diffs[appName] {
+			branchApp.ConfigDiff = append(branchApp.ConfigDiff, params.ConfigItemDiff{
+				Key:      diff.Key,
+				Base:     diff.Base,
+				Branch:   diff.Branch,
+				Master:   diff.Master,
+				Conflict: diff.Conflicted(),
+			})
+		}
 
-		// TODO (manadart 2019-04-12): Resources.
+		if curl, ok := branch.CharmURL(appName); ok {
+			branchApp.CharmURL = curl.String()
+			for name := range branch.ResourceIDs(appName) {
+				branchApp.Resources = append(branchApp.Resources, name)
+			}
+			sort.Strings(branchApp.Resources)
+		}
 
 		// Only include unit names if detailed info was requested.
 		if detailed {
@@ -405,7 +474,7 @@ func (api *API) oneBranchInfo(branch Generation, detailed bool) (params.Generati
 }
 
 func (api *API) getGenerationCommit(branch Generation) (params.Generation, error) {
-	generation, err := api.oneBranchInfo(branch, true)
+	generation, err := api.oneBranchInfo(branch, true, false)
 	if err != nil {
 		return params.Generation{}, errors.Trace(err)
 	}
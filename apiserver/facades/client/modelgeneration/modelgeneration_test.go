@@ -5,6 +5,7 @@ package modelgeneration_test
 
 import (
 	"github.com/golang/mock/gomock"
+	"github.com/juju/charm/v7"
 	"github.com/juju/errors"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/names/v4"
@@ -136,6 +137,41 @@ func (s *modelGenerationSuite) TestCommitBranchSuccess(c *gc.C) {
 	c.Assert(result, gc.DeepEquals, params.IntResult{Result: 3, Error: nil})
 }
 
+func (s *modelGenerationSuite) TestCommitBranchWithResolutionSuccess(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.mockGen.EXPECT().CommitWithResolution(s.apiUser, model.ConflictMasterWins).Return(3, nil)
+	s.expectBranch()
+
+	result, err := s.api.CommitBranchWithResolution(params.BranchCommitArg{
+		BranchName: s.newBranchName,
+		OnConflict: "master",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.IntResult{Result: 3, Error: nil})
+}
+
+func (s *modelGenerationSuite) TestCommitBranchWithResolutionDefault(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.mockGen.EXPECT().CommitWithResolution(s.apiUser, model.ConflictBranchWins).Return(3, nil)
+	s.expectBranch()
+
+	result, err := s.api.CommitBranchWithResolution(params.BranchCommitArg{BranchName: s.newBranchName})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.IntResult{Result: 3, Error: nil})
+}
+
+func (s *modelGenerationSuite) TestCommitBranchWithResolutionInvalid(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.expectBranch()
+
+	result, err := s.api.CommitBranchWithResolution(params.BranchCommitArg{
+		BranchName: s.newBranchName,
+		OnConflict: "whatever",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `conflict resolution "whatever" not valid`)
+}
+
 func (s *modelGenerationSuite) TestAbortBranchSuccess(c *gc.C) {
 	defer s.setupModelGenerationAPI(c).Finish()
 	s.expectAbort()
@@ -174,6 +210,39 @@ func (s *modelGenerationSuite) TestBranchInfoSummary(c *gc.C) {
 	s.testBranchInfo(c, []string{s.newBranchName}, false)
 }
 
+func (s *modelGenerationSuite) TestBranchInfoThreeWay(c *gc.C) {
+	ctrl := s.setupModelGenerationAPI(c)
+	defer ctrl.Finish()
+
+	units := []string{"redis/0", "redis/1", "redis/2"}
+
+	s.expectConfig()
+	s.expectBranchName()
+	s.expectAssignedUnits(units[:2])
+	s.expectCreated()
+	s.expectCreatedBy()
+	s.expectBranch()
+	s.mockGen.EXPECT().ConfigDiff().Return(map[string][]settings.ItemDiff{"redis": {
+		{Key: "password", Branch: "added-pass"},
+		{Key: "port", Base: 7000, Branch: 8000, Master: 7500},
+	}}, nil)
+	s.expectNoCharm()
+	s.setupMockApp(ctrl, units)
+
+	result, err := s.api.BranchInfo(params.BranchInfoArgs{
+		BranchNames: []string{s.newBranchName},
+		ThreeWay:    true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Generations, gc.HasLen, 1)
+	c.Assert(result.Generations[0].Applications, gc.HasLen, 1)
+	c.Check(result.Generations[0].Applications[0].ConfigDiff, gc.DeepEquals, []params.ConfigItemDiff{
+		{Key: "password", Branch: "added-pass"},
+		{Key: "port", Base: 7000, Branch: 8000, Master: 7500, Conflict: true},
+	})
+}
+
 func (s *modelGenerationSuite) testBranchInfo(c *gc.C, branchNames []string, detailed bool) {
 	ctrl := s.setupModelGenerationAPI(c)
 	defer ctrl.Finish()
@@ -194,6 +263,7 @@ func (s *modelGenerationSuite) testBranchInfo(c *gc.C, branchNames []string, det
 		s.expectBranches()
 	}
 
+	s.expectNoCharm()
 	s.setupMockApp(ctrl, units)
 
 	result, err := s.api.BranchInfo(params.BranchInfoArgs{
@@ -229,6 +299,37 @@ func (s *modelGenerationSuite) testBranchInfo(c *gc.C, branchNames []string, det
 	}
 }
 
+func (s *modelGenerationSuite) TestBranchInfoCharm(c *gc.C) {
+	ctrl := s.setupModelGenerationAPI(c)
+	defer ctrl.Finish()
+
+	units := []string{"redis/0", "redis/1", "redis/2"}
+
+	s.expectConfig()
+	s.expectBranchName()
+	s.expectAssignedUnits(units[:1])
+	s.expectCreated()
+	s.expectCreatedBy()
+	s.expectBranch()
+	s.mockGen.EXPECT().CharmURL("redis").Return(charm.MustParseURL("cs:redis-2"), true)
+	s.mockGen.EXPECT().ResourceIDs("redis").Return(map[string]string{
+		"server": "pending-1",
+		"config": "pending-2",
+	})
+	s.setupMockApp(ctrl, units)
+
+	result, err := s.api.BranchInfo(params.BranchInfoArgs{BranchNames: []string{s.newBranchName}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Generations, gc.HasLen, 1)
+	c.Assert(result.Generations[0].Applications, gc.HasLen, 1)
+
+	genApp := result.Generations[0].Applications[0]
+	c.Check(genApp.UnitProgress, gc.Equals, "1/3")
+	c.Check(genApp.CharmURL, gc.Equals, "cs:redis-2")
+	c.Check(genApp.Resources, gc.DeepEquals, []string{"config", "server"})
+}
+
 func (s *modelGenerationSuite) setupModelGenerationAPI(c *gc.C) *gomock.Controller {
 	ctrl := gomock.NewController(c)
 
@@ -319,6 +420,10 @@ func (s *modelGenerationSuite) expectConfig() {
 	}})
 }
 
+func (s *modelGenerationSuite) expectNoCharm() {
+	s.mockGen.EXPECT().CharmURL("redis").Return(nil, false)
+}
+
 func (s *modelGenerationSuite) setupMockApp(ctrl *gomock.Controller, units []string) {
 	mockApp := mocks.NewMockApplication(ctrl)
 	mockApp.EXPECT().DefaultCharmConfig().Return(map[string]interface{}{
@@ -183,7 +183,7 @@ func (s *modelInfoSuite) setAPIUser(c *gc.C, user names.UserTag) {
 }
 
 func (s *modelInfoSuite) TestModelInfoV7(c *gc.C) {
-	api := &modelmanager.ModelManagerAPIV7{s.modelmanager}
+	api := &modelmanager.ModelManagerAPIV7{&modelmanager.ModelManagerAPIV8{s.modelmanager}}
 
 	results, err := api.ModelInfo(params.Entities{
 		Entities: []params.Entity{{
@@ -648,6 +648,7 @@ type mockState struct {
 	block           state.BlockType
 	migration       *mockMigration
 	modelConfig     *config.Config
+	modelTemplates  []state.ModelTemplate
 
 	modelDetailsForUser func() ([]state.ModelSummary, error)
 }
@@ -969,6 +970,47 @@ func (st *mockState) HAPrimaryMachine() (names.MachineTag, error) {
 	return names.MachineTag{}, nil
 }
 
+func (st *mockState) AddModelTemplate(template state.ModelTemplate) error {
+	st.MethodCall(st, "AddModelTemplate", template)
+	if err := st.NextErr(); err != nil {
+		return err
+	}
+	st.modelTemplates = append(st.modelTemplates, template)
+	return nil
+}
+
+func (st *mockState) UpdateModelTemplate(template state.ModelTemplate) error {
+	st.MethodCall(st, "UpdateModelTemplate", template)
+	if err := st.NextErr(); err != nil {
+		return err
+	}
+	for i, t := range st.modelTemplates {
+		if t.Name == template.Name {
+			st.modelTemplates[i] = template
+			return nil
+		}
+	}
+	return errors.NotFoundf("model template %q", template.Name)
+}
+
+func (st *mockState) ModelTemplate(name string) (state.ModelTemplate, error) {
+	st.MethodCall(st, "ModelTemplate", name)
+	if err := st.NextErr(); err != nil {
+		return state.ModelTemplate{}, err
+	}
+	for _, t := range st.modelTemplates {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return state.ModelTemplate{}, errors.NotFoundf("model template %q", name)
+}
+
+func (st *mockState) ModelTemplates() ([]state.ModelTemplate, error) {
+	st.MethodCall(st, "ModelTemplates")
+	return st.modelTemplates, st.NextErr()
+}
+
 func (st *mockState) AddSpace(name string, provider network.Id, subnetIds []string, public bool) (*state.Space, error) {
 	st.MethodCall(st, "AddSpace", name, provider, subnetIds, public)
 	return nil, st.NextErr()
@@ -42,6 +42,15 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
+// ModelManagerV9 defines the methods on the version 9 facade for the
+// modelmanager API endpoint.
+type ModelManagerV9 interface {
+	ModelManagerV8
+	CreateModelTemplates(args params.ModelTemplates) (params.ErrorResults, error)
+	UpdateModelTemplates(args params.ModelTemplates) (params.ErrorResults, error)
+	ListModelTemplates() (params.ModelTemplates, error)
+}
+
 // ModelManagerV8 defines the methods on the version 8 facade for the
 // modelmanager API endpoint.
 type ModelManagerV8 interface {
@@ -134,6 +143,12 @@ type ModelManagerAPI struct {
 // ModelManagerAPIV7 provides a way to wrap the different calls between
 // version 8 and version 7 of the model manager API
 type ModelManagerAPIV7 struct {
+	*ModelManagerAPIV8
+}
+
+// ModelManagerAPIV8 provides a way to wrap the different calls between
+// version 8 and version 9 of the model manager API
+type ModelManagerAPIV8 struct {
 	*ModelManagerAPI
 }
 
@@ -168,7 +183,8 @@ type ModelManagerAPIV2 struct {
 }
 
 var (
-	_ ModelManagerV8 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV9 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV8 = (*ModelManagerAPIV8)(nil)
 	_ ModelManagerV7 = (*ModelManagerAPIV7)(nil)
 	_ ModelManagerV6 = (*ModelManagerAPIV6)(nil)
 	_ ModelManagerV5 = (*ModelManagerAPIV5)(nil)
@@ -177,8 +193,8 @@ var (
 	_ ModelManagerV2 = (*ModelManagerAPIV2)(nil)
 )
 
-// NewFacadeV8 is used for API registration.
-func NewFacadeV8(ctx facade.Context) (*ModelManagerAPI, error) {
+// NewFacadeV9 is used for API registration.
+func NewFacadeV9(ctx facade.Context) (*ModelManagerAPI, error) {
 	st := ctx.State()
 	pool := ctx.StatePool()
 	ctlrSt := pool.SystemState()
@@ -215,6 +231,15 @@ func NewFacadeV8(ctx facade.Context) (*ModelManagerAPI, error) {
 	)
 }
 
+// NewFacadeV8 is used for API registration.
+func NewFacadeV8(ctx facade.Context) (*ModelManagerAPIV8, error) {
+	v9, err := NewFacadeV9(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV8{v9}, nil
+}
+
 // NewFacadeV7 is used for API registration.
 func NewFacadeV7(ctx facade.Context) (*ModelManagerAPIV7, error) {
 	v8, err := NewFacadeV8(ctx)
@@ -531,11 +556,21 @@ func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Model
 		return result, errors.Trace(err)
 	}
 
+	var template state.ModelTemplate
+	if args.Template != "" {
+		template, err = m.ctlrState.ModelTemplate(args.Template)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		args.Config = templateModelConfig(template, args.Config)
+	}
+
 	var model common.Model
 	if jujucloud.CloudIsCAAS(cloud) {
 		model, err = m.newCAASModel(
 			cloudSpec,
 			args,
+			template,
 			controllerModel,
 			cloudTag,
 			cloudRegionName,
@@ -545,6 +580,7 @@ func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Model
 		model, err = m.newModel(
 			cloudSpec,
 			args,
+			template,
 			controllerModel,
 			cloudTag,
 			cloudRegionName,
@@ -560,6 +596,7 @@ func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Model
 func (m *ModelManagerAPI) newCAASModel(
 	cloudSpec environscloudspec.CloudSpec,
 	createArgs params.ModelCreateArgs,
+	template state.ModelTemplate,
 	controllerModel common.Model,
 	cloudTag names.CloudTag,
 	cloudRegionName string,
@@ -602,7 +639,7 @@ Please choose a different model name.
 
 	storageProviderRegistry := stateenvirons.NewStorageProviderRegistry(broker)
 
-	model, st, err := m.state.NewModel(state.ModelArgs{
+	model, st, err := m.state.NewModel(templateModelArgs(template, state.ModelArgs{
 		Type:                    state.ModelTypeCAAS,
 		CloudName:               cloudTag.Id(),
 		CloudRegion:             cloudRegionName,
@@ -610,7 +647,7 @@ Please choose a different model name.
 		Config:                  newConfig,
 		Owner:                   ownerTag,
 		StorageProviderRegistry: storageProviderRegistry,
-	})
+	}))
 	if err != nil {
 		return nil, errors.Annotate(err, "failed to create new model")
 	}
@@ -622,6 +659,7 @@ Please choose a different model name.
 func (m *ModelManagerAPI) newModel(
 	cloudSpec environscloudspec.CloudSpec,
 	createArgs params.ModelCreateArgs,
+	template state.ModelTemplate,
 	controllerModel common.Model,
 	cloudTag names.CloudTag,
 	cloudRegionName string,
@@ -662,7 +700,7 @@ func (m *ModelManagerAPI) newModel(
 	// NOTE: check the agent-version of the config, and if it is > the current
 	// version, it is not supported, also check existing tools, and if we don't
 	// have tools for that version, also die.
-	model, st, err := m.state.NewModel(state.ModelArgs{
+	model, st, err := m.state.NewModel(templateModelArgs(template, state.ModelArgs{
 		Type:                    state.ModelTypeIAAS,
 		CloudName:               cloudTag.Id(),
 		CloudRegion:             cloudRegionName,
@@ -671,7 +709,7 @@ func (m *ModelManagerAPI) newModel(
 		Owner:                   ownerTag,
 		StorageProviderRegistry: storageProviderRegistry,
 		EnvironVersion:          env.Provider().Version(),
-	})
+	}))
 	if err != nil {
 		// Clean up the environ.
 		if e := env.Destroy(m.callContext); e != nil {
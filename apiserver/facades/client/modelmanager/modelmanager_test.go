@@ -22,6 +22,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
@@ -510,6 +511,157 @@ func (s *modelManagerSuite) TestCreateCAASModelNamespaceClash(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
 }
 
+func (s *modelManagerSuite) modelTemplate() state.ModelTemplate {
+	return state.ModelTemplate{
+		Name:        "web",
+		Config:      map[string]interface{}{"bar": "template", "qux": "quux"},
+		Constraints: constraints.MustParse("mem=4G"),
+		StoragePools: []state.ModelTemplateStoragePool{{
+			Name:     "fast",
+			Provider: "ebs",
+			Attrs:    map[string]interface{}{"volume-type": "ssd"},
+		}},
+		Spaces: []string{"db"},
+		Users: []state.ModelTemplateUser{{
+			User:   names.NewUserTag("bob"),
+			Access: permission.WriteAccess,
+		}},
+		Bundle:    "cs:wordpress-simple",
+		CreatedBy: "admin",
+	}
+}
+
+func (s *modelManagerSuite) TestCreateModelFromTemplate(c *gc.C) {
+	template := s.modelTemplate()
+	s.ctlrSt.modelTemplates = []state.ModelTemplate{template}
+	args := params.ModelCreateArgs{
+		Name:     "foo",
+		OwnerTag: "user-admin",
+		Config: map[string]interface{}{
+			"bar": "baz",
+		},
+		CloudRegion:        "qux",
+		CloudCredentialTag: "cloudcred-some-cloud_admin_some-credential",
+		Template:           "web",
+	}
+	_, err := s.api.CreateModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newModelArgs := s.getModelArgs(c)
+	attrs := newModelArgs.Config.AllAttrs()
+	c.Assert(attrs["bar"], gc.Equals, "baz")
+	c.Assert(attrs["qux"], gc.Equals, "quux")
+	c.Assert(newModelArgs.Constraints, jc.DeepEquals, template.Constraints)
+	c.Assert(newModelArgs.StoragePools, jc.DeepEquals, template.StoragePools)
+	c.Assert(newModelArgs.Spaces, jc.DeepEquals, template.Spaces)
+	c.Assert(newModelArgs.Users, jc.DeepEquals, template.Users)
+}
+
+func (s *modelManagerSuite) TestCreateModelFromTemplateNotFound(c *gc.C) {
+	args := params.ModelCreateArgs{
+		Name:     "foo",
+		OwnerTag: "user-admin",
+		Template: "web",
+	}
+	_, err := s.api.CreateModel(args)
+	c.Assert(err, gc.ErrorMatches, `model template "web" not found`)
+	for _, call := range s.st.Calls() {
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "NewModel")
+	}
+}
+
+func (s *modelManagerSuite) TestCreateModelTemplates(c *gc.C) {
+	results, err := s.api.CreateModelTemplates(params.ModelTemplates{
+		Templates: []params.ModelTemplate{{
+			Name:        "web",
+			Config:      map[string]interface{}{"bar": "template", "qux": "quux"},
+			Constraints: constraints.MustParse("mem=4G"),
+			StoragePools: []params.ModelTemplateStoragePool{{
+				Name:     "fast",
+				Provider: "ebs",
+				Attrs:    map[string]interface{}{"volume-type": "ssd"},
+			}},
+			Spaces: []string{"db"},
+			Users:  []params.ModelTemplateUser{{UserTag: "user-bob", Access: "write"}},
+			Bundle: "cs:wordpress-simple",
+		}, {
+			Name:  "bad",
+			Users: []params.ModelTemplateUser{{UserTag: "bob", Access: "write"}},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"bob" is not a valid tag`)
+	s.ctlrSt.CheckCallNames(c, "AddModelTemplate")
+	c.Assert(s.ctlrSt.modelTemplates, jc.DeepEquals, []state.ModelTemplate{s.modelTemplate()})
+}
+
+func (s *modelManagerSuite) TestCreateModelTemplatesPermission(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("bob"))
+	_, err := s.api.CreateModelTemplates(params.ModelTemplates{
+		Templates: []params.ModelTemplate{{Name: "web"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(s.ctlrSt.modelTemplates, gc.HasLen, 0)
+}
+
+func (s *modelManagerSuite) TestUpdateModelTemplates(c *gc.C) {
+	s.ctlrSt.modelTemplates = []state.ModelTemplate{s.modelTemplate()}
+	results, err := s.api.UpdateModelTemplates(params.ModelTemplates{
+		Templates: []params.ModelTemplate{
+			{Name: "web", Bundle: "cs:mediawiki-single"},
+			{Name: "missing"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: `model template "missing" not found`, Code: params.CodeNotFound}},
+	})
+	c.Assert(s.ctlrSt.modelTemplates, jc.DeepEquals, []state.ModelTemplate{{
+		Name:      "web",
+		Bundle:    "cs:mediawiki-single",
+		CreatedBy: "admin",
+	}})
+}
+
+func (s *modelManagerSuite) TestListModelTemplates(c *gc.C) {
+	s.ctlrSt.cloudUsers["bob"] = permission.AddModelAccess
+	s.setAPIUser(c, names.NewUserTag("bob"))
+	s.ctlrSt.modelTemplates = []state.ModelTemplate{s.modelTemplate()}
+	result, err := s.api.ListModelTemplates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Templates, jc.DeepEquals, []params.ModelTemplate{{
+		Name:        "web",
+		Config:      map[string]interface{}{"bar": "template", "qux": "quux"},
+		Constraints: constraints.MustParse("mem=4G"),
+		StoragePools: []params.ModelTemplateStoragePool{{
+			Name:     "fast",
+			Provider: "ebs",
+			Attrs:    map[string]interface{}{"volume-type": "ssd"},
+		}},
+		Spaces:    []string{"db"},
+		Users:     []params.ModelTemplateUser{{UserTag: "user-bob", Access: "write"}},
+		Bundle:    "cs:wordpress-simple",
+		CreatedBy: "admin",
+	}})
+}
+
+func (s *modelManagerSuite) TestListModelTemplatesAdmin(c *gc.C) {
+	s.ctlrSt.modelTemplates = []state.ModelTemplate{s.modelTemplate()}
+	result, err := s.api.ListModelTemplates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Templates, gc.HasLen, 1)
+}
+
+func (s *modelManagerSuite) TestListModelTemplatesPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("bob"))
+	s.ctlrSt.modelTemplates = []state.ModelTemplate{s.modelTemplate()}
+	_, err := s.api.ListModelTemplates()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *modelManagerSuite) TestModelDefaults(c *gc.C) {
 	results, err := s.api.ModelDefaultsForClouds(params.Entities{
 		Entities: []params.Entity{{Tag: names.NewCloudTag("dummy").String()}},
@@ -690,7 +842,9 @@ func (s *modelManagerSuite) TestDumpModelV2(c *gc.C) {
 				&modelmanager.ModelManagerAPIV5{
 					&modelmanager.ModelManagerAPIV6{
 						&modelmanager.ModelManagerAPIV7{
-							s.api,
+							&modelmanager.ModelManagerAPIV8{
+								s.api,
+							},
 						},
 					},
 				},
@@ -860,7 +1014,9 @@ func (s *modelManagerSuite) TestDestroyModelsV3(c *gc.C) {
 			&modelmanager.ModelManagerAPIV5{
 				&modelmanager.ModelManagerAPIV6{
 					&modelmanager.ModelManagerAPIV7{
-						s.api,
+						&modelmanager.ModelManagerAPIV8{
+							s.api,
+						},
 					},
 				},
 			},
@@ -1710,7 +1866,9 @@ func (s *modelManagerSuite) TestModelStatusV2(c *gc.C) {
 				&modelmanager.ModelManagerAPIV5{
 					&modelmanager.ModelManagerAPIV6{
 						&modelmanager.ModelManagerAPIV7{
-							s.api,
+							&modelmanager.ModelManagerAPIV8{
+								s.api,
+							},
 						},
 					},
 				},
@@ -1749,7 +1907,9 @@ func (s *modelManagerSuite) TestModelStatusV3(c *gc.C) {
 			&modelmanager.ModelManagerAPIV5{
 				&modelmanager.ModelManagerAPIV6{
 					&modelmanager.ModelManagerAPIV7{
-						s.api,
+						&modelmanager.ModelManagerAPIV8{
+							s.api,
+						},
 					},
 				},
 			},
@@ -0,0 +1,175 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmanager
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/state"
+)
+
+// CreateModelTemplates creates the specified model templates. Only
+// controller admins may create templates.
+func (m *ModelManagerAPI) CreateModelTemplates(args params.ModelTemplates) (params.ErrorResults, error) {
+	return m.setModelTemplates(args, m.ctlrState.AddModelTemplate)
+}
+
+// UpdateModelTemplates replaces the settings of the specified model
+// templates. Only controller admins may update templates.
+func (m *ModelManagerAPI) UpdateModelTemplates(args params.ModelTemplates) (params.ErrorResults, error) {
+	return m.setModelTemplates(args, m.ctlrState.UpdateModelTemplate)
+}
+
+func (m *ModelManagerAPI) setModelTemplates(
+	args params.ModelTemplates, set func(state.ModelTemplate) error,
+) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Templates)),
+	}
+	if !m.isAdmin {
+		return results, apiservererrors.ErrPerm
+	}
+	for i, arg := range args.Templates {
+		template, err := modelTemplateFromParams(arg)
+		if err == nil {
+			template.CreatedBy = m.apiUser.Id()
+			err = set(template)
+		}
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+// ListModelTemplates returns all the model templates on the controller.
+// Only users who may add models to one of the controller's clouds may
+// list the templates.
+func (m *ModelManagerAPI) ListModelTemplates() (params.ModelTemplates, error) {
+	if err := m.checkCanAddModels(); err != nil {
+		return params.ModelTemplates{}, errors.Trace(err)
+	}
+	templates, err := m.ctlrState.ModelTemplates()
+	if err != nil {
+		return params.ModelTemplates{}, errors.Trace(err)
+	}
+	result := params.ModelTemplates{
+		Templates: make([]params.ModelTemplate, len(templates)),
+	}
+	for i, template := range templates {
+		result.Templates[i] = modelTemplateToParams(template)
+	}
+	return result, nil
+}
+
+// checkCanAddModels returns an error if the API user may not add models
+// to any of the controller's clouds.
+func (m *ModelManagerAPI) checkCanAddModels() error {
+	if m.isAdmin {
+		return nil
+	}
+	clouds, err := m.ctlrState.Clouds()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for tag := range clouds {
+		canAddModel, err := m.checkAddModelPermission(tag.Id(), m.apiUser)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if canAddModel {
+			return nil
+		}
+	}
+	return apiservererrors.ErrPerm
+}
+
+// CreateModelTemplates isn't on the V8 API.
+func (*ModelManagerAPIV8) CreateModelTemplates(_, _ struct{}) {}
+
+// UpdateModelTemplates isn't on the V8 API.
+func (*ModelManagerAPIV8) UpdateModelTemplates(_, _ struct{}) {}
+
+// ListModelTemplates isn't on the V8 API.
+func (*ModelManagerAPIV8) ListModelTemplates(_, _ struct{}) {}
+
+func modelTemplateFromParams(arg params.ModelTemplate) (state.ModelTemplate, error) {
+	template := state.ModelTemplate{
+		Name:        arg.Name,
+		Config:      arg.Config,
+		Constraints: arg.Constraints,
+		Spaces:      arg.Spaces,
+		Bundle:      arg.Bundle,
+	}
+	for _, pool := range arg.StoragePools {
+		template.StoragePools = append(template.StoragePools, state.ModelTemplateStoragePool{
+			Name:     pool.Name,
+			Provider: pool.Provider,
+			Attrs:    pool.Attrs,
+		})
+	}
+	for _, user := range arg.Users {
+		userTag, err := names.ParseUserTag(user.UserTag)
+		if err != nil {
+			return state.ModelTemplate{}, errors.Trace(err)
+		}
+		template.Users = append(template.Users, state.ModelTemplateUser{
+			User:   userTag,
+			Access: permission.Access(user.Access),
+		})
+	}
+	return template, nil
+}
+
+func modelTemplateToParams(template state.ModelTemplate) params.ModelTemplate {
+	result := params.ModelTemplate{
+		Name:        template.Name,
+		Config:      template.Config,
+		Constraints: template.Constraints,
+		Spaces:      template.Spaces,
+		Bundle:      template.Bundle,
+		CreatedBy:   template.CreatedBy,
+		DateUpdated: template.DateUpdated,
+	}
+	for _, pool := range template.StoragePools {
+		result.StoragePools = append(result.StoragePools, params.ModelTemplateStoragePool{
+			Name:     pool.Name,
+			Provider: pool.Provider,
+			Attrs:    pool.Attrs,
+		})
+	}
+	for _, user := range template.Users {
+		result.Users = append(result.Users, params.ModelTemplateUser{
+			UserTag: user.User.String(),
+			Access:  string(user.Access),
+		})
+	}
+	return result
+}
+
+// templateModelConfig returns the config for a model created from the
+// template, with the specified config taking precedence over the
+// template's config.
+func templateModelConfig(template state.ModelTemplate, attrs map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	for k, v := range template.Config {
+		result[k] = v
+	}
+	for k, v := range attrs {
+		result[k] = v
+	}
+	return result
+}
+
+// templateModelArgs returns the args for creating a model, with the
+// settings of the template the model is created from.
+func templateModelArgs(template state.ModelTemplate, args state.ModelArgs) state.ModelArgs {
+	args.Constraints = template.Constraints
+	args.StoragePools = template.StoragePools
+	args.Spaces = template.Spaces
+	args.Users = template.Users
+	return args
+}
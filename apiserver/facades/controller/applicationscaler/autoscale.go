@@ -0,0 +1,190 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationscaler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.applicationscaler")
+
+// autoscaleMetricWindow is how recently a metric must have been reported
+// to be measured by the autoscaler, so that units which have stopped
+// reporting don't keep driving scaling decisions.
+const autoscaleMetricWindow = 10 * time.Minute
+
+// AutoscaleApplication exposes the application functionality required
+// for autoscaling.
+type AutoscaleApplication interface {
+	Name() string
+	ApplicationConfig() (application.ConfigAttributes, error)
+	MinUnits() int
+	AutoscaleUnits() (int, error)
+	MetricMean(key string, window time.Duration) (float64, bool, error)
+	WorkloadStatusCount(status.Status) (int, error)
+	Autoscale(units int) error
+}
+
+// FacadeV1 provides the ApplicationScaler API facade for version 1,
+// which lacks autoscaling.
+type FacadeV1 struct {
+	*Facade
+}
+
+// AutoscaleCandidates isn't on the V1 API.
+func (*FacadeV1) AutoscaleCandidates(_, _ struct{}) {}
+
+// ScaleApplications isn't on the V1 API.
+func (*FacadeV1) ScaleApplications(_, _ struct{}) {}
+
+// AutoscaleCandidates returns the applications whose autoscale policy is
+// enabled, with the latest measurement of each policy's source.
+func (facade *Facade) AutoscaleCandidates() (params.AutoscaleCandidatesResult, error) {
+	candidates, err := facade.autoscaleCandidates()
+	if err != nil {
+		return params.AutoscaleCandidatesResult{Error: apiservererrors.ServerError(err)}, nil
+	}
+	return params.AutoscaleCandidatesResult{Candidates: candidates}, nil
+}
+
+func (facade *Facade) autoscaleCandidates() ([]params.AutoscaleCandidate, error) {
+	apps, err := facade.backend.AutoscaleApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	candidates := []params.AutoscaleCandidate{}
+	for _, app := range apps {
+		policy, err := autoscalePolicy(app)
+		if err != nil {
+			logger.Warningf("application %q: %v", app.Name(), err)
+			continue
+		} else if !policy.Enabled() {
+			continue
+		}
+		units, err := app.AutoscaleUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		candidate := params.AutoscaleCandidate{
+			ApplicationTag:     names.NewApplicationTag(app.Name()).String(),
+			Units:              units,
+			Source:             policy.Source.String(),
+			MinUnits:           policy.MinUnits,
+			MaxUnits:           policy.MaxUnits,
+			ScaleUpThreshold:   policy.ScaleUpThreshold,
+			ScaleDownThreshold: policy.ScaleDownThreshold,
+			Cooldown:           policy.Cooldown,
+		}
+		if policy.Source.Metric != "" {
+			value, ok, err := app.MetricMean(policy.Source.Metric, autoscaleMetricWindow)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if ok {
+				candidate.Value = &value
+			}
+		} else {
+			count, err := app.WorkloadStatusCount(policy.Source.Status)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			value := float64(count)
+			candidate.Value = &value
+		}
+		lastScaled, err := facade.backend.LastAutoscaled(app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !lastScaled.IsZero() {
+			candidate.LastScaled = &lastScaled
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
+
+// ScaleApplications changes the number of units of each of the given
+// applications as decided by the autoscaler, and records each decision
+// along with any error. The applications' autoscale policies are checked
+// again before scaling.
+func (facade *Facade) ScaleApplications(args params.AutoscaleDecisions) params.ErrorResults {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Decisions)),
+	}
+	for i, decision := range args.Decisions {
+		err := facade.scaleApplication(decision)
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results
+}
+
+func (facade *Facade) scaleApplication(decision params.AutoscaleDecision) error {
+	tag, err := names.ParseApplicationTag(decision.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := facade.backend.AutoscaleApplication(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	policy, err := autoscalePolicy(app)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !policy.Enabled() {
+		return errors.NotValidf("scaling application %q without an autoscale policy", tag.Id())
+	}
+	if decision.ToUnits < policy.MinUnits || decision.ToUnits > policy.MaxUnits {
+		return errors.NotValidf(
+			"scaling application %q to %d unit(s) outside %d-%d",
+			tag.Id(), decision.ToUnits, policy.MinUnits, policy.MaxUnits,
+		)
+	}
+	scaleErr := app.Autoscale(decision.ToUnits)
+	if err := facade.backend.RecordAutoscaleDecision(state.AutoscaleDecisionArgs{
+		Application: tag.Id(),
+		FromUnits:   decision.FromUnits,
+		ToUnits:     decision.ToUnits,
+		Source:      decision.Source,
+		Value:       decision.Value,
+		Reason:      decision.Reason,
+		Err:         scaleErr,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(scaleErr)
+}
+
+func autoscalePolicy(app AutoscaleApplication) (application.AutoscalePolicy, error) {
+	cfg, err := app.ApplicationConfig()
+	if err != nil {
+		return application.AutoscalePolicy{}, errors.Trace(err)
+	}
+	policy, err := cfg.AutoscalePolicy()
+	if err != nil || !policy.Enabled() {
+		return policy, errors.Trace(err)
+	}
+	// The application's min-units are maintained regardless of its
+	// autoscale policy, so the autoscaler mustn't scale below them.
+	if minUnits := app.MinUnits(); minUnits > policy.MinUnits {
+		if minUnits > policy.MaxUnits {
+			return application.AutoscalePolicy{}, errors.NotValidf(
+				"%s %d less than min-units %d",
+				application.AutoscaleMaxUnitsOptionName, policy.MaxUnits, minUnits,
+			)
+		}
+		policy.MinUnits = minUnits
+	}
+	return policy, nil
+}
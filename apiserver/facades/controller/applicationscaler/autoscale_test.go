@@ -0,0 +1,203 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationscaler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/controller/applicationscaler"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+type AutoscaleSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&AutoscaleSuite{})
+
+func (s *AutoscaleSuite) newFacade(c *gc.C, backend applicationscaler.Backend) *applicationscaler.Facade {
+	facade, err := applicationscaler.NewFacade(backend, nil, auth(true))
+	c.Assert(err, jc.ErrorIsNil)
+	return facade
+}
+
+func (s *AutoscaleSuite) TestAutoscaleCandidates(c *gc.C) {
+	lastScaled := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	backend := &autoscaleBackend{
+		apps: []*mockApplication{{
+			name:      "metered",
+			config:    autoscaleConfig("metric:load"),
+			units:     2,
+			metric:    0.9,
+			hasMetric: true,
+		}, {
+			name:     "busy",
+			config:   autoscaleConfig("status:maintenance"),
+			units:    1,
+			statuses: map[status.Status]int{status.Maintenance: 1},
+		}, {
+			name:   "unmeasured",
+			config: autoscaleConfig("metric:load"),
+			units:  1,
+		}, {
+			name:   "manual",
+			config: application.ConfigAttributes{},
+			units:  4,
+		}, {
+			name: "broken",
+			config: application.ConfigAttributes{
+				application.AutoscaleSourceOptionName: "metric:load",
+			},
+		}},
+		lastScaled: map[string]time.Time{"metered": lastScaled},
+	}
+	result, err := s.newFacade(c, backend).AutoscaleCandidates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	metered, busy := 0.9, 1.0
+	expect := func(tag, source string, units int, value *float64, last *time.Time) params.AutoscaleCandidate {
+		return params.AutoscaleCandidate{
+			ApplicationTag:     tag,
+			Units:              units,
+			Source:             source,
+			Value:              value,
+			MinUnits:           1,
+			MaxUnits:           3,
+			ScaleUpThreshold:   0.8,
+			ScaleDownThreshold: 0.2,
+			Cooldown:           time.Minute,
+			LastScaled:         last,
+		}
+	}
+	c.Assert(result.Candidates, jc.DeepEquals, []params.AutoscaleCandidate{
+		expect("application-metered", "metric:load", 2, &metered, &lastScaled),
+		expect("application-busy", "status:maintenance", 1, &busy, nil),
+		expect("application-unmeasured", "metric:load", 1, nil, nil),
+	})
+	// Only recently reported metrics are measured.
+	c.Assert(backend.apps[0].metricWindow, gc.Equals, 10*time.Minute)
+}
+
+func (s *AutoscaleSuite) TestScaleApplications(c *gc.C) {
+	app := &mockApplication{name: "metered", config: autoscaleConfig("metric:load")}
+	backend := &autoscaleBackend{apps: []*mockApplication{app}}
+	results := s.newFacade(c, backend).ScaleApplications(params.AutoscaleDecisions{
+		Decisions: []params.AutoscaleDecision{{
+			ApplicationTag: "application-metered",
+			FromUnits:      2,
+			ToUnits:        3,
+			Source:         "metric:load",
+			Value:          0.9,
+			Reason:         "metric:load 0.9 above 0.8",
+		}},
+	})
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	c.Check(app.scaledTo, jc.DeepEquals, []int{3})
+	c.Check(backend.recorded, jc.DeepEquals, []state.AutoscaleDecisionArgs{{
+		Application: "metered",
+		FromUnits:   2,
+		ToUnits:     3,
+		Source:      "metric:load",
+		Value:       0.9,
+		Reason:      "metric:load 0.9 above 0.8",
+	}})
+}
+
+func (s *AutoscaleSuite) TestScaleApplicationsRecordsFailure(c *gc.C) {
+	app := &mockApplication{
+		name:     "metered",
+		config:   autoscaleConfig("metric:load"),
+		scaleErr: errors.New("no machines"),
+	}
+	backend := &autoscaleBackend{apps: []*mockApplication{app}}
+	results := s.newFacade(c, backend).ScaleApplications(params.AutoscaleDecisions{
+		Decisions: []params.AutoscaleDecision{{
+			ApplicationTag: "application-metered",
+			FromUnits:      2,
+			ToUnits:        3,
+		}},
+	})
+	c.Assert(results.OneError(), gc.ErrorMatches, "no machines")
+	c.Assert(backend.recorded, gc.HasLen, 1)
+	c.Check(backend.recorded[0].Err, gc.ErrorMatches, "no machines")
+}
+
+func (s *AutoscaleSuite) TestScaleApplicationsChecksPolicy(c *gc.C) {
+	backend := &autoscaleBackend{apps: []*mockApplication{{
+		name:   "metered",
+		config: autoscaleConfig("metric:load"),
+	}, {
+		name:   "manual",
+		config: application.ConfigAttributes{},
+	}}}
+	results := s.newFacade(c, backend).ScaleApplications(params.AutoscaleDecisions{
+		Decisions: []params.AutoscaleDecision{
+			{ApplicationTag: "application-metered", FromUnits: 3, ToUnits: 4},
+			{ApplicationTag: "application-manual", FromUnits: 1, ToUnits: 2},
+			{ApplicationTag: "application-missing", FromUnits: 1, ToUnits: 2},
+			{ApplicationTag: "machine-0", FromUnits: 1, ToUnits: 2},
+		},
+	})
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `scaling application "metered" to 4 unit\(s\) outside 1-3 not valid`)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `scaling application "manual" without an autoscale policy not valid`)
+	c.Check(results.Results[2].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `"machine-0" is not a valid application tag`)
+	c.Check(backend.recorded, gc.HasLen, 0)
+}
+
+func (s *AutoscaleSuite) TestAutoscaleCandidatesMinUnits(c *gc.C) {
+	backend := &autoscaleBackend{
+		apps: []*mockApplication{{
+			name:     "kept",
+			config:   autoscaleConfig("status:maintenance"),
+			minUnits: 2,
+			units:    2,
+		}, {
+			name:     "unbounded",
+			config:   autoscaleConfig("status:maintenance"),
+			minUnits: 4,
+			units:    4,
+		}},
+	}
+	result, err := s.newFacade(c, backend).AutoscaleCandidates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Candidates, gc.HasLen, 1)
+	c.Check(result.Candidates[0].ApplicationTag, gc.Equals, "application-kept")
+	c.Check(result.Candidates[0].MinUnits, gc.Equals, 2)
+	c.Check(result.Candidates[0].MaxUnits, gc.Equals, 3)
+}
+
+func (s *AutoscaleSuite) TestScaleApplicationsChecksMinUnits(c *gc.C) {
+	backend := &autoscaleBackend{apps: []*mockApplication{{
+		name:     "metered",
+		config:   autoscaleConfig("metric:load"),
+		minUnits: 2,
+	}, {
+		name:     "unbounded",
+		config:   autoscaleConfig("metric:load"),
+		minUnits: 4,
+	}}}
+	results := s.newFacade(c, backend).ScaleApplications(params.AutoscaleDecisions{
+		Decisions: []params.AutoscaleDecision{
+			{ApplicationTag: "application-metered", FromUnits: 2, ToUnits: 1},
+			{ApplicationTag: "application-unbounded", FromUnits: 4, ToUnits: 3},
+		},
+	})
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `scaling application "metered" to 1 unit\(s\) outside 2-3 not valid`)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `autoscale-max-units 3 less than min-units 4 not valid`)
+	c.Check(backend.apps[0].scaledTo, gc.HasLen, 0)
+	c.Check(backend.recorded, gc.HasLen, 0)
+}
@@ -4,6 +4,8 @@
 package applicationscaler
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
@@ -24,6 +26,21 @@ type Backend interface {
 	// RescaleService ensures that the named service has at least its
 	// configured minimum unit count.
 	RescaleService(name string) error
+
+	// AutoscaleApplications returns the model's alive applications.
+	AutoscaleApplications() ([]AutoscaleApplication, error)
+
+	// AutoscaleApplication returns the named application.
+	AutoscaleApplication(name string) (AutoscaleApplication, error)
+
+	// LastAutoscaled returns the time of the latest scaling decision
+	// recorded for the named application, or the zero time if there
+	// has been none.
+	LastAutoscaled(name string) (time.Time, error)
+
+	// RecordAutoscaleDecision records a change made, or attempted, by
+	// the autoscaler.
+	RecordAutoscaleDecision(args state.AutoscaleDecisionArgs) error
 }
 
 // Facade allows model-manager clients to watch and rescale services.
@@ -4,6 +4,8 @@
 package applicationscaler
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/apiserver/facade"
@@ -20,6 +22,15 @@ func NewAPI(st *state.State, res facade.Resources, auth facade.Authorizer) (*Fac
 	return NewFacade(backendShim{st}, res, auth)
 }
 
+// NewAPIV1 provides the required signature for V1 facade registration.
+func NewAPIV1(st *state.State, res facade.Resources, auth facade.Authorizer) (*FacadeV1, error) {
+	api, err := NewAPI(st, res, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{api}, nil
+}
+
 // backendShim wraps a *State to implement Backend without pulling in direct
 // mongodb dependencies. It would be awesome if we were to put this in state
 // and test it properly there, where we have no choice but to test against
@@ -44,3 +55,50 @@ func (shim backendShim) RescaleService(name string) error {
 	}
 	return service.EnsureMinUnits()
 }
+
+// AutoscaleApplications is part of the Backend interface.
+func (shim backendShim) AutoscaleApplications() ([]AutoscaleApplication, error) {
+	apps, err := shim.st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []AutoscaleApplication
+	for _, app := range apps {
+		if app.Life() == state.Alive {
+			result = append(result, app)
+		}
+	}
+	return result, nil
+}
+
+// AutoscaleApplication is part of the Backend interface.
+func (shim backendShim) AutoscaleApplication(name string) (AutoscaleApplication, error) {
+	app, err := shim.st.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app, nil
+}
+
+// LastAutoscaled is part of the Backend interface.
+func (shim backendShim) LastAutoscaled(name string) (time.Time, error) {
+	model, err := shim.st.Model()
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	decisions, err := model.AutoscaleDecisions(name)
+	if err != nil || len(decisions) == 0 {
+		return time.Time{}, errors.Trace(err)
+	}
+	return decisions[len(decisions)-1].Time(), nil
+}
+
+// RecordAutoscaleDecision is part of the Backend interface.
+func (shim backendShim) RecordAutoscaleDecision(args state.AutoscaleDecisionArgs) error {
+	model, err := shim.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = model.RecordAutoscaleDecision(args)
+	return errors.Trace(err)
+}
@@ -4,6 +4,8 @@
 package applicationscaler_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -12,6 +14,8 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/facades/controller/applicationscaler"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
 )
 
@@ -103,6 +107,99 @@ func newRescaleFixture(c *gc.C) *rescaleFixture {
 	return &rescaleFixture{facade}
 }
 
+// mockApplication implements applicationscaler.AutoscaleApplication for
+// the tests' convenience.
+type mockApplication struct {
+	name      string
+	config    application.ConfigAttributes
+	minUnits  int
+	units     int
+	metric    float64
+	hasMetric bool
+	statuses  map[status.Status]int
+	scaleErr  error
+	scaledTo  []int
+
+	metricWindow time.Duration
+}
+
+func (mock *mockApplication) Name() string {
+	return mock.name
+}
+
+func (mock *mockApplication) ApplicationConfig() (application.ConfigAttributes, error) {
+	return mock.config, nil
+}
+
+func (mock *mockApplication) MinUnits() int {
+	return mock.minUnits
+}
+
+func (mock *mockApplication) AutoscaleUnits() (int, error) {
+	return mock.units, nil
+}
+
+func (mock *mockApplication) MetricMean(key string, window time.Duration) (float64, bool, error) {
+	mock.metricWindow = window
+	return mock.metric, mock.hasMetric, nil
+}
+
+func (mock *mockApplication) WorkloadStatusCount(s status.Status) (int, error) {
+	return mock.statuses[s], nil
+}
+
+func (mock *mockApplication) Autoscale(units int) error {
+	mock.scaledTo = append(mock.scaledTo, units)
+	return mock.scaleErr
+}
+
+// autoscaleBackend implements applicationscaler.Backend for the
+// convenience of the tests for the autoscaling methods.
+type autoscaleBackend struct {
+	applicationscaler.Backend
+	apps       []*mockApplication
+	lastScaled map[string]time.Time
+	recorded   []state.AutoscaleDecisionArgs
+}
+
+func (backend *autoscaleBackend) AutoscaleApplications() ([]applicationscaler.AutoscaleApplication, error) {
+	var result []applicationscaler.AutoscaleApplication
+	for _, app := range backend.apps {
+		result = append(result, app)
+	}
+	return result, nil
+}
+
+func (backend *autoscaleBackend) AutoscaleApplication(name string) (applicationscaler.AutoscaleApplication, error) {
+	for _, app := range backend.apps {
+		if app.name == name {
+			return app, nil
+		}
+	}
+	return nil, errors.NotFoundf("application %q", name)
+}
+
+func (backend *autoscaleBackend) LastAutoscaled(name string) (time.Time, error) {
+	return backend.lastScaled[name], nil
+}
+
+func (backend *autoscaleBackend) RecordAutoscaleDecision(args state.AutoscaleDecisionArgs) error {
+	backend.recorded = append(backend.recorded, args)
+	return nil
+}
+
+// autoscaleConfig returns application config enabling autoscaling
+// between 1 and 3 units of the given source.
+func autoscaleConfig(source string) application.ConfigAttributes {
+	return application.ConfigAttributes{
+		application.AutoscaleSourceOptionName:             source,
+		application.AutoscaleMaxUnitsOptionName:           3,
+		application.AutoscaleScaleUpThresholdOptionName:   "0.8",
+		application.AutoscaleScaleDownThresholdOptionName: "0.2",
+		application.AutoscaleCooldownOptionName:           "1m",
+	}
+}
+
 // entities is a convenience constructor for params.Entities.
 func entities(tags ...string) params.Entities {
 	entities := params.Entities{Entities: make([]params.Entity, len(tags))}
@@ -4,17 +4,23 @@
 package charmrevisionupdater
 
 import (
+	"reflect"
 	"strconv"
 	"strings"
 
+	"github.com/juju/charm/v7"
+	csparams "github.com/juju/charmrepo/v5/csclient/params"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
 
+	"github.com/juju/juju/apiserver/common/charmscommon"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/version"
 )
@@ -24,6 +30,8 @@ var logger = loggo.GetLogger("juju.apiserver.charmrevisionupdater")
 // CharmRevisionUpdater defines the methods on the charmrevisionupdater API end point.
 type CharmRevisionUpdater interface {
 	UpdateLatestRevisions() (params.ErrorResult, error)
+	CharmUpgradeCandidates() (params.CharmUpgradeCandidatesResult, error)
+	UpgradeCharms(params.CharmUpgradeArgs) (params.CharmUpgradeResults, error)
 }
 
 // CharmRevisionUpdaterAPI implements the CharmRevisionUpdater interface and is the concrete
@@ -34,8 +42,34 @@ type CharmRevisionUpdaterAPI struct {
 	authorizer facade.Authorizer
 }
 
+// CharmRevisionUpdaterAPIV2 implements the V2 CharmRevisionUpdater API,
+// which lacks automatic charm upgrades.
+type CharmRevisionUpdaterAPIV2 struct {
+	*CharmRevisionUpdaterAPI
+}
+
 var _ CharmRevisionUpdater = (*CharmRevisionUpdaterAPI)(nil)
 
+// NewCharmRevisionUpdaterAPIV2 creates a new server-side V2
+// charmrevisionupdater API end point.
+func NewCharmRevisionUpdaterAPIV2(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*CharmRevisionUpdaterAPIV2, error) {
+	api, err := NewCharmRevisionUpdaterAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &CharmRevisionUpdaterAPIV2{api}, nil
+}
+
+// CharmUpgradeCandidates isn't on the V2 API.
+func (*CharmRevisionUpdaterAPIV2) CharmUpgradeCandidates(_, _ struct{}) {}
+
+// UpgradeCharms isn't on the V2 API.
+func (*CharmRevisionUpdaterAPIV2) UpgradeCharms(_, _ struct{}) {}
+
 // NewCharmRevisionUpdaterAPI creates a new server-side charmrevisionupdater API end point.
 func NewCharmRevisionUpdaterAPI(
 	st *state.State,
@@ -91,6 +125,197 @@ func (api *CharmRevisionUpdaterAPI) updateLatestRevisions() error {
 	return nil
 }
 
+// CharmUpgradeCandidates returns the applications whose charm update
+// policy is to upgrade automatically, and for which a later revision of
+// their charm has been found by UpdateLatestRevisions.
+func (api *CharmRevisionUpdaterAPI) CharmUpgradeCandidates() (params.CharmUpgradeCandidatesResult, error) {
+	candidates, err := api.charmUpgradeCandidates()
+	if err != nil {
+		return params.CharmUpgradeCandidatesResult{Error: apiservererrors.ServerError(err)}, nil
+	}
+	return params.CharmUpgradeCandidatesResult{Candidates: candidates}, nil
+}
+
+func (api *CharmRevisionUpdaterAPI) charmUpgradeCandidates() ([]params.CharmUpgradeCandidate, error) {
+	applications, err := api.state.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := api.state.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	configs, err := model.AllApplicationConfigs()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	candidates := []params.CharmUpgradeCandidate{}
+	for _, app := range applications {
+		if app.Life() != state.Alive {
+			continue
+		}
+		curl, _ := app.CharmURL()
+		if curl.Schema != "cs" {
+			continue
+		}
+		cfg := configs[app.Name()]
+		if policy, err := cfg.CharmUpdatePolicy(); err != nil {
+			logger.Warningf("application %q: %v", app.Name(), err)
+			continue
+		} else if policy != application.CharmUpdateAuto {
+			continue
+		}
+		latest, err := api.state.LatestPlaceholderCharm(curl)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if latest.Revision() <= curl.Revision {
+			continue
+		}
+		candidates = append(candidates, params.CharmUpgradeCandidate{
+			ApplicationTag:    app.ApplicationTag().String(),
+			CharmURL:          curl.String(),
+			LatestCharmURL:    latest.URL().String(),
+			MaintenanceWindow: cfg.GetString(application.CharmUpdateWindowOptionName, ""),
+		})
+	}
+	return candidates, nil
+}
+
+// UpgradeCharms upgrades each of the given applications to the given
+// revision of its charm, as upgrade-charm would, and records each upgrade
+// as an operation along with the charm it replaced so that it can be
+// rolled back. The applications' charm update policies are checked again
+// before upgrading.
+func (api *CharmRevisionUpdaterAPI) UpgradeCharms(args params.CharmUpgradeArgs) (params.CharmUpgradeResults, error) {
+	results := params.CharmUpgradeResults{
+		Results: make([]params.CharmUpgradeResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		operationID, err := api.upgradeCharm(arg)
+		results.Results[i] = params.CharmUpgradeResult{
+			OperationId: operationID,
+			Error:       apiservererrors.ServerError(err),
+		}
+	}
+	return results, nil
+}
+
+func (api *CharmRevisionUpdaterAPI) upgradeCharm(arg params.CharmUpgradeArg) (string, error) {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	newURL, err := charm.ParseURL(arg.CharmURL)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := api.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	cfg, err := app.ApplicationConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	policy, err := cfg.CharmUpdatePolicy()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if policy != application.CharmUpdateAuto {
+		return "", errors.Errorf("application %q has charm update policy %q", app.Name(), policy)
+	}
+	curl, _ := app.CharmURL()
+	if *curl.WithRevision(-1) != *newURL.WithRevision(-1) {
+		return "", errors.NotValidf("automatic upgrade of application %q from %s to a different charm %s", app.Name(), curl, newURL)
+	}
+	if newURL.Revision <= curl.Revision {
+		return "", errors.NotValidf("automatic upgrade of application %q from %s to earlier revision %s", app.Name(), curl, newURL)
+	}
+
+	channel := app.Channel()
+	upgradeErr := api.setCharm(app, newURL, channel)
+	model, err := api.state.Model()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	upgrade, err := model.RecordCharmUpgrade(state.CharmUpgradeArgs{
+		Application: app.Name(),
+		From:        curl,
+		To:          newURL,
+		Channel:     channel,
+		Err:         upgradeErr,
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if upgradeErr != nil {
+		return upgrade.OperationId(), errors.Trace(upgradeErr)
+	}
+	logger.Infof("upgraded application %q from %s to %s (operation %s)", app.Name(), curl, newURL, upgrade.OperationId())
+	return upgrade.OperationId(), nil
+}
+
+// setCharm adds the charm to the model, downloading it from the charm
+// store if needed, and upgrades the application to it.
+func (api *CharmRevisionUpdaterAPI) setCharm(app *state.Application, curl *charm.URL, channel csparams.Channel) error {
+	if err := AddCharm(api.state, curl, channel); err != nil {
+		return errors.Annotatef(err, "adding charm %s", curl)
+	}
+	newCharm, err := api.state.Charm(curl)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	currentCharm, _, err := app.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	oldMeta, newMeta := currentCharm.Meta(), newCharm.Meta()
+	if !reflect.DeepEqual(oldMeta.Resources, newMeta.Resources) {
+		// refresh uploads or resolves the resources of the new charm
+		// before switching to it; without a client to do that, leave
+		// such upgrades to the operator.
+		return errors.NotSupportedf("automatic upgrade changing resources")
+	}
+	model, err := api.state.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if model.Type() == state.ModelTypeCAAS {
+		// As with upgrade-charm, k8s does not support changing the
+		// deployment info, storage or devices of an application.
+		if !reflect.DeepEqual(oldMeta.Deployment, newMeta.Deployment) ||
+			!reflect.DeepEqual(oldMeta.Storage, newMeta.Storage) ||
+			!reflect.DeepEqual(oldMeta.Devices, newMeta.Devices) {
+			return errors.NotSupportedf("automatic upgrade changing deployment info, storage or devices")
+		}
+	}
+	// As with refresh run without --force, --force-units or
+	// --force-series, the series, LXD profile and storage of the new
+	// charm are validated, and units in an error state are left to be
+	// upgraded once they are resolved.
+	return errors.Trace(app.SetCharm(state.SetCharmConfig{
+		Charm:   newCharm,
+		Channel: channel,
+	}))
+}
+
+// AddCharm adds the charm store charm with the given URL to the model,
+// if it has not been added already. Exported so it can be replaced
+// during testing.
+var AddCharm = func(st *state.State, curl *charm.URL, channel csparams.Channel) error {
+	return charmscommon.AddCharmWithAuthorization(
+		charmscommon.NewStateShim(st),
+		params.AddCharmWithAuthorization{
+			URL:     curl.String(),
+			Channel: string(channel),
+		},
+		charmscommon.OpenCSRepo,
+	)
+}
+
 // NewCharmStoreClient instantiates a new charm store repository.  Exported so
 // we can change it during testing.
 var NewCharmStoreClient = func(st *state.State) (charmstore.Client, error) {
@@ -5,15 +5,19 @@ package charmrevisionupdater_test
 
 import (
 	"github.com/juju/charm/v7"
+	csparams "github.com/juju/charmrepo/v5/csclient/params"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater"
 	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater/testing"
+	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/application"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 )
@@ -137,3 +141,106 @@ func (s *charmVersionSuite) TestWordpressCharmNoReadAccessIsNotVisible(c *gc.C)
 	_, err = s.State.LatestPlaceholderCharm(curl)
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
+
+func (s *charmVersionSuite) setCharmUpdatePolicy(c *gc.C, appName string, policy application.CharmUpdatePolicy, window string) {
+	app, err := s.State.Application(appName)
+	c.Assert(err, jc.ErrorIsNil)
+	schema := environschema.Fields{
+		application.CharmUpdatePolicyOptionName: {Type: environschema.Tstring},
+		application.CharmUpdateWindowOptionName: {Type: environschema.Tstring},
+	}
+	err = app.UpdateApplicationConfig(application.ConfigAttributes{
+		application.CharmUpdatePolicyOptionName: string(policy),
+		application.CharmUpdateWindowOptionName: window,
+	}, nil, schema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *charmVersionSuite) TestCharmUpgradeCandidates(c *gc.C) {
+	s.AddMachine(c, "0", state.JobManageModel)
+	s.SetupScenario(c)
+	result, err := s.charmrevisionupdater.UpdateLatestRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	// Only applications with the auto policy are candidates.
+	candidates, err := s.charmrevisionupdater.CharmUpgradeCandidates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(candidates.Error, gc.IsNil)
+	c.Assert(candidates.Candidates, gc.HasLen, 0)
+
+	s.setCharmUpdatePolicy(c, "mysql", application.CharmUpdateAuto, "sat 02:00-04:00")
+	// wordpress is already up to date.
+	s.setCharmUpdatePolicy(c, "wordpress", application.CharmUpdateAuto, "")
+
+	candidates, err = s.charmrevisionupdater.CharmUpgradeCandidates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(candidates.Error, gc.IsNil)
+	c.Assert(candidates.Candidates, jc.DeepEquals, []params.CharmUpgradeCandidate{{
+		ApplicationTag:    "application-mysql",
+		CharmURL:          "cs:quantal/mysql-22",
+		LatestCharmURL:    "cs:quantal/mysql-23",
+		MaintenanceWindow: "sat 02:00-04:00",
+	}})
+}
+
+func (s *charmVersionSuite) TestUpgradeCharms(c *gc.C) {
+	s.AddMachine(c, "0", state.JobManageModel)
+	s.SetupScenario(c)
+	s.setCharmUpdatePolicy(c, "mysql", application.CharmUpdateAuto, "")
+	s.PatchValue(&charmrevisionupdater.AddCharm, func(_ *state.State, curl *charm.URL, channel csparams.Channel) error {
+		c.Check(curl.String(), gc.Equals, "cs:quantal/mysql-23")
+		s.AddCharmWithRevision(c, "mysql", 23)
+		return nil
+	})
+
+	results, err := s.charmrevisionupdater.UpgradeCharms(params.CharmUpgradeArgs{
+		Args: []params.CharmUpgradeArg{
+			{ApplicationTag: "application-mysql", CharmURL: "cs:quantal/mysql-23"},
+			{ApplicationTag: "application-wordpress", CharmURL: "cs:quantal/wordpress-27"},
+			{ApplicationTag: "application-mysql", CharmURL: "cs:quantal/wordpress-27"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].OperationId, gc.Not(gc.Equals), "")
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "wordpress" has charm update policy "notify"`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `automatic upgrade of application "mysql" from .* to a different charm .* not valid`)
+
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Assert(curl.String(), gc.Equals, "cs:quantal/mysql-23")
+
+	upgrade, err := s.Model.CharmUpgrade(results.Results[0].OperationId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.ApplicationName(), gc.Equals, "mysql")
+	c.Assert(upgrade.From().String(), gc.Equals, "cs:quantal/mysql-22")
+	c.Assert(upgrade.To().String(), gc.Equals, "cs:quantal/mysql-23")
+	c.Assert(upgrade.Error(), gc.Equals, "")
+}
+
+func (s *charmVersionSuite) TestUpgradeCharmsRecordsFailure(c *gc.C) {
+	s.AddMachine(c, "0", state.JobManageModel)
+	s.SetupScenario(c)
+	s.setCharmUpdatePolicy(c, "mysql", application.CharmUpdateAuto, "")
+	s.PatchValue(&charmrevisionupdater.AddCharm, func(*state.State, *charm.URL, csparams.Channel) error {
+		return errors.New("boom")
+	})
+
+	results, err := s.charmrevisionupdater.UpgradeCharms(params.CharmUpgradeArgs{
+		Args: []params.CharmUpgradeArg{
+			{ApplicationTag: "application-mysql", CharmURL: "cs:quantal/mysql-23"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `adding charm cs:quantal/mysql-23: boom`)
+
+	upgrade, err := s.Model.CharmUpgrade(results.Results[0].OperationId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.Error(), gc.Equals, "adding charm cs:quantal/mysql-23: boom")
+	operation, err := s.Model.Operation(results.Results[0].OperationId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Status(), gc.Equals, state.ActionFailed)
+}
@@ -682,6 +682,13 @@ func (s *crossmodelRelationsSuite) TestPublishChangesWithApplicationSettings(c *
 	rel.CheckCall(c, 1, "ReplaceApplicationSettings", "db2", map[string]interface{}{
 		"slaughterhouse": "the-tongue",
 	})
+
+	event := s.st.remoteRelationEvents["db2:db django:db"]
+	c.Assert(event.ApplicationToken, gc.Equals, "token-db2")
+	c.Assert(event.Life, gc.Equals, life.Alive)
+	c.Assert(event.ChangedUnits, jc.DeepEquals, []int{1})
+	c.Assert(event.DepartedUnits, jc.DeepEquals, []int{2})
+	c.Assert(event.ApplicationSettingsChanged, jc.IsTrue)
 }
 
 func (s *crossmodelRelationsSuite) TestWatchRelationChanges(c *gc.C) {
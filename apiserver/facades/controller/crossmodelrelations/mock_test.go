@@ -55,6 +55,7 @@ type mockState struct {
 	firewallRules         map[corefirewall.WellKnownServiceType]*state.FirewallRule
 	ingressNetworks       map[string][]string
 	migrationActive       bool
+	remoteRelationEvents  map[string]state.RemoteRelationEvent
 }
 
 func newMockState() *mockState {
@@ -62,6 +63,7 @@ func newMockState() *mockState {
 		relations:             make(map[string]*mockRelation),
 		remoteApplications:    make(map[string]*mockRemoteApplication),
 		applications:          make(map[string]*mockApplication),
+		remoteRelationEvents:  make(map[string]state.RemoteRelationEvent),
 		remoteEntities:        make(map[names.Tag]string),
 		offers:                make(map[string]*crossmodel.ApplicationOffer),
 		offerNames:            make(map[string]string),
@@ -220,6 +222,11 @@ func (st *mockState) GetToken(entity names.Tag) (string, error) {
 	return "", errors.NotFoundf("entity %v", entity)
 }
 
+func (st *mockState) SetRemoteRelationEvent(relationKey string, event state.RemoteRelationEvent) error {
+	st.remoteRelationEvents[relationKey] = event
+	return nil
+}
+
 func (st *mockState) KeyRelation(key string) (commoncrossmodel.Relation, error) {
 	st.MethodCall(st, "KeyRelation", key)
 	if err := st.NextErr(); err != nil {
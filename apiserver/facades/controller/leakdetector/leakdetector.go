@@ -0,0 +1,466 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package leakdetector provides the API used to find, and optionally
+// release, cloud resources created by the controller that no model or
+// machine in state refers to any more.
+package leakdetector
+
+import (
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.leakdetector")
+
+// Backend exposes the controller state functionality required by the
+// facade.
+type Backend interface {
+	// ControllerTag returns the tag of the controller.
+	ControllerTag() names.ControllerTag
+
+	// ControllerModelUUID returns the UUID of the controller model.
+	ControllerModelUUID() string
+
+	// ModelUUIDs returns the UUIDs of all the models in the
+	// controller, whatever their life.
+	ModelUUIDs() ([]string, error)
+
+	// ModelBackend returns the ModelBackend for the model with the
+	// given UUID, along with a function that must be called to release
+	// it once it is no longer needed.
+	ModelBackend(modelUUID string) (ModelBackend, func(), error)
+}
+
+// ModelBackend exposes the model state functionality required by the
+// facade.
+type ModelBackend interface {
+	// Type returns the type of the model.
+	Type() state.ModelType
+
+	// Life returns the life of the model.
+	Life() state.Life
+
+	// Environ returns the environ for the model.
+	Environ() (environs.Environ, error)
+
+	// CallContext returns the context to use when calling the
+	// model's cloud.
+	CallContext() context.ProviderCallContext
+
+	// MachineIds returns the ids of all the model's machines, whether
+	// or not they have been provisioned.
+	MachineIds() ([]string, error)
+
+	// InstanceIds returns the ids of the model's provisioned instances,
+	// excluding containers, and the ids of the machines that have yet
+	// to be provisioned.
+	InstanceIds() ([]instance.Id, []string, error)
+
+	// VolumeIds returns the provider ids of the model's provisioned
+	// model-scoped volumes, and the ids of the storage instances of
+	// those that have yet to be provisioned. A volume yet to be
+	// provisioned that has no storage instance is reported with an
+	// empty storage id.
+	VolumeIds() ([]string, []string, error)
+}
+
+// Facade allows controller admins and the controller agent to find
+// cloud resources that have leaked from the controller's models.
+type Facade struct {
+	backend Backend
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(backend Backend, auth facade.Authorizer) (*Facade, error) {
+	if !auth.AuthController() {
+		isAdmin, err := auth.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !isAdmin {
+			return nil, apiservererrors.ErrPerm
+		}
+	}
+	return &Facade{backend: backend}, nil
+}
+
+// DetectLeaks returns the cloud resources that are tagged as belonging
+// to the controller, but which are not referred to by any machine or
+// volume in state, or whose model no longer exists. If args.Cleanup is
+// true, the resources found are also released.
+//
+// Models whose cloud cannot be queried are logged and skipped, so that
+// one broken model does not hide leaks in the others.
+func (f *Facade) DetectLeaks(args params.DetectLeaksArgs) (params.LeakedResourcesResult, error) {
+	modelUUIDs, err := f.backend.ModelUUIDs()
+	if err != nil {
+		return params.LeakedResourcesResult{}, errors.Trace(err)
+	}
+	result := params.LeakedResourcesResult{Resources: []params.LeakedResource{}}
+	for _, modelUUID := range modelUUIDs {
+		leaked, err := f.modelLeaks(modelUUID, args.Cleanup)
+		if err != nil {
+			logger.Warningf("cannot check model %q for leaked resources: %v", modelUUID, err)
+			continue
+		}
+		result.Resources = append(result.Resources, leaked...)
+	}
+	leaked, err := f.controllerLeaks(args.Cleanup)
+	if err != nil {
+		return params.LeakedResourcesResult{}, errors.Trace(err)
+	}
+	result.Resources = append(result.Resources, leaked...)
+	return result, nil
+}
+
+// modelLeaks returns the instances, volumes and security groups in the
+// cloud of the model with the given UUID that the model does not know
+// about.
+func (f *Facade) modelLeaks(modelUUID string, cleanup bool) ([]params.LeakedResource, error) {
+	model, release, err := f.backend.ModelBackend(modelUUID)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer release()
+	if model.Type() != state.ModelTypeIAAS || model.Life() != state.Alive {
+		// Dying models are still releasing their resources.
+		return nil, nil
+	}
+	env, err := model.Environ()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ctx := model.CallContext()
+
+	// Where the cloud supports it, the model's resources are listed
+	// along with the machine or storage they were created for, so that
+	// those of machines and volumes being provisioned can be told
+	// apart from leaked ones.
+	lister, _ := env.(environs.ControllerResourceLister)
+	var resources []environs.CloudResource
+	if lister != nil {
+		all, err := lister.ControllerResources(ctx, f.backend.ControllerTag().Id())
+		if err != nil {
+			return nil, errors.Annotate(err, "listing resources")
+		}
+		for _, r := range all {
+			if r.ModelUUID == modelUUID {
+				resources = append(resources, r)
+			}
+		}
+	}
+
+	instanceLeaks, err := f.instanceLeaks(ctx, modelUUID, env, model, lister, resources, cleanup)
+	if err != nil {
+		return nil, errors.Annotate(err, "checking instances")
+	}
+	volumeLeaks, err := f.volumeLeaks(ctx, modelUUID, env, model, lister, resources, cleanup)
+	if err != nil {
+		return nil, errors.Annotate(err, "checking volumes")
+	}
+	leaked := append(instanceLeaks, volumeLeaks...)
+	if lister == nil {
+		return leaked, nil
+	}
+	groupLeaks, err := f.securityGroupLeaks(ctx, modelUUID, model, lister, resources, cleanup)
+	if err != nil {
+		return nil, errors.Annotate(err, "checking security groups")
+	}
+	return append(leaked, groupLeaks...), nil
+}
+
+func (f *Facade) instanceLeaks(
+	ctx context.ProviderCallContext,
+	modelUUID string,
+	env environs.Environ,
+	model ModelBackend,
+	lister environs.ControllerResourceLister,
+	resources []environs.CloudResource,
+	cleanup bool,
+) ([]params.LeakedResource, error) {
+	// The cloud is listed before state is read, so that an instance
+	// provisioned in between is always recorded in state by the time
+	// we look for it.
+	insts, err := env.AllInstances(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	known, pending, err := model.InstanceIds()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(pending) > 0 && lister == nil {
+		logger.Warningf(
+			"not checking instances of model %q: cannot tell which belong to machines %s being provisioned",
+			modelUUID, strings.Join(pending, ", "),
+		)
+		return nil, nil
+	}
+	knownIds := set.NewStrings()
+	for _, id := range known {
+		knownIds.Add(string(id))
+	}
+	owners := resourceOwners(resources, environs.CloudResourceInstance)
+	pendingIds := set.NewStrings(pending...)
+	var ids []instance.Id
+	var leaked []params.LeakedResource
+	for _, inst := range insts {
+		id := string(inst.Id())
+		if knownIds.Contains(id) {
+			continue
+		}
+		if owner, ok := owners[id]; len(pending) > 0 && (!ok || owner == "" || pendingIds.Contains(owner)) {
+			logger.Debugf("not checking instance %q of model %q: it may belong to a machine being provisioned", id, modelUUID)
+			continue
+		}
+		ids = append(ids, inst.Id())
+		leaked = append(leaked, params.LeakedResource{
+			Kind:      string(environs.CloudResourceInstance),
+			Id:        id,
+			ModelUUID: modelUUID,
+			Reason:    "no machine in the model refers to this instance",
+		})
+	}
+	if cleanup && len(ids) > 0 {
+		err := env.StopInstances(ctx, ids...)
+		for i := range leaked {
+			setReleased(&leaked[i], err)
+		}
+	}
+	return leaked, nil
+}
+
+func (f *Facade) volumeLeaks(
+	ctx context.ProviderCallContext,
+	modelUUID string,
+	env environs.Environ,
+	model ModelBackend,
+	lister environs.ControllerResourceLister,
+	resources []environs.CloudResource,
+	cleanup bool,
+) ([]params.LeakedResource, error) {
+	sources, err := modelVolumeSources(env)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(sources) == 0 {
+		return nil, nil
+	}
+	sourceIds := make([][]string, len(sources))
+	for i, source := range sources {
+		if sourceIds[i], err = source.ListVolumes(ctx); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	known, pending, err := model.VolumeIds()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	pendingIds := set.NewStrings(pending...)
+	if len(pending) > 0 && (lister == nil || pendingIds.Contains("")) {
+		logger.Warningf(
+			"not checking volumes of model %q: cannot tell which belong to the %d volume(s) being provisioned",
+			modelUUID, len(pending),
+		)
+		return nil, nil
+	}
+	owners := resourceOwners(resources, environs.CloudResourceVolume)
+	seen := set.NewStrings(known...)
+	var leaked []params.LeakedResource
+	for i, source := range sources {
+		var ids []string
+		start := len(leaked)
+		for _, id := range sourceIds[i] {
+			if seen.Contains(id) {
+				continue
+			}
+			seen.Add(id)
+			if owner, ok := owners[id]; len(pending) > 0 && (!ok || owner == "" || pendingIds.Contains(owner)) {
+				logger.Debugf("not checking volume %q of model %q: it may belong to storage being provisioned", id, modelUUID)
+				continue
+			}
+			ids = append(ids, id)
+			leaked = append(leaked, params.LeakedResource{
+				Kind:      string(environs.CloudResourceVolume),
+				Id:        id,
+				ModelUUID: modelUUID,
+				Reason:    "no volume in the model refers to this volume",
+			})
+		}
+		if !cleanup || len(ids) == 0 {
+			continue
+		}
+		errs, err := source.DestroyVolumes(ctx, ids)
+		for j := range ids {
+			releaseErr := err
+			if releaseErr == nil {
+				releaseErr = errs[j]
+			}
+			setReleased(&leaked[start+j], releaseErr)
+		}
+	}
+	return leaked, nil
+}
+
+// securityGroupLeaks returns the per-machine security groups of the
+// model with the given UUID whose machine no longer exists.
+func (f *Facade) securityGroupLeaks(
+	ctx context.ProviderCallContext,
+	modelUUID string,
+	model ModelBackend,
+	lister environs.ControllerResourceLister,
+	resources []environs.CloudResource,
+	cleanup bool,
+) ([]params.LeakedResource, error) {
+	// The machines are read after the cloud is listed, so that the
+	// group of a machine created in between is not reported.
+	machineIds, err := model.MachineIds()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machines := set.NewStrings(machineIds...)
+	var orphans []environs.CloudResource
+	var leaked []params.LeakedResource
+	for _, r := range resources {
+		if r.Kind != environs.CloudResourceSecurityGroup || r.MachineId == "" || machines.Contains(r.MachineId) {
+			continue
+		}
+		orphans = append(orphans, r)
+		leaked = append(leaked, params.LeakedResource{
+			Kind:      string(r.Kind),
+			Id:        r.Id,
+			ModelUUID: modelUUID,
+			Reason:    "no machine in the model uses this security group",
+		})
+	}
+	if cleanup && len(orphans) > 0 {
+		errs := lister.ReleaseControllerResources(ctx, orphans)
+		for i := range leaked {
+			setReleased(&leaked[i], errs[i])
+		}
+	}
+	return leaked, nil
+}
+
+// resourceOwners maps the ids of the resources of the given kind to
+// the id of the machine or storage instance they were created for.
+func resourceOwners(resources []environs.CloudResource, kind environs.CloudResourceKind) map[string]string {
+	owners := make(map[string]string)
+	for _, r := range resources {
+		if r.Kind != kind {
+			continue
+		}
+		if kind == environs.CloudResourceVolume {
+			owners[r.Id] = r.StorageId
+		} else {
+			owners[r.Id] = r.MachineId
+		}
+	}
+	return owners
+}
+
+// modelVolumeSources returns the volume sources for the environ's
+// model-scoped block storage providers.
+func modelVolumeSources(env environs.Environ) ([]storage.VolumeSource, error) {
+	types, err := env.StorageProviderTypes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var sources []storage.VolumeSource
+	for _, providerType := range types {
+		provider, err := env.StorageProvider(providerType)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if provider.Scope() != storage.ScopeEnviron || !provider.Supports(storage.StorageKindBlock) {
+			continue
+		}
+		cfg, err := storage.NewConfig(string(providerType), providerType, map[string]interface{}{})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		source, err := provider.VolumeSource(cfg)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+// controllerLeaks returns the resources in the controller model's cloud
+// that are tagged with the UUID of a model that no longer exists.
+func (f *Facade) controllerLeaks(cleanup bool) ([]params.LeakedResource, error) {
+	model, release, err := f.backend.ModelBackend(f.backend.ControllerModelUUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer release()
+	env, err := model.Environ()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	lister, ok := env.(environs.ControllerResourceLister)
+	if !ok {
+		return nil, nil
+	}
+	ctx := model.CallContext()
+	resources, err := lister.ControllerResources(ctx, f.backend.ControllerTag().Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The models are read after the cloud is listed, so that the
+	// resources of a model created in between are not reported.
+	modelUUIDs, err := f.backend.ModelUUIDs()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	models := set.NewStrings(modelUUIDs...)
+	var orphans []environs.CloudResource
+	var leaked []params.LeakedResource
+	for _, r := range resources {
+		if r.ModelUUID == "" || models.Contains(r.ModelUUID) {
+			continue
+		}
+		orphans = append(orphans, r)
+		leaked = append(leaked, params.LeakedResource{
+			Kind:      string(r.Kind),
+			Id:        r.Id,
+			ModelUUID: r.ModelUUID,
+			Reason:    "model no longer exists",
+		})
+	}
+	if cleanup && len(orphans) > 0 {
+		errs := lister.ReleaseControllerResources(ctx, orphans)
+		for i := range leaked {
+			setReleased(&leaked[i], errs[i])
+		}
+	}
+	return leaked, nil
+}
+
+// setReleased records the outcome of releasing a leaked resource.
+func setReleased(r *params.LeakedResource, err error) {
+	if err != nil {
+		r.Error = apiservererrors.ServerError(err)
+		return
+	}
+	r.Released = true
+}
@@ -0,0 +1,270 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leakdetector_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facades/controller/leakdetector"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+)
+
+type LeakDetectorSuite struct {
+	testing.IsolationSuite
+
+	backend     *mockBackend
+	controller  *mockControllerEnviron
+	hostedEnv   *mockEnviron
+	hostedModel *mockModel
+	source      *mockVolumeSource
+}
+
+var _ = gc.Suite(&LeakDetectorSuite{})
+
+func (s *LeakDetectorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.controller = &mockControllerEnviron{
+		mockEnviron: &mockEnviron{instanceIds: []instance.Id{"i-controller"}},
+		resources: []environs.CloudResource{
+			{Kind: environs.CloudResourceInstance, Id: "i-controller", ModelUUID: controllerModelUUID},
+			{Kind: environs.CloudResourceInstance, Id: "i-hosted", ModelUUID: hostedModelUUID},
+			{Kind: environs.CloudResourceInstance, Id: "i-dead", ModelUUID: deadModelUUID},
+			{Kind: environs.CloudResourceSecurityGroup, Id: "sg-dead", ModelUUID: deadModelUUID},
+			{Kind: environs.CloudResourceSecurityGroup, Id: "sg-unknown"},
+		},
+	}
+	s.source = &mockVolumeSource{volumeIds: []string{"vol-0", "vol-1"}}
+	s.hostedEnv = &mockEnviron{
+		instanceIds: []instance.Id{"i-hosted", "i-leaked"},
+		provider: &mockStorageProvider{
+			scope:  storage.ScopeEnviron,
+			source: s.source,
+		},
+	}
+	s.hostedModel = &mockModel{
+		modelType:   state.ModelTypeIAAS,
+		life:        state.Alive,
+		env:         s.hostedEnv,
+		machineIds:  []string{"0"},
+		instanceIds: []instance.Id{"i-hosted"},
+		volumeIds:   []string{"vol-0"},
+	}
+	s.backend = &mockBackend{
+		models: map[string]*mockModel{
+			controllerModelUUID: {
+				modelType:   state.ModelTypeIAAS,
+				life:        state.Alive,
+				env:         s.controller,
+				machineIds:  []string{"0"},
+				instanceIds: []instance.Id{"i-controller"},
+			},
+			hostedModelUUID: s.hostedModel,
+		},
+	}
+}
+
+func (s *LeakDetectorSuite) newFacade(c *gc.C) *leakdetector.Facade {
+	facade, err := leakdetector.NewFacade(s.backend, mockAuth{controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+	return facade
+}
+
+func (s *LeakDetectorSuite) TestAuthController(c *gc.C) {
+	_, err := leakdetector.NewFacade(s.backend, mockAuth{controller: true})
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *LeakDetectorSuite) TestAuthSuperuser(c *gc.C) {
+	_, err := leakdetector.NewFacade(s.backend, mockAuth{superuser: true})
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *LeakDetectorSuite) TestAuthOther(c *gc.C) {
+	facade, err := leakdetector.NewFacade(s.backend, mockAuth{})
+	c.Check(facade, gc.IsNil)
+	c.Check(err, gc.Equals, apiservererrors.ErrPerm)
+}
+
+func (s *LeakDetectorSuite) TestDetectLeaks(c *gc.C) {
+	result, err := s.newFacade(c).DetectLeaks(params.DetectLeaksArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Resources, jc.DeepEquals, []params.LeakedResource{{
+		Kind:      "instance",
+		Id:        "i-leaked",
+		ModelUUID: hostedModelUUID,
+		Reason:    "no machine in the model refers to this instance",
+	}, {
+		Kind:      "volume",
+		Id:        "vol-1",
+		ModelUUID: hostedModelUUID,
+		Reason:    "no volume in the model refers to this volume",
+	}, {
+		Kind:      "instance",
+		Id:        "i-dead",
+		ModelUUID: deadModelUUID,
+		Reason:    "model no longer exists",
+	}, {
+		Kind:      "security-group",
+		Id:        "sg-dead",
+		ModelUUID: deadModelUUID,
+		Reason:    "model no longer exists",
+	}})
+	c.Check(s.hostedEnv.stopped, gc.HasLen, 0)
+	c.Check(s.source.destroyed, gc.HasLen, 0)
+	c.Check(s.controller.released, gc.HasLen, 0)
+	c.Check(s.backend.released, jc.SameContents, []string{
+		controllerModelUUID, hostedModelUUID, controllerModelUUID,
+	})
+}
+
+func (s *LeakDetectorSuite) TestDetectLeaksCleanup(c *gc.C) {
+	s.source.destroyErr = errors.New("volume in use")
+	result, err := s.newFacade(c).DetectLeaks(params.DetectLeaksArgs{Cleanup: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Resources, gc.HasLen, 4)
+	c.Check(result.Resources[0].Released, jc.IsTrue)
+	c.Check(result.Resources[0].Error, gc.IsNil)
+	c.Check(result.Resources[1].Released, jc.IsFalse)
+	c.Check(result.Resources[1].Error, gc.ErrorMatches, "volume in use")
+	c.Check(result.Resources[2].Released, jc.IsTrue)
+	c.Check(result.Resources[3].Released, jc.IsTrue)
+
+	c.Check(s.hostedEnv.stopped, jc.DeepEquals, []instance.Id{"i-leaked"})
+	c.Check(s.source.destroyed, jc.DeepEquals, []string{"vol-1"})
+	c.Check(s.controller.released, jc.DeepEquals, []environs.CloudResource{
+		{Kind: environs.CloudResourceInstance, Id: "i-dead", ModelUUID: deadModelUUID},
+		{Kind: environs.CloudResourceSecurityGroup, Id: "sg-dead", ModelUUID: deadModelUUID},
+	})
+}
+
+func (s *LeakDetectorSuite) TestDetectLeaksSkipsUnprovisioned(c *gc.C) {
+	// The hosted model's cloud cannot tell which resources belong to
+	// the machines and volumes being provisioned, so they are not
+	// checked at all.
+	s.hostedModel.pendingMachines = []string{"1"}
+	s.hostedModel.pendingStorage = []string{"data/1"}
+	result, err := s.newFacade(c).DetectLeaks(params.DetectLeaksArgs{Cleanup: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Resources, gc.HasLen, 2)
+	c.Check(result.Resources[0].ModelUUID, gc.Equals, deadModelUUID)
+	c.Check(result.Resources[1].ModelUUID, gc.Equals, deadModelUUID)
+	c.Check(s.hostedEnv.stopped, gc.HasLen, 0)
+	c.Check(s.source.destroyed, gc.HasLen, 0)
+}
+
+func (s *LeakDetectorSuite) TestDetectLeaksSkipsUnprovisionedStorageWithoutInstance(c *gc.C) {
+	s.setHostedResources()
+	s.hostedModel.pendingStorage = []string{""}
+	result, err := s.newFacade(c).DetectLeaks(params.DetectLeaksArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	for _, r := range result.Resources {
+		c.Check(r.Kind, gc.Not(gc.Equals), "volume")
+	}
+}
+
+func (s *LeakDetectorSuite) TestDetectLeaksSkipsOnlyUnprovisioned(c *gc.C) {
+	hosted := s.setHostedResources()
+	s.hostedEnv.instanceIds = append(s.hostedEnv.instanceIds, "i-pending", "i-unattributed")
+	s.source.volumeIds = append(s.source.volumeIds, "vol-pending")
+	s.hostedModel.machineIds = []string{"0", "1"}
+	s.hostedModel.pendingMachines = []string{"1"}
+	s.hostedModel.pendingStorage = []string{"data/1"}
+	result, err := s.newFacade(c).DetectLeaks(params.DetectLeaksArgs{Cleanup: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Resources, gc.HasLen, 5)
+	c.Check(result.Resources[0].Id, gc.Equals, "i-leaked")
+	c.Check(result.Resources[1].Id, gc.Equals, "vol-1")
+	c.Check(result.Resources[2].Id, gc.Equals, "sg-leaked")
+	c.Check(result.Resources[3].Id, gc.Equals, "i-dead")
+	c.Check(result.Resources[4].Id, gc.Equals, "sg-dead")
+
+	c.Check(s.hostedEnv.stopped, jc.DeepEquals, []instance.Id{"i-leaked"})
+	c.Check(s.source.destroyed, jc.DeepEquals, []string{"vol-1"})
+	c.Check(hosted.released, jc.DeepEquals, []environs.CloudResource{
+		{Kind: environs.CloudResourceSecurityGroup, Id: "sg-leaked", ModelUUID: hostedModelUUID, MachineId: "2"},
+	})
+}
+
+func (s *LeakDetectorSuite) TestDetectLeaksSecurityGroups(c *gc.C) {
+	hosted := s.setHostedResources()
+	result, err := s.newFacade(c).DetectLeaks(params.DetectLeaksArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Resources, gc.HasLen, 5)
+	c.Check(result.Resources[2], jc.DeepEquals, params.LeakedResource{
+		Kind:      "security-group",
+		Id:        "sg-leaked",
+		ModelUUID: hostedModelUUID,
+		Reason:    "no machine in the model uses this security group",
+	})
+	c.Check(hosted.released, gc.HasLen, 0)
+}
+
+// setHostedResources makes the hosted model's cloud attribute its
+// resources to the machines and storage they were created for.
+func (s *LeakDetectorSuite) setHostedResources() *mockControllerEnviron {
+	hosted := &mockControllerEnviron{
+		mockEnviron: s.hostedEnv,
+		resources: append(s.controller.resources,
+			environs.CloudResource{Kind: environs.CloudResourceInstance, Id: "i-leaked", ModelUUID: hostedModelUUID, MachineId: "2"},
+			environs.CloudResource{Kind: environs.CloudResourceInstance, Id: "i-pending", ModelUUID: hostedModelUUID, MachineId: "1"},
+			environs.CloudResource{Kind: environs.CloudResourceInstance, Id: "i-unattributed", ModelUUID: hostedModelUUID},
+			environs.CloudResource{Kind: environs.CloudResourceVolume, Id: "vol-1", ModelUUID: hostedModelUUID, StorageId: "data/0"},
+			environs.CloudResource{Kind: environs.CloudResourceVolume, Id: "vol-pending", ModelUUID: hostedModelUUID, StorageId: "data/1"},
+			environs.CloudResource{Kind: environs.CloudResourceSecurityGroup, Id: "sg-hosted", ModelUUID: hostedModelUUID},
+			environs.CloudResource{Kind: environs.CloudResourceSecurityGroup, Id: "sg-machine", ModelUUID: hostedModelUUID, MachineId: "0"},
+			environs.CloudResource{Kind: environs.CloudResourceSecurityGroup, Id: "sg-leaked", ModelUUID: hostedModelUUID, MachineId: "2"},
+		),
+	}
+	s.hostedModel.env = hosted
+	return hosted
+}
+
+func (s *LeakDetectorSuite) TestDetectLeaksSkipsMachineScopedStorage(c *gc.C) {
+	s.hostedEnv.provider.scope = storage.ScopeMachine
+	result, err := s.newFacade(c).DetectLeaks(params.DetectLeaksArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Resources, gc.HasLen, 3)
+	c.Check(result.Resources[0].Id, gc.Equals, "i-leaked")
+	c.Check(result.Resources[1].Id, gc.Equals, "i-dead")
+}
+
+func (s *LeakDetectorSuite) TestDetectLeaksSkipsDyingModels(c *gc.C) {
+	s.hostedModel.life = state.Dying
+	result, err := s.newFacade(c).DetectLeaks(params.DetectLeaksArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Resources, gc.HasLen, 2)
+	c.Check(result.Resources[0].Id, gc.Equals, "i-dead")
+	c.Check(result.Resources[1].Id, gc.Equals, "sg-dead")
+}
+
+func (s *LeakDetectorSuite) TestDetectLeaksSkipsBrokenModels(c *gc.C) {
+	s.hostedModel.envErr = errors.New("bad credential")
+	result, err := s.newFacade(c).DetectLeaks(params.DetectLeaksArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Resources, gc.HasLen, 2)
+	c.Check(result.Resources[0].ModelUUID, gc.Equals, deadModelUUID)
+}
+
+func (s *LeakDetectorSuite) TestDetectLeaksNoControllerResourceLister(c *gc.C) {
+	s.backend.models[controllerModelUUID].env = s.controller.mockEnviron
+	result, err := s.newFacade(c).DetectLeaks(params.DetectLeaksArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Resources, gc.HasLen, 2)
+	c.Check(result.Resources[0].ModelUUID, gc.Equals, hostedModelUUID)
+	c.Check(result.Resources[1].ModelUUID, gc.Equals, hostedModelUUID)
+}
+
+func (s *LeakDetectorSuite) TestDetectLeaksControllerEnvironError(c *gc.C) {
+	s.backend.models[controllerModelUUID].envErr = errors.New("boom")
+	_, err := s.newFacade(c).DetectLeaks(params.DetectLeaksArgs{})
+	c.Check(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,155 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leakdetector
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+// NewAPI provides the required signature for facade registration.
+func NewAPI(ctx facade.Context) (*Facade, error) {
+	return NewFacade(backendShim{ctx.State(), ctx.StatePool()}, ctx.Auth())
+}
+
+// backendShim wraps the controller's *State and *StatePool to
+// implement Backend.
+type backendShim struct {
+	st   *state.State
+	pool *state.StatePool
+}
+
+// ControllerTag is part of the Backend interface.
+func (shim backendShim) ControllerTag() names.ControllerTag {
+	return shim.st.ControllerTag()
+}
+
+// ControllerModelUUID is part of the Backend interface.
+func (shim backendShim) ControllerModelUUID() string {
+	return shim.st.ControllerModelUUID()
+}
+
+// ModelUUIDs is part of the Backend interface.
+func (shim backendShim) ModelUUIDs() ([]string, error) {
+	return shim.st.AllModelUUIDsIncludingDead()
+}
+
+// ModelBackend is part of the Backend interface.
+func (shim backendShim) ModelBackend(modelUUID string) (ModelBackend, func(), error) {
+	model, ph, err := shim.pool.GetModel(modelUUID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	release := func() { ph.Release() }
+	return modelShim{model.State(), model}, release, nil
+}
+
+// modelShim wraps a model's *State and *Model to implement
+// ModelBackend.
+type modelShim struct {
+	st    *state.State
+	model *state.Model
+}
+
+// Type is part of the ModelBackend interface.
+func (shim modelShim) Type() state.ModelType {
+	return shim.model.Type()
+}
+
+// Life is part of the ModelBackend interface.
+func (shim modelShim) Life() state.Life {
+	return shim.model.Life()
+}
+
+// Environ is part of the ModelBackend interface.
+func (shim modelShim) Environ() (environs.Environ, error) {
+	return stateenvirons.GetNewEnvironFunc(environs.New)(shim.model)
+}
+
+// CallContext is part of the ModelBackend interface.
+func (shim modelShim) CallContext() context.ProviderCallContext {
+	return context.CallContext(shim.st)
+}
+
+// MachineIds is part of the ModelBackend interface.
+func (shim modelShim) MachineIds() ([]string, error) {
+	machines, err := shim.st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ids := make([]string, len(machines))
+	for i, m := range machines {
+		ids[i] = m.Id()
+	}
+	return ids, nil
+}
+
+// InstanceIds is part of the ModelBackend interface.
+func (shim modelShim) InstanceIds() ([]instance.Id, []string, error) {
+	machines, err := shim.st.AllMachines()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	var ids []instance.Id
+	var pending []string
+	for _, m := range machines {
+		if names.IsContainerMachine(m.Id()) {
+			// Containers are not cloud resources.
+			continue
+		}
+		id, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			pending = append(pending, m.Id())
+			continue
+		} else if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, pending, nil
+}
+
+// VolumeIds is part of the ModelBackend interface.
+func (shim modelShim) VolumeIds() ([]string, []string, error) {
+	sb, err := state.NewStorageBackend(shim.st)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	volumes, err := sb.AllVolumes()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	var ids, pending []string
+	for _, v := range volumes {
+		if _, ok := names.VolumeMachine(v.VolumeTag()); ok {
+			// Machine-scoped volumes are not cloud resources.
+			continue
+		}
+		info, err := v.Info()
+		if errors.IsNotProvisioned(err) {
+			var storageId string
+			if tag, err := v.StorageInstance(); err == nil {
+				storageId = tag.Id()
+			}
+			pending = append(pending, storageId)
+			continue
+		} else if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		ids = append(ids, info.VolumeId)
+	}
+	return ids, pending, nil
+}
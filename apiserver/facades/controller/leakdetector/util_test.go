@@ -0,0 +1,220 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leakdetector_test
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/controller/leakdetector"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	controllerUUID      = "deadbeef-1bad-500d-9000-4b1d0d06f00d"
+	controllerModelUUID = "c0dec0de-1bad-500d-9000-4b1d0d06f00d"
+	hostedModelUUID     = "f00df00d-1bad-500d-9000-4b1d0d06f00d"
+	deadModelUUID       = "baadf00d-1bad-500d-9000-4b1d0d06f00d"
+)
+
+// mockAuth implements facade.Authorizer for the tests' convenience.
+type mockAuth struct {
+	facade.Authorizer
+	controller bool
+	superuser  bool
+}
+
+func (mock mockAuth) AuthController() bool {
+	return mock.controller
+}
+
+func (mock mockAuth) HasPermission(operation permission.Access, target names.Tag) (bool, error) {
+	return mock.superuser && operation == permission.SuperuserAccess &&
+		target == names.NewControllerTag(controllerUUID), nil
+}
+
+// mockBackend implements leakdetector.Backend.
+type mockBackend struct {
+	models   map[string]*mockModel
+	released []string
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return names.NewControllerTag(controllerUUID)
+}
+
+func (b *mockBackend) ControllerModelUUID() string {
+	return controllerModelUUID
+}
+
+func (b *mockBackend) ModelUUIDs() ([]string, error) {
+	var uuids []string
+	for uuid := range b.models {
+		uuids = append(uuids, uuid)
+	}
+	sort.Strings(uuids)
+	return uuids, nil
+}
+
+func (b *mockBackend) ModelBackend(modelUUID string) (leakdetector.ModelBackend, func(), error) {
+	model, ok := b.models[modelUUID]
+	if !ok {
+		return nil, nil, errors.NotFoundf("model %q", modelUUID)
+	}
+	return model, func() { b.released = append(b.released, modelUUID) }, nil
+}
+
+// mockModel implements leakdetector.ModelBackend.
+type mockModel struct {
+	modelType       state.ModelType
+	life            state.Life
+	env             environs.Environ
+	envErr          error
+	machineIds      []string
+	instanceIds     []instance.Id
+	pendingMachines []string
+	volumeIds       []string
+	pendingStorage  []string
+}
+
+func (m *mockModel) Type() state.ModelType {
+	return m.modelType
+}
+
+func (m *mockModel) Life() state.Life {
+	return m.life
+}
+
+func (m *mockModel) Environ() (environs.Environ, error) {
+	return m.env, m.envErr
+}
+
+func (m *mockModel) CallContext() context.ProviderCallContext {
+	return context.NewCloudCallContext()
+}
+
+func (m *mockModel) MachineIds() ([]string, error) {
+	return m.machineIds, nil
+}
+
+func (m *mockModel) InstanceIds() ([]instance.Id, []string, error) {
+	return m.instanceIds, m.pendingMachines, nil
+}
+
+func (m *mockModel) VolumeIds() ([]string, []string, error) {
+	return m.volumeIds, m.pendingStorage, nil
+}
+
+// mockEnviron implements the parts of environs.Environ used by the
+// facade.
+type mockEnviron struct {
+	environs.Environ
+	instanceIds []instance.Id
+	stopped     []instance.Id
+	stopErr     error
+	provider    *mockStorageProvider
+}
+
+func (e *mockEnviron) AllInstances(context.ProviderCallContext) ([]instances.Instance, error) {
+	result := make([]instances.Instance, len(e.instanceIds))
+	for i, id := range e.instanceIds {
+		result[i] = mockInstance{id: id}
+	}
+	return result, nil
+}
+
+func (e *mockEnviron) StopInstances(_ context.ProviderCallContext, ids ...instance.Id) error {
+	e.stopped = append(e.stopped, ids...)
+	return e.stopErr
+}
+
+func (e *mockEnviron) StorageProviderTypes() ([]storage.ProviderType, error) {
+	if e.provider == nil {
+		return nil, nil
+	}
+	return []storage.ProviderType{"ebs"}, nil
+}
+
+func (e *mockEnviron) StorageProvider(storage.ProviderType) (storage.Provider, error) {
+	return e.provider, nil
+}
+
+// mockControllerEnviron is a mockEnviron that also implements
+// environs.ControllerResourceLister.
+type mockControllerEnviron struct {
+	*mockEnviron
+	resources  []environs.CloudResource
+	released   []environs.CloudResource
+	releaseErr error
+}
+
+func (e *mockControllerEnviron) ControllerResources(_ context.ProviderCallContext, uuid string) ([]environs.CloudResource, error) {
+	if uuid != controllerUUID {
+		return nil, errors.Errorf("unexpected controller %q", uuid)
+	}
+	return e.resources, nil
+}
+
+func (e *mockControllerEnviron) ReleaseControllerResources(_ context.ProviderCallContext, resources []environs.CloudResource) []error {
+	e.released = append(e.released, resources...)
+	errs := make([]error, len(resources))
+	errs[0] = e.releaseErr
+	return errs
+}
+
+type mockInstance struct {
+	instances.Instance
+	id instance.Id
+}
+
+func (i mockInstance) Id() instance.Id {
+	return i.id
+}
+
+// mockStorageProvider implements storage.Provider.
+type mockStorageProvider struct {
+	storage.Provider
+	scope  storage.Scope
+	source *mockVolumeSource
+}
+
+func (p *mockStorageProvider) Scope() storage.Scope {
+	return p.scope
+}
+
+func (p *mockStorageProvider) Supports(kind storage.StorageKind) bool {
+	return kind == storage.StorageKindBlock
+}
+
+func (p *mockStorageProvider) VolumeSource(*storage.Config) (storage.VolumeSource, error) {
+	return p.source, nil
+}
+
+// mockVolumeSource implements storage.VolumeSource.
+type mockVolumeSource struct {
+	storage.VolumeSource
+	volumeIds  []string
+	destroyed  []string
+	destroyErr error
+}
+
+func (s *mockVolumeSource) ListVolumes(context.ProviderCallContext) ([]string, error) {
+	return s.volumeIds, nil
+}
+
+func (s *mockVolumeSource) DestroyVolumes(_ context.ProviderCallContext, ids []string) ([]error, error) {
+	s.destroyed = append(s.destroyed, ids...)
+	errs := make([]error, len(ids))
+	errs[0] = s.destroyErr
+	return errs, nil
+}
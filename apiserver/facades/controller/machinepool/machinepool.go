@@ -0,0 +1,152 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package machinepool provides the API used by the machine pool worker
+// to keep a model's pool of idle machines at its configured size.
+package machinepool
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// Machine exposes the machine functionality required by the facade.
+type Machine interface {
+	Id() string
+	InstanceId() (instance.Id, error)
+	Constraints() (constraints.Value, error)
+	Destroy() error
+}
+
+// Backend exposes the state functionality required by the facade.
+type Backend interface {
+	// ModelConfig returns the model's config.
+	ModelConfig() (*config.Config, error)
+
+	// MachinePool returns the idle machines in the model's machine
+	// pool, in order of machine id.
+	MachinePool() ([]Machine, error)
+
+	// PruneMachinePool forgets the machines that have left the pool.
+	PruneMachinePool() error
+
+	// AddPoolMachine adds a new machine to the pool.
+	AddPoolMachine(template state.MachineTemplate) (Machine, error)
+
+	// ResolveConstraints combines the given constraints with the
+	// model's constraints, as is done for every new machine.
+	ResolveConstraints(cons constraints.Value) (constraints.Value, error)
+}
+
+// Facade allows the machine pool worker to maintain a model's machine
+// pool.
+type Facade struct {
+	backend Backend
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(backend Backend, auth facade.Authorizer) (*Facade, error) {
+	if !auth.AuthController() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &Facade{backend: backend}, nil
+}
+
+// MaintainMachinePool adds machines to, or removes them from, the
+// model's machine pool so that it holds as many idle machines as the
+// model's machine-pool-size, all matching its machine-pool-constraints.
+// Pooled machines that no longer match the constraints are replaced,
+// and surplus machines that are not yet provisioned are removed first.
+func (f *Facade) MaintainMachinePool() (params.MachinePoolResult, error) {
+	result, err := f.maintainMachinePool()
+	if err != nil {
+		return params.MachinePoolResult{Error: apiservererrors.ServerError(err)}, nil
+	}
+	return result, nil
+}
+
+func (f *Facade) maintainMachinePool() (params.MachinePoolResult, error) {
+	cfg, err := f.backend.ModelConfig()
+	if err != nil {
+		return params.MachinePoolResult{}, errors.Trace(err)
+	}
+	if err := f.backend.PruneMachinePool(); err != nil {
+		return params.MachinePoolResult{}, errors.Trace(err)
+	}
+	pool, err := f.backend.MachinePool()
+	if err != nil {
+		return params.MachinePoolResult{}, errors.Trace(err)
+	}
+	size := cfg.MachinePoolSize()
+	cons := cfg.MachinePoolConstraints()
+
+	// Pooled machines record the pool constraints combined with the
+	// model's, without any container, so compare them likewise.
+	resolved, err := f.backend.ResolveConstraints(cons)
+	if err != nil {
+		return params.MachinePoolResult{}, errors.Trace(err)
+	}
+	resolved.Container = nil
+
+	var keep, surplus []Machine
+	for _, m := range pool {
+		mcons, err := m.Constraints()
+		if err != nil {
+			return params.MachinePoolResult{}, errors.Trace(err)
+		}
+		if mcons.String() != resolved.String() {
+			surplus = append(surplus, m)
+		} else {
+			keep = append(keep, m)
+		}
+	}
+	if len(keep) > size {
+		// Keep the provisioned machines, and the oldest of those.
+		provisioned := make(map[string]bool)
+		for _, m := range keep {
+			if _, err := m.InstanceId(); err == nil {
+				provisioned[m.Id()] = true
+			} else if !errors.IsNotProvisioned(err) {
+				return params.MachinePoolResult{}, errors.Trace(err)
+			}
+		}
+		sort.SliceStable(keep, func(i, j int) bool {
+			return provisioned[keep[i].Id()] && !provisioned[keep[j].Id()]
+		})
+		surplus = append(surplus, keep[size:]...)
+		keep = keep[:size]
+	}
+
+	result := params.MachinePoolResult{Size: size, Machines: []string{}}
+	for _, m := range surplus {
+		if err := m.Destroy(); err != nil {
+			return params.MachinePoolResult{}, errors.Annotatef(err, "cannot remove machine %s from pool", m.Id())
+		}
+		result.Removed = append(result.Removed, m.Id())
+	}
+	for _, m := range keep {
+		result.Machines = append(result.Machines, m.Id())
+	}
+	for n := len(keep); n < size; n++ {
+		m, err := f.backend.AddPoolMachine(state.MachineTemplate{
+			Series:      config.PreferredSeries(cfg),
+			Constraints: cons,
+			Jobs:        []state.MachineJob{state.JobHostUnits},
+		})
+		if err != nil {
+			return params.MachinePoolResult{}, errors.Trace(err)
+		}
+		result.Added = append(result.Added, m.Id())
+		result.Machines = append(result.Machines, m.Id())
+	}
+	return result, nil
+}
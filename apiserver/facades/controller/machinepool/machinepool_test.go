@@ -0,0 +1,146 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinepool_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facades/controller/machinepool"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type MachinePoolSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&MachinePoolSuite{})
+
+func (s *MachinePoolSuite) newFacade(c *gc.C, backend machinepool.Backend) *machinepool.Facade {
+	f, err := machinepool.NewFacade(backend, mockAuth{controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+	return f
+}
+
+func (s *MachinePoolSuite) newBackend(c *gc.C, size int, cons string) *mockBackend {
+	cfg := coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"machine-pool-size":        size,
+		"machine-pool-constraints": cons,
+	})
+	return &mockBackend{config: cfg, nextId: 10}
+}
+
+func (s *MachinePoolSuite) TestNewFacadeNotController(c *gc.C) {
+	f, err := machinepool.NewFacade(&mockBackend{}, mockAuth{})
+	c.Check(f, gc.IsNil)
+	c.Check(err, gc.Equals, apiservererrors.ErrPerm)
+}
+
+func (s *MachinePoolSuite) TestMaintainEmptyPool(c *gc.C) {
+	backend := s.newBackend(c, 0, "")
+	result, err := s.newFacade(c, backend).MaintainMachinePool()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.MachinePoolResult{Machines: []string{}})
+	c.Check(backend.pruned, jc.IsTrue)
+	c.Check(backend.templates, gc.HasLen, 0)
+}
+
+func (s *MachinePoolSuite) TestMaintainAddsMachines(c *gc.C) {
+	backend := s.newBackend(c, 3, "mem=4G")
+	backend.pool = []*mockMachine{{id: "1", cons: constraints.MustParse("mem=4G")}}
+	result, err := s.newFacade(c, backend).MaintainMachinePool()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.MachinePoolResult{
+		Size:     3,
+		Machines: []string{"1", "10", "11"},
+		Added:    []string{"10", "11"},
+	})
+	c.Assert(backend.templates, gc.HasLen, 2)
+	c.Check(backend.templates[0], jc.DeepEquals, state.MachineTemplate{
+		Series:      "bionic",
+		Constraints: constraints.MustParse("mem=4G"),
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+	})
+}
+
+func (s *MachinePoolSuite) TestMaintainRemovesSurplusUnprovisionedFirst(c *gc.C) {
+	backend := s.newBackend(c, 2, "")
+	backend.pool = []*mockMachine{
+		{id: "1"},
+		{id: "2", provisioned: true},
+		{id: "3", provisioned: true},
+		{id: "4", provisioned: true},
+	}
+	result, err := s.newFacade(c, backend).MaintainMachinePool()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.MachinePoolResult{
+		Size:     2,
+		Machines: []string{"2", "3"},
+		Removed:  []string{"4", "1"},
+	})
+	c.Check(backend.pool[0].destroyed, jc.IsTrue)
+	c.Check(backend.pool[1].destroyed, jc.IsFalse)
+	c.Check(backend.pool[2].destroyed, jc.IsFalse)
+	c.Check(backend.pool[3].destroyed, jc.IsTrue)
+}
+
+func (s *MachinePoolSuite) TestMaintainReplacesMismatchedMachines(c *gc.C) {
+	backend := s.newBackend(c, 1, "cores=2")
+	backend.pool = []*mockMachine{{id: "1", provisioned: true, cons: constraints.MustParse("cores=1")}}
+	result, err := s.newFacade(c, backend).MaintainMachinePool()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.MachinePoolResult{
+		Size:     1,
+		Machines: []string{"10"},
+		Added:    []string{"10"},
+		Removed:  []string{"1"},
+	})
+	c.Check(backend.pool[0].destroyed, jc.IsTrue)
+}
+
+func (s *MachinePoolSuite) TestMaintainWithModelConstraints(c *gc.C) {
+	backend := s.newBackend(c, 2, "mem=4G")
+	backend.modelCons = constraints.MustParse("cores=2")
+	backend.pool = []*mockMachine{
+		{id: "1", provisioned: true, cons: constraints.MustParse("cores=2 mem=4G")},
+		{id: "2", provisioned: true, cons: constraints.MustParse("mem=4G")},
+	}
+	result, err := s.newFacade(c, backend).MaintainMachinePool()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.MachinePoolResult{
+		Size:     2,
+		Machines: []string{"1", "10"},
+		Added:    []string{"10"},
+		Removed:  []string{"2"},
+	})
+	c.Check(backend.pool[0].destroyed, jc.IsFalse)
+	c.Check(backend.pool[1].destroyed, jc.IsTrue)
+	c.Assert(backend.templates, gc.HasLen, 1)
+	c.Check(backend.templates[0].Constraints, jc.DeepEquals, constraints.MustParse("mem=4G"))
+
+	// The machines now in the pool match, so are kept.
+	backend.pool = []*mockMachine{backend.pool[0], {id: "10", cons: constraints.MustParse("cores=2 mem=4G")}}
+	backend.templates = nil
+	result, err = s.newFacade(c, backend).MaintainMachinePool()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.MachinePoolResult{
+		Size:     2,
+		Machines: []string{"1", "10"},
+	})
+	c.Check(backend.templates, gc.HasLen, 0)
+}
+
+func (s *MachinePoolSuite) TestMaintainError(c *gc.C) {
+	backend := s.newBackend(c, 1, "")
+	backend.addErr = errors.New("boom")
+	result, err := s.newFacade(c, backend).MaintainMachinePool()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Error, gc.ErrorMatches, "boom")
+}
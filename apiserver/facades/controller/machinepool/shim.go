@@ -0,0 +1,69 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinepool
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+// NewAPI provides the required signature for facade registration.
+func NewAPI(st *state.State, _ facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	return NewFacade(backendShim{st}, auth)
+}
+
+// backendShim wraps a *State to implement Backend.
+type backendShim struct {
+	st *state.State
+}
+
+// ModelConfig is part of the Backend interface.
+func (shim backendShim) ModelConfig() (*config.Config, error) {
+	model, err := shim.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return model.ModelConfig()
+}
+
+// MachinePool is part of the Backend interface.
+func (shim backendShim) MachinePool() ([]Machine, error) {
+	machines, err := shim.st.MachinePool()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Machine, len(machines))
+	for i, m := range machines {
+		result[i] = m
+	}
+	return result, nil
+}
+
+// PruneMachinePool is part of the Backend interface.
+func (shim backendShim) PruneMachinePool() error {
+	return shim.st.PruneMachinePool()
+}
+
+// AddPoolMachine is part of the Backend interface.
+func (shim backendShim) AddPoolMachine(template state.MachineTemplate) (Machine, error) {
+	m, err := shim.st.AddPoolMachine(template)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m, nil
+}
+
+// ResolveConstraints is part of the Backend interface.
+func (shim backendShim) ResolveConstraints(cons constraints.Value) (constraints.Value, error) {
+	return shim.st.ResolveConstraints(cons)
+}
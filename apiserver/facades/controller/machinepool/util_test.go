@@ -0,0 +1,101 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinepool_test
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/controller/machinepool"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// mockAuth implements facade.Authorizer for the tests' convenience.
+type mockAuth struct {
+	facade.Authorizer
+	controller bool
+}
+
+func (mock mockAuth) AuthController() bool {
+	return mock.controller
+}
+
+// mockMachine implements machinepool.Machine.
+type mockMachine struct {
+	id          string
+	provisioned bool
+	cons        constraints.Value
+	destroyed   bool
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if !m.provisioned {
+		return "", errors.NotProvisionedf("machine %s", m.id)
+	}
+	return instance.Id("inst-" + m.id), nil
+}
+
+func (m *mockMachine) Constraints() (constraints.Value, error) {
+	return m.cons, nil
+}
+
+func (m *mockMachine) Destroy() error {
+	m.destroyed = true
+	return nil
+}
+
+// mockBackend implements machinepool.Backend.
+type mockBackend struct {
+	config    *config.Config
+	modelCons constraints.Value
+	pool      []*mockMachine
+	nextId    int
+	pruned    bool
+	templates []state.MachineTemplate
+	addErr    error
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	return b.config, nil
+}
+
+func (b *mockBackend) MachinePool() ([]machinepool.Machine, error) {
+	result := make([]machinepool.Machine, len(b.pool))
+	for i, m := range b.pool {
+		result[i] = m
+	}
+	return result, nil
+}
+
+func (b *mockBackend) PruneMachinePool() error {
+	b.pruned = true
+	return nil
+}
+
+func (b *mockBackend) AddPoolMachine(template state.MachineTemplate) (machinepool.Machine, error) {
+	if b.addErr != nil {
+		return nil, b.addErr
+	}
+	cons, err := b.ResolveConstraints(template.Constraints)
+	if err != nil {
+		return nil, err
+	}
+	b.templates = append(b.templates, template)
+	m := &mockMachine{id: fmt.Sprint(b.nextId), cons: cons}
+	b.nextId++
+	return m, nil
+}
+
+func (b *mockBackend) ResolveConstraints(cons constraints.Value) (constraints.Value, error) {
+	return constraints.Merge(b.modelCons, cons)
+}
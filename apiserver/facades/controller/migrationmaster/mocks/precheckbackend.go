@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	constraints "github.com/juju/juju/core/constraints"
 	migration "github.com/juju/juju/migration"
 	resource "github.com/juju/juju/resource"
 	state "github.com/juju/juju/state"
@@ -188,6 +189,21 @@ func (mr *MockPrecheckBackendMockRecorder) ListPendingResources(arg0 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingResources", reflect.TypeOf((*MockPrecheckBackend)(nil).ListPendingResources), arg0)
 }
 
+// ModelConstraints mocks base method
+func (m *MockPrecheckBackend) ModelConstraints() (constraints.Value, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModelConstraints")
+	ret0, _ := ret[0].(constraints.Value)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModelConstraints indicates an expected call of ModelConstraints
+func (mr *MockPrecheckBackendMockRecorder) ModelConstraints() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelConstraints", reflect.TypeOf((*MockPrecheckBackend)(nil).ModelConstraints))
+}
+
 // Model mocks base method
 func (m *MockPrecheckBackend) Model() (migration.PrecheckModel, error) {
 	m.ctrl.T.Helper()
@@ -34,6 +34,7 @@ type mockState struct {
 	applicationRelationsWatchers map[string]*mockStringsWatcher
 	remoteEntities               map[names.Tag]string
 	controllerInfo               map[string]*mockControllerInfo
+	remoteRelationEvents         map[string]state.RemoteRelationEvent
 }
 
 func newMockState() *mockState {
@@ -44,6 +45,7 @@ func newMockState() *mockState {
 		remoteApplicationsWatcher:    newMockStringsWatcher(),
 		remoteRelationsWatcher:       newMockStringsWatcher(),
 		applicationRelationsWatchers: make(map[string]*mockStringsWatcher),
+		remoteRelationEvents:         make(map[string]state.RemoteRelationEvent),
 		remoteEntities:               make(map[names.Tag]string),
 		controllerInfo:               make(map[string]*mockControllerInfo),
 	}
@@ -132,6 +134,11 @@ func (st *mockState) SaveMacaroon(entity names.Tag, mac *macaroon.Macaroon) erro
 	return st.NextErr()
 }
 
+func (st *mockState) SetRemoteRelationEvent(relationKey string, event state.RemoteRelationEvent) error {
+	st.remoteRelationEvents[relationKey] = event
+	return nil
+}
+
 func (st *mockState) KeyRelation(key string) (common.Relation, error) {
 	st.MethodCall(st, "KeyRelation", key)
 	if err := st.NextErr(); err != nil {
@@ -492,6 +492,11 @@ func (s *remoteRelationsSuite) TestConsumeRemoteRelationChange(c *gc.C) {
 		{"KeyRelation", []interface{}{"db2:db django:db"}},
 		{"GetRemoteEntity", []interface{}{"app-token"}},
 	})
+
+	event := s.st.remoteRelationEvents["db2:db django:db"]
+	c.Assert(event.ApplicationToken, gc.Equals, "app-token")
+	c.Assert(event.ChangedUnits, jc.DeepEquals, []int{0})
+	c.Assert(event.Error, gc.Equals, "")
 }
 
 func (s *remoteRelationsSuite) TestControllerAPIInfoForModels(c *gc.C) {
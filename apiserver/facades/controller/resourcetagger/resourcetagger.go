@@ -0,0 +1,122 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package resourcetagger provides the API used by the resource tagger
+// worker to keep the tags on a model's cloud resources up to date with
+// the model's resource-tags config.
+package resourcetagger
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes the state functionality required by the facade.
+type Backend interface {
+	state.ModelAccessor
+
+	// ModelTag returns the tag of the model.
+	ModelTag() names.ModelTag
+
+	// ControllerTag returns the tag of the model's controller.
+	ControllerTag() names.ControllerTag
+
+	// InstanceIds returns the ids of the model's provisioned instances,
+	// excluding containers.
+	InstanceIds() ([]instance.Id, error)
+
+	// VolumeIds returns the provider ids of the model's provisioned,
+	// model-scoped volumes.
+	VolumeIds() ([]string, error)
+
+	// AppliedResourceTags returns the tags last recorded as set on the
+	// model's cloud resources, or nil if none have been recorded.
+	AppliedResourceTags() (map[string]string, error)
+
+	// SetAppliedResourceTags records the tags set on the model's
+	// cloud resources.
+	SetAppliedResourceTags(tags map[string]string) error
+}
+
+// Facade allows the resource tagger worker to find the tags to set on
+// a model's cloud resources.
+type Facade struct {
+	*common.ModelWatcher
+	backend Backend
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(backend Backend, resources facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	if !auth.AuthController() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &Facade{
+		ModelWatcher: common.NewModelWatcher(backend, resources, auth),
+		backend:      backend,
+	}, nil
+}
+
+// ResourceTagTargets returns the tags computed from the model's config
+// that are to be set on its cloud resources, along with the instances
+// and volumes to set them on.
+func (f *Facade) ResourceTagTargets() (params.ResourceTagTargetsResult, error) {
+	result, err := f.resourceTagTargets()
+	if err != nil {
+		return params.ResourceTagTargetsResult{Error: apiservererrors.ServerError(err)}, nil
+	}
+	return result, nil
+}
+
+func (f *Facade) resourceTagTargets() (params.ResourceTagTargetsResult, error) {
+	cfg, err := f.backend.ModelConfig()
+	if err != nil {
+		return params.ResourceTagTargetsResult{}, errors.Trace(err)
+	}
+	instanceIds, err := f.backend.InstanceIds()
+	if err != nil {
+		return params.ResourceTagTargetsResult{}, errors.Trace(err)
+	}
+	volumeIds, err := f.backend.VolumeIds()
+	if err != nil {
+		return params.ResourceTagTargetsResult{}, errors.Trace(err)
+	}
+	result := params.ResourceTagTargetsResult{
+		Tags:        tags.ResourceTags(f.backend.ModelTag(), f.backend.ControllerTag(), cfg),
+		InstanceIds: make([]string, len(instanceIds)),
+		VolumeIds:   volumeIds,
+	}
+	for i, id := range instanceIds {
+		result.InstanceIds[i] = string(id)
+	}
+	if result.VolumeIds == nil {
+		result.VolumeIds = []string{}
+	}
+	return result, nil
+}
+
+// AppliedResourceTags returns the tags last recorded as set on the
+// model's cloud resources by the resource tagger, or no tags if none
+// have been recorded.
+func (f *Facade) AppliedResourceTags() (params.AppliedResourceTagsResult, error) {
+	tags, err := f.backend.AppliedResourceTags()
+	if err != nil {
+		return params.AppliedResourceTagsResult{Error: apiservererrors.ServerError(err)}, nil
+	}
+	return params.AppliedResourceTagsResult{Tags: tags}, nil
+}
+
+// SetAppliedResourceTags records the tags set on the model's cloud
+// resources by the resource tagger, so that the tags later removed from
+// the model's config can be removed from the resources.
+func (f *Facade) SetAppliedResourceTags(args params.SetAppliedResourceTags) (params.ErrorResult, error) {
+	err := f.backend.SetAppliedResourceTags(args.Tags)
+	return params.ErrorResult{Error: apiservererrors.ServerError(err)}, nil
+}
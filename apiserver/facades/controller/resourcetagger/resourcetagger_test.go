@@ -0,0 +1,112 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facades/controller/resourcetagger"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ResourceTaggerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ResourceTaggerSuite{})
+
+func (s *ResourceTaggerSuite) newFacade(c *gc.C, backend resourcetagger.Backend) *resourcetagger.Facade {
+	f, err := resourcetagger.NewFacade(backend, nil, mockAuth{controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+	return f
+}
+
+func (s *ResourceTaggerSuite) newBackend(c *gc.C, resourceTags string) *mockBackend {
+	cfg := coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"resource-tags": resourceTags,
+	})
+	return &mockBackend{config: cfg}
+}
+
+func (s *ResourceTaggerSuite) TestNewFacadeNotController(c *gc.C) {
+	f, err := resourcetagger.NewFacade(&mockBackend{}, nil, mockAuth{})
+	c.Check(f, gc.IsNil)
+	c.Check(err, gc.Equals, apiservererrors.ErrPerm)
+}
+
+func (s *ResourceTaggerSuite) TestResourceTagTargets(c *gc.C) {
+	backend := s.newBackend(c, "cost-centre=research owner=eve")
+	backend.instanceIds = []instance.Id{"i-0", "i-1"}
+	backend.volumeIds = []string{"vol-0"}
+	result, err := s.newFacade(c, backend).ResourceTagTargets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.ResourceTagTargetsResult{
+		Tags: map[string]string{
+			"juju-model-uuid":      coretesting.ModelTag.Id(),
+			"juju-controller-uuid": "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+			"cost-centre":          "research",
+			"owner":                "eve",
+		},
+		InstanceIds: []string{"i-0", "i-1"},
+		VolumeIds:   []string{"vol-0"},
+	})
+}
+
+func (s *ResourceTaggerSuite) TestResourceTagTargetsNoResources(c *gc.C) {
+	backend := s.newBackend(c, "")
+	result, err := s.newFacade(c, backend).ResourceTagTargets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.InstanceIds, gc.HasLen, 0)
+	c.Check(result.VolumeIds, gc.NotNil)
+	c.Check(result.VolumeIds, gc.HasLen, 0)
+	c.Check(result.Tags, jc.DeepEquals, map[string]string{
+		"juju-model-uuid":      coretesting.ModelTag.Id(),
+		"juju-controller-uuid": "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+	})
+}
+
+func (s *ResourceTaggerSuite) TestResourceTagTargetsError(c *gc.C) {
+	backend := s.newBackend(c, "")
+	backend.err = errors.New("boom")
+	result, err := s.newFacade(c, backend).ResourceTagTargets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Error, gc.ErrorMatches, "boom")
+}
+
+func (s *ResourceTaggerSuite) TestAppliedResourceTags(c *gc.C) {
+	backend := s.newBackend(c, "")
+	f := s.newFacade(c, backend)
+	result, err := f.AppliedResourceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.AppliedResourceTagsResult{})
+
+	setResult, err := f.SetAppliedResourceTags(params.SetAppliedResourceTags{
+		Tags: map[string]string{"cost-centre": "research"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(setResult.Error, gc.IsNil)
+	result, err = f.AppliedResourceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.AppliedResourceTagsResult{
+		Tags: map[string]string{"cost-centre": "research"},
+	})
+}
+
+func (s *ResourceTaggerSuite) TestAppliedResourceTagsError(c *gc.C) {
+	backend := s.newBackend(c, "")
+	backend.err = errors.New("boom")
+	f := s.newFacade(c, backend)
+	result, err := f.AppliedResourceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Error, gc.ErrorMatches, "boom")
+	setResult, err := f.SetAppliedResourceTags(params.SetAppliedResourceTags{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(setResult.Error, gc.ErrorMatches, "boom")
+}
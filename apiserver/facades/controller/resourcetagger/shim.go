@@ -0,0 +1,114 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+// NewAPI provides the required signature for facade registration.
+func NewAPI(st *state.State, resources facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewFacade(backendShim{st, model}, resources, auth)
+}
+
+// backendShim wraps a *State and its *Model to implement Backend.
+type backendShim struct {
+	st    *state.State
+	model *state.Model
+}
+
+// WatchForModelConfigChanges is part of the Backend interface.
+func (shim backendShim) WatchForModelConfigChanges() state.NotifyWatcher {
+	return shim.model.WatchForModelConfigChanges()
+}
+
+// ModelConfig is part of the Backend interface.
+func (shim backendShim) ModelConfig() (*config.Config, error) {
+	return shim.model.ModelConfig()
+}
+
+// ModelTag is part of the Backend interface.
+func (shim backendShim) ModelTag() names.ModelTag {
+	return shim.model.ModelTag()
+}
+
+// ControllerTag is part of the Backend interface.
+func (shim backendShim) ControllerTag() names.ControllerTag {
+	return shim.st.ControllerTag()
+}
+
+// InstanceIds is part of the Backend interface.
+func (shim backendShim) InstanceIds() ([]instance.Id, error) {
+	machines, err := shim.st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ids []instance.Id
+	for _, m := range machines {
+		if names.IsContainerMachine(m.Id()) {
+			// Containers are not cloud resources.
+			continue
+		}
+		id, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// VolumeIds is part of the Backend interface.
+func (shim backendShim) VolumeIds() ([]string, error) {
+	sb, err := state.NewStorageBackend(shim.st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumes, err := sb.AllVolumes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ids []string
+	for _, v := range volumes {
+		if _, ok := names.VolumeMachine(v.VolumeTag()); ok {
+			// Machine-scoped volumes are not cloud resources.
+			continue
+		}
+		info, err := v.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ids = append(ids, info.VolumeId)
+	}
+	return ids, nil
+}
+
+// AppliedResourceTags is part of the Backend interface.
+func (shim backendShim) AppliedResourceTags() (map[string]string, error) {
+	return shim.model.AppliedResourceTags()
+}
+
+// SetAppliedResourceTags is part of the Backend interface.
+func (shim backendShim) SetAppliedResourceTags(tags map[string]string) error {
+	return shim.model.SetAppliedResourceTags(tags)
+}
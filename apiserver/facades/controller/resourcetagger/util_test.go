@@ -0,0 +1,65 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger_test
+
+import (
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// mockAuth implements facade.Authorizer for the tests' convenience.
+type mockAuth struct {
+	facade.Authorizer
+	controller bool
+}
+
+func (mock mockAuth) AuthController() bool {
+	return mock.controller
+}
+
+// mockBackend implements resourcetagger.Backend.
+type mockBackend struct {
+	state.ModelAccessor
+	config      *config.Config
+	instanceIds []instance.Id
+	volumeIds   []string
+	applied     map[string]string
+	err         error
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	return b.config, nil
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return names.NewModelTag(b.config.UUID())
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return names.NewControllerTag("deadbeef-1bad-500d-9000-4b1d0d06f00d")
+}
+
+func (b *mockBackend) InstanceIds() ([]instance.Id, error) {
+	return b.instanceIds, b.err
+}
+
+func (b *mockBackend) VolumeIds() ([]string, error) {
+	return b.volumeIds, nil
+}
+
+func (b *mockBackend) AppliedResourceTags() (map[string]string, error) {
+	return b.applied, b.err
+}
+
+func (b *mockBackend) SetAppliedResourceTags(tags map[string]string) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.applied = tags
+	return nil
+}
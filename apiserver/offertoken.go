@@ -0,0 +1,73 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/juju/errors"
+	"gopkg.in/macaroon-bakery.v2/httpbakery"
+
+	"github.com/juju/juju/apiserver/facades/client/applicationoffers"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// offerTokenHandler is an http.Handler for the "/offer-token" endpoint.
+// This is used by consumers who are unknown to this controller to redeem
+// an offer token, obtaining the details and macaroon they need to consume
+// the offer.
+type offerTokenHandler struct {
+	ctxt httpContext
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *offerTokenHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "POST" {
+		err := sendError(w, errors.MethodNotAllowedf("unsupported method: %q", req.Method))
+		if err != nil {
+			logger.Errorf("%v", err)
+		}
+		return
+	}
+	response, err := h.processPost(req)
+	if err != nil {
+		if err := sendError(w, err); err != nil {
+			logger.Errorf("%v", err)
+		}
+		return
+	}
+	if err := sendStatusAndJSON(w, http.StatusOK, response); err != nil {
+		logger.Errorf("%v", err)
+	}
+}
+
+// The client will POST to the "/offer-token" endpoint of the model hosting
+// the offer with a JSON-encoded params.RedeemOfferTokenRequest, which holds
+// the id and secret key of the token.
+func (h *offerTokenHandler) processPost(req *http.Request) (*params.ConsumeOfferDetails, error) {
+	var redeemRequest params.RedeemOfferTokenRequest
+	if err := json.NewDecoder(req.Body).Decode(&redeemRequest); err != nil {
+		return nil, errors.BadRequestf("invalid offer token request")
+	}
+	st, err := h.ctxt.stateForRequestUnauthenticated(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer st.Release()
+
+	// The macaroon is discharged by this controller, as is the case
+	// for offers consumed via the API.
+	localOfferAccessEndpoint := url.URL{
+		Scheme: "https",
+		Host:   req.Host,
+		Path:   localOfferAccessLocationPath,
+	}
+	authCtxt := h.ctxt.srv.offerAuthCtxt.WithDischargeURL(localOfferAccessEndpoint.String())
+	return applicationoffers.RedeemOfferToken(
+		req.Context(), h.ctxt.srv.shared.statePool, st.State,
+		authCtxt, redeemRequest, httpbakery.RequestVersion(req),
+	)
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/juju/testing/httptesting"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type offerTokenSuite struct {
+	apiserverBaseSuite
+	offerTokenURL string
+}
+
+var _ = gc.Suite(&offerTokenSuite{})
+
+func (s *offerTokenSuite) SetUpTest(c *gc.C) {
+	s.apiserverBaseSuite.SetUpTest(c)
+	s.offerTokenURL = s.server.URL + "/model/" + s.State.ModelUUID() + "/offer-token"
+}
+
+func (s *offerTokenSuite) TestInvalidMethod(c *gc.C) {
+	httptesting.AssertJSONCall(c, httptesting.JSONCallParams{
+		Do:           utils.GetNonValidatingHTTPClient().Do,
+		URL:          s.offerTokenURL,
+		Method:       "GET",
+		ExpectStatus: http.StatusMethodNotAllowed,
+		ExpectBody: &params.ErrorResult{
+			Error: &params.Error{
+				Message: `unsupported method: "GET"`,
+				Code:    params.CodeMethodNotAllowed,
+			},
+		},
+	})
+}
+
+func (s *offerTokenSuite) TestInvalidFormat(c *gc.C) {
+	httptesting.AssertJSONCall(c, httptesting.JSONCallParams{
+		Do:           utils.GetNonValidatingHTTPClient().Do,
+		URL:          s.offerTokenURL,
+		Method:       "POST",
+		Body:         strings.NewReader("[]"),
+		ExpectStatus: http.StatusBadRequest,
+		ExpectBody: &params.ErrorResult{
+			Error: &params.Error{
+				Message: "invalid offer token request",
+				Code:    params.CodeBadRequest,
+			},
+		},
+	})
+}
+
+func (s *offerTokenSuite) TestUnknownToken(c *gc.C) {
+	httptesting.AssertJSONCall(c, httptesting.JSONCallParams{
+		Do:     utils.GetNonValidatingHTTPClient().Do,
+		URL:    s.offerTokenURL,
+		Method: "POST",
+		JSONBody: &params.RedeemOfferTokenRequest{
+			TokenId:   "missing",
+			SecretKey: "secret",
+		},
+		ExpectStatus: http.StatusUnauthorized,
+		ExpectBody: &params.ErrorResult{
+			Error: &params.Error{
+				Message: "invalid offer token",
+				Code:    params.CodeUnauthorized,
+			},
+		},
+	})
+}
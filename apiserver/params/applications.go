@@ -311,6 +311,11 @@ type DestroyApplicationParams struct {
 	// will wait before forcing the next step to kick-off. This parameter
 	// only makes sense in combination with 'force' set to 'true'.
 	MaxWait *time.Duration `json:"max-wait,omitempty"`
+
+	// Cascade controls whether or not subordinate applications and
+	// machines which are left unused by destroying the application
+	// should be destroyed as well.
+	Cascade bool `json:"cascade,omitempty"`
 }
 
 // DestroyConsumedApplicationsParams holds bulk parameters for the
@@ -379,6 +384,42 @@ type DestroyApplicationInfo struct {
 	// DestroyedUnits is the tags of units that will be destroyed
 	// as a result of destroying the application.
 	DestroyedUnits []Entity `json:"destroyed-units,omitempty"`
+
+	// DestroyedSubordinates is the tags of the subordinate units of
+	// other applications that will be destroyed along with the
+	// application's units.
+	DestroyedSubordinates []Entity `json:"destroyed-subordinates,omitempty"`
+
+	// DestroyedRelations is the tags of relations that will be
+	// destroyed as a result of destroying the application.
+	DestroyedRelations []Entity `json:"destroyed-relations,omitempty"`
+
+	// DestroyedOffers is the tags of the application's offers,
+	// which will be destroyed along with it.
+	DestroyedOffers []Entity `json:"destroyed-offers,omitempty"`
+
+	// OfferConnections is the tags of the relations made by consuming
+	// the application's offers. The application can not be destroyed
+	// while there are any.
+	OfferConnections []Entity `json:"offer-connections,omitempty"`
+
+	// DestroyedApplications is the tags of subordinate applications
+	// that will be destroyed because destroying the application
+	// leaves them unused.
+	DestroyedApplications []Entity `json:"destroyed-applications,omitempty"`
+
+	// OrphanedApplications is the tags of subordinate applications
+	// that will be left without any principal application to relate
+	// to, but will remain in the model.
+	OrphanedApplications []Entity `json:"orphaned-applications,omitempty"`
+
+	// DestroyedMachines is the tags of machines that will be destroyed
+	// once the units and containers on them are gone.
+	DestroyedMachines []Entity `json:"destroyed-machines,omitempty"`
+
+	// OrphanedMachines is the tags of machines that will be left
+	// without any units or containers, but will remain in the model.
+	OrphanedMachines []Entity `json:"orphaned-machines,omitempty"`
 }
 
 // ScaleApplicationsParams holds bulk parameters for the Application.ScaleApplication call.
@@ -488,3 +529,79 @@ type UnitInfoResult struct {
 type UnitInfoResults struct {
 	Results []UnitInfoResult `json:"results"`
 }
+
+// AutoscaleCandidate describes an application with an autoscale policy,
+// along with the latest measurement of the policy's source.
+type AutoscaleCandidate struct {
+	ApplicationTag string `json:"application-tag"`
+
+	// Units is the number of units the application has.
+	Units int `json:"units"`
+
+	// Source is what the autoscaler measures, in the form accepted by
+	// core/application.ParseAutoscaleSource.
+	Source string `json:"source"`
+
+	// Value is the latest measurement of the source, if there is one.
+	Value *float64 `json:"value,omitempty"`
+
+	MinUnits           int     `json:"min-units"`
+	MaxUnits           int     `json:"max-units"`
+	ScaleUpThreshold   float64 `json:"scale-up-threshold"`
+	ScaleDownThreshold float64 `json:"scale-down-threshold"`
+
+	// Cooldown is the shortest time between scaling decisions.
+	Cooldown time.Duration `json:"cooldown"`
+
+	// LastScaled is the time of the latest scaling decision for the
+	// application, if there has been one.
+	LastScaled *time.Time `json:"last-scaled,omitempty"`
+}
+
+// AutoscaleCandidatesResult holds the applications with autoscale
+// policies.
+type AutoscaleCandidatesResult struct {
+	Candidates []AutoscaleCandidate `json:"candidates"`
+	Error      *Error               `json:"error,omitempty"`
+}
+
+// AutoscaleDecision describes a change to the number of units of an
+// application decided by the autoscaler.
+type AutoscaleDecision struct {
+	ApplicationTag string  `json:"application-tag"`
+	FromUnits      int     `json:"from-units"`
+	ToUnits        int     `json:"to-units"`
+	Source         string  `json:"source"`
+	Value          float64 `json:"value"`
+	Reason         string  `json:"reason"`
+}
+
+// AutoscaleDecisions holds the arguments for scaling several
+// applications.
+type AutoscaleDecisions struct {
+	Decisions []AutoscaleDecision `json:"decisions"`
+}
+
+// AutoscaleHistoryEntry describes a recorded scaling decision.
+type AutoscaleHistoryEntry struct {
+	Time      time.Time `json:"time"`
+	FromUnits int       `json:"from-units"`
+	ToUnits   int       `json:"to-units"`
+	Source    string    `json:"source"`
+	Value     float64   `json:"value"`
+	Reason    string    `json:"reason"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// AutoscaleHistoryResult holds the scaling decisions recorded for an
+// application, oldest first.
+type AutoscaleHistoryResult struct {
+	Entries []AutoscaleHistoryEntry `json:"entries"`
+	Error   *Error                  `json:"error,omitempty"`
+}
+
+// AutoscaleHistoryResults holds the scaling decisions recorded for
+// several applications.
+type AutoscaleHistoryResults struct {
+	Results []AutoscaleHistoryResult `json:"results"`
+}
@@ -239,3 +239,54 @@ func fromParamsCharmOption(opt CharmOption) charm.Option {
 		Default:     opt.Default,
 	}
 }
+
+// CharmUpgradeCandidate describes an application whose charm may be
+// upgraded automatically according to its charm update policy.
+type CharmUpgradeCandidate struct {
+	ApplicationTag string `json:"application-tag"`
+
+	// CharmURL is the URL of the application's current charm.
+	CharmURL string `json:"charm-url"`
+
+	// LatestCharmURL is the URL of the latest revision of the charm
+	// in the application's channel.
+	LatestCharmURL string `json:"latest-charm-url"`
+
+	// MaintenanceWindow restricts when the upgrade may take place, in
+	// the form accepted by core/application.ParseMaintenanceWindow.
+	MaintenanceWindow string `json:"maintenance-window,omitempty"`
+}
+
+// CharmUpgradeCandidatesResult holds the applications whose charms may
+// be upgraded automatically.
+type CharmUpgradeCandidatesResult struct {
+	Candidates []CharmUpgradeCandidate `json:"candidates"`
+	Error      *Error                  `json:"error,omitempty"`
+}
+
+// CharmUpgradeArg identifies an application to be upgraded automatically
+// to the given charm.
+type CharmUpgradeArg struct {
+	ApplicationTag string `json:"application-tag"`
+	CharmURL       string `json:"charm-url"`
+}
+
+// CharmUpgradeArgs holds the arguments for upgrading several
+// applications' charms automatically.
+type CharmUpgradeArgs struct {
+	Args []CharmUpgradeArg `json:"args"`
+}
+
+// CharmUpgradeResult holds the id of the operation recording an
+// automatic charm upgrade, or the error that prevented it from being
+// attempted.
+type CharmUpgradeResult struct {
+	OperationId string `json:"operation-id,omitempty"`
+	Error       *Error `json:"error,omitempty"`
+}
+
+// CharmUpgradeResults holds the results of upgrading several
+// applications' charms automatically.
+type CharmUpgradeResults struct {
+	Results []CharmUpgradeResult `json:"results"`
+}
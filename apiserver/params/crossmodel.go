@@ -4,6 +4,8 @@
 package params
 
 import (
+	"time"
+
 	"github.com/juju/charm/v7"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 	"gopkg.in/macaroon.v2"
@@ -644,6 +646,76 @@ type ModifyOfferAccess struct {
 	OfferURL string                `json:"offer-url"`
 }
 
+// CreateOfferTokensArgs holds the parameters for creating offer tokens.
+type CreateOfferTokensArgs struct {
+	Args []CreateOfferTokenArg `json:"args"`
+}
+
+// CreateOfferTokenArg holds the parameters for creating a token that
+// allows an offer to be consumed by users unknown to the controller.
+type CreateOfferTokenArg struct {
+	OfferURL string    `json:"offer-url"`
+	Expires  time.Time `json:"expires"`
+	MaxUses  int       `json:"max-uses,omitempty"`
+}
+
+// CreateOfferTokenResults holds the results of a CreateOfferTokens call.
+type CreateOfferTokenResults struct {
+	Results []CreateOfferTokenResult `json:"results"`
+}
+
+// CreateOfferTokenResult holds a newly created offer token, with the
+// secret key needed to redeem it, or an error.
+type CreateOfferTokenResult struct {
+	Id        string `json:"id,omitempty"`
+	SecretKey string `json:"secret-key,omitempty"`
+	ModelTag  string `json:"model-tag,omitempty"`
+	OfferURL  string `json:"offer-url,omitempty"`
+	Error     *Error `json:"error,omitempty"`
+}
+
+// OfferToken holds the details of an offer token.
+type OfferToken struct {
+	Id          string    `json:"id"`
+	OfferURL    string    `json:"offer-url"`
+	CreatedBy   string    `json:"created-by"`
+	DateCreated time.Time `json:"date-created"`
+	Expires     time.Time `json:"expires"`
+	MaxUses     int       `json:"max-uses,omitempty"`
+	Uses        int       `json:"uses"`
+	Revoked     bool      `json:"revoked,omitempty"`
+}
+
+// OfferTokensResults holds the results of a ListOfferTokens call.
+type OfferTokensResults struct {
+	Results []OfferTokensResult `json:"results"`
+}
+
+// OfferTokensResult holds the tokens for an offer, or an error.
+type OfferTokensResult struct {
+	Tokens []OfferToken `json:"tokens,omitempty"`
+	Error  *Error       `json:"error,omitempty"`
+}
+
+// OfferTokenArgs identifies offer tokens.
+type OfferTokenArgs struct {
+	Args []OfferTokenArg `json:"args"`
+}
+
+// OfferTokenArg identifies an offer token.
+type OfferTokenArg struct {
+	OfferURL string `json:"offer-url"`
+	Id       string `json:"id"`
+}
+
+// RedeemOfferTokenRequest is sent to the "/offer-token" endpoint of the
+// model hosting an offer, to exchange an offer token for the details
+// needed to consume the offer.
+type RedeemOfferTokenRequest struct {
+	TokenId   string `json:"token-id"`
+	SecretKey string `json:"secret-key"`
+}
+
 // UpdateControllerForModel contains the parameters for setting
 // a new external controller for the supplied model.
 type UpdateControllerForModel struct {
@@ -689,3 +761,81 @@ type ExternalControllerInfo struct {
 	Addrs         []string `json:"addrs"`
 	CACert        string   `json:"ca-cert"`
 }
+
+// RemoteRelationInfoArgs holds the cross model relations, and the
+// applications whose cross model relations, to get information about.
+type RemoteRelationInfoArgs struct {
+	RelationIds  []int    `json:"relation-ids,omitempty"`
+	Applications []string `json:"applications,omitempty"`
+}
+
+// RemoteRelationInfoResults holds information about cross model relations.
+type RemoteRelationInfoResults struct {
+	Results []RemoteRelationInfoResult `json:"results"`
+}
+
+// RemoteRelationInfoResult holds information about a cross model
+// relation, or the error getting it.
+type RemoteRelationInfoResult struct {
+	Result *RemoteRelationInfo `json:"result,omitempty"`
+	Error  *Error              `json:"error,omitempty"`
+}
+
+// RemoteRelationInfo holds information about a cross model relation, as
+// used to diagnose relations that are not progressing.
+type RemoteRelationInfo struct {
+	Id    int    `json:"id"`
+	Key   string `json:"key"`
+	Token string `json:"token,omitempty"`
+
+	// Offered is true if the relation is to an offer made by the model,
+	// and false if the model consumes the offer.
+	Offered bool `json:"offered"`
+
+	// SourceModelUUID is the UUID of the model at the other end of
+	// the relation.
+	SourceModelUUID string `json:"source-model-uuid,omitempty"`
+
+	// Username is the user that made the relation to the offer, for
+	// offered relations.
+	Username string `json:"username,omitempty"`
+
+	Suspended       bool   `json:"suspended"`
+	SuspendedReason string `json:"suspended-reason,omitempty"`
+
+	// MacaroonExpiry is when the macaroon used to make changes to the
+	// relation in the offering model expires, for consumed relations.
+	MacaroonExpiry *time.Time `json:"macaroon-expiry,omitempty"`
+
+	IngressNetworks []string `json:"ingress-networks,omitempty"`
+	EgressNetworks  []string `json:"egress-networks,omitempty"`
+
+	// LastEvent is the last change to the relation received from the
+	// other model, if any.
+	LastEvent *RemoteRelationEvent `json:"last-event,omitempty"`
+
+	Local  RemoteRelationEndpoint `json:"local"`
+	Remote RemoteRelationEndpoint `json:"remote"`
+}
+
+// RemoteRelationEndpoint holds the settings of one side of a cross model
+// relation.
+type RemoteRelationEndpoint struct {
+	ApplicationName  string                  `json:"application-name"`
+	Endpoint         string                  `json:"endpoint"`
+	Token            string                  `json:"token,omitempty"`
+	ApplicationData  map[string]interface{}  `json:"application-data,omitempty"`
+	UnitRelationData map[string]RelationData `json:"unit-relation-data,omitempty"`
+}
+
+// RemoteRelationEvent describes a change to a cross model relation
+// received from the other model.
+type RemoteRelationEvent struct {
+	Received                   time.Time  `json:"received"`
+	Life                       life.Value `json:"life,omitempty"`
+	Suspended                  *bool      `json:"suspended,omitempty"`
+	ChangedUnits               []int      `json:"changed-units,omitempty"`
+	DepartedUnits              []int      `json:"departed-units,omitempty"`
+	ApplicationSettingsChanged bool       `json:"application-settings-changed,omitempty"`
+	Error                      string     `json:"error,omitempty"`
+}
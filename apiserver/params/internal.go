@@ -144,6 +144,10 @@ type ModelCreateArgs struct {
 	// and the owner is the controller owner, the same credential
 	// used for the controller model will be used.
 	CloudCredentialTag string `json:"credential,omitempty"`
+
+	// Template is the name of the model template to create the model
+	// from, if any.
+	Template string `json:"template,omitempty"`
 }
 
 // Model holds the result of an API call returning a name and UUID
@@ -1030,3 +1034,37 @@ type ContainerTypeResult struct {
 	Type  instance.ContainerType `json:"container-type"`
 	Error *Error                 `json:"error"`
 }
+
+// MachinePoolResult holds the result of maintaining a model's machine
+// pool: the machines added to and removed from the pool, and the idle
+// machines left in it.
+type MachinePoolResult struct {
+	Size     int      `json:"size"`
+	Machines []string `json:"machines"`
+	Added    []string `json:"added,omitempty"`
+	Removed  []string `json:"removed,omitempty"`
+	Error    *Error   `json:"error,omitempty"`
+}
+
+// ResourceTagTargetsResult holds the tags to set on a model's cloud
+// resources, and the provider ids of the instances and volumes to set
+// them on.
+type ResourceTagTargetsResult struct {
+	Tags        map[string]string `json:"tags"`
+	InstanceIds []string          `json:"instance-ids"`
+	VolumeIds   []string          `json:"volume-ids"`
+	Error       *Error            `json:"error,omitempty"`
+}
+
+// AppliedResourceTagsResult holds the tags last set on a model's cloud
+// resources by the resource tagger.
+type AppliedResourceTagsResult struct {
+	Tags  map[string]string `json:"tags"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// SetAppliedResourceTags holds the tags set on a model's cloud resources
+// by the resource tagger.
+type SetAppliedResourceTags struct {
+	Tags map[string]string `json:"tags"`
+}
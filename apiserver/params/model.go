@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/version"
 
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/life"
 )
 
@@ -453,3 +454,55 @@ type ChangeModelCredentialParams struct {
 type ChangeModelCredentialsParams struct {
 	Models []ChangeModelCredentialParams `json:"model-credentials"`
 }
+
+// ModelTemplate holds the settings applied to models created from
+// a template.
+type ModelTemplate struct {
+	// Name is the name of the template.
+	Name string `json:"name"`
+
+	// Config holds model config attributes, which are overridden by
+	// any config specified when the model is created.
+	Config map[string]interface{} `json:"config,omitempty"`
+
+	// Constraints holds the default constraints for the model.
+	Constraints constraints.Value `json:"constraints"`
+
+	// StoragePools holds the storage pools to create in the model.
+	StoragePools []ModelTemplateStoragePool `json:"storage-pools,omitempty"`
+
+	// Spaces holds the names of the spaces to create in the model.
+	Spaces []string `json:"spaces,omitempty"`
+
+	// Users holds the access granted to users on the model.
+	Users []ModelTemplateUser `json:"users,omitempty"`
+
+	// Bundle is the bundle to deploy into the model, if any.
+	Bundle string `json:"bundle,omitempty"`
+
+	// CreatedBy is the name of the user who created the template.
+	CreatedBy string `json:"created-by,omitempty"`
+
+	// DateUpdated is when the template was last created or updated.
+	DateUpdated time.Time `json:"date-updated,omitempty"`
+}
+
+// ModelTemplateStoragePool holds a storage pool to create in models
+// created from a template.
+type ModelTemplateStoragePool struct {
+	Name     string                 `json:"name"`
+	Provider string                 `json:"provider"`
+	Attrs    map[string]interface{} `json:"attrs,omitempty"`
+}
+
+// ModelTemplateUser holds the access granted to a user on models
+// created from a template.
+type ModelTemplateUser struct {
+	UserTag string `json:"user-tag"`
+	Access  string `json:"access"`
+}
+
+// ModelTemplates holds a number of model templates.
+type ModelTemplates struct {
+	Templates []ModelTemplate `json:"templates"`
+}
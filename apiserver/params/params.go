@@ -1012,6 +1012,23 @@ type BundleChangesMapArgs struct {
 	Requires []string `json:"requires"`
 }
 
+// ExportBundleResult holds the result of the Bundle.ExportFullBundle call.
+type ExportBundleResult struct {
+	// Bundle holds the YAML-encoded bundle describing the model.
+	Bundle string `json:"bundle"`
+
+	// Overlay holds the YAML-encoded overlay for the cross-model parts of
+	// the model: its offers, the offers it consumes, and the relations to
+	// them. It is empty if there are none.
+	Overlay string `json:"overlay,omitempty"`
+
+	// ModelConfig holds the model config values that have been set on
+	// the model, rather than inherited.
+	ModelConfig map[string]interface{} `json:"model-config,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
 type MongoVersion struct {
 	Major         int    `json:"major"`
 	Minor         int    `json:"minor"`
@@ -1214,6 +1231,17 @@ type BranchArg struct {
 	BranchName string `json:"branch"`
 }
 
+// BranchCommitArg identifies an in-flight branch to commit, and how to
+// resolve conflicts with changes made to master since the branch changed
+// the same settings.
+type BranchCommitArg struct {
+	BranchName string `json:"branch"`
+
+	// OnConflict is one of "abort", "branch" or "master".
+	// If empty, branch changes overwrite conflicting master changes.
+	OnConflict string `json:"on-conflict,omitempty"`
+}
+
 // GenerationId represents an GenerationId from a branch.
 type GenerationId struct {
 	GenerationId int `json:"generation-id"`
@@ -1227,6 +1255,10 @@ type BranchInfoArgs struct {
 	// Detailed indicates whether full unit tracking detail should returned,
 	// or a summary.
 	Detailed bool `json:"detailed"`
+
+	// ThreeWay indicates whether each configuration change should be
+	// compared with its base value and the current master value.
+	ThreeWay bool `json:"three-way,omitempty"`
 }
 
 // BranchTrackArg identifies an in-flight branch and a collection of
@@ -1257,6 +1289,38 @@ type GenerationApplication struct {
 	// Config changes are the effective new configuration values resulting from
 	// changes made under this branch.
 	ConfigChanges map[string]interface{} `json:"config"`
+
+	// ConfigDiff compares each configuration value changed under this
+	// branch with its base value and the current master value.
+	ConfigDiff []ConfigItemDiff `json:"config-diff,omitempty"`
+
+	// CharmURL is the URL of the charm that the application is
+	// upgraded to under this branch, if any.
+	CharmURL string `json:"charm-url,omitempty"`
+
+	// Resources is the names of the resources uploaded
+	// with the charm that the application is upgraded to.
+	Resources []string `json:"resources,omitempty"`
+}
+
+// ConfigItemDiff is a three-way comparison of a configuration
+// value changed under a branch.
+type ConfigItemDiff struct {
+	// Key is the configuration setting.
+	Key string `json:"key"`
+
+	// Base is the master value when the branch first changed it.
+	Base interface{} `json:"base,omitempty"`
+
+	// Branch is the value under the branch.
+	Branch interface{} `json:"branch,omitempty"`
+
+	// Master is the current master value.
+	Master interface{} `json:"master,omitempty"`
+
+	// Conflict is true if master was changed since the branch changed
+	// the value, and committing the branch would overwrite it.
+	Conflict bool `json:"conflict,omitempty"`
 }
 
 // Generation represents a model generation's details including config changes.
@@ -1324,3 +1388,39 @@ type WatchContainerStartArg struct {
 type WatchContainerStartArgs struct {
 	Args []WatchContainerStartArg `json:"args"`
 }
+
+// DetectLeaksArgs holds the arguments for detecting cloud resources
+// that were created by the controller but are no longer known to it.
+type DetectLeaksArgs struct {
+	// Cleanup, if true, causes any leaked resources found to be
+	// released.
+	Cleanup bool `json:"cleanup"`
+}
+
+// LeakedResource describes a cloud resource that is tagged as belonging
+// to the controller, but which no model or machine in state refers to.
+type LeakedResource struct {
+	// Kind is the kind of resource, e.g. "instance" or "volume".
+	Kind string `json:"kind"`
+
+	// Id is the provider id of the resource.
+	Id string `json:"id"`
+
+	// ModelUUID is the UUID of the model the resource is tagged with.
+	ModelUUID string `json:"model-uuid"`
+
+	// Reason describes why the resource is considered leaked.
+	Reason string `json:"reason"`
+
+	// Released is true if the resource was released during cleanup.
+	Released bool `json:"released,omitempty"`
+
+	// Error holds any error that occurred releasing the resource.
+	Error *Error `json:"error,omitempty"`
+}
+
+// LeakedResourcesResult holds the leaked cloud resources found in
+// the controller's clouds.
+type LeakedResourcesResult struct {
+	Resources []LeakedResource `json:"resources"`
+}
@@ -15,6 +15,10 @@ type RetryStrategy struct {
 	MaxRetryTime    time.Duration `json:"max-retry-time"`
 	JitterRetryTime bool          `json:"jitter-retry-time"`
 	RetryTimeFactor int64         `json:"retry-time-factor"`
+
+	// MaxRetryAttempts is the number of times a failed hook is retried
+	// before giving up; zero means that there is no limit.
+	MaxRetryAttempts int `json:"max-retry-attempts,omitempty"`
 }
 
 // RetryStrategyResult holds a RetryStrategy or an error.
@@ -32,6 +32,7 @@ type FullStatus struct {
 	Relations           []RelationStatus                   `json:"relations"`
 	ControllerTimestamp *time.Time                         `json:"controller-timestamp"`
 	Branches            map[string]BranchStatus            `json:"branches"`
+	MachinePool         *MachinePoolStatus                 `json:"machine-pool,omitempty"`
 }
 
 // IsEmpty checks all collections on FullStatus to determine if the status is empty.
@@ -349,3 +350,11 @@ type BranchStatus struct {
 	Created       int64               `json:"created"`
 	CreatedBy     string              `json:"created-by"`
 }
+
+// MachinePoolStatus holds status info about a model's pool of idle
+// machines.
+type MachinePoolStatus struct {
+	Size        int      `json:"size"`
+	Constraints string   `json:"constraints,omitempty"`
+	Machines    []string `json:"machines"`
+}
@@ -42,6 +42,10 @@ var commonFacadeNames = set.NewStrings(
 
 	// ModelConfig may be used for letting controller commands access provider, for example, juju add-k8s.
 	"ModelConfig",
+
+	// LeakDetector is used by juju detect-leaks over a controller
+	// connection, and by the controller agent's leak detector worker.
+	"LeakDetector",
 )
 
 func controllerFacadesOnly(facadeName, _ string) error {
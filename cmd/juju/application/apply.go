@@ -0,0 +1,152 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const applyDoc = `
+Makes the model match the given bundle. The bundle can be a local bundle
+file or the name of a bundle in the charm store, and can be combined with
+overlays and given values for its variables in the same way as for the
+deploy command.
+
+Applications, units, relations, offers, options, constraints and machines
+defined in the bundle are added to the model or updated in place, as
+bundle deploy does. Existing machines are always used for the machines of
+the bundle, and the map-machines option can be used to say how they
+correspond.
+
+With --prune, anything in the model that the bundle does not define is
+also removed, once the bundle has been deployed: options not set in the
+bundle are reset to their defaults, constraints are cleared, applications
+are unexposed, and relations, offers, surplus units, applications and the
+machines left empty are removed, in that order. Machines that still host
+containers are removed by a later apply, once their containers have gone.
+
+The changes to be made are shown before any are made, and must be
+confirmed unless --yes is given. Use --dry-run to only show the changes.
+Use --parallel to make up to the given number of changes at once, as for the
+deploy command.
+
+Examples:
+    juju apply ./bundle.yaml
+    juju apply ./bundle.yaml --prune
+    juju apply ./bundle.yaml --prune --dry-run
+    juju apply ./bundle.yaml --overlay ./staging.yaml --prune --yes
+    juju apply ./bundle.yaml --var-file ./production.yaml --prune
+    juju apply ./bundle.yaml --parallel 8
+    juju apply canonical-kubernetes --channel beta
+
+See also:
+    deploy
+    diff-bundle
+`
+
+// NewApplyCommand returns a command which reconciles the model with a
+// bundle.
+func NewApplyCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(newApplyCommand())
+}
+
+func newApplyCommand() *applyCommand {
+	return &applyCommand{DeployCommand: newDeployCommand()}
+}
+
+// applyCommand deploys a bundle to the model, optionally removing what the
+// bundle does not define.
+type applyCommand struct {
+	*DeployCommand
+
+	assumeYes bool
+}
+
+// Info implements cmd.Command.
+func (c *applyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "apply",
+		Args:    "<bundle file or name>",
+		Purpose: "Makes a model match a bundle.",
+		Doc:     applyDoc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *applyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar((*string)(&c.Channel), "channel", "", "Channel to use when getting the bundle from the charm store")
+	f.Var(cmd.NewAppendStringsValue(&c.BundleOverlayFile), "overlay", "Bundles to overlay on the primary bundle, applied in order")
+	f.StringVar(&c.machineMap, "map-machines", "", "Indicates how existing machines correspond to bundle machines")
+	f.BoolVar(&c.Trust, "trust", false, "Allows charms to run hooks that require access credentials")
+	f.BoolVar(&c.Force, "force", false, "Allow a bundle to be applied which bypasses checks such as supported series or LXD profile allow list")
+	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
+	f.Var(devicesFlag{&c.Devices, &c.BundleDevices}, "device", "Charm device constraints")
+	c.bundleVars.SetFlags(f)
+	f.IntVar(&c.parallel, "parallel", 1, "Maximum number of bundle changes to make at once")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Just show the changes that would be made")
+	f.BoolVar(&c.prune, "prune", false, "Remove anything in the model that is not defined in the bundle")
+	f.BoolVar(&c.assumeYes, "y", false, "Do not prompt for confirmation")
+	f.BoolVar(&c.assumeYes, "yes", false, "")
+	for _, step := range c.Steps {
+		step.SetFlags(f)
+	}
+	c.flagSet = f
+}
+
+// Init implements cmd.Command.
+func (c *applyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no bundle specified")
+	}
+	c.CharmOrBundle = args[0]
+	// As for diff-bundle, existing machines are always used.
+	_, mapping, err := parseMachineMap(c.machineMap)
+	if err != nil {
+		return errors.Annotate(err, "error in --map-machines")
+	}
+	c.UseExisting = true
+	c.BundleMachines = mapping
+	if c.parallel < 1 {
+		return errors.New("--parallel must be at least 1")
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *applyCommand) Run(ctx *cmd.Context) error {
+	if !c.assumeYes {
+		c.confirm = jujucmd.UserConfirmYes
+	}
+	cstoreAPI, err := c.NewCharmRepo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	apiRoot, err := c.NewAPIRoot()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer apiRoot.Close()
+
+	for _, step := range c.Steps {
+		step.SetPlanURL(apiRoot.PlanURL())
+	}
+
+	deploy, err := findDeployerFIFO(
+		func() (deployFn, error) { return c.maybeReadLocalBundle(ctx) },
+		c.maybeReadCharmstoreBundleFn(cstoreAPI),
+	)
+	if errors.IsNotFound(err) {
+		return errors.Errorf("%q is not a bundle", c.CharmOrBundle)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return block.ProcessBlockedError(deploy(ctx, apiRoot, c.DeployResources, cstoreAPI), block.BlockChange)
+}
@@ -0,0 +1,177 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const autoscaleHistoryDoc = `
+Shows the changes made by the autoscaler to the number of units of an
+application, oldest first. Each entry shows what the autoscaler measured,
+the measurement and the reason the application was scaled, and the
+error if scaling failed.
+
+Applications are autoscaled when their autoscale-source config is set.
+See "juju help config" for the autoscale options.
+
+When an application on machines is scaled down its newest units are
+removed. A machine is destroyed along with the last unit on it; machines
+that still host other units or containers are kept.
+
+Examples:
+    juju autoscale-history mysql
+    juju autoscale-history mysql --format yaml
+
+See also:
+    config
+    scale-application
+`
+
+// NewAutoscaleHistoryCommand returns a command that shows the scaling
+// decisions made by the autoscaler for an application.
+func NewAutoscaleHistoryCommand() cmd.Command {
+	c := &autoscaleHistoryCommand{}
+	c.newAPIFunc = func() (AutoscaleHistoryAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+// AutoscaleHistoryAPI defines the API methods that the autoscale-history
+// command uses.
+type AutoscaleHistoryAPI interface {
+	Close() error
+	AutoscaleHistory(application string) ([]params.AutoscaleHistoryEntry, error)
+}
+
+type autoscaleHistoryCommand struct {
+	modelcmd.ModelCommandBase
+
+	out         cmd.Output
+	isoTime     bool
+	application string
+
+	newAPIFunc func() (AutoscaleHistoryAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *autoscaleHistoryCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "autoscale-history",
+		Args:    "<application name>",
+		Purpose: "Displays the scaling decisions made by the autoscaler.",
+		Doc:     autoscaleHistoryDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *autoscaleHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAutoscaleHistoryTabular,
+	})
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+}
+
+// Init implements Command.Init.
+func (c *autoscaleHistoryCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no application name specified")
+	case 1:
+		if !names.IsValidApplication(args[0]) {
+			return errors.NotValidf("application name %q", args[0])
+		}
+		c.application = args[0]
+		return nil
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *autoscaleHistoryCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	entries, err := client.AutoscaleHistory(c.application)
+	if errors.IsNotSupported(err) {
+		return errors.New("autoscaling is not supported by this version of Juju")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if len(entries) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("Application %q has not been autoscaled.", c.application)
+		return nil
+	}
+	history := make([]AutoscaleHistoryEntry, len(entries))
+	for i, entry := range entries {
+		history[i] = AutoscaleHistoryEntry{
+			Time:      common.FormatTime(&entry.Time, c.isoTime),
+			FromUnits: entry.FromUnits,
+			ToUnits:   entry.ToUnits,
+			Source:    entry.Source,
+			Value:     entry.Value,
+			Reason:    entry.Reason,
+			Error:     entry.Error,
+		}
+	}
+	return c.out.Write(ctx, history)
+}
+
+// AutoscaleHistoryEntry defines the serialization behaviour of a scaling
+// decision made by the autoscaler.
+type AutoscaleHistoryEntry struct {
+	Time      string  `yaml:"time" json:"time"`
+	FromUnits int     `yaml:"from-units" json:"from-units"`
+	ToUnits   int     `yaml:"to-units" json:"to-units"`
+	Source    string  `yaml:"source" json:"source"`
+	Value     float64 `yaml:"value" json:"value"`
+	Reason    string  `yaml:"reason" json:"reason"`
+	Error     string  `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+func formatAutoscaleHistoryTabular(writer io.Writer, value interface{}) error {
+	history, ok := value.([]AutoscaleHistoryEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", history, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "Units", "Source", "Value", "Reason", "Error")
+	for _, entry := range history {
+		w.Println(
+			entry.Time,
+			fmt.Sprintf("%d->%d", entry.FromUnits, entry.ToUnits),
+			entry.Source,
+			strconv.FormatFloat(entry.Value, 'g', -1, 64),
+			entry.Reason,
+			entry.Error,
+		)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+	jujutesting "github.com/juju/juju/testing"
+)
+
+type AutoscaleHistorySuite struct {
+	jujutesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+
+	mockAPI *mockAutoscaleHistoryAPI
+}
+
+var _ = gc.Suite(&AutoscaleHistorySuite{})
+
+func (s *AutoscaleHistorySuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	when := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+	s.mockAPI = &mockAutoscaleHistoryAPI{
+		entries: []params.AutoscaleHistoryEntry{{
+			Time:      when,
+			FromUnits: 1,
+			ToUnits:   2,
+			Source:    "metric:load",
+			Value:     0.9,
+			Reason:    "metric:load 0.9 above 0.8",
+		}, {
+			Time:      when.Add(5 * time.Minute),
+			FromUnits: 2,
+			ToUnits:   3,
+			Source:    "metric:load",
+			Value:     0.95,
+			Reason:    "metric:load 0.95 above 0.8",
+			Error:     "no machines",
+		}},
+	}
+}
+
+func (s *AutoscaleHistorySuite) runHistory(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, application.NewAutoscaleHistoryCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *AutoscaleHistorySuite) TestInit(c *gc.C) {
+	_, err := s.runHistory(c)
+	c.Assert(err, gc.ErrorMatches, "no application name specified")
+	_, err = s.runHistory(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `application name "mysql/0" not valid`)
+	_, err = s.runHistory(c, "mysql", "wordpress")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["wordpress"\]`)
+}
+
+func (s *AutoscaleHistorySuite) TestTabular(c *gc.C) {
+	ctx, err := s.runHistory(c, "mysql", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.application, gc.Equals, "mysql")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Time                  Units  Source       Value  Reason                      Error
+2020-04-01 12:00:00Z  1->2   metric:load  0.9    metric:load 0.9 above 0.8   
+2020-04-01 12:05:00Z  2->3   metric:load  0.95   metric:load 0.95 above 0.8  no machines
+
+`[1:])
+}
+
+func (s *AutoscaleHistorySuite) TestYAML(c *gc.C) {
+	ctx, err := s.runHistory(c, "mysql", "--utc", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- time: 2020-04-01 12:00:00Z
+  from-units: 1
+  to-units: 2
+  source: metric:load
+  value: 0.9
+  reason: metric:load 0.9 above 0.8
+- time: 2020-04-01 12:05:00Z
+  from-units: 2
+  to-units: 3
+  source: metric:load
+  value: 0.95
+  reason: metric:load 0.95 above 0.8
+  error: no machines
+`[1:])
+}
+
+func (s *AutoscaleHistorySuite) TestNoHistory(c *gc.C) {
+	s.mockAPI.entries = nil
+	ctx, err := s.runHistory(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Application \"mysql\" has not been autoscaled.\n")
+}
+
+func (s *AutoscaleHistorySuite) TestNotSupported(c *gc.C) {
+	s.mockAPI.err = errors.NotSupportedf("AutoscaleHistory")
+	_, err := s.runHistory(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "autoscaling is not supported by this version of Juju")
+}
+
+type mockAutoscaleHistoryAPI struct {
+	application string
+	entries     []params.AutoscaleHistoryEntry
+	err         error
+}
+
+func (m *mockAutoscaleHistoryAPI) Close() error {
+	return nil
+}
+
+func (m *mockAutoscaleHistoryAPI) AutoscaleHistory(application string) ([]params.AutoscaleHistoryEntry, error) {
+	m.application = application
+	return m.entries, m.err
+}
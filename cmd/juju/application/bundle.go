@@ -10,6 +10,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/bundlechanges"
@@ -113,13 +114,24 @@ type bundleDeploySpec struct {
 	bundleStorage       map[string]map[string]storage.Constraints
 	bundleDevices       map[string]map[string]devices.Constraints
 
+	// prune requests that everything in the model that is not defined in
+	// the bundle is removed once the bundle has been deployed.
+	prune bool
+
+	// confirm, if set, is called once the changes have been shown to
+	// the user, to confirm that they should be made.
+	confirm func(*cmd.Context) error
+
+	// concurrency is the maximum number of changes to apply at once.
+	concurrency int
+
 	targetModelName string
 	targetModelUUID string
 	controllerName  string
 	accountUser     string
 }
 
-func composeAndVerifyBundle(base charm.BundleDataSource, pathToOverlays []string) (*charm.BundleData, error) {
+func composeAndVerifyBundle(base charm.BundleDataSource, pathToOverlays []string, vars *bundleVariables) (*charm.BundleData, error) {
 	var (
 		dsList []charm.BundleDataSource
 		err    error
@@ -127,13 +139,16 @@ func composeAndVerifyBundle(base charm.BundleDataSource, pathToOverlays []string
 
 	dsList = append(dsList, base)
 	for _, pathToOverlay := range pathToOverlays {
-		ds, err := charm.LocalBundleDataSource(pathToOverlay)
+		ds, err := vars.localDataSource(pathToOverlay)
 		if err != nil {
 			return nil, errors.Annotatef(err, "unable to process overlays")
 		}
 		dsList = append(dsList, ds)
 	}
 
+	if err := vars.checkDeclared(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	bundleData, err := charm.ReadAndMergeBundleData(dsList...)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -163,6 +178,18 @@ func deployBundle(bundleData *charm.BundleData, spec bundleDeploySpec) (map[*cha
 	if err := h.getChanges(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if spec.prune {
+		if err := h.getPruneChanges(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if spec.confirm != nil && !spec.dryRun && h.hasChanges() {
+		h.showChanges()
+		fmt.Fprint(spec.ctx.Stdout, "Continue [y/N]? ")
+		if err := spec.confirm(spec.ctx); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	if err := h.handleChanges(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -175,6 +202,11 @@ type bundleHandler struct {
 	force  bool
 	trust  bool
 
+	// concurrency is the maximum number of changes to apply at once. If
+	// it is more than one, each change is applied as soon as the changes
+	// it requires have been.
+	concurrency int
+
 	clock jujuclock.Clock
 
 	// bundleDir is the path where the bundle file is located for local bundles.
@@ -182,6 +214,11 @@ type bundleHandler struct {
 	// changes holds the changes to be applied in order to deploy the bundle.
 	changes []bundlechanges.Change
 
+	// pruneChanges holds the changes to be applied, once the bundle has
+	// been deployed, to remove what is not defined in the bundle from the
+	// model.
+	pruneChanges []pruneChange
+
 	// applications are all the applications defined in the bundle.
 	// Used primarily for iterating over sorted values.
 	applications set.Strings
@@ -240,14 +277,20 @@ type bundleHandler struct {
 
 	modelConfig *config.Config
 
-	model *bundlechanges.Model
+	model       *bundlechanges.Model
+	modelStatus *params.FullStatus
 
 	macaroons map[*charm.URL]*macaroon.Macaroon
 	channels  map[*charm.URL]csparams.Channel
 
 	// watcher holds an environment mega-watcher used to keep the environment
-	// status up to date.
+	// status up to date. watchMu serialises reading from it.
 	watcher allWatcher
+	watchMu sync.Mutex
+
+	// mu guards results, unitStatus, macaroons, channels and warnedLXC,
+	// which are updated by changes applied concurrently.
+	mu sync.Mutex
 
 	// warnedLXC indicates whether or not we have warned the user that the
 	// bundle they're deploying uses lxc containers, which will be treated as
@@ -279,6 +322,7 @@ func makeBundleHandler(bundleData *charm.BundleData, spec bundleDeploySpec) *bun
 		dryRun:               spec.dryRun,
 		force:                spec.force,
 		trust:                spec.trust,
+		concurrency:          spec.concurrency,
 		bundleDir:            spec.bundleDir,
 		applications:         applications,
 		results:              make(map[string]string),
@@ -317,6 +361,7 @@ func (h *bundleHandler) makeModel(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	h.modelStatus = status
 	logger.Debugf("model: %s", pretty.Sprint(h.model))
 
 	for _, appData := range status.Applications {
@@ -429,6 +474,30 @@ func (h *bundleHandler) getChanges() error {
 	return nil
 }
 
+func (h *bundleHandler) getPruneChanges() error {
+	changes, err := pruneChanges(h.data, h.model, h.modelStatus)
+	if err != nil {
+		return errors.Annotate(err, "cannot work out what to remove from the model")
+	}
+	h.pruneChanges = changes
+	return nil
+}
+
+func (h *bundleHandler) hasChanges() bool {
+	return len(h.changes) > 0 || len(h.pruneChanges) > 0
+}
+
+// showChanges writes the changes that are going to be made to the model.
+func (h *bundleHandler) showChanges() {
+	fmt.Fprintf(h.ctx.Stdout, "Changes to apply bundle:\n")
+	for _, change := range h.changes {
+		fmt.Fprintf(h.ctx.Stdout, "- %s\n", change.Description())
+	}
+	for _, change := range h.pruneChanges {
+		fmt.Fprintf(h.ctx.Stdout, "- %s\n", change.Description())
+	}
+}
+
 func (h *bundleHandler) handleChanges() error {
 	var err error
 	// Instantiate a watcher used to follow the deployment progress.
@@ -438,7 +507,7 @@ func (h *bundleHandler) handleChanges() error {
 	}
 	defer h.watcher.Stop()
 
-	if len(h.changes) == 0 {
+	if !h.hasChanges() {
 		h.ctx.Infof("No changes to apply.")
 		return nil
 	}
@@ -450,44 +519,19 @@ func (h *bundleHandler) handleChanges() error {
 	}
 
 	// Deploy the bundle.
-	for i, change := range h.changes {
-		fmt.Fprintf(h.ctx.Stdout, "- %s\n", change.Description())
-		logger.Tracef("%d: change %s", i, pretty.Sprint(change))
-		switch change := change.(type) {
-		case *bundlechanges.AddCharmChange:
-			err = h.addCharm(change)
-		case *bundlechanges.AddMachineChange:
-			err = h.addMachine(change)
-		case *bundlechanges.AddRelationChange:
-			err = h.addRelation(change)
-		case *bundlechanges.AddApplicationChange:
-			err = h.addApplication(change)
-		case *bundlechanges.ScaleChange:
-			err = h.scaleApplication(change)
-		case *bundlechanges.AddUnitChange:
-			err = h.addUnit(change)
-		case *bundlechanges.ExposeChange:
-			err = h.exposeApplication(change)
-		case *bundlechanges.SetAnnotationsChange:
-			err = h.setAnnotations(change)
-		case *bundlechanges.UpgradeCharmChange:
-			err = h.upgradeCharm(change)
-		case *bundlechanges.SetOptionsChange:
-			err = h.setOptions(change)
-		case *bundlechanges.SetConstraintsChange:
-			err = h.setConstraints(change)
-		case *bundlechanges.CreateOfferChange:
-			err = h.createOffer(change)
-		case *bundlechanges.ConsumeOfferChange:
-			err = h.consumeOffer(change)
-		case *bundlechanges.GrantOfferAccessChange:
-			err = h.grantOfferAccess(change)
-		default:
-			return errors.Errorf("unknown change type: %T", change)
-		}
-		if err != nil {
-			return errors.Trace(err)
+	if h.dryRun || h.concurrency <= 1 {
+		for i, change := range h.changes {
+			fmt.Fprintf(h.ctx.Stdout, "- %s\n", change.Description())
+			logger.Tracef("%d: change %s", i, pretty.Sprint(change))
+			if err := h.applyChange(change); err != nil {
+				return errors.Trace(err)
+			}
 		}
+	} else if err := h.applyChangesConcurrently(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := h.handlePruneChanges(); err != nil {
+		return errors.Trace(err)
 	}
 
 	if !h.dryRun {
@@ -497,6 +541,41 @@ func (h *bundleHandler) handleChanges() error {
 	return nil
 }
 
+// applyChange applies a single bundle change to the model.
+func (h *bundleHandler) applyChange(change bundlechanges.Change) error {
+	switch change := change.(type) {
+	case *bundlechanges.AddCharmChange:
+		return h.addCharm(change)
+	case *bundlechanges.AddMachineChange:
+		return h.addMachine(change)
+	case *bundlechanges.AddRelationChange:
+		return h.addRelation(change)
+	case *bundlechanges.AddApplicationChange:
+		return h.addApplication(change)
+	case *bundlechanges.ScaleChange:
+		return h.scaleApplication(change)
+	case *bundlechanges.AddUnitChange:
+		return h.addUnit(change)
+	case *bundlechanges.ExposeChange:
+		return h.exposeApplication(change)
+	case *bundlechanges.SetAnnotationsChange:
+		return h.setAnnotations(change)
+	case *bundlechanges.UpgradeCharmChange:
+		return h.upgradeCharm(change)
+	case *bundlechanges.SetOptionsChange:
+		return h.setOptions(change)
+	case *bundlechanges.SetConstraintsChange:
+		return h.setConstraints(change)
+	case *bundlechanges.CreateOfferChange:
+		return h.createOffer(change)
+	case *bundlechanges.ConsumeOfferChange:
+		return h.consumeOffer(change)
+	case *bundlechanges.GrantOfferAccessChange:
+		return h.grantOfferAccess(change)
+	}
+	return errors.Errorf("unknown change type: %T", change)
+}
+
 func (h *bundleHandler) isLocalCharm(name string) bool {
 	return strings.HasPrefix(name, ".") || filepath.IsAbs(name)
 }
@@ -533,7 +612,7 @@ func (h *bundleHandler) addCharm(change *bundlechanges.AddCharmChange) error {
 				return err
 			}
 			logger.Debugf("added charm %s", curl)
-			h.results[id] = curl.String()
+			h.setResult(id, curl.String())
 			return nil
 		}
 	}
@@ -557,9 +636,11 @@ func (h *bundleHandler) addCharm(change *bundlechanges.AddCharmChange) error {
 		return errors.Annotatef(err, "cannot add charm %q", p.Charm)
 	}
 	logger.Debugf("added charm %s", url)
+	h.mu.Lock()
 	h.results[id] = url.String()
 	h.macaroons[url] = macaroon
 	h.channels[url] = channel
+	h.mu.Unlock()
 	return nil
 }
 
@@ -593,18 +674,20 @@ func (h *bundleHandler) addApplication(change *bundlechanges.AddApplicationChang
 	}
 
 	p := change.Params
-	cURL, err := charm.ParseURL(resolve(p.Charm, h.results))
+	cURL, err := charm.ParseURL(h.resolve(p.Charm))
 	if err != nil {
 		return errors.Trace(err)
 	}
 
+	h.mu.Lock()
 	chID := charmstore.CharmID{
 		URL:     cURL,
 		Channel: h.channels[cURL],
 	}
 	macaroon := h.macaroons[cURL]
+	h.mu.Unlock()
 
-	h.results[change.Id()] = p.Application
+	h.setResult(change.Id(), p.Application)
 	ch := chID.URL.String()
 
 	// If this application requires trust and the operator consented to
@@ -827,10 +910,12 @@ func (h *bundleHandler) addMachine(change *bundlechanges.AddMachineChange) error
 		// for backwards compatibility with 1.x bundles, we treat lxc
 		// placement directives as lxd.
 		if ct == "lxc" {
+			h.mu.Lock()
 			if !h.warnedLXC {
 				h.ctx.Infof("Bundle has one or more containers specified as lxc. lxc containers are deprecated in Juju 2.0. lxd containers will be deployed instead.")
 				h.warnedLXC = true
 			}
+			h.mu.Unlock()
 			ct = string(instance.LXD)
 		}
 		containerType, err := instance.ParseContainerType(ct)
@@ -864,7 +949,7 @@ func (h *bundleHandler) addMachine(change *bundlechanges.AddMachineChange) error
 	} else {
 		logger.Debugf("created %s container in machine %s for holding %s", machine, machineParams.ParentId, deployedApps())
 	}
-	h.results[change.Id()] = machine
+	h.setResult(change.Id(), machine)
 	return nil
 }
 
@@ -874,8 +959,8 @@ func (h *bundleHandler) addRelation(change *bundlechanges.AddRelationChange) err
 		return nil
 	}
 	p := change.Params
-	ep1 := resolveRelation(p.Endpoint1, h.results)
-	ep2 := resolveRelation(p.Endpoint2, h.results)
+	ep1 := h.resolveRelation(p.Endpoint1)
+	ep2 := h.resolveRelation(p.Endpoint2)
 	// TODO(wallyworld) - CMR support in bundles
 	_, err := h.api.AddRelation([]string{ep1, ep2}, nil)
 	if err != nil {
@@ -897,7 +982,7 @@ func (h *bundleHandler) addUnit(change *bundlechanges.AddUnitChange) error {
 	}
 
 	p := change.Params
-	applicationName := resolve(p.Application, h.results)
+	applicationName := h.resolve(p.Application)
 	var err error
 	var placementArg []*instance.Placement
 	targetMachine := p.To
@@ -939,16 +1024,18 @@ func (h *bundleHandler) addUnit(change *bundlechanges.AddUnitChange) error {
 		// In this case, the unit name is stored in results instead of the
 		// machine id, which is lazily evaluated later only if required.
 		// This way we avoid waiting for watcher updates.
-		h.results[change.Id()] = unit
+		h.setResult(change.Id(), unit)
 	} else {
 		logger.Debugf("added %s unit to new machine", unit)
-		h.results[change.Id()] = targetMachine
+		h.setResult(change.Id(), targetMachine)
 	}
 
 	// Note that the targetMachine can be empty for now, resulting in a partially
 	// incomplete unit status. That's ok as the missing info is provided later
 	// when it is required.
+	h.mu.Lock()
 	h.unitStatus[unit] = targetMachine
+	h.mu.Unlock()
 	return nil
 }
 
@@ -959,16 +1046,18 @@ func (h *bundleHandler) upgradeCharm(change *bundlechanges.UpgradeCharmChange) e
 	}
 
 	p := change.Params
-	cURL, err := charm.ParseURL(resolve(p.Charm, h.results))
+	cURL, err := charm.ParseURL(h.resolve(p.Charm))
 	if err != nil {
 		return errors.Trace(err)
 	}
 
+	h.mu.Lock()
 	chID := charmstore.CharmID{
 		URL:     cURL,
 		Channel: h.channels[cURL],
 	}
 	macaroon := h.macaroons[cURL]
+	h.mu.Unlock()
 
 	meta, err := getMetaResources(cURL, h.api)
 	if err != nil {
@@ -1069,7 +1158,7 @@ func (h *bundleHandler) exposeApplication(change *bundlechanges.ExposeChange) er
 		return nil
 	}
 
-	application := resolve(change.Params.Application, h.results)
+	application := h.resolve(change.Params.Application)
 	if err := h.api.Expose(application); err != nil {
 		return errors.Annotatef(err, "cannot expose application %s", application)
 	}
@@ -1086,7 +1175,7 @@ func (h *bundleHandler) setAnnotations(change *bundlechanges.SetAnnotationsChang
 	if h.dryRun {
 		return nil
 	}
-	eid := resolve(p.Id, h.results)
+	eid := h.resolve(p.Id)
 	var tag string
 	switch p.EntityType {
 	case bundlechanges.MachineType:
@@ -1191,7 +1280,7 @@ func (h *bundleHandler) consumeOffer(change *bundlechanges.ConsumeOfferChange) e
 	if err != nil {
 		return errors.Trace(err)
 	}
-	h.results[change.Id()] = localName
+	h.setResult(change.Id(), localName)
 	h.ctx.Infof("Added %s as %s", url.Path(), localName)
 	return nil
 }
@@ -1236,7 +1325,7 @@ mainloop:
 			case *bundlechanges.AddUnitChange:
 				// We have found the "addUnit" change, which refers to a
 				// application: now resolve the application holding the unit.
-				application := resolve(change.Params.Application, h.results)
+				application := h.resolve(change.Params.Application)
 				applications.Add(application)
 				continue mainloop
 			case *bundlechanges.SetAnnotationsChange:
@@ -1274,12 +1363,14 @@ func (h *bundleHandler) updateUnitStatus() error {
 		if err != nil {
 			return errors.Annotate(err, "cannot update model status")
 		}
+		h.mu.Lock()
 		for _, d := range delta {
 			switch entityInfo := d.Entity.(type) {
 			case *params.UnitInfo:
 				h.unitStatus[entityInfo.Name] = entityInfo.MachineId
 			}
 		}
+		h.mu.Unlock()
 	case <-time.After(updateUnitStatusPeriod):
 		// TODO(fwereade): 2016-03-17 lp:1558657
 		return errors.New("timeout while trying to get new changes from the watcher")
@@ -1291,16 +1382,47 @@ func (h *bundleHandler) updateUnitStatus() error {
 // placeholder.
 func (h *bundleHandler) resolveMachine(placeholder string) (string, error) {
 	logger.Debugf("resolveMachine(%q)", placeholder)
-	machineOrUnit := resolve(placeholder, h.results)
+	machineOrUnit := h.resolve(placeholder)
 	if !names.IsValidUnit(machineOrUnit) {
 		return machineOrUnit, nil
 	}
-	for h.unitStatus[machineOrUnit] == "" {
+	// Only one change at a time may read from the watcher.
+	h.watchMu.Lock()
+	defer h.watchMu.Unlock()
+	for {
+		h.mu.Lock()
+		machine := h.unitStatus[machineOrUnit]
+		h.mu.Unlock()
+		if machine != "" {
+			return machine, nil
+		}
 		if err := h.updateUnitStatus(); err != nil {
 			return "", errors.Annotate(err, "cannot resolve machine")
 		}
 	}
-	return h.unitStatus[machineOrUnit], nil
+}
+
+// resolve returns the real entity name for the bundle entity with the given
+// placeholder id, from the results of the changes applied so far.
+func (h *bundleHandler) resolve(placeholder string) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return resolve(placeholder, h.results)
+}
+
+// resolveRelation returns the relation name resolving the included
+// application placeholder.
+func (h *bundleHandler) resolveRelation(e string) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return resolveRelation(e, h.results)
+}
+
+// setResult records the result of applying the change with the given id.
+func (h *bundleHandler) setResult(id, result string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.results[id] = result
 }
 
 func (h *bundleHandler) topLevelMachine(id string) string {
@@ -0,0 +1,134 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/bundlechanges"
+	"github.com/juju/errors"
+	"github.com/kr/pretty"
+)
+
+// applyChangesConcurrently applies the bundle changes, starting each as soon
+// as the changes it requires have been applied, with at most h.concurrency
+// being applied at once. Each change is reported as it completes.
+func (h *bundleHandler) applyChangesConcurrently() error {
+	total := len(h.changes)
+	started := make(map[string]time.Time)
+	applied := 0
+	return applyChanges(h.changes, h.concurrency,
+		func(change bundlechanges.Change) error {
+			logger.Tracef("change %s", pretty.Sprint(change))
+			return h.applyChange(change)
+		},
+		func(change bundlechanges.Change) {
+			started[change.Id()] = h.clock.Now()
+			h.ctx.Verbosef("  started: %s", change.Description())
+		},
+		func(change bundlechanges.Change) {
+			applied++
+			elapsed := h.clock.Now().Sub(started[change.Id()])
+			fmt.Fprintf(h.ctx.Stdout, "- [%d/%d] %s\n", applied, total, change.Description())
+			h.ctx.Verbosef("  took %s", elapsed.Round(time.Millisecond))
+		},
+	)
+}
+
+// applyChanges calls apply for each of the changes, once the changes it
+// requires have been applied, with at most limit calls running at once.
+// Of the changes ready to be applied, those given first are started first,
+// so that with a limit of one the changes are applied in order. The start and
+// done functions, which are called for each change as it is started and
+// once it has been applied, are only called from the calling goroutine.
+//
+// Once a change fails, no more are started, and the error of the first
+// to fail is returned once those already started have completed.
+func applyChanges(
+	changes []bundlechanges.Change,
+	limit int,
+	apply func(bundlechanges.Change) error,
+	start, done func(bundlechanges.Change),
+) error {
+	if limit < 1 {
+		limit = 1
+	}
+	index := make(map[string]int)
+	for i, change := range changes {
+		index[change.Id()] = i
+	}
+
+	// waiting holds the number of changes that each change still requires,
+	// and dependents the changes that require each change.
+	waiting := make(map[string]int)
+	dependents := make(map[string][]bundlechanges.Change)
+	var ready []bundlechanges.Change
+	for _, change := range changes {
+		for _, required := range change.Requires() {
+			if _, ok := index[required]; !ok {
+				continue
+			}
+			waiting[change.Id()]++
+			dependents[required] = append(dependents[required], change)
+		}
+		if waiting[change.Id()] == 0 {
+			ready = append(ready, change)
+		}
+	}
+
+	type result struct {
+		change bundlechanges.Change
+		err    error
+	}
+	results := make(chan result)
+	var (
+		running  int
+		applied  int
+		firstErr error
+	)
+	for {
+		for firstErr == nil && running < limit && len(ready) > 0 {
+			change := ready[0]
+			ready = ready[1:]
+			start(change)
+			running++
+			go func() {
+				results <- result{change: change, err: apply(change)}
+			}()
+		}
+		if running == 0 {
+			break
+		}
+		r := <-results
+		running--
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = errors.Annotate(r.err, r.change.Description())
+			}
+			continue
+		}
+		applied++
+		done(r.change)
+		for _, dependent := range dependents[r.change.Id()] {
+			waiting[dependent.Id()]--
+			if waiting[dependent.Id()] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+		sort.Slice(ready, func(i, j int) bool {
+			return index[ready[i].Id()] < index[ready[j].Id()]
+		})
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+	if applied != len(changes) {
+		// This should never happen, as bundlechanges only returns
+		// changes that can be applied in order.
+		return errors.Errorf("cannot apply %d of the bundle changes: they require each other", len(changes)-applied)
+	}
+	return nil
+}
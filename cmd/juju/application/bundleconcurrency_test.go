@@ -0,0 +1,162 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/juju/bundlechanges"
+	"github.com/juju/charm/v7"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application"
+)
+
+type applyChangesSuite struct {
+	testing.IsolationSuite
+
+	changes []bundlechanges.Change
+}
+
+var _ = gc.Suite(&applyChangesSuite{})
+
+func (s *applyChangesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	data, err := charm.ReadBundleData(strings.NewReader(`
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: 2
+  wordpress:
+    charm: cs:wordpress
+    num_units: 2
+  haproxy:
+    charm: cs:haproxy
+    num_units: 1
+relations:
+- - wordpress:db
+  - mysql:db
+- - haproxy:reverseproxy
+  - wordpress:website
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	s.changes, err = bundlechanges.FromData(bundlechanges.ChangesConfig{
+		Bundle: data,
+		Logger: loggo.GetLogger("bundlechanges"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// recorder records the changes applied, checking that the changes each
+// requires were applied first.
+type recorder struct {
+	c *gc.C
+
+	mu         sync.Mutex
+	applied    set.Strings
+	started    int
+	running    int
+	maxRunning int
+
+	// If pair is set, the first change started waits for the second to
+	// start before completing.
+	pair chan struct{}
+}
+
+func newRecorder(c *gc.C) *recorder {
+	return &recorder{c: c, applied: set.NewStrings()}
+}
+
+func (r *recorder) apply(change bundlechanges.Change) error {
+	r.mu.Lock()
+	for _, required := range change.Requires() {
+		r.c.Check(r.applied.Contains(required), jc.IsTrue, gc.Commentf("%s before %s", change.Id(), required))
+	}
+	r.started++
+	started := r.started
+	r.running++
+	if r.running > r.maxRunning {
+		r.maxRunning = r.running
+	}
+	r.mu.Unlock()
+
+	if r.pair != nil {
+		switch started {
+		case 1:
+			<-r.pair
+		case 2:
+			close(r.pair)
+		}
+	}
+
+	r.mu.Lock()
+	r.running--
+	r.applied.Add(change.Id())
+	r.mu.Unlock()
+	return nil
+}
+
+func (s *applyChangesSuite) TestRequiredChangesFirst(c *gc.C) {
+	r := newRecorder(c)
+	var started, done []string
+	err := application.ApplyChanges(s.changes, 4, r.apply,
+		func(change bundlechanges.Change) { started = append(started, change.Id()) },
+		func(change bundlechanges.Change) { done = append(done, change.Id()) },
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.applied.Size(), gc.Equals, len(s.changes))
+	c.Assert(started, gc.HasLen, len(s.changes))
+	c.Assert(done, gc.HasLen, len(s.changes))
+}
+
+func (s *applyChangesSuite) TestConcurrencyLimit(c *gc.C) {
+	r := newRecorder(c)
+	r.pair = make(chan struct{})
+	err := application.ApplyChanges(s.changes, 2, r.apply,
+		func(bundlechanges.Change) {},
+		func(bundlechanges.Change) {},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.maxRunning, gc.Equals, 2)
+}
+
+func (s *applyChangesSuite) TestSequential(c *gc.C) {
+	r := newRecorder(c)
+	var done []string
+	err := application.ApplyChanges(s.changes, 1, r.apply,
+		func(bundlechanges.Change) {},
+		func(change bundlechanges.Change) { done = append(done, change.Id()) },
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.maxRunning, gc.Equals, 1)
+	var expected []string
+	for _, change := range s.changes {
+		expected = append(expected, change.Id())
+	}
+	c.Assert(done, jc.DeepEquals, expected)
+}
+
+func (s *applyChangesSuite) TestFailure(c *gc.C) {
+	var applied []string
+	err := application.ApplyChanges(s.changes, 1,
+		func(change bundlechanges.Change) error {
+			if change.Method() == "deploy" {
+				return errors.New("boom")
+			}
+			applied = append(applied, change.Id())
+			return nil
+		},
+		func(bundlechanges.Change) {},
+		func(bundlechanges.Change) {},
+	)
+	c.Assert(err, gc.ErrorMatches, `deploy application haproxy using cs:haproxy: boom`)
+	// No change is started once one has failed.
+	c.Assert(applied, jc.DeepEquals, []string{"addCharm-0"})
+}
@@ -35,6 +35,9 @@ same way as the deploy command) before comparing with the model.
 The map-machines option works similarly as for the deploy command, but
 existing is always assumed, so it doesn't need to be specified.
 
+Values for the variables declared by local bundles and overlays are given
+with the var and var-file options, as for the deploy command.
+
 Config values for comparison are always source from the "current" model
 generation.
 
@@ -45,6 +48,7 @@ Examples:
     juju diff-bundle mongodb-cluster --channel beta
     juju diff-bundle canonical-kubernetes --overlay local-config.yaml --overlay extra.yaml
     juju diff-bundle localbundle.yaml --map-machines 3=4
+    juju diff-bundle localbundle.yaml --var units=3 --var-file production.yaml
 
 See also:
     deploy
@@ -61,6 +65,7 @@ type bundleDiffCommand struct {
 	modelcmd.ModelCommandBase
 	bundle         string
 	bundleOverlays []string
+	bundleVars     bundleVariables
 	channel        csparams.Channel
 	annotations    bool
 
@@ -96,6 +101,7 @@ func (c *bundleDiffCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.bundleOverlays), "overlay", "Bundles to overlay on the primary bundle, applied in order")
 	f.StringVar(&c.machineMap, "map-machines", "", "Indicates how existing machines correspond to bundle machines")
 	f.BoolVar(&c.annotations, "annotations", false, "Include differences in annotations")
+	c.bundleVars.SetFlags(f)
 }
 
 // Init is part of cmd.Command.
@@ -128,7 +134,7 @@ func (c *bundleDiffCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
-	bundle, err := composeAndVerifyBundle(baseSrc, c.bundleOverlays)
+	bundle, err := composeAndVerifyBundle(baseSrc, c.bundleOverlays, &c.bundleVars)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -204,7 +210,7 @@ func (c *bundleDiffCommand) newAPIRoot() (base.APICallCloser, error) {
 }
 
 func (c *bundleDiffCommand) bundleDataSource(ctx *cmd.Context) (charm.BundleDataSource, error) {
-	ds, err := charm.LocalBundleDataSource(c.bundle)
+	ds, err := c.bundleVars.localDataSource(c.bundle)
 
 	// NotValid/NotFound means we should try interpreting it as a charm store
 	// bundle URL.
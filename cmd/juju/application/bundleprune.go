@@ -0,0 +1,389 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/bundlechanges"
+	"github.com/juju/charm/v7"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/model"
+)
+
+// pruneChange is a change that removes from the model, or resets, something
+// that is not defined in the bundle being applied.
+type pruneChange interface {
+	// Description returns a human readable description of the change.
+	Description() string
+}
+
+// unsetOptionsChange resets application options that are not set in the
+// bundle to their defaults.
+type unsetOptionsChange struct {
+	Application string
+	Options     []string
+}
+
+// Description implements pruneChange.
+func (ch *unsetOptionsChange) Description() string {
+	return fmt.Sprintf("unset application options for %s: %s", ch.Application, strings.Join(ch.Options, ", "))
+}
+
+// resetConstraintsChange clears the constraints of an application with no
+// constraints in the bundle.
+type resetConstraintsChange struct {
+	Application string
+}
+
+// Description implements pruneChange.
+func (ch *resetConstraintsChange) Description() string {
+	return fmt.Sprintf("reset constraints for %s", ch.Application)
+}
+
+// unexposeChange unexposes an application that is not exposed in the
+// bundle.
+type unexposeChange struct {
+	Application string
+}
+
+// Description implements pruneChange.
+func (ch *unexposeChange) Description() string {
+	return fmt.Sprintf("unexpose %s", ch.Application)
+}
+
+// removeRelationChange removes a relation that is not in the bundle.
+type removeRelationChange struct {
+	Endpoints []string
+}
+
+// Description implements pruneChange.
+func (ch *removeRelationChange) Description() string {
+	return fmt.Sprintf("remove relation %s - %s", ch.Endpoints[0], ch.Endpoints[1])
+}
+
+// removeOfferChange removes an offer that is not in the bundle.
+type removeOfferChange struct {
+	Application string
+	Offer       string
+}
+
+// Description implements pruneChange.
+func (ch *removeOfferChange) Description() string {
+	return fmt.Sprintf("remove offer %s of %s", ch.Offer, ch.Application)
+}
+
+// removeUnitChange removes a unit in excess of the number of units of its
+// application in the bundle.
+type removeUnitChange struct {
+	Unit string
+}
+
+// Description implements pruneChange.
+func (ch *removeUnitChange) Description() string {
+	return fmt.Sprintf("remove unit %s", ch.Unit)
+}
+
+// removeApplicationChange removes an application that is not in the bundle.
+type removeApplicationChange struct {
+	Application string
+}
+
+// Description implements pruneChange.
+func (ch *removeApplicationChange) Description() string {
+	return fmt.Sprintf("remove application %s", ch.Application)
+}
+
+// removeMachineChange removes a machine or container that is not in the
+// bundle and will no longer host any units.
+type removeMachineChange struct {
+	Machine string
+}
+
+// Description implements pruneChange.
+func (ch *removeMachineChange) Description() string {
+	return fmt.Sprintf("remove machine %s", ch.Machine)
+}
+
+// pruneChanges returns the changes required to remove from the model
+// everything that is not defined in the bundle. The changes are returned in
+// the order in which they are safe to apply: resets of application settings
+// first, then relations, offers, units, applications and finally the
+// machines left empty by the removals.
+//
+// Machines that host containers are only removed once their containers
+// have gone, so a later prune removes them.
+func pruneChanges(data *charm.BundleData, mod *bundlechanges.Model, status *params.FullStatus) ([]pruneChange, error) {
+	diff, err := bundlechanges.BuildDiff(bundlechanges.DiffConfig{
+		Bundle: data,
+		Model:  mod,
+		Logger: logger,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var (
+		resets       []pruneChange
+		relations    []pruneChange
+		offers       []pruneChange
+		units        []pruneChange
+		applications []pruneChange
+		machines     []pruneChange
+	)
+	removedApps := set.NewStrings()
+	removedUnits := set.NewStrings()
+	for _, name := range sortedAppDiffNames(diff.Applications) {
+		appDiff := diff.Applications[name]
+		switch appDiff.Missing {
+		case bundlechanges.BundleSide:
+			removedApps.Add(name)
+			applications = append(applications, &removeApplicationChange{Application: name})
+			continue
+		case bundlechanges.ModelSide:
+			continue
+		}
+
+		var unset []string
+		for key, option := range appDiff.Options {
+			if option.Bundle == nil {
+				unset = append(unset, key)
+			}
+		}
+		if len(unset) > 0 {
+			sort.Strings(unset)
+			resets = append(resets, &unsetOptionsChange{Application: name, Options: unset})
+		}
+		if appDiff.Constraints != nil && appDiff.Constraints.Bundle == "" {
+			resets = append(resets, &resetConstraintsChange{Application: name})
+		}
+		if appDiff.Expose != nil && !appDiff.Expose.Bundle {
+			resets = append(resets, &unexposeChange{Application: name})
+		}
+		// Kubernetes applications are scaled by the bundle changes.
+		if appDiff.NumUnits != nil && appDiff.NumUnits.Model > appDiff.NumUnits.Bundle {
+			for _, unit := range excessUnits(mod.Applications[name], appDiff.NumUnits.Bundle) {
+				removedUnits.Add(unit)
+				units = append(units, &removeUnitChange{Unit: unit})
+			}
+		}
+	}
+
+	for _, rel := range mod.Relations {
+		if removedApps.Contains(rel.App1) || removedApps.Contains(rel.App2) {
+			// The relation goes with the application.
+			continue
+		}
+		if bundleHasRelation(data, rel) {
+			continue
+		}
+		relations = append(relations, &removeRelationChange{Endpoints: []string{
+			rel.App1 + ":" + rel.Endpoint1,
+			rel.App2 + ":" + rel.Endpoint2,
+		}})
+	}
+	sort.Slice(relations, func(i, j int) bool {
+		return relations[i].Description() < relations[j].Description()
+	})
+
+	for _, offerName := range sortedOfferNames(status.Offers) {
+		offer := status.Offers[offerName]
+		if spec, ok := data.Applications[offer.ApplicationName]; ok && spec.Offers[offerName] != nil {
+			continue
+		}
+		offers = append(offers, &removeOfferChange{Application: offer.ApplicationName, Offer: offerName})
+	}
+
+	// Work out which machines will still host units once the removals have
+	// been made; the others are removed if the bundle doesn't define them.
+	inUse := set.NewStrings()
+	for appName, app := range status.Applications {
+		if removedApps.Contains(appName) {
+			continue
+		}
+		for unitName, unit := range app.Units {
+			if removedUnits.Contains(unitName) || unit.Machine == "" {
+				continue
+			}
+			inUse.Add(unit.Machine)
+			if names.IsContainerMachine(unit.Machine) {
+				inUse.Add(names.NewMachineTag(unit.Machine).Parent().Id())
+			}
+		}
+	}
+	for _, id := range sortedMachineIDs(status.Machines) {
+		machine := status.Machines[id]
+		if isControllerMachine(machine) {
+			continue
+		}
+		// Containers are not defined by the bundle other than as
+		// placements for units, so those left empty are removed.
+		for _, containerID := range sortedMachineIDs(machine.Containers) {
+			if !inUse.Contains(containerID) {
+				machines = append(machines, &removeMachineChange{Machine: containerID})
+			}
+		}
+		if inUse.Contains(id) || len(machine.Containers) > 0 {
+			continue
+		}
+		if machineDiff := diff.Machines[id]; machineDiff != nil && machineDiff.Missing == bundlechanges.BundleSide {
+			machines = append(machines, &removeMachineChange{Machine: id})
+		}
+	}
+
+	var changes []pruneChange
+	for _, group := range [][]pruneChange{resets, relations, offers, units, applications, machines} {
+		changes = append(changes, group...)
+	}
+	return changes, nil
+}
+
+// excessUnits returns the names of the units of the application beyond the
+// given count, newest first.
+func excessUnits(app *bundlechanges.Application, count int) []string {
+	unitNames := make([]string, len(app.Units))
+	for i, unit := range app.Units {
+		unitNames[i] = unit.Name
+	}
+	sort.Slice(unitNames, func(i, j int) bool {
+		// The names come from the model, so they are valid.
+		a, _ := names.UnitNumber(unitNames[i])
+		b, _ := names.UnitNumber(unitNames[j])
+		return a > b
+	})
+	if excess := len(unitNames) - count; excess > 0 {
+		return unitNames[:excess]
+	}
+	return nil
+}
+
+// bundleHasRelation returns whether the bundle defines the given model
+// relation. Bundle relations that leave out an endpoint match any
+// relation between the two applications.
+func bundleHasRelation(data *charm.BundleData, rel bundlechanges.Relation) bool {
+	matches := func(bundleEndpoint, app, endpoint string) bool {
+		parts := strings.SplitN(bundleEndpoint, ":", 2)
+		if parts[0] != app {
+			return false
+		}
+		return len(parts) == 1 || parts[1] == "" || parts[1] == endpoint
+	}
+	for _, pair := range data.Relations {
+		if len(pair) != 2 {
+			continue
+		}
+		if matches(pair[0], rel.App1, rel.Endpoint1) && matches(pair[1], rel.App2, rel.Endpoint2) ||
+			matches(pair[0], rel.App2, rel.Endpoint2) && matches(pair[1], rel.App1, rel.Endpoint1) {
+			return true
+		}
+	}
+	return false
+}
+
+func isControllerMachine(machine params.MachineStatus) bool {
+	for _, job := range machine.Jobs {
+		if job.NeedsState() {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedAppDiffNames(apps map[string]*bundlechanges.ApplicationDiff) []string {
+	result := make([]string, 0, len(apps))
+	for name := range apps {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func sortedOfferNames(offers map[string]params.ApplicationOfferStatus) []string {
+	result := make([]string, 0, len(offers))
+	for name := range offers {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func sortedMachineIDs(machines map[string]params.MachineStatus) []string {
+	result := make([]string, 0, len(machines))
+	for id := range machines {
+		result = append(result, id)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return names.NewMachineTag(result[i]).String() < names.NewMachineTag(result[j]).String()
+	})
+	return result
+}
+
+// handlePruneChanges applies the given prune changes to the model.
+func (h *bundleHandler) handlePruneChanges() error {
+	for _, change := range h.pruneChanges {
+		fmt.Fprintf(h.ctx.Stdout, "- %s\n", change.Description())
+		if h.dryRun {
+			continue
+		}
+		var err error
+		switch change := change.(type) {
+		case *unsetOptionsChange:
+			err = h.api.UnsetApplicationConfig(model.GenerationMaster, change.Application, change.Options)
+		case *resetConstraintsChange:
+			err = h.api.SetConstraints(change.Application, constraints.Value{})
+		case *unexposeChange:
+			err = h.api.Unexpose(change.Application)
+		case *removeRelationChange:
+			err = h.api.DestroyRelation(nil, nil, change.Endpoints...)
+		case *removeOfferChange:
+			err = h.api.DestroyOffers(false, fmt.Sprintf("%s.%s", h.targetModelName, change.Offer))
+		case *removeUnitChange:
+			err = h.removeUnit(change.Unit)
+		case *removeApplicationChange:
+			err = h.removeApplication(change.Application)
+		case *removeMachineChange:
+			err = h.api.DestroyMachinesWithParams(false, false, change.Machine)
+		default:
+			return errors.Errorf("unknown change type: %T", change)
+		}
+		if err != nil {
+			return errors.Annotatef(err, "cannot %s", change.Description())
+		}
+	}
+	return nil
+}
+
+func (h *bundleHandler) removeUnit(unitName string) error {
+	results, err := h.api.DestroyUnits(application.DestroyUnitsParams{
+		Units: []string{unitName},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) > 0 && results[0].Error != nil {
+		return results[0].Error
+	}
+	return nil
+}
+
+func (h *bundleHandler) removeApplication(appName string) error {
+	results, err := h.api.DestroyApplications(application.DestroyApplicationsParams{
+		Applications: []string{appName},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) > 0 && results[0].Error != nil {
+		return results[0].Error
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"strings"
+
+	"github.com/juju/bundlechanges"
+	"github.com/juju/charm/v7"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/core/model"
+)
+
+type pruneSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&pruneSuite{})
+
+const pruneBundle = `
+applications:
+  mysql:
+    charm: cs:mysql-42
+    num_units: 2
+  logging:
+    charm: cs:logging-1
+relations:
+- [mysql:juju-info, logging:info]
+`
+
+func (s *pruneSuite) readBundle(c *gc.C, content string) *charm.BundleData {
+	data, err := charm.ReadBundleData(strings.NewReader(content))
+	c.Assert(err, jc.ErrorIsNil)
+	return data
+}
+
+func (s *pruneSuite) matchingModel() (*bundlechanges.Model, *params.FullStatus) {
+	mod := &bundlechanges.Model{
+		Applications: map[string]*bundlechanges.Application{
+			"mysql": {
+				Name:  "mysql",
+				Charm: "cs:mysql-42",
+				Units: []bundlechanges.Unit{
+					{Name: "mysql/0", Machine: "0"},
+					{Name: "mysql/1", Machine: "1"},
+				},
+			},
+			"logging": {
+				Name:          "logging",
+				Charm:         "cs:logging-1",
+				SubordinateTo: []string{"mysql"},
+			},
+		},
+		Machines: map[string]*bundlechanges.Machine{
+			"0": {ID: "0"},
+			"1": {ID: "1"},
+		},
+		Relations: []bundlechanges.Relation{{
+			App1: "mysql", Endpoint1: "juju-info",
+			App2: "logging", Endpoint2: "info",
+		}},
+		MachineMap: map[string]string{"0": "0", "1": "1"},
+	}
+	status := &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Units: map[string]params.UnitStatus{
+					"mysql/0": {Machine: "0"},
+					"mysql/1": {Machine: "1"},
+				},
+			},
+			"logging": {},
+		},
+		Machines: map[string]params.MachineStatus{
+			"0": {Jobs: []model.MachineJob{model.JobHostUnits}},
+			"1": {Jobs: []model.MachineJob{model.JobHostUnits}},
+		},
+	}
+	return mod, status
+}
+
+func (s *pruneSuite) TestNothingToPrune(c *gc.C) {
+	mod, status := s.matchingModel()
+	changes, err := application.PruneChanges(s.readBundle(c, pruneBundle), mod, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, gc.HasLen, 0)
+}
+
+func (s *pruneSuite) TestPrune(c *gc.C) {
+	mod, status := s.matchingModel()
+
+	mysql := mod.Applications["mysql"]
+	mysql.Options = map[string]interface{}{"tuning": "fast"}
+	mysql.Constraints = "mem=4G"
+	mysql.Exposed = true
+	mysql.Units = append(mysql.Units, bundlechanges.Unit{Name: "mysql/2", Machine: "3"})
+	status.Applications["mysql"].Units["mysql/2"] = params.UnitStatus{Machine: "3"}
+
+	mod.Applications["wordpress"] = &bundlechanges.Application{
+		Name:  "wordpress",
+		Charm: "cs:wordpress-3",
+		Units: []bundlechanges.Unit{{Name: "wordpress/0", Machine: "2"}},
+	}
+	status.Applications["wordpress"] = params.ApplicationStatus{
+		Units: map[string]params.UnitStatus{"wordpress/0": {Machine: "2"}},
+	}
+	mod.Relations = append(mod.Relations, bundlechanges.Relation{
+		App1: "wordpress", Endpoint1: "db",
+		App2: "mysql", Endpoint2: "server",
+	}, bundlechanges.Relation{
+		App1: "mysql", Endpoint1: "server",
+		App2: "mysql-router", Endpoint2: "db",
+	})
+
+	status.Offers = map[string]params.ApplicationOfferStatus{
+		"db": {OfferName: "db", ApplicationName: "mysql"},
+	}
+
+	for _, id := range []string{"2", "3", "4"} {
+		mod.Machines[id] = &bundlechanges.Machine{ID: id}
+		status.Machines[id] = params.MachineStatus{Jobs: []model.MachineJob{model.JobHostUnits}}
+	}
+	status.Machines["0"] = params.MachineStatus{
+		Jobs: []model.MachineJob{model.JobHostUnits},
+		Containers: map[string]params.MachineStatus{
+			"0/lxd/0": {Jobs: []model.MachineJob{model.JobHostUnits}},
+		},
+	}
+	// Controller machines are never removed.
+	mod.Machines["5"] = &bundlechanges.Machine{ID: "5"}
+	status.Machines["5"] = params.MachineStatus{Jobs: []model.MachineJob{model.JobManageModel}}
+
+	changes, err := application.PruneChanges(s.readBundle(c, pruneBundle), mod, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, jc.DeepEquals, []string{
+		"unset application options for mysql: tuning",
+		"reset constraints for mysql",
+		"unexpose mysql",
+		"remove relation mysql:server - mysql-router:db",
+		"remove offer db of mysql",
+		"remove unit mysql/2",
+		"remove application wordpress",
+		"remove machine 0/lxd/0",
+		"remove machine 2",
+		"remove machine 3",
+		"remove machine 4",
+	})
+}
+
+func (s *pruneSuite) TestKeepsMachinesWithContainers(c *gc.C) {
+	mod, status := s.matchingModel()
+	mod.Machines["2"] = &bundlechanges.Machine{ID: "2"}
+	status.Machines["2"] = params.MachineStatus{
+		Jobs: []model.MachineJob{model.JobHostUnits},
+		Containers: map[string]params.MachineStatus{
+			"2/lxd/0": {Jobs: []model.MachineJob{model.JobHostUnits}},
+		},
+	}
+
+	changes, err := application.PruneChanges(s.readBundle(c, pruneBundle), mod, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, jc.DeepEquals, []string{"remove machine 2/lxd/0"})
+}
+
+func (s *pruneSuite) TestRelationWithoutEndpointsInBundle(c *gc.C) {
+	mod, status := s.matchingModel()
+	bundle := strings.Replace(pruneBundle, "[mysql:juju-info, logging:info]", "[mysql, logging]", 1)
+
+	changes, err := application.PruneChanges(s.readBundle(c, bundle), mod, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, gc.HasLen, 0)
+}
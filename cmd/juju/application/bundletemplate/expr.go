@@ -0,0 +1,594 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundletemplate
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/juju/errors"
+)
+
+// Expressions evaluate to one of the following types: int64, float64,
+// string or bool.
+
+// Evaluate evaluates an expression, such as "units * 2" or
+// "ha ? 3 : 1", using the given variable values.
+//
+// Expressions may use integer, float, quoted string and boolean
+// literals, variable names, the arithmetic operators + - * / %, the
+// comparison operators == != < <= > >=, the logical operators && || !,
+// the conditional operator cond ? a : b, parentheses, and the functions
+// min and max. Adding a string to any value concatenates them.
+func Evaluate(expr string, vars map[string]interface{}) (interface{}, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, errors.Annotatef(err, "expression %q", expr)
+	}
+	p := &parser{tokens: tokens}
+	node, err := p.parseExpr()
+	if err == nil && p.peek().kind != tokEOF {
+		err = errors.Errorf("unexpected %s", p.peek())
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "expression %q", expr)
+	}
+	value, err := node.eval(vars)
+	if err != nil {
+		return nil, errors.Annotatef(err, "expression %q", expr)
+	}
+	return value, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokString
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value interface{}
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+var operators = []string{
+	"&&", "||", "==", "!=", "<=", ">=",
+	"+", "-", "*", "/", "%", "<", ">", "!", "?", ":", "(", ")", ",",
+}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := rune(expr[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case unicode.IsDigit(c) || c == '.' && i+1 < len(expr) && unicode.IsDigit(rune(expr[i+1])):
+			start := i
+			for i < len(expr) && (unicode.IsDigit(rune(expr[i])) || expr[i] == '.') {
+				i++
+			}
+			text := expr[start:i]
+			if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+				tokens = append(tokens, token{kind: tokNumber, text: text, value: n})
+			} else if f, err := strconv.ParseFloat(text, 64); err == nil {
+				tokens = append(tokens, token{kind: tokNumber, text: text, value: f})
+			} else {
+				return nil, errors.Errorf("invalid number %q", text)
+			}
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], expr[i])
+			if end < 0 {
+				return nil, errors.Errorf("unterminated string")
+			}
+			text := expr[i : i+end+2]
+			tokens = append(tokens, token{kind: tokString, text: text, value: text[1 : len(text)-1]})
+			i += end + 2
+		case isIdentStart(c):
+			start := i
+			for i < len(expr) && isIdentChar(rune(expr[i])) {
+				i++
+			}
+			text := expr[start:i]
+			tokens = append(tokens, token{kind: tokIdent, text: text})
+		default:
+			op := ""
+			for _, candidate := range operators {
+				if strings.HasPrefix(expr[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, errors.Errorf("unexpected character %q", c)
+			}
+			tokens = append(tokens, token{kind: tokOp, text: op})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokEOF}), nil
+}
+
+func isIdentStart(c rune) bool {
+	return c == '_' || c < unicode.MaxASCII && unicode.IsLetter(c)
+}
+
+func isIdentChar(c rune) bool {
+	return isIdentStart(c) || c < unicode.MaxASCII && unicode.IsDigit(c)
+}
+
+// isValidName returns whether name may be used as a variable name in
+// expressions.
+func isValidName(name string) bool {
+	if name == "" || name == "true" || name == "false" {
+		return false
+	}
+	for i, c := range name {
+		if !isIdentChar(c) || i == 0 && !isIdentStart(c) {
+			return false
+		}
+	}
+	return true
+}
+
+type node interface {
+	eval(vars map[string]interface{}) (interface{}, error)
+}
+
+type literal struct {
+	value interface{}
+}
+
+func (n literal) eval(map[string]interface{}) (interface{}, error) {
+	return n.value, nil
+}
+
+type variable struct {
+	name string
+}
+
+func (n variable) eval(vars map[string]interface{}) (interface{}, error) {
+	value, ok := vars[n.name]
+	if !ok {
+		return nil, errors.NotFoundf("variable %q", n.name)
+	}
+	return value, nil
+}
+
+type unary struct {
+	op      string
+	operand node
+}
+
+func (n unary) eval(vars map[string]interface{}) (interface{}, error) {
+	value, err := n.operand.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	switch v := value.(type) {
+	case bool:
+		if n.op == "!" {
+			return !v, nil
+		}
+	case int64:
+		if n.op == "-" {
+			return -v, nil
+		}
+	case float64:
+		if n.op == "-" {
+			return -v, nil
+		}
+	}
+	return nil, errors.Errorf("cannot apply %q to %s", n.op, describe(value))
+}
+
+type binary struct {
+	op          string
+	left, right node
+}
+
+func (n binary) eval(vars map[string]interface{}) (interface{}, error) {
+	left, err := n.left.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	// The logical operators short-circuit.
+	if n.op == "&&" || n.op == "||" {
+		l, ok := left.(bool)
+		if !ok {
+			return nil, errors.Errorf("cannot apply %q to %s", n.op, describe(left))
+		}
+		if l == (n.op == "||") {
+			return l, nil
+		}
+		right, err := n.right.eval(vars)
+		if err != nil {
+			return nil, err
+		}
+		if r, ok := right.(bool); ok {
+			return r, nil
+		}
+		return nil, errors.Errorf("cannot apply %q to %s", n.op, describe(right))
+	}
+	right, err := n.right.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	return applyBinary(n.op, left, right)
+}
+
+func applyBinary(op string, left, right interface{}) (interface{}, error) {
+	if op == "+" {
+		if l, ok := left.(string); ok {
+			return l + Format(right), nil
+		}
+		if r, ok := right.(string); ok {
+			return Format(left) + r, nil
+		}
+	}
+	switch op {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	}
+	if l, ok := left.(string); ok {
+		if r, ok := right.(string); ok {
+			switch op {
+			case "<":
+				return l < r, nil
+			case "<=":
+				return l <= r, nil
+			case ">":
+				return l > r, nil
+			case ">=":
+				return l >= r, nil
+			}
+		}
+	}
+	if l, ok := left.(int64); ok {
+		if r, ok := right.(int64); ok {
+			return applyInt(op, l, r)
+		}
+	}
+	l, lok := toFloat(left)
+	r, rok := toFloat(right)
+	if !lok || !rok {
+		return nil, errors.Errorf("cannot apply %q to %s and %s", op, describe(left), describe(right))
+	}
+	return applyFloat(op, l, r)
+}
+
+func applyInt(op string, l, r int64) (interface{}, error) {
+	switch op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/", "%":
+		if r == 0 {
+			return nil, errors.New("division by zero")
+		}
+		if op == "/" {
+			return l / r, nil
+		}
+		return l % r, nil
+	case "<":
+		return l < r, nil
+	case "<=":
+		return l <= r, nil
+	case ">":
+		return l > r, nil
+	case ">=":
+		return l >= r, nil
+	}
+	return nil, errors.Errorf("cannot apply %q to numbers", op)
+}
+
+func applyFloat(op string, l, r float64) (interface{}, error) {
+	switch op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return nil, errors.New("division by zero")
+		}
+		return l / r, nil
+	case "%":
+		if r == 0 {
+			return nil, errors.New("division by zero")
+		}
+		return math.Mod(l, r), nil
+	case "<":
+		return l < r, nil
+	case "<=":
+		return l <= r, nil
+	case ">":
+		return l > r, nil
+	case ">=":
+		return l >= r, nil
+	}
+	return nil, errors.Errorf("cannot apply %q to numbers", op)
+}
+
+func equal(left, right interface{}) bool {
+	l, lok := toFloat(left)
+	r, rok := toFloat(right)
+	if lok && rok {
+		return l == r
+	}
+	return left == right
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+type conditional struct {
+	cond, then, otherwise node
+}
+
+func (n conditional) eval(vars map[string]interface{}) (interface{}, error) {
+	value, err := n.cond.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	cond, ok := value.(bool)
+	if !ok {
+		return nil, errors.Errorf("condition is %s, not a bool", describe(value))
+	}
+	if cond {
+		return n.then.eval(vars)
+	}
+	return n.otherwise.eval(vars)
+}
+
+type call struct {
+	name string
+	args []node
+}
+
+func (n call) eval(vars map[string]interface{}) (interface{}, error) {
+	if len(n.args) == 0 {
+		return nil, errors.Errorf("%s requires at least one argument", n.name)
+	}
+	var result interface{}
+	for i, arg := range n.args {
+		value, err := arg.eval(vars)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := toFloat(value); !ok {
+			return nil, errors.Errorf("%s: argument %d is %s, not a number", n.name, i+1, describe(value))
+		}
+		if i == 0 {
+			result = value
+			continue
+		}
+		op := "<"
+		if n.name == "max" {
+			op = ">"
+		}
+		better, err := applyBinary(op, value, result)
+		if err != nil {
+			return nil, err
+		}
+		if better.(bool) {
+			result = value
+		}
+	}
+	return result, nil
+}
+
+var functions = map[string]bool{
+	"min": true,
+	"max": true,
+}
+
+// parser is a recursive descent parser for expressions. In order of
+// increasing precedence, the operators are: ?:, ||, &&, comparisons,
+// + and -, * / and %, and the unary operators.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) acceptOp(ops ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != tokOp {
+		return "", false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) expectOp(op string) error {
+	if _, ok := p.acceptOp(op); !ok {
+		return errors.Errorf("expected %q, got %s", op, p.peek())
+	}
+	return nil
+}
+
+func (p *parser) parseExpr() (node, error) {
+	cond, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.acceptOp("?"); !ok {
+		return cond, nil
+	}
+	then, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectOp(":"); err != nil {
+		return nil, err
+	}
+	otherwise, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	return conditional{cond: cond, then: then, otherwise: otherwise}, nil
+}
+
+var binaryLevels = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!=", "<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *parser) parseBinary(level int) (node, error) {
+	if level == len(binaryLevels) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptOp(binaryLevels[level]...)
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = binary{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if op, ok := p.acceptOp("!", "-"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unary{op: op, operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber, tokString:
+		return literal{value: t.value}, nil
+	case tokIdent:
+		switch t.text {
+		case "true":
+			return literal{value: true}, nil
+		case "false":
+			return literal{value: false}, nil
+		}
+		if _, ok := p.acceptOp("("); !ok {
+			return variable{name: t.text}, nil
+		}
+		if !functions[t.text] {
+			return nil, errors.Errorf("unknown function %q", t.text)
+		}
+		var args []node
+		if _, ok := p.acceptOp(")"); ok {
+			return call{name: t.text}, nil
+		}
+		for {
+			arg, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if _, ok := p.acceptOp(","); !ok {
+				break
+			}
+		}
+		if err := p.expectOp(")"); err != nil {
+			return nil, err
+		}
+		return call{name: t.text, args: args}, nil
+	case tokOp:
+		if t.text == "(" {
+			n, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		}
+	}
+	return nil, errors.Errorf("unexpected %s", t)
+}
+
+// Format returns the string form of a value, as used when it is
+// substituted into a larger string.
+func Format(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+func describe(value interface{}) string {
+	switch value.(type) {
+	case int64:
+		return "an int"
+	case float64:
+		return "a float"
+	case string:
+		return "a string"
+	case bool:
+		return "a bool"
+	}
+	return fmt.Sprintf("a %T", value)
+}
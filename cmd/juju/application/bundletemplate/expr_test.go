@@ -0,0 +1,93 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundletemplate_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application/bundletemplate"
+)
+
+type exprSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&exprSuite{})
+
+var exprVars = map[string]interface{}{
+	"units":  int64(3),
+	"ratio":  1.5,
+	"ha":     true,
+	"name":   "db",
+	"prefix": "prod",
+}
+
+func (s *exprSuite) TestEvaluate(c *gc.C) {
+	for i, test := range []struct {
+		expr   string
+		result interface{}
+	}{
+		{"units", int64(3)},
+		{"units * 2 + 1", int64(7)},
+		{"(units + 1) * 2", int64(8)},
+		{"units / 2", int64(1)},
+		{"units % 2", int64(1)},
+		{"-units", int64(-3)},
+		{"units * ratio", 4.5},
+		{"units / 2.0", 1.5},
+		{"ha ? units : 1", int64(3)},
+		{"!ha ? units : 1", int64(1)},
+		{"units > 2 && ha", true},
+		{"units == 3.0", true},
+		{"name != 'db'", false},
+		{"prefix + '-' + name", "prod-db"},
+		{`"units: " + units`, "units: 3"},
+		{"max(units, 5)", int64(5)},
+		{"min(units, 5, ratio)", 1.5},
+		{"false || units < 3", false},
+		{"ha ? (units > 2 ? 'big' : 'small') : 'none'", "big"},
+	} {
+		c.Logf("test %d: %s", i, test.expr)
+		result, err := bundletemplate.Evaluate(test.expr, exprVars)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(result, gc.Equals, test.result)
+	}
+}
+
+func (s *exprSuite) TestEvaluateErrors(c *gc.C) {
+	for i, test := range []struct {
+		expr string
+		err  string
+	}{
+		{"", `expression "": unexpected end of expression`},
+		{"units +", `expression "units \+": unexpected end of expression`},
+		{"(units", `expression "\(units": expected "\)", got end of expression`},
+		{"units units", `expression "units units": unexpected "units"`},
+		{"missing", `expression "missing": variable "missing" not found`},
+		{"units / 0", `expression "units / 0": division by zero`},
+		{"ha * 2", `expression "ha \* 2": cannot apply "\*" to a bool and an int`},
+		{"units ? 1 : 2", `expression "units \? 1 : 2": condition is an int, not a bool`},
+		{"'open", `expression "'open": unterminated string`},
+		{"units # 2", `expression "units # 2": unexpected character '#'`},
+		{"sum(units)", `expression "sum\(units\)": unknown function "sum"`},
+		{"max()", `expression "max\(\)": max requires at least one argument`},
+		{"max(name)", `expression "max\(name\)": max: argument 1 is a string, not a number`},
+	} {
+		c.Logf("test %d: %s", i, test.expr)
+		_, err := bundletemplate.Evaluate(test.expr, exprVars)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *exprSuite) TestShortCircuit(c *gc.C) {
+	result, err := bundletemplate.Evaluate("!ha && missing", exprVars)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.Equals, false)
+
+	result, err = bundletemplate.Evaluate("ha ? 1 : units / 0", exprVars)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.Equals, int64(1))
+}
@@ -0,0 +1,404 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package bundletemplate renders bundles that are parameterised with
+// variables, so that one bundle can be deployed to several environments.
+//
+// A bundle declares its variables in a top-level variables section:
+//
+//     variables:
+//       units:
+//         type: int
+//         default: 3
+//         description: Number of database units.
+//       flavour: small
+//
+// Only bundles with a variables section are templates; in other bundles
+// "${" has no special meaning and is left as it is.
+//
+// A variable is either a map with an optional type (string, int, float or
+// bool, defaulting to the type of the default value, or string), default
+// and description, or simply a default value. Variables without a default
+// must be given a value when the bundle is rendered.
+//
+// Values anywhere in the bundle may then refer to variables with
+// ${<expression>}; see Evaluate for the expressions supported. A value
+// made up of a single reference takes the type of the expression, so
+// "num_units: ${units * 2}" is an integer, while references within a
+// longer value are substituted as strings. "$${" is rendered as a
+// literal "${". As with any YAML value, an expression containing ": ",
+// such as "${ha ? 3 : 1}", must be quoted.
+package bundletemplate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// VariablesKey is the top-level bundle key under which variables are
+// declared.
+const VariablesKey = "variables"
+
+// VariableType is the type of a bundle variable.
+type VariableType string
+
+const (
+	TypeString VariableType = "string"
+	TypeInt    VariableType = "int"
+	TypeFloat  VariableType = "float"
+	TypeBool   VariableType = "bool"
+)
+
+// Variable describes a variable declared by a bundle.
+type Variable struct {
+	Name        string
+	Type        VariableType
+	Description string
+
+	// Default holds the default value of the variable, or nil if a value
+	// must be given.
+	Default interface{}
+}
+
+// Template is a parsed, possibly multi-document, bundle that may declare
+// and refer to variables.
+type Template struct {
+	docs      []interface{}
+	variables map[string]Variable
+}
+
+// IsTemplate returns whether the bundle data declares variables in a
+// top-level variables section, which marks it as a template.
+func IsTemplate(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(VariablesKey+":")) ||
+		bytes.Contains(data, []byte("\n"+VariablesKey+":"))
+}
+
+// Parse parses the bundle data, which may hold several YAML documents.
+// The variables declared by all of the documents are combined.
+func Parse(data []byte) (*Template, error) {
+	t := &Template{variables: make(map[string]Variable)}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for docIdx := 0; ; docIdx++ {
+		var doc interface{}
+		err := dec.Decode(&doc)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "unmarshal document %d", docIdx)
+		}
+		if doc == nil {
+			continue
+		}
+		m, ok := doc.(map[interface{}]interface{})
+		if !ok {
+			t.docs = append(t.docs, doc)
+			continue
+		}
+		if decls, ok := m[VariablesKey]; ok {
+			delete(m, VariablesKey)
+			if err := t.addVariables(decls); err != nil {
+				return nil, errors.Annotatef(err, "document %d", docIdx)
+			}
+			if len(m) == 0 {
+				// The document only declares variables.
+				continue
+			}
+		}
+		t.docs = append(t.docs, m)
+	}
+	return t, nil
+}
+
+func (t *Template) addVariables(decls interface{}) error {
+	if decls == nil {
+		return nil
+	}
+	m, ok := decls.(map[interface{}]interface{})
+	if !ok {
+		return errors.NotValidf("%s: expected map, got %T", VariablesKey, decls)
+	}
+	for key, decl := range m {
+		name := fmt.Sprint(key)
+		v, err := parseVariable(name, decl)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if existing, ok := t.variables[name]; ok && existing.Type != v.Type {
+			return errors.NotValidf("variable %q declared as both %s and %s", name, existing.Type, v.Type)
+		}
+		t.variables[name] = v
+	}
+	return nil
+}
+
+func parseVariable(name string, decl interface{}) (Variable, error) {
+	if !isValidName(name) {
+		return Variable{}, errors.NotValidf("variable name %q", name)
+	}
+	v := Variable{Name: name}
+	attrs, ok := decl.(map[interface{}]interface{})
+	if !ok {
+		// The shorthand form, which only gives the default.
+		attrs = map[interface{}]interface{}{"default": decl}
+	}
+	for key, value := range attrs {
+		switch key {
+		case "type":
+			s, _ := value.(string)
+			v.Type = VariableType(s)
+		case "description":
+			v.Description = fmt.Sprint(value)
+		case "default":
+			v.Default = value
+		default:
+			return Variable{}, errors.NotValidf("variable %q: unknown attribute %q", name, key)
+		}
+	}
+	if v.Type == "" {
+		v.Type = typeOf(v.Default)
+	}
+	switch v.Type {
+	case TypeString, TypeInt, TypeFloat, TypeBool:
+	default:
+		return Variable{}, errors.NotValidf("variable %q: type %q", name, v.Type)
+	}
+	if v.Default != nil {
+		value, err := v.convert(v.Default)
+		if err != nil {
+			return Variable{}, errors.Annotate(err, "default")
+		}
+		v.Default = value
+	}
+	return v, nil
+}
+
+func typeOf(value interface{}) VariableType {
+	switch value.(type) {
+	case int, int64:
+		return TypeInt
+	case float64:
+		return TypeFloat
+	case bool:
+		return TypeBool
+	}
+	return TypeString
+}
+
+// convert converts the given value to the type of the variable. Strings,
+// as given on the command line, are parsed.
+func (v Variable) convert(value interface{}) (interface{}, error) {
+	if s, ok := value.(string); ok && v.Type != TypeString {
+		var (
+			parsed interface{}
+			err    error
+		)
+		switch v.Type {
+		case TypeInt:
+			parsed, err = strconv.ParseInt(s, 10, 64)
+		case TypeFloat:
+			parsed, err = strconv.ParseFloat(s, 64)
+		case TypeBool:
+			parsed, err = strconv.ParseBool(s)
+		}
+		if err != nil {
+			return nil, errors.NotValidf("value %q for %s variable %q", s, v.Type, v.Name)
+		}
+		return parsed, nil
+	}
+	switch v.Type {
+	case TypeString:
+		switch value.(type) {
+		case string, int, int64, float64, bool:
+			return Format(normalise(value)), nil
+		}
+	case TypeInt:
+		switch n := value.(type) {
+		case int:
+			return int64(n), nil
+		case int64:
+			return n, nil
+		}
+	case TypeFloat:
+		switch n := value.(type) {
+		case int:
+			return float64(n), nil
+		case int64:
+			return float64(n), nil
+		case float64:
+			return n, nil
+		}
+	case TypeBool:
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+	}
+	return nil, errors.NotValidf("value %v for %s variable %q", value, v.Type, v.Name)
+}
+
+func normalise(value interface{}) interface{} {
+	if n, ok := value.(int); ok {
+		return int64(n)
+	}
+	return value
+}
+
+// Variables returns the variables declared by the bundle.
+func (t *Template) Variables() map[string]Variable {
+	result := make(map[string]Variable, len(t.variables))
+	for name, v := range t.variables {
+		result[name] = v
+	}
+	return result
+}
+
+// Render returns the bundle data with all variable references resolved
+// using the given values, and the variable declarations removed. Values
+// given for variables that the bundle does not declare are ignored.
+func (t *Template) Render(values map[string]interface{}) ([]byte, error) {
+	resolved := make(map[string]interface{}, len(t.variables))
+	var missing []string
+	for name, v := range t.variables {
+		value, ok := values[name]
+		if !ok {
+			if v.Default == nil {
+				missing = append(missing, name)
+				continue
+			}
+			value = v.Default
+		}
+		converted, err := v.convert(value)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		resolved[name] = converted
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, errors.Errorf("no value given for bundle variable(s): %s", strings.Join(missing, ", "))
+	}
+
+	var buf bytes.Buffer
+	for i, doc := range t.docs {
+		rendered, err := render(doc, resolved)
+		if err != nil {
+			return nil, errors.Annotatef(err, "document %d", i)
+		}
+		data, err := yaml.Marshal(rendered)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// render returns the value with the variable references in any strings it
+// holds resolved.
+func render(value interface{}, vars map[string]interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return renderString(v, vars)
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			rendered, err := render(item, vars)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			result[i] = rendered
+		}
+		return result, nil
+	case map[interface{}]interface{}:
+		result := make(map[interface{}]interface{}, len(v))
+		for key, item := range v {
+			rendered, err := render(item, vars)
+			if err != nil {
+				return nil, errors.Annotatef(err, "%v", key)
+			}
+			result[key] = rendered
+		}
+		return result, nil
+	}
+	return value, nil
+}
+
+func renderString(s string, vars map[string]interface{}) (interface{}, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+	var (
+		buf   strings.Builder
+		value interface{}
+		parts int
+	)
+	for rest := s; rest != ""; {
+		start := strings.Index(rest, "${")
+		if start < 0 {
+			buf.WriteString(rest)
+			parts++
+			break
+		}
+		if start > 0 && rest[start-1] == '$' {
+			// An escaped reference.
+			buf.WriteString(rest[:start-1] + "${")
+			rest = rest[start+2:]
+			parts++
+			continue
+		}
+		if start > 0 {
+			buf.WriteString(rest[:start])
+			parts++
+		}
+		end := closingBrace(rest[start+2:])
+		if end < 0 {
+			return nil, errors.Errorf("unterminated reference in %q", s)
+		}
+		expr := rest[start+2 : start+2+end]
+		var err error
+		if value, err = Evaluate(expr, vars); err != nil {
+			return nil, errors.Trace(err)
+		}
+		buf.WriteString(Format(value))
+		parts++
+		rest = rest[start+2+end+1:]
+	}
+	if parts == 1 && value != nil {
+		// The string is a single reference, so keeps the type of its
+		// value.
+		if n, ok := value.(int64); ok {
+			return int(n), nil
+		}
+		return value, nil
+	}
+	return buf.String(), nil
+}
+
+// closingBrace returns the index of the brace closing an expression,
+// skipping over quoted strings, or -1 if there is none.
+func closingBrace(s string) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '}':
+			return i
+		}
+	}
+	return -1
+}
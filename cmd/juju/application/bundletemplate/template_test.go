@@ -0,0 +1,190 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundletemplate_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/cmd/juju/application/bundletemplate"
+)
+
+type templateSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&templateSuite{})
+
+const templateBundle = `
+variables:
+  units:
+    type: int
+    default: 3
+    description: Number of database units.
+  flavour: small
+  ha:
+    type: bool
+  cache_size:
+    type: float
+    default: 0.5
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: "${ha ? units : 1}"
+    options:
+      flavour: ${flavour}
+      max-connections: ${units * 100}
+      cache: ${cache_size}
+      dataset: mysql-${flavour}-$${literal}
+`
+
+func (s *templateSuite) render(c *gc.C, data string, values map[string]interface{}) map[string]interface{} {
+	t, err := bundletemplate.Parse([]byte(data))
+	c.Assert(err, jc.ErrorIsNil)
+	rendered, err := t.Render(values)
+	c.Assert(err, jc.ErrorIsNil)
+	var result map[string]interface{}
+	err = yaml.Unmarshal(rendered, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	return result
+}
+
+func (s *templateSuite) TestIsTemplate(c *gc.C) {
+	c.Check(bundletemplate.IsTemplate([]byte(templateBundle)), jc.IsTrue)
+	c.Check(bundletemplate.IsTemplate([]byte("variables:\n  a: 1\n")), jc.IsTrue)
+	c.Check(bundletemplate.IsTemplate([]byte("applications:\n  a:\n    charm: cs:a\n")), jc.IsFalse)
+	c.Check(bundletemplate.IsTemplate([]byte("applications:\n  a:\n    options:\n      script: echo ${HOME}\n")), jc.IsFalse)
+}
+
+func (s *templateSuite) TestVariables(c *gc.C) {
+	t, err := bundletemplate.Parse([]byte(`
+variables:
+  units:
+    type: int
+    default: 3
+    description: Number of units.
+  flavour: small
+  ha:
+    type: bool
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t.Variables(), jc.DeepEquals, map[string]bundletemplate.Variable{
+		"units": {
+			Name:        "units",
+			Type:        bundletemplate.TypeInt,
+			Description: "Number of units.",
+			Default:     int64(3),
+		},
+		"flavour": {
+			Name:    "flavour",
+			Type:    bundletemplate.TypeString,
+			Default: "small",
+		},
+		"ha": {
+			Name: "ha",
+			Type: bundletemplate.TypeBool,
+		},
+	})
+}
+
+func (s *templateSuite) TestInvalidVariableName(c *gc.C) {
+	_, err := bundletemplate.Parse([]byte("variables:\n  cache-size: 1\n"))
+	c.Assert(err, gc.ErrorMatches, `document 0: variable name "cache-size" not valid`)
+}
+
+func (s *templateSuite) TestRender(c *gc.C) {
+	result := s.render(c, templateBundle, map[string]interface{}{
+		"ha":    "true",
+		"units": int64(5),
+	})
+	c.Assert(result, jc.DeepEquals, map[string]interface{}{
+		"applications": map[interface{}]interface{}{
+			"mysql": map[interface{}]interface{}{
+				"charm":     "cs:mysql",
+				"num_units": 5,
+				"options": map[interface{}]interface{}{
+					"flavour":         "small",
+					"max-connections": 500,
+					"cache":           0.5,
+					"dataset":         "mysql-small-${literal}",
+				},
+			},
+		},
+	})
+}
+
+func (s *templateSuite) TestRenderDefaults(c *gc.C) {
+	result := s.render(c, templateBundle, map[string]interface{}{"ha": false})
+	mysql := result["applications"].(map[interface{}]interface{})["mysql"].(map[interface{}]interface{})
+	c.Assert(mysql["num_units"], gc.Equals, 1)
+}
+
+func (s *templateSuite) TestRenderMissingValue(c *gc.C) {
+	t, err := bundletemplate.Parse([]byte(templateBundle))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = t.Render(nil)
+	c.Assert(err, gc.ErrorMatches, `no value given for bundle variable\(s\): ha`)
+}
+
+func (s *templateSuite) TestRenderBadValue(c *gc.C) {
+	t, err := bundletemplate.Parse([]byte(templateBundle))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = t.Render(map[string]interface{}{"ha": true, "units": "lots"})
+	c.Assert(err, gc.ErrorMatches, `value "lots" for int variable "units" not valid`)
+}
+
+func (s *templateSuite) TestRenderBadExpression(c *gc.C) {
+	t, err := bundletemplate.Parse([]byte(`
+applications:
+  mysql:
+    num_units: ${units * }
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = t.Render(nil)
+	c.Assert(err, gc.ErrorMatches, `document 0: applications: mysql: num_units: expression "units \* ": unexpected end of expression`)
+}
+
+func (s *templateSuite) TestMultipleDocuments(c *gc.C) {
+	t, err := bundletemplate.Parse([]byte(`
+variables:
+  units: 2
+---
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: ${units}
+---
+applications:
+  mysql:
+    options:
+      name: db-${units}
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	rendered, err := t.Render(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(rendered), gc.Equals, `
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: 2
+---
+applications:
+  mysql:
+    options:
+      name: db-2
+`[1:])
+}
+
+func (s *templateSuite) TestConflictingDeclarations(c *gc.C) {
+	_, err := bundletemplate.Parse([]byte(`
+variables:
+  units: 2
+---
+variables:
+  units: two
+`))
+	c.Assert(err, gc.ErrorMatches, `document 1: variable "units" declared as both int and string not valid`)
+}
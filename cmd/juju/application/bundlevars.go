@@ -0,0 +1,135 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/cmd/juju/application/bundletemplate"
+)
+
+// bundleVariables holds the values given for the variables declared by
+// bundles and overlays, and renders the local bundles that use them.
+type bundleVariables struct {
+	// files holds the paths of YAML files mapping variable names to
+	// values. Values in later files take precedence.
+	files []string
+
+	// flags holds the values given as name=value pairs, which take
+	// precedence over those in files.
+	flags map[string]string
+
+	values   map[string]interface{}
+	declared set.Strings
+}
+
+// SetFlags adds the --var and --var-file flags to the flag set.
+func (v *bundleVariables) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(stringMap{&v.flags}, "var", "Value for a bundle variable, as name=value (may be repeated)")
+	f.Var(cmd.NewAppendStringsValue(&v.files), "var-file", "YAML file of values for bundle variables (may be repeated)")
+}
+
+// load reads the variable values, once.
+func (v *bundleVariables) load() (map[string]interface{}, error) {
+	if v.values != nil {
+		return v.values, nil
+	}
+	values := make(map[string]interface{})
+	for _, path := range v.files {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot read bundle variables")
+		}
+		var fileValues map[string]interface{}
+		if err := yaml.Unmarshal(data, &fileValues); err != nil {
+			return nil, errors.Annotatef(err, "cannot parse bundle variables in %q", path)
+		}
+		for name, value := range fileValues {
+			values[name] = value
+		}
+	}
+	for name, value := range v.flags {
+		values[name] = value
+	}
+	v.values = values
+	return values, nil
+}
+
+// localDataSource returns a data source for the local bundle or overlay at
+// the given path, as charm.LocalBundleDataSource does, having first
+// rendered the variables that the bundle declares and uses. Bundles that
+// do not declare variables are read as they are.
+func (v *bundleVariables) localDataSource(path string) (charm.BundleDataSource, error) {
+	bundleFile := path
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		bundleFile = filepath.Join(path, "bundle.yaml")
+	}
+	data, err := ioutil.ReadFile(bundleFile)
+	if err != nil || isZip(data) || !bundletemplate.IsTemplate(data) {
+		// Leave it to the charm package to report any error, and to
+		// read bundle archives.
+		return charm.LocalBundleDataSource(path)
+	}
+	t, err := bundletemplate.Parse(data)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot parse bundle %q", path)
+	}
+	if v.declared == nil {
+		v.declared = set.NewStrings()
+	}
+	for name := range t.Variables() {
+		v.declared.Add(name)
+	}
+	values, err := v.load()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rendered, err := t.Render(values)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot render bundle %q", path)
+	}
+	absPath, err := filepath.Abs(bundleFile)
+	if err != nil {
+		return nil, errors.Annotatef(err, "resolve absolute path to %s", bundleFile)
+	}
+	return charm.StreamBundleDataSource(bytes.NewReader(rendered), filepath.Dir(absPath))
+}
+
+// isZip returns whether the data is a zip archive, such as a bundle
+// archive.
+func isZip(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("PK\x03\x04"))
+}
+
+// checkDeclared returns an error if values were given for variables that
+// none of the bundles read declares, which is most likely a mistake.
+func (v *bundleVariables) checkDeclared() error {
+	values, err := v.load()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var unknown []string
+	for name := range values {
+		if !v.declared.Contains(name) {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return errors.Errorf("bundle variable(s) not declared by the bundle: %s", strings.Join(unknown, ", "))
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application"
+)
+
+type bundleVarsSuite struct {
+	testing.IsolationSuite
+
+	dir string
+}
+
+var _ = gc.Suite(&bundleVarsSuite{})
+
+const variablesBundle = `
+variables:
+  units:
+    type: int
+    default: 1
+  flavour:
+    type: string
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: ${units}
+    options:
+      flavour: ${flavour}
+`
+
+func (s *bundleVarsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+}
+
+func (s *bundleVarsSuite) writeFile(c *gc.C, name, content string) string {
+	path := filepath.Join(s.dir, name)
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *bundleVarsSuite) TestVarsAndVarFiles(c *gc.C) {
+	bundlePath := s.writeFile(c, "bundle.yaml", variablesBundle)
+	varFile := s.writeFile(c, "vars.yaml", "units: 2\nflavour: large\n")
+
+	data, err := application.ReadBundleWithVariables(bundlePath, map[string]string{"units": "3"}, []string{varFile})
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := data.Applications["mysql"]
+	c.Assert(mysql.NumUnits, gc.Equals, 3)
+	c.Assert(mysql.Options, jc.DeepEquals, map[string]interface{}{"flavour": "large"})
+}
+
+func (s *bundleVarsSuite) TestUndeclaredVariable(c *gc.C) {
+	bundlePath := s.writeFile(c, "bundle.yaml", variablesBundle)
+
+	_, err := application.ReadBundleWithVariables(bundlePath, map[string]string{"flavour": "small", "unit": "3"}, nil)
+	c.Assert(err, gc.ErrorMatches, `bundle variable\(s\) not declared by the bundle: unit`)
+}
+
+func (s *bundleVarsSuite) TestMissingValue(c *gc.C) {
+	bundlePath := s.writeFile(c, "bundle.yaml", variablesBundle)
+
+	_, err := application.ReadBundleWithVariables(bundlePath, nil, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot render bundle ".*": no value given for bundle variable\(s\): flavour`)
+}
+
+func (s *bundleVarsSuite) TestPlainBundle(c *gc.C) {
+	bundlePath := s.writeFile(c, "bundle.yaml", "applications:\n  mysql:\n    charm: cs:mysql\n    num_units: 1\n")
+
+	data, err := application.ReadBundleWithVariables(bundlePath, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Applications["mysql"].NumUnits, gc.Equals, 1)
+}
+
+func (s *bundleVarsSuite) TestPlainBundleKeepsReferences(c *gc.C) {
+	bundlePath := s.writeFile(c, "bundle.yaml", "applications:\n  mysql:\n    charm: cs:mysql\n    options:\n      script: echo ${HOME}\n")
+
+	data, err := application.ReadBundleWithVariables(bundlePath, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Applications["mysql"].Options, jc.DeepEquals, map[string]interface{}{"script": "echo ${HOME}"})
+}
+
+func (s *bundleVarsSuite) TestParseError(c *gc.C) {
+	bundlePath := s.writeFile(c, "bundle.yaml", "variables:\n  units:\n    type: complex\napplications: {}\n")
+
+	_, err := application.ReadBundleWithVariables(bundlePath, nil, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot parse bundle ".*": document 0: variable "units": type "complex" not valid`)
+}
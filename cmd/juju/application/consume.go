@@ -4,10 +4,19 @@
 package application
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	"gopkg.in/macaroon-bakery.v2/httpbakery"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/api/applicationoffers"
 	"github.com/juju/juju/apiserver/params"
@@ -27,10 +36,15 @@ The remote offer is identified by providing a path to the offer:
     [<model owner>/]<model name>.<application name>
         for an application in another model in this controller (if owner isn't specified it's assumed to be the logged-in user)
 
+Alternatively, the remote offer can be identified by a token created with
+"juju create-offer-token" by an administrator of the offer. This allows an
+offer to be consumed without having a user on the controller hosting it.
+
 Examples:
     $ juju consume othermodel.mysql
     $ juju consume owner/othermodel.mysql
     $ juju consume anothercontroller:owner/othermodel.mysql
+    $ juju consume MIIBqTCCAQ...
 
 See also:
     add-relation
@@ -48,6 +62,7 @@ type consumeCommand struct {
 	modelcmd.ModelCommandBase
 	sourceAPI         applicationConsumeDetailsAPI
 	targetAPI         applicationConsumeAPI
+	tokenAPI          offerTokenRedeemAPI
 	remoteApplication string
 	applicationAlias  string
 }
@@ -56,7 +71,7 @@ type consumeCommand struct {
 func (c *consumeCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "consume",
-		Args:    "<remote offer path> [<local application name>]",
+		Args:    "<remote offer path>|<offer token> [<local application name>]",
 		Purpose: usageConsumeSummary,
 		Doc:     usageConsumeDetails,
 	})
@@ -109,9 +124,43 @@ func (c *consumeCommand) getSourceAPI(url *crossmodel.OfferURL) (applicationCons
 	return applicationoffers.NewClient(root), nil
 }
 
+func (c *consumeCommand) getTokenAPI() offerTokenRedeemAPI {
+	if c.tokenAPI != nil {
+		return c.tokenAPI
+	}
+	return offerTokenRedeemer{}
+}
+
+// redeemOfferToken redeems the given offer token with the controller
+// hosting the offer, returning the details needed to consume it.
+func (c *consumeCommand) redeemOfferToken(info crossmodel.OfferTokenInfo) (*crossmodel.OfferURL, params.ConsumeOfferDetails, error) {
+	url, err := crossmodel.ParseOfferURL(info.OfferURL)
+	if err != nil {
+		return nil, params.ConsumeOfferDetails{}, errors.Trace(err)
+	}
+	url.Source = info.ControllerName
+	consumeDetails, err := c.getTokenAPI().RedeemOfferToken(info)
+	if err != nil {
+		return nil, params.ConsumeOfferDetails{}, errors.Annotate(err, "cannot redeem offer token")
+	}
+	c.remoteApplication = url.Path()
+	return url, consumeDetails, nil
+}
+
 // Run adds the requested remote offer to the model. Implements
 // cmd.Command.
 func (c *consumeCommand) Run(ctx *cmd.Context) error {
+	// Offer tokens never contain a ".", which every offer URL must.
+	if !strings.Contains(c.remoteApplication, ".") {
+		if info, err := crossmodel.DecodeOfferToken(c.remoteApplication); err == nil {
+			url, consumeDetails, err := c.redeemOfferToken(info)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			return c.consume(ctx, url, consumeDetails)
+		}
+	}
+
 	accountDetails, err := c.CurrentAccountDetails()
 	if err != nil {
 		return errors.Trace(err)
@@ -137,6 +186,11 @@ func (c *consumeCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	return c.consume(ctx, url, consumeDetails)
+}
+
+// consume adds the remote offer with the given URL and details to the model.
+func (c *consumeCommand) consume(ctx *cmd.Context, url *crossmodel.OfferURL, consumeDetails params.ConsumeOfferDetails) error {
 	// Parse the offer details URL and add the source controller so
 	// things like status can show the original source of the offer.
 	offerURL, err := crossmodel.ParseOfferURL(consumeDetails.Offer.OfferURL)
@@ -186,3 +240,67 @@ type applicationConsumeDetailsAPI interface {
 	Close() error
 	GetConsumeDetails(string) (params.ConsumeOfferDetails, error)
 }
+
+type offerTokenRedeemAPI interface {
+	RedeemOfferToken(crossmodel.OfferTokenInfo) (params.ConsumeOfferDetails, error)
+}
+
+// offerTokenRedeemer redeems offer tokens with the controller hosting the
+// offer. The consumer has no user on that controller, so rather than using
+// the API, the token is posted to the controller's "/offer-token" endpoint,
+// verifying the controller's identity with the CA certificate in the token.
+type offerTokenRedeemer struct{}
+
+// RedeemOfferToken implements offerTokenRedeemAPI.
+func (offerTokenRedeemer) RedeemOfferToken(info crossmodel.OfferTokenInfo) (params.ConsumeOfferDetails, error) {
+	certPool, err := api.CreateCertPool(info.CACert)
+	if err != nil {
+		return params.ConsumeOfferDetails{}, errors.Annotate(err, "cannot parse controller CA certificate")
+	}
+	httpClient := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: api.NewTLSConfig(certPool)},
+	}
+	body, err := json.Marshal(params.RedeemOfferTokenRequest{
+		TokenId:   info.TokenId,
+		SecretKey: info.SecretKey,
+	})
+	if err != nil {
+		return params.ConsumeOfferDetails{}, errors.Trace(err)
+	}
+
+	// Try each of the controller's addresses in turn until one responds.
+	var httpResp *http.Response
+	for _, addr := range info.Addrs {
+		urlString := fmt.Sprintf("https://%s/model/%s/offer-token", addr, info.ModelUUID)
+		httpReq, err := http.NewRequest("POST", urlString, bytes.NewReader(body))
+		if err != nil {
+			return params.ConsumeOfferDetails{}, errors.Trace(err)
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		httpReq.Header.Set(httpbakery.BakeryProtocolHeader, fmt.Sprint(bakery.LatestVersion))
+		httpResp, err = httpClient.Do(httpReq)
+		if err == nil {
+			break
+		}
+		logger.Debugf("cannot redeem offer token at %s: %v", addr, err)
+	}
+	if httpResp == nil {
+		return params.ConsumeOfferDetails{}, errors.Errorf("cannot connect to controller %q", info.ControllerName)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		var resp params.ErrorResult
+		if err := json.NewDecoder(httpResp.Body).Decode(&resp); err == nil && resp.Error != nil {
+			return params.ConsumeOfferDetails{}, resp.Error
+		}
+		// The response did not describe the error, as happens when
+		// something other than the controller answers the request.
+		return params.ConsumeOfferDetails{}, errors.Errorf("cannot redeem offer token: %s", httpResp.Status)
+	}
+	var resp params.ConsumeOfferDetails
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return params.ConsumeOfferDetails{}, errors.Annotate(err, "cannot decode offer token response")
+	}
+	return resp, nil
+}
@@ -4,6 +4,11 @@
 package application_test
 
 import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
@@ -51,7 +56,7 @@ func (s *ConsumeSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *ConsumeSuite) runConsume(c *gc.C, args ...string) (*cmd.Context, error) {
-	return cmdtesting.RunCommand(c, application.NewConsumeCommandForTest(s.store, s.mockAPI, s.mockAPI), args...)
+	return cmdtesting.RunCommand(c, application.NewConsumeCommandForTest(s.store, s.mockAPI, s.mockAPI, s.mockAPI), args...)
 }
 
 func (s *ConsumeSuite) TestNoArguments(c *gc.C) {
@@ -134,6 +139,59 @@ func (s *ConsumeSuite) TestSuccessModelDotApplicationWithAlias(c *gc.C) {
 	s.assertSuccessModelDotApplication(c, "alias")
 }
 
+func (s *ConsumeSuite) TestSuccessOfferToken(c *gc.C) {
+	s.mockAPI.localName = "mary-weep"
+	info := crossmodel.OfferTokenInfo{
+		ControllerName: "ctrl",
+		Addrs:          []string{"192.168.1:1234"},
+		CACert:         coretesting.CACert,
+		ModelUUID:      coretesting.ModelTag.Id(),
+		OfferURL:       "bob/booster.uke",
+		TokenId:        "token-id",
+		SecretKey:      "secret",
+	}
+	token, err := crossmodel.EncodeOfferToken(info)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// No account is needed on any controller to consume with a token.
+	delete(s.store.Accounts, "test-master")
+	ctx, err := s.runConsume(c, token)
+	c.Assert(err, jc.ErrorIsNil)
+	mac, err := apitesting.NewMacaroon("id")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"RedeemOfferToken", []interface{}{info}},
+		{"Consume", []interface{}{crossmodel.ConsumeApplicationArgs{
+			Offer:    params.ApplicationOfferDetails{OfferName: "an offer", OfferURL: "ctrl:bob/booster.uke"},
+			Macaroon: mac,
+			ControllerInfo: &crossmodel.ControllerInfo{
+				ControllerTag: coretesting.ControllerTag,
+				Alias:         "controller-alias",
+				Addrs:         []string{"192.168.1:1234"},
+				CACert:        coretesting.CACert,
+			},
+		},
+		}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Added ctrl:bob/booster.uke as mary-weep\n")
+}
+
+func (s *ConsumeSuite) TestOfferTokenRedeemError(c *gc.C) {
+	token, err := crossmodel.EncodeOfferToken(crossmodel.OfferTokenInfo{
+		ControllerName: "ctrl",
+		Addrs:          []string{"192.168.1:1234"},
+		ModelUUID:      coretesting.ModelTag.Id(),
+		OfferURL:       "bob/booster.uke",
+		TokenId:        "token-id",
+		SecretKey:      "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.SetErrors(&params.Error{Code: params.CodeForbidden, Message: `offer token "token-id" has expired`})
+	_, err = s.runConsume(c, token)
+	c.Assert(err, gc.ErrorMatches, `cannot redeem offer token: offer token "token-id" has expired`)
+}
+
 type mockConsumeAPI struct {
 	*testing.Stub
 
@@ -152,6 +210,10 @@ func (a *mockConsumeAPI) Consume(arg crossmodel.ConsumeApplicationArgs) (string,
 
 func (a *mockConsumeAPI) GetConsumeDetails(url string) (params.ConsumeOfferDetails, error) {
 	a.MethodCall(a, "GetConsumeDetails", url)
+	return a.consumeDetails()
+}
+
+func (a *mockConsumeAPI) consumeDetails() (params.ConsumeOfferDetails, error) {
 	mac, err := apitesting.NewMacaroon("id")
 	if err != nil {
 		return params.ConsumeOfferDetails{}, err
@@ -167,3 +229,36 @@ func (a *mockConsumeAPI) GetConsumeDetails(url string) (params.ConsumeOfferDetai
 		},
 	}, a.NextErr()
 }
+
+func (a *mockConsumeAPI) RedeemOfferToken(info crossmodel.OfferTokenInfo) (params.ConsumeOfferDetails, error) {
+	a.MethodCall(a, "RedeemOfferToken", info)
+	return a.consumeDetails()
+}
+
+func (s *ConsumeSuite) redeemWithResponse(c *gc.C, status int, body string) error {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{*coretesting.ServerTLSCert}}
+	srv.StartTLS()
+	defer srv.Close()
+
+	_, err := application.RedeemOfferToken(crossmodel.OfferTokenInfo{
+		ControllerName: "prod",
+		Addrs:          []string{strings.TrimPrefix(srv.URL, "https://")},
+		CACert:         coretesting.CACert,
+		ModelUUID:      "prod-uuid",
+	})
+	return err
+}
+
+func (s *ConsumeSuite) TestRedeemOfferTokenError(c *gc.C) {
+	err := s.redeemWithResponse(c, http.StatusForbidden, `{"error": {"message": "token expired", "code": "unauthorized access"}}`)
+	c.Assert(err, gc.ErrorMatches, "token expired")
+}
+
+func (s *ConsumeSuite) TestRedeemOfferTokenErrorWithoutDetails(c *gc.C) {
+	err := s.redeemWithResponse(c, http.StatusBadGateway, "")
+	c.Assert(err, gc.ErrorMatches, "cannot redeem offer token: 502 Bad Gateway")
+}
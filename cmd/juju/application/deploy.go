@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/charm/v7"
 	"github.com/juju/charm/v7/resource"
@@ -105,6 +106,18 @@ type ConsumeDetails interface {
 	Close() error
 }
 
+// PruneAPI represents the methods of the API the apply command needs to
+// remove from a model what is not defined in a bundle.
+type PruneAPI interface {
+	DestroyApplications(application.DestroyApplicationsParams) ([]apiparams.DestroyApplicationResult, error)
+	DestroyMachinesWithParams(force, keep bool, machines ...string) error
+	DestroyOffers(force bool, offerURLs ...string) error
+	DestroyRelation(force *bool, maxWait *time.Duration, endpoints ...string) error
+	DestroyUnits(application.DestroyUnitsParams) ([]apiparams.DestroyUnitResult, error)
+	Unexpose(application string) error
+	UnsetApplicationConfig(branchName, application string, options []string) error
+}
+
 // SpacesAPI defines the necessary API methods needed for listing spaces.
 type SpacesAPI interface {
 	ListSpaces() ([]apiparams.Space, error)
@@ -124,6 +137,7 @@ type DeployAPI interface {
 	ApplicationAPI
 	ModelAPI
 	OfferAPI
+	PruneAPI
 	SpacesAPI
 
 	// ApplicationClient
@@ -410,6 +424,20 @@ type DeployCommand struct {
 	flagSet    *gnuflag.FlagSet
 
 	unknownModel bool
+
+	// bundleVars holds the values given for the variables of the bundle
+	// and its overlays.
+	bundleVars bundleVariables
+
+	// prune and confirm are used by the apply command. If prune is true,
+	// everything in the model that is not defined in the bundle is
+	// removed. If confirm is set, it is called to confirm the planned
+	// changes before any are made.
+	prune   bool
+	confirm func(*cmd.Context) error
+
+	// parallel is the maximum number of bundle changes to apply at once.
+	parallel int
 }
 
 const deployDoc = `
@@ -568,6 +596,22 @@ bundle.
 Only top level machines can be mapped in this way, just as only top level
 machines can be defined in the machines section of the bundle.
 
+Local bundles and overlays may declare variables in a top level 'variables'
+section, and refer to them in values as ${<expression>}. Values for the
+variables are given with '--var name=value', or in a YAML file given with
+'--var-file'; variables that are not given a value take their declared
+default. Only bundles with a 'variables' section are treated as templates,
+so '${' in other bundles is left as it is. For example:
+
+  juju deploy ./bundle.yaml --var units=3 --var-file ./production.yaml
+
+By default the changes needed to deploy a bundle are made one at a time. Use
+'--parallel' to make up to the given number of changes at once, each as soon
+as the changes it depends on have been made; each change is then reported as
+it completes. For example:
+
+  juju deploy ./bundle.yaml --parallel 8
+
 When charms that include LXD profiles are deployed the profiles are validated
 for security purposes by allowing only certain configurations and devices. Use
 the '--force' option to bypass this check. Doing so is not recommended as it
@@ -689,7 +733,7 @@ func (c *DeployCommand) Info() *cmd.Info {
 var (
 	// TODO(thumper): support dry-run for apps as well as bundles.
 	bundleOnlyFlags = []string{
-		"overlay", "dry-run", "map-machines",
+		"overlay", "dry-run", "map-machines", "var", "var-file", "parallel",
 	}
 )
 
@@ -726,6 +770,8 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.StringVar(&c.BindToSpaces, "bind", "", "Configure application endpoint bindings to spaces")
 	f.StringVar(&c.machineMap, "map-machines", "", "Specify the existing machines to use for bundle deployments")
+	c.bundleVars.SetFlags(f)
+	f.IntVar(&c.parallel, "parallel", 1, "Maximum number of bundle changes to make at once")
 
 	for _, step := range c.Steps {
 		step.SetFlags(f)
@@ -767,6 +813,9 @@ func (c *DeployCommand) Init(args []string) error {
 	}
 	c.UseExisting = useExisting
 	c.BundleMachines = mapping
+	if c.parallel < 1 {
+		return errors.New("--parallel must be at least 1")
+	}
 
 	if err := c.UnitCommandBase.Init(args); err != nil {
 		return err
@@ -887,7 +936,7 @@ func (c *DeployCommand) deployBundle(spec bundleDeploySpec) (rErr error) {
 	// Compose bundle to be deployed and check its validity before running
 	// any pre/post checks.
 	var bundleData *charm.BundleData
-	if bundleData, err = composeAndVerifyBundle(spec.bundleDataSource, spec.bundleOverlayFile); err != nil {
+	if bundleData, err = composeAndVerifyBundle(spec.bundleDataSource, spec.bundleOverlayFile, &c.bundleVars); err != nil {
 		return errors.Annotatef(err, "cannot deploy bundle")
 	}
 	spec.bundleDir = spec.bundleDataSource.BasePath()
@@ -1338,7 +1387,7 @@ func (c *DeployCommand) maybeReadLocalBundle(ctx *cmd.Context) (deployFn, error)
 		)
 	}
 
-	ds, err := charm.LocalBundleDataSource(bundleFile)
+	ds, err := c.bundleVars.localDataSource(bundleFile)
 	if errors.IsNotFound(err) {
 		// Not a local bundle. Return nil, nil to indicate the fallback
 		// pipeline should try the next possibility.
@@ -1368,6 +1417,9 @@ func (c *DeployCommand) maybeReadLocalBundle(ctx *cmd.Context) (deployFn, error)
 			bundleMachines:      c.BundleMachines,
 			bundleStorage:       c.BundleStorage,
 			bundleDevices:       c.BundleDevices,
+			prune:               c.prune,
+			confirm:             c.confirm,
+			concurrency:         c.parallel,
 		}))
 	}, nil
 }
@@ -1559,6 +1611,9 @@ func (c *DeployCommand) maybeReadCharmstoreBundleFn(cstore BundleResolver) func(
 				bundleMachines:      c.BundleMachines,
 				bundleStorage:       c.BundleStorage,
 				bundleDevices:       c.BundleDevices,
+				prune:               c.prune,
+				confirm:             c.confirm,
+				concurrency:         c.parallel,
 			}))
 		}, nil
 	}
@@ -6,6 +6,8 @@ package application
 import (
 	"time"
 
+	"github.com/juju/bundlechanges"
+	"github.com/juju/charm/v7"
 	charmresource "github.com/juju/charm/v7/resource"
 	"github.com/juju/charmrepo/v5"
 	jujuclock "github.com/juju/clock"
@@ -21,8 +23,10 @@ import (
 	"github.com/juju/juju/api/base"
 	apicharms "github.com/juju/juju/api/charms"
 	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/apiserver/params"
 	jujucharmstore "github.com/juju/juju/charmstore"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/crossmodel"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/resource/resourceadapters"
@@ -218,12 +222,19 @@ func NewConsumeCommandForTest(
 	store jujuclient.ClientStore,
 	sourceAPI applicationConsumeDetailsAPI,
 	targetAPI applicationConsumeAPI,
+	tokenAPI offerTokenRedeemAPI,
 ) cmd.Command {
-	c := &consumeCommand{sourceAPI: sourceAPI, targetAPI: targetAPI}
+	c := &consumeCommand{sourceAPI: sourceAPI, targetAPI: targetAPI, tokenAPI: tokenAPI}
 	c.SetClientStore(store)
 	return modelcmd.Wrap(c)
 }
 
+// RedeemOfferToken redeems the offer token as consume does, by posting it
+// to the offering controller.
+func RedeemOfferToken(info crossmodel.OfferTokenInfo) (params.ConsumeOfferDetails, error) {
+	return offerTokenRedeemer{}.RedeemOfferToken(info)
+}
+
 // NewSetSeriesCommandForTest returns a SetSeriesCommand with the specified api.
 func NewSetSeriesCommandForTest(
 	seriesAPI setSeriesAPI,
@@ -281,6 +292,20 @@ func NewBundleDiffCommandForTest(api base.APICallCloser, charmStore BundleResolv
 	return modelcmd.Wrap(cmd)
 }
 
+// PruneChanges returns the descriptions of the changes needed to remove
+// from the model what is not defined in the bundle.
+func PruneChanges(data *charm.BundleData, model *bundlechanges.Model, status *params.FullStatus) ([]string, error) {
+	changes, err := pruneChanges(data, model, status)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	descriptions := make([]string, len(changes))
+	for i, change := range changes {
+		descriptions[i] = change.Description()
+	}
+	return descriptions, nil
+}
+
 func NewShowCommandForTest(api ApplicationsInfoAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &showApplicationCommand{newAPIFunc: func() (ApplicationsInfoAPI, error) {
 		return api, nil
@@ -289,6 +314,25 @@ func NewShowCommandForTest(api ApplicationsInfoAPI, store jujuclient.ClientStore
 	return modelcmd.Wrap(cmd)
 }
 
+func NewShowRemoteRelationCommandForTest(api RemoteRelationInfoAPI, clock jujuclock.Clock, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showRemoteRelationCommand{
+		clock: clock,
+		newAPIFunc: func() (RemoteRelationInfoAPI, error) {
+			return api, nil
+		},
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewAutoscaleHistoryCommandForTest(api AutoscaleHistoryAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &autoscaleHistoryCommand{newAPIFunc: func() (AutoscaleHistoryAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 func NewShowUnitCommandForTest(api UnitsInfoAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &showUnitCommand{newAPIFunc: func() (UnitsInfoAPI, error) {
 		return api, nil
@@ -309,3 +353,21 @@ func (s *RepoSuiteBaseSuite) SetUpTest(c *gc.C) {
 		return defaultSupportedJujuSeries, nil
 	})
 }
+
+// ReadBundleWithVariables reads the local bundle at the given path,
+// rendering its variables with the given --var and --var-file values.
+func ReadBundleWithVariables(path string, vars map[string]string, files []string) (*charm.BundleData, error) {
+	v := bundleVariables{files: files, flags: vars}
+	ds, err := v.localDataSource(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := v.checkDeclared(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return charm.ReadAndMergeBundleData(ds)
+}
+
+// ApplyChanges applies the changes as bundle deployment does with
+// --parallel.
+var ApplyChanges = applyChanges
@@ -119,11 +119,110 @@ func (s *RemoveApplicationCmdSuite) TestHandlingNotSupportedMultipleApps(c *gc.C
 	s.api.CheckCallNames(c, "DestroyApplications", "Close")
 }
 
+func (s *RemoveApplicationCmdSuite) TestDryRun(c *gc.C) {
+	s.apiFunc = func() (application.RemoveApplicationAPI, int, error) {
+		return s.api, 15, nil
+	}
+	s.api.planDestroyApplications = func(args apiapplication.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error) {
+		c.Assert(args.Applications, jc.DeepEquals, []string{"mysql"})
+		c.Assert(args.Cascade, jc.IsFalse)
+		return []params.DestroyApplicationResult{{
+			Info: &params.DestroyApplicationInfo{
+				DestroyedUnits:        []params.Entity{{Tag: "unit-mysql-0"}},
+				DestroyedSubordinates: []params.Entity{{Tag: "unit-logging-0"}},
+				DestroyedRelations:    []params.Entity{{Tag: "relation-logging.info#mysql.juju-info"}},
+				DestroyedOffers:       []params.Entity{{Tag: "applicationoffer-hosted-mysql"}},
+				DetachedStorage:       []params.Entity{{Tag: "storage-data-0"}},
+				DestroyedMachines:     []params.Entity{{Tag: "machine-0-lxd-0"}},
+				OrphanedApplications:  []params.Entity{{Tag: "application-logging"}},
+				OrphanedMachines:      []params.Entity{{Tag: "machine-0"}},
+			},
+		}}, nil
+	}
+	ctx, err := s.runRemoveApplication(c, "mysql", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+removing application mysql would:
+- remove unit mysql/0
+- remove subordinate unit logging/0
+- remove relation "logging:info mysql:juju-info"
+- remove offer hosted-mysql
+- detach storage data/0
+- remove machine 0/lxd/0
+- leave subordinate application logging without principals
+- leave machine 0 unused
+`[1:])
+	s.api.CheckCallNames(c, "PlanDestroyApplications", "Close")
+}
+
+func (s *RemoveApplicationCmdSuite) TestDryRunOfferConnections(c *gc.C) {
+	s.apiFunc = func() (application.RemoveApplicationAPI, int, error) {
+		return s.api, 15, nil
+	}
+	s.api.planDestroyApplications = func(args apiapplication.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error) {
+		return []params.DestroyApplicationResult{{
+			Info: &params.DestroyApplicationInfo{
+				DestroyedUnits:   []params.Entity{{Tag: "unit-mysql-0"}},
+				OfferConnections: []params.Entity{{Tag: "relation-mysql.db#remote-abcdef.db"}},
+			},
+		}, {
+			Error: &params.Error{Code: params.CodeNotFound, Message: `application "unknown" not found`},
+		}}, nil
+	}
+	ctx, err := s.runRemoveApplication(c, "mysql", "unknown", "--dry-run", "--cascade")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+removing application mysql would:
+- remove unit mysql/0
+- fail while offer consumer relation "mysql:db remote-abcdef:db" exists
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing application unknown would fail: application "unknown" not found
+`[1:])
+	s.api.CheckCall(c, 0, "PlanDestroyApplications", apiapplication.DestroyApplicationsParams{
+		Applications: []string{"mysql", "unknown"},
+		Cascade:      true,
+	})
+}
+
+func (s *RemoveApplicationCmdSuite) TestCascade(c *gc.C) {
+	s.apiFunc = func() (application.RemoveApplicationAPI, int, error) {
+		return s.api, 15, nil
+	}
+	s.api.destroyApplications = func(args apiapplication.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error) {
+		c.Assert(args.Cascade, jc.IsTrue)
+		return []params.DestroyApplicationResult{{
+			Info: &params.DestroyApplicationInfo{
+				DestroyedApplications: []params.Entity{{Tag: "application-logging"}},
+				DestroyedMachines:     []params.Entity{{Tag: "machine-0"}},
+			},
+		}}, nil
+	}
+	ctx, err := s.runRemoveApplication(c, "mysql", "--cascade")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing application mysql
+- will remove subordinate application logging
+- will remove machine 0
+`[1:])
+	s.api.CheckCallNames(c, "DestroyApplications", "Close")
+}
+
+func (s *RemoveApplicationCmdSuite) TestDryRunAndCascadeNotSupported(c *gc.C) {
+	_, err := s.runRemoveApplication(c, "mysql", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "--dry-run is not supported by this controller")
+	_, err = s.runRemoveApplication(c, "mysql", "--cascade")
+	c.Assert(err, gc.ErrorMatches, "--cascade is not supported by this controller")
+	s.api.CheckCallNames(c, "Close", "Close")
+}
+
 type testApplicationRemoveUnitAPI struct {
 	*jujutesting.Stub
 
 	destroyApplications func(args apiapplication.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error)
 
+	planDestroyApplications func(args apiapplication.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error)
+
 	destroyUnits func(args apiapplication.DestroyUnitsParams) ([]params.DestroyUnitResult, error)
 }
 
@@ -132,6 +231,11 @@ func (a *testApplicationRemoveUnitAPI) DestroyApplications(args apiapplication.D
 	return a.destroyApplications(args)
 }
 
+func (a *testApplicationRemoveUnitAPI) PlanDestroyApplications(args apiapplication.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error) {
+	a.AddCall("PlanDestroyApplications", args)
+	return a.planDestroyApplications(args)
+}
+
 func (a *testApplicationRemoveUnitAPI) DestroyUnits(args apiapplication.DestroyUnitsParams) ([]params.DestroyUnitResult, error) {
 	a.AddCall("DestroyUnits", args)
 	return a.destroyUnits(args)
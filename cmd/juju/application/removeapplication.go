@@ -4,6 +4,7 @@
 package application
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/cmd"
@@ -39,6 +40,8 @@ type removeApplicationCommand struct {
 	DestroyStorage   bool
 	Force            bool
 	NoWait           bool
+	DryRun           bool
+	Cascade          bool
 	fs               *gnuflag.FlagSet
 }
 
@@ -67,11 +70,23 @@ proceed to a next step until the current step has finished.
 However, when using --force, users can also specify --no-wait to progress through steps 
 without delay waiting for each step to complete.
 
+Use --dry-run to see what removing the applications would affect without
+removing anything: the units, subordinate units, relations, offers and
+storage which would be removed, any consumers of the applications' offers
+which prevent their removal, and the subordinate applications and machines
+which would be removed or left unused.
+
+Subordinate applications which are left without any principal application
+to relate to, and machines which are left without any units or containers,
+remain in the model unless --cascade is specified, in which case they are
+removed as well.
+
 Examples:
     juju remove-application hadoop
     juju remove-application --force hadoop
     juju remove-application --force --no-wait hadoop
-    juju remove-application -m test-model mariadb`[1:]
+    juju remove-application -m test-model mariadb
+    juju remove-application --dry-run --cascade mariadb`[1:]
 
 func (c *removeApplicationCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
@@ -87,6 +102,8 @@ func (c *removeApplicationCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.DestroyStorage, "destroy-storage", false, "Destroy storage attached to application units")
 	f.BoolVar(&c.Force, "force", false, "Completely remove an application and all its dependencies")
 	f.BoolVar(&c.NoWait, "no-wait", false, "Rush through application removal without waiting for each individual step to complete")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Show what removing the applications would affect, without removing them")
+	f.BoolVar(&c.Cascade, "cascade", false, "Also remove subordinate applications and machines left unused by the removal")
 	c.fs = f
 }
 
@@ -107,6 +124,7 @@ type RemoveApplicationAPI interface {
 	Close() error
 	ScaleApplication(application.ScaleApplicationParams) (params.ScaleApplicationResult, error)
 	DestroyApplications(application.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error)
+	PlanDestroyApplications(application.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error)
 	DestroyDeprecated(appName string) error
 	DestroyUnits(application.DestroyUnitsParams) ([]params.DestroyUnitResult, error)
 	DestroyUnitsDeprecated(unitNames ...string) error
@@ -196,6 +214,15 @@ func (c *removeApplicationCommand) Run(ctx *cmd.Context) error {
 	if c.DestroyStorage && apiVersion < 5 {
 		return errors.New("--destroy-storage is not supported by this controller")
 	}
+	if c.DryRun && apiVersion < 15 {
+		return errors.New("--dry-run is not supported by this controller")
+	}
+	if c.Cascade && apiVersion < 15 {
+		return errors.New("--cascade is not supported by this controller")
+	}
+	if c.DryRun {
+		return c.planRemoveApplications(ctx, client)
+	}
 	return c.removeApplications(ctx, client)
 }
 
@@ -214,10 +241,7 @@ func (c *removeApplicationCommand) removeApplicationsDeprecated(
 	return nil
 }
 
-func (c *removeApplicationCommand) removeApplications(
-	ctx *cmd.Context,
-	client RemoveApplicationAPI,
-) error {
+func (c *removeApplicationCommand) destroyApplicationsParams() application.DestroyApplicationsParams {
 	var maxWait *time.Duration
 	if c.Force {
 		if c.NoWait {
@@ -225,13 +249,71 @@ func (c *removeApplicationCommand) removeApplications(
 			maxWait = &zeroSec
 		}
 	}
-
-	results, err := client.DestroyApplications(application.DestroyApplicationsParams{
+	return application.DestroyApplicationsParams{
 		Applications:   c.ApplicationNames,
 		DestroyStorage: c.DestroyStorage,
 		Force:          c.Force,
 		MaxWait:        maxWait,
-	})
+		Cascade:        c.Cascade,
+	}
+}
+
+// planRemoveApplications prints everything that removing the applications
+// would affect, without removing them.
+func (c *removeApplicationCommand) planRemoveApplications(
+	ctx *cmd.Context,
+	client RemoveApplicationAPI,
+) error {
+	results, err := client.PlanDestroyApplications(c.destroyApplicationsParams())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	anyFailed := false
+	for i, name := range c.ApplicationNames {
+		result := results[i]
+		if result.Error != nil {
+			anyFailed = true
+			ctx.Infof("removing application %s would fail: %s", name, result.Error.Error())
+			continue
+		}
+		info := result.Info
+		fmt.Fprintf(ctx.Stdout, "removing application %s would:\n", name)
+		printEntities := func(format string, entities []params.Entity) {
+			for _, entity := range entities {
+				tag, err := names.ParseTag(entity.Tag)
+				if err != nil {
+					logger.Warningf("%s", err)
+					continue
+				}
+				fmt.Fprintf(ctx.Stdout, "- "+format+"\n", tag.Id())
+			}
+		}
+		printEntities("remove unit %s", info.DestroyedUnits)
+		printEntities("remove subordinate unit %s", info.DestroyedSubordinates)
+		printEntities("remove relation %q", info.DestroyedRelations)
+		printEntities("remove offer %s", info.DestroyedOffers)
+		printEntities("remove storage %s", info.DestroyedStorage)
+		printEntities("detach storage %s", info.DetachedStorage)
+		printEntities("remove subordinate application %s", info.DestroyedApplications)
+		printEntities("remove machine %s", info.DestroyedMachines)
+		printEntities("leave subordinate application %s without principals", info.OrphanedApplications)
+		printEntities("leave machine %s unused", info.OrphanedMachines)
+		if len(info.OfferConnections) > 0 {
+			anyFailed = true
+			printEntities("fail while offer consumer relation %q exists", info.OfferConnections)
+		}
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+func (c *removeApplicationCommand) removeApplications(
+	ctx *cmd.Context,
+	client RemoveApplicationAPI,
+) error {
+	results, err := client.DestroyApplications(c.destroyApplicationsParams())
 	if err := block.ProcessBlockedError(err, block.BlockRemove); err != nil {
 		return errors.Trace(err)
 	}
@@ -272,6 +354,22 @@ func (c *removeApplicationCommand) removeApplications(
 			}
 			ctx.Infof("- will detach %s", names.ReadableString(storageTag))
 		}
+		for _, entity := range result.Info.DestroyedApplications {
+			appTag, err := names.ParseApplicationTag(entity.Tag)
+			if err != nil {
+				logger.Warningf("%s", err)
+				continue
+			}
+			ctx.Infof("- will remove subordinate %s", names.ReadableString(appTag))
+		}
+		for _, entity := range result.Info.DestroyedMachines {
+			machineTag, err := names.ParseMachineTag(entity.Tag)
+			if err != nil {
+				logger.Warningf("%s", err)
+				continue
+			}
+			ctx.Infof("- will remove %s", names.ReadableString(machineTag))
+		}
 	}
 	if anyFailed {
 		return cmd.ErrSilent
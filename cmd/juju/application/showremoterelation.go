@@ -0,0 +1,255 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const showRemoteRelationDoc = `
+Shows information about cross model relations, to help find out why a
+relation to an offer in another model is not progressing. Relations are
+specified by their id, or by the name of an application, in which case
+all of its cross model relations are shown. The application can be a
+consumed offer (SAAS), an offered application, or the application that
+stands in for a model consuming an offer, as shown by the offers command.
+
+For each relation the following are shown:
+ - the tokens that identify the relation and the applications to the
+   other model,
+ - when the macaroon used to make changes to the relation in the
+   offering model expires, for relations to consumed offers,
+ - the ingress and egress networks of the relation,
+ - the last change to the relation received from the other model, and
+   any error applying it,
+ - the relation settings of the applications and units on both sides,
+   with those of the other model's as last received.
+
+Examples:
+    juju show-remote-relation 12
+    juju show-remote-relation mysql
+    juju show-remote-relation 12 13 --format json
+
+See also:
+    consume
+    offers
+    show-unit
+    suspend-relation
+`
+
+// NewShowRemoteRelationCommand returns a command that shows information
+// about cross model relations.
+func NewShowRemoteRelationCommand() cmd.Command {
+	c := &showRemoteRelationCommand{clock: clock.WallClock}
+	c.newAPIFunc = func() (RemoteRelationInfoAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+// RemoteRelationInfoAPI defines the API methods that the
+// show-remote-relation command uses.
+type RemoteRelationInfoAPI interface {
+	Close() error
+	RemoteRelationInfo(relationIds []int, applications []string) ([]params.RemoteRelationInfoResult, error)
+}
+
+type showRemoteRelationCommand struct {
+	modelcmd.ModelCommandBase
+
+	out          cmd.Output
+	isoTime      bool
+	relationIds  []int
+	applications []string
+
+	clock      clock.Clock
+	newAPIFunc func() (RemoteRelationInfoAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *showRemoteRelationCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-remote-relation",
+		Args:    "<relation-id>|<application name> ...",
+		Purpose: "Displays information about cross model relations.",
+		Doc:     showRemoteRelationDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showRemoteRelationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters.Formatters())
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+}
+
+// Init implements Command.Init.
+func (c *showRemoteRelationCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no relation ids or application names specified")
+	}
+	for _, arg := range args {
+		if id, err := strconv.Atoi(arg); err == nil {
+			if id < 0 {
+				return errors.NotValidf("relation ID %q", arg)
+			}
+			c.relationIds = append(c.relationIds, id)
+			continue
+		}
+		if !names.IsValidApplication(arg) {
+			return errors.NotValidf("relation ID or application name %q", arg)
+		}
+		c.applications = append(c.applications, arg)
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *showRemoteRelationCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	results, err := client.RemoteRelationInfo(c.relationIds, c.applications)
+	if errors.IsNotSupported(err) {
+		return errors.New("showing remote relations is not supported by this version of Juju")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	var errorStrings []string
+	output := make(map[int]RemoteRelationInfo)
+	for _, result := range results {
+		if result.Error != nil {
+			errorStrings = append(errorStrings, result.Error.Error())
+			continue
+		}
+		output[result.Result.Id] = c.formatRemoteRelationInfo(result.Result)
+	}
+	if len(errorStrings) > 0 {
+		return errors.New(strings.Join(errorStrings, "\n"))
+	}
+	return c.out.Write(ctx, output)
+}
+
+// RemoteRelationInfo defines the serialization behaviour of the
+// information about a cross model relation.
+type RemoteRelationInfo struct {
+	Key             string                     `yaml:"key" json:"key"`
+	Role            string                     `yaml:"role" json:"role"`
+	RemoteModel     string                     `yaml:"remote-model" json:"remote-model"`
+	Username        string                     `yaml:"username,omitempty" json:"username,omitempty"`
+	Suspended       bool                       `yaml:"suspended,omitempty" json:"suspended,omitempty"`
+	SuspendedReason string                     `yaml:"suspended-reason,omitempty" json:"suspended-reason,omitempty"`
+	Token           string                     `yaml:"token,omitempty" json:"token,omitempty"`
+	MacaroonExpiry  string                     `yaml:"macaroon-expiry,omitempty" json:"macaroon-expiry,omitempty"`
+	MacaroonExpired bool                       `yaml:"macaroon-expired,omitempty" json:"macaroon-expired,omitempty"`
+	IngressNetworks []string                   `yaml:"ingress-networks,omitempty" json:"ingress-networks,omitempty"`
+	EgressNetworks  []string                   `yaml:"egress-networks,omitempty" json:"egress-networks,omitempty"`
+	LastEvent       *RemoteRelationEventInfo   `yaml:"last-event-received,omitempty" json:"last-event-received,omitempty"`
+	Local           RemoteRelationEndpointInfo `yaml:"local" json:"local"`
+	Remote          RemoteRelationEndpointInfo `yaml:"remote" json:"remote"`
+}
+
+// RemoteRelationEndpointInfo defines the serialization behaviour of the
+// settings of one side of a cross model relation.
+type RemoteRelationEndpointInfo struct {
+	Application     string                      `yaml:"application" json:"application"`
+	Endpoint        string                      `yaml:"endpoint" json:"endpoint"`
+	Token           string                      `yaml:"token,omitempty" json:"token,omitempty"`
+	ApplicationData map[string]interface{}      `yaml:"application-data,omitempty" json:"application-data,omitempty"`
+	Units           map[string]UnitRelationData `yaml:"units,omitempty" json:"units,omitempty"`
+}
+
+// RemoteRelationEventInfo defines the serialization behaviour of a change
+// to a cross model relation received from the other model.
+type RemoteRelationEventInfo struct {
+	Received                   string   `yaml:"received" json:"received"`
+	Life                       string   `yaml:"life,omitempty" json:"life,omitempty"`
+	Suspended                  *bool    `yaml:"suspended,omitempty" json:"suspended,omitempty"`
+	ChangedUnits               []string `yaml:"changed-units,omitempty" json:"changed-units,omitempty"`
+	DepartedUnits              []string `yaml:"departed-units,omitempty" json:"departed-units,omitempty"`
+	ApplicationSettingsChanged bool     `yaml:"application-settings-changed,omitempty" json:"application-settings-changed,omitempty"`
+	Error                      string   `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+func (c *showRemoteRelationCommand) formatRemoteRelationInfo(in *params.RemoteRelationInfo) RemoteRelationInfo {
+	info := RemoteRelationInfo{
+		Key:             in.Key,
+		Role:            "consumer",
+		RemoteModel:     in.SourceModelUUID,
+		Username:        in.Username,
+		Suspended:       in.Suspended,
+		SuspendedReason: in.SuspendedReason,
+		Token:           in.Token,
+		IngressNetworks: in.IngressNetworks,
+		EgressNetworks:  in.EgressNetworks,
+		Local:           formatRemoteRelationEndpoint(in.Local),
+		Remote:          formatRemoteRelationEndpoint(in.Remote),
+	}
+	if in.Offered {
+		info.Role = "offerer"
+	}
+	if in.MacaroonExpiry != nil {
+		info.MacaroonExpiry = common.FormatTime(in.MacaroonExpiry, c.isoTime)
+		info.MacaroonExpired = !c.clock.Now().Before(*in.MacaroonExpiry)
+	}
+	if event := in.LastEvent; event != nil {
+		remoteUnits := func(ids []int) []string {
+			var units []string
+			for _, id := range ids {
+				units = append(units, fmt.Sprintf("%s/%d", in.Remote.ApplicationName, id))
+			}
+			return units
+		}
+		info.LastEvent = &RemoteRelationEventInfo{
+			Received:                   common.FormatTime(&event.Received, c.isoTime),
+			Life:                       string(event.Life),
+			Suspended:                  event.Suspended,
+			ChangedUnits:               remoteUnits(event.ChangedUnits),
+			DepartedUnits:              remoteUnits(event.DepartedUnits),
+			ApplicationSettingsChanged: event.ApplicationSettingsChanged,
+			Error:                      event.Error,
+		}
+	}
+	return info
+}
+
+func formatRemoteRelationEndpoint(in params.RemoteRelationEndpoint) RemoteRelationEndpointInfo {
+	out := RemoteRelationEndpointInfo{
+		Application:     in.ApplicationName,
+		Endpoint:        in.Endpoint,
+		Token:           in.Token,
+		ApplicationData: in.ApplicationData,
+	}
+	if len(in.UnitRelationData) > 0 {
+		out.Units = make(map[string]UnitRelationData)
+		for unit, data := range in.UnitRelationData {
+			out.Units[unit] = UnitRelationData{
+				InScope:  data.InScope,
+				UnitData: data.UnitData,
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,200 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/jujuclient"
+	jujutesting "github.com/juju/juju/testing"
+)
+
+type ShowRemoteRelationSuite struct {
+	jujutesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+
+	mockAPI *mockRemoteRelationInfoAPI
+	clock   *testclock.Clock
+}
+
+var _ = gc.Suite(&ShowRemoteRelationSuite{})
+
+func (s *ShowRemoteRelationSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	s.mockAPI = &mockRemoteRelationInfoAPI{}
+	s.clock = testclock.NewClock(time.Date(2020, 11, 4, 12, 0, 0, 0, time.UTC))
+}
+
+func (s *ShowRemoteRelationSuite) runShow(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, application.NewShowRemoteRelationCommandForTest(s.mockAPI, s.clock, s.store), args...)
+}
+
+func (s *ShowRemoteRelationSuite) TestInit(c *gc.C) {
+	_, err := s.runShow(c)
+	c.Assert(err, gc.ErrorMatches, "no relation ids or application names specified")
+	_, err = s.runShow(c, "--", "-1")
+	c.Assert(err, gc.ErrorMatches, `relation ID "-1" not valid`)
+	_, err = s.runShow(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `relation ID or application name "mysql/0" not valid`)
+}
+
+func (s *ShowRemoteRelationSuite) TestShow(c *gc.C) {
+	expiry := time.Date(2020, 11, 4, 10, 0, 0, 0, time.UTC)
+	suspended := true
+	s.mockAPI.results = []params.RemoteRelationInfoResult{{
+		Result: &params.RemoteRelationInfo{
+			Id:              7,
+			Key:             "wordpress:db mysql:server",
+			Token:           "rel-token",
+			SourceModelUUID: "offer-model-uuid",
+			MacaroonExpiry:  &expiry,
+			EgressNetworks:  []string{"10.0.0.0/24"},
+			LastEvent: &params.RemoteRelationEvent{
+				Received:      time.Date(2020, 11, 4, 9, 30, 0, 0, time.UTC),
+				Life:          life.Alive,
+				Suspended:     &suspended,
+				ChangedUnits:  []int{0},
+				DepartedUnits: []int{1},
+				Error:         "boom",
+			},
+			Local: params.RemoteRelationEndpoint{
+				ApplicationName: "wordpress",
+				Endpoint:        "db",
+				ApplicationData: map[string]interface{}{"database": "wp"},
+				UnitRelationData: map[string]params.RelationData{
+					"wordpress/0": {InScope: true, UnitData: map[string]interface{}{"host": "10.0.0.2"}},
+				},
+			},
+			Remote: params.RemoteRelationEndpoint{
+				ApplicationName: "mysql",
+				Endpoint:        "server",
+				Token:           "mysql-token",
+				UnitRelationData: map[string]params.RelationData{
+					"mysql/0": {InScope: true, UnitData: map[string]interface{}{"user": "admin"}},
+				},
+			},
+		},
+	}}
+	ctx, err := s.runShow(c, "7", "mysql", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.relationIds, jc.DeepEquals, []int{7})
+	c.Assert(s.mockAPI.applications, jc.DeepEquals, []string{"mysql"})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+7:
+  key: wordpress:db mysql:server
+  role: consumer
+  remote-model: offer-model-uuid
+  token: rel-token
+  macaroon-expiry: 2020-11-04 10:00:00Z
+  macaroon-expired: true
+  egress-networks:
+  - 10.0.0.0/24
+  last-event-received:
+    received: 2020-11-04 09:30:00Z
+    life: alive
+    suspended: true
+    changed-units:
+    - mysql/0
+    departed-units:
+    - mysql/1
+    error: boom
+  local:
+    application: wordpress
+    endpoint: db
+    application-data:
+      database: wp
+    units:
+      wordpress/0:
+        in-scope: true
+        data:
+          host: 10.0.0.2
+  remote:
+    application: mysql
+    endpoint: server
+    token: mysql-token
+    units:
+      mysql/0:
+        in-scope: true
+        data:
+          user: admin
+`[1:])
+}
+
+func (s *ShowRemoteRelationSuite) TestShowOffered(c *gc.C) {
+	s.mockAPI.results = []params.RemoteRelationInfoResult{{
+		Result: &params.RemoteRelationInfo{
+			Id:              8,
+			Key:             "remote-abc:db mysql:server",
+			Offered:         true,
+			SourceModelUUID: "consumer-model-uuid",
+			Username:        "fred",
+			Suspended:       true,
+			SuspendedReason: "paused",
+			IngressNetworks: []string{"192.168.0.0/16"},
+			Local:           params.RemoteRelationEndpoint{ApplicationName: "mysql", Endpoint: "server"},
+			Remote:          params.RemoteRelationEndpoint{ApplicationName: "remote-abc", Endpoint: "db"},
+		},
+	}}
+	ctx, err := s.runShow(c, "8", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"8":{"key":"remote-abc:db mysql:server","role":"offerer","remote-model":"consumer-model-uuid",`+
+		`"username":"fred","suspended":true,"suspended-reason":"paused","ingress-networks":["192.168.0.0/16"],`+
+		`"local":{"application":"mysql","endpoint":"server"},"remote":{"application":"remote-abc","endpoint":"db"}}}`+"\n")
+}
+
+func (s *ShowRemoteRelationSuite) TestShowErrors(c *gc.C) {
+	s.mockAPI.results = []params.RemoteRelationInfoResult{
+		{Error: &params.Error{Message: `relation "wordpress:db mysql:server" is not a cross model relation`}},
+		{Error: &params.Error{Message: `application "foo" not found`}},
+	}
+	_, err := s.runShow(c, "7", "foo")
+	c.Assert(err, gc.ErrorMatches, `relation "wordpress:db mysql:server" is not a cross model relation
+application "foo" not found`)
+}
+
+func (s *ShowRemoteRelationSuite) TestShowNotSupported(c *gc.C) {
+	s.mockAPI.err = errors.NotSupportedf("RemoteRelationInfo for Application facade v12")
+	_, err := s.runShow(c, "7")
+	c.Assert(err, gc.ErrorMatches, "showing remote relations is not supported by this version of Juju")
+}
+
+type mockRemoteRelationInfoAPI struct {
+	relationIds  []int
+	applications []string
+	results      []params.RemoteRelationInfoResult
+	err          error
+}
+
+func (m *mockRemoteRelationInfoAPI) Close() error {
+	return nil
+}
+
+func (m *mockRemoteRelationInfoAPI) RemoteRelationInfo(relationIds []int, applications []string) ([]params.RemoteRelationInfoResult, error) {
+	m.relationIds = relationIds
+	m.applications = applications
+	return m.results, m.err
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"strings"
 
 	"github.com/juju/charm/v7"
 	charmresource "github.com/juju/charm/v7/resource"
@@ -34,6 +35,7 @@ import (
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourceadapters"
 	"github.com/juju/juju/storage"
@@ -229,6 +231,19 @@ is determined by the contents of the charm at the specified path.
 number with --switch, give it in the charm URL, for instance "cs:wordpress-5"
 would specify revision number 5 of the wordpress charm.
 
+If the active branch is not "master", the upgrade is staged on that branch
+instead of being applied to the whole application. Only units tracking the
+branch are upgraded to the new charm, along with any resources uploaded with
+it, so that the new charm can be tried out on a few units first. Committing
+the branch upgrades the rest of the application; aborting it, once no units
+are tracking it, discards the upgrade. The --config, --storage and --bind
+options can not be used when upgrading a charm on a branch.
+
+  juju add-branch canary
+  juju track canary foo/0
+  juju upgrade-charm foo
+  juju commit canary
+
 Use of the --force-units option is not generally recommended; units upgraded while in an
 error state will not have upgrade-charm hooks executed, and may cause unexpected
 behavior.
@@ -311,6 +326,12 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	onBranch := generation != model.GenerationMaster
+	if onBranch {
+		if err := c.checkBranchUpgrade(apiRoot, generation); err != nil {
+			return err
+		}
+	}
 	charmUpgradeClient := c.NewCharmUpgradeClient(apiRoot)
 	oldURL, err := charmUpgradeClient.GetCharmURL(generation, c.ApplicationName)
 	if err != nil {
@@ -406,7 +427,8 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 	}
 
 	var bindingsChangelog []string
-	if apiRoot.BestFacadeVersion("Application") >= 11 {
+	// Endpoint bindings can not be changed by an upgrade staged on a branch.
+	if apiRoot.BestFacadeVersion("Application") >= 11 && !onBranch {
 		// Fetch information about the charm we want to upgrade to and
 		// print out the updated endpoint binding plan.
 		charmInfo, err := c.NewCharmClient(apiRoot).CharmInfo(chID.URL.String())
@@ -452,6 +474,41 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ctx.Infof(change)
 	}
 
+	if onBranch {
+		ctx.Infof("Upgrade of %q to charm %q staged on branch %q.\n"+
+			"Units tracking the branch are upgraded now, the rest when the branch is committed.",
+			c.ApplicationName, chID.URL, generation)
+	}
+	return nil
+}
+
+// checkBranchUpgrade returns an error if the charm upgrade can not be staged
+// on the input branch, either because the server does not support it, or
+// because options were used that can only apply to the whole application.
+func (c *upgradeCharmCommand) checkBranchUpgrade(verQuerier versionQuerier, branchName string) error {
+	if verQuerier.BestFacadeVersion("Application") < 14 {
+		suffix := "this server"
+		if version, ok := verQuerier.ServerVersion(); ok {
+			suffix = fmt.Sprintf("server version %s", version)
+		}
+		return errors.Errorf("upgrading a charm on a branch is not supported by %s; "+
+			"switch to branch %q to upgrade the whole application", suffix, model.GenerationMaster)
+	}
+
+	var options []string
+	if c.Config.Path != "" {
+		options = append(options, "--config")
+	}
+	if len(c.Storage) > 0 {
+		options = append(options, "--storage")
+	}
+	if c.BindToSpaces != "" {
+		options = append(options, "--bind")
+	}
+	if len(options) > 0 {
+		return errors.Errorf("%s can not be used when upgrading a charm on branch %q",
+			strings.Join(options, ", "), branchName)
+	}
 	return nil
 }
 
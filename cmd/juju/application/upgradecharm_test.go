@@ -50,6 +50,7 @@ type BaseUpgradeCharmSuite struct {
 	testing.IsolationSuite
 	testing.Stub
 
+	activeBranch      string
 	deployResources   resourceadapters.DeployResourcesFunc
 	fakeAPI           *fakeDeployAPI
 	resolveCharm      ResolveCharmFunc
@@ -94,6 +95,7 @@ func (s *BaseUpgradeCharmSuite) SetUpTest(c *gc.C) {
 		return nil, s.NextErr()
 	}
 
+	s.activeBranch = model.GenerationMaster
 	s.resolvedChannel = csclientparams.StableChannel
 	s.resolveCharm = func(
 		resolveWithChannel func(*charm.URL, csclientparams.Channel) (*charm.URL, csclientparams.Channel, []string, error),
@@ -154,7 +156,7 @@ func (s *BaseUpgradeCharmSuite) upgradeCommand() cmd.Command {
 	store.Models["foo"] = &jujuclient.ControllerModels{
 		CurrentModel: "admin/bar",
 		Models: map[string]jujuclient.ModelDetails{
-			"admin/bar": {ActiveBranch: model.GenerationMaster},
+			"admin/bar": {ActiveBranch: s.activeBranch},
 		},
 	}
 	store.Accounts["foo"] = jujuclient.AccountDetails{
@@ -567,6 +569,43 @@ func (s *UpgradeCharmSuite) TestUpgradeCharmShouldRespectDeployedChannelByDefaul
 	})
 }
 
+func (s *UpgradeCharmSuite) TestUpgradeOnBranch(c *gc.C) {
+	s.activeBranch = "canary"
+	s.apiConnection.bestFacadeVersion = 14
+	ctx, err := s.runUpgradeCharm(c, "foo")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.charmAPIClient.CheckCallNames(c, "GetCharmURL", "Get", "SetCharm")
+	s.charmAPIClient.CheckCall(c, 0, "GetCharmURL", "canary", "foo")
+	// Endpoint bindings are not changed by an upgrade on a branch.
+	s.charmAPIClient.CheckCall(c, 2, "SetCharm", "canary", application.SetCharmConfig{
+		ApplicationName: "foo",
+		CharmID: jujucharmstore.CharmID{
+			URL:     s.resolvedCharmURL,
+			Channel: csclientparams.StableChannel,
+		},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains,
+		`Upgrade of "foo" to charm "cs:quantal/foo-2" staged on branch "canary".`)
+}
+
+func (s *UpgradeCharmSuite) TestUpgradeOnBranchMinFacadeVersion(c *gc.C) {
+	s.activeBranch = "canary"
+	s.apiConnection.bestFacadeVersion = 13
+	_, err := s.runUpgradeCharm(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `upgrading a charm on a branch is not supported by server version 1.2.3; `+
+		`switch to branch "master" to upgrade the whole application`)
+	s.charmAPIClient.CheckNoCalls(c)
+}
+
+func (s *UpgradeCharmSuite) TestUpgradeOnBranchUnsupportedOptions(c *gc.C) {
+	s.activeBranch = "canary"
+	s.apiConnection.bestFacadeVersion = 14
+	_, err := s.runUpgradeCharm(c, "foo", "--storage", "bar=baz", "--bind", "sp1")
+	c.Assert(err, gc.ErrorMatches, `--storage, --bind can not be used when upgrading a charm on branch "canary"`)
+	s.charmAPIClient.CheckNoCalls(c)
+}
+
 func (s *UpgradeCharmSuite) TestSwitch(c *gc.C) {
 	_, err := s.runUpgradeCharm(c, "foo", "--switch=cs:~other/trusty/anotherriak")
 	c.Assert(err, jc.ErrorIsNil)
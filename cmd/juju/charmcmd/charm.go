@@ -34,5 +34,6 @@ func NewSuperCommand() *Command {
 		),
 	}
 	charmCmd.Register(resource.NewListCharmResourcesCommand(nil))
+	charmCmd.Register(NewLintCommand())
 	return charmCmd
 }
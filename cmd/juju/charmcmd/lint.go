@@ -0,0 +1,122 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmcmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+)
+
+var lintDoc = `
+Validates a charm directory without contacting a controller, reporting
+the problems that would otherwise only be found when the charm is
+deployed.
+
+The charm's metadata.yaml, config.yaml, actions.yaml and lxd-profile.yaml
+files are checked, along with the resources defined in its metadata.
+Kubernetes pod spec files used by the charm may be checked as well, by
+passing them with --pod-spec.
+
+Every problem found is reported with the file and line on which it was
+found, and the command exits with a non-zero status if there are any,
+so that it may be used in continuous integration.
+
+Examples:
+    juju charm lint ./mycharm
+    juju charm lint ./mycharm --pod-spec ./mycharm/templates/spec.yaml
+    juju charm lint ./mycharm --format json
+`[1:]
+
+// NewLintCommand returns a command that validates a charm directory.
+func NewLintCommand() cmd.Command {
+	return &lintCommand{}
+}
+
+type lintCommand struct {
+	cmd.CommandBase
+
+	out      cmd.Output
+	charmDir string
+	podSpecs []string
+}
+
+// Info implements cmd.Command.
+func (c *lintCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "lint",
+		Args:    "<charm directory>",
+		Purpose: "Validate a charm offline.",
+		Doc:     lintDoc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *lintCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"tabular": formatLintTabular,
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+	})
+	f.Var(cmd.NewAppendStringsValue(&c.podSpecs), "pod-spec", "Kubernetes pod spec file to validate (may be repeated)")
+}
+
+// Init implements cmd.Command.
+func (c *lintCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no charm directory specified")
+	}
+	c.charmDir = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *lintCommand) Run(ctx *cmd.Context) error {
+	issues, err := LintCharm(ctx.AbsPath(c.charmDir), c.absPaths(ctx))
+	if err != nil {
+		return errors.Annotate(err, "cannot lint charm")
+	}
+	if len(issues) == 0 {
+		if c.out.Name() == "tabular" {
+			ctx.Infof("No issues found.")
+			return nil
+		}
+		issues = []Issue{}
+	}
+	if err := c.out.Write(ctx, issues); err != nil {
+		return errors.Trace(err)
+	}
+	if len(issues) > 0 {
+		return errors.Errorf("found %d issue(s) in charm", len(issues))
+	}
+	return nil
+}
+
+func (c *lintCommand) absPaths(ctx *cmd.Context) []string {
+	paths := make([]string, len(c.podSpecs))
+	for i, path := range c.podSpecs {
+		paths[i] = ctx.AbsPath(path)
+	}
+	return paths
+}
+
+func formatLintTabular(writer io.Writer, value interface{}) error {
+	issues, ok := value.([]Issue)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", issues, value)
+	}
+	lines := make([]string, len(issues))
+	for i, issue := range issues {
+		lines[i] = issue.String()
+	}
+	_, err := fmt.Fprint(writer, strings.Join(lines, "\n"))
+	return errors.Trace(err)
+}
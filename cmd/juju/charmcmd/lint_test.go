@@ -0,0 +1,194 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmcmd_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/charmcmd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type LintSuite struct {
+	coretesting.BaseSuite
+
+	dir string
+}
+
+var _ = gc.Suite(&LintSuite{})
+
+func (s *LintSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+}
+
+func (s *LintSuite) writeFile(c *gc.C, name, content string) string {
+	path := filepath.Join(s.dir, name)
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+const validMetadata = `
+name: mycharm
+summary: A charm.
+description: A charm for testing.
+series:
+  - focal
+resources:
+  software:
+    type: file
+    filename: software.tgz
+`
+
+func (s *LintSuite) TestLintValidCharm(c *gc.C) {
+	s.writeFile(c, "metadata.yaml", validMetadata)
+	s.writeFile(c, "config.yaml", `
+options:
+  title:
+    type: string
+    default: hello
+`)
+	s.writeFile(c, "actions.yaml", `
+backup:
+  description: Take a backup.
+`)
+	s.writeFile(c, "lxd-profile.yaml", `
+config:
+  security.nesting: "true"
+`)
+	issues, err := charmcmd.LintCharm(s.dir, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, gc.HasLen, 0)
+}
+
+func (s *LintSuite) TestLintMissingMetadata(c *gc.C) {
+	issues, err := charmcmd.LintCharm(s.dir, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, jc.DeepEquals, []charmcmd.Issue{{
+		File:    filepath.Join(s.dir, "metadata.yaml"),
+		Message: "file is missing",
+	}})
+}
+
+func (s *LintSuite) TestLintNotADirectory(c *gc.C) {
+	path := s.writeFile(c, "metadata.yaml", validMetadata)
+	_, err := charmcmd.LintCharm(path, nil)
+	c.Assert(err, gc.ErrorMatches, `charm path .* not a directory not valid`)
+}
+
+func (s *LintSuite) TestLintReportsAllIssues(c *gc.C) {
+	metadata := s.writeFile(c, "metadata.yaml", `
+name: mycharm
+summary: A charm.
+description: ""
+resources:
+  good:
+    type: oci-image
+  bad-type:
+    type: floppy
+  no-filename:
+    type: file
+`)
+	config := s.writeFile(c, "config.yaml", `
+options:
+  good:
+    type: int
+    default: 1
+  bad:
+    type: colour
+`)
+	actions := s.writeFile(c, "actions.yaml", `
+backup:
+  description: Take a backup.
+juju-run:
+  description: Reserved.
+`)
+	profile := s.writeFile(c, "lxd-profile.yaml", `
+config:
+  security.nesting: "true"
+  limits.cpu: "2"
+devices:
+  disk:
+    type: disk
+`)
+	issues, err := charmcmd.LintCharm(s.dir, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, jc.DeepEquals, []charmcmd.Issue{{
+		File:    actions,
+		Line:    4,
+		Message: `action "juju-run": cannot use action name juju-run: the "juju-" prefix is reserved`,
+	}, {
+		File:    config,
+		Line:    6,
+		Message: `invalid config: option "bad" has unknown type "colour"`,
+	}, {
+		File:    profile,
+		Line:    4,
+		Message: `invalid lxd-profile.yaml: contains config value "limits.cpu"`,
+	}, {
+		File:    profile,
+		Line:    6,
+		Message: `invalid lxd-profile.yaml: contains device type "disk"`,
+	}, {
+		File:    metadata,
+		Line:    4,
+		Message: `description is missing`,
+	}, {
+		File:    metadata,
+		Line:    8,
+		Message: `resource "bad-type": unsupported resource type "floppy"`,
+	}, {
+		File:    metadata,
+		Line:    10,
+		Message: `resource "no-filename": resource missing filename`,
+	}})
+}
+
+func (s *LintSuite) TestLintSyntaxError(c *gc.C) {
+	path := s.writeFile(c, "metadata.yaml", "name: mycharm\nsummary: [unclosed\n")
+	issues, err := charmcmd.LintCharm(s.dir, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, gc.HasLen, 1)
+	c.Assert(issues[0].File, gc.Equals, path)
+	c.Assert(issues[0].Line, gc.Equals, 2)
+}
+
+func (s *LintSuite) TestLintPodSpec(c *gc.C) {
+	s.writeFile(c, "metadata.yaml", validMetadata)
+	spec := s.writeFile(c, "spec.yaml", `
+version: 3
+containers:
+  - image: mysql
+`)
+	issues, err := charmcmd.LintCharm(s.dir, []string{spec})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, gc.HasLen, 1)
+	c.Assert(issues[0].File, gc.Equals, spec)
+	c.Assert(issues[0].Message, gc.Matches, `.*name is missing.*`)
+}
+
+func (s *LintSuite) TestCommandNoIssues(c *gc.C) {
+	s.writeFile(c, "metadata.yaml", validMetadata)
+	ctx, err := cmdtesting.RunCommand(c, charmcmd.NewLintCommand(), s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No issues found.\n")
+}
+
+func (s *LintSuite) TestCommandIssues(c *gc.C) {
+	path := s.writeFile(c, "metadata.yaml", "name: mycharm\nsummary: A charm.\n")
+	ctx, err := cmdtesting.RunCommand(c, charmcmd.NewLintCommand(), s.dir)
+	c.Assert(err, gc.ErrorMatches, `found 1 issue\(s\) in charm`)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, path+": description is missing\n")
+}
+
+func (s *LintSuite) TestCommandNoArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, charmcmd.NewLintCommand())
+	c.Assert(err, gc.ErrorMatches, `no charm directory specified`)
+}
@@ -0,0 +1,362 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmcmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/charm/v7"
+	charmresource "github.com/juju/charm/v7/resource"
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+
+	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	"github.com/juju/juju/core/lxdprofile"
+)
+
+const (
+	metadataFile   = "metadata.yaml"
+	configFile     = "config.yaml"
+	actionsFile    = "actions.yaml"
+	lxdProfileFile = "lxd-profile.yaml"
+)
+
+// Issue describes a single problem found in a charm.
+type Issue struct {
+	// File is the path of the file containing the problem.
+	File string `yaml:"file" json:"file"`
+
+	// Line is the line of the file on which the problem was found,
+	// or zero if it applies to the file as a whole.
+	Line int `yaml:"line,omitempty" json:"line,omitempty"`
+
+	// Message describes the problem.
+	Message string `yaml:"message" json:"message"`
+}
+
+// String returns the issue in the form file:line: message.
+func (i Issue) String() string {
+	if i.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", i.File, i.Line, i.Message)
+	}
+	return fmt.Sprintf("%s: %s", i.File, i.Message)
+}
+
+// LintCharm validates the charm in the given directory, along with any
+// kubernetes pod spec files, without contacting a controller. All of the
+// problems found are returned, ordered by file and line. An error is
+// returned only if the charm could not be read at all.
+func LintCharm(charmDir string, podSpecFiles []string) ([]Issue, error) {
+	info, err := os.Stat(charmDir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !info.IsDir() {
+		return nil, errors.NotValidf("charm path %q: not a directory", charmDir)
+	}
+	l := &linter{dir: charmDir}
+	l.lintMetadata()
+	l.lintConfig()
+	l.lintActions()
+	l.lintLXDProfile()
+	for _, path := range podSpecFiles {
+		l.lintPodSpec(path)
+	}
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		if l.issues[i].File != l.issues[j].File {
+			return l.issues[i].File < l.issues[j].File
+		}
+		return l.issues[i].Line < l.issues[j].Line
+	})
+	return l.issues, nil
+}
+
+type linter struct {
+	dir    string
+	issues []Issue
+}
+
+func (l *linter) addIssue(file string, line int, format string, args ...interface{}) {
+	l.issues = append(l.issues, Issue{
+		File:    file,
+		Line:    line,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// readYAML reads the named file from the charm directory and unmarshals
+// it into a map. If the file does not exist, ok is false and no issue is
+// recorded unless the file is required.
+func (l *linter) readYAML(name string, required bool) (path string, data []byte, raw map[interface{}]interface{}, ok bool) {
+	path = filepath.Join(l.dir, name)
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		if required {
+			l.addIssue(path, 0, "file is missing")
+		}
+		return path, nil, nil, false
+	} else if err != nil {
+		l.addIssue(path, 0, "cannot read file: %v", err)
+		return path, nil, nil, false
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		l.addIssue(path, yamlErrorLine(err), "%s", yamlErrorMessage(err))
+		return path, data, nil, false
+	}
+	return path, data, raw, true
+}
+
+func (l *linter) lintMetadata() {
+	path, data, raw, ok := l.readYAML(metadataFile, true)
+	if !ok {
+		return
+	}
+	before := len(l.issues)
+
+	switch name, _ := raw["name"].(string); {
+	case name == "":
+		l.addIssue(path, 0, "name is missing")
+	case !charm.IsValidName(name):
+		l.addIssue(path, yamlLine(data, "name"), "invalid charm name %q", name)
+	}
+	for _, key := range []string{"summary", "description"} {
+		if value, _ := raw[key].(string); strings.TrimSpace(value) == "" {
+			l.addIssue(path, yamlLine(data, key), "%s is missing", key)
+		}
+	}
+	if series, ok := raw["series"].([]interface{}); ok {
+		for _, s := range series {
+			if name, _ := s.(string); !charm.IsValidSeries(name) {
+				l.addIssue(path, yamlLine(data, "series"), "invalid series %v", s)
+			}
+		}
+	}
+	if resources, ok := raw["resources"].(map[interface{}]interface{}); ok {
+		for name, value := range resources {
+			name := fmt.Sprint(name)
+			if err := validateResource(name, value); err != nil {
+				l.addIssue(path, yamlLine(data, "resources", name), "resource %q: %v", name, err)
+			}
+		}
+	}
+
+	// Only report the error from the charm package if none of the
+	// checks above has found the problem already.
+	if _, err := charm.ReadMeta(bytes.NewReader(data)); err != nil && len(l.issues) == before {
+		msg := err.Error()
+		l.addIssue(path, metadataErrorLine(data, msg), "%s", msg)
+	}
+}
+
+func validateResource(name string, value interface{}) error {
+	meta := charmresource.Meta{Name: name}
+	if value != nil {
+		attrs, ok := value.(map[interface{}]interface{})
+		if !ok {
+			return errors.Errorf("expected map, got %T", value)
+		}
+		for key, v := range attrs {
+			s, ok := v.(string)
+			if !ok {
+				return errors.Errorf("%v: expected string, got %T", key, v)
+			}
+			switch key {
+			case "type":
+				t, err := charmresource.ParseType(s)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				meta.Type = t
+			case "filename":
+				meta.Path = s
+			case "description":
+				meta.Description = s
+			default:
+				return errors.Errorf("unknown attribute %q", key)
+			}
+		}
+	}
+	if meta.Type == 0 {
+		meta.Type = charmresource.TypeFile
+	}
+	return meta.Validate()
+}
+
+func (l *linter) lintConfig() {
+	path, data, raw, ok := l.readYAML(configFile, false)
+	if !ok {
+		return
+	}
+	options, ok := raw["options"].(map[interface{}]interface{})
+	if !ok {
+		if _, err := charm.ReadConfig(bytes.NewReader(data)); err != nil {
+			l.addIssue(path, yamlLine(data, "options"), "%v", err)
+		}
+		return
+	}
+	// Validate each option separately, so that all of the invalid
+	// options are reported rather than just the first.
+	for name, option := range options {
+		name := fmt.Sprint(name)
+		single, err := yaml.Marshal(map[string]interface{}{
+			"options": map[string]interface{}{name: option},
+		})
+		if err != nil {
+			l.addIssue(path, yamlLine(data, "options", name), "option %q: %v", name, err)
+			continue
+		}
+		if _, err := charm.ReadConfig(bytes.NewReader(single)); err != nil {
+			l.addIssue(path, yamlLine(data, "options", name), "%s", yamlErrorMessage(err))
+		}
+	}
+}
+
+func (l *linter) lintActions() {
+	path, data, raw, ok := l.readYAML(actionsFile, false)
+	if !ok {
+		return
+	}
+	// Validate each action separately, so that all of the invalid
+	// actions are reported rather than just the first.
+	for name, spec := range raw {
+		name := fmt.Sprint(name)
+		single, err := yaml.Marshal(map[string]interface{}{name: spec})
+		if err != nil {
+			l.addIssue(path, yamlLine(data, name), "action %q: %v", name, err)
+			continue
+		}
+		if _, err := charm.ReadActionsYaml(bytes.NewReader(single)); err != nil {
+			l.addIssue(path, yamlLine(data, name), "action %q: %s", name, yamlErrorMessage(err))
+		}
+	}
+}
+
+// lxdProfiler adapts a charm's LXD profile for validation by the
+// lxdprofile package, as the controller does when deploying.
+type lxdProfiler struct {
+	profile *charm.LXDProfile
+}
+
+// LXDProfile implements lxdprofile.LXDProfiler.
+func (p lxdProfiler) LXDProfile() lxdprofile.LXDProfile {
+	return p.profile
+}
+
+func (l *linter) lintLXDProfile() {
+	path, data, _, ok := l.readYAML(lxdProfileFile, false)
+	if !ok {
+		return
+	}
+	profile, err := charm.ReadLXDProfile(bytes.NewReader(data))
+	if err != nil {
+		l.addIssue(path, yamlErrorLine(err), "%s", yamlErrorMessage(err))
+		return
+	}
+	// Validate each config key and device separately, so that all of
+	// the invalid entries are reported rather than just the first.
+	for key, value := range profile.Config {
+		single := &charm.LXDProfile{Config: map[string]string{key: value}}
+		if err := lxdprofile.ValidateLXDProfile(lxdProfiler{single}); err != nil {
+			l.addIssue(path, yamlLine(data, "config", key), "%v", err)
+		}
+	}
+	for name, device := range profile.Devices {
+		single := &charm.LXDProfile{Devices: map[string]map[string]string{name: device}}
+		if err := lxdprofile.ValidateLXDProfile(lxdProfiler{single}); err != nil {
+			l.addIssue(path, yamlLine(data, "devices", name), "%v", err)
+		}
+	}
+}
+
+func (l *linter) lintPodSpec(path string) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		l.addIssue(path, 0, "cannot read file: %v", err)
+		return
+	}
+	if _, err := k8sspecs.ParsePodSpec(string(data)); err != nil {
+		l.addIssue(path, yamlErrorLine(err), "%s", yamlErrorMessage(err))
+	}
+}
+
+var yamlLineRE = regexp.MustCompile(`line (\d+): `)
+
+// yamlErrorLine returns the line number reported in a YAML parsing
+// error, or zero if there is none.
+func yamlErrorLine(err error) int {
+	match := yamlLineRE.FindStringSubmatch(err.Error())
+	if match == nil {
+		return 0
+	}
+	line, _ := strconv.Atoi(match[1])
+	return line
+}
+
+// yamlErrorMessage returns the message of a YAML parsing error without
+// the line number, which is reported separately.
+func yamlErrorMessage(err error) string {
+	msg := yamlLineRE.ReplaceAllString(err.Error(), "")
+	return strings.Join(strings.Fields(msg), " ")
+}
+
+var metadataPathRE = regexp.MustCompile(`^metadata: ([^ :]+): `)
+
+// metadataErrorLine returns the line of the metadata attribute named by
+// a schema error from the charm package, or zero if it cannot be found.
+func metadataErrorLine(data []byte, msg string) int {
+	match := metadataPathRE.FindStringSubmatch(msg)
+	if match == nil {
+		return 0
+	}
+	return yamlLine(data, strings.Split(match[1], ".")...)
+}
+
+// yamlLine returns the line on which the value at the given path of
+// mapping keys is defined in a YAML document. If the full path cannot be
+// found, the line of the longest prefix found is returned, or zero if
+// none of the path is found. Only block-style mappings are understood,
+// which is how charm authors write them.
+func yamlLine(data []byte, path ...string) int {
+	found := 0
+	depth := 0
+	parentIndent, childIndent := -1, -1
+	for i, line := range strings.Split(string(data), "\n") {
+		if depth == len(path) {
+			break
+		}
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(trimmed)
+		if indent <= parentIndent {
+			// We've left the block of the last key found.
+			break
+		}
+		if childIndent == -1 {
+			childIndent = indent
+		}
+		if indent != childIndent {
+			continue
+		}
+		key := path[depth]
+		for _, prefix := range []string{key, strconv.Quote(key), "'" + key + "'"} {
+			if strings.HasPrefix(trimmed, prefix+":") {
+				found = i + 1
+				depth++
+				parentIndent, childIndent = indent, -1
+				break
+			}
+		}
+	}
+	return found
+}
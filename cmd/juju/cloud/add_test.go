@@ -550,6 +550,7 @@ func (*addSuite) TestInteractive(c *gc.C) {
 		"  maas\n"+
 		"  manual\n"+
 		"  openstack\n"+
+		"  proxmox\n"+
 		"  vsphere\n"+
 		"\n"+
 		"Select cloud type: \n",
@@ -635,6 +636,7 @@ Cloud Types
   maas
   manual
   openstack
+  proxmox
   vsphere
 
 Select cloud type: 
@@ -812,6 +814,7 @@ func (*addSuite) TestInteractiveExistingNameNoOverride(c *gc.C) {
 		"  maas\n"+
 		"  manual\n"+
 		"  openstack\n"+
+		"  proxmox\n"+
 		"  vsphere\n\n"+
 		"Select cloud type: \n"+
 		"Enter a name for your manual cloud: \n"+
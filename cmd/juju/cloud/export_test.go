@@ -62,6 +62,16 @@ func NewShowCloudCommandForTest(store jujuclient.ClientStore, cloudAPI func() (s
 	}
 }
 
+func NewShowCloudCommandWithCapacityForTest(
+	store jujuclient.ClientStore,
+	cloudAPI func() (showCloudAPI, error),
+	cloudCapacity func(*cmd.Context, jujucloud.Cloud) ([]environs.ZoneCapacity, error),
+) *showCloudCommand {
+	command := NewShowCloudCommandForTest(store, cloudAPI)
+	command.cloudCapacityFunc = cloudCapacity
+	return command
+}
+
 func NewRemoveCloudCommandForTest(store jujuclient.ClientStore, cloudAPI func() (removeCloudAPI, error)) *removeCloudCommand {
 	return &removeCloudCommand{
 		OptionalControllerCommand: modelcmd.OptionalControllerCommand{Store: store},
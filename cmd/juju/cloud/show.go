@@ -17,6 +17,9 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/jujuclient"
 )
 
@@ -26,11 +29,14 @@ type showCloudCommand struct {
 
 	CloudName string
 
-	includeConfig bool
+	includeConfig   bool
+	includeCapacity bool
 
-	showCloudAPIFunc func() (showCloudAPI, error)
+	showCloudAPIFunc  func() (showCloudAPI, error)
+	cloudCapacityFunc func(*cmd.Context, jujucloud.Cloud) ([]environs.ZoneCapacity, error)
 
 	configDisplayed bool
+	controllerCloud *jujucloud.Cloud
 }
 
 var showCloudDoc = `
@@ -41,6 +47,12 @@ options.
 If ‘--include-config’ is used, additional configuration (key, type, and
 description) specific to the cloud are displayed if available.
 
+If ‘--include-capacity’ is used, the cores, memory and number of instances
+of each availability zone of the cloud, such as each member of a LXD
+cluster, are displayed if the cloud's provider can report them. The
+capacity is queried directly from the cloud using this client's
+credential for the cloud.
+
 Use --controller option to show a cloud from a controller.
 
 Use --client option to show a cloud known on this client.
@@ -52,6 +64,7 @@ Examples:
     juju show-cloud myopenstack --controller mycontroller
     juju show-cloud myopenstack --client
     juju show-cloud myopenstack --client --controller mycontroller
+    juju show-cloud mylxdcluster --client --include-capacity
 
 See also:
     clouds
@@ -74,6 +87,7 @@ func NewShowCloudCommand() cmd.Command {
 		},
 	}
 	c.showCloudAPIFunc = c.cloudAPI
+	c.cloudCapacityFunc = c.cloudCapacity
 	return modelcmd.WrapBase(c)
 }
 
@@ -92,6 +106,7 @@ func (c *showCloudCommand) SetFlags(f *gnuflag.FlagSet) {
 		"yaml": cmd.FormatYaml,
 	})
 	f.BoolVar(&c.includeConfig, "include-config", false, "Print available config option details specific to the specified cloud")
+	f.BoolVar(&c.includeCapacity, "include-capacity", false, "Print the capacity of each availability zone of the specified cloud")
 }
 
 func (c *showCloudCommand) Init(args []string) error {
@@ -178,6 +193,13 @@ func (c *showCloudCommand) Run(ctxt *cmd.Context) error {
 		}
 	}
 
+	if c.includeCapacity {
+		if err := c.displayCapacity(ctxt); err != nil {
+			ctxt.Infof("ERROR %v", err)
+			displayErr = cmd.ErrSilent
+		}
+	}
+
 	return displayErr
 }
 
@@ -220,6 +242,7 @@ func (c *showCloudCommand) getControllerCloud() (*CloudDetails, error) {
 	if err != nil {
 		return nil, err
 	}
+	c.controllerCloud = &controllerCloud
 	cloud := makeCloudDetails(c.Store, controllerCloud)
 	return cloud, nil
 }
@@ -236,6 +259,100 @@ func (c *showCloudCommand) getLocalCloud() (*CloudDetails, error) {
 	return cloud, nil
 }
 
+// displayCapacity displays the capacity of each availability zone of the
+// cloud, preferring the client's definition of the cloud to the
+// controller's.
+func (c *showCloudCommand) displayCapacity(ctxt *cmd.Context) error {
+	var aCloud *jujucloud.Cloud
+	if c.Client {
+		var err error
+		aCloud, err = common.CloudByName(c.CloudName)
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+	if aCloud == nil {
+		aCloud = c.controllerCloud
+	}
+	if aCloud == nil {
+		return errors.NotFoundf("cloud %q", c.CloudName)
+	}
+	capacity, err := c.cloudCapacityFunc(ctxt, *aCloud)
+	if err != nil {
+		return errors.Annotatef(err, "getting capacity of cloud %q", c.CloudName)
+	}
+	fmt.Fprintln(ctxt.Stdout, fmt.Sprintf("\nCapacity of cloud %q:\n", c.CloudName))
+	return c.out.Write(ctxt, makeCapacityDetails(capacity))
+}
+
+// cloudCapacity queries the capacity of the cloud's availability zones,
+// using the client's credential for the cloud.
+func (c *showCloudCommand) cloudCapacity(ctxt *cmd.Context, aCloud jujucloud.Cloud) ([]environs.ZoneCapacity, error) {
+	provider, err := environs.Provider(aCloud.Type)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	reporter, ok := provider.(environs.CloudCapacityReporter)
+	if !ok {
+		return nil, errors.NotSupportedf("reporting the capacity of %s clouds", aCloud.Type)
+	}
+
+	credential, _, regionName, err := modelcmd.GetCredentials(ctxt, c.Store, modelcmd.GetCredentialsParams{
+		Cloud: aCloud,
+	})
+	if errors.IsNotFound(err) {
+		// Credentials for some clouds, such as localhost,
+		// are detected rather than added.
+		detected, detectErr := modelcmd.DetectCredential(aCloud.Name, provider)
+		if detectErr != nil {
+			return nil, errors.Trace(detectErr)
+		}
+		for _, detectedCredential := range detected.AuthCredentials {
+			detectedCredential := detectedCredential
+			credential = &detectedCredential
+		}
+		err = nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if regionName == "" && len(aCloud.Regions) > 0 {
+		regionName = aCloud.Regions[0].Name
+	}
+	spec, err := environscloudspec.MakeCloudSpec(aCloud, regionName, credential)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return reporter.CloudCapacity(context.NewCloudCallContext(), spec)
+}
+
+// ZoneCapacityDetails holds the capacity of an availability zone.
+type ZoneCapacityDetails struct {
+	Status     string `yaml:"status" json:"status"`
+	Cores      uint64 `yaml:"cores,omitempty" json:"cores,omitempty"`
+	Memory     string `yaml:"memory,omitempty" json:"memory,omitempty"`
+	MemoryUsed string `yaml:"memory-used,omitempty" json:"memory-used,omitempty"`
+	Instances  int    `yaml:"instances" json:"instances"`
+}
+
+func makeCapacityDetails(capacity []environs.ZoneCapacity) map[string]ZoneCapacityDetails {
+	result := make(map[string]ZoneCapacityDetails)
+	for _, zone := range capacity {
+		details := ZoneCapacityDetails{
+			Status:    "unavailable",
+			Instances: zone.Instances,
+		}
+		if zone.Available {
+			details.Status = "available"
+			details.Cores = zone.CpuCores
+			details.Memory = fmt.Sprintf("%dM", zone.Mem)
+			details.MemoryUsed = fmt.Sprintf("%dM", zone.MemUsed)
+		}
+		result[zone.Zone] = details
+	}
+	return result
+}
+
 // RegionDetails holds region details.
 type RegionDetails struct {
 	Name             string `yaml:"-" json:"-"`
@@ -7,7 +7,9 @@ import (
 	"io/ioutil"
 	"strings"
 
+	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -15,6 +17,7 @@ import (
 
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/juju/cloud"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/jujuclient"
 	_ "github.com/juju/juju/provider/all"
@@ -436,3 +439,77 @@ func (api *fakeShowCloudAPI) Cloud(tag names.CloudTag) (jujucloud.Cloud, error)
 	api.AddCall("Cloud", tag)
 	return api.cloud, api.NextErr()
 }
+
+func (s *showSuite) TestShowWithCapacity(c *gc.C) {
+	var queried jujucloud.Cloud
+	command := cloud.NewShowCloudCommandWithCapacityForTest(
+		s.store,
+		func() (cloud.ShowCloudAPI, error) {
+			c.Fail()
+			return s.api, nil
+		},
+		func(_ *cmd.Context, aCloud jujucloud.Cloud) ([]environs.ZoneCapacity, error) {
+			queried = aCloud
+			return []environs.ZoneCapacity{{
+				Zone:      "node01",
+				Available: true,
+				CpuCores:  4,
+				Mem:       8192,
+				MemUsed:   2048,
+				Instances: 3,
+			}, {
+				Zone: "node02",
+			}}, nil
+		})
+	ctx, err := cmdtesting.RunCommand(c, command, "localhost", "--client", "--include-capacity")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(queried.Type, gc.Equals, "lxd")
+	out := cmdtesting.Stdout(ctx)
+	c.Assert(out, jc.HasSuffix, `
+Capacity of cloud "localhost":
+
+node01:
+  status: available
+  cores: 4
+  memory: 8192M
+  memory-used: 2048M
+  instances: 3
+node02:
+  status: unavailable
+  instances: 0
+`)
+}
+
+func (s *showSuite) TestShowWithCapacityControllerCloud(c *gc.C) {
+	s.setupRemoteCloud("beehive")
+	var queried jujucloud.Cloud
+	command := cloud.NewShowCloudCommandWithCapacityForTest(
+		s.store,
+		func() (cloud.ShowCloudAPI, error) {
+			return s.api, nil
+		},
+		func(_ *cmd.Context, aCloud jujucloud.Cloud) ([]environs.ZoneCapacity, error) {
+			queried = aCloud
+			return nil, nil
+		})
+	_, err := cmdtesting.RunCommand(c, command, "beehive", "-c", "mycontroller", "--include-capacity")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(queried.Name, gc.Equals, "beehive")
+	c.Assert(queried.Endpoint, gc.Equals, "http://myopenstack")
+}
+
+func (s *showSuite) TestShowWithCapacityError(c *gc.C) {
+	command := cloud.NewShowCloudCommandWithCapacityForTest(
+		s.store,
+		func() (cloud.ShowCloudAPI, error) {
+			c.Fail()
+			return s.api, nil
+		},
+		func(*cmd.Context, jujucloud.Cloud) ([]environs.ZoneCapacity, error) {
+			return nil, errors.NotSupportedf("reporting the capacity of ec2 clouds")
+		})
+	ctx, err := cmdtesting.RunCommand(c, command, "aws-china", "--client", "--include-capacity")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains,
+		`ERROR getting capacity of cloud "aws-china": reporting the capacity of ec2 clouds not supported`)
+}
@@ -104,6 +104,9 @@ var expectedCommands = []string{
 	"config-get",
 	"credential-get",
 	"goal-state",
+	"health-check-delete",
+	"health-check-get",
+	"health-check-set",
 	"is-leader",
 	"juju-log",
 	"juju-reboot",
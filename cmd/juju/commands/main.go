@@ -285,6 +285,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(crossmodel.NewShowOfferedEndpointCommand())
 	r.Register(crossmodel.NewListEndpointsCommand())
 	r.Register(crossmodel.NewFindEndpointsCommand())
+	r.Register(crossmodel.NewCreateOfferTokenCommand())
+	r.Register(crossmodel.NewListOfferTokensCommand())
+	r.Register(crossmodel.NewRevokeOfferTokenCommand())
 	r.Register(application.NewConsumeCommand())
 	r.Register(application.NewSuspendRelationCommand())
 	r.Register(application.NewResumeRelationCommand())
@@ -368,6 +371,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(machine.NewRemoveCommand())
 	r.Register(machine.NewListMachinesCommand())
 	r.Register(machine.NewShowMachineCommand())
+	r.Register(machine.NewShowInstanceTypesCommand())
 	r.Register(machine.NewUpgradeSeriesCommand())
 
 	// Manage model
@@ -425,8 +429,11 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewApplicationGetConstraintsCommand())
 	r.Register(application.NewApplicationSetConstraintsCommand())
 	r.Register(application.NewBundleDiffCommand())
+	r.Register(application.NewApplyCommand())
 	r.Register(application.NewShowApplicationCommand())
 	r.Register(application.NewShowUnitCommand())
+	r.Register(application.NewShowRemoteRelationCommand())
+	r.Register(application.NewAutoscaleHistoryCommand())
 
 	// Operation protection commands
 	r.Register(block.NewDisableCommand())
@@ -460,7 +467,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(subnet.NewListCommand())
 
 	// Manage controllers
-	r.Register(controller.NewAddModelCommand())
+	r.Register(controller.NewAddModelCommand(func() cmd.Command {
+		return application.NewDeployCommand()
+	}))
 	r.Register(controller.NewDestroyCommand())
 	r.Register(controller.NewListModelsCommand())
 	r.Register(controller.NewKillCommand())
@@ -470,6 +479,10 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewConfigCommand())
+	r.Register(controller.NewCreateModelTemplateCommand())
+	r.Register(controller.NewUpdateModelTemplateCommand())
+	r.Register(controller.NewModelTemplatesCommand())
+	r.Register(controller.NewDetectLeaksCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -479,10 +479,12 @@ var commandNames = []string{
 	"add-user",
 	"agree",
 	"agreements",
+	"apply",
 	"attach",
 	"attach-resource",
 	"attach-storage",
 	"autoload-credentials",
+	"autoscale-history",
 	"backups",
 	"bind",
 	"bootstrap",
@@ -499,6 +501,8 @@ var commandNames = []string{
 	"controller-config",
 	"controllers",
 	"create-backup",
+	"create-model-template",
+	"create-offer-token",
 	"create-storage-pool",
 	"create-wallet",
 	"credentials",
@@ -513,6 +517,7 @@ var commandNames = []string{
 	"destroy-controller",
 	"destroy-model",
 	"detach-storage",
+	"detect-leaks",
 	"diff-bundle",
 	"disable-command",
 	"disable-user",
@@ -550,7 +555,9 @@ var commandNames = []string{
 	"list-disabled-commands",
 	"list-firewall-rules",
 	"list-machines",
+	"list-model-templates",
 	"list-models",
+	"list-offer-tokens",
 	"list-offers",
 	"list-payloads",
 	"list-plans",
@@ -571,9 +578,11 @@ var commandNames = []string{
 	"model-config",
 	"model-default",
 	"model-defaults",
+	"model-templates",
 	"models",
 	"move-to-space",
 	"offer",
+	"offer-tokens",
 	"offers",
 	"payloads",
 	"plans",
@@ -607,6 +616,7 @@ var commandNames = []string{
 	"retry-provisioning",
 	"revoke",
 	"revoke-cloud",
+	"revoke-offer-token",
 	"run",
 	"scale-application",
 	"scp",
@@ -627,9 +637,11 @@ var commandNames = []string{
 	"show-controller",
 	"show-credential",
 	"show-credentials",
+	"show-instance-types",
 	"show-machine",
 	"show-model",
 	"show-offer",
+	"show-remote-relation",
 	"show-status",
 	"show-status-log",
 	"show-storage",
@@ -654,6 +666,7 @@ var commandNames = []string{
 	"unregister",
 	"update-cloud",
 	"update-k8s",
+	"update-model-template",
 	"update-public-clouds",
 	"update-credential",
 	"update-credentials",
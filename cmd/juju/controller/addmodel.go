@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -33,7 +34,9 @@ import (
 )
 
 // NewAddModelCommand returns a command to add a model.
-func NewAddModelCommand() cmd.Command {
+// The input function returns the command used to deploy the bundle of
+// any model template that the model is created from.
+func NewAddModelCommand(newDeployCommand func() cmd.Command) cmd.Command {
 	command := &addModelCommand{
 		newAddModelAPI: func(caller base.APICallCloser) AddModelAPI {
 			return modelmanager.NewClient(caller)
@@ -42,6 +45,7 @@ func NewAddModelCommand() cmd.Command {
 			return cloudapi.NewClient(caller)
 		},
 		providerRegistry: environs.GlobalProviderRegistry(),
+		deployBundle:     bundleDeployer(newDeployCommand),
 	}
 	command.CanClearCurrentModel = true
 	return modelcmd.WrapController(command)
@@ -54,12 +58,14 @@ type addModelCommand struct {
 	newAddModelAPI   func(base.APICallCloser) AddModelAPI
 	newCloudAPI      func(base.APICallCloser) CloudAPI
 	providerRegistry environs.ProviderRegistry
+	deployBundle     func(ctx *cmd.Context, model, bundle string) error
 
 	Name           string
 	Owner          string
 	CredentialName string
 	CloudRegion    string
 	Config         common.ConfigFlag
+	Template       string
 	noSwitch       bool
 }
 
@@ -91,6 +97,15 @@ You may also supply model-specific configuration as well as a
 cloud/region to which this model will be deployed. The cloud/region and credentials
 are the ones used to create any future resources within the model.
 
+A model may be created from a model template, which holds the config,
+default constraints, storage pools, spaces and user access that the model
+is created with. The template's config is overridden by any config given
+with --config. If the template includes a bundle, the bundle is deployed
+once the model has been created, and the model is destroyed again if the
+bundle can not be deployed. Templates are managed by controller
+administrators with "juju create-model-template" and
+"juju update-model-template", and listed with "juju model-templates".
+
 If no cloud/region is specified, then the model will be deployed to
 the same cloud/region as the controller model. If a region is specified
 without a cloud qualifier, then it is assumed to be in the same cloud
@@ -103,6 +118,11 @@ Examples:
     juju add-model mymodel aws/us-east-1
     juju add-model mymodel --config my-config.yaml --config image-stream=daily
     juju add-model mymodel --credential credential_name --config authorized-keys="ssh-rsa ..."
+    juju add-model mymodel --template web
+
+See also:
+    model-templates
+    create-model-template
 `
 
 func (c *addModelCommand) Info() *cmd.Info {
@@ -120,6 +140,7 @@ func (c *addModelCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.CredentialName, "credential", "", "Credential used to add the model")
 	f.Var(&c.Config, "config", "Path to YAML model configuration file or individual options (--config config.yaml [--config key=value ...])")
 	f.BoolVar(&c.noSwitch, "no-switch", false, "Do not switch to the newly created model")
+	f.StringVar(&c.Template, "template", "", "The model template to create the model from")
 }
 
 func (c *addModelCommand) Init(args []string) error {
@@ -149,6 +170,13 @@ type AddModelAPI interface {
 		cloudCredential names.CloudCredentialTag,
 		config map[string]interface{},
 	) (base.ModelInfo, error)
+	CreateModelFromTemplate(
+		template, name, owner, cloudName, cloudRegion string,
+		cloudCredential names.CloudCredentialTag,
+		config map[string]interface{},
+	) (base.ModelInfo, error)
+	ListModelTemplates() ([]params.ModelTemplate, error)
+	DestroyModel(tag names.ModelTag, destroyStorage, force *bool, maxWait *time.Duration) error
 }
 
 type CloudAPI interface {
@@ -246,7 +274,20 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 	}
 
 	addModelClient := c.newAddModelAPI(root)
-	model, err := addModelClient.CreateModel(c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs)
+	var (
+		template params.ModelTemplate
+		model    base.ModelInfo
+	)
+	if c.Template != "" {
+		if template, err = findModelTemplate(addModelClient, c.Template); err != nil {
+			return errors.Trace(err)
+		}
+		model, err = addModelClient.CreateModelFromTemplate(
+			c.Template, c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs,
+		)
+	} else {
+		model, err = addModelClient.CreateModel(c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs)
+	}
 	if err != nil {
 		if params.IsCodeUnauthorized(err) {
 			common.PermissionsMessage(ctx.Stderr, "add a model")
@@ -306,7 +347,29 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 	// "Added '<model>' model [on <cloud>/<region>] [with credential '<credential>'] for user '<user namePart>'"
 	ctx.Infof(messageFormat, messageArgs...)
 
-	if _, ok := attrs[config.AuthorizedKeysKey]; !ok {
+	if template.Bundle != "" {
+		ctx.Infof("Deploying bundle %q from model template %q", template.Bundle, template.Name)
+		modelName := fmt.Sprintf("%s:%s/%s", controllerName, modelOwner, c.Name)
+		if err := c.deployBundle(ctx, modelName, template.Bundle); err != nil {
+			// The model is only useful with the template applied, so
+			// rather than leave it half deployed, remove it again.
+			// Any error has already been reported by the deploy command.
+			ctx.Infof("Destroying model %q as its bundle could not be deployed", c.Name)
+			destroyStorage := true
+			if err := addModelClient.DestroyModel(names.NewModelTag(model.UUID), &destroyStorage, nil, nil); err != nil {
+				return errors.Annotatef(err, "cannot destroy model %q", c.Name)
+			}
+			if modelOwner == accountDetails.User {
+				if err := store.RemoveModel(controllerName, c.Name); err != nil && !errors.IsNotFound(err) {
+					return errors.Trace(err)
+				}
+			}
+			return cmd.ErrSilent
+		}
+	}
+
+	_, inTemplate := template.Config[config.AuthorizedKeysKey]
+	if _, ok := attrs[config.AuthorizedKeysKey]; !ok && !inTemplate {
 		// It is not an error to have no authorized-keys when adding a
 		// model, though this should never happen since we generate
 		// juju-specific SSH keys.
@@ -318,6 +381,32 @@ before "juju ssh", "juju scp", or "juju debug-hooks" will work.`)
 	return nil
 }
 
+// findModelTemplate returns the model template with the given name.
+func findModelTemplate(client AddModelAPI, name string) (params.ModelTemplate, error) {
+	templates, err := client.ListModelTemplates()
+	if err != nil {
+		return params.ModelTemplate{}, errors.Trace(err)
+	}
+	for _, template := range templates {
+		if template.Name == name {
+			return template, nil
+		}
+	}
+	return params.ModelTemplate{}, errors.NotFoundf("model template %q", name)
+}
+
+// bundleDeployer returns a function that deploys a bundle into the
+// named model with the deploy command returned by the input function.
+func bundleDeployer(newDeployCommand func() cmd.Command) func(ctx *cmd.Context, model, bundle string) error {
+	return func(ctx *cmd.Context, model, bundle string) error {
+		code := cmd.Main(newDeployCommand(), ctx, []string{"-m", model, bundle})
+		if code == 0 {
+			return nil
+		}
+		return cmd.ErrSilent
+	}
+}
+
 func (c *addModelCommand) getCloudRegion(cloudClient CloudAPI) (cloudTag names.CloudTag, cloud jujucloud.Cloud, cloudRegion string, err error) {
 	fail := func(err error) (names.CloudTag, jujucloud.Cloud, string, error) {
 		return names.CloudTag{}, jujucloud.Cloud{}, "", err
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -694,6 +695,71 @@ func (s *AddModelSuite) TestNoEnvCacheOtherUser(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *AddModelSuite) TestAddModelFromTemplate(c *gc.C) {
+	s.fakeAddModelAPI.templates = []params.ModelTemplate{{
+		Name:   "web",
+		Config: map[string]interface{}{"authorized-keys": "ssh-rsa ..."},
+		Bundle: "cs:wordpress-simple",
+	}}
+	command, addModel := controller.NewAddModelCommandForTest(
+		&fakeAPIConnection{},
+		s.fakeAddModelAPI,
+		s.fakeCloudAPI,
+		s.store,
+		s.fakeProviderRegistry,
+	)
+	var deployed []string
+	addModel.SetDeployBundle(func(ctx *cmd.Context, model, bundle string) error {
+		deployed = append(deployed, model, bundle)
+		return nil
+	})
+	ctx, err := cmdtesting.RunCommand(c, command, "test", "--template", "web")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAddModelAPI.template, gc.Equals, "web")
+	c.Assert(deployed, jc.DeepEquals, []string{"test-master:bob/test", "cs:wordpress-simple"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+Added 'test' model for user 'bob'
+Deploying bundle "cs:wordpress-simple" from model template "web"
+`[1:])
+}
+
+func (s *AddModelSuite) TestAddModelFromTemplateDeployFails(c *gc.C) {
+	s.fakeAddModelAPI.templates = []params.ModelTemplate{{
+		Name:   "web",
+		Config: map[string]interface{}{"authorized-keys": "ssh-rsa ..."},
+		Bundle: "cs:wordpress-simple",
+	}}
+	command, addModel := controller.NewAddModelCommandForTest(
+		&fakeAPIConnection{},
+		s.fakeAddModelAPI,
+		s.fakeCloudAPI,
+		s.store,
+		s.fakeProviderRegistry,
+	)
+	addModel.SetDeployBundle(func(ctx *cmd.Context, model, bundle string) error {
+		return cmd.ErrSilent
+	})
+	ctx, err := cmdtesting.RunCommand(c, command, "test", "--template", "web")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(s.fakeAddModelAPI.destroyed, jc.DeepEquals, []names.ModelTag{
+		names.NewModelTag(s.fakeAddModelAPI.model.UUID),
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+Added 'test' model for user 'bob'
+Deploying bundle "cs:wordpress-simple" from model template "web"
+Destroying model "test" as its bundle could not be deployed
+`[1:])
+
+	_, err = s.store.ModelByName("test-master", "bob/test")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *AddModelSuite) TestAddModelFromTemplateNotFound(c *gc.C) {
+	_, err := s.run(c, "test", "--template", "web")
+	c.Assert(err, gc.ErrorMatches, `model template "web" not found`)
+	c.Assert(s.fakeAddModelAPI.template, gc.Equals, "")
+}
+
 // fakeAddClient is used to mock out the behavior of the real
 // AddModel command.
 type fakeAddClient struct {
@@ -702,6 +768,9 @@ type fakeAddClient struct {
 	cloudRegion     string
 	cloudCredential names.CloudCredentialTag
 	config          map[string]interface{}
+	template        string
+	templates       []params.ModelTemplate
+	destroyed       []names.ModelTag
 	err             error
 	model           base.ModelInfo
 }
@@ -724,6 +793,20 @@ func (f *fakeAddClient) CreateModel(name, owner, cloudName, cloudRegion string,
 	return f.model, nil
 }
 
+func (f *fakeAddClient) CreateModelFromTemplate(template, name, owner, cloudName, cloudRegion string, cloudCredential names.CloudCredentialTag, config map[string]interface{}) (base.ModelInfo, error) {
+	f.template = template
+	return f.CreateModel(name, owner, cloudName, cloudRegion, cloudCredential, config)
+}
+
+func (f *fakeAddClient) ListModelTemplates() ([]params.ModelTemplate, error) {
+	return f.templates, nil
+}
+
+func (f *fakeAddClient) DestroyModel(tag names.ModelTag, destroyStorage, force *bool, maxWait *time.Duration) error {
+	f.destroyed = append(f.destroyed, tag)
+	return nil
+}
+
 // TODO(wallyworld) - improve this stub and add test asserts
 type fakeCloudAPI struct {
 	clouds map[names.CloudTag]cloud.Cloud
@@ -0,0 +1,177 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/leakdetector"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// DetectLeaksAPI defines the methods on the leak detector API that the
+// detect-leaks command calls.
+type DetectLeaksAPI interface {
+	Close() error
+	DetectLeaks(cleanup bool) ([]leakdetector.Resource, error)
+}
+
+// NewDetectLeaksCommand returns a command to find cloud resources that
+// have leaked from a controller's models.
+func NewDetectLeaksCommand() cmd.Command {
+	return modelcmd.WrapController(&detectLeaksCommand{})
+}
+
+// detectLeaksCommand lists, and optionally releases, the cloud
+// resources that a controller created but no longer knows about.
+type detectLeaksCommand struct {
+	modelcmd.ControllerCommandBase
+	api DetectLeaksAPI
+	out cmd.Output
+
+	cleanup bool
+}
+
+// leakedResource is the format of the detect-leaks command's output.
+type leakedResource struct {
+	Kind      string `yaml:"kind" json:"kind"`
+	Id        string `yaml:"id" json:"id"`
+	ModelUUID string `yaml:"model-uuid" json:"model-uuid"`
+	Reason    string `yaml:"reason" json:"reason"`
+	Released  bool   `yaml:"released,omitempty" json:"released,omitempty"`
+	Error     string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+const detectLeaksHelpDoc = `
+Lists the instances, volumes and security groups in the controller's
+clouds that are tagged as belonging to the controller, but which no
+model, machine or volume in the controller refers to. Such resources
+are left behind when a model's destruction fails part way through, or
+when a controller is killed, and continue to incur costs until they
+are removed.
+
+Only the controller model's cloud is searched for the resources of
+models that no longer exist, and only some providers support that
+search. Models whose machines or volumes are still being provisioned
+are not checked.
+
+With --cleanup, the resources found are also released. This cannot be
+undone, so run the command without --cleanup first and check its
+output. Only controller administrators may run this command.
+
+Examples:
+
+    juju detect-leaks
+    juju detect-leaks --format yaml
+    juju detect-leaks --cleanup
+
+See also:
+    destroy-model
+    kill-controller
+`
+
+// Info implements Command.Info.
+func (c *detectLeaksCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "detect-leaks",
+		Purpose: "Finds cloud resources that have leaked from a controller's models.",
+		Doc:     detectLeaksHelpDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *detectLeaksCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.cleanup, "cleanup", false, "Release the leaked resources found")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatLeakedResourcesTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *detectLeaksCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *detectLeaksCommand) getAPI() (DetectLeaksAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return struct {
+		io.Closer
+		*leakdetector.API
+	}{root, leakdetector.NewAPI(root)}, nil
+}
+
+// Run implements Command.Run.
+func (c *detectLeaksCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	resources, err := client.DetectLeaks(c.cleanup)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(resources) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No leaked resources found.")
+		return nil
+	}
+	result := make([]leakedResource, len(resources))
+	failed := 0
+	for i, r := range resources {
+		result[i] = leakedResource{
+			Kind:      r.Kind,
+			Id:        r.Id,
+			ModelUUID: r.ModelUUID,
+			Reason:    r.Reason,
+			Released:  r.Released,
+		}
+		if r.Error != nil {
+			result[i].Error = r.Error.Error()
+			failed++
+		}
+	}
+	if err := c.out.Write(ctx, result); err != nil {
+		return errors.Trace(err)
+	}
+	if failed > 0 {
+		return errors.Errorf("failed to release %d resource(s)", failed)
+	}
+	return nil
+}
+
+func formatLeakedResourcesTabular(writer io.Writer, value interface{}) error {
+	resources, ok := value.([]leakedResource)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", resources, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Model", "Kind", "Id", "Status", "Reason")
+	for _, r := range resources {
+		status := "leaked"
+		if r.Released {
+			status = "released"
+		} else if r.Error != "" {
+			status = "error: " + r.Error
+		}
+		w.Println(r.ModelUUID, r.Kind, r.Id, status, r.Reason)
+	}
+	return tw.Flush()
+}
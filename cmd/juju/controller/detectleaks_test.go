@@ -0,0 +1,122 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/leakdetector"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+)
+
+type detectLeaksSuite struct {
+	baseControllerSuite
+	api   *fakeDetectLeaksAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&detectLeaksSuite{})
+
+func (s *detectLeaksSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeDetectLeaksAPI{
+		resources: []leakdetector.Resource{{
+			Kind:      "instance",
+			Id:        "i-0",
+			ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Reason:    "model no longer exists",
+		}, {
+			Kind:      "volume",
+			Id:        "vol-0",
+			ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Reason:    "model no longer exists",
+		}},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *detectLeaksSuite) run(c *gc.C, args ...string) (string, error) {
+	ctx, err := cmdtesting.RunCommand(c, controller.NewDetectLeaksCommandForTest(s.api, s.store), args...)
+	return cmdtesting.Stdout(ctx), err
+}
+
+func (s *detectLeaksSuite) TestTabular(c *gc.C) {
+	out, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.cleanup, jc.DeepEquals, []bool{false})
+	c.Assert(out, gc.Equals, `
+Model                                 Kind      Id     Status  Reason
+deadbeef-0bad-400d-8000-4b1d0d06f00d  instance  i-0    leaked  model no longer exists
+deadbeef-0bad-400d-8000-4b1d0d06f00d  volume    vol-0  leaked  model no longer exists
+
+`[1:])
+}
+
+func (s *detectLeaksSuite) TestYAML(c *gc.C) {
+	s.api.resources = s.api.resources[:1]
+	out, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+- kind: instance
+  id: i-0
+  model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+  reason: model no longer exists
+`[1:])
+}
+
+func (s *detectLeaksSuite) TestCleanup(c *gc.C) {
+	s.api.resources[0].Released = true
+	s.api.resources[1].Error = errors.New("volume in use")
+	out, err := s.run(c, "--cleanup")
+	c.Assert(err, gc.ErrorMatches, `failed to release 1 resource\(s\)`)
+	c.Assert(s.api.cleanup, jc.DeepEquals, []bool{true})
+	c.Assert(out, gc.Equals, `
+Model                                 Kind      Id     Status                Reason
+deadbeef-0bad-400d-8000-4b1d0d06f00d  instance  i-0    released              model no longer exists
+deadbeef-0bad-400d-8000-4b1d0d06f00d  volume    vol-0  error: volume in use  model no longer exists
+
+`[1:])
+}
+
+func (s *detectLeaksSuite) TestNone(c *gc.C) {
+	s.api.resources = nil
+	ctx, err := cmdtesting.RunCommand(c, controller.NewDetectLeaksCommandForTest(s.api, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No leaked resources found.\n")
+}
+
+func (s *detectLeaksSuite) TestError(c *gc.C) {
+	s.api.err = apiservererrors.ErrPerm
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *detectLeaksSuite) TestInit(c *gc.C) {
+	_, err := s.run(c, "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+type fakeDetectLeaksAPI struct {
+	resources []leakdetector.Resource
+	cleanup   []bool
+	err       error
+}
+
+func (f *fakeDetectLeaksAPI) Close() error {
+	return nil
+}
+
+func (f *fakeDetectLeaksAPI) DetectLeaks(cleanup bool) ([]leakdetector.Resource, error) {
+	f.cleanup = append(f.cleanup, cleanup)
+	return f.resources, f.err
+}
@@ -62,6 +62,51 @@ func NewAddModelCommandForTest(
 	return modelcmd.WrapController(c), &AddModelCommand{c}
 }
 
+// SetDeployBundle sets the function used to deploy the bundle of
+// a model template.
+func (c *AddModelCommand) SetDeployBundle(deployBundle func(ctx *cmd.Context, model, bundle string) error) {
+	c.deployBundle = deployBundle
+}
+
+// NewCreateModelTemplateCommandForTest returns a create-model-template
+// command with the API provided as specified.
+func NewCreateModelTemplateCommandForTest(api ModelTemplateAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &setModelTemplateCommand{
+		modelTemplateCommandBase: modelTemplateCommandBase{api: api},
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewUpdateModelTemplateCommandForTest returns an update-model-template
+// command with the API provided as specified.
+func NewUpdateModelTemplateCommandForTest(api ModelTemplateAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &setModelTemplateCommand{
+		modelTemplateCommandBase: modelTemplateCommandBase{api: api},
+		update:                   true,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewModelTemplatesCommandForTest returns a model-templates command
+// with the API provided as specified.
+func NewModelTemplatesCommandForTest(api ModelTemplateAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &modelTemplatesCommand{
+		modelTemplateCommandBase: modelTemplateCommandBase{api: api},
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewDetectLeaksCommandForTest returns a detect-leaks command with the
+// API provided as specified.
+func NewDetectLeaksCommandForTest(api DetectLeaksAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &detectLeaksCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 // NewListModelsCommandForTest returns a ListModelsCommand with the API
 // and userCreds provided as specified.
 func NewListModelsCommandForTest(modelAPI ModelManagerAPI, sysAPI ModelsSysAPI, store jujuclient.ClientStore) cmd.Command {
@@ -0,0 +1,405 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/constraints"
+)
+
+// ModelTemplateAPI defines the methods on the model manager API that
+// the model template commands call.
+type ModelTemplateAPI interface {
+	Close() error
+	CreateModelTemplate(params.ModelTemplate) error
+	UpdateModelTemplate(params.ModelTemplate) error
+	ListModelTemplates() ([]params.ModelTemplate, error)
+}
+
+// modelTemplateDetails is the format of model template files, and of
+// the yaml and json output of the model-templates command.
+type modelTemplateDetails struct {
+	Config       map[string]interface{}       `yaml:"config,omitempty" json:"config,omitempty"`
+	Constraints  string                       `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	StoragePools map[string]storagePoolDetail `yaml:"storage-pools,omitempty" json:"storage-pools,omitempty"`
+	Spaces       []string                     `yaml:"spaces,omitempty" json:"spaces,omitempty"`
+	Users        map[string]string            `yaml:"users,omitempty" json:"users,omitempty"`
+	Bundle       string                       `yaml:"bundle,omitempty" json:"bundle,omitempty"`
+	CreatedBy    string                       `yaml:"created-by,omitempty" json:"created-by,omitempty"`
+	DateUpdated  *time.Time                   `yaml:"updated,omitempty" json:"updated,omitempty"`
+}
+
+type storagePoolDetail struct {
+	Provider string                 `yaml:"provider" json:"provider"`
+	Attrs    map[string]interface{} `yaml:"attrs,omitempty" json:"attrs,omitempty"`
+}
+
+func (d modelTemplateDetails) params(name string) (params.ModelTemplate, error) {
+	cons, err := constraints.Parse(d.Constraints)
+	if err != nil {
+		return params.ModelTemplate{}, errors.Trace(err)
+	}
+	result := params.ModelTemplate{
+		Name:        name,
+		Config:      d.Config,
+		Constraints: cons,
+		Spaces:      d.Spaces,
+		Bundle:      d.Bundle,
+	}
+	for _, poolName := range sortedPoolNames(d.StoragePools) {
+		pool := d.StoragePools[poolName]
+		result.StoragePools = append(result.StoragePools, params.ModelTemplateStoragePool{
+			Name:     poolName,
+			Provider: pool.Provider,
+			Attrs:    pool.Attrs,
+		})
+	}
+	users := make([]string, 0, len(d.Users))
+	for user := range d.Users {
+		users = append(users, user)
+	}
+	sort.Strings(users)
+	for _, user := range users {
+		if !names.IsValidUser(user) {
+			return params.ModelTemplate{}, errors.NotValidf("user %q", user)
+		}
+		result.Users = append(result.Users, params.ModelTemplateUser{
+			UserTag: names.NewUserTag(user).String(),
+			Access:  d.Users[user],
+		})
+	}
+	return result, nil
+}
+
+func sortedPoolNames(pools map[string]storagePoolDetail) []string {
+	result := make([]string, 0, len(pools))
+	for name := range pools {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func newModelTemplateDetails(template params.ModelTemplate) modelTemplateDetails {
+	result := modelTemplateDetails{
+		Config:    template.Config,
+		Spaces:    template.Spaces,
+		Bundle:    template.Bundle,
+		CreatedBy: template.CreatedBy,
+	}
+	if !constraints.IsEmpty(&template.Constraints) {
+		result.Constraints = template.Constraints.String()
+	}
+	if !template.DateUpdated.IsZero() {
+		updated := template.DateUpdated
+		result.DateUpdated = &updated
+	}
+	if len(template.StoragePools) > 0 {
+		result.StoragePools = make(map[string]storagePoolDetail)
+		for _, pool := range template.StoragePools {
+			result.StoragePools[pool.Name] = storagePoolDetail{
+				Provider: pool.Provider,
+				Attrs:    pool.Attrs,
+			}
+		}
+	}
+	if len(template.Users) > 0 {
+		result.Users = make(map[string]string)
+		for _, user := range template.Users {
+			userTag, err := names.ParseUserTag(user.UserTag)
+			if err != nil {
+				continue
+			}
+			result.Users[userTag.Id()] = user.Access
+		}
+	}
+	return result
+}
+
+type modelTemplateCommandBase struct {
+	modelcmd.ControllerCommandBase
+	api ModelTemplateAPI
+}
+
+func (c *modelTemplateCommandBase) getAPI() (ModelTemplateAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelmanager.NewClient(root), nil
+}
+
+// NewCreateModelTemplateCommand returns a command to create a model
+// template.
+func NewCreateModelTemplateCommand() cmd.Command {
+	return modelcmd.WrapController(&setModelTemplateCommand{})
+}
+
+// NewUpdateModelTemplateCommand returns a command to update a model
+// template.
+func NewUpdateModelTemplateCommand() cmd.Command {
+	return modelcmd.WrapController(&setModelTemplateCommand{update: true})
+}
+
+// setModelTemplateCommand creates or updates a model template from
+// a YAML file.
+type setModelTemplateCommand struct {
+	modelTemplateCommandBase
+	update bool
+
+	name     string
+	filename string
+}
+
+const createModelTemplateHelpDoc = `
+A model template holds settings which are applied to models added with
+"juju add-model --template". Only controller administrators may create
+model templates.
+
+The template is read from a YAML file, which may contain:
+
+    config:
+      <model config attributes>
+    constraints: <default model constraints>
+    storage-pools:
+      <pool name>:
+        provider: <storage provider>
+        attrs:
+          <pool attributes>
+    spaces:
+      - <space name>
+    users:
+      <user name>: <read|write|admin>
+    bundle: <bundle to deploy>
+
+Config given to add-model takes precedence over the template's config.
+Everything other than the bundle is applied when the model is added;
+the bundle is then deployed into the new model.
+
+Examples:
+
+    juju create-model-template web web-template.yaml
+
+See also:
+    add-model
+    model-templates
+    update-model-template
+`
+
+const updateModelTemplateHelpDoc = `
+Replaces the settings of an existing model template with those read from
+a YAML file, in the same format used by create-model-template. Models
+already added from the template are not affected. Only controller
+administrators may update model templates.
+
+Examples:
+
+    juju update-model-template web web-template.yaml
+
+See also:
+    add-model
+    create-model-template
+    model-templates
+`
+
+// Info implements Command.Info.
+func (c *setModelTemplateCommand) Info() *cmd.Info {
+	if c.update {
+		return jujucmd.Info(&cmd.Info{
+			Name:    "update-model-template",
+			Args:    "<template name> <template file>",
+			Purpose: "Updates a model template.",
+			Doc:     updateModelTemplateHelpDoc,
+		})
+	}
+	return jujucmd.Info(&cmd.Info{
+		Name:    "create-model-template",
+		Args:    "<template name> <template file>",
+		Purpose: "Creates a model template.",
+		Doc:     createModelTemplateHelpDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *setModelTemplateCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("template name and file must be specified")
+	case 1:
+		return errors.New("template file must be specified")
+	case 2:
+		c.name, c.filename = args[0], args[1]
+	default:
+		return cmd.CheckEmpty(args[2:])
+	}
+	if !names.IsValidModelName(c.name) {
+		return errors.NotValidf("template name %q", c.name)
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setModelTemplateCommand) Run(ctx *cmd.Context) error {
+	data, err := ioutil.ReadFile(ctx.AbsPath(c.filename))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var details modelTemplateDetails
+	if err := yaml.UnmarshalStrict(data, &details); err != nil {
+		return errors.Annotatef(err, "cannot parse %q", c.filename)
+	}
+	template, err := details.params(c.name)
+	if err != nil {
+		return errors.Annotatef(err, "cannot parse %q", c.filename)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if c.update {
+		if err := client.UpdateModelTemplate(template); err != nil {
+			return errors.Trace(err)
+		}
+		ctx.Infof("Updated model template %q", c.name)
+		return nil
+	}
+	if err := client.CreateModelTemplate(template); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Created model template %q", c.name)
+	return nil
+}
+
+// NewModelTemplatesCommand returns a command to list model templates.
+func NewModelTemplatesCommand() cmd.Command {
+	return modelcmd.WrapController(&modelTemplatesCommand{})
+}
+
+// modelTemplatesCommand lists the model templates on a controller.
+type modelTemplatesCommand struct {
+	modelTemplateCommandBase
+	out cmd.Output
+}
+
+const modelTemplatesHelpDoc = `
+Lists the model templates on a controller, which may be used to add
+models with "juju add-model --template". Use --format=yaml to see the
+full settings of each template.
+
+Examples:
+
+    juju model-templates
+    juju model-templates --format yaml
+
+See also:
+    add-model
+    create-model-template
+    update-model-template
+`
+
+// Info implements Command.Info.
+func (c *modelTemplatesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "model-templates",
+		Purpose: "Lists model templates on a controller.",
+		Doc:     modelTemplatesHelpDoc,
+		Aliases: []string{"list-model-templates"},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *modelTemplatesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatModelTemplatesTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *modelTemplatesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	templates, err := client.ListModelTemplates()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(templates) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No model templates to display.")
+		return nil
+	}
+	result := make(map[string]modelTemplateDetails)
+	for _, template := range templates {
+		result[template.Name] = newModelTemplateDetails(template)
+	}
+	return c.out.Write(ctx, result)
+}
+
+func formatModelTemplatesTabular(writer io.Writer, value interface{}) error {
+	templates, ok := value.(map[string]modelTemplateDetails)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", templates, value)
+	}
+	templateNames := make([]string, 0, len(templates))
+	for name := range templates {
+		templateNames = append(templateNames, name)
+	}
+	sort.Strings(templateNames)
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Template", "Constraints", "Spaces", "Bundle", "Created by", "Updated")
+	now := time.Now()
+	for _, name := range templateNames {
+		t := templates[name]
+		updated := "-"
+		if t.DateUpdated != nil {
+			updated = common.UserFriendlyDuration(*t.DateUpdated, now)
+		}
+		w.Println(
+			name,
+			valueOrDash(t.Constraints),
+			valueOrDash(strings.Join(t.Spaces, ",")),
+			valueOrDash(t.Bundle),
+			valueOrDash(t.CreatedBy),
+			updated,
+		)
+	}
+	return tw.Flush()
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,215 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/jujuclient"
+)
+
+type modelTemplatesSuite struct {
+	baseControllerSuite
+	api   *fakeModelTemplateAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&modelTemplatesSuite{})
+
+func (s *modelTemplatesSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeModelTemplateAPI{}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+const templateYAML = `
+config:
+  default-series: focal
+constraints: mem=4G
+storage-pools:
+  fast:
+    provider: tmpfs
+    attrs:
+      size: 1G
+spaces: [db, public]
+users:
+  bob: write
+bundle: cs:wordpress-simple
+`
+
+func (s *modelTemplatesSuite) writeTemplate(c *gc.C, content string) string {
+	path := filepath.Join(c.MkDir(), "template.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *modelTemplatesSuite) expectedTemplate() params.ModelTemplate {
+	return params.ModelTemplate{
+		Name:        "web",
+		Config:      map[string]interface{}{"default-series": "focal"},
+		Constraints: constraints.MustParse("mem=4G"),
+		StoragePools: []params.ModelTemplateStoragePool{{
+			Name:     "fast",
+			Provider: "tmpfs",
+			Attrs:    map[string]interface{}{"size": "1G"},
+		}},
+		Spaces: []string{"db", "public"},
+		Users: []params.ModelTemplateUser{{
+			UserTag: "user-bob",
+			Access:  "write",
+		}},
+		Bundle: "cs:wordpress-simple",
+	}
+}
+
+func (s *modelTemplatesSuite) TestCreate(c *gc.C) {
+	path := s.writeTemplate(c, templateYAML)
+	ctx, err := cmdtesting.RunCommand(c, controller.NewCreateModelTemplateCommandForTest(s.api, s.store), "web", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.created, jc.DeepEquals, []params.ModelTemplate{s.expectedTemplate()})
+	c.Assert(s.api.updated, gc.HasLen, 0)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Created model template \"web\"\n")
+}
+
+func (s *modelTemplatesSuite) TestUpdate(c *gc.C) {
+	path := s.writeTemplate(c, templateYAML)
+	ctx, err := cmdtesting.RunCommand(c, controller.NewUpdateModelTemplateCommandForTest(s.api, s.store), "web", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.updated, jc.DeepEquals, []params.ModelTemplate{s.expectedTemplate()})
+	c.Assert(s.api.created, gc.HasLen, 0)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Updated model template \"web\"\n")
+}
+
+func (s *modelTemplatesSuite) TestCreateError(c *gc.C) {
+	s.api.err = apiservererrors.ErrPerm
+	path := s.writeTemplate(c, templateYAML)
+	_, err := cmdtesting.RunCommand(c, controller.NewCreateModelTemplateCommandForTest(s.api, s.store), "web", path)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelTemplatesSuite) TestCreateInvalidFile(c *gc.C) {
+	path := s.writeTemplate(c, "bogus: true\n")
+	_, err := cmdtesting.RunCommand(c, controller.NewCreateModelTemplateCommandForTest(s.api, s.store), "web", path)
+	c.Assert(err, gc.ErrorMatches, `(?s)cannot parse ".*template.yaml": .*field bogus not found.*`)
+
+	path = s.writeTemplate(c, "constraints: foo=bar\n")
+	_, err = cmdtesting.RunCommand(c, controller.NewCreateModelTemplateCommandForTest(s.api, s.store), "web", path)
+	c.Assert(err, gc.ErrorMatches, `cannot parse ".*template.yaml": unknown constraint "foo"`)
+	c.Assert(s.api.created, gc.HasLen, 0)
+}
+
+func (s *modelTemplatesSuite) TestCreateInit(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "template name and file must be specified",
+	}, {
+		args: []string{"web"},
+		err:  "template file must be specified",
+	}, {
+		args: []string{"Web", "template.yaml"},
+		err:  `template name "Web" not valid`,
+	}, {
+		args: []string{"web", "template.yaml", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		_, err := cmdtesting.RunCommand(c, controller.NewCreateModelTemplateCommandForTest(s.api, s.store), t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *modelTemplatesSuite) TestListTabular(c *gc.C) {
+	t := s.expectedTemplate()
+	t.CreatedBy = "admin"
+	t.DateUpdated = time.Now().Add(-time.Hour)
+	s.api.templates = []params.ModelTemplate{t, {Name: "empty"}}
+	ctx, err := cmdtesting.RunCommand(c, controller.NewModelTemplatesCommandForTest(s.api, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Template  Constraints  Spaces     Bundle               Created by  Updated
+empty     -            -          -                    -           -
+web       mem=4096M    db,public  cs:wordpress-simple  admin       1 hour ago
+
+`[1:])
+}
+
+func (s *modelTemplatesSuite) TestListYAML(c *gc.C) {
+	t := s.expectedTemplate()
+	t.CreatedBy = "admin"
+	s.api.templates = []params.ModelTemplate{t}
+	ctx, err := cmdtesting.RunCommand(c, controller.NewModelTemplatesCommandForTest(s.api, s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+web:
+  config:
+    default-series: focal
+  constraints: mem=4096M
+  storage-pools:
+    fast:
+      provider: tmpfs
+      attrs:
+        size: 1G
+  spaces:
+  - db
+  - public
+  users:
+    bob: write
+  bundle: cs:wordpress-simple
+  created-by: admin
+`[1:])
+}
+
+func (s *modelTemplatesSuite) TestListNone(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, controller.NewModelTemplatesCommandForTest(s.api, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No model templates to display.\n")
+}
+
+type fakeModelTemplateAPI struct {
+	created   []params.ModelTemplate
+	updated   []params.ModelTemplate
+	templates []params.ModelTemplate
+	err       error
+}
+
+func (f *fakeModelTemplateAPI) Close() error {
+	return nil
+}
+
+func (f *fakeModelTemplateAPI) CreateModelTemplate(template params.ModelTemplate) error {
+	if f.err != nil {
+		return errors.Trace(f.err)
+	}
+	f.created = append(f.created, template)
+	return nil
+}
+
+func (f *fakeModelTemplateAPI) UpdateModelTemplate(template params.ModelTemplate) error {
+	if f.err != nil {
+		return errors.Trace(f.err)
+	}
+	f.updated = append(f.updated, template)
+	return nil
+}
+
+func (f *fakeModelTemplateAPI) ListModelTemplates() ([]params.ModelTemplate, error) {
+	return f.templates, f.err
+}
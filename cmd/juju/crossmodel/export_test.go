@@ -70,3 +70,30 @@ func NewRemoveCommandForTest(store jujuclient.ClientStore, api RemoveAPI) cmd.Co
 	aCmd.SetClientStore(store)
 	return modelcmd.WrapController(aCmd)
 }
+
+func NewCreateOfferTokenCommandForTest(store jujuclient.ClientStore, api OfferTokenAPI) cmd.Command {
+	aCmd := &createOfferTokenCommand{}
+	aCmd.newAPIFunc = func(controllerName string) (OfferTokenAPI, error) {
+		return api, nil
+	}
+	aCmd.SetClientStore(store)
+	return modelcmd.WrapController(aCmd)
+}
+
+func NewListOfferTokensCommandForTest(store jujuclient.ClientStore, api OfferTokenAPI) cmd.Command {
+	aCmd := &listOfferTokensCommand{}
+	aCmd.newAPIFunc = func(controllerName string) (OfferTokenAPI, error) {
+		return api, nil
+	}
+	aCmd.SetClientStore(store)
+	return modelcmd.WrapController(aCmd)
+}
+
+func NewRevokeOfferTokenCommandForTest(store jujuclient.ClientStore, api OfferTokenAPI) cmd.Command {
+	aCmd := &revokeOfferTokenCommand{}
+	aCmd.newAPIFunc = func(controllerName string) (OfferTokenAPI, error) {
+		return api, nil
+	}
+	aCmd.SetClientStore(store)
+	return modelcmd.WrapController(aCmd)
+}
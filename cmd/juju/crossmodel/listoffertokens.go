@@ -0,0 +1,177 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const listOfferTokensDoc = `
+List the tokens which have been created for an offer with
+"juju create-offer-token", showing how many times each has been used and
+whether it has expired or been revoked.
+
+The offer is normally specified by its URL. It's also possible to specify
+just the offer name, in which case the offer is considered to reside in the
+current model.
+
+Examples:
+
+    juju offer-tokens admin/prod.hosted-mysql
+    juju offer-tokens hosted-mysql --format yaml
+
+See also:
+    create-offer-token
+    revoke-offer-token
+`
+
+// NewListOfferTokensCommand returns a command used to list the tokens
+// for an offer.
+func NewListOfferTokensCommand() cmd.Command {
+	listCmd := &listOfferTokensCommand{}
+	listCmd.newAPIFunc = func(controllerName string) (OfferTokenAPI, error) {
+		return listCmd.NewApplicationOffersAPI(controllerName)
+	}
+	return modelcmd.WrapController(listCmd)
+}
+
+type listOfferTokensCommand struct {
+	offerTokenCommandBase
+	out cmd.Output
+}
+
+// OfferToken holds the details of an offer token for display.
+type OfferToken struct {
+	CreatedBy   string    `yaml:"created-by" json:"created-by"`
+	DateCreated time.Time `yaml:"date-created" json:"date-created"`
+	Expires     time.Time `yaml:"expires" json:"expires"`
+	MaxUses     int       `yaml:"max-uses,omitempty" json:"max-uses,omitempty"`
+	Uses        int       `yaml:"uses" json:"uses"`
+	Status      string    `yaml:"status" json:"status"`
+}
+
+// Info implements Command.Info.
+func (c *listOfferTokensCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "offer-tokens",
+		Args:    "<offer-url>",
+		Purpose: "Lists the tokens created for an offer.",
+		Doc:     listOfferTokensDoc,
+		Aliases: []string{"list-offer-tokens"},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listOfferTokensCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatOfferTokensTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listOfferTokensCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no offer specified")
+	}
+	c.offer = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *listOfferTokensCommand) Run(ctx *cmd.Context) error {
+	if err := c.resolveOfferURL(); err != nil {
+		return errors.Trace(err)
+	}
+	api, err := c.newAPIFunc(c.controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	tokens, err := api.ListOfferTokens(c.offerURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(tokens) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No tokens have been created for offer %q.", c.offerURL)
+		return nil
+	}
+	now := time.Now()
+	result := make(map[string]OfferToken, len(tokens))
+	for _, token := range tokens {
+		status := "active"
+		switch {
+		case token.Revoked:
+			status = "revoked"
+		case !token.Expires.After(now):
+			status = "expired"
+		case token.MaxUses > 0 && token.Uses >= token.MaxUses:
+			status = "used"
+		}
+		result[token.Id] = OfferToken{
+			CreatedBy:   token.CreatedBy,
+			DateCreated: token.DateCreated,
+			Expires:     token.Expires,
+			MaxUses:     token.MaxUses,
+			Uses:        token.Uses,
+			Status:      status,
+		}
+	}
+	return c.out.Write(ctx, result)
+}
+
+// formatOfferTokensTabular writes a tabular summary of offer tokens.
+func formatOfferTokensTabular(writer io.Writer, value interface{}) error {
+	tokens, ok := value.(map[string]OfferToken)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", tokens, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Token", "Created by", "Created", "Expires", "Uses", "Status")
+	ids := make([]string, 0, len(tokens))
+	for id := range tokens {
+		ids = append(ids, id)
+	}
+	sortTokenIds(ids, tokens)
+	for _, id := range ids {
+		token := tokens[id]
+		uses := fmt.Sprint(token.Uses)
+		if token.MaxUses > 0 {
+			uses = fmt.Sprintf("%d/%d", token.Uses, token.MaxUses)
+		}
+		w.Println(
+			id, token.CreatedBy,
+			token.DateCreated.UTC().Format(time.RFC3339),
+			token.Expires.UTC().Format(time.RFC3339),
+			uses, token.Status,
+		)
+	}
+	return tw.Flush()
+}
+
+// sortTokenIds sorts the token ids by the date their tokens were created.
+func sortTokenIds(ids []string, tokens map[string]OfferToken) {
+	sort.Slice(ids, func(i, j int) bool {
+		ti, tj := tokens[ids[i]], tokens[ids[j]]
+		if !ti.DateCreated.Equal(tj.DateCreated) {
+			return ti.DateCreated.Before(tj.DateCreated)
+		}
+		return ids[i] < ids[j]
+	})
+}
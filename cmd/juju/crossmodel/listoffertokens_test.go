@@ -0,0 +1,96 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/crossmodel"
+)
+
+type listOfferTokensSuite struct {
+	BaseCrossModelSuite
+	mockAPI *mockOfferTokenAPI
+}
+
+var _ = gc.Suite(&listOfferTokensSuite{})
+
+func (s *listOfferTokensSuite) SetUpTest(c *gc.C) {
+	s.BaseCrossModelSuite.SetUpTest(c)
+	created := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	s.mockAPI = &mockOfferTokenAPI{
+		tokens: []params.OfferToken{{
+			Id:          "token1",
+			OfferURL:    "fred/test.db2",
+			CreatedBy:   "fred",
+			DateCreated: created.Add(time.Hour),
+			Expires:     created.Add(24 * time.Hour),
+			Uses:        1,
+			Revoked:     true,
+		}, {
+			Id:          "token0",
+			OfferURL:    "fred/test.db2",
+			CreatedBy:   "fred",
+			DateCreated: created,
+			Expires:     time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+			MaxUses:     2,
+			Uses:        1,
+		}},
+	}
+}
+
+func (s *listOfferTokensSuite) runList(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, crossmodel.NewListOfferTokensCommandForTest(s.store, s.mockAPI), args...)
+}
+
+func (s *listOfferTokensSuite) TestInit(c *gc.C) {
+	_, err := s.runList(c)
+	c.Assert(err, gc.ErrorMatches, "no offer specified")
+}
+
+func (s *listOfferTokensSuite) TestListTabular(c *gc.C) {
+	ctx, err := s.runList(c, "db2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.offerURL, gc.Equals, "fred/test.db2")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Token   Created by  Created               Expires               Uses  Status
+token0  fred        2020-06-01T00:00:00Z  2100-01-01T00:00:00Z  1/2   active
+token1  fred        2020-06-01T01:00:00Z  2020-06-02T00:00:00Z  1     revoked
+
+`[1:])
+}
+
+func (s *listOfferTokensSuite) TestListYAML(c *gc.C) {
+	ctx, err := s.runList(c, "fred/test.db2", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+token0:
+  created-by: fred
+  date-created: 2020-06-01T00:00:00Z
+  expires: 2100-01-01T00:00:00Z
+  max-uses: 2
+  uses: 1
+  status: active
+token1:
+  created-by: fred
+  date-created: 2020-06-01T01:00:00Z
+  expires: 2020-06-02T00:00:00Z
+  uses: 1
+  status: revoked
+`[1:])
+}
+
+func (s *listOfferTokensSuite) TestListNone(c *gc.C) {
+	s.mockAPI.tokens = nil
+	ctx, err := s.runList(c, "fred/test.db2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No tokens have been created for offer \"fred/test.db2\".\n")
+}
@@ -0,0 +1,210 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/applicationoffers"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/crossmodel"
+)
+
+const createOfferTokenDoc = `
+Create a token which allows an offer to be consumed by someone who has no
+user on the offer's controller, typically a user of another controller.
+
+The token is printed as a "juju consume" command to send to the consumer.
+It embeds the addresses and CA certificate of the controller hosting the
+offer, along with a secret key which is only shown once.
+
+By default the token expires after 24 hours and may be used any number of
+times before then. Use --expires and --max-uses to change this. Each time
+the token is redeemed, a relation to the offer can be made. Tokens can be
+listed with "juju offer-tokens" and revoked with "juju revoke-offer-token";
+revoking a token suspends any relations made with it.
+
+The offer is normally specified by its URL. It's also possible to specify
+just the offer name, in which case the offer is considered to reside in the
+current model.
+
+Examples:
+
+    juju create-offer-token admin/prod.hosted-mysql
+    juju create-offer-token hosted-mysql --expires 2h --max-uses 1
+
+See also:
+    consume
+    offer-tokens
+    revoke-offer-token
+`
+
+// OfferTokenAPI defines the API methods that the offer token commands use.
+type OfferTokenAPI interface {
+	Close() error
+	CreateOfferToken(offerURL string, expires time.Time, maxUses int) (params.CreateOfferTokenResult, error)
+	ListOfferTokens(offerURL string) ([]params.OfferToken, error)
+	RevokeOfferToken(offerURL, id string) error
+}
+
+// offerTokenCommandBase is embedded by the commands which manage the tokens
+// for an offer.
+type offerTokenCommandBase struct {
+	modelcmd.ControllerCommandBase
+	newAPIFunc func(controllerName string) (OfferTokenAPI, error)
+
+	offer          string
+	offerURL       string
+	controllerName string
+}
+
+// NewApplicationOffersAPI returns an application offers api.
+func (c *offerTokenCommandBase) NewApplicationOffersAPI(controllerName string) (*applicationoffers.Client, error) {
+	root, err := c.CommandBase.NewAPIRoot(c.ClientStore(), controllerName, "")
+	if err != nil {
+		return nil, err
+	}
+	return applicationoffers.NewClient(root), nil
+}
+
+// resolveOfferURL sets the offer URL and the name of the controller hosting
+// the offer from the offer specified on the command line, which may be just
+// an offer name in the current model.
+func (c *offerTokenCommandBase) resolveOfferURL() error {
+	urlStr := c.offer
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	url, err := crossmodel.ParseOfferURL(urlStr)
+	if err != nil {
+		currentModel, err := c.ClientStore().CurrentModel(controllerName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		url, err = makeURLFromCurrentModel(urlStr, "", currentModel)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if url.HasEndpoint() {
+		return errors.Errorf("offer URL %q contains an endpoint; only specify the offer name itself", urlStr)
+	}
+	c.controllerName = controllerName
+	if url.Source != "" {
+		c.controllerName = url.Source
+	}
+	url.Source = ""
+	c.offerURL = url.String()
+	return nil
+}
+
+// NewCreateOfferTokenCommand returns a command used to create a token for
+// consuming an offer.
+func NewCreateOfferTokenCommand() cmd.Command {
+	createCmd := &createOfferTokenCommand{}
+	createCmd.newAPIFunc = func(controllerName string) (OfferTokenAPI, error) {
+		return createCmd.NewApplicationOffersAPI(controllerName)
+	}
+	return modelcmd.WrapController(createCmd)
+}
+
+type createOfferTokenCommand struct {
+	offerTokenCommandBase
+
+	expires time.Duration
+	maxUses int
+}
+
+// Info implements Command.Info.
+func (c *createOfferTokenCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "create-offer-token",
+		Args:    "<offer-url>",
+		Purpose: "Creates a token which allows an offer to be consumed.",
+		Doc:     createOfferTokenDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *createOfferTokenCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.DurationVar(&c.expires, "expires", 24*time.Hour, "How long until the token expires")
+	f.IntVar(&c.maxUses, "max-uses", 0, "The number of times the token may be used (0 means no limit)")
+}
+
+// Init implements Command.Init.
+func (c *createOfferTokenCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no offer specified")
+	}
+	if c.expires <= 0 {
+		return errors.New("--expires must be a positive duration")
+	}
+	if c.maxUses < 0 {
+		return errors.New("--max-uses cannot be negative")
+	}
+	c.offer = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *createOfferTokenCommand) Run(ctx *cmd.Context) error {
+	if err := c.resolveOfferURL(); err != nil {
+		return errors.Trace(err)
+	}
+	controllerDetails, err := c.ClientStore().ControllerByName(c.controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	api, err := c.newAPIFunc(c.controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	expires := time.Now().Add(c.expires)
+	result, err := api.CreateOfferToken(c.offerURL, expires, c.maxUses)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	modelTag, err := names.ParseModelTag(result.ModelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	token, err := crossmodel.EncodeOfferToken(crossmodel.OfferTokenInfo{
+		ControllerName: c.controllerName,
+		Addrs:          controllerDetails.APIEndpoints,
+		CACert:         controllerDetails.CACert,
+		ModelUUID:      modelTag.Id(),
+		OfferURL:       result.OfferURL,
+		TokenId:        result.Id,
+		SecretKey:      result.SecretKey,
+	})
+	if err != nil {
+		return errors.Annotate(err, "generating offer token")
+	}
+
+	uses := "any number of times"
+	if c.maxUses == 1 {
+		uses = "once"
+	} else if c.maxUses > 1 {
+		uses = fmt.Sprintf("up to %d times", c.maxUses)
+	}
+	fmt.Fprintf(ctx.Stdout, "Token %q created for offer %q\n", result.Id, result.OfferURL)
+	fmt.Fprintf(ctx.Stdout, "It can be used %s until %s.\n", uses, expires.UTC().Format(time.RFC3339))
+	fmt.Fprintf(ctx.Stdout, "Please send this command to the consumer of the offer:\n")
+	fmt.Fprintf(ctx.Stdout, "    juju consume %s\n", token)
+	return nil
+}
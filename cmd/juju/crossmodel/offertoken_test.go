@@ -0,0 +1,133 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/crossmodel"
+	jujucrossmodel "github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type createOfferTokenSuite struct {
+	BaseCrossModelSuite
+	mockAPI *mockOfferTokenAPI
+}
+
+var _ = gc.Suite(&createOfferTokenSuite{})
+
+func (s *createOfferTokenSuite) SetUpTest(c *gc.C) {
+	s.BaseCrossModelSuite.SetUpTest(c)
+	s.store.Controllers["test-master"] = jujuclient.ControllerDetails{
+		APIEndpoints: []string{"10.0.0.1:17070"},
+		CACert:       testing.CACert,
+	}
+	s.mockAPI = &mockOfferTokenAPI{}
+}
+
+func (s *createOfferTokenSuite) runCreate(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, crossmodel.NewCreateOfferTokenCommandForTest(s.store, s.mockAPI), args...)
+}
+
+func (s *createOfferTokenSuite) TestInit(c *gc.C) {
+	_, err := s.runCreate(c)
+	c.Assert(err, gc.ErrorMatches, "no offer specified")
+	_, err = s.runCreate(c, "fred/test.db2", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+	_, err = s.runCreate(c, "fred/test.db2", "--expires", "-1h")
+	c.Assert(err, gc.ErrorMatches, "--expires must be a positive duration")
+	_, err = s.runCreate(c, "fred/test.db2", "--max-uses", "-1")
+	c.Assert(err, gc.ErrorMatches, "--max-uses cannot be negative")
+}
+
+func (s *createOfferTokenSuite) TestCreateURLWithEndpoint(c *gc.C) {
+	_, err := s.runCreate(c, "fred/test.db2:db")
+	c.Assert(err, gc.ErrorMatches, `offer URL "fred/test.db2:db" contains an endpoint; only specify the offer name itself`)
+}
+
+func (s *createOfferTokenSuite) TestCreate(c *gc.C) {
+	start := time.Now()
+	ctx, err := s.runCreate(c, "db2", "--expires", "2h", "--max-uses", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.offerURL, gc.Equals, "fred/test.db2")
+	c.Assert(s.mockAPI.maxUses, gc.Equals, 3)
+	c.Assert(s.mockAPI.expires, jc.TimeBetween(start.Add(2*time.Hour), time.Now().Add(2*time.Hour)))
+
+	lines := strings.Split(cmdtesting.Stdout(ctx), "\n")
+	c.Assert(lines, gc.HasLen, 5)
+	c.Assert(lines[0], gc.Equals, `Token "token-id" created for offer "fred/test.db2"`)
+	c.Assert(lines[1], gc.Matches, `It can be used up to 3 times until .*\.`)
+	c.Assert(lines[3], gc.Matches, `    juju consume .*`)
+
+	token := strings.TrimPrefix(lines[3], "    juju consume ")
+	info, err := jujucrossmodel.DecodeOfferToken(token)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, jujucrossmodel.OfferTokenInfo{
+		ControllerName: "test-master",
+		Addrs:          []string{"10.0.0.1:17070"},
+		CACert:         testing.CACert,
+		ModelUUID:      testing.ModelTag.Id(),
+		OfferURL:       "fred/test.db2",
+		TokenId:        "token-id",
+		SecretKey:      "secret",
+	})
+}
+
+func (s *createOfferTokenSuite) TestCreateAPIError(c *gc.C) {
+	s.mockAPI.err = errors.New("fail")
+	_, err := s.runCreate(c, "fred/test.db2")
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+type mockOfferTokenAPI struct {
+	err      error
+	offerURL string
+	expires  time.Time
+	maxUses  int
+	tokenId  string
+	tokens   []params.OfferToken
+}
+
+func (m *mockOfferTokenAPI) Close() error {
+	return nil
+}
+
+func (m *mockOfferTokenAPI) CreateOfferToken(offerURL string, expires time.Time, maxUses int) (params.CreateOfferTokenResult, error) {
+	if m.err != nil {
+		return params.CreateOfferTokenResult{}, m.err
+	}
+	m.offerURL, m.expires, m.maxUses = offerURL, expires, maxUses
+	return params.CreateOfferTokenResult{
+		Id:        "token-id",
+		SecretKey: "secret",
+		ModelTag:  testing.ModelTag.String(),
+		OfferURL:  offerURL,
+	}, nil
+}
+
+func (m *mockOfferTokenAPI) ListOfferTokens(offerURL string) ([]params.OfferToken, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.offerURL = offerURL
+	return m.tokens, nil
+}
+
+func (m *mockOfferTokenAPI) RevokeOfferToken(offerURL, id string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.offerURL, m.tokenId = offerURL, id
+	return nil
+}
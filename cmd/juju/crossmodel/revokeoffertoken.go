@@ -0,0 +1,86 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const revokeOfferTokenDoc = `
+Revoke a token created with "juju create-offer-token" so that it can no
+longer be used to consume the offer. Any relations made by consuming the
+offer with the token are suspended.
+
+The offer is normally specified by its URL. It's also possible to specify
+just the offer name, in which case the offer is considered to reside in the
+current model.
+
+Examples:
+
+    juju revoke-offer-token admin/prod.hosted-mysql 0a1b2c3d4e5f6789
+
+See also:
+    create-offer-token
+    offer-tokens
+`
+
+// NewRevokeOfferTokenCommand returns a command used to revoke a token
+// for an offer.
+func NewRevokeOfferTokenCommand() cmd.Command {
+	revokeCmd := &revokeOfferTokenCommand{}
+	revokeCmd.newAPIFunc = func(controllerName string) (OfferTokenAPI, error) {
+		return revokeCmd.NewApplicationOffersAPI(controllerName)
+	}
+	return modelcmd.WrapController(revokeCmd)
+}
+
+type revokeOfferTokenCommand struct {
+	offerTokenCommandBase
+	tokenId string
+}
+
+// Info implements Command.Info.
+func (c *revokeOfferTokenCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "revoke-offer-token",
+		Args:    "<offer-url> <token>",
+		Purpose: "Revokes a token created for an offer.",
+		Doc:     revokeOfferTokenDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *revokeOfferTokenCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no offer specified")
+	case 1:
+		return errors.New("no token specified")
+	}
+	c.offer, c.tokenId = args[0], args[1]
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements Command.Run.
+func (c *revokeOfferTokenCommand) Run(ctx *cmd.Context) error {
+	if err := c.resolveOfferURL(); err != nil {
+		return errors.Trace(err)
+	}
+	api, err := c.newAPIFunc(c.controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.RevokeOfferToken(c.offerURL, c.tokenId); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Token %q for offer %q revoked.", c.tokenId, c.offerURL)
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/crossmodel"
+)
+
+type revokeOfferTokenSuite struct {
+	BaseCrossModelSuite
+	mockAPI *mockOfferTokenAPI
+}
+
+var _ = gc.Suite(&revokeOfferTokenSuite{})
+
+func (s *revokeOfferTokenSuite) SetUpTest(c *gc.C) {
+	s.BaseCrossModelSuite.SetUpTest(c)
+	s.mockAPI = &mockOfferTokenAPI{}
+}
+
+func (s *revokeOfferTokenSuite) runRevoke(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, crossmodel.NewRevokeOfferTokenCommandForTest(s.store, s.mockAPI), args...)
+}
+
+func (s *revokeOfferTokenSuite) TestInit(c *gc.C) {
+	_, err := s.runRevoke(c)
+	c.Assert(err, gc.ErrorMatches, "no offer specified")
+	_, err = s.runRevoke(c, "fred/test.db2")
+	c.Assert(err, gc.ErrorMatches, "no token specified")
+	_, err = s.runRevoke(c, "fred/test.db2", "token0", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *revokeOfferTokenSuite) TestRevoke(c *gc.C) {
+	ctx, err := s.runRevoke(c, "db2", "token0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.offerURL, gc.Equals, "fred/test.db2")
+	c.Assert(s.mockAPI.tokenId, gc.Equals, "token0")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Token \"token0\" for offer \"fred/test.db2\" revoked.\n")
+}
+
+func (s *revokeOfferTokenSuite) TestRevokeAPIError(c *gc.C) {
+	s.mockAPI.err = errors.New("fail")
+	_, err := s.runRevoke(c, "fred/test.db2", "token0")
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
@@ -78,6 +78,30 @@ and bringing it under Juju's management. The Juju controller must be able to
 access the new machine over the network.
 
 
+Provisioning many machines from an inventory
+
+Use the --inventory option to manually provision all of the hosts listed
+in a YAML inventory file over SSH. Several hosts are provisioned at once,
+up to the number given by --concurrency, and a summary of the hosts which
+were provisioned, skipped or failed is shown when all have finished. Each
+host may specify the SSH user, port and identity file to connect with,
+falling back to those in the "defaults" section:
+
+    defaults:
+      user: admin
+      identity-file: ~/.ssh/deploy_key
+    hosts:
+      - host: 10.10.0.3
+      - host: 10.10.0.4
+        port: 2222
+      - host: ubuntu@node5.internal
+
+As no passwords can be prompted for, the SSH user must be able to log in
+with a key and to run sudo without a password. Hosts which are already
+machines in the model are skipped, so the command may be run again with
+the same inventory to retry hosts which failed.
+
+
 Container creation
 
 If a operating system container type is specified (e.g. "lxd" or "kvm"), 
@@ -122,6 +146,10 @@ Examples:
 	# Allocate a machine to the model via SSH
 	juju add-machine ssh:user@10.10.0.3
 
+	# Allocate the machines listed in an inventory file to the model via
+	# SSH, provisioning up to 10 at once
+	juju add-machine --inventory hosts.yaml --concurrency 10
+
 	# Allocate a machine to the model via WinRM
 	juju add-machine winrm:user@10.10.0.3
 
@@ -150,6 +178,7 @@ type addCommand struct {
 	api               AddMachineAPI
 	modelConfigAPI    ModelConfigAPI
 	machineManagerAPI MachineManagerAPI
+	statusAPI         statusAPI
 	// If specified, use this series, else use the model default-series
 	Series string
 	// If specified, these constraints are merged with those already in the model.
@@ -162,6 +191,10 @@ type addCommand struct {
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
 	Disks []storage.Constraints
+	// Inventory is the path of a file listing hosts to provision over SSH.
+	Inventory string
+	// Concurrency is the number of inventory hosts to provision at once.
+	Concurrency int
 }
 
 func (c *addCommand) Info() *cmd.Info {
@@ -179,6 +212,8 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Machine constraints that overwrite those available from 'juju get-model-constraints' and provider's defaults")
 	f.Var(disksFlag{&c.Disks}, "disks", "Storage constraints for disks to attach to the machine(s)")
+	f.StringVar(&c.Inventory, "inventory", "", "Path to a YAML file listing hosts to provision over SSH")
+	f.IntVar(&c.Concurrency, "concurrency", defaultInventoryConcurrency, "The number of inventory hosts to provision at once")
 }
 
 func (c *addCommand) Init(args []string) error {
@@ -200,6 +235,17 @@ func (c *addCommand) Init(args []string) error {
 	if c.NumMachines > 1 && c.Placement != nil && c.Placement.Directive != "" {
 		return errors.New("cannot use -n when specifying a placement directive")
 	}
+	if c.Inventory != "" {
+		if c.Placement != nil {
+			return errors.New("cannot use --inventory when specifying a placement directive")
+		}
+		if c.NumMachines > 1 {
+			return errors.New("cannot use -n with --inventory")
+		}
+	}
+	if c.Concurrency < 1 {
+		return errors.Errorf("--concurrency must be at least 1, got %d", c.Concurrency)
+	}
 	return nil
 }
 
@@ -266,6 +312,13 @@ func (c *addCommand) getMachineManagerAPI() (MachineManagerAPI, error) {
 	return c.NewMachineManagerClient()
 }
 
+func (c *addCommand) getStatusAPI() (statusAPI, error) {
+	if c.statusAPI != nil {
+		return c.statusAPI, nil
+	}
+	return c.NewAPIClient()
+}
+
 func (c *addCommand) Run(ctx *cmd.Context) error {
 	var err error
 	c.Constraints, err = common.ParseConstraints(ctx, c.ConstraintsStr)
@@ -306,6 +359,10 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
+	if c.Inventory != "" {
+		return c.provisionInventory(client, cfg, ctx)
+	}
+
 	if c.Placement != nil {
 		err := c.tryManualProvision(client, cfg, ctx)
 		if err != errNonManualScope {
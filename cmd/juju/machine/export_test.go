@@ -32,6 +32,12 @@ func NewAddCommandForTest(api AddMachineAPI, mcAPI ModelConfigAPI, mmAPI Machine
 	return modelcmd.Wrap(command), &AddCommand{command}
 }
 
+// SetStatusAPI sets the API used to find the machines already in the
+// model when provisioning an inventory.
+func (c *AddCommand) SetStatusAPI(api statusAPI) {
+	c.statusAPI = api
+}
+
 // NewListCommandForTest returns a listMachineCommand with specified api
 func NewListCommandForTest(api statusAPI) cmd.Command {
 	command := newListMachinesCommand(api)
@@ -46,6 +52,17 @@ func NewShowCommandForTest(api statusAPI) cmd.Command {
 	return modelcmd.Wrap(command)
 }
 
+// NewShowInstanceTypesCommandForTest returns a show-instance-types
+// command with the APIs provided as specified.
+func NewShowInstanceTypesCommandForTest(api InstanceTypesAPI, mcAPI ModelConfigAPI) cmd.Command {
+	command := &showInstanceTypesCommand{
+		api:            api,
+		modelConfigAPI: mcAPI,
+	}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(command)
+}
+
 type RemoveCommand struct {
 	*removeCommand
 }
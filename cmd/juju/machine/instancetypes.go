@@ -0,0 +1,278 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs/config"
+)
+
+// InstanceTypesAPI defines the machine manager API method used by the
+// show-instance-types command.
+type InstanceTypesAPI interface {
+	InstanceTypes(constraints.Value) (params.InstanceTypesResult, error)
+	Close() error
+}
+
+const showInstanceTypesDoc = `
+Lists the instance types available in the cloud region of the model which
+match the given constraints, cheapest first, together with their cost where
+the cloud reports it. These are the instance types a new machine with the
+same constraints may be started on. Model and application constraints are
+not merged with those given.
+
+On AWS, costs are on-demand prices, or spot prices if the constraints
+include spot-max-price, matching how such machines are launched.
+
+If the model's max-instance-cost config is set, instance types costing more
+are marked; machines which can only be started on such instance types fail
+to provision.
+
+Examples:
+
+    juju show-instance-types
+    juju show-instance-types --constraints "cores=4 mem=16G"
+    juju show-instance-types --constraints arch=arm64 --format yaml
+    juju show-instance-types --constraints "mem=8G spot-max-price=0.1"
+
+See also:
+    add-machine
+    model-config
+    set-model-constraints
+`
+
+// NewShowInstanceTypesCommand returns a command which lists the
+// instance types matching constraints in the model's cloud region.
+func NewShowInstanceTypesCommand() cmd.Command {
+	return modelcmd.Wrap(&showInstanceTypesCommand{})
+}
+
+// showInstanceTypesCommand lists the instance types matching
+// constraints, with their costs.
+type showInstanceTypesCommand struct {
+	baseMachinesCommand
+	out cmd.Output
+
+	api            InstanceTypesAPI
+	modelConfigAPI ModelConfigAPI
+
+	constraintsStr string
+}
+
+// Info implements Command.Info.
+func (c *showInstanceTypesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-instance-types",
+		Purpose: "Lists the instance types matching constraints, with their costs.",
+		Doc:     showInstanceTypesDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showInstanceTypesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.constraintsStr, "constraints", "", "Constraints the instance types must satisfy")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatInstanceTypesTabular,
+	})
+}
+
+func (c *showInstanceTypesCommand) getAPI() (InstanceTypesAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+func (c *showInstanceTypesCommand) getModelConfigAPI() (ModelConfigAPI, error) {
+	if c.modelConfigAPI != nil {
+		return c.modelConfigAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelconfig.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *showInstanceTypesCommand) Run(ctx *cmd.Context) error {
+	cons, err := common.ParseConstraints(ctx, c.constraintsStr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	maxCost, err := c.maxInstanceCost()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.InstanceTypes(cons)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, newInstanceTypesOutput(result, maxCost))
+}
+
+// maxInstanceCost returns the model's max-instance-cost, or nil
+// if it is not set.
+func (c *showInstanceTypesCommand) maxInstanceCost() (*float64, error) {
+	client, err := c.getModelConfigAPI()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer client.Close()
+
+	attrs, err := client.ModelGet()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	raw, _ := attrs[config.MaxInstanceCostKey].(string)
+	if raw == "" {
+		return nil, nil
+	}
+	maxCost, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, errors.NotValidf("max instance cost %q", raw)
+	}
+	return &maxCost, nil
+}
+
+// instanceTypesOutput is the yaml and json output of the
+// show-instance-types command.
+type instanceTypesOutput struct {
+	CostUnit        string               `yaml:"cost-unit,omitempty" json:"cost-unit,omitempty"`
+	CostCurrency    string               `yaml:"cost-currency,omitempty" json:"cost-currency,omitempty"`
+	MaxInstanceCost *float64             `yaml:"max-instance-cost,omitempty" json:"max-instance-cost,omitempty"`
+	InstanceTypes   []instanceTypeDetail `yaml:"instance-types" json:"instance-types"`
+}
+
+type instanceTypeDetail struct {
+	Name           string   `yaml:"name" json:"name"`
+	Arches         []string `yaml:"arches" json:"arches"`
+	CPUCores       int      `yaml:"cpu-cores" json:"cpu-cores"`
+	Memory         int      `yaml:"memory" json:"memory"`
+	RootDisk       int      `yaml:"root-disk,omitempty" json:"root-disk,omitempty"`
+	VirtType       string   `yaml:"virt-type,omitempty" json:"virt-type,omitempty"`
+	Cost           *float64 `yaml:"cost,omitempty" json:"cost,omitempty"`
+	ExceedsMaxCost bool     `yaml:"exceeds-max-instance-cost,omitempty" json:"exceeds-max-instance-cost,omitempty"`
+}
+
+func newInstanceTypesOutput(result params.InstanceTypesResult, maxCost *float64) instanceTypesOutput {
+	out := instanceTypesOutput{
+		CostUnit:      result.CostUnit,
+		CostCurrency:  result.CostCurrency,
+		InstanceTypes: make([]instanceTypeDetail, len(result.InstanceTypes)),
+	}
+	// Costs without a unit are only useful for comparing
+	// instance types, so can't be checked against the maximum.
+	if result.CostUnit != "" {
+		out.MaxInstanceCost = maxCost
+	}
+	for i, itype := range result.InstanceTypes {
+		detail := instanceTypeDetail{
+			Name:     itype.Name,
+			Arches:   itype.Arches,
+			CPUCores: itype.CPUCores,
+			Memory:   itype.Memory,
+			RootDisk: itype.RootDiskSize,
+			VirtType: itype.VirtType,
+		}
+		// Providers report the maximum uint64 cost for instance types
+		// whose cost is not known, which the API server converts to a
+		// negative int. Clouds which don't report a cost unit use zero.
+		if itype.Cost > 0 || (itype.Cost == 0 && result.CostUnit != "") {
+			cost := float64(itype.Cost)
+			if result.CostDivisor > 0 {
+				cost /= float64(result.CostDivisor)
+			}
+			detail.Cost = &cost
+		}
+		if out.MaxInstanceCost != nil {
+			// Machines can't be started on instance types of unknown
+			// cost when a maximum is set.
+			detail.ExceedsMaxCost = detail.Cost == nil || *detail.Cost > *out.MaxInstanceCost
+		}
+		out.InstanceTypes[i] = detail
+	}
+	return out
+}
+
+func formatInstanceTypesTabular(writer io.Writer, value interface{}) error {
+	out, ok := value.(instanceTypesOutput)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", out, value)
+	}
+	costHeader := "Cost"
+	if out.CostUnit != "" {
+		costHeader = fmt.Sprintf("Cost (%s)", out.CostUnit)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Instance type", "Arches", "Cores", "Memory", "Root disk", "Virt type", costHeader)
+	for _, itype := range out.InstanceTypes {
+		rootDisk := "-"
+		if itype.RootDisk > 0 {
+			rootDisk = fmt.Sprintf("%dM", itype.RootDisk)
+		}
+		cost := "-"
+		if itype.Cost != nil {
+			cost = strconv.FormatFloat(*itype.Cost, 'f', -1, 64)
+		}
+		if itype.ExceedsMaxCost {
+			cost += "*"
+		}
+		w.Println(
+			itype.Name,
+			strings.Join(itype.Arches, ","),
+			itype.CPUCores,
+			fmt.Sprintf("%dM", itype.Memory),
+			rootDisk,
+			valueOrDash(itype.VirtType),
+			cost,
+		)
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+	if out.MaxInstanceCost != nil {
+		fmt.Fprintf(writer, "\n* exceeds %s %v\n", config.MaxInstanceCostKey, *out.MaxInstanceCost)
+	}
+	return nil
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,173 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/testing"
+)
+
+type ShowInstanceTypesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api         *fakeInstanceTypesAPI
+	modelConfig *fakeModelConfigAPI
+}
+
+var _ = gc.Suite(&ShowInstanceTypesSuite{})
+
+func (s *ShowInstanceTypesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeInstanceTypesAPI{
+		result: params.InstanceTypesResult{
+			InstanceTypes: []params.InstanceType{{
+				Name:     "t3a.medium",
+				Arches:   []string{"amd64"},
+				CPUCores: 2,
+				Memory:   4096,
+				VirtType: "hvm",
+				Cost:     38,
+			}, {
+				Name:     "m5.large",
+				Arches:   []string{"amd64"},
+				CPUCores: 2,
+				Memory:   8192,
+				VirtType: "hvm",
+				Cost:     96,
+			}, {
+				Name:     "x1.huge",
+				Arches:   []string{"amd64"},
+				CPUCores: 64,
+				Memory:   1048576,
+				Cost:     -1,
+			}},
+			CostUnit:     "$USD/hour",
+			CostCurrency: "USD",
+			CostDivisor:  1000,
+		},
+	}
+	s.modelConfig = &fakeModelConfigAPI{attrs: map[string]interface{}{}}
+}
+
+func (s *ShowInstanceTypesSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := machine.NewShowInstanceTypesCommandForTest(s.api, s.modelConfig)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *ShowInstanceTypesSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c, "--constraints", "mem=4G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.constraints, jc.DeepEquals, []constraints.Value{constraints.MustParse("mem=4G")})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Instance type  Arches  Cores  Memory    Root disk  Virt type  Cost ($USD/hour)
+t3a.medium     amd64   2      4096M     -          hvm        0.038
+m5.large       amd64   2      8192M     -          hvm        0.096
+x1.huge        amd64   64     1048576M  -          -          -
+
+`[1:])
+}
+
+func (s *ShowInstanceTypesSuite) TestTabularMaxInstanceCost(c *gc.C) {
+	s.modelConfig.attrs["max-instance-cost"] = "0.05"
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Instance type  Arches  Cores  Memory    Root disk  Virt type  Cost ($USD/hour)
+t3a.medium     amd64   2      4096M     -          hvm        0.038
+m5.large       amd64   2      8192M     -          hvm        0.096*
+x1.huge        amd64   64     1048576M  -          -          -*
+
+* exceeds max-instance-cost 0.05
+
+`[1:])
+}
+
+func (s *ShowInstanceTypesSuite) TestYAML(c *gc.C) {
+	s.modelConfig.attrs["max-instance-cost"] = "0.05"
+	s.api.result.InstanceTypes = s.api.result.InstanceTypes[1:2]
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+cost-unit: $USD/hour
+cost-currency: USD
+max-instance-cost: 0.05
+instance-types:
+- name: m5.large
+  arches:
+  - amd64
+  cpu-cores: 2
+  memory: 8192
+  virt-type: hvm
+  cost: 0.096
+  exceeds-max-instance-cost: true
+`[1:])
+}
+
+func (s *ShowInstanceTypesSuite) TestNoCostUnit(c *gc.C) {
+	// Costs without a unit can't be compared with max-instance-cost.
+	s.modelConfig.attrs["max-instance-cost"] = "0.05"
+	s.api.result = params.InstanceTypesResult{
+		InstanceTypes: []params.InstanceType{{
+			Name:     "Standard_A1",
+			Arches:   []string{"amd64"},
+			CPUCores: 1,
+			Memory:   1792,
+			Cost:     60,
+		}},
+		CostCurrency: "USD",
+	}
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Instance type  Arches  Cores  Memory  Root disk  Virt type  Cost
+Standard_A1    amd64   1      1792M   -          -          60
+
+`[1:])
+}
+
+func (s *ShowInstanceTypesSuite) TestError(c *gc.C) {
+	s.api.err = errors.New(`no instance types in us-east-1 matching constraints "mem=1P"`)
+	_, err := s.run(c, "--constraints", "mem=1P")
+	c.Assert(err, gc.ErrorMatches, `no instance types in us-east-1 matching constraints "mem=1P"`)
+}
+
+func (s *ShowInstanceTypesSuite) TestInvalidConstraints(c *gc.C) {
+	_, err := s.run(c, "--constraints", "foo=bar")
+	c.Assert(err, gc.ErrorMatches, `unknown constraint "foo"`)
+	c.Assert(s.api.constraints, gc.HasLen, 0)
+}
+
+type fakeInstanceTypesAPI struct {
+	result      params.InstanceTypesResult
+	err         error
+	constraints []constraints.Value
+}
+
+func (f *fakeInstanceTypesAPI) InstanceTypes(cons constraints.Value) (params.InstanceTypesResult, error) {
+	f.constraints = append(f.constraints, cons)
+	return f.result, f.err
+}
+
+func (f *fakeInstanceTypesAPI) Close() error {
+	return nil
+}
+
+type fakeModelConfigAPI struct {
+	attrs map[string]interface{}
+}
+
+func (f *fakeModelConfigAPI) ModelGet() (map[string]interface{}, error) {
+	return f.attrs, nil
+}
+
+func (f *fakeModelConfigAPI) Close() error {
+	return nil
+}
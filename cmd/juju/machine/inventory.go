@@ -0,0 +1,227 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/ssh"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/manual"
+)
+
+// defaultInventoryConcurrency is the number of hosts in an inventory
+// which are provisioned at once, unless --concurrency is specified.
+const defaultInventoryConcurrency = 5
+
+// inventory describes the hosts to be manually provisioned by
+// add-machine --inventory.
+type inventory struct {
+	// Defaults holds the SSH settings used for hosts
+	// which don't specify their own.
+	Defaults inventoryHost `yaml:"defaults"`
+
+	// Hosts holds the hosts to provision.
+	Hosts []inventoryHost `yaml:"hosts"`
+}
+
+// inventoryHost holds a host to provision, and how to connect to it
+// over SSH.
+type inventoryHost struct {
+	Host         string `yaml:"host"`
+	User         string `yaml:"user,omitempty"`
+	Port         int    `yaml:"port,omitempty"`
+	IdentityFile string `yaml:"identity-file,omitempty"`
+}
+
+// sshOptions returns the options for SSH connections to the host.
+func (h inventoryHost) sshOptions() *ssh.Options {
+	var options ssh.Options
+	if h.Port != 0 {
+		options.SetPort(h.Port)
+	}
+	if h.IdentityFile != "" {
+		options.SetIdentities(h.IdentityFile)
+	}
+	return &options
+}
+
+// readInventory reads the inventory file at the given path, returning
+// its hosts with the inventory defaults applied.
+func readInventory(path string) ([]inventoryHost, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var inv inventory
+	if err := yaml.UnmarshalStrict(data, &inv); err != nil {
+		return nil, errors.Annotatef(err, "cannot parse inventory %q", path)
+	}
+	if inv.Defaults.Host != "" {
+		return nil, errors.NotValidf("host in inventory defaults")
+	}
+	if len(inv.Hosts) == 0 {
+		return nil, errors.NotFoundf("hosts in inventory %q", path)
+	}
+
+	seen := set.NewStrings()
+	hosts := make([]inventoryHost, len(inv.Hosts))
+	for i, h := range inv.Hosts {
+		if h.User == "" {
+			h.User, h.Host = splitUserHost(h.Host)
+		}
+		if h.Host == "" {
+			return nil, errors.NotValidf("inventory entry %d without host", i+1)
+		}
+		if seen.Contains(h.Host) {
+			return nil, errors.NotValidf("duplicate host %q in inventory", h.Host)
+		}
+		seen.Add(h.Host)
+		if h.User == "" {
+			h.User = inv.Defaults.User
+		}
+		if h.Port == 0 {
+			h.Port = inv.Defaults.Port
+		}
+		if h.Port < 0 || h.Port > 65535 {
+			return nil, errors.NotValidf("port %d for host %q", h.Port, h.Host)
+		}
+		if h.IdentityFile == "" {
+			h.IdentityFile = inv.Defaults.IdentityFile
+		}
+		if h.IdentityFile != "" {
+			if h.IdentityFile, err = utils.NormalizePath(h.IdentityFile); err != nil {
+				return nil, errors.Annotatef(err, "identity file for host %q", h.Host)
+			}
+		}
+		hosts[i] = h
+	}
+	return hosts, nil
+}
+
+// inventoryResult records the outcome of provisioning an inventory host.
+type inventoryResult struct {
+	host      string
+	machineId string
+	skipped   string
+	err       error
+}
+
+// provisionInventory provisions the hosts in the command's inventory
+// file over SSH, several at a time, and reports the outcome for each.
+// Hosts which are already machines in the model are skipped, so that
+// the command may be run again to retry those which failed.
+func (c *addCommand) provisionInventory(client AddMachineAPI, config *config.Config, ctx *cmd.Context) error {
+	hosts, err := readInventory(c.Inventory)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	authKeys, err := common.ReadAuthorizedKeys(ctx, "")
+	if err != nil {
+		return errors.Annotatef(err, "cannot reading authorized-keys")
+	}
+	registered, err := c.manualHosts()
+	if err != nil {
+		return errors.Annotate(err, "cannot get existing machines")
+	}
+
+	results := make([]inventoryResult, len(hosts))
+	sem := make(chan struct{}, c.Concurrency)
+	var wg sync.WaitGroup
+	for i, h := range hosts {
+		results[i].host = h.Host
+		if registered.Contains(h.Host) {
+			results[i].skipped = "already a machine in the model"
+			continue
+		}
+		wg.Add(1)
+		go func(h inventoryHost, result *inventoryResult) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			// Output from concurrent provisioning can't be interleaved
+			// usefully, so it's only logged when provisioning fails.
+			var output bytes.Buffer
+			args := manual.ProvisionMachineArgs{
+				Host:           h.Host,
+				User:           h.User,
+				Client:         client,
+				Stdin:          strings.NewReader(""),
+				Stdout:         &output,
+				Stderr:         &output,
+				AuthorizedKeys: authKeys,
+				SSHOptions:     h.sshOptions(),
+				NonInteractive: true,
+				UpdateBehavior: &params.UpdateBehavior{
+					EnableOSRefreshUpdate: config.EnableOSRefreshUpdate(),
+					EnableOSUpgrade:       config.EnableOSUpgrade(),
+				},
+			}
+			logger.Infof("provisioning %q", h.Host)
+			result.machineId, result.err = sshProvisioner(args)
+			if errors.Cause(result.err) == manual.ErrProvisioned {
+				result.skipped = "already provisioned"
+				result.err = nil
+			} else if result.err != nil {
+				logger.Debugf("provisioning %q failed, output:\n%s", h.Host, output.String())
+			}
+		}(h, &results[i])
+	}
+	wg.Wait()
+
+	var provisioned, skipped, failed int
+	for _, result := range results {
+		switch {
+		case result.err != nil:
+			failed++
+			ctx.Infof("%s: failed: %v", result.host, result.err)
+		case result.skipped != "":
+			skipped++
+			ctx.Infof("%s: skipped, %s", result.host, result.skipped)
+		default:
+			provisioned++
+			ctx.Infof("%s: created machine %v", result.host, result.machineId)
+		}
+	}
+	ctx.Infof("provisioned %d, skipped %d, failed %d of %d hosts", provisioned, skipped, failed, len(results))
+	if failed > 0 {
+		return errors.Errorf("failed to provision %d of %d hosts", failed, len(results))
+	}
+	return nil
+}
+
+// manualHosts returns the hosts of the manually provisioned machines
+// in the model.
+func (c *addCommand) manualHosts() (set.Strings, error) {
+	client, err := c.getStatusAPI()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer client.Close()
+
+	status, err := client.Status(nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	hosts := set.NewStrings()
+	for _, m := range status.Machines {
+		id := string(m.InstanceId)
+		if strings.HasPrefix(id, manual.ManualInstancePrefix) {
+			hosts.Add(strings.TrimPrefix(id, manual.ManualInstancePrefix))
+		}
+	}
+	return hosts, nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	"github.com/juju/utils/ssh"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/testing"
+)
+
+type InventorySuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fakeAddMachine     *fakeAddMachineAPI
+	fakeMachineManager *fakeMachineManagerAPI
+	fakeStatus         *fakeManualStatusAPI
+
+	mu          sync.Mutex
+	provisioned []manual.ProvisionMachineArgs
+}
+
+var _ = gc.Suite(&InventorySuite{})
+
+func (s *InventorySuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fakeAddMachine = &fakeAddMachineAPI{}
+	s.fakeMachineManager = &fakeMachineManagerAPI{}
+	s.fakeStatus = &fakeManualStatusAPI{}
+	s.provisioned = nil
+}
+
+const inventoryYAML = `
+defaults:
+  user: admin
+  port: 2222
+  identity-file: /keys/default
+hosts:
+  - host: 10.0.0.1
+  - host: 10.0.0.2
+    user: ubuntu
+    port: 22
+    identity-file: /keys/other
+  - host: root@10.0.0.3
+`
+
+func (s *InventorySuite) writeInventory(c *gc.C, content string) string {
+	path := filepath.Join(c.MkDir(), "hosts.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *InventorySuite) patchProvisioner(results map[string]error) {
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err := results[args.Host]; err != nil {
+			return "", err
+		}
+		s.provisioned = append(s.provisioned, args)
+		return "m-" + args.Host, nil
+	})
+}
+
+func (s *InventorySuite) run(c *gc.C, args ...string) (string, error) {
+	add, cmd := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager)
+	cmd.SetStatusAPI(s.fakeStatus)
+	ctx, err := cmdtesting.RunCommand(c, add, args...)
+	if ctx == nil {
+		return "", err
+	}
+	return cmdtesting.Stderr(ctx), err
+}
+
+func (s *InventorySuite) TestProvisionInventory(c *gc.C) {
+	s.patchProvisioner(nil)
+	stderr, err := s.run(c, "--inventory", s.writeInventory(c, inventoryYAML))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stderr, gc.Equals, `
+10.0.0.1: created machine m-10.0.0.1
+10.0.0.2: created machine m-10.0.0.2
+10.0.0.3: created machine m-10.0.0.3
+provisioned 3, skipped 0, failed 0 of 3 hosts
+`[1:])
+
+	sort.Slice(s.provisioned, func(i, j int) bool {
+		return s.provisioned[i].Host < s.provisioned[j].Host
+	})
+	c.Assert(s.provisioned, gc.HasLen, 3)
+	for i, expect := range []struct {
+		user     string
+		port     int
+		identity string
+	}{
+		{"admin", 2222, "/keys/default"},
+		{"ubuntu", 22, "/keys/other"},
+		{"root", 2222, "/keys/default"},
+	} {
+		args := s.provisioned[i]
+		c.Check(args.User, gc.Equals, expect.user)
+		c.Check(args.NonInteractive, jc.IsTrue)
+		c.Check(args.SSHOptions, jc.DeepEquals, newSSHOptions(expect.port, expect.identity))
+	}
+}
+
+func (s *InventorySuite) TestProvisionInventoryExpandsIdentityFile(c *gc.C) {
+	s.patchProvisioner(nil)
+	_, err := s.run(c, "--inventory", s.writeInventory(c, `
+hosts:
+  - host: 10.0.0.1
+    identity-file: ~/.ssh/deploy
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.provisioned, gc.HasLen, 1)
+	expected, err := utils.NormalizePath("~/.ssh/deploy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.provisioned[0].SSHOptions, jc.DeepEquals, newSSHOptions(0, expected))
+}
+
+func (s *InventorySuite) TestProvisionInventorySkipsAndFails(c *gc.C) {
+	s.fakeStatus.instanceIds = []instance.Id{"manual:10.0.0.1", "i-abcdef"}
+	s.patchProvisioner(map[string]error{
+		"10.0.0.2": errors.Annotate(manual.ErrProvisioned, "checking provisioned"),
+		"10.0.0.3": errors.New("connection refused"),
+	})
+	stderr, err := s.run(c, "--inventory", s.writeInventory(c, inventoryYAML), "--concurrency", "1")
+	c.Assert(err, gc.ErrorMatches, "failed to provision 1 of 3 hosts")
+	c.Assert(stderr, gc.Equals, `
+10.0.0.1: skipped, already a machine in the model
+10.0.0.2: skipped, already provisioned
+10.0.0.3: failed: connection refused
+provisioned 0, skipped 2, failed 1 of 3 hosts
+`[1:])
+	c.Assert(s.provisioned, gc.HasLen, 0)
+}
+
+func (s *InventorySuite) TestInventoryInvalid(c *gc.C) {
+	for _, t := range []struct {
+		content string
+		err     string
+	}{{
+		content: "hosts: []\n",
+		err:     `hosts in inventory ".*hosts.yaml" not found`,
+	}, {
+		content: "hosts:\n  - hostname: foo\n",
+		err:     `(?s)cannot parse inventory ".*hosts.yaml": .*field hostname not found.*`,
+	}, {
+		content: "hosts:\n  - user: foo\n",
+		err:     `inventory entry 1 without host not valid`,
+	}, {
+		content: "hosts:\n  - host: foo\n  - host: bar@foo\n",
+		err:     `duplicate host "foo" in inventory not valid`,
+	}, {
+		content: "hosts:\n  - host: foo\n    port: 70000\n",
+		err:     `port 70000 for host "foo" not valid`,
+	}, {
+		content: "defaults:\n  host: foo\nhosts:\n  - host: bar\n",
+		err:     `host in inventory defaults not valid`,
+	}} {
+		s.patchProvisioner(nil)
+		_, err := s.run(c, "--inventory", s.writeInventory(c, t.content))
+		c.Check(err, gc.ErrorMatches, t.err)
+		c.Check(s.provisioned, gc.HasLen, 0)
+	}
+}
+
+func (s *InventorySuite) TestInit(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--inventory", "hosts.yaml", "ssh:10.0.0.1"},
+		err:  "cannot use --inventory when specifying a placement directive",
+	}, {
+		args: []string{"--inventory", "hosts.yaml", "-n", "2"},
+		err:  "cannot use -n with --inventory",
+	}, {
+		args: []string{"--inventory", "hosts.yaml", "--concurrency", "0"},
+		err:  "--concurrency must be at least 1, got 0",
+	}} {
+		add, _ := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager)
+		err := cmdtesting.InitCommand(add, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func newSSHOptions(port int, identity string) *ssh.Options {
+	var options ssh.Options
+	if port != 0 {
+		options.SetPort(port)
+	}
+	options.SetIdentities(identity)
+	return &options
+}
+
+type fakeManualStatusAPI struct {
+	instanceIds []instance.Id
+}
+
+func (f *fakeManualStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
+	machines := make(map[string]params.MachineStatus)
+	for i, id := range f.instanceIds {
+		machines[strconv.Itoa(i)] = params.MachineStatus{InstanceId: id}
+	}
+	return &params.FullStatus{Machines: machines}, nil
+}
+
+func (*fakeManualStatusAPI) Close() error {
+	return nil
+}
@@ -5,15 +5,23 @@ package model
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/api/modelgeneration"
+	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
 )
 
 const (
@@ -23,8 +31,27 @@ Committing a branch writes changes to charm configuration made under the
 branch, to the model. All units who's applications were changed under the 
 branch realise those changes, as will any new units.
 
+If configuration changed under the branch has also been changed in "master"
+since the branch changed it, the branch is in conflict with "master". By
+default the branch values are committed, overwriting those in "master". Use
+"juju diff" to see the conflicting values. The --on-conflict option
+determines how conflicts are handled:
+    abort:  do not commit the branch
+    branch: commit the branch values, overwriting those in "master"
+    master: keep the "master" values and commit the other branch changes
+
+Supplying --batch-size commits the branch gradually. Units of the changed
+applications that are not yet tracking the branch are set to track it in
+batches of the given size for each application. After each batch the
+command waits for --batch-wait, then checks the workload status of the units
+tracking the branch. If any are in error, the branch is not committed and
+no more units are moved; fix the problem and run the command again to
+continue. Once all units are tracking the branch, it is committed.
+
 Examples:
     juju commit upgrade-postgresql
+    juju commit upgrade-postgresql --on-conflict master
+    juju commit upgrade-postgresql --batch-size 2 --batch-wait 5m
 
 See also:
     add-branch
@@ -37,7 +64,7 @@ See also:
 
 // NewCommitCommand wraps commitCommand with sane model settings.
 func NewCommitCommand() cmd.Command {
-	return modelcmd.Wrap(&commitCommand{})
+	return modelcmd.Wrap(&commitCommand{clock: clock.WallClock})
 }
 
 // commitCommand supplies the "commit" CLI command used to commit changes made
@@ -45,13 +72,18 @@ func NewCommitCommand() cmd.Command {
 type commitCommand struct {
 	modelcmd.ModelCommandBase
 
-	api CommitCommandAPI
+	api       CommitCommandAPI
+	statusAPI CommitStatusAPI
+	clock     clock.Clock
 
 	branchName string
+	onConflict string
+	batchSize  int
+	batchWait  time.Duration
 }
 
 // CommitCommandAPI defines an API interface to be used during testing.
-//go:generate go run github.com/golang/mock/mockgen -package mocks -destination ./mocks/commit_mock.go github.com/juju/juju/cmd/juju/model CommitCommandAPI
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination ./mocks/commit_mock.go github.com/juju/juju/cmd/juju/model CommitCommandAPI,CommitStatusAPI
 type CommitCommandAPI interface {
 	Close() error
 
@@ -60,6 +92,23 @@ type CommitCommandAPI interface {
 	// all branch changes across the model.
 	// The new generation ID of the model is returned.
 	CommitBranch(branchName string) (int, error)
+
+	// CommitBranchWithResolution commits the branch as for CommitBranch,
+	// resolving conflicts with changes made to master as indicated.
+	CommitBranchWithResolution(branchName string, resolution model.BranchConflictResolution) (int, error)
+
+	// BranchInfo returns information about "in-flight" branches.
+	BranchInfo(branchName string, detailed bool, formatTime func(time.Time) string) (model.GenerationSummaries, error)
+
+	// TrackBranch sets the input units and/or applications
+	// to track changes made under the input branch name.
+	TrackBranch(branchName string, entities []string, numUnits int) error
+}
+
+// CommitStatusAPI defines the API method used to check the workload
+// status of units tracking a branch that is being committed gradually.
+type CommitStatusAPI interface {
+	Status(patterns []string) (*params.FullStatus, error)
 }
 
 // Info implements part of the cmd.Command interface.
@@ -76,6 +125,9 @@ func (c *commitCommand) Info() *cmd.Info {
 // SetFlags implements part of the cmd.Command interface.
 func (c *commitCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.onConflict, "on-conflict", "", `How to handle conflicts with "master": abort, branch or master`)
+	f.IntVar(&c.batchSize, "batch-size", 0, "Move units to the branch in batches of this size before committing")
+	f.DurationVar(&c.batchWait, "batch-wait", time.Minute, "How long to wait after each batch before checking workload status")
 }
 
 // Init implements part of the cmd.Command interface.
@@ -84,6 +136,18 @@ func (c *commitCommand) Init(args []string) error {
 		return errors.Errorf("must specify a branch name to commit")
 	}
 	c.branchName = args[0]
+
+	if c.onConflict != "" {
+		if err := model.BranchConflictResolution(c.onConflict).Validate(); err != nil {
+			return errors.Errorf("--on-conflict must be one of abort, branch or master, got %q", c.onConflict)
+		}
+	}
+	if c.batchSize < 0 {
+		return errors.New("--batch-size cannot be negative")
+	}
+	if c.batchWait <= 0 {
+		return errors.New("--batch-wait must be a positive duration")
+	}
 	return nil
 }
 
@@ -97,6 +161,9 @@ func (c *commitCommand) getAPI() (CommitCommandAPI, error) {
 	if err != nil {
 		return nil, errors.Annotate(err, "opening API connection")
 	}
+	// The status client shares the connection,
+	// which is closed along with the branch client.
+	c.statusAPI = api.Client()
 	client := modelgeneration.NewClient(api)
 	return client, nil
 }
@@ -109,7 +176,13 @@ func (c *commitCommand) Run(ctx *cmd.Context) error {
 	}
 	defer func() { _ = client.Close() }()
 
-	newGenId, err := client.CommitBranch(c.branchName)
+	if c.batchSize > 0 {
+		if err := c.trackInBatches(ctx, client); err != nil {
+			return errors.Annotatef(err, "branch %q not committed", c.branchName)
+		}
+	}
+
+	newGenId, err := c.commit(client)
 	if err != nil {
 		return err
 	}
@@ -133,3 +206,107 @@ func (c *commitCommand) Run(ctx *cmd.Context) error {
 	_, err = ctx.Stdout.Write([]byte(msg))
 	return err
 }
+
+// commit commits the branch, resolving conflicts as requested. Without
+// --on-conflict the branch values overwrite master, as they always have.
+// Controllers that can not detect conflicts only support that resolution.
+func (c *commitCommand) commit(client CommitCommandAPI) (int, error) {
+	if c.onConflict == "" {
+		return client.CommitBranch(c.branchName)
+	}
+	resolution := model.BranchConflictResolution(c.onConflict)
+	newGenId, err := client.CommitBranchWithResolution(c.branchName, resolution)
+	if errors.IsNotSupported(err) && resolution == model.ConflictBranchWins {
+		return client.CommitBranch(c.branchName)
+	}
+	return newGenId, errors.Trace(err)
+}
+
+// trackInBatches sets the units of applications changed under the branch to
+// track it, a batch at a time, until all are tracking it. The workload status
+// of tracking units is checked before each batch and after the last.
+func (c *commitCommand) trackInBatches(ctx *cmd.Context, client CommitCommandAPI) error {
+	formatTime := func(t time.Time) string { return t.String() }
+	for {
+		summaries, err := client.BranchInfo(c.branchName, true, formatTime)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		branch, ok := summaries[c.branchName]
+		if !ok {
+			return errors.NotFoundf("branch %q", c.branchName)
+		}
+		if err := c.checkTrackingUnits(branch); err != nil {
+			return errors.Trace(err)
+		}
+
+		var moved bool
+		for _, app := range branch.Applications {
+			if app.UnitDetail == nil || len(app.UnitDetail.UnitsPending) == 0 {
+				continue
+			}
+			if err := client.TrackBranch(c.branchName, []string{app.ApplicationName}, c.batchSize); err != nil {
+				return errors.Trace(err)
+			}
+			num := c.batchSize
+			if pending := len(app.UnitDetail.UnitsPending); pending < num {
+				num = pending
+			}
+			noun := "units"
+			if num == 1 {
+				noun = "unit"
+			}
+			ctx.Infof("Set %d more %s of %q to track branch %q", num, noun, app.ApplicationName, c.branchName)
+			moved = true
+		}
+		if !moved {
+			return nil
+		}
+
+		ctx.Infof("Waiting %v before checking workload status", c.batchWait)
+		<-c.clock.After(c.batchWait)
+	}
+}
+
+// checkTrackingUnits returns an error if the workload
+// of any unit tracking the branch is in error.
+func (c *commitCommand) checkTrackingUnits(branch model.Generation) error {
+	tracking := make(map[string]set.Strings)
+	var appNames []string
+	for _, app := range branch.Applications {
+		if app.UnitDetail == nil || len(app.UnitDetail.UnitsTracking) == 0 {
+			continue
+		}
+		tracking[app.ApplicationName] = set.NewStrings(app.UnitDetail.UnitsTracking...)
+		appNames = append(appNames, app.ApplicationName)
+	}
+	if len(appNames) == 0 {
+		return nil
+	}
+
+	fullStatus, err := c.statusAPI.Status(appNames)
+	if err != nil {
+		return errors.Annotate(err, "checking workload status")
+	}
+	// Subordinate units are only reported under their principals, which
+	// may belong to any application.
+	var inError []string
+	var check func(units map[string]params.UnitStatus)
+	check = func(units map[string]params.UnitStatus) {
+		for unitName, unit := range units {
+			appName, err := names.UnitApplication(unitName)
+			if err == nil && tracking[appName].Contains(unitName) && unit.WorkloadStatus.Status == status.Error.String() {
+				inError = append(inError, unitName)
+			}
+			check(unit.Subordinates)
+		}
+	}
+	for _, app := range fullStatus.Applications {
+		check(app.Units)
+	}
+	if len(inError) > 0 {
+		sort.Strings(inError)
+		return errors.Errorf("tracking units with workload in error: %s", strings.Join(inError, ", "))
+	}
+	return nil
+}
@@ -4,24 +4,37 @@
 package model_test
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/golang/mock/gomock"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/model"
 	"github.com/juju/juju/cmd/juju/model/mocks"
 	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/testing"
 )
 
 type commitSuite struct {
 	generationBaseSuite
+
+	clock *testclock.Clock
 }
 
 var _ = gc.Suite(&commitSuite{})
 
+func (s *commitSuite) SetUpTest(c *gc.C) {
+	s.generationBaseSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC))
+}
+
 func (s *commitSuite) TestInit(c *gc.C) {
 	err := s.runInit(s.branchName)
 	c.Assert(err, jc.ErrorIsNil)
@@ -32,6 +45,16 @@ func (s *commitSuite) TestInitFail(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "must specify a branch name to commit")
 }
 
+func (s *commitSuite) TestInitInvalidOnConflict(c *gc.C) {
+	err := s.runInit(s.branchName, "--on-conflict", "mine")
+	c.Assert(err, gc.ErrorMatches, `--on-conflict must be one of abort, branch or master, got "mine"`)
+}
+
+func (s *commitSuite) TestInitNegativeBatchSize(c *gc.C) {
+	err := s.runInit(s.branchName, "--batch-size", "-1")
+	c.Assert(err, gc.ErrorMatches, "--batch-size cannot be negative")
+}
+
 func (s *commitSuite) TestRunCommandAborted(c *gc.C) {
 	ctrl, api := setUpCancelMocks(c)
 	defer ctrl.Finish()
@@ -82,12 +105,183 @@ func (s *commitSuite) TestRunCommandFail(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "fail")
 }
 
+func (s *commitSuite) TestRunCommandOnConflict(c *gc.C) {
+	ctrl, api := setUpCancelMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().CommitBranchWithResolution(s.branchName, coremodel.ConflictMasterWins).Return(3, nil)
+
+	ctx, err := s.runCommand(c, api, "--on-conflict", "master")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Branch "new-branch" committed; model is now at generation 3
+Active branch set to "master"
+`[1:])
+}
+
+func (s *commitSuite) TestRunCommandConflictResolutionNotSupported(c *gc.C) {
+	ctrl, api := setUpCancelMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().CommitBranchWithResolution(s.branchName, coremodel.ConflictBranchWins).Return(0, errors.NotSupportedf("conflicts"))
+	api.EXPECT().CommitBranch(s.branchName).Return(3, nil)
+
+	ctx, err := s.runCommand(c, api, "--on-conflict", "branch")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Branch "new-branch" committed; model is now at generation 3
+Active branch set to "master"
+`[1:])
+}
+
+func (s *commitSuite) TestRunCommandOnConflictNotSupported(c *gc.C) {
+	ctrl, api := setUpCancelMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().CommitBranchWithResolution(s.branchName, coremodel.ConflictMasterWins).Return(0, errors.NotSupportedf("conflicts"))
+
+	_, err := s.runCommand(c, api, "--on-conflict", "master")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *commitSuite) TestRunCommandInBatches(c *gc.C) {
+	ctrl, api := setUpCancelMocks(c)
+	defer ctrl.Finish()
+	statusAPI := mocks.NewMockCommitStatusAPI(ctrl)
+
+	gomock.InOrder(
+		api.EXPECT().BranchInfo(s.branchName, true, gomock.Any()).Return(s.branchInfo(
+			[]string{"redis/0"}, []string{"redis/1", "redis/2", "redis/3"}), nil),
+		statusAPI.EXPECT().Status([]string{"redis"}).Return(redisStatus("active", "active", "active", "active"), nil),
+		api.EXPECT().TrackBranch(s.branchName, []string{"redis"}, 2).Return(nil),
+		api.EXPECT().BranchInfo(s.branchName, true, gomock.Any()).Return(s.branchInfo(
+			[]string{"redis/0", "redis/1", "redis/2"}, []string{"redis/3"}), nil),
+		statusAPI.EXPECT().Status([]string{"redis"}).Return(redisStatus("active", "active", "active", "active"), nil),
+		api.EXPECT().TrackBranch(s.branchName, []string{"redis"}, 2).Return(nil),
+		api.EXPECT().BranchInfo(s.branchName, true, gomock.Any()).Return(s.branchInfo(
+			[]string{"redis/0", "redis/1", "redis/2", "redis/3"}, nil), nil),
+		statusAPI.EXPECT().Status([]string{"redis"}).Return(redisStatus("active", "active", "active", "active"), nil),
+		api.EXPECT().CommitBranch(s.branchName).Return(3, nil),
+	)
+
+	go func() {
+		for i := 0; i < 2; i++ {
+			c.Check(s.clock.WaitAdvance(5*time.Minute, testing.LongWait, 1), jc.ErrorIsNil)
+		}
+	}()
+	ctx, err := s.runCommandWithStatus(c, api, statusAPI, "--batch-size", "2", "--batch-wait", "5m")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+Set 2 more units of "redis" to track branch "new-branch"
+Waiting 5m0s before checking workload status
+Set 1 more unit of "redis" to track branch "new-branch"
+Waiting 5m0s before checking workload status
+`[1:])
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Branch "new-branch" committed; model is now at generation 3
+Active branch set to "master"
+`[1:])
+}
+
+func (s *commitSuite) TestRunCommandInBatchesWorkloadError(c *gc.C) {
+	ctrl, api := setUpCancelMocks(c)
+	defer ctrl.Finish()
+	statusAPI := mocks.NewMockCommitStatusAPI(ctrl)
+
+	gomock.InOrder(
+		api.EXPECT().BranchInfo(s.branchName, true, gomock.Any()).Return(s.branchInfo(
+			[]string{"redis/0", "redis/1"}, []string{"redis/2", "redis/3"}), nil),
+		statusAPI.EXPECT().Status([]string{"redis"}).Return(redisStatus("active", "error", "error", "active"), nil),
+	)
+
+	_, err := s.runCommandWithStatus(c, api, statusAPI, "--batch-size", "2")
+	c.Assert(err, gc.ErrorMatches, `branch "new-branch" not committed: tracking units with workload in error: redis/1`)
+}
+
+func (s *commitSuite) TestRunCommandOnConflictAbort(c *gc.C) {
+	ctrl, api := setUpCancelMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().CommitBranchWithResolution(s.branchName, coremodel.ConflictAbort).Return(0, errors.New(`branch "new-branch" conflicts with master`))
+
+	_, err := s.runCommand(c, api, "--on-conflict", "abort")
+	c.Assert(err, gc.ErrorMatches, `branch "new-branch" conflicts with master`)
+}
+
+func (s *commitSuite) TestRunCommandInBatchesSubordinateWorkloadError(c *gc.C) {
+	ctrl, api := setUpCancelMocks(c)
+	defer ctrl.Finish()
+	statusAPI := mocks.NewMockCommitStatusAPI(ctrl)
+
+	branch := coremodel.GenerationSummaries{
+		s.branchName: {
+			Applications: []coremodel.GenerationApplication{{
+				ApplicationName: "logging",
+				UnitDetail: &coremodel.GenerationUnits{
+					UnitsTracking: []string{"logging/0"},
+					UnitsPending:  []string{"logging/1"},
+				},
+			}},
+		},
+	}
+	fullStatus := redisStatus("active", "active")
+	redis := fullStatus.Applications["redis"]
+	redis.Units["redis/0"] = params.UnitStatus{
+		WorkloadStatus: params.DetailedStatus{Status: "active"},
+		Subordinates: map[string]params.UnitStatus{
+			"logging/0": {WorkloadStatus: params.DetailedStatus{Status: "error"}},
+		},
+	}
+	gomock.InOrder(
+		api.EXPECT().BranchInfo(s.branchName, true, gomock.Any()).Return(branch, nil),
+		statusAPI.EXPECT().Status([]string{"logging"}).Return(fullStatus, nil),
+	)
+
+	_, err := s.runCommandWithStatus(c, api, statusAPI, "--batch-size", "1")
+	c.Assert(err, gc.ErrorMatches, `branch "new-branch" not committed: tracking units with workload in error: logging/0`)
+}
+
+func (s *commitSuite) branchInfo(tracking, pending []string) coremodel.GenerationSummaries {
+	return coremodel.GenerationSummaries{
+		s.branchName: {
+			Applications: []coremodel.GenerationApplication{{
+				ApplicationName: "redis",
+				UnitDetail: &coremodel.GenerationUnits{
+					UnitsTracking: tracking,
+					UnitsPending:  pending,
+				},
+			}},
+		},
+	}
+}
+
+func redisStatus(workloads ...string) *params.FullStatus {
+	units := make(map[string]params.UnitStatus)
+	for i, workload := range workloads {
+		units[fmt.Sprintf("redis/%d", i)] = params.UnitStatus{
+			WorkloadStatus: params.DetailedStatus{Status: workload},
+		}
+	}
+	return &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"redis": {Units: units},
+		},
+	}
+}
+
 func (s *commitSuite) runInit(args ...string) error {
-	return cmdtesting.InitCommand(model.NewCommitCommandForTest(nil, s.store), args)
+	return cmdtesting.InitCommand(model.NewCommitCommandForTest(nil, nil, s.clock, s.store), args)
+}
+
+func (s *commitSuite) runCommand(c *gc.C, api model.CommitCommandAPI, args ...string) (*cmd.Context, error) {
+	return s.runCommandWithStatus(c, api, nil, args...)
 }
 
-func (s *commitSuite) runCommand(c *gc.C, api model.CommitCommandAPI) (*cmd.Context, error) {
-	return cmdtesting.RunCommand(c, model.NewCommitCommandForTest(api, s.store), s.branchName)
+func (s *commitSuite) runCommandWithStatus(
+	c *gc.C, api model.CommitCommandAPI, statusAPI model.CommitStatusAPI, args ...string,
+) (*cmd.Context, error) {
+	cmd := model.NewCommitCommandForTest(api, statusAPI, s.clock, s.store)
+	return cmdtesting.RunCommand(c, cmd, append([]string{s.branchName}, args...)...)
 }
 
 func setUpCancelMocks(c *gc.C) (*gomock.Controller, *mocks.MockCommitCommandAPI) {
@@ -29,6 +29,13 @@ Details displayed include:
 - when it was created
 - configuration changes made under the branch for each application
 - a summary of how many units are tracking the branch
+- a three-way comparison of each configuration value changed under the
+  branch, showing its value in "master" when the branch changed it (base),
+  its value under the branch, and its current value in "master"
+
+Configuration changed in "master" since the branch changed it is marked as a
+conflict. Committing the branch would overwrite those "master" changes; see
+"juju commit" for how conflicts can be resolved.
 
 Supplying the --all flag will show units tracking the branch and those still
 tracking "master".
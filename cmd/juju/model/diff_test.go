@@ -78,6 +78,51 @@ new-branch:
 `[1:])
 }
 
+func (s *diffSuite) TestRunCommandThreeWay(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	result := map[string]coremodel.Generation{
+		s.branchName: {
+			Created:   "0001-01-01 00:00:00Z",
+			CreatedBy: "test-user",
+			Applications: []coremodel.GenerationApplication{{
+				ApplicationName: "redis",
+				UnitProgress:    "1/2",
+				ConfigChanges:   map[string]interface{}{"databases": 8, "port": 7000},
+				ConfigDiff: map[string]coremodel.ConfigItemDiff{
+					"databases": {Base: 4, Branch: 8, Master: 6, Conflict: true},
+					"port":      {Branch: 7000},
+				},
+			}},
+		},
+	}
+	s.api.EXPECT().BranchInfo(s.branchName, true, gomock.Any()).Return(result, nil)
+
+	ctx, err := s.runCommand(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+new-branch:
+  created: 0001-01-01 00:00:00Z
+  created-by: test-user
+  applications:
+  - application: redis
+    progress: 1/2
+    config:
+      databases: 8
+      port: 7000
+    config-diff:
+      databases:
+        base: 4
+        branch: 8
+        master: 6
+        conflict: true
+      port:
+        base: null
+        branch: 7000
+        master: null
+`[1:])
+}
+
 func (s *diffSuite) TestRunCommandAPIError(c *gc.C) {
 	defer s.setup(c).Finish()
 
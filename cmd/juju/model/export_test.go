@@ -197,9 +197,13 @@ func NewAbortCommandForTest(api AbortCommandAPI, store jujuclient.ClientStore) c
 	return modelcmd.Wrap(cmd)
 }
 
-func NewCommitCommandForTest(api CommitCommandAPI, store jujuclient.ClientStore) cmd.Command {
+func NewCommitCommandForTest(
+	api CommitCommandAPI, statusAPI CommitStatusAPI, clock jujuclock.Clock, store jujuclient.ClientStore,
+) cmd.Command {
 	cmd := &commitCommand{
-		api: api,
+		api:       api,
+		statusAPI: statusAPI,
+		clock:     clock,
 	}
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
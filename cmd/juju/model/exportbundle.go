@@ -5,6 +5,8 @@ package model
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/juju/charm/v7"
 	"github.com/juju/cmd"
@@ -35,6 +37,7 @@ type exportBundleCommand struct {
 	out        cmd.Output
 	newAPIFunc func() (ExportBundleAPI, ConfigAPI, error)
 	Filename   string
+	full       bool
 }
 
 const exportBundleHelpDoc = `
@@ -43,10 +46,25 @@ Exports the current model configuration as a reusable bundle.
 If --filename is not used, the configuration is printed to stdout.
  --filename specifies an output file.
 
+With --full, the bundle also records the channel, storage and device
+directives, and charm store resource revisions of each application, so that
+the model can be reproduced from it. The offers of the model, the offers it
+consumes and the relations to them are written to a companion overlay, and
+the config set on the model to a YAML file for the model-config command.
+These are written next to the bundle, so --filename must be given. The model
+can then be reproduced with:
+
+    juju model-config mymodel-model-config.yaml
+    juju deploy ./mymodel.yaml --overlay ./mymodel-overlay.yaml
+
+Uploaded resources, which cannot be given by revision, must be uploaded
+again with --resource.
+
 Examples:
 
     juju export-bundle
     juju export-bundle --filename mymodel.yaml
+    juju export-bundle --full --filename mymodel.yaml
 
 `
 
@@ -63,10 +81,14 @@ func (c *exportBundleCommand) Info() *cmd.Info {
 func (c *exportBundleCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.StringVar(&c.Filename, "filename", "", "Bundle file")
+	f.BoolVar(&c.full, "full", false, "Export everything needed to reproduce the model, with a companion overlay and model config file")
 }
 
 // Init implements Command.
 func (c *exportBundleCommand) Init(args []string) error {
+	if c.full && c.Filename == "" {
+		return errors.New("--full requires --filename, as the overlay and model config are written to separate files")
+	}
 	return cmd.CheckEmpty(args)
 }
 
@@ -75,6 +97,7 @@ type ExportBundleAPI interface {
 	BestAPIVersion() int
 	Close() error
 	ExportBundle() (string, error)
+	ExportFullBundle() (bundle.ExportedBundle, error)
 }
 
 // ConfigAPI specifies the used function calls of the ApplicationFacade.
@@ -103,6 +126,10 @@ func (c *exportBundleCommand) Run(ctx *cmd.Context) error {
 		_ = cfgClient.Close()
 	}()
 
+	if c.full {
+		return c.exportFullBundle(ctx, bundleClient)
+	}
+
 	result, err := bundleClient.ExportBundle()
 	if err != nil {
 		return err
@@ -138,6 +165,76 @@ func (c *exportBundleCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// exportFullBundle writes the bundle, overlay and model config exported from
+// the model to the bundle file and the companion files named after it.
+func (c *exportBundleCommand) exportFullBundle(ctx *cmd.Context, bundleClient ExportBundleAPI) error {
+	exported, err := bundleClient.ExportFullBundle()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := writeExportFile(c.Filename, exported.Bundle); err != nil {
+		return errors.Trace(err)
+	}
+	fmt.Fprintln(ctx.Stdout, "Bundle successfully exported to", c.Filename)
+
+	deployArgs := []string{"juju deploy", c.Filename}
+	if exported.Overlay != "" {
+		filename := companionFilename(c.Filename, "overlay")
+		if err := writeExportFile(filename, exported.Overlay); err != nil {
+			return errors.Trace(err)
+		}
+		fmt.Fprintln(ctx.Stdout, "Overlay for offers and cross-model relations exported to", filename)
+		deployArgs = append(deployArgs, "--overlay", filename)
+	}
+
+	var steps []string
+	if len(exported.ModelConfig) > 0 {
+		data, err := yaml.Marshal(exported.ModelConfig)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		filename := companionFilename(c.Filename, "model-config")
+		if err := writeExportFile(filename, string(data)); err != nil {
+			return errors.Trace(err)
+		}
+		fmt.Fprintln(ctx.Stdout, "Model config exported to", filename)
+		steps = append(steps, "juju model-config "+filename)
+	}
+	steps = append(steps, strings.Join(deployArgs, " "))
+
+	fmt.Fprintln(ctx.Stdout, "\nTo reproduce the model, run:")
+	for _, step := range steps {
+		fmt.Fprintln(ctx.Stdout, "    "+step)
+	}
+	return nil
+}
+
+// companionFilename returns the name of the file holding the given part of a
+// full export, next to the bundle file: "mymodel-overlay.yaml" for the
+// overlay of "mymodel.yaml".
+func companionFilename(bundleFilename, part string) string {
+	ext := filepath.Ext(bundleFilename)
+	base := strings.TrimSuffix(bundleFilename, ext)
+	if ext == "" {
+		ext = ".yaml"
+	}
+	return base + "-" + part + ext
+}
+
+func writeExportFile(filename, content string) error {
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Annotate(err, "while creating local file")
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(content); err != nil {
+		return errors.Annotate(err, "while copying in local file")
+	}
+	return nil
+}
+
 func (c *exportBundleCommand) injectTrustFlag(cfgClient ConfigAPI, bundleYaml string) (string, error) {
 	var (
 		bundleSpec   *charm.BundleData
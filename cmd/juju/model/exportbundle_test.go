@@ -15,6 +15,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/api/bundle"
 	appFacade "github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/model"
@@ -296,9 +297,69 @@ func (s *ExportBundleCommandSuite) TestPatchOfExportedBundleToExposeTrustFlag(c
 		"series: bionic\n")
 }
 
+func (s *ExportBundleCommandSuite) TestExportFullBundle(c *gc.C) {
+	dir := c.MkDir()
+	filename := filepath.Join(dir, "mymodel.yaml")
+	s.fakeBundle.fullResult = bundle.ExportedBundle{
+		Bundle:      "applications:\n  mysql:\n    charm: cs:mysql-42\n    channel: stable\n",
+		Overlay:     "saas:\n  logs:\n    url: admin/logging.logs\n",
+		ModelConfig: map[string]interface{}{"logging-config": "<root>=DEBUG"},
+	}
+	ctx, err := cmdtesting.RunCommand(c, model.NewExportBundleCommandForTest(s.fakeBundle, s.fakeConfig, s.store), "--full", "--filename", filename)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fakeBundle.CheckCalls(c, []jujutesting.StubCall{
+		{"ExportFullBundle", nil},
+	})
+
+	overlayFilename := filepath.Join(dir, "mymodel-overlay.yaml")
+	configFilename := filepath.Join(dir, "mymodel-model-config.yaml")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, fmt.Sprintf(`
+Bundle successfully exported to %[1]s
+Overlay for offers and cross-model relations exported to %[2]s
+Model config exported to %[3]s
+
+To reproduce the model, run:
+    juju model-config %[3]s
+    juju deploy %[1]s --overlay %[2]s
+`[1:], filename, overlayFilename, configFilename))
+
+	for path, expected := range map[string]string{
+		filename:        s.fakeBundle.fullResult.Bundle,
+		overlayFilename: s.fakeBundle.fullResult.Overlay,
+		configFilename:  "logging-config: <root>=DEBUG\n",
+	} {
+		output, err := ioutil.ReadFile(path)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(string(output), gc.Equals, expected)
+	}
+}
+
+func (s *ExportBundleCommandSuite) TestExportFullBundleNothingCrossModel(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "mymodel")
+	s.fakeBundle.fullResult = bundle.ExportedBundle{
+		Bundle: "applications:\n  mysql:\n    charm: cs:mysql-42\n",
+	}
+	ctx, err := cmdtesting.RunCommand(c, model.NewExportBundleCommandForTest(s.fakeBundle, s.fakeConfig, s.store), "--full", "--filename", filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, fmt.Sprintf(`
+Bundle successfully exported to %[1]s
+
+To reproduce the model, run:
+    juju deploy %[1]s
+`[1:], filename))
+	_, err = os.Stat(filename + "-overlay.yaml")
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *ExportBundleCommandSuite) TestExportFullBundleNeedsFilename(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewExportBundleCommandForTest(s.fakeBundle, s.fakeConfig, s.store), "--full")
+	c.Assert(err, gc.ErrorMatches, "--full requires --filename, as the overlay and model config are written to separate files")
+}
+
 type fakeExportBundleClient struct {
 	*jujutesting.Stub
 	result         string
+	fullResult     bundle.ExportedBundle
 	filename       string
 	bestAPIVersion int
 }
@@ -316,6 +377,11 @@ func (f *fakeExportBundleClient) ExportBundle() (string, error) {
 	return f.result, f.NextErr()
 }
 
+func (f *fakeExportBundleClient) ExportFullBundle() (bundle.ExportedBundle, error) {
+	f.MethodCall(f, "ExportFullBundle")
+	return f.fullResult, f.NextErr()
+}
+
 type fakeConfigClient struct {
 	*jujutesting.Stub
 	result map[string]*params.ApplicationGetResults
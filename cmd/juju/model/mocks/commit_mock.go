@@ -1,13 +1,15 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/juju/juju/cmd/juju/model (interfaces: CommitCommandAPI)
+// Source: github.com/juju/juju/cmd/juju/model (interfaces: CommitCommandAPI,CommitStatusAPI)
 
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
-	reflect "reflect"
-
 	gomock "github.com/golang/mock/gomock"
+	params "github.com/juju/juju/apiserver/params"
+	model "github.com/juju/juju/core/model"
+	reflect "reflect"
+	time "time"
 )
 
 // MockCommitCommandAPI is a mock of CommitCommandAPI interface
@@ -33,8 +35,24 @@ func (m *MockCommitCommandAPI) EXPECT() *MockCommitCommandAPIMockRecorder {
 	return m.recorder
 }
 
+// BranchInfo mocks base method
+func (m *MockCommitCommandAPI) BranchInfo(arg0 string, arg1 bool, arg2 func(time.Time) string) (map[string]model.Generation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BranchInfo", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string]model.Generation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BranchInfo indicates an expected call of BranchInfo
+func (mr *MockCommitCommandAPIMockRecorder) BranchInfo(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchInfo", reflect.TypeOf((*MockCommitCommandAPI)(nil).BranchInfo), arg0, arg1, arg2)
+}
+
 // Close mocks base method
 func (m *MockCommitCommandAPI) Close() error {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Close")
 	ret0, _ := ret[0].(error)
 	return ret0
@@ -42,11 +60,13 @@ func (m *MockCommitCommandAPI) Close() error {
 
 // Close indicates an expected call of Close
 func (mr *MockCommitCommandAPIMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockCommitCommandAPI)(nil).Close))
 }
 
 // CommitBranch mocks base method
 func (m *MockCommitCommandAPI) CommitBranch(arg0 string) (int, error) {
+	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CommitBranch", arg0)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
@@ -55,5 +75,73 @@ func (m *MockCommitCommandAPI) CommitBranch(arg0 string) (int, error) {
 
 // CommitBranch indicates an expected call of CommitBranch
 func (mr *MockCommitCommandAPIMockRecorder) CommitBranch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitBranch", reflect.TypeOf((*MockCommitCommandAPI)(nil).CommitBranch), arg0)
 }
+
+// CommitBranchWithResolution mocks base method
+func (m *MockCommitCommandAPI) CommitBranchWithResolution(arg0 string, arg1 model.BranchConflictResolution) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitBranchWithResolution", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitBranchWithResolution indicates an expected call of CommitBranchWithResolution
+func (mr *MockCommitCommandAPIMockRecorder) CommitBranchWithResolution(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitBranchWithResolution", reflect.TypeOf((*MockCommitCommandAPI)(nil).CommitBranchWithResolution), arg0, arg1)
+}
+
+// TrackBranch mocks base method
+func (m *MockCommitCommandAPI) TrackBranch(arg0 string, arg1 []string, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackBranch", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TrackBranch indicates an expected call of TrackBranch
+func (mr *MockCommitCommandAPIMockRecorder) TrackBranch(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackBranch", reflect.TypeOf((*MockCommitCommandAPI)(nil).TrackBranch), arg0, arg1, arg2)
+}
+
+// MockCommitStatusAPI is a mock of CommitStatusAPI interface
+type MockCommitStatusAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommitStatusAPIMockRecorder
+}
+
+// MockCommitStatusAPIMockRecorder is the mock recorder for MockCommitStatusAPI
+type MockCommitStatusAPIMockRecorder struct {
+	mock *MockCommitStatusAPI
+}
+
+// NewMockCommitStatusAPI creates a new mock instance
+func NewMockCommitStatusAPI(ctrl *gomock.Controller) *MockCommitStatusAPI {
+	mock := &MockCommitStatusAPI{ctrl: ctrl}
+	mock.recorder = &MockCommitStatusAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockCommitStatusAPI) EXPECT() *MockCommitStatusAPIMockRecorder {
+	return m.recorder
+}
+
+// Status mocks base method
+func (m *MockCommitStatusAPI) Status(arg0 []string) (*params.FullStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", arg0)
+	ret0, _ := ret[0].(*params.FullStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status
+func (mr *MockCommitStatusAPIMockRecorder) Status(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockCommitStatusAPI)(nil).Status), arg0)
+}
@@ -23,6 +23,7 @@ type formattedStatus struct {
 	Storage            *storage.CombinedStorage           `json:"storage,omitempty" yaml:"storage,omitempty"`
 	Controller         *controllerStatus                  `json:"controller,omitempty" yaml:"controller,omitempty"`
 	Branches           map[string]branchStatus            `json:"branches,omitempty" yaml:"branches,omitempty"`
+	MachinePool        *machinePoolStatus                 `json:"machine-pool,omitempty" yaml:"machine-pool,omitempty"`
 }
 
 type formattedMachineStatus struct {
@@ -330,3 +331,9 @@ type branchStatus struct {
 	CreatedBy string `json:"created-by,omitempty" yaml:"created-by,omitempty"`
 	Active    bool   `json:"active,omitempty" yaml:"active,omitempty"`
 }
+
+type machinePoolStatus struct {
+	Size        int      `json:"size" yaml:"size"`
+	Constraints string   `json:"constraints,omitempty" yaml:"constraints,omitempty"`
+	Machines    []string `json:"machines" yaml:"machines"`
+}
@@ -146,6 +146,13 @@ func (sf *statusFormatter) format() (formattedStatus, error) {
 	if sf.storage != nil {
 		out.Storage = sf.storage
 	}
+	if pool := sf.status.MachinePool; pool != nil {
+		out.MachinePool = &machinePoolStatus{
+			Size:        pool.Size,
+			Constraints: pool.Constraints,
+			Machines:    pool.Machines,
+		}
+	}
 	return out, nil
 }
 
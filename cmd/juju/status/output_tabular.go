@@ -91,6 +91,10 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 		printMachines(tw, false, fs.Machines)
 	}
 
+	if fs.MachinePool != nil {
+		printMachinePool(tw, fs.MachinePool)
+	}
+
 	if err := printOffers(tw, fs.Offers); err != nil {
 		w.Println(err.Error())
 	}
@@ -275,6 +279,11 @@ func printBranches(tw *ansiterm.TabWriter, branches map[string]branchStatus) {
 	}
 }
 
+func printMachinePool(tw *ansiterm.TabWriter, pool *machinePoolStatus) {
+	w := startSection(tw, false, "Pool size", "Idle", "Machines", "Constraints")
+	w.Println(pool.Size, len(pool.Machines), strings.Join(pool.Machines, ","), pool.Constraints)
+}
+
 func printRemoteApplications(tw *ansiterm.TabWriter, remoteApplications map[string]remoteApplicationStatus) {
 	w := startSection(tw, false, "SAAS", "Status", "Store", "URL")
 	for _, appName := range naturalsort.Sort(stringKeysFromMap(remoteApplications)) {
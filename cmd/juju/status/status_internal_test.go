@@ -5974,6 +5974,47 @@ func (s *StatusSuite) TestControllerTimestampInFullStatus(c *gc.C) {
 	})
 }
 
+func (s *StatusSuite) TestMachinePoolInFullStatus(c *gc.C) {
+	status := &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			CloudTag: "cloud-dummy",
+		},
+		MachinePool: &params.MachinePoolStatus{
+			Size:        2,
+			Constraints: "mem=4096M",
+			Machines:    []string{"3"},
+		},
+	}
+	formatter := NewStatusFormatter(status, true)
+	formatted, err := formatter.format()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(formatted.MachinePool, jc.DeepEquals, &machinePoolStatus{
+		Size:        2,
+		Constraints: "mem=4096M",
+		Machines:    []string{"3"},
+	})
+}
+
+func (s *StatusSuite) TestFormatTabularMachinePool(c *gc.C) {
+	status := formattedStatus{
+		MachinePool: &machinePoolStatus{
+			Size:        3,
+			Constraints: "mem=4096M",
+			Machines:    []string{"3", "4"},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `
+Model  Controller  Cloud/Region  Version
+                                 
+
+Pool size  Idle  Machines  Constraints
+3          2     3,4       mem=4096M
+`[1:])
+}
+
 func (s *StatusSuite) TestTabularNoRelations(c *gc.C) {
 	ctx := s.FilteringTestSetup(c)
 	defer s.resetContext(c, ctx)
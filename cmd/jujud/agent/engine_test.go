@@ -34,13 +34,16 @@ var (
 	requireValidCredentialModelWorkers = []string{
 		"action-pruner",          // tertiary dependency: will be inactive because migration workers will be inactive
 		"application-scaler",     // tertiary dependency: will be inactive because migration workers will be inactive
+		"autoscaler",             // tertiary dependency: will be inactive because migration workers will be inactive
 		"charm-revision-updater", // tertiary dependency: will be inactive because migration workers will be inactive
+		"charm-upgrader",         // tertiary dependency: will be inactive because migration workers will be inactive
 		"compute-provisioner",
 		"environ-tracker",
 		"firewaller",
 		"instance-mutater",
 		"instance-poller",
 		"logging-config-updater",  // tertiary dependency: will be inactive because migration workers will be inactive
+		"machine-pool",            // tertiary dependency: will be inactive because migration workers will be inactive
 		"machine-undertaker",      // tertiary dependency: will be inactive because migration workers will be inactive
 		"metric-worker",           // tertiary dependency: will be inactive because migration workers will be inactive
 		"migration-fortress",      // secondary dependency: will be inactive because depends on model-upgrader
@@ -48,6 +51,7 @@ var (
 		"migration-master",        // secondary dependency: will be inactive because depends on model-upgrader
 		"model-upgrader",
 		"remote-relations",      // tertiary dependency: will be inactive because migration workers will be inactive
+		"resource-tagger",       // tertiary dependency: will be inactive because migration workers will be inactive
 		"state-cleaner",         // tertiary dependency: will be inactive because migration workers will be inactive
 		"status-history-pruner", // tertiary dependency: will be inactive because migration workers will be inactive
 		"storage-provisioner",   // tertiary dependency: will be inactive because migration workers will be inactive
@@ -57,7 +61,9 @@ var (
 	aliveModelWorkers = []string{
 		"action-pruner",
 		"application-scaler",
+		"autoscaler",
 		"charm-revision-updater",
+		"charm-upgrader",
 		"compute-provisioner",
 		"environ-tracker",
 		"firewaller",
@@ -65,12 +71,14 @@ var (
 		"instance-poller",
 		"log-forwarder",
 		"logging-config-updater",
+		"machine-pool",
 		"machine-undertaker",
 		"metric-worker",
 		"migration-fortress",
 		"migration-inactive-flag",
 		"migration-master",
 		"remote-relations",
+		"resource-tagger",
 		"state-cleaner",
 		"status-history-pruner",
 		"storage-provisioner",
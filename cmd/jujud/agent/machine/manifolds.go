@@ -62,6 +62,7 @@ import (
 	"github.com/juju/juju/worker/httpserverargs"
 	"github.com/juju/juju/worker/identityfilewriter"
 	"github.com/juju/juju/worker/instancemutater"
+	"github.com/juju/juju/worker/leakdetector"
 	leasemanager "github.com/juju/juju/worker/lease/manifold"
 	"github.com/juju/juju/worker/logger"
 	"github.com/juju/juju/worker/logsender"
@@ -654,6 +655,17 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			},
 		))),
 
+		leakDetectorName: ifNotMigrating(ifPrimaryController(leakdetector.Manifold(
+			leakdetector.ManifoldConfig{
+				APICallerName: apiCallerName,
+				Clock:         config.Clock,
+				Period:        leakdetector.DefaultPeriod,
+				Logger:        loggo.GetLogger("juju.worker.leakdetector"),
+				NewFacade:     leakdetector.NewAPIFacade,
+				NewWorker:     leakdetector.NewWorker,
+			},
+		))),
+
 		httpServerArgsName: httpserverargs.Manifold(httpserverargs.ManifoldConfig{
 			ClockName:             clockName,
 			ControllerPortName:    controllerPortName,
@@ -1130,6 +1142,7 @@ const (
 	isControllerFlagName          = "is-controller-flag"
 	instanceMutaterName           = "instance-mutater"
 	txnPrunerName                 = "transaction-pruner"
+	leakDetectorName              = "leak-detector"
 	certificateWatcherName        = "certificate-watcher"
 	modelCacheName                = "model-cache"
 	modelCacheInitializedFlagName = "model-cache-initialized-flag"
@@ -80,6 +80,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"instance-mutater",
 			"is-controller-flag",
 			"is-primary-controller-flag",
+			"leak-detector",
 			"lease-clock-updater",
 			"lease-manager",
 			"log-sender",
@@ -153,6 +154,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 			"http-server-args",
 			"is-controller-flag",
 			"is-primary-controller-flag",
+			"leak-detector",
 			"lease-clock-updater",
 			"lease-manager",
 			"log-sender",
@@ -305,6 +307,7 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 	)
 	primaryControllerWorkers := set.NewStrings(
 		"external-controller-updater",
+		"leak-detector",
 		"transaction-pruner",
 	)
 	for name, manifold := range manifolds {
@@ -618,6 +621,22 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"state-config-watcher",
 	},
 
+	"leak-detector": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"lease-clock-updater": {
 		"agent",
 		"central-hub",
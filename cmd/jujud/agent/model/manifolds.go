@@ -29,6 +29,7 @@ import (
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/applicationscaler"
+	"github.com/juju/juju/worker/autoscaler"
 	"github.com/juju/juju/worker/caasbroker"
 	"github.com/juju/juju/worker/caasenvironupgrader"
 	"github.com/juju/juju/worker/caasfirewaller"
@@ -37,6 +38,7 @@ import (
 	"github.com/juju/juju/worker/caasunitprovisioner"
 	"github.com/juju/juju/worker/charmrevision"
 	"github.com/juju/juju/worker/charmrevision/charmrevisionmanifold"
+	"github.com/juju/juju/worker/charmupgrader"
 	"github.com/juju/juju/worker/cleaner"
 	"github.com/juju/juju/worker/common"
 	"github.com/juju/juju/worker/credentialvalidator"
@@ -50,6 +52,7 @@ import (
 	"github.com/juju/juju/worker/logforwarder"
 	"github.com/juju/juju/worker/logforwarder/sinks"
 	"github.com/juju/juju/worker/logger"
+	"github.com/juju/juju/worker/machinepool"
 	"github.com/juju/juju/worker/machineundertaker"
 	"github.com/juju/juju/worker/metricworker"
 	"github.com/juju/juju/worker/migrationflag"
@@ -58,6 +61,7 @@ import (
 	"github.com/juju/juju/worker/provisioner"
 	"github.com/juju/juju/worker/pruner"
 	"github.com/juju/juju/worker/remoterelations"
+	"github.com/juju/juju/worker/resourcetagger"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/statushistorypruner"
 	"github.com/juju/juju/worker/storageprovisioner"
@@ -269,6 +273,22 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker: charmrevision.NewWorker,
 			// No Logger defined in charmrevision or charmrevisionmanifold package.
 		})),
+		charmUpgraderName: ifNotMigrating(charmupgrader.Manifold(charmupgrader.ManifoldConfig{
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			Period:        charmupgrader.DefaultPeriod,
+			Logger:        config.LoggingContext.GetLogger("juju.worker.charmupgrader"),
+			NewFacade:     charmupgrader.NewAPIFacade,
+			NewWorker:     charmupgrader.NewWorker,
+		})),
+		autoscalerName: ifNotMigrating(autoscaler.Manifold(autoscaler.ManifoldConfig{
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			Period:        autoscaler.DefaultPeriod,
+			Logger:        config.LoggingContext.GetLogger("juju.worker.autoscaler"),
+			NewFacade:     autoscaler.NewAPIFacade,
+			NewWorker:     autoscaler.NewWorker,
+		})),
 		remoteRelationsName: ifNotMigrating(remoterelations.Manifold(remoterelations.ManifoldConfig{
 			AgentName:                agentName,
 			APICallerName:            apiCallerName,
@@ -404,6 +424,14 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			Logger:        config.LoggingContext.GetLogger("juju.worker.unitassigner"),
 		})),
+		machinePoolName: ifNotMigrating(machinepool.Manifold(machinepool.ManifoldConfig{
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			Period:        machinepool.DefaultPeriod,
+			Logger:        config.LoggingContext.GetLogger("juju.worker.machinepool"),
+			NewFacade:     machinepool.NewAPIFacade,
+			NewWorker:     machinepool.NewWorker,
+		})),
 		applicationScalerName: ifNotMigrating(applicationscaler.Manifold(applicationscaler.ManifoldConfig{
 			APICallerName: apiCallerName,
 			NewFacade:     applicationscaler.NewFacade,
@@ -428,6 +456,14 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
 			Logger:                       config.LoggingContext.GetLogger("juju.worker.machineundertaker"),
 		}))),
+		resourceTaggerName: ifNotMigrating(ifCredentialValid(resourcetagger.Manifold(resourcetagger.ManifoldConfig{
+			APICallerName:                apiCallerName,
+			EnvironName:                  environTrackerName,
+			Logger:                       config.LoggingContext.GetLogger("juju.worker.resourcetagger"),
+			NewFacade:                    resourcetagger.NewAPIFacade,
+			NewWorker:                    resourcetagger.NewWorker,
+			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
+		}))),
 		modelUpgraderName: ifNotDead(ifCredentialValid(modelupgrader.Manifold(modelupgrader.ManifoldConfig{
 			APICallerName:                apiCallerName,
 			EnvironName:                  environTrackerName,
@@ -651,12 +687,16 @@ const (
 	applicationScalerName    = "application-scaler"
 	instancePollerName       = "instance-poller"
 	charmRevisionUpdaterName = "charm-revision-updater"
+	charmUpgraderName        = "charm-upgrader"
+	autoscalerName           = "autoscaler"
 	metricWorkerName         = "metric-worker"
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
 	actionPrunerName         = "action-pruner"
 	machineUndertakerName    = "machine-undertaker"
+	machinePoolName          = "machine-pool"
 	remoteRelationsName      = "remote-relations"
+	resourceTaggerName       = "resource-tagger"
 	logForwarderName         = "log-forwarder"
 	loggingConfigUpdaterName = "logging-config-updater"
 	instanceMutaterName      = "instance-mutater"
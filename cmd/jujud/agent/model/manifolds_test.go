@@ -39,7 +39,9 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"api-caller",
 		"api-config-watcher",
 		"application-scaler",
+		"autoscaler",
 		"charm-revision-updater",
+		"charm-upgrader",
 		"clock",
 		"compute-provisioner",
 		"environ-tracker",
@@ -49,6 +51,7 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"is-responsible-flag",
 		"log-forwarder",
 		"logging-config-updater",
+		"machine-pool",
 		"machine-undertaker",
 		"metric-worker",
 		"migration-fortress",
@@ -60,6 +63,7 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"not-alive-flag",
 		"not-dead-flag",
 		"remote-relations",
+		"resource-tagger",
 		"state-cleaner",
 		"status-history-pruner",
 		"storage-provisioner",
@@ -85,6 +89,7 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 		"agent",
 		"api-caller",
 		"api-config-watcher",
+		"autoscaler",
 		"caas-broker-tracker",
 		"caas-firewaller",
 		"caas-model-operator",
@@ -92,6 +97,7 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 		"caas-storage-provisioner",
 		"caas-unit-provisioner",
 		"charm-revision-updater",
+		"charm-upgrader",
 		"clock",
 		"is-responsible-flag",
 		"log-forwarder",
@@ -216,6 +222,16 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 
 	"api-config-watcher": {"agent"},
 
+	"autoscaler": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"caas-broker-tracker": {"agent", "api-caller", "is-responsible-flag"},
 
 	"caas-firewaller": {
@@ -281,6 +297,16 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 		"model-upgraded-flag",
 		"not-dead-flag"},
 
+	"charm-upgrader": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"clock": {},
 
 	"is-responsible-flag": {"agent", "api-caller"},
@@ -409,6 +435,16 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"model-upgraded-flag",
 		"not-dead-flag"},
 
+	"autoscaler": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"charm-revision-updater": {
 		"agent",
 		"api-caller",
@@ -419,6 +455,16 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"model-upgraded-flag",
 		"not-dead-flag"},
 
+	"charm-upgrader": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"clock": {},
 
 	"compute-provisioner": {
@@ -501,6 +547,16 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"not-dead-flag",
 	},
 
+	"machine-pool": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"machine-undertaker": {
 		"agent",
 		"api-caller",
@@ -577,6 +633,19 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"model-upgraded-flag",
 		"not-dead-flag"},
 
+	"resource-tagger": {
+		"agent",
+		"api-caller",
+		"environ-tracker",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag",
+		"valid-credential-flag",
+	},
+
 	"state-cleaner": {
 		"agent",
 		"api-caller",
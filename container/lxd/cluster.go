@@ -3,6 +3,11 @@
 
 package lxd
 
+import (
+	"github.com/juju/errors"
+	"github.com/lxc/lxd/shared/api"
+)
+
 func (s *Server) ClusterSupported() bool {
 	return s.clusterAPISupport
 }
@@ -14,3 +19,10 @@ func (s Server) UseTargetServer(name string) (*Server, error) {
 	logger.Debugf("creating LXD server for cluster node %q", name)
 	return NewServer(s.UseTarget(name))
 }
+
+// GetClusterMemberResources returns the system resources of the cluster
+// member with the input name.
+func (s *Server) GetClusterMemberResources(name string) (*api.Resources, error) {
+	resources, err := s.UseTarget(name).GetServerResources()
+	return resources, errors.Annotatef(err, "getting resources of cluster member %q", name)
+}
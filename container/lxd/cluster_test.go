@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/container/lxd"
 	lxdtesting "github.com/juju/juju/container/lxd/testing"
+	"github.com/lxc/lxd/shared/api"
 	"github.com/pkg/errors"
 )
 
@@ -51,3 +52,42 @@ func (s *imageSuite) TestUseTargetBadNode(c *gc.C) {
 	_, err = jujuSvr.UseTargetServer("cluster-2")
 	c.Assert(err, gc.ErrorMatches, "not a cluster member")
 }
+
+func (s *clusterSuite) TestGetClusterMemberResources(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	c1Svr := s.NewMockServerClustered(ctrl, "cluster-1")
+	c2Svr := lxdtesting.NewMockContainerServer(ctrl)
+
+	resources := &api.Resources{
+		CPU:    api.ResourcesCPU{Total: 8},
+		Memory: api.ResourcesMemory{Total: 16 << 30, Used: 4 << 30},
+	}
+	c1Svr.EXPECT().UseTarget("cluster-2").Return(c2Svr)
+	c2Svr.EXPECT().GetServerResources().Return(resources, nil)
+
+	jujuSvr, err := lxd.NewServer(c1Svr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := jujuSvr.GetClusterMemberResources("cluster-2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.Equals, resources)
+}
+
+func (s *clusterSuite) TestGetClusterMemberResourcesError(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	c1Svr := s.NewMockServerClustered(ctrl, "cluster-1")
+	c2Svr := lxdtesting.NewMockContainerServer(ctrl)
+
+	c1Svr.EXPECT().UseTarget("cluster-2").Return(c2Svr)
+	c2Svr.EXPECT().GetServerResources().Return(nil, errors.New("not a cluster member"))
+
+	jujuSvr, err := lxd.NewServer(c1Svr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = jujuSvr.GetClusterMemberResources("cluster-2")
+	c.Assert(err, gc.ErrorMatches, `getting resources of cluster member "cluster-2": not a cluster member`)
+}
@@ -0,0 +1,217 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/status"
+)
+
+// The following application config options make the autoscaler adjust
+// the number of units of an application.
+const (
+	// AutoscaleSourceOptionName is what the autoscaler measures, either
+	// "metric:<key>" for the mean of the latest value of a charm metric
+	// reported by each unit, or "status:<status>" for the number of units
+	// with the given workload status. Autoscaling is disabled when it is
+	// not set.
+	AutoscaleSourceOptionName = "autoscale-source"
+
+	// AutoscaleMinUnitsOptionName is the fewest units the autoscaler
+	// scales the application down to.
+	AutoscaleMinUnitsOptionName = "autoscale-min-units"
+
+	// AutoscaleMaxUnitsOptionName is the most units the autoscaler
+	// scales the application up to.
+	AutoscaleMaxUnitsOptionName = "autoscale-max-units"
+
+	// AutoscaleScaleUpThresholdOptionName is the measurement above which
+	// a unit is added.
+	AutoscaleScaleUpThresholdOptionName = "autoscale-scale-up-threshold"
+
+	// AutoscaleScaleDownThresholdOptionName is the measurement below
+	// which a unit is removed.
+	AutoscaleScaleDownThresholdOptionName = "autoscale-scale-down-threshold"
+
+	// AutoscaleCooldownOptionName is the shortest time between scaling
+	// decisions for the application.
+	AutoscaleCooldownOptionName = "autoscale-cooldown"
+)
+
+const (
+	// DefaultAutoscaleMinUnits is the fewest units the autoscaler
+	// scales an application down to unless configured otherwise.
+	DefaultAutoscaleMinUnits = 1
+
+	// DefaultAutoscaleCooldown is the shortest time between scaling
+	// decisions unless configured otherwise.
+	DefaultAutoscaleCooldown = 5 * time.Minute
+
+	autoscaleMetricPrefix = "metric:"
+	autoscaleStatusPrefix = "status:"
+)
+
+// AutoscaleSource identifies what the autoscaler measures to decide
+// whether to scale an application.
+type AutoscaleSource struct {
+	// Metric is the key of the charm metric whose mean is measured.
+	Metric string
+
+	// Status is the workload status whose units are counted.
+	Status status.Status
+}
+
+// ParseAutoscaleSource parses a source in the form "metric:<key>" or
+// "status:<status>".
+func ParseAutoscaleSource(value string) (AutoscaleSource, error) {
+	switch {
+	case strings.HasPrefix(value, autoscaleMetricPrefix):
+		if key := strings.TrimPrefix(value, autoscaleMetricPrefix); key != "" {
+			return AutoscaleSource{Metric: key}, nil
+		}
+	case strings.HasPrefix(value, autoscaleStatusPrefix):
+		s := status.Status(strings.TrimPrefix(value, autoscaleStatusPrefix))
+		if status.ValidWorkloadStatus(s) {
+			return AutoscaleSource{Status: s}, nil
+		}
+	}
+	return AutoscaleSource{}, errors.NotValidf("%s %q", AutoscaleSourceOptionName, value)
+}
+
+// IsZero returns true if the source measures nothing.
+func (s AutoscaleSource) IsZero() bool {
+	return s.Metric == "" && s.Status == ""
+}
+
+// String returns the source in the form accepted by ParseAutoscaleSource.
+func (s AutoscaleSource) String() string {
+	switch {
+	case s.Metric != "":
+		return autoscaleMetricPrefix + s.Metric
+	case s.Status != "":
+		return autoscaleStatusPrefix + string(s.Status)
+	}
+	return ""
+}
+
+// AutoscalePolicy holds the rules by which the autoscaler adjusts the
+// number of units of an application.
+type AutoscalePolicy struct {
+	Source             AutoscaleSource
+	MinUnits           int
+	MaxUnits           int
+	ScaleUpThreshold   float64
+	ScaleDownThreshold float64
+	Cooldown           time.Duration
+}
+
+// Enabled returns true if the application is to be autoscaled.
+func (p AutoscalePolicy) Enabled() bool {
+	return !p.Source.IsZero()
+}
+
+// Validate returns an error if the policy cannot be applied.
+func (p AutoscalePolicy) Validate() error {
+	if !p.Enabled() {
+		return nil
+	}
+	if p.MinUnits < 0 {
+		return errors.NotValidf("negative %s", AutoscaleMinUnitsOptionName)
+	}
+	if p.MaxUnits < p.MinUnits {
+		return errors.NotValidf(
+			"%s %d less than %s %d",
+			AutoscaleMaxUnitsOptionName, p.MaxUnits,
+			AutoscaleMinUnitsOptionName, p.MinUnits,
+		)
+	}
+	if p.ScaleDownThreshold >= p.ScaleUpThreshold {
+		return errors.NotValidf(
+			"%s %v not less than %s %v",
+			AutoscaleScaleDownThresholdOptionName, p.ScaleDownThreshold,
+			AutoscaleScaleUpThresholdOptionName, p.ScaleUpThreshold,
+		)
+	}
+	if p.Cooldown < 0 {
+		return errors.NotValidf("negative %s", AutoscaleCooldownOptionName)
+	}
+	return nil
+}
+
+// Decide returns the number of units the application should have, given
+// its current number of units and the measurement of the policy's
+// source, along with the reason for any change. The application is
+// scaled by one unit at a time, and always brought within its bounds.
+func (p AutoscalePolicy) Decide(units int, value float64) (int, string) {
+	switch {
+	case units < p.MinUnits:
+		return p.MinUnits, fmt.Sprintf("%d unit(s) below minimum of %d", units, p.MinUnits)
+	case units > p.MaxUnits:
+		return p.MaxUnits, fmt.Sprintf("%d unit(s) above maximum of %d", units, p.MaxUnits)
+	case value > p.ScaleUpThreshold && units < p.MaxUnits:
+		return units + 1, fmt.Sprintf("%s %v above %v", p.Source, value, p.ScaleUpThreshold)
+	case value < p.ScaleDownThreshold && units > p.MinUnits:
+		return units - 1, fmt.Sprintf("%s %v below %v", p.Source, value, p.ScaleDownThreshold)
+	}
+	return units, ""
+}
+
+// AutoscalePolicy returns the autoscale policy set for the application.
+// The policy is not enabled unless the application's autoscale source is
+// set.
+func (c ConfigAttributes) AutoscalePolicy() (AutoscalePolicy, error) {
+	value := c.GetString(AutoscaleSourceOptionName, "")
+	if value == "" {
+		return AutoscalePolicy{}, nil
+	}
+	source, err := ParseAutoscaleSource(value)
+	if err != nil {
+		return AutoscalePolicy{}, errors.Trace(err)
+	}
+	policy := AutoscalePolicy{
+		Source:   source,
+		MinUnits: DefaultAutoscaleMinUnits,
+		Cooldown: DefaultAutoscaleCooldown,
+	}
+	if _, ok := c[AutoscaleMinUnitsOptionName]; ok {
+		policy.MinUnits = c.GetInt(AutoscaleMinUnitsOptionName, 0)
+	}
+	if _, ok := c[AutoscaleMaxUnitsOptionName]; !ok {
+		return AutoscalePolicy{}, errors.NotValidf("%s without %s", AutoscaleSourceOptionName, AutoscaleMaxUnitsOptionName)
+	}
+	policy.MaxUnits = c.GetInt(AutoscaleMaxUnitsOptionName, 0)
+	if policy.ScaleUpThreshold, err = c.autoscaleThreshold(AutoscaleScaleUpThresholdOptionName); err != nil {
+		return AutoscalePolicy{}, errors.Trace(err)
+	}
+	if policy.ScaleDownThreshold, err = c.autoscaleThreshold(AutoscaleScaleDownThresholdOptionName); err != nil {
+		return AutoscalePolicy{}, errors.Trace(err)
+	}
+	if cooldown := c.GetString(AutoscaleCooldownOptionName, ""); cooldown != "" {
+		if policy.Cooldown, err = time.ParseDuration(cooldown); err != nil {
+			return AutoscalePolicy{}, errors.NotValidf("%s %q", AutoscaleCooldownOptionName, cooldown)
+		}
+	}
+	if err := policy.Validate(); err != nil {
+		return AutoscalePolicy{}, errors.Trace(err)
+	}
+	return policy, nil
+}
+
+func (c ConfigAttributes) autoscaleThreshold(name string) (float64, error) {
+	value := c.GetString(name, "")
+	if value == "" {
+		return 0, errors.NotValidf("%s without %s", AutoscaleSourceOptionName, name)
+	}
+	threshold, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, errors.NotValidf("%s %q", name, value)
+	}
+	return threshold, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type AutoscaleSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&AutoscaleSuite{})
+
+func (s *AutoscaleSuite) TestAutoscalePolicyDisabled(c *gc.C) {
+	policy, err := application.ConfigAttributes{
+		application.AutoscaleMaxUnitsOptionName: 5,
+	}.AutoscalePolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy.Enabled(), jc.IsFalse)
+}
+
+func (s *AutoscaleSuite) TestAutoscalePolicyMetric(c *gc.C) {
+	policy, err := application.ConfigAttributes{
+		application.AutoscaleSourceOptionName:             "metric:cpu-load",
+		application.AutoscaleMaxUnitsOptionName:           5,
+		application.AutoscaleScaleUpThresholdOptionName:   "0.8",
+		application.AutoscaleScaleDownThresholdOptionName: "0.2",
+	}.AutoscalePolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, application.AutoscalePolicy{
+		Source:             application.AutoscaleSource{Metric: "cpu-load"},
+		MinUnits:           application.DefaultAutoscaleMinUnits,
+		MaxUnits:           5,
+		ScaleUpThreshold:   0.8,
+		ScaleDownThreshold: 0.2,
+		Cooldown:           application.DefaultAutoscaleCooldown,
+	})
+	c.Assert(policy.Enabled(), jc.IsTrue)
+	c.Assert(policy.Source.String(), gc.Equals, "metric:cpu-load")
+}
+
+func (s *AutoscaleSuite) TestAutoscalePolicyStatus(c *gc.C) {
+	policy, err := application.ConfigAttributes{
+		application.AutoscaleSourceOptionName:             "status:blocked",
+		application.AutoscaleMinUnitsOptionName:           2,
+		application.AutoscaleMaxUnitsOptionName:           4,
+		application.AutoscaleScaleUpThresholdOptionName:   "1",
+		application.AutoscaleScaleDownThresholdOptionName: "-1",
+		application.AutoscaleCooldownOptionName:           "10m",
+	}.AutoscalePolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, application.AutoscalePolicy{
+		Source:             application.AutoscaleSource{Status: status.Blocked},
+		MinUnits:           2,
+		MaxUnits:           4,
+		ScaleUpThreshold:   1,
+		ScaleDownThreshold: -1,
+		Cooldown:           10 * time.Minute,
+	})
+	c.Assert(policy.Source.String(), gc.Equals, "status:blocked")
+}
+
+func (s *AutoscaleSuite) TestAutoscalePolicyInvalid(c *gc.C) {
+	valid := func(attrs application.ConfigAttributes) application.ConfigAttributes {
+		result := application.ConfigAttributes{
+			application.AutoscaleSourceOptionName:             "metric:load",
+			application.AutoscaleMaxUnitsOptionName:           3,
+			application.AutoscaleScaleUpThresholdOptionName:   "10",
+			application.AutoscaleScaleDownThresholdOptionName: "1",
+		}
+		for k, v := range attrs {
+			result[k] = v
+		}
+		return result
+	}
+	for i, test := range []struct {
+		attrs application.ConfigAttributes
+		err   string
+	}{{
+		attrs: application.ConfigAttributes{application.AutoscaleSourceOptionName: "load"},
+		err:   `autoscale-source "load" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{application.AutoscaleSourceOptionName: "metric:"},
+		err:   `autoscale-source "metric:" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{application.AutoscaleSourceOptionName: "status:lost"},
+		err:   `autoscale-source "status:lost" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{application.AutoscaleSourceOptionName: "metric:load"},
+		err:   `autoscale-source without autoscale-max-units not valid`,
+	}, {
+		attrs: valid(application.ConfigAttributes{application.AutoscaleMinUnitsOptionName: -1}),
+		err:   `negative autoscale-min-units not valid`,
+	}, {
+		attrs: valid(application.ConfigAttributes{application.AutoscaleMinUnitsOptionName: 4}),
+		err:   `autoscale-max-units 3 less than autoscale-min-units 4 not valid`,
+	}, {
+		attrs: valid(application.ConfigAttributes{application.AutoscaleScaleUpThresholdOptionName: ""}),
+		err:   `autoscale-source without autoscale-scale-up-threshold not valid`,
+	}, {
+		attrs: valid(application.ConfigAttributes{application.AutoscaleScaleDownThresholdOptionName: "low"}),
+		err:   `autoscale-scale-down-threshold "low" not valid`,
+	}, {
+		attrs: valid(application.ConfigAttributes{application.AutoscaleScaleDownThresholdOptionName: "10"}),
+		err:   `autoscale-scale-down-threshold 10 not less than autoscale-scale-up-threshold 10 not valid`,
+	}, {
+		attrs: valid(application.ConfigAttributes{application.AutoscaleCooldownOptionName: "a while"}),
+		err:   `autoscale-cooldown "a while" not valid`,
+	}, {
+		attrs: valid(application.ConfigAttributes{application.AutoscaleCooldownOptionName: "-1m"}),
+		err:   `negative autoscale-cooldown not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := test.attrs.AutoscalePolicy()
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AutoscaleSuite) TestDecide(c *gc.C) {
+	policy := application.AutoscalePolicy{
+		Source:             application.AutoscaleSource{Metric: "load"},
+		MinUnits:           2,
+		MaxUnits:           4,
+		ScaleUpThreshold:   0.8,
+		ScaleDownThreshold: 0.2,
+	}
+	for i, test := range []struct {
+		units  int
+		value  float64
+		target int
+		reason string
+	}{
+		{units: 3, value: 0.5, target: 3},
+		{units: 3, value: 0.9, target: 4, reason: "metric:load 0.9 above 0.8"},
+		{units: 4, value: 0.9, target: 4},
+		{units: 3, value: 0.1, target: 2, reason: "metric:load 0.1 below 0.2"},
+		{units: 2, value: 0.1, target: 2},
+		{units: 1, value: 0.5, target: 2, reason: "1 unit(s) below minimum of 2"},
+		{units: 6, value: 0.9, target: 4, reason: "6 unit(s) above maximum of 4"},
+	} {
+		c.Logf("test %d", i)
+		target, reason := policy.Decide(test.units, test.value)
+		c.Check(target, gc.Equals, test.target)
+		c.Check(reason, gc.Equals, test.reason)
+	}
+}
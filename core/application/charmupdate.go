@@ -0,0 +1,211 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// CharmUpdatePolicyOptionName is the application config option that
+	// determines what happens when a new revision of the application's
+	// charm is published to its channel.
+	CharmUpdatePolicyOptionName = "charm-update-policy"
+
+	// CharmUpdateWindowOptionName is the application config option that
+	// restricts automatic charm upgrades to a maintenance window.
+	CharmUpdateWindowOptionName = "charm-update-window"
+)
+
+// CharmUpdatePolicy determines what happens when a new revision of an
+// application's charm is published to its channel.
+type CharmUpdatePolicy string
+
+const (
+	// CharmUpdateManual pins the application's charm revision: new
+	// revisions are neither reported nor applied.
+	CharmUpdateManual CharmUpdatePolicy = "manual"
+
+	// CharmUpdateNotify reports new revisions in status, leaving the
+	// upgrade to the operator. This is the default.
+	CharmUpdateNotify CharmUpdatePolicy = "notify"
+
+	// CharmUpdateAuto upgrades the application to new revisions in its
+	// channel automatically, within its maintenance window if one is set.
+	CharmUpdateAuto CharmUpdatePolicy = "auto"
+)
+
+// Validate returns an error if the policy is not one of the known
+// policies.
+func (p CharmUpdatePolicy) Validate() error {
+	switch p {
+	case CharmUpdateManual, CharmUpdateNotify, CharmUpdateAuto:
+		return nil
+	}
+	return errors.NotValidf("charm update policy %q", string(p))
+}
+
+// CharmUpdatePolicy returns the charm update policy set for the
+// application, which defaults to CharmUpdateNotify.
+func (c ConfigAttributes) CharmUpdatePolicy() (CharmUpdatePolicy, error) {
+	value := c.GetString(CharmUpdatePolicyOptionName, "")
+	if value == "" {
+		return CharmUpdateNotify, nil
+	}
+	policy := CharmUpdatePolicy(value)
+	if err := policy.Validate(); err != nil {
+		return "", errors.Trace(err)
+	}
+	return policy, nil
+}
+
+// CharmUpdateWindow returns the maintenance window within which the
+// application's charm may be upgraded automatically.
+func (c ConfigAttributes) CharmUpdateWindow() (MaintenanceWindow, error) {
+	return ParseMaintenanceWindow(c.GetString(CharmUpdateWindowOptionName, ""))
+}
+
+// MaintenanceWindow is a period of each day, or of certain days of the
+// week, in UTC. The zero value is a window that is always open.
+type MaintenanceWindow struct {
+	// Days holds the days of the week on which the window opens. If it
+	// is empty, the window opens every day.
+	Days []time.Weekday
+
+	// Start is the time of day, since midnight, at which the window
+	// opens.
+	Start time.Duration
+
+	// Duration is how long the window stays open. A window may remain
+	// open past midnight.
+	Duration time.Duration
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ParseMaintenanceWindow parses a maintenance window of the form
+// "[<day>[,<day>...]] HH:MM-HH:MM", for example "sat,sun 02:00-04:00"
+// or "23:00-01:00". Days are given by the first three letters of their
+// names, and times are in UTC. An empty value results in a window that
+// is always open.
+func ParseMaintenanceWindow(value string) (MaintenanceWindow, error) {
+	var w MaintenanceWindow
+	fields := strings.Fields(value)
+	switch len(fields) {
+	case 0:
+		return w, nil
+	case 1:
+	case 2:
+		for _, day := range strings.Split(fields[0], ",") {
+			weekday, ok := weekdays[strings.ToLower(day)]
+			if !ok {
+				return MaintenanceWindow{}, errors.NotValidf("maintenance window %q: day %q", value, day)
+			}
+			w.Days = append(w.Days, weekday)
+		}
+	default:
+		return MaintenanceWindow{}, errors.NotValidf("maintenance window %q", value)
+	}
+	times := strings.Split(fields[len(fields)-1], "-")
+	if len(times) != 2 {
+		return MaintenanceWindow{}, errors.NotValidf("maintenance window %q: expected HH:MM-HH:MM", value)
+	}
+	start, err := parseTimeOfDay(times[0])
+	if err != nil {
+		return MaintenanceWindow{}, errors.Annotatef(err, "maintenance window %q", value)
+	}
+	end, err := parseTimeOfDay(times[1])
+	if err != nil {
+		return MaintenanceWindow{}, errors.Annotatef(err, "maintenance window %q", value)
+	}
+	if end == start {
+		return MaintenanceWindow{}, errors.NotValidf("maintenance window %q: empty window", value)
+	}
+	if end < start {
+		end += 24 * time.Hour
+	}
+	w.Start = start
+	w.Duration = end - start
+	return w, nil
+}
+
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, errors.NotValidf("time of day %q", value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// IsZero returns true if the window is always open.
+func (w MaintenanceWindow) IsZero() bool {
+	return w.Duration == 0
+}
+
+// Contains returns true if the window is open at the given time.
+func (w MaintenanceWindow) Contains(t time.Time) bool {
+	if w.IsZero() {
+		return true
+	}
+	t = t.UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	// The window may have opened today, or yesterday and remained
+	// open past midnight.
+	for _, opened := range []time.Time{midnight, midnight.AddDate(0, 0, -1)} {
+		if !w.opensOn(opened.Weekday()) {
+			continue
+		}
+		start := opened.Add(w.Start)
+		if !t.Before(start) && t.Before(start.Add(w.Duration)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w MaintenanceWindow) opensOn(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the window in the form accepted by
+// ParseMaintenanceWindow.
+func (w MaintenanceWindow) String() string {
+	if w.IsZero() {
+		return ""
+	}
+	end := (w.Start + w.Duration) % (24 * time.Hour)
+	times := fmt.Sprintf("%s-%s", formatTimeOfDay(w.Start), formatTimeOfDay(end))
+	if len(w.Days) == 0 {
+		return times
+	}
+	days := make([]string, len(w.Days))
+	for i, day := range w.Days {
+		days[i] = strings.ToLower(day.String()[:3])
+	}
+	return strings.Join(days, ",") + " " + times
+}
+
+func formatTimeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
@@ -0,0 +1,123 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"strings"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type CharmUpdateSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&CharmUpdateSuite{})
+
+func (s *CharmUpdateSuite) TestCharmUpdatePolicy(c *gc.C) {
+	policy, err := application.ConfigAttributes{}.CharmUpdatePolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, gc.Equals, application.CharmUpdateNotify)
+
+	policy, err = application.ConfigAttributes{
+		application.CharmUpdatePolicyOptionName: "auto",
+	}.CharmUpdatePolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, gc.Equals, application.CharmUpdateAuto)
+
+	_, err = application.ConfigAttributes{
+		application.CharmUpdatePolicyOptionName: "sometimes",
+	}.CharmUpdatePolicy()
+	c.Assert(err, gc.ErrorMatches, `charm update policy "sometimes" not valid`)
+}
+
+func (s *CharmUpdateSuite) TestParseMaintenanceWindow(c *gc.C) {
+	for i, test := range []struct {
+		value    string
+		expected application.MaintenanceWindow
+		err      string
+	}{{
+		value: "",
+	}, {
+		value: "02:00-04:30",
+		expected: application.MaintenanceWindow{
+			Start:    2 * time.Hour,
+			Duration: 150 * time.Minute,
+		},
+	}, {
+		value: "sat,Sun 23:00-01:00",
+		expected: application.MaintenanceWindow{
+			Days:     []time.Weekday{time.Saturday, time.Sunday},
+			Start:    23 * time.Hour,
+			Duration: 2 * time.Hour,
+		},
+	}, {
+		value: "someday 02:00-04:00",
+		err:   `maintenance window "someday 02:00-04:00": day "someday" not valid`,
+	}, {
+		value: "02:00",
+		err:   `maintenance window "02:00": expected HH:MM-HH:MM not valid`,
+	}, {
+		value: "02:00-25:00",
+		err:   `maintenance window "02:00-25:00": time of day "25:00" not valid`,
+	}, {
+		value: "02:00-02:00",
+		err:   `maintenance window "02:00-02:00": empty window not valid`,
+	}, {
+		value: "mon 02:00-03:00 extra",
+		err:   `maintenance window "mon 02:00-03:00 extra" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.value)
+		w, err := application.ParseMaintenanceWindow(test.value)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(w, jc.DeepEquals, test.expected)
+		c.Check(w.String(), gc.Equals, strings.ToLower(test.value))
+	}
+}
+
+func (s *CharmUpdateSuite) TestMaintenanceWindowContains(c *gc.C) {
+	// 2020-07-04 is a Saturday.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2020, 7, day, hour, minute, 0, 0, time.UTC)
+	}
+	for i, test := range []struct {
+		window   string
+		t        time.Time
+		expected bool
+	}{
+		{"", at(4, 12, 0), true},
+		{"02:00-04:00", at(4, 2, 0), true},
+		{"02:00-04:00", at(4, 3, 59), true},
+		{"02:00-04:00", at(4, 4, 0), false},
+		{"02:00-04:00", at(4, 1, 59), false},
+		{"sat 23:00-01:00", at(4, 23, 30), true},
+		{"sat 23:00-01:00", at(5, 0, 30), true},
+		{"sat 23:00-01:00", at(5, 23, 30), false},
+		{"sun 23:00-01:00", at(4, 23, 30), false},
+		{"mon,wed 10:00-11:00", at(6, 10, 30), true},
+		{"mon,wed 10:00-11:00", at(7, 10, 30), false},
+	} {
+		c.Logf("test %d: %q at %v", i, test.window, test.t)
+		w, err := application.ParseMaintenanceWindow(test.window)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(w.Contains(test.t), gc.Equals, test.expected)
+	}
+}
+
+func (s *CharmUpdateSuite) TestMaintenanceWindowContainsConvertsToUTC(c *gc.C) {
+	w, err := application.ParseMaintenanceWindow("02:00-04:00")
+	c.Assert(err, jc.ErrorIsNil)
+	zone := time.FixedZone("UTC+10", 10*60*60)
+	c.Assert(w.Contains(time.Date(2020, 7, 4, 13, 0, 0, 0, zone)), jc.IsTrue)
+	c.Assert(w.Contains(time.Date(2020, 7, 4, 3, 0, 0, 0, zone)), jc.IsFalse)
+}
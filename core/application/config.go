@@ -71,6 +71,24 @@ func (c *Config) schemaChecker() (schema.Checker, error) {
 
 // Validate returns an error if the config is not valid.
 func (c *Config) Validate() error {
+	if _, err := c.Attributes().UpdateStatusHookInterval(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := c.Attributes().HookRetryPolicy(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := c.Attributes().CharmUpdatePolicy(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := c.Attributes().CharmUpdateWindow(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := c.Attributes().PlacementPolicy(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := c.Attributes().AutoscalePolicy(); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
@@ -0,0 +1,89 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// The following application config options override the model's hook
+// retry strategy for the units of a single application.
+const (
+	// HookRetryOptionName enables or disables the automatic retry of
+	// failed hooks, overriding the model's automatically-retry-hooks.
+	HookRetryOptionName = "hook-retry"
+
+	// HookRetryMaxAttemptsOptionName is the number of times a failed
+	// hook is retried before the agent gives up. Zero means no limit.
+	HookRetryMaxAttemptsOptionName = "hook-retry-max-attempts"
+
+	// HookRetryMinDelayOptionName is the delay before the first retry
+	// of a failed hook.
+	HookRetryMinDelayOptionName = "hook-retry-min-delay"
+
+	// HookRetryMaxDelayOptionName is the longest delay between retries
+	// of a failed hook.
+	HookRetryMaxDelayOptionName = "hook-retry-max-delay"
+
+	// HookRetryJitterOptionName determines whether the delay between
+	// retries of a failed hook is randomised.
+	HookRetryJitterOptionName = "hook-retry-jitter"
+)
+
+// HookRetryPolicy holds an application's overrides of the model's hook
+// retry strategy. Nil and zero values mean that the model's value is to
+// be used.
+type HookRetryPolicy struct {
+	ShouldRetry *bool
+	MaxAttempts int
+	MinDelay    time.Duration
+	MaxDelay    time.Duration
+	Jitter      *bool
+}
+
+// HookRetryPolicy returns the hook retry policy set for the application.
+func (c ConfigAttributes) HookRetryPolicy() (HookRetryPolicy, error) {
+	var policy HookRetryPolicy
+	if v, ok := c[HookRetryOptionName].(bool); ok {
+		policy.ShouldRetry = &v
+	}
+	if v, ok := c[HookRetryJitterOptionName].(bool); ok {
+		policy.Jitter = &v
+	}
+	if _, ok := c[HookRetryMaxAttemptsOptionName]; ok {
+		policy.MaxAttempts = c.GetInt(HookRetryMaxAttemptsOptionName, 0)
+		if policy.MaxAttempts < 0 {
+			return HookRetryPolicy{}, errors.NotValidf("negative %s", HookRetryMaxAttemptsOptionName)
+		}
+	}
+	var err error
+	if policy.MinDelay, err = c.hookRetryDelay(HookRetryMinDelayOptionName); err != nil {
+		return HookRetryPolicy{}, errors.Trace(err)
+	}
+	if policy.MaxDelay, err = c.hookRetryDelay(HookRetryMaxDelayOptionName); err != nil {
+		return HookRetryPolicy{}, errors.Trace(err)
+	}
+	if policy.MinDelay > 0 && policy.MaxDelay > 0 && policy.MinDelay > policy.MaxDelay {
+		return HookRetryPolicy{}, errors.NotValidf(
+			"%s %v greater than %s %v",
+			HookRetryMinDelayOptionName, policy.MinDelay,
+			HookRetryMaxDelayOptionName, policy.MaxDelay,
+		)
+	}
+	return policy, nil
+}
+
+func (c ConfigAttributes) hookRetryDelay(name string) (time.Duration, error) {
+	value := c.GetString(name, "")
+	if value == "" {
+		return 0, nil
+	}
+	delay, err := time.ParseDuration(value)
+	if err != nil || delay <= 0 {
+		return 0, errors.NotValidf("%s %q", name, value)
+	}
+	return delay, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type HookRetrySuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&HookRetrySuite{})
+
+func (s *HookRetrySuite) TestHookRetryPolicyEmpty(c *gc.C) {
+	policy, err := application.ConfigAttributes{}.HookRetryPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, application.HookRetryPolicy{})
+}
+
+func (s *HookRetrySuite) TestHookRetryPolicy(c *gc.C) {
+	policy, err := application.ConfigAttributes{
+		application.HookRetryOptionName:            true,
+		application.HookRetryMaxAttemptsOptionName: 5,
+		application.HookRetryMinDelayOptionName:    "10s",
+		application.HookRetryMaxDelayOptionName:    "10m",
+		application.HookRetryJitterOptionName:      false,
+	}.HookRetryPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	shouldRetry, jitter := true, false
+	c.Assert(policy, jc.DeepEquals, application.HookRetryPolicy{
+		ShouldRetry: &shouldRetry,
+		MaxAttempts: 5,
+		MinDelay:    10 * time.Second,
+		MaxDelay:    10 * time.Minute,
+		Jitter:      &jitter,
+	})
+}
+
+func (s *HookRetrySuite) TestHookRetryPolicyInvalid(c *gc.C) {
+	for i, test := range []struct {
+		attrs application.ConfigAttributes
+		err   string
+	}{{
+		attrs: application.ConfigAttributes{application.HookRetryMaxAttemptsOptionName: -1},
+		err:   `negative hook-retry-max-attempts not valid`,
+	}, {
+		attrs: application.ConfigAttributes{application.HookRetryMinDelayOptionName: "soon"},
+		err:   `hook-retry-min-delay "soon" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{application.HookRetryMaxDelayOptionName: "-1m"},
+		err:   `hook-retry-max-delay "-1m" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{
+			application.HookRetryMinDelayOptionName: "10m",
+			application.HookRetryMaxDelayOptionName: "1m",
+		},
+		err: `hook-retry-min-delay 10m0s greater than hook-retry-max-delay 1m0s not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := test.attrs.HookRetryPolicy()
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+)
+
+// The following application config options constrain where the units of
+// an application may be placed.
+const (
+	// PlacementSpreadOptionName determines what the units of the
+	// application are spread across: "machine", "zone", or
+	// "label:<key>" for the values of a machine annotation.
+	PlacementSpreadOptionName = "placement-spread"
+
+	// PlacementMaxUnitsPerMachineOptionName is the largest number of
+	// units of the application that may share a machine. Zero means no
+	// limit.
+	PlacementMaxUnitsPerMachineOptionName = "placement-max-units-per-machine"
+
+	// PlacementAvoidOptionName is a comma separated list of applications
+	// whose units must never share a machine with the application's.
+	PlacementAvoidOptionName = "placement-avoid"
+)
+
+// PlacementSpread identifies what the units of an application are spread
+// across.
+type PlacementSpread string
+
+const (
+	// PlacementSpreadNone places units without regard to each other.
+	// This is the default.
+	PlacementSpreadNone PlacementSpread = ""
+
+	// PlacementSpreadMachine places each unit on a different machine.
+	PlacementSpreadMachine PlacementSpread = "machine"
+
+	// PlacementSpreadZone prefers the availability zone hosting the
+	// fewest units of the application.
+	PlacementSpreadZone PlacementSpread = "zone"
+
+	// placementSpreadLabelPrefix prefixes the annotation key when units
+	// are spread across the values of a machine annotation.
+	placementSpreadLabelPrefix = "label:"
+)
+
+// LabelPlacementSpread returns the policy that spreads units across the
+// values of the given machine annotation.
+func LabelPlacementSpread(key string) PlacementSpread {
+	return PlacementSpread(placementSpreadLabelPrefix + key)
+}
+
+// Label returns the machine annotation key that units are spread across,
+// or "" if the units are not spread by label.
+func (s PlacementSpread) Label() string {
+	if !strings.HasPrefix(string(s), placementSpreadLabelPrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(string(s), placementSpreadLabelPrefix)
+}
+
+// Validate returns an error if the spread is not one of the known kinds.
+func (s PlacementSpread) Validate() error {
+	switch s {
+	case PlacementSpreadNone, PlacementSpreadMachine, PlacementSpreadZone:
+		return nil
+	}
+	if s.Label() != "" {
+		return nil
+	}
+	return errors.NotValidf("%s %q", PlacementSpreadOptionName, string(s))
+}
+
+// PlacementPolicy holds the constraints on where the units of an
+// application may be placed.
+type PlacementPolicy struct {
+	Spread             PlacementSpread
+	MaxUnitsPerMachine int
+	Avoid              []string
+}
+
+// IsEmpty returns true if the policy places no constraints on units.
+func (p PlacementPolicy) IsEmpty() bool {
+	return p.Spread == PlacementSpreadNone && p.MaxUnitsPerMachine == 0 && len(p.Avoid) == 0
+}
+
+// UnitsPerMachine returns the largest number of units that may share a
+// machine, or zero if there is no limit.
+func (p PlacementPolicy) UnitsPerMachine() int {
+	if p.Spread == PlacementSpreadMachine {
+		return 1
+	}
+	return p.MaxUnitsPerMachine
+}
+
+// Avoids returns true if the policy forbids sharing a machine with the
+// named application.
+func (p PlacementPolicy) Avoids(appName string) bool {
+	for _, name := range p.Avoid {
+		if name == appName {
+			return true
+		}
+	}
+	return false
+}
+
+// PlacementPolicy returns the placement policy set for the application.
+func (c ConfigAttributes) PlacementPolicy() (PlacementPolicy, error) {
+	policy := PlacementPolicy{
+		Spread: PlacementSpread(c.GetString(PlacementSpreadOptionName, "")),
+	}
+	if err := policy.Spread.Validate(); err != nil {
+		return PlacementPolicy{}, errors.Trace(err)
+	}
+	if _, ok := c[PlacementMaxUnitsPerMachineOptionName]; ok {
+		policy.MaxUnitsPerMachine = c.GetInt(PlacementMaxUnitsPerMachineOptionName, 0)
+		if policy.MaxUnitsPerMachine < 0 {
+			return PlacementPolicy{}, errors.NotValidf("negative %s", PlacementMaxUnitsPerMachineOptionName)
+		}
+	}
+	if policy.Spread == PlacementSpreadMachine && policy.MaxUnitsPerMachine > 1 {
+		return PlacementPolicy{}, errors.NotValidf(
+			"%s %d with %s %q",
+			PlacementMaxUnitsPerMachineOptionName, policy.MaxUnitsPerMachine,
+			PlacementSpreadOptionName, PlacementSpreadMachine,
+		)
+	}
+	for _, name := range strings.Split(c.GetString(PlacementAvoidOptionName, ""), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !names.IsValidApplication(name) {
+			return PlacementPolicy{}, errors.NotValidf("%s application name %q", PlacementAvoidOptionName, name)
+		}
+		policy.Avoid = append(policy.Avoid, name)
+	}
+	return policy, nil
+}
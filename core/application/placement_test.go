@@ -0,0 +1,91 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type PlacementSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&PlacementSuite{})
+
+func (s *PlacementSuite) TestPlacementPolicyEmpty(c *gc.C) {
+	policy, err := application.ConfigAttributes{}.PlacementPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, application.PlacementPolicy{})
+	c.Assert(policy.IsEmpty(), jc.IsTrue)
+	c.Assert(policy.UnitsPerMachine(), gc.Equals, 0)
+}
+
+func (s *PlacementSuite) TestPlacementPolicy(c *gc.C) {
+	policy, err := application.ConfigAttributes{
+		application.PlacementSpreadOptionName:             "zone",
+		application.PlacementMaxUnitsPerMachineOptionName: 2,
+		application.PlacementAvoidOptionName:              "mysql, postgresql,",
+	}.PlacementPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, application.PlacementPolicy{
+		Spread:             application.PlacementSpreadZone,
+		MaxUnitsPerMachine: 2,
+		Avoid:              []string{"mysql", "postgresql"},
+	})
+	c.Assert(policy.IsEmpty(), jc.IsFalse)
+	c.Assert(policy.UnitsPerMachine(), gc.Equals, 2)
+	c.Assert(policy.Avoids("mysql"), jc.IsTrue)
+	c.Assert(policy.Avoids("wordpress"), jc.IsFalse)
+}
+
+func (s *PlacementSuite) TestPlacementPolicySpreadMachine(c *gc.C) {
+	policy, err := application.ConfigAttributes{
+		application.PlacementSpreadOptionName: "machine",
+	}.PlacementPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy.UnitsPerMachine(), gc.Equals, 1)
+}
+
+func (s *PlacementSuite) TestPlacementPolicySpreadLabel(c *gc.C) {
+	policy, err := application.ConfigAttributes{
+		application.PlacementSpreadOptionName: "label:rack",
+	}.PlacementPolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy.Spread, gc.Equals, application.LabelPlacementSpread("rack"))
+	c.Assert(policy.Spread.Label(), gc.Equals, "rack")
+	c.Assert(application.PlacementSpreadZone.Label(), gc.Equals, "")
+}
+
+func (s *PlacementSuite) TestPlacementPolicyInvalid(c *gc.C) {
+	for i, test := range []struct {
+		attrs application.ConfigAttributes
+		err   string
+	}{{
+		attrs: application.ConfigAttributes{application.PlacementSpreadOptionName: "rack"},
+		err:   `placement-spread "rack" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{application.PlacementSpreadOptionName: "label:"},
+		err:   `placement-spread "label:" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{application.PlacementMaxUnitsPerMachineOptionName: -1},
+		err:   `negative placement-max-units-per-machine not valid`,
+	}, {
+		attrs: application.ConfigAttributes{
+			application.PlacementSpreadOptionName:             "machine",
+			application.PlacementMaxUnitsPerMachineOptionName: 2,
+		},
+		err: `placement-max-units-per-machine 2 with placement-spread "machine" not valid`,
+	}, {
+		attrs: application.ConfigAttributes{application.PlacementAvoidOptionName: "mysql,Bad_Name"},
+		err:   `placement-avoid application name "Bad_Name" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := test.attrs.PlacementPolicy()
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
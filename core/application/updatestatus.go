@@ -0,0 +1,51 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// UpdateStatusHookIntervalOptionName is the application config option
+	// used to override the model's update-status-hook-interval for the
+	// units of a single application.
+	UpdateStatusHookIntervalOptionName = "update-status-hook-interval"
+
+	// MinUpdateStatusHookInterval is the shortest update status hook
+	// interval that may be configured for an application.
+	MinUpdateStatusHookInterval = 10 * time.Second
+
+	// MaxUpdateStatusHookInterval is the longest update status hook
+	// interval that may be configured for an application.
+	MaxUpdateStatusHookInterval = 60 * time.Minute
+)
+
+// ParseUpdateStatusHookInterval parses and validates an application's
+// update status hook interval. An empty value is valid, and means that
+// the model's interval is to be used; it is returned as zero.
+func ParseUpdateStatusHookInterval(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.NotValidf("update status hook interval %q", value)
+	}
+	if interval < MinUpdateStatusHookInterval {
+		return 0, errors.NotValidf("update status hook interval %v less than %v", interval, MinUpdateStatusHookInterval)
+	}
+	if interval > MaxUpdateStatusHookInterval {
+		return 0, errors.NotValidf("update status hook interval %v greater than %v", interval, MaxUpdateStatusHookInterval)
+	}
+	return interval, nil
+}
+
+// UpdateStatusHookInterval returns the update status hook interval set
+// for the application, or zero if the model's interval is to be used.
+func (c ConfigAttributes) UpdateStatusHookInterval() (time.Duration, error) {
+	return ParseUpdateStatusHookInterval(c.GetString(UpdateStatusHookIntervalOptionName, ""))
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type UpdateStatusSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&UpdateStatusSuite{})
+
+func (s *UpdateStatusSuite) TestParseUpdateStatusHookInterval(c *gc.C) {
+	for i, test := range []struct {
+		value    string
+		expected time.Duration
+		err      string
+	}{{
+		value:    "",
+		expected: 0,
+	}, {
+		value:    "30s",
+		expected: 30 * time.Second,
+	}, {
+		value:    "1h",
+		expected: time.Hour,
+	}, {
+		value: "soon",
+		err:   `update status hook interval "soon" not valid`,
+	}, {
+		value: "5s",
+		err:   `update status hook interval 5s less than 10s not valid`,
+	}, {
+		value: "2h",
+		err:   `update status hook interval 2h0m0s greater than 1h0m0s not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.value)
+		interval, err := application.ParseUpdateStatusHookInterval(test.value)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(interval, gc.Equals, test.expected)
+	}
+}
+
+func (s *UpdateStatusSuite) TestConfigValidate(c *gc.C) {
+	fields := environschema.Fields{
+		application.UpdateStatusHookIntervalOptionName: {
+			Type:  environschema.Tstring,
+			Group: environschema.JujuGroup,
+		},
+	}
+	cfg, err := application.NewConfig(map[string]interface{}{
+		application.UpdateStatusHookIntervalOptionName: "30s",
+	}, fields, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Validate(), jc.ErrorIsNil)
+	interval, err := cfg.Attributes().UpdateStatusHookInterval()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(interval, gc.Equals, 30*time.Second)
+
+	cfg, err = application.NewConfig(map[string]interface{}{
+		application.UpdateStatusHookIntervalOptionName: "1s",
+	}, fields, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Validate(), gc.ErrorMatches, `update status hook interval 1s less than 10s not valid`)
+}
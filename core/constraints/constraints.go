@@ -32,6 +32,7 @@ const (
 	Tags           = "tags"
 	InstanceType   = "instance-type"
 	Spaces         = "spaces"
+	SpotMaxPrice   = "spot-max-price"
 	VirtType       = "virt-type"
 	Zones          = "zones"
 )
@@ -90,6 +91,12 @@ type Value struct {
 	// have a "^" prefix to the name.
 	Spaces *[]string `json:"spaces,omitempty" yaml:"spaces,omitempty"`
 
+	// SpotMaxPrice, if not nil or empty, indicates that the machine
+	// should be a spot instance, costing at most the given price in US
+	// dollars per hour. Only valid for clouds which support spot
+	// instances.
+	SpotMaxPrice *string `json:"spot-max-price,omitempty" yaml:"spot-max-price,omitempty"`
+
 	// VirtType, if not nil or empty, indicates that a machine must run the named
 	// virtual type. Only valid for clouds with multi-hypervisor support.
 	VirtType *string `json:"virt-type,omitempty" yaml:"virt-type,omitempty"`
@@ -198,6 +205,12 @@ func (v *Value) HasSpaces() bool {
 	return v.Spaces != nil && len(*v.Spaces) > 0
 }
 
+// HasSpotMaxPrice returns true if the constraints.Value specifies a
+// maximum spot price.
+func (v *Value) HasSpotMaxPrice() bool {
+	return v.SpotMaxPrice != nil && *v.SpotMaxPrice != ""
+}
+
 // HasVirtType returns true if the constraints.Value specifies an virtual type.
 func (v *Value) HasVirtType() bool {
 	return v.VirtType != nil && *v.VirtType != ""
@@ -252,6 +265,9 @@ func (v Value) String() string {
 		s := strings.Join(*v.Spaces, ",")
 		strs = append(strs, "spaces="+s)
 	}
+	if v.SpotMaxPrice != nil {
+		strs = append(strs, "spot-max-price="+(*v.SpotMaxPrice))
+	}
 	if v.VirtType != nil {
 		strs = append(strs, "virt-type="+(*v.VirtType))
 	}
@@ -303,6 +319,9 @@ func (v Value) GoString() string {
 	} else if v.Spaces != nil {
 		values = append(values, "Spaces: (*[]string)(nil)")
 	}
+	if v.SpotMaxPrice != nil {
+		values = append(values, fmt.Sprintf("SpotMaxPrice: %q", *v.SpotMaxPrice))
+	}
 	if v.VirtType != nil {
 		values = append(values, fmt.Sprintf("VirtType: %q", *v.VirtType))
 	}
@@ -472,6 +491,8 @@ func (v *Value) setRaw(name, str string) error {
 		err = v.setInstanceType(str)
 	case Spaces:
 		err = v.setSpaces(str)
+	case SpotMaxPrice:
+		err = v.setSpotMaxPrice(str)
 	case VirtType:
 		err = v.setVirtType(str)
 	case Zones:
@@ -539,6 +560,10 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			if err == nil {
 				v.Spaces = spaces
 			}
+		case SpotMaxPrice:
+			if err = validateSpotMaxPrice(vstr); err == nil {
+				v.SpotMaxPrice = &vstr
+			}
 		case VirtType:
 			v.VirtType = &vstr
 		case Zones:
@@ -667,6 +692,27 @@ func (v *Value) validateSpaces(spaces *[]string) error {
 	return nil
 }
 
+func (v *Value) setSpotMaxPrice(str string) error {
+	if v.SpotMaxPrice != nil {
+		return errors.Errorf("already set")
+	}
+	if err := validateSpotMaxPrice(str); err != nil {
+		return err
+	}
+	v.SpotMaxPrice = &str
+	return nil
+}
+
+func validateSpotMaxPrice(str string) error {
+	if str == "" {
+		return nil
+	}
+	if price, err := strconv.ParseFloat(str, 64); err != nil || price <= 0 {
+		return errors.Errorf("must be a positive price in US dollars per hour")
+	}
+	return nil
+}
+
 func (v *Value) setVirtType(str string) error {
 	if v.VirtType != nil {
 		return errors.Errorf("already set")
@@ -272,6 +272,27 @@ var parseConstraintsTests = []struct {
 		err:     `bad "root-disk-source" constraint: already set`,
 	},
 
+	// spot-max-price in detail.
+	{
+		summary: "set spot-max-price empty",
+		args:    []string{"spot-max-price="},
+	}, {
+		summary: "set spot-max-price to a value",
+		args:    []string{"spot-max-price=0.05"},
+	}, {
+		summary: "set spot-max-price to a non-numeric value",
+		args:    []string{"spot-max-price=cheap"},
+		err:     `bad "spot-max-price" constraint: must be a positive price in US dollars per hour`,
+	}, {
+		summary: "set spot-max-price to zero",
+		args:    []string{"spot-max-price=0"},
+		err:     `bad "spot-max-price" constraint: must be a positive price in US dollars per hour`,
+	}, {
+		summary: "double set spot-max-price separately",
+		args:    []string{"spot-max-price=0.05", "spot-max-price=0.1"},
+		err:     `bad "spot-max-price" constraint: already set`,
+	},
+
 	// tags
 	{
 		summary: "single tag",
@@ -559,6 +580,15 @@ func (s *ConstraintsSuite) TestHasRootDiskSource(c *gc.C) {
 	c.Check(con.HasRootDiskSource(), jc.IsFalse)
 }
 
+func (s *ConstraintsSuite) TestHasSpotMaxPrice(c *gc.C) {
+	con := constraints.MustParse("spot-max-price=0.05")
+	c.Check(con.HasSpotMaxPrice(), jc.IsTrue)
+	con = constraints.MustParse("spot-max-price=")
+	c.Check(con.HasSpotMaxPrice(), jc.IsFalse)
+	con = constraints.MustParse("instance-type=m5.large")
+	c.Check(con.HasSpotMaxPrice(), jc.IsFalse)
+}
+
 func (s *ConstraintsSuite) TestHasRootDisk(c *gc.C) {
 	con := constraints.MustParse("root-disk=32G")
 	c.Check(con.HasRootDisk(), jc.IsTrue)
@@ -642,6 +672,8 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Spaces1", constraints.Value{Spaces: nil}},
 	{"Spaces2", constraints.Value{Spaces: &[]string{}}},
 	{"Spaces3", constraints.Value{Spaces: &[]string{"space1", "^space2"}}},
+	{"SpotMaxPrice1", constraints.Value{SpotMaxPrice: nil}},
+	{"SpotMaxPrice2", constraints.Value{SpotMaxPrice: strp("0.05")}},
 	{"InstanceType1", constraints.Value{InstanceType: strp("")}},
 	{"InstanceType2", constraints.Value{InstanceType: strp("foo")}},
 	{"Zones1", constraints.Value{Zones: nil}},
@@ -658,6 +690,7 @@ var constraintsRoundtripTests = []roundTrip{
 		Tags:           &[]string{"foo", "bar"},
 		Spaces:         &[]string{"space1", "^space2"},
 		InstanceType:   strp("foo"),
+		SpotMaxPrice:   strp("1.25"),
 		Zones:          &[]string{"az1", "az2"},
 	}},
 }
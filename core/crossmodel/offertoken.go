@@ -0,0 +1,73 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"encoding/asn1"
+	"encoding/base64"
+
+	"github.com/juju/errors"
+)
+
+// OfferTokenInfo holds what a consumer needs to redeem an offer token
+// with the controller hosting the offer, without having a user there.
+type OfferTokenInfo struct {
+	// ControllerName is the name the token's creator has for the
+	// controller hosting the offer.
+	ControllerName string
+
+	// Addrs contains the "host:port" addresses of the controller
+	// hosting the offer.
+	Addrs []string
+
+	// CACert is the CA certificate of the controller hosting the offer.
+	CACert string
+
+	// ModelUUID is the UUID of the model hosting the offer.
+	ModelUUID string
+
+	// OfferURL is the URL of the offer.
+	OfferURL string
+
+	// TokenId is the id of the token.
+	TokenId string
+
+	// SecretKey is the secret key that must be presented with the
+	// token id to redeem the token.
+	SecretKey string
+}
+
+// EncodeOfferToken returns the string to give to the consumer of an offer
+// for the given token. The string is URL-safe base64 encoded ASN.1, to keep
+// it short and easy to copy and paste in a terminal.
+func EncodeOfferToken(info OfferTokenInfo) (string, error) {
+	data, err := asn1.Marshal(info)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	// Pad with zero bytes so we don't get =. The embedded ASN.1 data
+	// is length-encoded, so the padding will not complicate decoding.
+	if remainder := len(data) % 3; remainder != 0 {
+		var pad [3]byte
+		data = append(data, pad[:3-remainder]...)
+	}
+	return base64.URLEncoding.EncodeToString(data), nil
+}
+
+// DecodeOfferToken returns the offer token information encoded in the
+// given string by EncodeOfferToken.
+func DecodeOfferToken(token string) (OfferTokenInfo, error) {
+	var info OfferTokenInfo
+	data, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return info, errors.NotValidf("offer token")
+	}
+	if _, err := asn1.Unmarshal(data, &info); err != nil {
+		return info, errors.NotValidf("offer token")
+	}
+	if info.TokenId == "" || info.SecretKey == "" || info.ModelUUID == "" || len(info.Addrs) == 0 {
+		return info, errors.NotValidf("offer token")
+	}
+	return info, nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/testing"
+)
+
+type OfferTokenSuite struct{}
+
+var _ = gc.Suite(&OfferTokenSuite{})
+
+func (s *OfferTokenSuite) TestRoundTrip(c *gc.C) {
+	info := crossmodel.OfferTokenInfo{
+		ControllerName: "prod",
+		Addrs:          []string{"10.0.0.1:17070", "10.0.0.2:17070"},
+		CACert:         testing.CACert,
+		ModelUUID:      testing.ModelTag.Id(),
+		OfferURL:       "prod:admin/db.mysql",
+		TokenId:        "0123456789abcdef",
+		SecretKey:      "secret",
+	}
+	token, err := crossmodel.EncodeOfferToken(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.ContainsAny(token, "=+/."), jc.IsFalse)
+
+	decoded, err := crossmodel.DecodeOfferToken(token)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decoded, jc.DeepEquals, info)
+}
+
+func (s *OfferTokenSuite) TestDecodeInvalid(c *gc.C) {
+	for _, token := range []string{"admin/db.mysql", "AAAA", ""} {
+		_, err := crossmodel.DecodeOfferToken(token)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
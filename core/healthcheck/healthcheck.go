@@ -0,0 +1,185 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package healthcheck defines the workload health checks that a charm can
+// register with its unit agent.
+package healthcheck
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Kind identifies the type of probe used by a health check.
+type Kind string
+
+const (
+	// Exec checks run a command; a zero exit code means healthy.
+	Exec Kind = "exec"
+
+	// HTTP checks perform an HTTP GET; a 2xx or 3xx response means healthy.
+	HTTP Kind = "http"
+
+	// TCP checks open a TCP connection; a successful connect means healthy.
+	TCP Kind = "tcp"
+)
+
+const (
+	// DefaultInterval is the time between probes when a check does not
+	// specify an interval.
+	DefaultInterval = 30 * time.Second
+
+	// DefaultTimeout is the time a single probe may take when a check
+	// does not specify a timeout.
+	DefaultTimeout = 10 * time.Second
+
+	// DefaultThreshold is the number of consecutive failed probes after
+	// which a check is considered unhealthy, when the check does not
+	// specify a threshold.
+	DefaultThreshold = 3
+
+	// MinInterval is the smallest interval allowed between probes.
+	MinInterval = time.Second
+)
+
+// Status describes the outcome of one or more health checks.
+type Status string
+
+const (
+	// Unknown is reported before a check has been probed.
+	Unknown Status = "unknown"
+
+	// Healthy is reported when a check is passing.
+	Healthy Status = "healthy"
+
+	// Unhealthy is reported once a check has failed at least
+	// threshold consecutive times.
+	Unhealthy Status = "unhealthy"
+)
+
+var validName = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// IsValidName returns whether name is a valid health check name.
+func IsValidName(name string) bool {
+	return validName.MatchString(name)
+}
+
+// Check describes a single workload health check.
+type Check struct {
+	// Name uniquely identifies the check within the unit.
+	Name string `yaml:"name"`
+
+	// Kind is the type of probe to run.
+	Kind Kind `yaml:"kind"`
+
+	// Command is the shell command run by exec checks.
+	Command string `yaml:"command,omitempty"`
+
+	// URL is the address requested by http checks.
+	URL string `yaml:"url,omitempty"`
+
+	// Address is the host:port dialled by tcp checks.
+	Address string `yaml:"address,omitempty"`
+
+	// Interval is the time between probes.
+	Interval time.Duration `yaml:"interval,omitempty"`
+
+	// Timeout is the time a single probe may take before it is
+	// considered to have failed.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+
+	// Threshold is the number of consecutive failed probes after
+	// which the check is considered unhealthy.
+	Threshold int `yaml:"threshold,omitempty"`
+}
+
+// WithDefaults returns a copy of the check with any unset interval,
+// timeout and threshold replaced by their default values.
+func (c Check) WithDefaults() Check {
+	if c.Interval == 0 {
+		c.Interval = DefaultInterval
+	}
+	if c.Timeout == 0 {
+		c.Timeout = DefaultTimeout
+	}
+	if c.Threshold == 0 {
+		c.Threshold = DefaultThreshold
+	}
+	return c
+}
+
+// Validate returns an error if the check is not valid.
+func (c Check) Validate() error {
+	if !IsValidName(c.Name) {
+		return errors.NotValidf("health check name %q", c.Name)
+	}
+	switch c.Kind {
+	case Exec:
+		if c.Command == "" {
+			return errors.NotValidf("exec health check %q without command", c.Name)
+		}
+	case HTTP:
+		u, err := url.Parse(c.URL)
+		if err != nil {
+			return errors.NotValidf("http health check %q url %q", c.Name, c.URL)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			return errors.NotValidf("http health check %q url %q", c.Name, c.URL)
+		}
+	case TCP:
+		if _, _, err := net.SplitHostPort(c.Address); err != nil {
+			return errors.NotValidf("tcp health check %q address %q", c.Name, c.Address)
+		}
+	default:
+		return errors.NotValidf("health check %q kind %q", c.Name, c.Kind)
+	}
+	if c.Interval != 0 && c.Interval < MinInterval {
+		return errors.NotValidf("health check %q interval %v less than %v", c.Name, c.Interval, MinInterval)
+	}
+	if c.Timeout < 0 {
+		return errors.NotValidf("health check %q negative timeout", c.Name)
+	}
+	if c.Threshold < 0 {
+		return errors.NotValidf("health check %q negative threshold", c.Name)
+	}
+	return nil
+}
+
+// Result records the most recent outcome of a health check.
+type Result struct {
+	// Status is the current status of the check.
+	Status Status `yaml:"status" json:"status"`
+
+	// Failures is the number of consecutive failed probes.
+	Failures int `yaml:"failures,omitempty" json:"failures,omitempty"`
+
+	// Message describes the most recent failure, if any.
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// Report summarises the health of a unit's workload.
+type Report struct {
+	// Status is Unhealthy if any check is unhealthy, Healthy if
+	// every check is healthy, and Unknown otherwise.
+	Status Status
+
+	// Results holds the result of each check, keyed by check name.
+	Results map[string]Result
+}
+
+// Failing returns the sorted names of the unhealthy checks in the report.
+func (r Report) Failing() []string {
+	var failing []string
+	for name, result := range r.Results {
+		if result.Status == Unhealthy {
+			failing = append(failing, name)
+		}
+	}
+	sort.Strings(failing)
+	return failing
+}
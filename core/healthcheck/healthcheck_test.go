@@ -0,0 +1,83 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/healthcheck"
+)
+
+type healthCheckSuite struct{}
+
+var _ = gc.Suite(&healthCheckSuite{})
+
+func (s *healthCheckSuite) TestValidate(c *gc.C) {
+	for i, t := range []struct {
+		check healthcheck.Check
+		err   string
+	}{{
+		check: healthcheck.Check{Name: "daemon", Kind: healthcheck.Exec, Command: "pgrep foo"},
+	}, {
+		check: healthcheck.Check{Name: "web", Kind: healthcheck.HTTP, URL: "http://localhost:8080/health"},
+	}, {
+		check: healthcheck.Check{Name: "db-port", Kind: healthcheck.TCP, Address: "localhost:5432", Interval: 5 * time.Second, Threshold: 1},
+	}, {
+		check: healthcheck.Check{Name: "Bad_Name", Kind: healthcheck.Exec, Command: "true"},
+		err:   `health check name "Bad_Name" not valid`,
+	}, {
+		check: healthcheck.Check{Name: "daemon", Kind: healthcheck.Exec},
+		err:   `exec health check "daemon" without command not valid`,
+	}, {
+		check: healthcheck.Check{Name: "web", Kind: healthcheck.HTTP, URL: "ftp://localhost"},
+		err:   `http health check "web" url "ftp://localhost" not valid`,
+	}, {
+		check: healthcheck.Check{Name: "db-port", Kind: healthcheck.TCP, Address: "localhost"},
+		err:   `tcp health check "db-port" address "localhost" not valid`,
+	}, {
+		check: healthcheck.Check{Name: "daemon", Kind: "udp"},
+		err:   `health check "daemon" kind "udp" not valid`,
+	}, {
+		check: healthcheck.Check{Name: "daemon", Kind: healthcheck.Exec, Command: "true", Interval: time.Millisecond},
+		err:   `health check "daemon" interval 1ms less than 1s not valid`,
+	}, {
+		check: healthcheck.Check{Name: "daemon", Kind: healthcheck.Exec, Command: "true", Threshold: -1},
+		err:   `health check "daemon" negative threshold not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := t.check.Validate()
+		if t.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.err)
+		}
+	}
+}
+
+func (s *healthCheckSuite) TestWithDefaults(c *gc.C) {
+	check := healthcheck.Check{Name: "daemon", Kind: healthcheck.Exec, Command: "true"}.WithDefaults()
+	c.Assert(check.Interval, gc.Equals, healthcheck.DefaultInterval)
+	c.Assert(check.Timeout, gc.Equals, healthcheck.DefaultTimeout)
+	c.Assert(check.Threshold, gc.Equals, healthcheck.DefaultThreshold)
+
+	check = healthcheck.Check{Interval: time.Minute, Timeout: time.Second, Threshold: 1}.WithDefaults()
+	c.Assert(check.Interval, gc.Equals, time.Minute)
+	c.Assert(check.Timeout, gc.Equals, time.Second)
+	c.Assert(check.Threshold, gc.Equals, 1)
+}
+
+func (s *healthCheckSuite) TestReportFailing(c *gc.C) {
+	report := healthcheck.Report{
+		Status: healthcheck.Unhealthy,
+		Results: map[string]healthcheck.Result{
+			"web":    {Status: healthcheck.Unhealthy, Failures: 3},
+			"daemon": {Status: healthcheck.Unhealthy, Failures: 5},
+			"db":     {Status: healthcheck.Healthy},
+		},
+	}
+	c.Assert(report.Failing(), jc.DeepEquals, []string{"daemon", "web"})
+}
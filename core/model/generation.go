@@ -28,6 +28,34 @@ func ValidateBranchName(name string) error {
 	return nil
 }
 
+// BranchConflictResolution indicates how conflicts between a branch and
+// changes made to master since the branch was created are to be resolved
+// when committing the branch.
+type BranchConflictResolution string
+
+const (
+	// ConflictAbort indicates that a branch with conflicts
+	// is not to be committed.
+	ConflictAbort BranchConflictResolution = "abort"
+
+	// ConflictBranchWins indicates that the branch values of conflicting
+	// settings are to overwrite those in master.
+	ConflictBranchWins BranchConflictResolution = "branch"
+
+	// ConflictMasterWins indicates that the master values of conflicting
+	// settings are to be retained. Other branch changes are committed.
+	ConflictMasterWins BranchConflictResolution = "master"
+)
+
+// Validate returns an error if the resolution is not one of those supported.
+func (r BranchConflictResolution) Validate() error {
+	switch r {
+	case ConflictAbort, ConflictBranchWins, ConflictMasterWins:
+		return nil
+	}
+	return errors.NotValidf("conflict resolution %q", string(r))
+}
+
 // GenerationUnits indicates which units from an application are and are not
 // tracking a model branch.
 type GenerationUnits struct {
@@ -57,6 +85,36 @@ type GenerationApplication struct {
 	// TODO (manadart 2018-02-22) This data-type will evolve as more aspects
 	// of the application are made generational.
 	ConfigChanges map[string]interface{} `yaml:"config"`
+
+	// ConfigDiff compares each configuration value changed under the
+	// branch with its value in master when the branch changed it and its
+	// current value in master.
+	ConfigDiff map[string]ConfigItemDiff `yaml:"config-diff,omitempty"`
+
+	// Charm is the URL of the charm that the application is upgraded to
+	// under the branch. Units tracking the branch run this charm.
+	Charm string `yaml:"charm,omitempty"`
+
+	// Resources is the names of the resources uploaded
+	// with the charm that the application is upgraded to.
+	Resources []string `yaml:"resources,omitempty"`
+}
+
+// ConfigItemDiff is a three-way comparison of a configuration value
+// changed under a branch.
+type ConfigItemDiff struct {
+	// Base is the master value when the branch first changed it.
+	Base interface{} `yaml:"base"`
+
+	// Branch is the value under the branch.
+	Branch interface{} `yaml:"branch"`
+
+	// Master is the current master value.
+	Master interface{} `yaml:"master"`
+
+	// Conflict is true if master has been changed since the branch
+	// changed the value, and committing the branch would overwrite it.
+	Conflict bool `yaml:"conflict,omitempty"`
 }
 
 // Generation represents detail of a model generation including config changes.
@@ -5,6 +5,7 @@ package settings
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/juju/charm/v7"
 	"github.com/juju/errors"
@@ -178,6 +179,51 @@ func (c ItemChanges) Map() (map[string]ItemChange, error) {
 	return m, nil
 }
 
+// ThreeWayDiff compares these changes with the input master settings.
+// The old value of each change is the value of the setting in master when
+// it was first changed under the branch, so it represents the common base
+// of the branch and the current master settings.
+func (c ItemChanges) ThreeWayDiff(master map[string]interface{}) []ItemDiff {
+	diffs := make([]ItemDiff, len(c))
+	for i, ch := range c {
+		diffs[i] = ItemDiff{
+			Key:    ch.Key,
+			Base:   ch.OldValue,
+			Branch: ch.NewValue,
+			Master: master[ch.Key],
+		}
+	}
+	return diffs
+}
+
 func (c ItemChanges) Len() int           { return len(c) }
 func (c ItemChanges) Less(i, j int) bool { return c[i].Key < c[j].Key }
 func (c ItemChanges) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+
+// ItemDiff is a three-way comparison of a setting changed under a branch.
+type ItemDiff struct {
+	// Key is the setting being compared.
+	Key string
+	// Base is the value of the setting in master when it was first
+	// changed under the branch. Nil indicates that it was not set.
+	Base interface{}
+	// Branch is the value of the setting under the branch.
+	// Nil indicates that it was deleted under the branch.
+	Branch interface{}
+	// Master is the current value of the setting in master.
+	// Nil indicates that it is not set.
+	Master interface{}
+}
+
+// MasterChanged returns true if the setting was changed in master after
+// it was first changed under the branch.
+func (d ItemDiff) MasterChanged() bool {
+	return !reflect.DeepEqual(d.Base, d.Master)
+}
+
+// Conflicted returns true if the setting was changed in master after it was
+// first changed under the branch, to a value other than the branch value.
+// Committing the branch would overwrite the master change.
+func (d ItemDiff) Conflicted() bool {
+	return d.MasterChanged() && !reflect.DeepEqual(d.Branch, d.Master)
+}
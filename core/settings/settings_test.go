@@ -112,3 +112,42 @@ func (*settingsSuite) TestEffectiveSettings(c *gc.C) {
 	}
 	c.Check(changes.EffectiveChanges(defaults), gc.DeepEquals, exp)
 }
+
+func (*settingsSuite) TestThreeWayDiff(c *gc.C) {
+	changes := ItemChanges{
+		MakeAddition("key1", "new-val"),
+		MakeModification("key2", "old-val", "other-val"),
+		MakeModification("key3", "old-val", "branch-val"),
+		MakeModification("key4", "old-val", "same-val"),
+		MakeDeletion("key5", "old-deleted-val"),
+		MakeModification("key6", "reverted-val", "reverted-val"),
+	}
+
+	master := map[string]interface{}{
+		"key2": "old-val",
+		"key3": "master-val",
+		"key4": "same-val",
+		"key5": "master-val",
+		"key6": "master-val",
+	}
+
+	diffs := changes.ThreeWayDiff(master)
+	c.Assert(diffs, gc.DeepEquals, []ItemDiff{
+		{Key: "key1", Branch: "new-val"},
+		{Key: "key2", Base: "old-val", Branch: "other-val", Master: "old-val"},
+		{Key: "key3", Base: "old-val", Branch: "branch-val", Master: "master-val"},
+		{Key: "key4", Base: "old-val", Branch: "same-val", Master: "same-val"},
+		{Key: "key5", Base: "old-deleted-val", Master: "master-val"},
+		{Key: "key6", Base: "reverted-val", Branch: "reverted-val", Master: "master-val"},
+	})
+
+	var conflicted []string
+	for _, d := range diffs {
+		if d.Conflicted() {
+			conflicted = append(conflicted, d.Key)
+		}
+	}
+	c.Check(conflicted, gc.DeepEquals, []string{"key3", "key5", "key6"})
+	c.Check(diffs[3].MasterChanged(), jc.IsTrue)
+	c.Check(diffs[1].MasterChanged(), jc.IsFalse)
+}
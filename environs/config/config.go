@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 
 	"github.com/juju/juju/charmhub"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/logfwd/syslog"
@@ -256,6 +258,19 @@ const (
 	// CharmhubURLKey is the key for the url to use for charmhub API calls
 	CharmhubURLKey = "charmhub-url"
 
+	// MaxInstanceCostKey is the maximum cost of an instance type which
+	// the provisioner may start, in the cost unit reported by the
+	// provider, eg "0.25" for 25 cents per hour on AWS.
+	MaxInstanceCostKey = "max-instance-cost"
+
+	// MachinePoolSizeKey is the number of idle machines to keep
+	// provisioned in the model, ready for new units to be placed on.
+	MachinePoolSizeKey = "machine-pool-size"
+
+	// MachinePoolConstraintsKey holds the constraints of the machines
+	// kept in the model's machine pool.
+	MachinePoolConstraintsKey = "machine-pool-constraints"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -473,6 +488,9 @@ var defaultConfigValues = map[string]interface{}{
 	ContainerInheritPropertiesKey: "",
 	BackupDirKey:                  "",
 	LXDSnapChannel:                "latest/stable",
+	MaxInstanceCostKey:            "",
+	MachinePoolSizeKey:            0,
+	MachinePoolConstraintsKey:     "",
 
 	CharmhubURLKey: charmhub.CharmhubServerURL,
 
@@ -661,6 +679,22 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[MachinePoolSizeKey].(int); ok && v < 0 {
+		return errors.NotValidf("negative machine pool size %d", v)
+	}
+
+	if v, ok := cfg.defined[MachinePoolConstraintsKey].(string); ok && v != "" {
+		if _, err := constraints.Parse(v); err != nil {
+			return errors.Annotate(err, "invalid machine pool constraints")
+		}
+	}
+
+	if v, ok := cfg.defined[MaxInstanceCostKey].(string); ok && v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err != nil || f <= 0 {
+			return errors.NotValidf("max instance cost %q", v)
+		}
+	}
+
 	if v, ok := cfg.defined[EgressSubnets].(string); ok && v != "" {
 		cidrs := strings.Split(v, ",")
 		for _, cidr := range cidrs {
@@ -1414,6 +1448,34 @@ func (c *Config) UpdateStatusHookInterval() time.Duration {
 	return val
 }
 
+// MaxInstanceCost returns the maximum cost of an instance type which
+// may be started in the model, in the provider's cost unit, and whether
+// a maximum has been set.
+func (c *Config) MaxInstanceCost() (float64, bool) {
+	raw := c.asString(MaxInstanceCostKey)
+	if raw == "" {
+		return 0, false
+	}
+	// Value has already been validated.
+	val, _ := strconv.ParseFloat(raw, 64)
+	return val, true
+}
+
+// MachinePoolSize returns the number of idle machines to keep
+// provisioned in the model.
+func (c *Config) MachinePoolSize() int {
+	value, _ := c.defined[MachinePoolSizeKey].(int)
+	return value
+}
+
+// MachinePoolConstraints returns the constraints of the machines kept
+// in the model's machine pool.
+func (c *Config) MachinePoolConstraints() constraints.Value {
+	// Value has already been validated.
+	cons, _ := constraints.Parse(c.asString(MachinePoolConstraintsKey))
+	return cons
+}
+
 // EgressSubnets are the source addresses from which traffic from this model
 // originates if the model is deployed such that NAT or similar is in use.
 func (c *Config) EgressSubnets() []string {
@@ -1591,6 +1653,9 @@ var alwaysOptional = schema.Defaults{
 	DefaultSpace:                  schema.Omit,
 	LXDSnapChannel:                schema.Omit,
 	CharmhubURLKey:                schema.Omit,
+	MaxInstanceCostKey:            schema.Omit,
+	MachinePoolSizeKey:            schema.Omit,
+	MachinePoolConstraintsKey:     schema.Omit,
 }
 
 func allowEmpty(attr string) bool {
@@ -2106,4 +2171,19 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	MaxInstanceCostKey: {
+		Description: "The maximum cost of an instance type that may be started in this model, in the cost unit reported by the cloud (eg US dollars per hour on AWS, compared against the on-demand price, or the spot price for machines with a spot-max-price constraint)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MachinePoolSizeKey: {
+		Description: "The number of idle machines to keep provisioned in this model, so that new units can be placed without waiting for a machine to start",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	MachinePoolConstraintsKey: {
+		Description: "The constraints of the idle machines kept in this model's machine pool",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 }
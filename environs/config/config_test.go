@@ -20,6 +20,7 @@ import (
 	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/testing"
@@ -1380,6 +1381,52 @@ func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	c.Assert(cfg.EgressSubnets(), gc.DeepEquals, []string{"10.0.0.1/32", "192.168.1.1/16"})
 }
 
+func (s *ConfigSuite) TestMaxInstanceCost(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	_, ok := cfg.MaxInstanceCost()
+	c.Assert(ok, jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"max-instance-cost": "0.25",
+	})
+	cost, ok := cfg.MaxInstanceCost()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(cost, gc.Equals, 0.25)
+}
+
+func (s *ConfigSuite) TestMaxInstanceCostInvalid(c *gc.C) {
+	for _, value := range []string{"cheap", "0", "-1"} {
+		_, err := config.New(config.UseDefaults, sampleConfig.Merge(testing.Attrs{
+			"max-instance-cost": value,
+		}))
+		c.Check(err, gc.ErrorMatches, fmt.Sprintf(`max instance cost %q not valid`, value))
+	}
+}
+
+func (s *ConfigSuite) TestMachinePool(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.MachinePoolSize(), gc.Equals, 0)
+	c.Assert(cfg.MachinePoolConstraints(), jc.DeepEquals, constraints.Value{})
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"machine-pool-size":        2,
+		"machine-pool-constraints": "mem=4G cores=2",
+	})
+	c.Assert(cfg.MachinePoolSize(), gc.Equals, 2)
+	c.Assert(cfg.MachinePoolConstraints(), jc.DeepEquals, constraints.MustParse("mem=4G cores=2"))
+}
+
+func (s *ConfigSuite) TestMachinePoolInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, sampleConfig.Merge(testing.Attrs{
+		"machine-pool-size": -1,
+	}))
+	c.Check(err, gc.ErrorMatches, `negative machine pool size -1 not valid`)
+	_, err = config.New(config.UseDefaults, sampleConfig.Merge(testing.Attrs{
+		"machine-pool-constraints": "mem=lots",
+	}))
+	c.Check(err, gc.ErrorMatches, `invalid machine pool constraints: .*`)
+}
+
 func (s *ConfigSuite) TestCloudInitUserDataFromEnvironment(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		config.CloudInitUserDataKey: validCloudInitUserData,
@@ -23,6 +23,16 @@ type Instance interface {
 	Addresses(context.ProviderCallContext) (corenetwork.ProviderAddresses, error)
 }
 
+// InterruptibleInstance is implemented by instances which the provider
+// may reclaim at short notice, such as EC2 spot instances.
+type InterruptibleInstance interface {
+	Instance
+
+	// Interruptible returns true if the provider may reclaim the
+	// instance at short notice.
+	Interruptible() bool
+}
+
 // InstanceFirewaller provides instance-level firewall functionality
 type InstanceFirewaller interface {
 	// OpenPorts opens the given port ranges on the instance, which
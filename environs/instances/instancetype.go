@@ -5,6 +5,7 @@ package instances
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 
@@ -42,6 +43,20 @@ type InstanceTypesWithCostMetadata struct {
 	CostDivisor uint64
 }
 
+// UnitCost returns the cost of the instance type expressed in CostUnit,
+// and whether the cost is known.
+func (m InstanceTypesWithCostMetadata) UnitCost(itype InstanceType) (float64, bool) {
+	if itype.Cost == math.MaxUint64 {
+		// Providers use the maximum cost for instance
+		// types whose cost is not known.
+		return 0, false
+	}
+	if m.CostDivisor == 0 {
+		return float64(itype.Cost), true
+	}
+	return float64(itype.Cost) / float64(m.CostDivisor), true
+}
+
 func CpuPower(power uint64) *uint64 {
 	return &power
 }
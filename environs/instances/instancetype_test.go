@@ -4,6 +4,7 @@
 package instances
 
 import (
+	"math"
 	"sort"
 
 	jc "github.com/juju/testing/checkers"
@@ -469,6 +470,21 @@ var byNameTests = []struct {
 	},
 }
 
+func (s *instanceTypeSuite) TestUnitCost(c *gc.C) {
+	itypes := InstanceTypesWithCostMetadata{CostUnit: "$USD/hour", CostDivisor: 1000}
+	cost, ok := itypes.UnitCost(InstanceType{Cost: 250})
+	c.Check(ok, jc.IsTrue)
+	c.Check(cost, gc.Equals, 0.25)
+
+	itypes.CostDivisor = 0
+	cost, ok = itypes.UnitCost(InstanceType{Cost: 250})
+	c.Check(ok, jc.IsTrue)
+	c.Check(cost, gc.Equals, 250.0)
+
+	_, ok = itypes.UnitCost(InstanceType{Cost: math.MaxUint64})
+	c.Check(ok, jc.IsFalse)
+}
+
 func (s *instanceTypeSuite) TestSortByName(c *gc.C) {
 	for i, t := range byNameTests {
 		c.Logf("test %d: %s", i, t.about)
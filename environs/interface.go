@@ -21,7 +21,7 @@ import (
 	"github.com/juju/juju/storage"
 )
 
-//go:generate go run github.com/golang/mock/mockgen -package testing -destination testing/package_mock.go github.com/juju/juju/environs EnvironProvider,CloudEnvironProvider,ProviderSchema,ProviderCredentials,FinalizeCredentialContext,FinalizeCloudContext,CloudFinalizer,CloudDetector,CloudRegionDetector,ModelConfigUpgrader,ConfigGetter,CloudDestroyer,Environ,InstancePrechecker,Firewaller,InstanceTagger,InstanceTypesFetcher,Upgrader,UpgradeStep,DefaultConstraintsChecker,ProviderCredentialsRegister,RequestFinalizeCredential,NetworkingEnviron
+//go:generate go run github.com/golang/mock/mockgen -package testing -destination testing/package_mock.go github.com/juju/juju/environs EnvironProvider,CloudEnvironProvider,ProviderSchema,ProviderCredentials,FinalizeCredentialContext,FinalizeCloudContext,CloudFinalizer,CloudDetector,CloudRegionDetector,ModelConfigUpgrader,ConfigGetter,CloudDestroyer,Environ,InstancePrechecker,Firewaller,InstanceTagger,VolumeTagger,InstanceTagRemover,VolumeTagRemover,InstanceTypesFetcher,Upgrader,UpgradeStep,DefaultConstraintsChecker,ProviderCredentialsRegister,RequestFinalizeCredential,NetworkingEnviron
 
 // A EnvironProvider represents a computing and storage provider
 // for either a traditional cloud or a container substrate like k8s.
@@ -237,6 +237,37 @@ type CloudRegionDetector interface {
 	DetectRegions() ([]cloud.Region, error)
 }
 
+// CloudCapacityReporter is an interface that an EnvironProvider implements
+// in order to report the capacity of each of a cloud's availability zones.
+type CloudCapacityReporter interface {
+	// CloudCapacity returns the capacity of each availability zone of
+	// the cloud described by the spec, ordered by zone name. This is
+	// always done client-side, using the client's credential.
+	CloudCapacity(context.ProviderCallContext, environscloudspec.CloudSpec) ([]ZoneCapacity, error)
+}
+
+// ZoneCapacity describes the resources of an availability zone.
+type ZoneCapacity struct {
+	// Zone is the name of the availability zone.
+	Zone string
+
+	// Available is true if instances can be started in the zone.
+	Available bool
+
+	// CpuCores is the number of CPU cores in the zone.
+	CpuCores uint64
+
+	// Mem is the amount of memory in the zone, in MiB.
+	Mem uint64
+
+	// MemUsed is the amount of memory in use in the zone, in MiB.
+	MemUsed uint64
+
+	// Instances is the number of instances in the zone, including
+	// those which are not managed by Juju.
+	Instances int
+}
+
 // ModelConfigUpgrader is an interface that an EnvironProvider may
 // implement in order to modify environment configuration on agent upgrade.
 type ModelConfigUpgrader interface {
@@ -396,6 +427,52 @@ type ControllerDestroyer interface {
 	DestroyController(ctx context.ProviderCallContext, controllerUUID string) error
 }
 
+// CloudResourceKind identifies the kind of a cloud resource.
+type CloudResourceKind string
+
+const (
+	CloudResourceInstance      CloudResourceKind = "instance"
+	CloudResourceVolume        CloudResourceKind = "volume"
+	CloudResourceSecurityGroup CloudResourceKind = "security-group"
+)
+
+// CloudResource identifies a cloud resource created by a controller.
+type CloudResource struct {
+	// Kind is the kind of the resource.
+	Kind CloudResourceKind
+
+	// Id is the provider id of the resource.
+	Id string
+
+	// ModelUUID is the UUID of the model the resource was created
+	// for.
+	ModelUUID string
+
+	// MachineId is the id of the machine the resource was created
+	// for, if known.
+	MachineId string
+
+	// StorageId is the id of the storage instance the resource was
+	// created for, if known.
+	StorageId string
+}
+
+// ControllerResourceLister is an interface that can be used to find
+// the cloud resources created by a controller for all of its models,
+// including models that no longer exist, so that leaked resources can
+// be detected and released.
+type ControllerResourceLister interface {
+	// ControllerResources returns the instances, volumes and security
+	// groups tagged with the given controller UUID. Root disks, which
+	// are destroyed along with their instances, are not returned.
+	ControllerResources(ctx context.ProviderCallContext, controllerUUID string) ([]CloudResource, error)
+
+	// ReleaseControllerResources destroys the given resources, as
+	// returned by ControllerResources, and returns an error for each
+	// that could not be destroyed.
+	ReleaseControllerResources(ctx context.ProviderCallContext, resources []CloudResource) []error
+}
+
 type ResourceAdopter interface {
 	// AdoptResources is called when the model is moved from one
 	// controller to another using model migration. Some providers tag
@@ -499,6 +576,34 @@ type InstanceTagger interface {
 	TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error
 }
 
+// VolumeTagger is an interface that can be used for tagging volumes.
+type VolumeTagger interface {
+	// TagVolume tags the volume with the given provider id with the
+	// specified tags.
+	//
+	// The specified tags will replace any existing ones with the
+	// same names, but other existing tags will be left alone.
+	TagVolume(ctx context.ProviderCallContext, volumeId string, tags map[string]string) error
+}
+
+// InstanceTagRemover is an interface that can be used for removing
+// tags from instances.
+type InstanceTagRemover interface {
+	// RemoveInstanceTags removes the tags with the specified names
+	// from the instance with the given id. Names of tags that the
+	// instance doesn't have are ignored.
+	RemoveInstanceTags(ctx context.ProviderCallContext, id instance.Id, names []string) error
+}
+
+// VolumeTagRemover is an interface that can be used for removing tags
+// from volumes.
+type VolumeTagRemover interface {
+	// RemoveVolumeTags removes the tags with the specified names from
+	// the volume with the given provider id. Names of tags that the
+	// volume doesn't have are ignored.
+	RemoveVolumeTags(ctx context.ProviderCallContext, volumeId string, names []string) error
+}
+
 // InstanceTypesFetcher is an interface that allows for instance information from
 // a provider to be obtained.
 type InstanceTypesFetcher interface {
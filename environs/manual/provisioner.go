@@ -9,6 +9,7 @@ import (
 	"io"
 
 	"github.com/juju/loggo"
+	"github.com/juju/utils/ssh"
 	"github.com/juju/utils/winrm"
 
 	"github.com/juju/juju/apiserver/params"
@@ -54,6 +55,16 @@ type ProvisionMachineArgs struct {
 	// WinRM contains keys and client interface api with the remote windows machine
 	WinRM WinRMArgs
 
+	// SSHOptions, if non-nil, contains options for the SSH connections
+	// made to the host, such as its port and the identity files to use.
+	SSHOptions *ssh.Options
+
+	// NonInteractive, if true, prevents prompting for passwords when
+	// connecting to the host, so that many hosts may be provisioned
+	// at once. Key based authentication and passwordless sudo must
+	// then be set up for the given user.
+	NonInteractive bool
+
 	*params.UpdateBehavior
 }
 
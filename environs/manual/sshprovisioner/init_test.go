@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/ssh"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/environs/manual/sshprovisioner"
@@ -34,6 +35,25 @@ func (s *initialisationSuite) TestDetectSeries(c *gc.C) {
 	c.Assert(series, gc.Equals, "edgy")
 }
 
+func (s *initialisationSuite) TestDetectSeriesSSHOptions(c *gc.C) {
+	// The fake ssh reports the arguments it was run with as the series.
+	response := strings.Join([]string{
+		"$*",
+		"armv4",
+		"MemTotal: 4096 kB",
+		"processor: 0",
+	}, "\n")
+	defer installFakeSSH(c, sshprovisioner.DetectionScript, response, 0)()
+	var options ssh.Options
+	options.SetPort(2222)
+	options.SetIdentities("/path/to/key")
+	_, args, err := sshprovisioner.DetectSeriesAndHardwareCharacteristicsWithOptions("whatever", &options)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(args, jc.Contains, "-p 2222")
+	c.Assert(args, jc.Contains, "-i /path/to/key")
+	c.Assert(args, jc.Contains, "ubuntu@whatever")
+}
+
 func (s *initialisationSuite) TestDetectionError(c *gc.C) {
 	scriptResponse := strings.Join([]string{
 		"edgy",
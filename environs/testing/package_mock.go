@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/juju/juju/environs (interfaces: EnvironProvider,CloudEnvironProvider,ProviderSchema,ProviderCredentials,FinalizeCredentialContext,FinalizeCloudContext,CloudFinalizer,CloudDetector,CloudRegionDetector,ModelConfigUpgrader,ConfigGetter,CloudDestroyer,Environ,InstancePrechecker,Firewaller,InstanceTagger,InstanceTypesFetcher,Upgrader,UpgradeStep,DefaultConstraintsChecker,ProviderCredentialsRegister,RequestFinalizeCredential,NetworkingEnviron)
+// Source: github.com/juju/juju/environs (interfaces: EnvironProvider,CloudEnvironProvider,ProviderSchema,ProviderCredentials,FinalizeCredentialContext,FinalizeCloudContext,CloudFinalizer,CloudDetector,CloudRegionDetector,ModelConfigUpgrader,ConfigGetter,CloudDestroyer,Environ,InstancePrechecker,Firewaller,InstanceTagger,VolumeTagger,InstanceTagRemover,VolumeTagRemover,InstanceTypesFetcher,Upgrader,UpgradeStep,DefaultConstraintsChecker,ProviderCredentialsRegister,RequestFinalizeCredential,NetworkingEnviron)
 
 // Package testing is a generated GoMock package.
 package testing
@@ -1211,6 +1211,117 @@ func (mr *MockInstanceTaggerMockRecorder) TagInstance(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagInstance", reflect.TypeOf((*MockInstanceTagger)(nil).TagInstance), arg0, arg1, arg2)
 }
 
+// MockVolumeTagger is a mock of VolumeTagger interface
+type MockVolumeTagger struct {
+	ctrl     *gomock.Controller
+	recorder *MockVolumeTaggerMockRecorder
+}
+
+// MockVolumeTaggerMockRecorder is the mock recorder for MockVolumeTagger
+type MockVolumeTaggerMockRecorder struct {
+	mock *MockVolumeTagger
+}
+
+// NewMockVolumeTagger creates a new mock instance
+func NewMockVolumeTagger(ctrl *gomock.Controller) *MockVolumeTagger {
+	mock := &MockVolumeTagger{ctrl: ctrl}
+	mock.recorder = &MockVolumeTaggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockVolumeTagger) EXPECT() *MockVolumeTaggerMockRecorder {
+	return m.recorder
+}
+
+// TagVolume mocks base method
+func (m *MockVolumeTagger) TagVolume(arg0 context.ProviderCallContext, arg1 string, arg2 map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagVolume", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TagVolume indicates an expected call of TagVolume
+func (mr *MockVolumeTaggerMockRecorder) TagVolume(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagVolume", reflect.TypeOf((*MockVolumeTagger)(nil).TagVolume), arg0, arg1, arg2)
+}
+
+// MockInstanceTagRemover is a mock of InstanceTagRemover interface
+type MockInstanceTagRemover struct {
+	ctrl     *gomock.Controller
+	recorder *MockInstanceTagRemoverMockRecorder
+}
+
+// MockInstanceTagRemoverMockRecorder is the mock recorder for MockInstanceTagRemover
+type MockInstanceTagRemoverMockRecorder struct {
+	mock *MockInstanceTagRemover
+}
+
+// NewMockInstanceTagRemover creates a new mock instance
+func NewMockInstanceTagRemover(ctrl *gomock.Controller) *MockInstanceTagRemover {
+	mock := &MockInstanceTagRemover{ctrl: ctrl}
+	mock.recorder = &MockInstanceTagRemoverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockInstanceTagRemover) EXPECT() *MockInstanceTagRemoverMockRecorder {
+	return m.recorder
+}
+
+// RemoveInstanceTags mocks base method
+func (m *MockInstanceTagRemover) RemoveInstanceTags(arg0 context.ProviderCallContext, arg1 instance.Id, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveInstanceTags", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveInstanceTags indicates an expected call of RemoveInstanceTags
+func (mr *MockInstanceTagRemoverMockRecorder) RemoveInstanceTags(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveInstanceTags", reflect.TypeOf((*MockInstanceTagRemover)(nil).RemoveInstanceTags), arg0, arg1, arg2)
+}
+
+// MockVolumeTagRemover is a mock of VolumeTagRemover interface
+type MockVolumeTagRemover struct {
+	ctrl     *gomock.Controller
+	recorder *MockVolumeTagRemoverMockRecorder
+}
+
+// MockVolumeTagRemoverMockRecorder is the mock recorder for MockVolumeTagRemover
+type MockVolumeTagRemoverMockRecorder struct {
+	mock *MockVolumeTagRemover
+}
+
+// NewMockVolumeTagRemover creates a new mock instance
+func NewMockVolumeTagRemover(ctrl *gomock.Controller) *MockVolumeTagRemover {
+	mock := &MockVolumeTagRemover{ctrl: ctrl}
+	mock.recorder = &MockVolumeTagRemoverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockVolumeTagRemover) EXPECT() *MockVolumeTagRemoverMockRecorder {
+	return m.recorder
+}
+
+// RemoveVolumeTags mocks base method
+func (m *MockVolumeTagRemover) RemoveVolumeTags(arg0 context.ProviderCallContext, arg1 string, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveVolumeTags", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveVolumeTags indicates an expected call of RemoveVolumeTags
+func (mr *MockVolumeTagRemoverMockRecorder) RemoveVolumeTags(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveVolumeTags", reflect.TypeOf((*MockVolumeTagRemover)(nil).RemoveVolumeTags), arg0, arg1, arg2)
+}
+
 // MockInstanceTypesFetcher is a mock of InstanceTypesFetcher interface
 type MockInstanceTypesFetcher struct {
 	ctrl     *gomock.Controller
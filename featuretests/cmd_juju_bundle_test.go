@@ -3,13 +3,20 @@
 package featuretests
 
 import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/charm/v7"
 	"github.com/juju/cmd/cmdtesting"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/cmd/juju/model"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/testcharms"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -95,3 +102,93 @@ relations:
   - logging:logging-directory
 `[1:])
 }
+
+const roundTripBundle = `
+series: bionic
+applications:
+  mysql:
+    charm: ./mysql
+    num_units: 1
+  wordpress:
+    charm: ./wordpress
+    num_units: 1
+    expose: true
+    options:
+      blog-title: round trip
+relations:
+- - wordpress:db
+  - mysql:server
+`
+
+const roundTripOverlay = `
+applications:
+  mysql:
+    offers:
+      db:
+        endpoints:
+        - server
+`
+
+// addModel adds a model to the controller, making it the current model.
+func (s *cmdExportBundleSuite) addModel(c *gc.C, name string) {
+	// The JujuConnSuite doesn't set up an ssh key in the fake home dir,
+	// so fake one on the command line. The dummy provider also expects
+	// a config value for 'controller'.
+	runCommandExpectSuccess(c, "add-model", name,
+		"--config", "authorized-keys=fake-key",
+		"--config", "controller=false",
+	)
+}
+
+// exportFullBundle runs export-bundle --full on the given model, and
+// returns the paths of the exported bundle and overlay.
+func (s *cmdExportBundleSuite) exportFullBundle(c *gc.C, dir, modelName string) (string, string) {
+	bundlePath := filepath.Join(dir, modelName+".yaml")
+	runCommandExpectSuccess(c, "export-bundle", "-m", modelName, "--full", "--filename", bundlePath)
+	return bundlePath, filepath.Join(dir, modelName+"-overlay.yaml")
+}
+
+func (s *cmdExportBundleSuite) readFile(c *gc.C, path string) string {
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	return string(data)
+}
+
+func (s *cmdExportBundleSuite) TestExportFullBundleRoundTrip(c *gc.C) {
+	dir := c.MkDir()
+	for _, name := range []string{"mysql", "wordpress"} {
+		testcharms.RepoWithSeries("bionic").ClonedDir(dir, name)
+	}
+	bundlePath := filepath.Join(dir, "bundle.yaml")
+	err := ioutil.WriteFile(bundlePath, []byte(roundTripBundle), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	overlayPath := filepath.Join(dir, "overlay.yaml")
+	err = ioutil.WriteFile(overlayPath, []byte(roundTripOverlay), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.addModel(c, "first")
+	runCommandExpectSuccess(c, "deploy", "-m", "first", bundlePath, "--overlay", overlayPath)
+	exportedBundle, exportedOverlay := s.exportFullBundle(c, dir, "first")
+
+	// Local charms can only be deployed from disk, so point the exported
+	// bundle at the charm directories; everything else is deployed as
+	// exported.
+	data, err := charm.ReadBundleData(strings.NewReader(s.readFile(c, exportedBundle)))
+	c.Assert(err, jc.ErrorIsNil)
+	for name, app := range data.Applications {
+		c.Assert(app.Charm, gc.Matches, "local:bionic/"+name+"-[0-9]+")
+		app.Charm = "./" + name
+	}
+	redeploy, err := yaml.Marshal(data)
+	c.Assert(err, jc.ErrorIsNil)
+	redeployPath := filepath.Join(dir, "redeploy.yaml")
+	err = ioutil.WriteFile(redeployPath, redeploy, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.addModel(c, "second")
+	runCommandExpectSuccess(c, "deploy", "-m", "second", redeployPath, "--overlay", exportedOverlay)
+	reexportedBundle, reexportedOverlay := s.exportFullBundle(c, dir, "second")
+
+	c.Check(s.readFile(c, reexportedBundle), gc.Equals, s.readFile(c, exportedBundle))
+	c.Check(s.readFile(c, reexportedOverlay), gc.Equals, s.readFile(c, exportedOverlay))
+}
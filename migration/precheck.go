@@ -12,6 +12,7 @@ import (
 	"github.com/juju/version"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/core/constraints"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
@@ -33,6 +34,7 @@ type PrecheckBackend interface {
 	AllApplications() ([]PrecheckApplication, error)
 	AllRelations() ([]PrecheckRelation, error)
 	ControllerBackend() (PrecheckBackend, error)
+	ModelConstraints() (constraints.Value, error)
 	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
 	ListPendingResources(string) ([]resource.Resource, error)
 }
@@ -64,6 +66,7 @@ type PrecheckMachine interface {
 	Status() (status.StatusInfo, error)
 	InstanceStatus() (status.StatusInfo, error)
 	ShouldRebootOrShutdown() (state.RebootAction, error)
+	Constraints() (constraints.Value, error)
 }
 
 // PrecheckApplication describes the state interface for an
@@ -74,6 +77,7 @@ type PrecheckApplication interface {
 	CharmURL() (*charm.URL, bool)
 	AllUnits() ([]PrecheckUnit, error)
 	MinUnits() int
+	Constraints() (constraints.Value, error)
 }
 
 // PrecheckUnit describes state interface for a unit needed by
@@ -179,7 +183,11 @@ func (ctx *precheckContext) checkModel() error {
 			return errors.New("model has revoked credentials")
 		}
 	}
-	return nil
+	cons, err := ctx.backend.ModelConstraints()
+	if err != nil {
+		return errors.Annotate(err, "retrieving model constraints")
+	}
+	return errors.Trace(checkConstraints(cons, "model"))
 }
 
 // TargetPrecheck checks the state of the target controller to make
@@ -322,6 +330,12 @@ func (ctx *precheckContext) checkMachines() error {
 		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
 			return errors.Trace(err)
 		}
+
+		if cons, err := machine.Constraints(); err != nil {
+			return errors.Annotatef(err, "retrieving machine %s constraints", machine.Id())
+		} else if err := checkConstraints(cons, "machine "+machine.Id()); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	return nil
 }
@@ -345,6 +359,11 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 		if app.Life() != state.Alive {
 			return nil, errors.Errorf("application %s is %s", app.Name(), app.Life())
 		}
+		if cons, err := app.Constraints(); err != nil {
+			return nil, errors.Annotatef(err, "retrieving constraints for %s", app.Name())
+		} else if err := checkConstraints(cons, "application "+app.Name()); err != nil {
+			return nil, errors.Trace(err)
+		}
 		units, err := app.AllUnits()
 		if err != nil {
 			return nil, errors.Annotatef(err, "retrieving units for %s", app.Name())
@@ -417,6 +436,16 @@ func checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentL
 	return nil
 }
 
+// checkConstraints returns an error if the constraints can't be held by
+// the model description, so that the target controller doesn't silently
+// provision machines differently.
+func checkConstraints(cons constraints.Value, label string) error {
+	if cons.HasSpotMaxPrice() {
+		return errors.Errorf("%s uses the %s constraint, which cannot be migrated", label, constraints.SpotMaxPrice)
+	}
+	return nil
+}
+
 type agentToolsGetter interface {
 	AgentTools() (*tools.Tools, error)
 }
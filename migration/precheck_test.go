@@ -11,6 +11,7 @@ import (
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/constraints"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
@@ -54,6 +55,27 @@ func (*SourcePrecheckSuite) TestDyingModel(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "model is dying")
 }
 
+func (*SourcePrecheckSuite) TestModelSpotMaxPrice(c *gc.C) {
+	backend := newHappyBackend()
+	backend.modelConstraints = constraints.MustParse("spot-max-price=0.05")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model uses the spot-max-price constraint, which cannot be migrated")
+}
+
+func (*SourcePrecheckSuite) TestMachineSpotMaxPrice(c *gc.C) {
+	backend := newHappyBackend()
+	backend.machines[1].(*fakeMachine).constraints = constraints.MustParse("spot-max-price=0.05")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "machine 1 uses the spot-max-price constraint, which cannot be migrated")
+}
+
+func (*SourcePrecheckSuite) TestApplicationSpotMaxPrice(c *gc.C) {
+	backend := newHappyBackend()
+	backend.apps[0].(*fakeApp).constraints = constraints.MustParse("mem=4G spot-max-price=0.05")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "application foo uses the spot-max-price constraint, which cannot be migrated")
+}
+
 func (*SourcePrecheckSuite) TestCharmUpgrades(c *gc.C) {
 	backend := &fakeBackend{
 		apps: []migration.PrecheckApplication{
@@ -749,6 +771,8 @@ type fakeBackend struct {
 	pendingResources    []resource.Resource
 	pendingResourcesErr error
 
+	modelConstraints constraints.Value
+
 	controllerBackend *fakeBackend
 }
 
@@ -796,6 +820,10 @@ func (b *fakeBackend) ListPendingResources(app string) ([]resource.Resource, err
 	return b.pendingResources, b.pendingResourcesErr
 }
 
+func (b *fakeBackend) ModelConstraints() (constraints.Value, error) {
+	return b.modelConstraints, nil
+}
+
 func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackend, error) {
 	if b.controllerBackend == nil {
 		return b, nil
@@ -872,6 +900,7 @@ type fakeMachine struct {
 	status         status.Status
 	instanceStatus status.Status
 	rebootAction   state.RebootAction
+	constraints    constraints.Value
 }
 
 func (m *fakeMachine) Id() string {
@@ -919,12 +948,17 @@ func (m *fakeMachine) ShouldRebootOrShutdown() (state.RebootAction, error) {
 	return m.rebootAction, nil
 }
 
+func (m *fakeMachine) Constraints() (constraints.Value, error) {
+	return m.constraints, nil
+}
+
 type fakeApp struct {
-	name     string
-	life     state.Life
-	charmURL string
-	units    []migration.PrecheckUnit
-	minunits int
+	name        string
+	life        state.Life
+	charmURL    string
+	units       []migration.PrecheckUnit
+	minunits    int
+	constraints constraints.Value
 }
 
 func (a *fakeApp) Name() string {
@@ -951,6 +985,10 @@ func (a *fakeApp) MinUnits() int {
 	return a.minunits
 }
 
+func (a *fakeApp) Constraints() (constraints.Value, error) {
+	return a.constraints, nil
+}
+
 type fakeUnit struct {
 	name        string
 	version     version.Binary
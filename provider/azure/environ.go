@@ -125,7 +125,14 @@ type azureEnviron struct {
 	commonResourcesCreated bool
 }
 
-var _ environs.Environ = (*azureEnviron)(nil)
+var (
+	_ environs.Environ        = (*azureEnviron)(nil)
+	_ environs.InstanceTagger = (*azureEnviron)(nil)
+	_ environs.VolumeTagger   = (*azureEnviron)(nil)
+
+	_ environs.InstanceTagRemover = (*azureEnviron)(nil)
+	_ environs.VolumeTagRemover   = (*azureEnviron)(nil)
+)
 
 // newEnviron creates a new azureEnviron.
 func newEnviron(
@@ -402,6 +409,7 @@ func (env *azureEnviron) ConstraintsValidator(ctx context.ProviderCallContext) (
 		constraints.CpuPower,
 		constraints.Tags,
 		constraints.VirtType,
+		constraints.SpotMaxPrice,
 	})
 	validator.RegisterVocabulary(
 		constraints.Arch,
@@ -1551,6 +1559,56 @@ func (env *azureEnviron) updateResourceControllerTag(
 	return errorutils.HandleCredentialError(errors.Annotatef(err, "updating controller for %q", to.String(resource.Name)), ctx)
 }
 
+// TagInstance is part of the environs.InstanceTagger interface.
+func (env *azureEnviron) TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error {
+	return env.updateInstanceTags(ctx, id, tags, nil)
+}
+
+// RemoveInstanceTags is part of the environs.InstanceTagRemover interface.
+func (env *azureEnviron) RemoveInstanceTags(ctx context.ProviderCallContext, id instance.Id, names []string) error {
+	return env.updateInstanceTags(ctx, id, nil, names)
+}
+
+// updateInstanceTags sets and removes tags on the instance's virtual
+// machine.
+func (env *azureEnviron) updateInstanceTags(ctx context.ProviderCallContext, id instance.Id, tags map[string]string, remove []string) error {
+	vmClient := compute.VirtualMachinesClient{env.compute}
+	sdkCtx := stdcontext.Background()
+	vm, err := vmClient.Get(sdkCtx, env.resourceGroup, string(id), "")
+	if err != nil {
+		if isNotFoundResult(vm.Response) {
+			return errors.NotFoundf("instance %q", id)
+		}
+		return errorutils.HandleCredentialError(errors.Annotatef(err, "getting instance %q", id), ctx)
+	}
+	future, err := vmClient.Update(sdkCtx, env.resourceGroup, string(id), compute.VirtualMachineUpdate{
+		Tags: mergeResourceTags(vm.Tags, tags, remove),
+	})
+	if err != nil {
+		return errorutils.HandleCredentialError(errors.Annotatef(err, "tagging instance %q", id), ctx)
+	}
+	err = future.WaitForCompletionRef(sdkCtx, vmClient.Client)
+	return errorutils.HandleCredentialError(errors.Annotatef(err, "tagging instance %q", id), ctx)
+}
+
+// mergeResourceTags returns the existing Azure resource tags with the
+// specified tags added, replacing any with the same names, and the
+// tags with the names in remove deleted. Azure replaces all of a
+// resource's tags with those given when it is updated.
+func mergeResourceTags(existing map[string]*string, tags map[string]string, remove []string) map[string]*string {
+	merged := make(map[string]*string)
+	for k, v := range existing {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = to.StringPtr(v)
+	}
+	for _, k := range remove {
+		delete(merged, k)
+	}
+	return merged
+}
+
 // AllInstances is specified in the InstanceBroker interface.
 func (env *azureEnviron) AllInstances(ctx context.ProviderCallContext) ([]instances.Instance, error) {
 	return env.allInstances(ctx, env.resourceGroup, true /* refresh addresses */, false /* all instances */)
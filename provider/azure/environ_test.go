@@ -1715,10 +1715,10 @@ func (s *environSuite) TestStopInstancesStorageAccountKeysError(c *gc.C) {
 func (s *environSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	validator := s.constraintsValidator(c)
 	unsupported, err := validator.Validate(constraints.MustParse(
-		"arch=amd64 tags=foo cpu-power=100 virt-type=kvm",
+		"arch=amd64 tags=foo cpu-power=100 virt-type=kvm spot-max-price=0.05",
 	))
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(unsupported, jc.SameContents, []string{"tags", "cpu-power", "virt-type"})
+	c.Assert(unsupported, jc.SameContents, []string{"tags", "cpu-power", "virt-type", "spot-max-price"})
 }
 
 func (s *environSuite) TestConstraintsValidatorVocabulary(c *gc.C) {
@@ -2230,3 +2230,138 @@ func (s *environSuite) TestAdoptResourcesErrorUpdating(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `failed to update controller for some resources: \[boxing-day-blues\]`)
 	c.Check(s.requests, gc.HasLen, 9)
 }
+
+func (s *environSuite) checkRequestTags(c *gc.C, ix int, expected map[string]string) {
+	req := s.requests[ix]
+	c.Check(req.Method, gc.Equals, "PATCH")
+	data := make([]byte, req.ContentLength)
+	_, err := req.Body.Read(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var resource struct {
+		Tags map[string]*string `json:"tags"`
+	}
+	err = json.Unmarshal(data, &resource)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(to.StringMap(resource.Tags), jc.DeepEquals, expected)
+}
+
+func (s *environSuite) TestTagInstance(c *gc.C) {
+	env := s.openEnviron(c)
+	vm := compute.VirtualMachine{
+		Name: to.StringPtr("machine-0"),
+		Tags: map[string]*string{
+			tags.JujuModel:   to.StringPtr("deadbeef-0bad-400d-8000-4b1d0d06f00d"),
+			"cost-centre":    to.StringPtr("accounts"),
+			"something else": to.StringPtr("good"),
+		},
+	}
+	s.sender = azuretesting.Senders{
+		makeSender(".*/virtualMachines/machine-0", vm), // GET
+		makeSender(".*/virtualMachines/machine-0", vm), // PATCH
+	}
+	err := env.(environs.InstanceTagger).TagInstance(s.callCtx, "machine-0", map[string]string{
+		"cost-centre": "research",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Check(s.requests[0].Method, gc.Equals, "GET")
+	s.checkRequestTags(c, 1, map[string]string{
+		tags.JujuModel:   "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"cost-centre":    "research",
+		"something else": "good",
+	})
+}
+
+func (s *environSuite) TestTagInstanceNotFound(c *gc.C) {
+	env := s.openEnviron(c)
+	sender := mocks.NewSender()
+	sender.AppendResponse(mocks.NewResponseWithStatus(
+		"vm not found", http.StatusNotFound,
+	))
+	s.sender = azuretesting.Senders{sender}
+	err := env.(environs.InstanceTagger).TagInstance(s.callCtx, "machine-0", map[string]string{
+		"cost-centre": "research",
+	})
+	c.Assert(err, gc.ErrorMatches, `instance "machine-0" not found`)
+}
+
+func (s *environSuite) TestTagVolume(c *gc.C) {
+	env := s.openEnviron(c)
+	disk := compute.Disk{
+		Name: to.StringPtr("volume-0"),
+		Tags: map[string]*string{
+			tags.JujuModel: to.StringPtr("deadbeef-0bad-400d-8000-4b1d0d06f00d"),
+		},
+	}
+	s.sender = azuretesting.Senders{
+		makeResourceGroupNotFoundSender(".*/storageAccounts/" + storageAccountName),
+		makeSender(".*/disks/volume-0", disk), // GET
+		makeSender(".*/disks/volume-0", disk), // PATCH
+	}
+	err := env.(environs.VolumeTagger).TagVolume(s.callCtx, "volume-0", map[string]string{
+		"cost-centre": "research",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 3)
+	c.Check(s.requests[1].Method, gc.Equals, "GET")
+	s.checkRequestTags(c, 2, map[string]string{
+		tags.JujuModel: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"cost-centre":  "research",
+	})
+}
+
+func (s *environSuite) TestTagVolumeUnmanaged(c *gc.C) {
+	env := s.openEnviron(c)
+	s.sender = azuretesting.Senders{
+		s.storageAccountSender(),
+		s.storageAccountKeysSender(),
+	}
+	err := env.(environs.VolumeTagger).TagVolume(s.callCtx, "volume-0", map[string]string{
+		"cost-centre": "research",
+	})
+	c.Assert(err, gc.ErrorMatches, `tagging unmanaged disk volumes not supported`)
+}
+
+func (s *environSuite) TestRemoveInstanceTags(c *gc.C) {
+	env := s.openEnviron(c)
+	vm := compute.VirtualMachine{
+		Name: to.StringPtr("machine-0"),
+		Tags: map[string]*string{
+			tags.JujuModel: to.StringPtr("deadbeef-0bad-400d-8000-4b1d0d06f00d"),
+			"cost-centre":  to.StringPtr("accounts"),
+		},
+	}
+	s.sender = azuretesting.Senders{
+		makeSender(".*/virtualMachines/machine-0", vm), // GET
+		makeSender(".*/virtualMachines/machine-0", vm), // PATCH
+	}
+	err := env.(environs.InstanceTagRemover).RemoveInstanceTags(s.callCtx, "machine-0", []string{"cost-centre"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 2)
+	s.checkRequestTags(c, 1, map[string]string{
+		tags.JujuModel: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+	})
+}
+
+func (s *environSuite) TestRemoveVolumeTags(c *gc.C) {
+	env := s.openEnviron(c)
+	disk := compute.Disk{
+		Name: to.StringPtr("volume-0"),
+		Tags: map[string]*string{
+			tags.JujuModel: to.StringPtr("deadbeef-0bad-400d-8000-4b1d0d06f00d"),
+			"cost-centre":  to.StringPtr("accounts"),
+		},
+	}
+	s.sender = azuretesting.Senders{
+		makeResourceGroupNotFoundSender(".*/storageAccounts/" + storageAccountName),
+		makeSender(".*/disks/volume-0", disk), // GET
+		makeSender(".*/disks/volume-0", disk), // PATCH
+	}
+	err := env.(environs.VolumeTagRemover).RemoveVolumeTags(s.callCtx, "volume-0", []string{"cost-centre"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 3)
+	s.checkRequestTags(c, 2, map[string]string{
+		tags.JujuModel: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+	})
+}
@@ -399,6 +399,45 @@ func (v *azureVolumeSource) listBlobs(ctx context.ProviderCallContext) ([]intern
 	return blobs, nil
 }
 
+// TagVolume is part of the environs.VolumeTagger interface.
+func (env *azureEnviron) TagVolume(ctx context.ProviderCallContext, volumeId string, tags map[string]string) error {
+	return env.updateVolumeTags(ctx, volumeId, tags, nil)
+}
+
+// RemoveVolumeTags is part of the environs.VolumeTagRemover interface.
+func (env *azureEnviron) RemoveVolumeTags(ctx context.ProviderCallContext, volumeId string, names []string) error {
+	return env.updateVolumeTags(ctx, volumeId, nil, names)
+}
+
+// updateVolumeTags sets and removes tags on the volume's managed disk.
+func (env *azureEnviron) updateVolumeTags(ctx context.ProviderCallContext, volumeId string, tags map[string]string, remove []string) error {
+	maybeStorageClient, _, err := env.maybeGetStorageClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if maybeStorageClient != nil {
+		// Unmanaged disks are blobs, which cannot be tagged.
+		return errors.NotSupportedf("tagging unmanaged disk volumes")
+	}
+	diskClient := compute.DisksClient{env.disk}
+	sdkCtx := stdcontext.Background()
+	disk, err := diskClient.Get(sdkCtx, env.resourceGroup, volumeId)
+	if err != nil {
+		if isNotFoundResult(disk.Response) {
+			return errors.NotFoundf("disk %s", volumeId)
+		}
+		return errorutils.HandleCredentialError(errors.Annotatef(err, "getting disk %q", volumeId), ctx)
+	}
+	future, err := diskClient.Update(sdkCtx, env.resourceGroup, volumeId, compute.DiskUpdate{
+		Tags: mergeResourceTags(disk.Tags, tags, remove),
+	})
+	if err != nil {
+		return errorutils.HandleCredentialError(errors.Annotatef(err, "tagging disk %q", volumeId), ctx)
+	}
+	err = future.WaitForCompletionRef(sdkCtx, diskClient.Client)
+	return errorutils.HandleCredentialError(errors.Annotatef(err, "tagging disk %q", volumeId), ctx)
+}
+
 // DescribeVolumes is specified on the storage.VolumeSource interface.
 func (v *azureVolumeSource) DescribeVolumes(ctx context.ProviderCallContext, volumeIds []string) ([]storage.DescribeVolumesResult, error) {
 	if v.maybeStorageClient == nil {
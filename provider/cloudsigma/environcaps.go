@@ -13,6 +13,7 @@ var unsupportedConstraints = []string{
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.SpotMaxPrice,
 }
 
 // ConstraintsValidator returns a Validator instance which
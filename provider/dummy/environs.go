@@ -294,6 +294,10 @@ type environ struct {
 
 var _ environs.Environ = (*environ)(nil)
 var _ environs.Networking = (*environ)(nil)
+var _ environs.InstanceTagger = (*environ)(nil)
+var _ environs.VolumeTagger = (*environ)(nil)
+var _ environs.InstanceTagRemover = (*environ)(nil)
+var _ environs.VolumeTagRemover = (*environ)(nil)
 
 // discardOperations discards all Operations written to it.
 var discardOperations = make(chan Operation)
@@ -1097,6 +1101,30 @@ func (e *environ) AdoptResources(ctx context.ProviderCallContext, controllerUUID
 	return nil
 }
 
+// TagInstance implements environs.InstanceTagger.
+func (e *environ) TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error {
+	// This provider doesn't track instance tags.
+	return nil
+}
+
+// TagVolume implements environs.VolumeTagger.
+func (e *environ) TagVolume(ctx context.ProviderCallContext, volumeId string, tags map[string]string) error {
+	// This provider doesn't track volume tags.
+	return nil
+}
+
+// RemoveInstanceTags implements environs.InstanceTagRemover.
+func (e *environ) RemoveInstanceTags(ctx context.ProviderCallContext, id instance.Id, names []string) error {
+	// This provider doesn't track instance tags.
+	return nil
+}
+
+// RemoveVolumeTags implements environs.VolumeTagRemover.
+func (e *environ) RemoveVolumeTags(ctx context.ProviderCallContext, volumeId string, names []string) error {
+	// This provider doesn't track volume tags.
+	return nil
+}
+
 func (e *environ) Destroy(ctx context.ProviderCallContext) (res error) {
 	defer delay()
 	estate, err := e.state()
@@ -1146,7 +1174,7 @@ func (e *environ) DestroyController(ctx context.ProviderCallContext, controllerU
 // ConstraintsValidator is defined on the Environs interface.
 func (e *environ) ConstraintsValidator(ctx context.ProviderCallContext) (constraints.Validator, error) {
 	validator := constraints.NewValidator()
-	validator.RegisterUnsupported([]string{constraints.CpuPower, constraints.VirtType})
+	validator.RegisterUnsupported([]string{constraints.CpuPower, constraints.VirtType, constraints.SpotMaxPrice})
 	validator.RegisterConflicts([]string{constraints.InstanceType}, []string{constraints.Mem})
 	validator.RegisterVocabulary(constraints.Arch, []string{arch.AMD64, arch.ARM64, arch.I386, arch.PPC64EL})
 	return validator, nil
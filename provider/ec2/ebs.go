@@ -428,14 +428,7 @@ func listVolumes(client *ec2.EC2, ctx context.ProviderCallContext, filter *ec2.F
 	}
 	volumeIds := make([]string, 0, len(resp.Volumes))
 	for _, vol := range resp.Volumes {
-		var isRootDisk bool
-		for _, att := range vol.Attachments {
-			if att.Device == rootDiskDeviceName {
-				isRootDisk = true
-				break
-			}
-		}
-		if isRootDisk && !includeRootDisks {
+		if isRootDisk(vol) && !includeRootDisks {
 			// We don't want to list root disks in the output.
 			// These are managed by the instance provisioning
 			// code; they will be created and destroyed with
@@ -447,6 +440,17 @@ func listVolumes(client *ec2.EC2, ctx context.ProviderCallContext, filter *ec2.F
 	return volumeIds, nil
 }
 
+// isRootDisk reports whether the volume is attached as an instance's
+// root disk.
+func isRootDisk(vol ec2.Volume) bool {
+	for _, att := range vol.Attachments {
+		if att.Device == rootDiskDeviceName {
+			return true
+		}
+	}
+	return false
+}
+
 // DescribeVolumes is specified on the storage.VolumeSource interface.
 func (v *ebsVolumeSource) DescribeVolumes(ctx context.ProviderCallContext, volIds []string) ([]storage.DescribeVolumesResult, error) {
 	// TODO(axw) invalid volIds here should not cause the whole
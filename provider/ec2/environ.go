@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -65,6 +66,7 @@ type environ struct {
 	name  string
 	cloud environscloudspec.CloudSpec
 	ec2   *ec2.EC2
+	clock clock.Clock
 
 	// ecfgMutex protects the *Unlocked fields below.
 	ecfgMutex    sync.Mutex
@@ -81,10 +83,21 @@ type environ struct {
 	defaultVPC        *ec2.VPC
 
 	ensureGroupMutex sync.Mutex
+
+	// spotMutex protects the spot* fields below, which hold the
+	// session used to check spot instances for interruptions, and
+	// the results of the last checks.
+	spotMutex         sync.Mutex
+	spotSession       ec2iface.EC2API
+	spotChecked       time.Time
+	spotInterruptions map[string]string
 }
 
 var _ environs.Environ = (*environ)(nil)
 var _ environs.Networking = (*environ)(nil)
+var _ environs.InstanceTagger = (*environ)(nil)
+var _ environs.VolumeTagger = (*environ)(nil)
+var _ environs.ControllerResourceLister = (*environ)(nil)
 
 func (e *environ) Config() *config.Config {
 	return e.ecfg().Config
@@ -474,6 +487,13 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 	if err != nil {
 		return nil, wrapError(err)
 	}
+	spot := args.Constraints.HasSpotMaxPrice()
+	if spot {
+		instanceTypes, err = spotInstanceTypes(instanceTypes, *args.Constraints.SpotMaxPrice)
+		if err != nil {
+			return nil, wrapError(err)
+		}
+	}
 
 	spec, err := findInstanceSpec(
 		args.InstanceConfig.Controller != nil,
@@ -574,7 +594,11 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 	}
 
 	callback(status.Allocating, fmt.Sprintf("Trying to start instance in availability zone %q", availabilityZone), nil)
-	instResp, err = runInstances(e.ec2, ctx, runArgs, callback)
+	if spot {
+		instResp, err = runSpotInstances(ec2Session, e.ec2, runArgs, *args.Constraints.SpotMaxPrice, callback)
+	} else {
+		instResp, err = runInstances(e.ec2, ctx, runArgs, callback)
+	}
 	if err != nil {
 		if !isZoneOrSubnetConstrainedError(err) {
 			err = annotateWrapError(err, "cannot run instances")
@@ -588,6 +612,7 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 	inst = &ec2Instance{
 		e:        e,
 		Instance: &instResp.Instances[0],
+		spot:     spot,
 	}
 	instAZ := inst.Instance.AvailZone
 	if hasVPCID {
@@ -603,6 +628,9 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 		names.NewMachineTag(args.InstanceConfig.MachineId), e.Config().Name(),
 	)
 	args.InstanceConfig.Tags[tagName] = instanceName
+	if spot {
+		args.InstanceConfig.Tags[tagSpotInstance] = "true"
+	}
 	if err := tagResources(e.ec2, ctx, args.InstanceConfig.Tags, string(inst.Id())); err != nil {
 		return nil, annotateWrapError(err, "tagging instance")
 	}
@@ -1020,6 +1048,7 @@ func (e *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id)
 	if err == environs.ErrPartialInstances {
 		for _, inst := range insts {
 			if inst != nil {
+				e.checkSpotInterruptions(insts)
 				return insts, environs.ErrPartialInstances
 			}
 		}
@@ -1028,9 +1057,62 @@ func (e *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id)
 	if err != nil {
 		return nil, err
 	}
+	e.checkSpotInterruptions(insts)
 	return insts, nil
 }
 
+// checkSpotInterruptions records whether any spot instances amongst
+// the given instances have been, or are about to be, interrupted.
+// The spot requests are described at most once per spotCheckInterval,
+// other than for instances that have not been checked before; the
+// results of the last check are used in between.
+func (e *environ) checkSpotInterruptions(insts []instances.Instance) {
+	var spotInsts []*ec2Instance
+	for _, inst := range insts {
+		if inst, ok := inst.(*ec2Instance); ok && inst.spot {
+			spotInsts = append(spotInsts, inst)
+		}
+	}
+	if len(spotInsts) == 0 {
+		return
+	}
+
+	e.spotMutex.Lock()
+	defer e.spotMutex.Unlock()
+	now := e.clock.Now()
+	recheck := now.Sub(e.spotChecked) >= spotCheckInterval
+	var ids []string
+	for _, inst := range spotInsts {
+		if _, checked := e.spotInterruptions[inst.InstanceId]; recheck || !checked {
+			ids = append(ids, inst.InstanceId)
+		}
+	}
+	if len(ids) > 0 {
+		if e.spotSession == nil {
+			e.spotSession = EC2Session(e.cloud.Region, e.ec2.AccessKey, e.ec2.SecretKey)
+		}
+		codes, err := spotInterruptions(e.spotSession, ids)
+		if err != nil {
+			// Not being able to check for interruptions shouldn't
+			// stop the instances from being reported.
+			logger.Warningf("cannot check spot instances for interruptions: %v", err)
+		} else {
+			if recheck || e.spotInterruptions == nil {
+				// Drop the results for instances that are no longer
+				// being asked about.
+				e.spotInterruptions = make(map[string]string)
+				e.spotChecked = now
+			}
+			for _, id := range ids {
+				e.spotInterruptions[id] = codes[id]
+			}
+		}
+	}
+	for _, inst := range spotInsts {
+		inst.spotInterruption = e.spotInterruptions[inst.InstanceId]
+	}
+}
+
 // gatherInstances tries to get information on each instance
 // id whose corresponding insts slot is nil.
 //
@@ -1062,7 +1144,7 @@ func (e *environ) gatherInstances(
 				}
 				inst := r.Instances[k]
 				// TODO(wallyworld): lookup the details to fill in the instance type data
-				insts[i] = &ec2Instance{e: e, Instance: &inst}
+				insts[i] = &ec2Instance{e: e, Instance: &inst, spot: isSpotInstance(&inst)}
 				n++
 			}
 		}
@@ -1447,6 +1529,16 @@ func (e *environ) AdoptResources(ctx context.ProviderCallContext, controllerUUID
 	return errors.Annotate(tagResources(e.ec2, ctx, tags, resourceIds...), "updating tags")
 }
 
+// TagInstance implements environs.InstanceTagger.
+func (e *environ) TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error {
+	return errors.Annotate(tagResources(e.ec2, ctx, tags, string(id)), "tagging instance")
+}
+
+// TagVolume implements environs.VolumeTagger.
+func (e *environ) TagVolume(ctx context.ProviderCallContext, volumeId string, tags map[string]string) error {
+	return errors.Annotate(tagResources(e.ec2, ctx, tags, volumeId), "tagging volume")
+}
+
 // AllInstances is part of the environs.InstanceBroker interface.
 func (e *environ) AllInstances(ctx context.ProviderCallContext) ([]instances.Instance, error) {
 	// We want to return everything we find here except for instances that are
@@ -1619,6 +1711,124 @@ func (e *environ) destroyControllerManagedEnvirons(ctx context.ProviderCallConte
 	return nil
 }
 
+// ControllerResources is part of the environs.ControllerResourceLister
+// interface.
+func (e *environ) ControllerResources(ctx context.ProviderCallContext, controllerUUID string) ([]environs.CloudResource, error) {
+	filter := ec2.NewFilter()
+	filter.Add("instance-state-name", aliveInstanceStates...)
+	e.addControllerFilter(filter, controllerUUID)
+	insts, err := e.allInstances(ctx, filter)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var resources []environs.CloudResource
+	for _, inst := range insts {
+		resources = append(resources, environs.CloudResource{
+			Kind:      environs.CloudResourceInstance,
+			Id:        string(inst.Id()),
+			ModelUUID: ec2TagValue(inst.(*ec2Instance).Tags, tags.JujuModel),
+			MachineId: taggedMachineId(ec2TagValue(inst.(*ec2Instance).Tags, tags.JujuMachine)),
+		})
+	}
+
+	filter = ec2.NewFilter()
+	e.addControllerFilter(filter, controllerUUID)
+	resp, err := e.ec2.Volumes(nil, filter)
+	if err != nil {
+		return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "listing volumes")
+	}
+	for _, vol := range resp.Volumes {
+		if isRootDisk(vol) {
+			continue
+		}
+		resources = append(resources, environs.CloudResource{
+			Kind:      environs.CloudResourceVolume,
+			Id:        vol.Id,
+			ModelUUID: ec2TagValue(vol.Tags, tags.JujuModel),
+			StorageId: ec2TagValue(vol.Tags, tags.JujuStorageInstance),
+		})
+	}
+
+	groups, err := e.controllerSecurityGroups(ctx, controllerUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, g := range groups {
+		// Security groups are named after their model, as
+		// "juju-<model-uuid>" with an optional suffix, which is the
+		// machine id for per-machine groups.
+		var modelUUID, machineId string
+		if name := strings.TrimPrefix(g.Name, "juju-"); len(name) >= 36 && utils.IsValidUUIDString(name[:36]) {
+			modelUUID = name[:36]
+			if suffix := strings.TrimPrefix(name[36:], "-"); names.IsValidMachine(suffix) {
+				machineId = suffix
+			}
+		}
+		resources = append(resources, environs.CloudResource{
+			Kind:      environs.CloudResourceSecurityGroup,
+			Id:        g.Id,
+			ModelUUID: modelUUID,
+			MachineId: machineId,
+		})
+	}
+	return resources, nil
+}
+
+// taggedMachineId returns the id of the machine identified by the
+// value of an instance's tags.JujuMachine tag, which has the form
+// "<model-name>-machine-<id>", or "" if the value is not of that form.
+func taggedMachineId(value string) string {
+	i := strings.LastIndex(value, "-machine-")
+	if i < 0 {
+		return ""
+	}
+	tag, err := names.ParseMachineTag(value[i+1:])
+	if err != nil {
+		return ""
+	}
+	return tag.Id()
+}
+
+// ReleaseControllerResources is part of the
+// environs.ControllerResourceLister interface. Instances are terminated
+// first, so that the volumes and security groups they use can then be
+// deleted.
+func (e *environ) ReleaseControllerResources(ctx context.ProviderCallContext, resources []environs.CloudResource) []error {
+	results := make([]error, len(resources))
+	var instIds []instance.Id
+	for _, r := range resources {
+		if r.Kind == environs.CloudResourceInstance {
+			instIds = append(instIds, instance.Id(r.Id))
+		}
+	}
+	if err := e.terminateInstances(ctx, instIds); err != nil {
+		err = errors.Annotate(err, "terminating instances")
+		for i, r := range resources {
+			if r.Kind == environs.CloudResourceInstance {
+				results[i] = err
+			}
+		}
+	}
+	for i, r := range resources {
+		switch r.Kind {
+		case environs.CloudResourceInstance:
+		case environs.CloudResourceVolume:
+			results[i] = destroyVolume(e.ec2, ctx, r.Id)
+		case environs.CloudResourceSecurityGroup:
+			// Terminating the instances above may already have
+			// removed the group, which is fine.
+			group := ec2.SecurityGroup{Id: r.Id, Name: r.Id}
+			err := deleteSecurityGroupInsistently(e.ec2, ctx, group, clock.WallClock)
+			if err != nil && !isNotFoundError(err) {
+				results[i] = err
+			}
+		default:
+			results[i] = errors.NotSupportedf("releasing %s", r.Kind)
+		}
+	}
+	return results
+}
+
 func (e *environ) allControllerManagedVolumes(ctx context.ProviderCallContext, controllerUUID string, includeRootDisks bool) ([]string, error) {
 	filter := ec2.NewFilter()
 	e.addControllerFilter(filter, controllerUUID)
@@ -1960,6 +2170,17 @@ var deleteSecurityGroupInsistently = func(inst SecurityGroupCleaner, ctx context
 	return nil
 }
 
+// ec2TagValue returns the value of the tag with the given key, or the
+// empty string if there is no such tag.
+func ec2TagValue(tags []ec2.Tag, key string) string {
+	for _, tag := range tags {
+		if tag.Key == key {
+			return tag.Value
+		}
+	}
+	return ""
+}
+
 func (e *environ) addModelFilter(f *ec2.Filter) {
 	f.Add(fmt.Sprintf("tag:%s", tags.JujuModel), e.uuid())
 }
@@ -2349,5 +2570,11 @@ func (e *environ) SetCloudSpec(spec environscloudspec.CloudSpec) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+
+	// The credentials may have changed, so the next spot
+	// instance check needs a new session.
+	e.spotMutex.Lock()
+	e.spotSession = nil
+	e.spotMutex.Unlock()
 	return nil
 }
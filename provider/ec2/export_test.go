@@ -4,6 +4,7 @@
 package ec2
 
 import (
+	"github.com/juju/clock"
 	"gopkg.in/amz.v3/ec2"
 
 	"github.com/juju/juju/core/instance"
@@ -29,6 +30,10 @@ func EnvironEC2(e environs.Environ) *ec2.EC2 {
 	return e.(*environ).ec2
 }
 
+func SetEnvironClock(e environs.Environ, clock clock.Clock) {
+	e.(*environ).clock = clock
+}
+
 func InstanceEC2(inst instances.Instance) *ec2.Instance {
 	return inst.(*ec2Instance).Instance
 }
@@ -61,6 +66,7 @@ var (
 	DeleteSecurityGroupInsistently = &deleteSecurityGroupInsistently
 	TerminateInstancesById         = &terminateInstancesById
 	MaybeConvertCredentialError    = maybeConvertCredentialError
+	RegionLocation                 = &regionLocation
 )
 
 const VPCIDNone = vpcIDNone
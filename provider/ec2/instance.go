@@ -21,13 +21,20 @@ type ec2Instance struct {
 	e *environ
 
 	*ec2.Instance
+
+	// spot is true if the instance is a spot instance.
+	spot bool
+
+	// spotInterruption holds the status code of the instance's spot
+	// request, if the instance has been or is about to be interrupted.
+	spotInterruption string
 }
 
 func (inst *ec2Instance) String() string {
 	return string(inst.Id())
 }
 
-var _ instances.Instance = (*ec2Instance)(nil)
+var _ instances.InterruptibleInstance = (*ec2Instance)(nil)
 
 func (inst *ec2Instance) Id() instance.Id {
 	return instance.Id(inst.InstanceId)
@@ -46,11 +53,21 @@ func (inst *ec2Instance) Status(ctx context.ProviderCallContext) instance.Status
 	default:
 		jujuStatus = status.Empty
 	}
+	message := inst.State.Name
+	if inst.spotInterruption != "" {
+		message = fmt.Sprintf("%s (spot interruption: %s)", message, inst.spotInterruption)
+	}
 	return instance.Status{
 		Status:  jujuStatus,
-		Message: inst.State.Name,
+		Message: message,
 	}
+}
 
+// Interruptible is part of the instances.InterruptibleInstance interface.
+// Spot instances may be interrupted when EC2 needs the capacity back, or
+// when the spot price rises above the maximum price.
+func (inst *ec2Instance) Interruptible() bool {
+	return inst.spot
 }
 
 // Addresses implements network.Addresses() returning generic address
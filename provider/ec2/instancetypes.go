@@ -11,9 +11,12 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/pricing"
+	"github.com/aws/aws-sdk-go/service/pricing/pricingiface"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/utils/arch"
@@ -39,13 +42,60 @@ var EC2Session = func(region, accessKey, secretKey string) ec2iface.EC2API {
 	return ec2Session
 }
 
-// InstanceTypes implements InstanceTypesFetcher
+// PricingSession returns a session for the AWS Price List Service with
+// the given credentials.
+var PricingSession = func(accessKey, secretKey string) pricingiface.PricingAPI {
+	sess := session.Must(session.NewSession())
+	return pricing.New(sess, &aws.Config{
+		// The Price List Service is only offered in a few
+		// regions, and reports the prices of all of them.
+		Region: aws.String(endpoints.UsEast1RegionID),
+		Credentials: credentials.NewStaticCredentialsFromCreds(credentials.Value{
+			AccessKeyID:     accessKey,
+			SecretAccessKey: secretKey,
+		}),
+	})
+}
+
+// regionLocation returns the location name the Price List Service uses
+// for the region with the given ID, such as "US East (N. Virginia)".
+var regionLocation = func(region string) (string, bool) {
+	r, ok := endpoints.AwsPartition().Regions()[region]
+	if !ok {
+		return "", false
+	}
+	return r.Description(), true
+}
+
+// InstanceTypes implements InstanceTypesFetcher. Instance types are
+// priced at their spot price if the constraints set spot-max-price, and
+// at their on-demand price otherwise, as that is how they're launched.
 func (e *environ) InstanceTypes(ctx context.ProviderCallContext, c constraints.Value) (instances.InstanceTypesWithCostMetadata, error) {
 	ec2Session := EC2Session(e.cloud.Region, e.ec2.AccessKey, e.ec2.SecretKey)
 	iTypes, err := e.supportedInstanceTypes(ec2Session, ctx)
 	if err != nil {
 		return instances.InstanceTypesWithCostMetadata{}, errors.Trace(err)
 	}
+	if !c.HasSpotMaxPrice() {
+		costs, err := onDemandCosts(PricingSession(e.ec2.AccessKey, e.ec2.SecretKey), e.cloud.Region)
+		if err != nil {
+			// Costs are left unknown rather than failing, so
+			// that instance types can still be listed.
+			logger.Warningf("cannot get on-demand prices of instance types: %v", err)
+		}
+		// The supported instance types are cached, so they're
+		// copied before being repriced.
+		onDemand := make([]instances.InstanceType, len(iTypes))
+		for i, iType := range iTypes {
+			cost, ok := costs[iType.Name]
+			if !ok {
+				cost = math.MaxUint64
+			}
+			iType.Cost = cost
+			onDemand[i] = iType
+		}
+		iTypes = onDemand
+	}
 	iTypes, err = instances.MatchingInstanceTypes(iTypes, "", c)
 	if err != nil {
 		return instances.InstanceTypesWithCostMetadata{}, errors.Trace(err)
@@ -328,6 +378,73 @@ func convertEC2InstanceType(
 	return instType
 }
 
+// onDemandCosts queries the on-demand price of Linux instances of each
+// instance type in the given region, as reported by the Price List
+// Service, in thousandths of a US dollar per hour.
+func onDemandCosts(pricingSession pricingiface.PricingAPI, region string) (map[string]uint64, error) {
+	const costFactor = 1000
+	location, ok := regionLocation(region)
+	if !ok {
+		return nil, errors.NotFoundf("price list location of region %q", region)
+	}
+	termMatch := func(field, value string) *pricing.Filter {
+		return &pricing.Filter{
+			Type:  aws.String(pricing.FilterTypeTermMatch),
+			Field: aws.String(field),
+			Value: aws.String(value),
+		}
+	}
+	input := &pricing.GetProductsInput{
+		ServiceCode: aws.String("AmazonEC2"),
+		Filters: []*pricing.Filter{
+			termMatch("location", location),
+			termMatch("operatingSystem", "Linux"),
+			termMatch("tenancy", "Shared"),
+			termMatch("preInstalledSw", "NA"),
+			termMatch("capacitystatus", "Used"),
+		},
+	}
+	costs := make(map[string]uint64)
+	err := pricingSession.GetProductsPages(input, func(out *pricing.GetProductsOutput, _ bool) bool {
+		for _, product := range out.PriceList {
+			if instType, price, ok := onDemandPrice(product); ok {
+				costs[instType] = uint64(costFactor * price)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return costs, nil
+}
+
+// onDemandPrice returns the instance type of a product in the price
+// list, and its on-demand price in US dollars per hour.
+func onDemandPrice(product aws.JSONValue) (string, float64, bool) {
+	attrs := jsonObject(jsonObject(product["product"])["attributes"])
+	instType, _ := attrs["instanceType"].(string)
+	if instType == "" {
+		return "", 0, false
+	}
+	for _, term := range jsonObject(jsonObject(product["terms"])["OnDemand"]) {
+		for _, dimension := range jsonObject(jsonObject(term)["priceDimensions"]) {
+			usd, _ := jsonObject(jsonObject(dimension)["pricePerUnit"])["USD"].(string)
+			price, err := strconv.ParseFloat(usd, 64)
+			if err == nil && price > 0 {
+				return instType, price, true
+			}
+		}
+	}
+	return "", 0, false
+}
+
+// jsonObject returns the value as a JSON object, or nil if it isn't one.
+func jsonObject(value interface{}) map[string]interface{} {
+	obj, _ := value.(map[string]interface{})
+	return obj
+}
+
 // instanceTypeCosts queries the latest spot price for the given instance types.
 func instanceTypeCosts(ec2Session ec2iface.EC2API, instTypeNames []*string, zoneNames []string) (map[string]uint64, error) {
 	const (
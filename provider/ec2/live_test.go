@@ -10,6 +10,7 @@ import (
 	"os"
 
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/pricing/pricingiface"
 	"github.com/juju/os/series"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
@@ -95,6 +96,9 @@ func (t *LiveTests) SetUpSuite(c *gc.C) {
 			c.Assert(secretKey, gc.Equals, "x")
 			return mockEC2Session{}
 		})
+		t.BaseSuite.PatchValue(&ec2.PricingSession, func(accessKey, secretKey string) pricingiface.PricingAPI {
+			return &mockPricingSession{}
+		})
 	}
 }
 
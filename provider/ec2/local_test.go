@@ -5,6 +5,7 @@ package ec2_test
 
 import (
 	"fmt"
+	"math"
 	"net/http/httptest"
 	"net/http/httputil"
 	"net/url"
@@ -12,9 +13,14 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/pricing/pricingiface"
 	"github.com/juju/clock"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -248,6 +254,9 @@ func (t *localServerSuite) SetUpSuite(c *gc.C) {
 		c.Assert(secretKey, gc.Equals, "x")
 		return mockEC2Session{}
 	})
+	t.BaseSuite.PatchValue(&ec2.PricingSession, func(accessKey, secretKey string) pricingiface.PricingAPI {
+		return &mockPricingSession{}
+	})
 	t.srv.createRootDisks = true
 	t.srv.startServer(c)
 	// TODO(jam) I don't understand why we shouldn't do this.
@@ -729,6 +738,104 @@ func (t *localServerSuite) TestDestroyControllerDestroysHostedModelResources(c *
 	assertGroups("default")
 }
 
+func (t *localServerSuite) TestControllerResources(c *gc.C) {
+	controllerEnv := t.prepareAndBootstrap(c)
+	controllerUUID := controllerEnv.Config().UUID()
+
+	// Create a hosted model environment with an instance and a volume.
+	hostedModelUUID := "7e386e08-cba7-44a4-a76e-7c1633584210"
+	t.srv.ec2srv.SetInitialInstanceState(ec2test.Running)
+	cfg, err := controllerEnv.Config().Apply(map[string]interface{}{
+		"uuid":          hostedModelUUID,
+		"firewall-mode": "instance",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	env, err := environs.New(environs.OpenParams{
+		Cloud:  t.CloudSpec(),
+		Config: cfg,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	inst, _ := testing.AssertStartInstance(c, env, t.callCtx, t.ControllerUUID, "0")
+	_, err = ec2.EnvironEC2(env).CreateTags([]string{string(inst.Id())}, []amzec2.Tag{
+		{Key: tags.JujuMachine, Value: "hosted-machine-0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	ebsProvider, err := env.StorageProvider(ec2.EBS_ProviderType)
+	c.Assert(err, jc.ErrorIsNil)
+	vs, err := ebsProvider.VolumeSource(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeResults, err := vs.CreateVolumes(t.callCtx, []storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		Size:     1024,
+		Provider: ec2.EBS_ProviderType,
+		ResourceTags: map[string]string{
+			tags.JujuController:      t.ControllerUUID,
+			tags.JujuModel:           hostedModelUUID,
+			tags.JujuStorageInstance: "data/0",
+		},
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				InstanceId: inst.Id(),
+			},
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeResults, gc.HasLen, 1)
+	c.Assert(volumeResults[0].Error, jc.ErrorIsNil)
+
+	lister := controllerEnv.(environs.ControllerResourceLister)
+	resources, err := lister.ControllerResources(t.callCtx, t.ControllerUUID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var hosted []environs.CloudResource
+	kinds := make(map[string][]environs.CloudResourceKind)
+	for _, r := range resources {
+		kinds[r.ModelUUID] = append(kinds[r.ModelUUID], r.Kind)
+		if r.ModelUUID == hostedModelUUID {
+			hosted = append(hosted, r)
+		}
+	}
+	c.Check(kinds[controllerUUID], jc.SameContents, []environs.CloudResourceKind{
+		environs.CloudResourceInstance,
+		environs.CloudResourceSecurityGroup,
+		environs.CloudResourceSecurityGroup,
+	})
+	c.Check(kinds[hostedModelUUID], jc.SameContents, []environs.CloudResourceKind{
+		environs.CloudResourceInstance,
+		environs.CloudResourceVolume,
+		environs.CloudResourceSecurityGroup,
+		environs.CloudResourceSecurityGroup,
+	})
+	hostedIds := make(map[environs.CloudResourceKind][]string)
+	for _, r := range hosted {
+		hostedIds[r.Kind] = append(hostedIds[r.Kind], r.Id)
+	}
+	c.Check(hostedIds[environs.CloudResourceInstance], jc.DeepEquals, []string{string(inst.Id())})
+	c.Check(hostedIds[environs.CloudResourceVolume], jc.DeepEquals, []string{volumeResults[0].Volume.VolumeId})
+
+	// Resources are attributed to the machine or storage they were
+	// created for.
+	owners := make(map[environs.CloudResourceKind][]string)
+	for _, r := range hosted {
+		owners[r.Kind] = append(owners[r.Kind], r.MachineId+r.StorageId)
+	}
+	c.Check(owners[environs.CloudResourceInstance], jc.DeepEquals, []string{"0"})
+	c.Check(owners[environs.CloudResourceVolume], jc.DeepEquals, []string{"data/0"})
+	c.Check(owners[environs.CloudResourceSecurityGroup], jc.SameContents, []string{"", "0"})
+
+	// Release the hosted model's resources, leaving the controller's.
+	errs := lister.ReleaseControllerResources(t.callCtx, hosted)
+	c.Assert(errs, gc.HasLen, len(hosted))
+	for _, err := range errs {
+		c.Check(err, jc.ErrorIsNil)
+	}
+	resources, err = lister.ControllerResources(t.callCtx, t.ControllerUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, r := range resources {
+		c.Check(r.ModelUUID, gc.Equals, controllerUUID)
+	}
+}
+
 func (t *localServerSuite) TestInstanceStatus(c *gc.C) {
 	env := t.Prepare(c)
 	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env,
@@ -753,6 +860,109 @@ func (t *localServerSuite) TestStartInstanceHardwareCharacteristics(c *gc.C) {
 	c.Check(*hc.CpuCores, gc.Equals, uint64(2))
 }
 
+func (t *localServerSuite) TestStartInstanceSpot(c *gc.C) {
+	session := &spotEC2Session{
+		client: t.client,
+		prices: map[string]string{
+			"t3a.micro":  "0.003",
+			"t3a.medium": "0.012",
+			"t2.medium":  "0.02",
+			"m1.small":   "0.05",
+		},
+	}
+	t.PatchValue(&ec2.EC2Session, func(region, accessKey, secretKey string) ec2iface.EC2API {
+		return session
+	})
+	env := t.prepareAndBootstrap(c)
+
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		StatusCallback: fakeCallback,
+		Constraints:    constraints.MustParse("mem=2G spot-max-price=0.015"),
+	}
+	result, err := testing.StartInstanceWithParams(env, t.callCtx, "1", params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session.runInput, gc.NotNil)
+	c.Check(*session.runInput.InstanceType, gc.Equals, "t3a.medium")
+	market := session.runInput.InstanceMarketOptions
+	c.Assert(market, gc.NotNil)
+	c.Check(*market.MarketType, gc.Equals, "spot")
+	c.Check(*market.SpotOptions.MaxPrice, gc.Equals, "0.015")
+	c.Check(result.Instance.(instances.InterruptibleInstance).Interruptible(), jc.IsTrue)
+
+	// The instance is tagged as a spot instance, so when it is
+	// described again, any interruption is reported in its status.
+	id := result.Instance.Id()
+	session.requestStatus = map[string]string{string(id): "marked-for-termination"}
+	insts, err := env.Instances(t.callCtx, []instance.Id{id})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(insts[0].(instances.InterruptibleInstance).Interruptible(), jc.IsTrue)
+	c.Check(insts[0].Status(t.callCtx).Message, gc.Matches, `.* \(spot interruption: marked-for-termination\)`)
+}
+
+func (t *localServerSuite) TestSpotInterruptionsCheckedAtMostOncePerInterval(c *gc.C) {
+	session := &spotEC2Session{
+		client: t.client,
+		prices: map[string]string{"t3a.medium": "0.012"},
+	}
+	t.PatchValue(&ec2.EC2Session, func(region, accessKey, secretKey string) ec2iface.EC2API {
+		return session
+	})
+	env := t.prepareAndBootstrap(c)
+
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		StatusCallback: fakeCallback,
+		Constraints:    constraints.MustParse("spot-max-price=0.015"),
+	}
+	result, err := testing.StartInstanceWithParams(env, t.callCtx, "1", params)
+	c.Assert(err, jc.ErrorIsNil)
+	id := result.Instance.Id()
+	clk := testclock.NewClock(coretesting.ZeroTime())
+	ec2.SetEnvironClock(env, clk)
+
+	for i := 0; i < 3; i++ {
+		_, err := env.Instances(t.callCtx, []instance.Id{id})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(session.describeCalls, gc.Equals, 1)
+
+	// Once the interval has passed, the spot requests are checked again.
+	session.requestStatus = map[string]string{string(id): "marked-for-stop"}
+	clk.Advance(time.Minute)
+	insts, err := env.Instances(t.callCtx, []instance.Id{id})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session.describeCalls, gc.Equals, 2)
+	c.Check(insts[0].Status(t.callCtx).Message, gc.Matches, `.* \(spot interruption: marked-for-stop\)`)
+}
+
+func (t *localServerSuite) TestStartInstanceSpotPriceTooLow(c *gc.C) {
+	session := &spotEC2Session{
+		client: t.client,
+		prices: map[string]string{"t3a.micro": "0.003"},
+	}
+	t.PatchValue(&ec2.EC2Session, func(region, accessKey, secretKey string) ec2iface.EC2API {
+		return session
+	})
+	env := t.prepareAndBootstrap(c)
+
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		StatusCallback: fakeCallback,
+		Constraints:    constraints.MustParse("spot-max-price=0.001"),
+	}
+	_, err := testing.StartInstanceWithParams(env, t.callCtx, "1", params)
+	c.Assert(err, gc.ErrorMatches, `no instance types with a spot price of at most \$0.001 per hour`)
+	c.Assert(session.runInput, gc.IsNil)
+}
+
+func (t *localServerSuite) TestOnDemandInstanceNotInterruptible(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	inst, _ := testing.AssertStartInstance(c, env, t.callCtx, t.ControllerUUID, "1")
+	c.Assert(inst.(instances.InterruptibleInstance).Interruptible(), jc.IsFalse)
+	c.Assert(inst.Status(t.callCtx).Message, gc.Not(gc.Matches), ".*spot.*")
+}
+
 func (t *localServerSuite) TestStartInstanceAvailZone(c *gc.C) {
 	inst, err := t.testStartInstanceAvailZone(c, "test-available")
 	c.Assert(err, jc.ErrorIsNil)
@@ -1767,6 +1977,50 @@ func (t *localServerSuite) TestSubnetsWithInstanceIdMissingSubnet(c *gc.C) {
 	c.Assert(subnets, gc.HasLen, 0)
 }
 
+func (t *localServerSuite) TestInstanceTypesCosts(c *gc.C) {
+	t.PatchValue(&ec2.EC2Session, func(region, accessKey, secretKey string) ec2iface.EC2API {
+		return &spotEC2Session{
+			prices: map[string]string{"t3a.medium": "0.012", "t2.medium": "0.02"},
+		}
+	})
+	pricingSession := &mockPricingSession{
+		prices: map[string]string{"t3a.medium": "0.0376", "t2.medium": "0.0464"},
+	}
+	t.PatchValue(&ec2.PricingSession, func(accessKey, secretKey string) pricingiface.PricingAPI {
+		return pricingSession
+	})
+	t.PatchValue(ec2.RegionLocation, func(region string) (string, bool) {
+		return "Test (Region)", region == "test"
+	})
+	env := t.prepareEnviron(c)
+
+	costs := func(types instances.InstanceTypesWithCostMetadata) map[string]uint64 {
+		result := make(map[string]uint64)
+		for _, itype := range types.InstanceTypes {
+			result[itype.Name] = itype.Cost
+		}
+		return result
+	}
+
+	// Instances are launched on demand, so they're priced as such.
+	types, err := env.InstanceTypes(t.callCtx, constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(costs(types), jc.DeepEquals, map[string]uint64{"t3a.medium": 37})
+	c.Assert(pricingSession.input, gc.NotNil)
+	c.Check(*pricingSession.input.Filters[0].Value, gc.Equals, "Test (Region)")
+
+	// Spot instances are priced at the spot price.
+	types, err = env.InstanceTypes(t.callCtx, constraints.MustParse("mem=4G spot-max-price=0.05"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(costs(types), jc.DeepEquals, map[string]uint64{"t3a.medium": 12})
+
+	// Costs are unknown if on-demand prices aren't available.
+	t.PatchValue(ec2.RegionLocation, func(string) (string, bool) { return "", false })
+	types, err = env.InstanceTypes(t.callCtx, constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(costs(types), jc.DeepEquals, map[string]uint64{"t3a.medium": math.MaxUint64})
+}
+
 func (t *localServerSuite) TestInstanceInformation(c *gc.C) {
 	// TODO(macgreagoir) Where do these magic length numbers come from?
 	c.Skip("Hard-coded InstanceTypes counts without explanation")
@@ -1887,6 +2141,85 @@ func (t *localServerSuite) TestRootDiskTags(c *gc.C) {
 	})
 }
 
+func (t *localServerSuite) TestTagInstance(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	instances, err := env.AllRunningInstances(t.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instances, gc.HasLen, 1)
+
+	err = env.(environs.InstanceTagger).TagInstance(t.callCtx, instances[0].Id(), map[string]string{
+		"cost-centre": "research",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	instances, err = env.AllRunningInstances(t.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	ec2Inst := ec2.InstanceEC2(instances[0])
+	c.Assert(ec2Inst.Tags, jc.SameContents, []amzec2.Tag{
+		{"Name", "juju-sample-machine-0"},
+		{"juju-model-uuid", coretesting.ModelTag.Id()},
+		{"juju-controller-uuid", t.ControllerUUID},
+		{"juju-is-controller", "true"},
+		{"cost-centre", "research"},
+	})
+}
+
+func (t *localServerSuite) TestTagVolume(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	ec2conn := ec2.EnvironEC2(env)
+	resp, err := ec2conn.Volumes(nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	var volumeId string
+	for _, vol := range resp.Volumes {
+		if len(vol.Tags) != 0 {
+			volumeId = vol.Id
+			break
+		}
+	}
+	c.Assert(volumeId, gc.Not(gc.Equals), "")
+
+	err = env.(environs.VolumeTagger).TagVolume(t.callCtx, volumeId, map[string]string{
+		"cost-centre": "research",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	resp, err = ec2conn.Volumes([]string{volumeId}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Volumes, gc.HasLen, 1)
+	c.Assert(resp.Volumes[0].Tags, jc.SameContents, []amzec2.Tag{
+		{"Name", "juju-sample-machine-0-root"},
+		{"juju-model-uuid", coretesting.ModelTag.Id()},
+		{"juju-controller-uuid", t.ControllerUUID},
+		{"cost-centre", "research"},
+	})
+}
+
+func (t *localServerSuite) TestRemoveResourceTags(c *gc.C) {
+	session := &tagsEC2Session{}
+	t.PatchValue(&ec2.EC2Session, func(region, accessKey, secretKey string) ec2iface.EC2API {
+		return session
+	})
+	env := t.prepareAndBootstrap(c)
+
+	err := env.(environs.InstanceTagRemover).RemoveInstanceTags(t.callCtx, "i-0", []string{"cost-centre", "owner"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.(environs.VolumeTagRemover).RemoveVolumeTags(t.callCtx, "vol-0", []string{"owner"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(session.deleteInput, gc.HasLen, 2)
+	c.Check(awssdk.StringValueSlice(session.deleteInput[0].Resources), jc.DeepEquals, []string{"i-0"})
+	c.Assert(session.deleteInput[0].Tags, gc.HasLen, 2)
+	c.Check(awssdk.StringValue(session.deleteInput[0].Tags[0].Key), gc.Equals, "cost-centre")
+	c.Check(awssdk.StringValue(session.deleteInput[0].Tags[1].Key), gc.Equals, "owner")
+	c.Check(awssdk.StringValueSlice(session.deleteInput[1].Resources), jc.DeepEquals, []string{"vol-0"})
+
+	session.err = awserr.New("InvalidVolume.NotFound", "no such volume", nil)
+	err = env.(environs.VolumeTagRemover).RemoveVolumeTags(t.callCtx, "vol-1", []string{"owner"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *localServerSuite) TestBootstrapInstanceConstraints(c *gc.C) {
 	env := s.prepareAndBootstrap(c)
 	inst, err := env.AllRunningInstances(s.callCtx)
@@ -7,6 +7,9 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/pricing"
+	"github.com/aws/aws-sdk-go/service/pricing/pricingiface"
+	amzec2 "gopkg.in/amz.v3/ec2"
 )
 
 type mockEC2Session struct {
@@ -86,3 +89,107 @@ func (mockEC2Session) DescribeSpotPriceHistory(*ec2.DescribeSpotPriceHistoryInpu
 		SpotPriceHistory: nil,
 	}, nil
 }
+
+// spotEC2Session is a mockEC2Session which reports spot prices, and
+// launches spot instances on a test server.
+type spotEC2Session struct {
+	mockEC2Session
+
+	client        *amzec2.EC2
+	prices        map[string]string
+	requestStatus map[string]string
+	runInput      *ec2.RunInstancesInput
+	describeCalls int
+}
+
+func (s *spotEC2Session) DescribeSpotPriceHistory(*ec2.DescribeSpotPriceHistoryInput) (*ec2.DescribeSpotPriceHistoryOutput, error) {
+	var history []*ec2.SpotPrice
+	for instType, price := range s.prices {
+		history = append(history, &ec2.SpotPrice{
+			InstanceType: aws.String(instType),
+			SpotPrice:    aws.String(price),
+		})
+	}
+	return &ec2.DescribeSpotPriceHistoryOutput{SpotPriceHistory: history}, nil
+}
+
+func (s *spotEC2Session) RunInstances(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+	s.runInput = input
+	runArgs := &amzec2.RunInstances{
+		ImageId:      aws.StringValue(input.ImageId),
+		InstanceType: aws.StringValue(input.InstanceType),
+		SubnetId:     aws.StringValue(input.SubnetId),
+	}
+	if input.Placement != nil {
+		runArgs.AvailZone = aws.StringValue(input.Placement.AvailabilityZone)
+	}
+	for _, id := range input.SecurityGroupIds {
+		runArgs.SecurityGroups = append(runArgs.SecurityGroups, amzec2.SecurityGroup{Id: aws.StringValue(id)})
+	}
+	resp, err := s.client.RunInstances(runArgs)
+	if err != nil {
+		return nil, err
+	}
+	out := &ec2.Reservation{ReservationId: aws.String(resp.ReservationId)}
+	for _, inst := range resp.Instances {
+		out.Instances = append(out.Instances, &ec2.Instance{InstanceId: aws.String(inst.InstanceId)})
+	}
+	return out, nil
+}
+
+func (s *spotEC2Session) DescribeSpotInstanceRequests(*ec2.DescribeSpotInstanceRequestsInput) (*ec2.DescribeSpotInstanceRequestsOutput, error) {
+	s.describeCalls++
+	out := &ec2.DescribeSpotInstanceRequestsOutput{}
+	for id, code := range s.requestStatus {
+		out.SpotInstanceRequests = append(out.SpotInstanceRequests, &ec2.SpotInstanceRequest{
+			InstanceId: aws.String(id),
+			Status:     &ec2.SpotInstanceStatus{Code: aws.String(code)},
+		})
+	}
+	return out, nil
+}
+
+type tagsEC2Session struct {
+	mockEC2Session
+
+	deleteInput []*ec2.DeleteTagsInput
+	err         error
+}
+
+func (s *tagsEC2Session) DeleteTags(input *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error) {
+	s.deleteInput = append(s.deleteInput, input)
+	return &ec2.DeleteTagsOutput{}, s.err
+}
+
+// mockPricingSession reports the on-demand prices of instance types.
+type mockPricingSession struct {
+	pricingiface.PricingAPI
+
+	prices map[string]string
+	input  *pricing.GetProductsInput
+}
+
+func (s *mockPricingSession) GetProductsPages(input *pricing.GetProductsInput, fn func(*pricing.GetProductsOutput, bool) bool) error {
+	s.input = input
+	out := &pricing.GetProductsOutput{}
+	for instType, price := range s.prices {
+		out.PriceList = append(out.PriceList, aws.JSONValue{
+			"product": map[string]interface{}{
+				"attributes": map[string]interface{}{"instanceType": instType},
+			},
+			"terms": map[string]interface{}{
+				"OnDemand": map[string]interface{}{
+					"SKU.TERM": map[string]interface{}{
+						"priceDimensions": map[string]interface{}{
+							"SKU.TERM.RATE": map[string]interface{}{
+								"pricePerUnit": map[string]interface{}{"USD": price},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	fn(out, true)
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/jsonschema"
 	"github.com/juju/loggo"
@@ -41,6 +42,7 @@ func (p environProvider) Open(args environs.OpenParams) (environs.Environ, error
 
 	e := new(environ)
 	e.name = args.Config.Name()
+	e.clock = clock.WallClock
 
 	if err := e.SetCloudSpec(args.Cloud); err != nil {
 		return nil, err
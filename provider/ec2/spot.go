@@ -0,0 +1,237 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	awsec2 "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/instances"
+)
+
+// tagSpotInstance is the tag applied to spot instances, so that their
+// spot requests can be checked for interruptions.
+const tagSpotInstance = "juju-spot-instance"
+
+// spotCheckInterval is the minimum time between checks of the spot
+// requests of instances that have already been checked.
+const spotCheckInterval = time.Minute
+
+// spotInterruptionCodes holds the spot request status codes which
+// indicate that an instance has been, or is about to be, interrupted.
+var spotInterruptionCodes = set.NewStrings(
+	"marked-for-hibernation",
+	"marked-for-stop",
+	"marked-for-termination",
+	"instance-stopped-by-price",
+	"instance-stopped-capacity-oversubscribed",
+	"instance-stopped-no-capacity",
+	"instance-terminated-by-price",
+	"instance-terminated-capacity-oversubscribed",
+	"instance-terminated-launch-group-constraint",
+	"instance-terminated-no-capacity",
+)
+
+// isSpotInstance returns true if the instance was launched as a spot
+// instance.
+func isSpotInstance(inst *ec2.Instance) bool {
+	for _, tag := range inst.Tags {
+		if tag.Key == tagSpotInstance {
+			return true
+		}
+	}
+	return false
+}
+
+// spotInstanceTypes returns the instance types whose current spot price
+// is at most maxPrice US dollars per hour.
+func spotInstanceTypes(instanceTypes []instances.InstanceType, maxPrice string) ([]instances.InstanceType, error) {
+	price, err := strconv.ParseFloat(maxPrice, 64)
+	if err != nil {
+		return nil, errors.Annotatef(err, "parsing spot max price %q", maxPrice)
+	}
+	// Instance type costs are the spot price multiplied by 1000; types
+	// without a spot price have the maximum cost, so are excluded.
+	maxCost := uint64(price * 1000)
+	var result []instances.InstanceType
+	for _, itype := range instanceTypes {
+		if itype.Cost <= maxCost {
+			result = append(result, itype)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.Errorf("no instance types with a spot price of at most $%s per hour", maxPrice)
+	}
+	return result, nil
+}
+
+// runSpotInstances launches instances as spot instances, costing at
+// most maxPrice US dollars per hour. The ec2 package doesn't support
+// instance market options, so the instances are launched with the AWS
+// SDK, and then described with the ec2 package like any other instance.
+func runSpotInstances(
+	ec2Session ec2iface.EC2API,
+	e *ec2.EC2,
+	ri *ec2.RunInstances,
+	maxPrice string,
+	c environs.StatusCallbackFunc,
+) (*ec2.RunInstancesResp, error) {
+	c(status.Allocating, fmt.Sprintf("Requesting spot instance at up to $%s per hour", maxPrice), nil)
+	out, err := ec2Session.RunInstances(spotRunInstancesInput(ri, maxPrice))
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			// Convert the error so that it is handled in the same
+			// way as errors from the ec2 package.
+			err = &ec2.Error{Code: awsErr.Code(), Message: awsErr.Message()}
+		}
+		return nil, err
+	}
+	ids := make([]string, len(out.Instances))
+	for i, inst := range out.Instances {
+		ids[i] = aws.StringValue(inst.InstanceId)
+	}
+
+	resp := &ec2.RunInstancesResp{
+		ReservationId: aws.StringValue(out.ReservationId),
+		OwnerId:       aws.StringValue(out.OwnerId),
+	}
+	// Newly launched instances may not be described straight away.
+	for a := shortAttempt.Start(); a.Next(); {
+		var instResp *ec2.InstancesResp
+		instResp, err = e.Instances(ids, nil)
+		if err != nil && ec2ErrCode(err) != "InvalidInstanceID.NotFound" {
+			return nil, err
+		}
+		if err == nil {
+			resp.Instances = nil
+			for _, r := range instResp.Reservations {
+				resp.Instances = append(resp.Instances, r.Instances...)
+			}
+			if len(resp.Instances) == len(ids) {
+				return resp, nil
+			}
+		}
+	}
+	if err == nil {
+		err = errors.Errorf("spot instances %v not found", ids)
+	}
+	return nil, errors.Annotate(err, "describing spot instances")
+}
+
+// spotRunInstancesInput returns the AWS SDK input to launch the
+// instances described by ri as spot instances. Only the fields used
+// by StartInstance are converted.
+func spotRunInstancesInput(ri *ec2.RunInstances, maxPrice string) *awsec2.RunInstancesInput {
+	minCount, maxCount := ri.MinCount, ri.MaxCount
+	if minCount == 0 {
+		minCount = 1
+	}
+	if maxCount == 0 {
+		maxCount = minCount
+	}
+	input := &awsec2.RunInstancesInput{
+		ImageId:      aws.String(ri.ImageId),
+		InstanceType: aws.String(ri.InstanceType),
+		MinCount:     aws.Int64(int64(minCount)),
+		MaxCount:     aws.Int64(int64(maxCount)),
+		InstanceMarketOptions: &awsec2.InstanceMarketOptionsRequest{
+			MarketType: aws.String(awsec2.MarketTypeSpot),
+			SpotOptions: &awsec2.SpotMarketOptions{
+				MaxPrice:                     aws.String(maxPrice),
+				SpotInstanceType:             aws.String(awsec2.SpotInstanceTypeOneTime),
+				InstanceInterruptionBehavior: aws.String(awsec2.InstanceInterruptionBehaviorTerminate),
+			},
+		},
+	}
+	if len(ri.UserData) > 0 {
+		input.UserData = aws.String(base64.StdEncoding.EncodeToString(ri.UserData))
+	}
+	if ri.AvailZone != "" {
+		input.Placement = &awsec2.Placement{AvailabilityZone: aws.String(ri.AvailZone)}
+	}
+	if ri.SubnetId != "" {
+		input.SubnetId = aws.String(ri.SubnetId)
+	}
+	for _, g := range ri.SecurityGroups {
+		if g.Id != "" {
+			input.SecurityGroupIds = append(input.SecurityGroupIds, aws.String(g.Id))
+		} else {
+			input.SecurityGroups = append(input.SecurityGroups, aws.String(g.Name))
+		}
+	}
+	for _, b := range ri.BlockDeviceMappings {
+		mapping := &awsec2.BlockDeviceMapping{}
+		if b.DeviceName != "" {
+			mapping.DeviceName = aws.String(b.DeviceName)
+		}
+		if b.VirtualName != "" {
+			mapping.VirtualName = aws.String(b.VirtualName)
+		}
+		if b.SnapshotId != "" || b.VolumeType != "" || b.VolumeSize > 0 || b.IOPS > 0 || b.DeleteOnTermination {
+			ebs := &awsec2.EbsBlockDevice{}
+			if b.SnapshotId != "" {
+				ebs.SnapshotId = aws.String(b.SnapshotId)
+			}
+			if b.VolumeType != "" {
+				ebs.VolumeType = aws.String(b.VolumeType)
+			}
+			if b.VolumeSize > 0 {
+				ebs.VolumeSize = aws.Int64(b.VolumeSize)
+			}
+			if b.IOPS > 0 {
+				ebs.Iops = aws.Int64(b.IOPS)
+			}
+			if b.DeleteOnTermination {
+				ebs.DeleteOnTermination = aws.Bool(true)
+			}
+			mapping.Ebs = ebs
+		}
+		input.BlockDeviceMappings = append(input.BlockDeviceMappings, mapping)
+	}
+	return input
+}
+
+// spotInterruptions returns the interruption status codes of the spot
+// requests for the instances with the input IDs, keyed by instance ID.
+// Instances that have not been interrupted are not included.
+func spotInterruptions(ec2Session ec2iface.EC2API, ids []string) (map[string]string, error) {
+	filter := &awsec2.Filter{Name: aws.String("instance-id")}
+	for _, id := range ids {
+		filter.Values = append(filter.Values, aws.String(id))
+	}
+	input := &awsec2.DescribeSpotInstanceRequestsInput{
+		Filters: []*awsec2.Filter{filter},
+	}
+	codes := make(map[string]string)
+	for {
+		out, err := ec2Session.DescribeSpotInstanceRequests(input)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, req := range out.SpotInstanceRequests {
+			if req.Status == nil {
+				continue
+			}
+			if code := aws.StringValue(req.Status.Code); spotInterruptionCodes.Contains(code) {
+				codes[aws.StringValue(req.InstanceId)] = code
+			}
+		}
+		if aws.StringValue(out.NextToken) == "" {
+			return codes, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
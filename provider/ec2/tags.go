@@ -0,0 +1,52 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	awsec2 "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+var _ environs.InstanceTagRemover = (*environ)(nil)
+var _ environs.VolumeTagRemover = (*environ)(nil)
+
+// RemoveInstanceTags implements environs.InstanceTagRemover.
+func (e *environ) RemoveInstanceTags(ctx context.ProviderCallContext, id instance.Id, names []string) error {
+	return errors.Annotate(e.untagResources(ctx, names, string(id)), "removing instance tags")
+}
+
+// RemoveVolumeTags implements environs.VolumeTagRemover.
+func (e *environ) RemoveVolumeTags(ctx context.ProviderCallContext, volumeId string, names []string) error {
+	return errors.Annotate(e.untagResources(ctx, names, volumeId), "removing volume tags")
+}
+
+// untagResources removes the tags with the given names from the
+// resources. The EC2 client used elsewhere cannot delete tags, so an
+// SDK session is used.
+func (e *environ) untagResources(ctx context.ProviderCallContext, names []string, resourceIds ...string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	tags := make([]*awsec2.Tag, len(names))
+	for i, name := range names {
+		tags[i] = &awsec2.Tag{Key: aws.String(name)}
+	}
+	session := EC2Session(e.cloud.Region, e.ec2.AccessKey, e.ec2.SecretKey)
+	_, err := session.DeleteTags(&awsec2.DeleteTagsInput{
+		Resources: aws.StringSlice(resourceIds),
+		Tags:      tags,
+	})
+	if awsErr, ok := err.(awserr.Error); ok && strings.HasSuffix(awsErr.Code(), ".NotFound") {
+		return errors.NotFoundf("resource(s) %v", resourceIds)
+	}
+	return maybeConvertCredentialError(err, ctx)
+}
@@ -5,6 +5,7 @@ package gce
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"sync"
 
@@ -505,17 +506,83 @@ func (v *volumeSource) detachOneVolume(ctx context.ProviderCallContext, attachPa
 	return google.HandleCredentialError(v.gce.DetachDisk(zone, string(instId), volumeName), ctx)
 }
 
+// TagVolume implements environs.VolumeTagger. The tags that can be
+// translated to disk labels are set on the volume's disk.
+func (env *environ) TagVolume(ctx context.ProviderCallContext, volumeId string, tags map[string]string) error {
+	return env.updateVolumeLabels(ctx, volumeId, resourceTagsToDiskLabels(tags), nil)
+}
+
+// RemoveVolumeTags implements environs.VolumeTagRemover.
+func (env *environ) RemoveVolumeTags(ctx context.ProviderCallContext, volumeId string, names []string) error {
+	return env.updateVolumeLabels(ctx, volumeId, nil, names)
+}
+
+// updateVolumeLabels sets and removes labels on the volume's disk in a
+// single request.
+func (env *environ) updateVolumeLabels(ctx context.ProviderCallContext, volumeId string, set map[string]string, remove []string) error {
+	zone, _, err := parseVolumeId(volumeId)
+	if err != nil {
+		return errors.Annotatef(err, "cannot get volume %q", volumeId)
+	}
+	disk, err := env.gce.Disk(zone, volumeId)
+	if err != nil {
+		return google.HandleCredentialError(errors.Annotatef(err, "cannot get volume %q", volumeId), ctx)
+	}
+	labels, changed := updateLabels(disk.Labels, set, remove)
+	if !changed {
+		return nil
+	}
+	if err := env.gce.SetDiskLabels(zone, volumeId, disk.LabelFingerprint, labels); err != nil {
+		return google.HandleCredentialError(errors.Annotatef(err, "cannot update labels on volume %q", volumeId), ctx)
+	}
+	return nil
+}
+
+// updateLabels returns a copy of the given labels with the labels in
+// set added or replaced, and those named in remove deleted. It also
+// returns whether the result differs from the given labels.
+func updateLabels(labels, set map[string]string, remove []string) (map[string]string, bool) {
+	result := make(map[string]string)
+	for k, v := range labels {
+		result[k] = v
+	}
+	var changed bool
+	for k, v := range set {
+		if old, ok := result[k]; !ok || old != v {
+			result[k] = v
+			changed = true
+		}
+	}
+	for _, k := range remove {
+		if _, ok := result[k]; ok {
+			delete(result, k)
+			changed = true
+		}
+	}
+	return result, changed
+}
+
+// diskLabelRegexp matches the keys and values that GCE accepts
+// as labels.
+var diskLabelRegexp = regexp.MustCompile(`^[a-z0-9_-]{0,63}$`)
+
 // resourceTagsToDiskLabels translates a set of
 // resource tags, provided by Juju, to disk labels.
 func resourceTagsToDiskLabels(in map[string]string) map[string]string {
 	out := make(map[string]string)
 	for k, v := range in {
-		// Only the controller and model UUID tags are carried
+		// The controller and model UUID tags are always carried
 		// over, as they're known not to conflict with GCE's
-		// rules regarding label values.
+		// rules regarding label values. Other tags are carried
+		// over only if they happen to obey those rules.
 		switch k {
 		case tags.JujuController, tags.JujuModel:
 			out[k] = v
+		default:
+			if k != "" && k[0] >= 'a' && k[0] <= 'z' &&
+				diskLabelRegexp.MatchString(k) && diskLabelRegexp.MatchString(v) {
+				out[k] = v
+			}
 		}
 	}
 	return out
@@ -32,6 +32,9 @@ type gceConnection interface {
 	AddInstance(spec google.InstanceSpec) (*google.Instance, error)
 	RemoveInstances(prefix string, ids ...string) error
 	UpdateMetadata(key, value string, ids ...string) error
+	// SetInstanceLabels sets the labels on an instance, ensuring that
+	// the instance's label fingerprint matches the one supplied.
+	SetInstanceLabels(zone, id, labelFingerprint string, labels map[string]string) error
 
 	IngressRules(fwname string) ([]network.IngressRule, error)
 	OpenPorts(fwname string, rules ...network.IngressRule) error
@@ -87,6 +90,10 @@ type environ struct {
 
 var _ environs.Environ = (*environ)(nil)
 var _ environs.NetworkingEnviron = (*environ)(nil)
+var _ environs.InstanceTagger = (*environ)(nil)
+var _ environs.VolumeTagger = (*environ)(nil)
+var _ environs.InstanceTagRemover = (*environ)(nil)
+var _ environs.VolumeTagRemover = (*environ)(nil)
 
 // Function entry points defined as variables so they can be overridden
 // for testing purposes.
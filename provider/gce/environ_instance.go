@@ -146,6 +146,38 @@ func (env *environ) AdoptResources(ctx context.ProviderCallContext, controllerUU
 	return nil
 }
 
+// TagInstance implements environs.InstanceTagger. The tags that can be
+// translated to labels, by the same rules as for disks, are set on the
+// instance.
+func (env *environ) TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error {
+	return env.updateInstanceLabels(ctx, id, resourceTagsToDiskLabels(tags), nil)
+}
+
+// RemoveInstanceTags implements environs.InstanceTagRemover.
+func (env *environ) RemoveInstanceTags(ctx context.ProviderCallContext, id instance.Id, names []string) error {
+	return env.updateInstanceLabels(ctx, id, nil, names)
+}
+
+// updateInstanceLabels sets and removes labels on the instance in a
+// single request.
+func (env *environ) updateInstanceLabels(ctx context.ProviderCallContext, id instance.Id, set map[string]string, remove []string) error {
+	insts, err := env.Instances(ctx, []instance.Id{id})
+	if err == environs.ErrNoInstances {
+		return errors.NotFoundf("instance %q", id)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot get instance %q", id)
+	}
+	base := insts[0].(*environInstance).base
+	labels, changed := updateLabels(base.Labels, set, remove)
+	if !changed {
+		return nil
+	}
+	if err := env.gce.SetInstanceLabels(base.ZoneName, string(id), base.LabelFingerprint, labels); err != nil {
+		return google.HandleCredentialError(errors.Annotatef(err, "cannot update labels on instance %q", id), ctx)
+	}
+	return nil
+}
+
 // TODO(ericsnow) Turn into an interface.
 type instPlacement struct {
 	Zone *google.AvailabilityZone
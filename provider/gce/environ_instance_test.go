@@ -231,6 +231,98 @@ func (s *environInstSuite) TestAdoptResources(c *gc.C) {
 	c.Check(call.Value, gc.Equals, "other-uuid")
 }
 
+func (s *environInstSuite) TestTagInstance(c *gc.C) {
+	s.BaseInstance.Labels = map[string]string{"yodel": "eh"}
+	s.BaseInstance.LabelFingerprint = "fingerprint"
+	s.FakeEnviron.Insts = []instances.Instance{s.NewInstanceFromBase(s.BaseInstance)}
+
+	err := s.Env.TagInstance(s.CallCtx, instance.Id(s.BaseInstance.ID), map[string]string{
+		"owner":       "eve",
+		"cost-centre": "research",
+		"Invalid Key": "ignored",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	called, calls := s.FakeConn.WasCalled("SetInstanceLabels")
+	c.Check(called, jc.IsTrue)
+	c.Assert(calls, gc.HasLen, 1)
+	c.Check(calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(calls[0].ID, gc.Equals, s.BaseInstance.ID)
+	c.Check(calls[0].LabelFingerprint, gc.Equals, "fingerprint")
+	c.Check(calls[0].Labels, jc.DeepEquals, map[string]string{
+		"yodel":       "eh",
+		"owner":       "eve",
+		"cost-centre": "research",
+	})
+}
+
+func (s *environInstSuite) TestTagInstanceUnchanged(c *gc.C) {
+	s.BaseInstance.Labels = map[string]string{"owner": "eve"}
+	s.FakeEnviron.Insts = []instances.Instance{s.NewInstanceFromBase(s.BaseInstance)}
+
+	err := s.Env.TagInstance(s.CallCtx, instance.Id(s.BaseInstance.ID), map[string]string{
+		"owner": "eve",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	called, _ := s.FakeConn.WasCalled("SetInstanceLabels")
+	c.Check(called, jc.IsFalse)
+}
+
+func (s *environInstSuite) TestTagInstanceNotFound(c *gc.C) {
+	err := s.Env.TagInstance(s.CallCtx, "missing", map[string]string{
+		"owner": "eve",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *environInstSuite) TestRemoveInstanceTags(c *gc.C) {
+	s.BaseInstance.Labels = map[string]string{"yodel": "eh", "owner": "eve"}
+	s.BaseInstance.LabelFingerprint = "fingerprint"
+	s.FakeEnviron.Insts = []instances.Instance{s.NewInstanceFromBase(s.BaseInstance)}
+
+	err := s.Env.RemoveInstanceTags(s.CallCtx, instance.Id(s.BaseInstance.ID), []string{"owner", "unset"})
+	c.Assert(err, jc.ErrorIsNil)
+	called, calls := s.FakeConn.WasCalled("SetInstanceLabels")
+	c.Check(called, jc.IsTrue)
+	c.Assert(calls, gc.HasLen, 1)
+	c.Check(calls[0].LabelFingerprint, gc.Equals, "fingerprint")
+	c.Check(calls[0].Labels, jc.DeepEquals, map[string]string{"yodel": "eh"})
+}
+
+func (s *environInstSuite) TestTagVolume(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+
+	err := s.Env.TagVolume(s.CallCtx, s.BaseDisk.Name, map[string]string{
+		"cost-centre": "research",
+		"Invalid Key": "ignored",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	called, calls := s.FakeConn.WasCalled("SetDiskLabels")
+	c.Check(called, jc.IsTrue)
+	c.Assert(calls, gc.HasLen, 1)
+	c.Check(calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(calls[0].ID, gc.Equals, s.BaseDisk.Name)
+	c.Check(calls[0].Labels, jc.DeepEquals, map[string]string{
+		"yodel":                "eh",
+		"juju-model-uuid":      s.Env.Config().UUID(),
+		"juju-controller-uuid": s.ControllerUUID,
+		"cost-centre":          "research",
+	})
+}
+
+func (s *environInstSuite) TestRemoveVolumeTags(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+
+	err := s.Env.RemoveVolumeTags(s.CallCtx, s.BaseDisk.Name, []string{"yodel"})
+	c.Assert(err, jc.ErrorIsNil)
+	called, calls := s.FakeConn.WasCalled("SetDiskLabels")
+	c.Check(called, jc.IsTrue)
+	c.Assert(calls, gc.HasLen, 1)
+	c.Check(calls[0].Labels, jc.DeepEquals, map[string]string{
+		"juju-model-uuid":      s.Env.Config().UUID(),
+		"juju-controller-uuid": s.ControllerUUID,
+	})
+}
+
 func (s *environInstSuite) TestAdoptResourcesInvalidCredentialError(c *gc.C) {
 	s.FakeConn.Err = gce.InvalidCredentialError
 	c.Assert(s.InvalidatedCredentials, jc.IsFalse)
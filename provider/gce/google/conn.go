@@ -52,6 +52,10 @@ type service interface {
 	// completed or fails.
 	SetMetadata(projectID, zone, instanceID string, metadata *compute.Metadata) error
 
+	// SetInstanceLabels sets the labels on an instance, ensuring that
+	// the instance's label fingerprint matches the one supplied.
+	SetInstanceLabels(projectID, zone, instanceID, labelFingerprint string, labels map[string]string) error
+
 	// GetFirewalls sends an API request to GCE for the information about
 	// the firewalls with the namePrefix and returns them.
 	// If no firewalls are not found, errors.NotFound is returned.
@@ -191,6 +191,14 @@ func (gce *Connection) updateInstanceMetadata(instance *compute.Instance, key, v
 	return errors.Trace(gce.service.SetMetadata(gce.projectID, zoneName, instance.Name, metadata))
 }
 
+// SetInstanceLabels sets the labels on the identified instance,
+// ensuring that the instance's label fingerprint matches the one
+// supplied.
+func (gce *Connection) SetInstanceLabels(zone, id, labelFingerprint string, labels map[string]string) error {
+	err := gce.service.SetInstanceLabels(gce.projectID, zone, id, labelFingerprint, labels)
+	return errors.Annotatef(err, "cannot update labels for instance %q in zone %q", id, zone)
+}
+
 func findMetadataItem(items []*compute.MetadataItems, key string) *compute.MetadataItems {
 	for _, item := range items {
 		if item == nil {
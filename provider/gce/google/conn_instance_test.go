@@ -464,6 +464,23 @@ func (s *connSuite) TestUpdateMetadataChecksCurrentValue(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
 }
 
+func (s *connSuite) TestConnectionSetInstanceLabels(c *gc.C) {
+	labels := map[string]string{
+		"a": "b",
+		"c": "d",
+	}
+	err := s.Conn.SetInstanceLabels("home-zone", "spam", "fingerprint", labels)
+	c.Check(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "SetInstanceLabels")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(s.FakeConn.Calls[0].InstanceId, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].LabelFingerprint, gc.Equals, "fingerprint")
+	c.Check(s.FakeConn.Calls[0].Labels, jc.DeepEquals, labels)
+}
+
 func makeMetadataItems(key, value string) *compute.MetadataItems {
 	return &compute.MetadataItems{Key: key, Value: google.StringPtr(value)}
 }
@@ -111,6 +111,11 @@ type InstanceSummary struct {
 	// NetworkInterfaces are the network connections associated with
 	// the instance.
 	NetworkInterfaces []*compute.NetworkInterface
+	// Labels holds the instance's labels.
+	Labels map[string]string
+	// LabelFingerprint is the fingerprint of the instance's labels,
+	// which must be supplied when they are changed.
+	LabelFingerprint string
 }
 
 func newInstanceSummary(raw *compute.Instance) InstanceSummary {
@@ -121,6 +126,8 @@ func newInstanceSummary(raw *compute.Instance) InstanceSummary {
 		Metadata:          unpackMetadata(raw.Metadata),
 		Addresses:         extractAddresses(raw.NetworkInterfaces...),
 		NetworkInterfaces: raw.NetworkInterfaces,
+		Labels:            raw.Labels,
+		LabelFingerprint:  raw.LabelFingerprint,
 	}
 }
 
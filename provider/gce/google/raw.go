@@ -425,6 +425,19 @@ func (rc *rawConn) SetMetadata(projectID, zone, instanceID string, metadata *com
 	return errors.Trace(err)
 }
 
+func (rc *rawConn) SetInstanceLabels(projectID, zone, instanceID, labelFingerprint string, labels map[string]string) error {
+	call := rc.Instances.SetLabels(projectID, zone, instanceID, &compute.InstancesSetLabelsRequest{
+		LabelFingerprint: labelFingerprint,
+		Labels:           labels,
+	})
+	op, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, op, attemptsLong, logOperationErrors)
+	return errors.Trace(err)
+}
+
 func (rc *rawConn) ListSubnetworks(projectID, region string) ([]*compute.Subnetwork, error) {
 	ctx := context.Background()
 	call := rc.Subnetworks.List(projectID, region)
@@ -506,6 +506,24 @@ func (rc *fakeConn) SetMetadata(projectID, zone, instanceID string, metadata *co
 	return err
 }
 
+func (rc *fakeConn) SetInstanceLabels(projectID, zone, instanceID, labelFingerprint string, labels map[string]string) error {
+	call := fakeCall{
+		FuncName:         "SetInstanceLabels",
+		ProjectID:        projectID,
+		ZoneName:         zone,
+		InstanceId:       instanceID,
+		LabelFingerprint: labelFingerprint,
+		Labels:           labels,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
 func (rc *fakeConn) ListNetworks(projectID string) ([]*compute.Network, error) {
 	call := fakeCall{
 		FuncName:  "ListNetworks",
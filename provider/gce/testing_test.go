@@ -578,6 +578,17 @@ func (fc *fakeConn) UpdateMetadata(key, value string, ids ...string) error {
 	return fc.err()
 }
 
+func (fc *fakeConn) SetInstanceLabels(zone, id, labelFingerprint string, labels map[string]string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:         "SetInstanceLabels",
+		ZoneName:         zone,
+		ID:               id,
+		LabelFingerprint: labelFingerprint,
+		Labels:           labels,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) IngressRules(fwname string) ([]network.IngressRule, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName:     "Ports",
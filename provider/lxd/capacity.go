@@ -0,0 +1,99 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	lxdapi "github.com/lxc/lxd/shared/api"
+
+	"github.com/juju/juju/environs"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/common"
+)
+
+var _ environs.CloudCapacityReporter = (*environProvider)(nil)
+
+// CloudCapacity is specified in the environs.CloudCapacityReporter
+// interface. For a LXD cluster, the capacity of each cluster member is
+// reported, and otherwise that of the single server.
+func (p *environProvider) CloudCapacity(
+	ctx context.ProviderCallContext, spec environscloudspec.CloudSpec,
+) ([]environs.ZoneCapacity, error) {
+	if err := p.validateCloudSpec(spec); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	server, err := p.serverFactory.RemoteServer(spec)
+	if err != nil {
+		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+		return nil, errors.Trace(err)
+	}
+	capacity, err := serverCapacity(server)
+	if err != nil {
+		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+		return nil, errors.Trace(err)
+	}
+	return capacity, nil
+}
+
+// serverCapacity returns the capacity of each member of the server's
+// cluster, or of the server itself if it is not clustered.
+func serverCapacity(server Server) ([]environs.ZoneCapacity, error) {
+	containers, err := server.AliveContainers("")
+	if err != nil {
+		return nil, errors.Annotate(err, "listing containers")
+	}
+
+	if !server.IsClustered() {
+		resources, err := server.GetServerResources()
+		if err != nil {
+			return nil, errors.Annotate(err, "getting server resources")
+		}
+		capacity := zoneCapacity(server.Name(), resources)
+		capacity.Available = true
+		capacity.Instances = len(containers)
+		return []environs.ZoneCapacity{capacity}, nil
+	}
+
+	members, err := server.GetClusterMembers()
+	if err != nil {
+		return nil, errors.Annotate(err, "listing cluster members")
+	}
+	instances := make(map[string]int)
+	for _, container := range containers {
+		instances[container.Location]++
+	}
+	result := make([]environs.ZoneCapacity, len(members))
+	for i, member := range members {
+		zone := &lxdAvailabilityZone{member}
+		// The resources of members which are offline can't be
+		// retrieved, but the members are still reported.
+		capacity := environs.ZoneCapacity{Zone: zone.Name()}
+		if zone.Available() {
+			resources, err := server.GetClusterMemberResources(zone.Name())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			capacity = zoneCapacity(zone.Name(), resources)
+			capacity.Available = true
+		}
+		capacity.Instances = instances[zone.Name()]
+		result[i] = capacity
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Zone < result[j].Zone
+	})
+	return result, nil
+}
+
+func zoneCapacity(name string, resources *lxdapi.Resources) environs.ZoneCapacity {
+	return environs.ZoneCapacity{
+		Zone:     name,
+		CpuCores: resources.CPU.Total,
+		Mem:      resources.Memory.Total / (1024 * 1024),
+		MemUsed:  resources.Memory.Used / (1024 * 1024),
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd/shared/api"
+	gc "gopkg.in/check.v1"
+
+	containerlxd "github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/lxd"
+)
+
+type capacitySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&capacitySuite{})
+
+func (s *capacitySuite) cloudCapacity(c *gc.C, svr lxd.Server) ([]environs.ZoneCapacity, error) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	factory := lxd.NewMockServerFactory(ctrl)
+	factory.EXPECT().RemoteServer(lxdCloudSpec()).Return(svr, nil)
+	provider := lxd.NewProviderWithMocks(nil, nil, factory, nil)
+
+	reporter, ok := provider.(environs.CloudCapacityReporter)
+	c.Assert(ok, jc.IsTrue)
+	return reporter.CloudCapacity(context.NewCloudCallContext(), lxdCloudSpec())
+}
+
+func resources(cores, memGiB, usedGiB uint64) *api.Resources {
+	return &api.Resources{
+		CPU:    api.ResourcesCPU{Total: cores},
+		Memory: api.ResourcesMemory{Total: memGiB << 30, Used: usedGiB << 30},
+	}
+}
+
+func (s *capacitySuite) TestCloudCapacityCluster(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	containers := []containerlxd.Container{
+		{Container: api.Container{Name: "juju-0", Location: "node02"}},
+		{Container: api.Container{Name: "juju-1", Location: "node02"}},
+		{Container: api.Container{Name: "other", Location: "node01"}},
+	}
+	members := []api.ClusterMember{
+		{ServerName: "node02", Status: "ONLINE"},
+		{ServerName: "node01", Status: "ONLINE"},
+		{ServerName: "node03", Status: "OFFLINE"},
+	}
+	exp := svr.EXPECT()
+	exp.AliveContainers("").Return(containers, nil)
+	exp.IsClustered().Return(true)
+	exp.GetClusterMembers().Return(members, nil)
+	exp.GetClusterMemberResources("node01").Return(resources(4, 8, 2), nil)
+	exp.GetClusterMemberResources("node02").Return(resources(8, 16, 12), nil)
+
+	capacity, err := s.cloudCapacity(c, svr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(capacity, jc.DeepEquals, []environs.ZoneCapacity{{
+		Zone:      "node01",
+		Available: true,
+		CpuCores:  4,
+		Mem:       8192,
+		MemUsed:   2048,
+		Instances: 1,
+	}, {
+		Zone:      "node02",
+		Available: true,
+		CpuCores:  8,
+		Mem:       16384,
+		MemUsed:   12288,
+		Instances: 2,
+	}, {
+		Zone: "node03",
+	}})
+}
+
+func (s *capacitySuite) TestCloudCapacityNotClustered(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	exp := svr.EXPECT()
+	exp.AliveContainers("").Return([]containerlxd.Container{{}}, nil)
+	exp.IsClustered().Return(false)
+	exp.GetServerResources().Return(resources(2, 4, 1), nil)
+	exp.Name().Return("server")
+
+	capacity, err := s.cloudCapacity(c, svr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(capacity, jc.DeepEquals, []environs.ZoneCapacity{{
+		Zone:      "server",
+		Available: true,
+		CpuCores:  2,
+		Mem:       4096,
+		MemUsed:   1024,
+		Instances: 1,
+	}})
+}
+
+func (s *capacitySuite) TestCloudCapacityMemberError(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	exp := svr.EXPECT()
+	exp.AliveContainers("").Return(nil, nil)
+	exp.IsClustered().Return(true)
+	exp.GetClusterMembers().Return([]api.ClusterMember{{ServerName: "node01", Status: "ONLINE"}}, nil)
+	exp.GetClusterMemberResources("node01").Return(nil, errors.New("boom"))
+
+	_, err := s.cloudCapacity(c, svr)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *capacitySuite) TestCloudCapacityInvalidCloudSpec(c *gc.C) {
+	provider := lxd.NewProviderWithMocks(nil, nil, nil, nil)
+	spec := lxdCloudSpec()
+	spec.Credential = nil
+	_, err := provider.(environs.CloudCapacityReporter).CloudCapacity(context.NewCloudCallContext(), spec)
+	c.Assert(err, gc.ErrorMatches, "validating cloud spec: missing credential not valid")
+}
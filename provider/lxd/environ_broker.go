@@ -245,6 +245,10 @@ func (env *environ) getContainerSpec(
 // getTargetServer checks to see if a valid zone was passed as a placement
 // directive in the start-up start-up arguments. If so, a server for the
 // specific node is returned.
+// Otherwise, if the server is clustered, a server for the node of the
+// availability zone chosen by the provisioner is returned. The provisioner
+// spreads the machines of an application's units across zones, so this
+// keeps replicas of an application on different cluster members.
 func (env *environ) getTargetServer(
 	ctx context.ProviderCallContext, args environs.StartInstanceParams,
 ) (Server, error) {
@@ -253,10 +257,14 @@ func (env *environ) getTargetServer(
 		return nil, errors.Trace(err)
 	}
 
-	if p.nodeName == "" {
+	nodeName := p.nodeName
+	if nodeName == "" && args.AvailabilityZone != "" && env.server().IsClustered() {
+		nodeName = args.AvailabilityZone
+	}
+	if nodeName == "" {
 		return env.server(), nil
 	}
-	return env.server().UseTargetServer(p.nodeName)
+	return env.server().UseTargetServer(nodeName)
 }
 
 type lxdPlacement struct {
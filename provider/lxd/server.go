@@ -77,6 +77,8 @@ type Server interface {
 	IsClustered() bool
 	UseTargetServer(name string) (*lxd.Server, error)
 	GetClusterMembers() (members []lxdapi.ClusterMember, err error)
+	GetClusterMemberResources(name string) (*lxdapi.Resources, error)
+	GetServerResources() (*lxdapi.Resources, error)
 	Name() string
 	GetNetworkNames() ([]string, error)
 	GetNetworkState(name string) (*lxdapi.NetworkState, error)
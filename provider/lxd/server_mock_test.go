@@ -288,6 +288,21 @@ func (mr *MockServerMockRecorder) GetCertificate(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertificate", reflect.TypeOf((*MockServer)(nil).GetCertificate), arg0)
 }
 
+// GetClusterMemberResources mocks base method
+func (m *MockServer) GetClusterMemberResources(arg0 string) (*api.Resources, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClusterMemberResources", arg0)
+	ret0, _ := ret[0].(*api.Resources)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClusterMemberResources indicates an expected call of GetClusterMemberResources
+func (mr *MockServerMockRecorder) GetClusterMemberResources(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterMemberResources", reflect.TypeOf((*MockServer)(nil).GetClusterMemberResources), arg0)
+}
+
 // GetClusterMembers mocks base method
 func (m *MockServer) GetClusterMembers() ([]api.ClusterMember, error) {
 	m.ctrl.T.Helper()
@@ -442,6 +457,21 @@ func (mr *MockServerMockRecorder) GetServer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServer", reflect.TypeOf((*MockServer)(nil).GetServer))
 }
 
+// GetServerResources mocks base method
+func (m *MockServer) GetServerResources() (*api.Resources, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServerResources")
+	ret0, _ := ret[0].(*api.Resources)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServerResources indicates an expected call of GetServerResources
+func (mr *MockServerMockRecorder) GetServerResources() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServerResources", reflect.TypeOf((*MockServer)(nil).GetServerResources))
+}
+
 // GetStoragePool mocks base method
 func (m *MockServer) GetStoragePool(arg0 string) (*api.StoragePool, string, error) {
 	m.ctrl.T.Helper()
@@ -737,6 +737,14 @@ func (conn *StubClient) GetClusterMembers() (members []api.ClusterMember, err er
 	return nil, conn.NextErr()
 }
 
+func (*StubClient) GetClusterMemberResources(string) (*api.Resources, error) {
+	panic("this stub is deprecated; use mocks instead")
+}
+
+func (*StubClient) GetServerResources() (*api.Resources, error) {
+	panic("this stub is deprecated; use mocks instead")
+}
+
 type MockClock struct {
 	clock.Clock
 	now time.Time
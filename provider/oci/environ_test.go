@@ -410,11 +410,11 @@ func (e *environSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	validator, err := e.env.ConstraintsValidator(envcontext.NewCloudCallContext())
 	c.Assert(err, jc.ErrorIsNil)
 
-	cons := constraints.MustParse("arch=amd64 tags=foo virt-type=kvm")
+	cons := constraints.MustParse("arch=amd64 tags=foo virt-type=kvm spot-max-price=0.05")
 	unsupported, err := validator.Validate(cons)
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(unsupported, jc.SameContents, []string{"tags", "virt-type"})
+	c.Check(unsupported, jc.SameContents, []string{"tags", "virt-type", "spot-max-price"})
 }
 
 func (e *environSuite) TestConstraintsValidatorWrongArch(c *gc.C) {
@@ -2696,6 +2696,26 @@ func (s *localServerSuite) TestTagInstance(c *gc.C) {
 	assertMetadata(extraKey, extraValue)
 }
 
+func (s *localServerSuite) TestTagVolume(c *gc.C) {
+	err := bootstrapEnv(c, s.env)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := addVolume(c, s.env, s.callCtx, coretesting.ControllerTag.Id(), "123")
+
+	err = s.env.(environs.VolumeTagger).TagVolume(
+		s.callCtx,
+		volume.VolumeId,
+		map[string]string{"extra-k": "extra-v"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stor, err := (*openstack.NewOpenstackStorage)(s.env.(*openstack.Environ))
+	c.Assert(err, jc.ErrorIsNil)
+	details, err := stor.GetVolume(volume.VolumeId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(details.Metadata["extra-k"], gc.Equals, "extra-v")
+	c.Check(details.Metadata[tags.JujuController], gc.Equals, coretesting.ControllerTag.Id())
+}
+
 func (s *localServerSuite) TestAdoptResources(c *gc.C) {
 	err := bootstrapEnv(c, s.env)
 	c.Assert(err, jc.ErrorIsNil)
@@ -348,6 +348,7 @@ var _ environs.NetworkingEnviron = (*Environ)(nil)
 var _ simplestreams.HasRegion = (*Environ)(nil)
 var _ context.Distributor = (*Environ)(nil)
 var _ environs.InstanceTagger = (*Environ)(nil)
+var _ environs.VolumeTagger = (*Environ)(nil)
 
 type openstackInstance struct {
 	e        *Environ
@@ -572,6 +573,7 @@ func (e *Environ) neutron() *neutron.Client {
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.CpuPower,
+	constraints.SpotMaxPrice,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -2203,6 +2205,19 @@ func (e *Environ) TagInstance(ctx context.ProviderCallContext, id instance.Id, t
 	return nil
 }
 
+// TagVolume implements environs.VolumeTagger.
+func (e *Environ) TagVolume(ctx context.ProviderCallContext, volumeId string, tags map[string]string) error {
+	storageAdapter, err := newOpenstackStorage(e)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := storageAdapter.SetVolumeMetadata(volumeId, tags); err != nil {
+		handleCredentialError(err, ctx)
+		return errors.Annotate(err, "setting volume metadata")
+	}
+	return nil
+}
+
 func (e *Environ) SetClock(clock clock.Clock) {
 	e.clock = clock
 }
@@ -0,0 +1,68 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmox
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+	"github.com/juju/juju/provider/proxmox/internal/proxmoxclient"
+)
+
+// Client is the interface to the Proxmox VE API used by the provider.
+type Client interface {
+	Ping(context.Context) error
+	Nodes(context.Context) ([]proxmoxclient.Node, error)
+	VirtualMachines(context.Context) ([]proxmoxclient.VirtualMachine, error)
+	VirtualMachineConfig(ctx context.Context, node string, id int) (proxmoxclient.VirtualMachineConfig, error)
+	SetVirtualMachineConfig(ctx context.Context, node string, id int, config url.Values) error
+	NextID(context.Context) (int, error)
+	CloneVirtualMachine(context.Context, proxmoxclient.CloneParams) error
+	ResizeDisk(ctx context.Context, node string, id int, disk string, sizeMiB uint64) error
+	StartVirtualMachine(ctx context.Context, node string, id int) error
+	StopVirtualMachine(ctx context.Context, node string, id int) error
+	DeleteVirtualMachine(ctx context.Context, node string, id int) error
+	UploadISO(ctx context.Context, node, storage, filename string, data []byte) error
+	DeleteVolume(ctx context.Context, node, volumeID string) error
+	GuestAddresses(ctx context.Context, node string, id int) ([]string, error)
+}
+
+// NewClientFunc is the type of a function that returns a Client
+// for the cloud, authenticating with the given credentials.
+type NewClientFunc func(endpoint, username, password string, caCertificates []string, clock clock.Clock) (Client, error)
+
+func newClient(endpoint, username, password string, caCertificates []string, clock clock.Clock) (Client, error) {
+	client, err := proxmoxclient.New(proxmoxclient.Config{
+		Endpoint:       endpoint,
+		Username:       username,
+		Password:       password,
+		CACertificates: caCertificates,
+		Clock:          clock,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return client, nil
+}
+
+func (p *environProvider) cloudClient(spec environscloudspec.CloudSpec) (Client, error) {
+	attrs := spec.Credential.Attributes()
+	return p.newClient(
+		spec.Endpoint,
+		attrs[credAttrUser],
+		attrs[credAttrPassword],
+		spec.CACertificates,
+		p.clock,
+	)
+}
+
+// isAuthorisationFailure returns true if the error is caused by
+// invalid credentials, or insufficient permissions.
+func isAuthorisationFailure(err error) bool {
+	return proxmoxclient.IsAuthorisationFailure(err)
+}
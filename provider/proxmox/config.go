@@ -0,0 +1,169 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmox
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/environs/config"
+)
+
+// The proxmox-specific config keys.
+const (
+	cfgTemplatePrefix = "template-prefix"
+	cfgDiskStorage    = "disk-storage"
+	cfgISOStorage     = "iso-storage"
+)
+
+var configSchema = environschema.Fields{
+	cfgTemplatePrefix: {
+		Description: `The prefix of the names of the templates to clone. The series follows the prefix, e.g. "juju-focal".`,
+		Type:        environschema.Tstring,
+	},
+	cfgDiskStorage: {
+		Description: "The storage to create machine disks and volumes on. The storage of each template's disks is used if it isn't set.",
+		Type:        environschema.Tstring,
+	},
+	cfgISOStorage: {
+		Description: "The storage to upload cloud-init seed images to, on the node each machine is created on. It must support ISO images.",
+		Type:        environschema.Tstring,
+	},
+}
+
+var (
+	configFields = func() schema.Fields {
+		fs, _, err := configSchema.ValidationSchema()
+		if err != nil {
+			panic(err)
+		}
+		return fs
+	}()
+
+	configDefaults = schema.Defaults{
+		cfgTemplatePrefix: "juju-",
+		cfgDiskStorage:    schema.Omit,
+		cfgISOStorage:     "local",
+	}
+
+	configRequiredFields  = []string{cfgTemplatePrefix, cfgISOStorage}
+	configImmutableFields = []string{}
+)
+
+// Schema returns the configuration schema for an environment.
+func (*environProvider) Schema() environschema.Fields {
+	fields, err := config.Schema(configSchema)
+	if err != nil {
+		panic(err)
+	}
+	return fields
+}
+
+// ConfigSchema returns extra config attributes specific
+// to this provider only.
+func (*environProvider) ConfigSchema() schema.Fields {
+	return configFields
+}
+
+// ConfigDefaults returns the default values for the
+// provider specific config attributes.
+func (*environProvider) ConfigDefaults() schema.Defaults {
+	return configDefaults
+}
+
+type environConfig struct {
+	*config.Config
+	attrs map[string]interface{}
+}
+
+// newConfig builds a new environConfig from the provided Config and
+// returns it.
+func newConfig(cfg *config.Config) *environConfig {
+	return &environConfig{
+		Config: cfg,
+		attrs:  cfg.UnknownAttrs(),
+	}
+}
+
+// newValidConfig builds a new environConfig from the provided Config
+// and returns it. The resulting config values are validated.
+func newValidConfig(cfg *config.Config) (*environConfig, error) {
+	// Ensure that the provided config is valid.
+	if err := config.Validate(cfg, nil); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Apply the defaults and coerce/validate the custom config attrs.
+	validated, err := cfg.ValidateUnknownAttrs(configFields, configDefaults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	validCfg, err := cfg.Apply(validated)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Build the config.
+	ecfg := newConfig(validCfg)
+
+	// Do final validation.
+	if err := ecfg.validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return ecfg, nil
+}
+
+func (c *environConfig) templatePrefix() string {
+	return c.attrs[cfgTemplatePrefix].(string)
+}
+
+func (c *environConfig) diskStorage() string {
+	storage, _ := c.attrs[cfgDiskStorage].(string)
+	return storage
+}
+
+func (c *environConfig) isoStorage() string {
+	return c.attrs[cfgISOStorage].(string)
+}
+
+// validate checks proxmox-specific config values.
+func (c environConfig) validate() error {
+	// All fields must be populated, even with just the default.
+	for _, field := range configRequiredFields {
+		if c.attrs[field].(string) == "" {
+			return errors.Errorf("%s: must not be empty", field)
+		}
+	}
+	return nil
+}
+
+// update applies changes from the provided config to the env config.
+// Changes to any immutable attributes result in an error.
+func (c *environConfig) update(cfg *config.Config) error {
+	// Validate the updates. newValidConfig does not modify the "known"
+	// config attributes so it is safe to call Validate here first.
+	if err := config.Validate(cfg, c.Config); err != nil {
+		return errors.Trace(err)
+	}
+
+	updates, err := newValidConfig(cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Check that no immutable fields have changed.
+	attrs := updates.UnknownAttrs()
+	for _, field := range configImmutableFields {
+		if attrs[field] != c.attrs[field] {
+			return errors.Errorf("%s: cannot change from %v to %v", field, c.attrs[field], attrs[field])
+		}
+	}
+
+	// Apply the updates.
+	c.Config = updates.Config
+	c.attrs = updates.attrs
+	return nil
+}
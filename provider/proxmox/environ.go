@@ -0,0 +1,252 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmox
+
+import (
+	stdcontext "context"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+	"github.com/juju/juju/environs/config"
+	callcontext "github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/proxmox/internal/proxmoxclient"
+)
+
+type environ struct {
+	name     string
+	cloud    environscloudspec.CloudSpec
+	provider *environProvider
+	client   Client
+
+	// namespace is used to create the machine hostnames,
+	// which are also the names of the virtual machines.
+	namespace instance.Namespace
+
+	lock sync.Mutex // lock protects access the following fields.
+	ecfg *environConfig
+}
+
+var _ environs.Environ = (*environ)(nil)
+var _ common.ZonedEnviron = (*environ)(nil)
+
+func newEnviron(
+	provider *environProvider,
+	cloud environscloudspec.CloudSpec,
+	cfg *config.Config,
+) (*environ, error) {
+	ecfg, err := newValidConfig(cfg)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
+
+	namespace, err := instance.NewNamespace(cfg.UUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	client, err := provider.cloudClient(cloud)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	env := &environ{
+		name:      ecfg.Name(),
+		cloud:     cloud,
+		provider:  provider,
+		client:    client,
+		ecfg:      ecfg,
+		namespace: namespace,
+	}
+	return env, nil
+}
+
+// handleCredentialError marks the cloud credential as invalid if the
+// error is caused by the credential being rejected.
+func (env *environ) handleCredentialError(err error, ctx callcontext.ProviderCallContext) {
+	common.HandleCredentialError(isAuthorisationFailure, err, ctx)
+}
+
+// Name is part of the environs.Environ interface.
+func (env *environ) Name() string {
+	return env.name
+}
+
+// Provider is part of the environs.Environ interface.
+func (env *environ) Provider() environs.EnvironProvider {
+	return env.provider
+}
+
+// SetConfig is part of the environs.Environ interface.
+func (env *environ) SetConfig(cfg *config.Config) error {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+
+	if env.ecfg == nil {
+		return errors.New("cannot set config on uninitialized env")
+	}
+
+	if err := env.ecfg.update(cfg); err != nil {
+		return errors.Annotate(err, "invalid config change")
+	}
+	return nil
+}
+
+// Config is part of the environs.Environ interface.
+func (env *environ) Config() *config.Config {
+	return env.environConfig().Config
+}
+
+func (env *environ) environConfig() *environConfig {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+	return env.ecfg
+}
+
+// PrepareForBootstrap implements environs.Environ.
+func (env *environ) PrepareForBootstrap(ctx environs.BootstrapContext, controllerName string) error {
+	return nil
+}
+
+// Create implements environs.Environ.
+func (env *environ) Create(ctx callcontext.ProviderCallContext, args environs.CreateParams) error {
+	// Check that the credentials are valid.
+	_, err := env.client.Nodes(stdcontext.Background())
+	env.handleCredentialError(err, ctx)
+	return errors.Trace(err)
+}
+
+// Bootstrap is exported, because it has to be rewritten in external unit tests
+var Bootstrap = common.Bootstrap
+
+// Bootstrap is part of the environs.Environ interface.
+func (env *environ) Bootstrap(
+	ctx environs.BootstrapContext,
+	callCtx callcontext.ProviderCallContext,
+	args environs.BootstrapParams,
+) (*environs.BootstrapResult, error) {
+	return Bootstrap(ctx, env, callCtx, args)
+}
+
+// DestroyEnv is exported, because it has to be rewritten in external unit tests.
+var DestroyEnv = common.Destroy
+
+// Destroy is part of the environs.Environ interface.
+func (env *environ) Destroy(ctx callcontext.ProviderCallContext) error {
+	return errors.Trace(DestroyEnv(env, ctx))
+}
+
+// DestroyController implements the Environ interface.
+func (env *environ) DestroyController(ctx callcontext.ProviderCallContext, controllerUUID string) error {
+	if err := env.Destroy(ctx); err != nil {
+		return errors.Trace(err)
+	}
+	// Destroy the virtual machines of the controller's hosted models.
+	vms, err := env.taggedVirtualMachines(ctx, jujuPrefix, func(vmTags map[string]string) bool {
+		return vmTags[tags.JujuController] == controllerUUID
+	})
+	if err != nil {
+		return errors.Annotate(err, "listing hosted model machines")
+	}
+	hosted := make([]proxmoxclient.VirtualMachine, len(vms))
+	for i, vm := range vms {
+		hosted[i] = vm.VirtualMachine
+	}
+	return errors.Trace(env.destroyVirtualMachines(ctx, hosted))
+}
+
+// AdoptResources is part of the Environ interface.
+func (env *environ) AdoptResources(ctx callcontext.ProviderCallContext, controllerUUID string, fromVersion version.Number) error {
+	vms, err := env.taggedVirtualMachines(ctx, env.namespace.Prefix(), nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var failed []string
+	for _, vm := range vms {
+		vmTags := vm.tags()
+		if vmTags[tags.JujuController] == controllerUUID {
+			continue
+		}
+		vmTags[tags.JujuController] = controllerUUID
+		if err := env.setDescription(ctx, vm.VirtualMachine, vmTags); err != nil {
+			logger.Errorf("updating controller tag of %q: %v", vm.Name, err)
+			failed = append(failed, vm.Name)
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("failed to update controller for some instances: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// jujuPrefix is the prefix of the names of all virtual
+// machines created by Juju, whichever model they're in.
+const jujuPrefix = "juju-"
+
+// virtualMachines returns the virtual machines, excluding templates,
+// whose names start with the given prefix.
+func (env *environ) virtualMachines(ctx callcontext.ProviderCallContext, prefix string) ([]proxmoxclient.VirtualMachine, error) {
+	all, err := env.client.VirtualMachines(stdcontext.Background())
+	if err != nil {
+		env.handleCredentialError(err, ctx)
+		return nil, errors.Trace(err)
+	}
+	var vms []proxmoxclient.VirtualMachine
+	for _, vm := range all {
+		if !vm.IsTemplate() && strings.HasPrefix(vm.Name, prefix) {
+			vms = append(vms, vm)
+		}
+	}
+	return vms, nil
+}
+
+// configuredVirtualMachine is a virtual machine with its configuration.
+type configuredVirtualMachine struct {
+	proxmoxclient.VirtualMachine
+	config proxmoxclient.VirtualMachineConfig
+}
+
+func (vm configuredVirtualMachine) tags() map[string]string {
+	return parseTags(vm.config[descriptionKey])
+}
+
+// taggedVirtualMachines returns the virtual machines whose names start
+// with the given prefix, and whose tags are accepted by the given
+// function, or all of them if it's nil.
+func (env *environ) taggedVirtualMachines(
+	ctx callcontext.ProviderCallContext, prefix string, accept func(map[string]string) bool,
+) ([]configuredVirtualMachine, error) {
+	vms, err := env.virtualMachines(ctx, prefix)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results []configuredVirtualMachine
+	for _, vm := range vms {
+		config, err := env.client.VirtualMachineConfig(stdcontext.Background(), vm.Node, vm.ID)
+		if err != nil {
+			env.handleCredentialError(err, ctx)
+			return nil, errors.Trace(err)
+		}
+		result := configuredVirtualMachine{vm, config}
+		if accept == nil || accept(result.tags()) {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+func (env *environ) setDescription(ctx callcontext.ProviderCallContext, vm proxmoxclient.VirtualMachine, vmTags map[string]string) error {
+	err := env.client.SetVirtualMachineConfig(stdcontext.Background(), vm.Node, vm.ID, map[string][]string{
+		descriptionKey: {formatTags(vmTags)},
+	})
+	env.handleCredentialError(err, ctx)
+	return errors.Trace(err)
+}
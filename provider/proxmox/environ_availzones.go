@@ -0,0 +1,95 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmox
+
+import (
+	stdcontext "context"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+// proxmoxAvailZone is an availability zone, which is a node of the
+// cluster.
+type proxmoxAvailZone struct {
+	name   string
+	online bool
+}
+
+// Name implements network.AvailabilityZone.
+func (z *proxmoxAvailZone) Name() string {
+	return z.name
+}
+
+// Available implements network.AvailabilityZone.
+func (z *proxmoxAvailZone) Available() bool {
+	return z.online
+}
+
+// AvailabilityZones is part of the common.ZonedEnviron interface.
+func (env *environ) AvailabilityZones(ctx context.ProviderCallContext) (network.AvailabilityZones, error) {
+	nodes, err := env.client.Nodes(stdcontext.Background())
+	if err != nil {
+		env.handleCredentialError(err, ctx)
+		return nil, errors.Trace(err)
+	}
+	zones := make(network.AvailabilityZones, len(nodes))
+	for i, node := range nodes {
+		zones[i] = &proxmoxAvailZone{
+			name:   node.Name,
+			online: node.Online(),
+		}
+	}
+	return zones, nil
+}
+
+// InstanceAvailabilityZoneNames is part of the common.ZonedEnviron interface.
+func (env *environ) InstanceAvailabilityZoneNames(ctx context.ProviderCallContext, ids []instance.Id) ([]string, error) {
+	instances, err := env.Instances(ctx, ids)
+	switch err {
+	case nil, environs.ErrPartialInstances:
+		break
+	case environs.ErrNoInstances:
+		return nil, err
+	default:
+		return nil, errors.Trace(err)
+	}
+
+	results := make([]string, len(ids))
+	for i, inst := range instances {
+		if inst != nil {
+			results[i] = inst.(*environInstance).vm.Node
+		}
+	}
+	return results, err
+}
+
+// DeriveAvailabilityZones is part of the common.ZonedEnviron interface.
+func (env *environ) DeriveAvailabilityZones(ctx context.ProviderCallContext, args environs.StartInstanceParams) ([]string, error) {
+	if args.Placement != "" {
+		placement, err := env.parsePlacement(ctx, args.Placement)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []string{placement.Name()}, nil
+	}
+	return nil, nil
+}
+
+func (env *environ) availZone(ctx context.ProviderCallContext, name string) (*proxmoxAvailZone, error) {
+	zones, err := env.AvailabilityZones(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, z := range zones {
+		if z.Name() == name {
+			return z.(*proxmoxAvailZone), nil
+		}
+	}
+	return nil, errors.NotFoundf("availability zone %q", name)
+}
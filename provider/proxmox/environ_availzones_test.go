@@ -0,0 +1,86 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmox_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/common"
+)
+
+type environAvailzonesSuite struct {
+	EnvironFixture
+}
+
+var _ = gc.Suite(&environAvailzonesSuite{})
+
+func (s *environAvailzonesSuite) TestAvailabilityZones(c *gc.C) {
+	s.server.SetNodeOnline("pve2", false)
+	zonedEnviron := s.env.(common.ZonedEnviron)
+	zones, err := zonedEnviron.AvailabilityZones(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.HasLen, 2)
+	c.Assert(zones[0].Name(), gc.Equals, "pve1")
+	c.Assert(zones[0].Available(), jc.IsTrue)
+	c.Assert(zones[1].Name(), gc.Equals, "pve2")
+	c.Assert(zones[1].Available(), jc.IsFalse)
+}
+
+func (s *environAvailzonesSuite) TestInstanceAvailabilityZoneNames(c *gc.C) {
+	s.server.AddTemplate("pve1", "juju-focal")
+	_, err := s.env.StartInstance(s.callCtx, startInstanceParams(c, "0"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	zonedEnviron := s.env.(common.ZonedEnviron)
+	names, err := zonedEnviron.InstanceAvailabilityZoneNames(s.callCtx, []instance.Id{"juju-f75cba-0", "juju-f75cba-1"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(names, jc.DeepEquals, []string{"pve2", ""})
+}
+
+func (s *environAvailzonesSuite) TestDeriveAvailabilityZones(c *gc.C) {
+	zonedEnviron := s.env.(common.ZonedEnviron)
+	zones, err := zonedEnviron.DeriveAvailabilityZones(s.callCtx, environs.StartInstanceParams{Placement: "zone=pve2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, jc.DeepEquals, []string{"pve2"})
+
+	_, err = zonedEnviron.DeriveAvailabilityZones(s.callCtx, environs.StartInstanceParams{Placement: "zone=pve3"})
+	c.Assert(err, gc.ErrorMatches, `availability zone "pve3" not found`)
+}
+
+type environPolicySuite struct {
+	EnvironFixture
+}
+
+var _ = gc.Suite(&environPolicySuite{})
+
+func (s *environPolicySuite) TestPrecheckInstance(c *gc.C) {
+	err := s.env.PrecheckInstance(s.callCtx, environs.PrecheckInstanceParams{
+		Series:    "focal",
+		Placement: "zone=pve1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.env.PrecheckInstance(s.callCtx, environs.PrecheckInstanceParams{Placement: "host=pve1"})
+	c.Assert(err, gc.ErrorMatches, `unknown placement directive: host=pve1`)
+
+	cons := constraints.MustParse("zones=pve1,pve3")
+	err = s.env.PrecheckInstance(s.callCtx, environs.PrecheckInstanceParams{Constraints: cons})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *environPolicySuite) TestConstraintsValidator(c *gc.C) {
+	validator, err := s.env.ConstraintsValidator(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	unsupported, err := validator.Validate(constraints.MustParse("arch=amd64 cores=2 mem=1G instance-type=large tags=foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, jc.SameContents, []string{"instance-type", "tags"})
+
+	_, err = validator.Validate(constraints.MustParse("arch=arm64"))
+	c.Assert(err, gc.ErrorMatches, `invalid constraint value: arch=arm64\nvalid values are: \[amd64\]`)
+}
@@ -0,0 +1,476 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmox
+
+import (
+	stdcontext "context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/cloudconfig/providerinit"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/proxmox/internal/cidata"
+	"github.com/juju/juju/provider/proxmox/internal/proxmoxclient"
+	"github.com/juju/juju/tools"
+)
+
+const (
+	// cloneAttempts is the number of times cloning a template is
+	// attempted, since the next free ID may be taken by a concurrent
+	// clone before this one starts.
+	cloneAttempts = 5
+
+	// seedSuffix is the suffix of the names of the cloud-init seed
+	// images, which are prefixed by the virtual machine name.
+	seedSuffix = "-cidata.iso"
+)
+
+// MaintainInstance is specified in the InstanceBroker interface.
+func (*environ) MaintainInstance(ctx context.ProviderCallContext, args environs.StartInstanceParams) error {
+	return nil
+}
+
+// StartInstance implements environs.InstanceBroker.
+func (env *environ) StartInstance(ctx context.ProviderCallContext, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	ecfg := env.environConfig()
+	series := args.InstanceConfig.Series
+	template, err := env.findTemplate(ctx, ecfg.templatePrefix()+series, args.AvailabilityZone)
+	if err != nil {
+		return nil, common.ZoneIndependentError(err)
+	}
+	if err := env.finishInstanceConfig(args, ecfg); err != nil {
+		return nil, common.ZoneIndependentError(err)
+	}
+
+	vm, hw, err := env.newRawInstance(ctx, args, template, ecfg)
+	if err != nil {
+		args.StatusCallback(status.ProvisioningError, fmt.Sprint(err), nil)
+		return nil, errors.Trace(err)
+	}
+
+	volumes, attachments, err := env.createInstanceVolumes(ctx, vm, args.Volumes)
+	if err != nil {
+		if destroyErr := env.destroyVirtualMachine(ctx, vm); destroyErr != nil {
+			logger.Errorf("cleaning up virtual machine %q: %v", vm.Name, destroyErr)
+		}
+		args.StatusCallback(status.ProvisioningError, fmt.Sprint(err), nil)
+		return nil, errors.Trace(err)
+	}
+
+	logger.Infof("started instance %q on node %q", vm.Name, vm.Node)
+	return &environs.StartInstanceResult{
+		Instance:          newInstance(vm, env),
+		Hardware:          hw,
+		Volumes:           volumes,
+		VolumeAttachments: attachments,
+	}, nil
+}
+
+// FinishInstanceConfig is exported, because it has to be rewritten in external unit tests
+var FinishInstanceConfig = instancecfg.FinishInstanceConfig
+
+// finishInstanceConfig updates args.InstanceConfig in place. Setting up
+// the API, StateServing, and SSHkeys information.
+func (env *environ) finishInstanceConfig(args environs.StartInstanceParams, ecfg *environConfig) error {
+	// Templates are only looked up by series, so they're assumed to
+	// be amd64 images, which is all Proxmox VE supports.
+	envTools, err := args.Tools.Match(tools.Filter{Arch: arch.AMD64})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := args.InstanceConfig.SetTools(envTools); err != nil {
+		return errors.Trace(err)
+	}
+	return FinishInstanceConfig(args.InstanceConfig, ecfg.Config)
+}
+
+// findTemplate returns the template with the given name, preferring
+// one on the given node, so that the clone doesn't need to be copied
+// between nodes.
+func (env *environ) findTemplate(ctx context.ProviderCallContext, name, node string) (proxmoxclient.VirtualMachine, error) {
+	vms, err := env.client.VirtualMachines(stdcontext.Background())
+	if err != nil {
+		env.handleCredentialError(err, ctx)
+		return proxmoxclient.VirtualMachine{}, errors.Trace(err)
+	}
+	var found *proxmoxclient.VirtualMachine
+	for i, vm := range vms {
+		if !vm.IsTemplate() || vm.Name != name {
+			continue
+		}
+		if found == nil || vm.Node == node {
+			found = &vms[i]
+		}
+	}
+	if found == nil {
+		return proxmoxclient.VirtualMachine{}, errors.NotFoundf("template %q", name)
+	}
+	return *found, nil
+}
+
+// newRawInstance clones the template to create the virtual machine
+// for the instance, and starts it.
+func (env *environ) newRawInstance(
+	ctx context.ProviderCallContext,
+	args environs.StartInstanceParams,
+	template proxmoxclient.VirtualMachine,
+	ecfg *environConfig,
+) (_ proxmoxclient.VirtualMachine, _ *instance.HardwareCharacteristics, err error) {
+	vmName, err := env.namespace.Hostname(args.InstanceConfig.MachineId)
+	if err != nil {
+		return proxmoxclient.VirtualMachine{}, nil, common.ZoneIndependentError(err)
+	}
+	node := args.AvailabilityZone
+	if node == "" {
+		node = template.Node
+	}
+	stdctx := stdcontext.Background()
+
+	cloudcfg, err := cloudinit.New(args.InstanceConfig.Series)
+	if err != nil {
+		return proxmoxclient.VirtualMachine{}, nil, common.ZoneIndependentError(err)
+	}
+	// The guest agent reports the addresses of the machine.
+	cloudcfg.AddPackage("qemu-guest-agent")
+	cloudcfg.AddRunCmd("systemctl start qemu-guest-agent")
+	// Make sure the hostname is resolvable by adding it to /etc/hosts.
+	cloudcfg.ManageEtcHosts(true)
+	userData, err := providerinit.ComposeUserData(args.InstanceConfig, cloudcfg, ProxmoxRenderer{})
+	if err != nil {
+		return proxmoxclient.VirtualMachine{}, nil, common.ZoneIndependentError(
+			errors.Annotate(err, "cannot make user data"),
+		)
+	}
+	logger.Debugf("Proxmox user data; %d bytes", len(userData))
+	metaData := fmt.Sprintf("instance-id: %s\nlocal-hostname: %s\n", vmName, vmName)
+
+	// The seed image is uploaded to the node the virtual machine is
+	// created on, so that it can be on the node's local storage.
+	seedName := vmName + seedSuffix
+	seed := cidata.NewImage(userData, []byte(metaData))
+	if err := env.client.UploadISO(stdctx, node, ecfg.isoStorage(), seedName, seed); err != nil {
+		env.handleCredentialError(err, ctx)
+		return proxmoxclient.VirtualMachine{}, nil, errors.Trace(err)
+	}
+	seedVolume := fmt.Sprintf("%s:iso/%s", ecfg.isoStorage(), seedName)
+
+	// Obtain the final constraints by merging with the template.
+	cons := args.Constraints
+	cores := uint64(template.MaxCPU)
+	if cons.CpuCores != nil && *cons.CpuCores > 0 {
+		cores = *cons.CpuCores
+	}
+	mem := template.MaxMem / (1024 * 1024)
+	if cons.Mem != nil && *cons.Mem > 0 {
+		mem = *cons.Mem
+	}
+	rootDisk := common.MinRootDiskSizeGiB(args.InstanceConfig.Series) * 1024
+	if cons.RootDisk != nil && *cons.RootDisk > rootDisk {
+		rootDisk = *cons.RootDisk
+	}
+	diskStorage := ecfg.diskStorage()
+	if cons.RootDiskSource != nil && *cons.RootDiskSource != "" {
+		diskStorage = *cons.RootDiskSource
+	}
+
+	vm := proxmoxclient.VirtualMachine{Name: vmName, Node: node}
+	for attempt := 1; ; attempt++ {
+		if vm.ID, err = env.client.NextID(stdctx); err != nil {
+			env.handleCredentialError(err, ctx)
+			break
+		}
+		err = env.client.CloneVirtualMachine(stdctx, proxmoxclient.CloneParams{
+			Template:   template,
+			ID:         vm.ID,
+			Name:       vmName,
+			TargetNode: node,
+			Storage:    diskStorage,
+		})
+		if err == nil || !isAlreadyExists(err) || attempt == cloneAttempts {
+			env.handleCredentialError(err, ctx)
+			break
+		}
+		logger.Debugf("virtual machine ID %d was taken, retrying", vm.ID)
+	}
+	if err != nil {
+		env.deleteSeed(ctx, node, seedVolume)
+		return proxmoxclient.VirtualMachine{}, nil, errors.Trace(err)
+	}
+	seedAttached := false
+	defer func() {
+		if err == nil {
+			return
+		}
+		// Destroying the virtual machine only deletes
+		// the seed image once it's attached.
+		if destroyErr := env.destroyVirtualMachine(ctx, vm); destroyErr != nil {
+			logger.Errorf("cleaning up virtual machine %q: %v", vmName, destroyErr)
+		} else if !seedAttached {
+			env.deleteSeed(ctx, node, seedVolume)
+		}
+	}()
+
+	config, err := env.client.VirtualMachineConfig(stdctx, node, vm.ID)
+	if err != nil {
+		env.handleCredentialError(err, ctx)
+		return proxmoxclient.VirtualMachine{}, nil, errors.Trace(err)
+	}
+	seedDrive, deletes := seedDrive(config)
+	values := url.Values{
+		"cores":        {strconv.FormatUint(cores, 10)},
+		"memory":       {strconv.FormatUint(mem, 10)},
+		"agent":        {"1"},
+		descriptionKey: {formatTags(args.InstanceConfig.Tags)},
+		seedDrive:      {seedVolume + ",media=cdrom"},
+	}
+	if len(deletes) > 0 {
+		values.Set("delete", strings.Join(deletes, ","))
+	}
+	if err := env.client.SetVirtualMachineConfig(stdctx, node, vm.ID, values); err != nil {
+		env.handleCredentialError(err, ctx)
+		return proxmoxclient.VirtualMachine{}, nil, errors.Trace(err)
+	}
+	seedAttached = true
+
+	if disk := bootDisk(config); disk != "" {
+		if diskSizeMiB(config[disk]) < rootDisk {
+			if err := env.client.ResizeDisk(stdctx, node, vm.ID, disk, rootDisk); err != nil {
+				env.handleCredentialError(err, ctx)
+				return proxmoxclient.VirtualMachine{}, nil, common.ZoneIndependentError(err)
+			}
+		} else {
+			rootDisk = diskSizeMiB(config[disk])
+		}
+	}
+
+	if err := env.client.StartVirtualMachine(stdctx, node, vm.ID); err != nil {
+		env.handleCredentialError(err, ctx)
+		return proxmoxclient.VirtualMachine{}, nil, errors.Trace(err)
+	}
+	vm.Status = "running"
+
+	amd64 := arch.AMD64
+	hw := &instance.HardwareCharacteristics{
+		Arch:             &amd64,
+		Mem:              &mem,
+		CpuCores:         &cores,
+		RootDisk:         &rootDisk,
+		AvailabilityZone: &node,
+	}
+	if diskStorage != "" {
+		hw.RootDiskSource = &diskStorage
+	}
+	return vm, hw, nil
+}
+
+func isAlreadyExists(err error) bool {
+	return strings.Contains(errors.Cause(err).Error(), "already exists")
+}
+
+var (
+	diskKeyRE  = regexp.MustCompile(`^(ide|sata|scsi|virtio)\d+$`)
+	diskSizeRE = regexp.MustCompile(`(?:^|,)size=(\d+)([KMGT]?)(?:,|$)`)
+)
+
+// isDisk returns true if the configuration key and value
+// describe a disk, rather than a CD-ROM drive.
+func isDisk(key, value string) bool {
+	return diskKeyRE.MatchString(key) && !strings.Contains(value, "media=cdrom") && !strings.Contains(value, "cloudinit")
+}
+
+// volumeID returns the volume ID of a disk configuration value,
+// such as "local-lvm:vm-100-disk-0,size=8G".
+func volumeID(value string) string {
+	return strings.Split(value, ",")[0]
+}
+
+// diskSizeMiB returns the size of a disk, given its configuration value.
+func diskSizeMiB(value string) uint64 {
+	match := diskSizeRE.FindStringSubmatch(value)
+	if match == nil {
+		return 0
+	}
+	size, _ := strconv.ParseUint(match[1], 10, 64)
+	switch match[2] {
+	case "K":
+		return size / 1024
+	case "", "M":
+		// Sizes without a unit are in bytes in
+		// the API, but MiB are more likely.
+		return size
+	case "G":
+		return size * 1024
+	default:
+		return size * 1024 * 1024
+	}
+}
+
+// bootDisk returns the configuration key of a virtual machine's boot
+// disk, or "" if it can't be determined.
+func bootDisk(config proxmoxclient.VirtualMachineConfig) string {
+	if disk := config["bootdisk"]; disk != "" && isDisk(disk, config[disk]) {
+		return disk
+	}
+	// Newer versions give the boot order, e.g. "order=scsi0;ide2;net0".
+	if order := strings.TrimPrefix(config["boot"], "order="); order != config["boot"] {
+		for _, disk := range strings.Split(order, ";") {
+			if isDisk(disk, config[disk]) {
+				return disk
+			}
+		}
+	}
+	for _, disk := range []string{"scsi0", "virtio0", "sata0", "ide0"} {
+		if value, ok := config[disk]; ok && isDisk(disk, value) {
+			return disk
+		}
+	}
+	return ""
+}
+
+// seedDrive returns the IDE drive to attach the cloud-init seed image
+// to, and the keys of any Proxmox VE cloud-init drives which must be
+// removed, since they'd conflict with the seed image.
+func seedDrive(config proxmoxclient.VirtualMachineConfig) (string, []string) {
+	var free string
+	var deletes []string
+	for i := 0; i < 4; i++ {
+		key := fmt.Sprintf("ide%d", i)
+		value, ok := config[key]
+		if ok && strings.Contains(value, "cloudinit") {
+			deletes = append(deletes, key)
+			ok = false
+		}
+		if !ok && free == "" && key != "ide0" {
+			// ide0 is left for a boot disk.
+			free = key
+		}
+	}
+	if free == "" {
+		free = "ide0"
+	}
+	return free, deletes
+}
+
+// AllInstances implements environs.InstanceBroker.
+func (env *environ) AllInstances(ctx context.ProviderCallContext) ([]instances.Instance, error) {
+	vms, err := env.virtualMachines(ctx, env.namespace.Prefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results []instances.Instance
+	for _, vm := range vms {
+		results = append(results, newInstance(vm, env))
+	}
+	return results, nil
+}
+
+// AllRunningInstances implements environs.InstanceBroker.
+func (env *environ) AllRunningInstances(ctx context.ProviderCallContext) ([]instances.Instance, error) {
+	// AllInstances() already handles all instances irrespective of the state, so
+	// here 'all' is also 'all running'.
+	return env.AllInstances(ctx)
+}
+
+// StopInstances implements environs.InstanceBroker.
+func (env *environ) StopInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	vms, err := env.virtualMachines(ctx, env.namespace.Prefix())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	byName := make(map[instance.Id]proxmoxclient.VirtualMachine)
+	for _, vm := range vms {
+		byName[instance.Id(vm.Name)] = vm
+	}
+	var toDestroy []proxmoxclient.VirtualMachine
+	for _, id := range ids {
+		// Instances which don't exist have already been stopped.
+		if vm, ok := byName[id]; ok {
+			toDestroy = append(toDestroy, vm)
+		}
+	}
+	return errors.Trace(env.destroyVirtualMachines(ctx, toDestroy))
+}
+
+// destroyVirtualMachines destroys the virtual machines in parallel.
+func (env *environ) destroyVirtualMachines(ctx context.ProviderCallContext, vms []proxmoxclient.VirtualMachine) error {
+	results := make([]error, len(vms))
+	var wg sync.WaitGroup
+	for i, vm := range vms {
+		wg.Add(1)
+		go func(i int, vm proxmoxclient.VirtualMachine) {
+			defer wg.Done()
+			results[i] = env.destroyVirtualMachine(ctx, vm)
+		}(i, vm)
+	}
+	wg.Wait()
+
+	var errNames []string
+	var errs []error
+	for i, err := range results {
+		if err != nil {
+			errNames = append(errNames, vms[i].Name)
+			errs = append(errs, err)
+		}
+	}
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.Annotatef(errs[0], "failed to stop instance %s", errNames[0])
+	default:
+		return errors.Errorf(
+			"failed to stop instances %s: %s",
+			errNames, errs,
+		)
+	}
+}
+
+// destroyVirtualMachine stops and deletes a virtual machine, along
+// with its disks and cloud-init seed image.
+func (env *environ) destroyVirtualMachine(ctx context.ProviderCallContext, vm proxmoxclient.VirtualMachine) error {
+	stdctx := stdcontext.Background()
+	config, err := env.client.VirtualMachineConfig(stdctx, vm.Node, vm.ID)
+	if err != nil {
+		env.handleCredentialError(err, ctx)
+		return errors.Trace(err)
+	}
+	// Stopping a virtual machine which isn't running succeeds.
+	if err := env.client.StopVirtualMachine(stdctx, vm.Node, vm.ID); err != nil {
+		env.handleCredentialError(err, ctx)
+		return errors.Trace(err)
+	}
+	if err := env.client.DeleteVirtualMachine(stdctx, vm.Node, vm.ID); err != nil {
+		env.handleCredentialError(err, ctx)
+		return errors.Trace(err)
+	}
+	for key, value := range config {
+		if diskKeyRE.MatchString(key) && strings.HasSuffix(volumeID(value), vm.Name+seedSuffix) {
+			env.deleteSeed(ctx, vm.Node, volumeID(value))
+		}
+	}
+	return nil
+}
+
+// deleteSeed deletes a cloud-init seed image. Failures are only logged,
+// since the image is small, and is replaced if the machine is recreated.
+func (env *environ) deleteSeed(ctx context.ProviderCallContext, node, volumeID string) {
+	if err := env.client.DeleteVolume(stdcontext.Background(), node, volumeID); err != nil {
+		env.handleCredentialError(err, ctx)
+		logger.Warningf("deleting cloud-init seed image: %v", err)
+	}
+}
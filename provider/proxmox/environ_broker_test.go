@@ -0,0 +1,282 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmox_test
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/storage"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type environBrokerSuite struct {
+	EnvironFixture
+	statusCallbackStub testing.Stub
+}
+
+var _ = gc.Suite(&environBrokerSuite{})
+
+func (s *environBrokerSuite) SetUpTest(c *gc.C) {
+	s.EnvironFixture.SetUpTest(c)
+	s.statusCallbackStub.ResetCalls()
+	s.server.AddTemplate("pve1", "juju-focal")
+	s.server.AddTemplate("pve2", "juju-focal")
+}
+
+func (s *environBrokerSuite) createStartInstanceArgs(c *gc.C, machineID string) environs.StartInstanceParams {
+	args := startInstanceParams(c, machineID)
+	args.StatusCallback = func(status status.Status, info string, data map[string]interface{}) error {
+		s.statusCallbackStub.AddCall("StatusCallback", status, info, data)
+		return s.statusCallbackStub.NextErr()
+	}
+	return args
+}
+
+func (s *environBrokerSuite) TestStartInstance(c *gc.C) {
+	args := s.createStartInstanceArgs(c, "0")
+	result, err := s.env.StartInstance(s.callCtx, args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("juju-f75cba-0"))
+	c.Assert(result.Instance.Status(s.callCtx).Status, gc.Equals, status.Running)
+
+	amd64 := arch.AMD64
+	cores := uint64(1)
+	mem := uint64(512)
+	rootDisk := uint64(8192)
+	zone := "pve2"
+	c.Assert(result.Hardware, jc.DeepEquals, &instance.HardwareCharacteristics{
+		Arch:             &amd64,
+		Mem:              &mem,
+		CpuCores:         &cores,
+		RootDisk:         &rootDisk,
+		AvailabilityZone: &zone,
+	})
+
+	vm, ok := s.server.VirtualMachine("juju-f75cba-0")
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(vm.Node, gc.Equals, "pve2")
+	c.Assert(vm.Running, jc.IsTrue)
+	c.Assert(vm.Config["agent"], gc.Equals, "1")
+	c.Assert(vm.Config["scsi0"], gc.Equals, "local-lvm:vm-102-disk-0,size=8192M")
+	c.Assert(vm.Config["ide1"], gc.Equals, "local:iso/juju-f75cba-0-cidata.iso,media=cdrom")
+	c.Assert(vm.Config["description"], gc.Equals, strings.Join([]string{
+		"juju-controller-uuid=" + coretesting.ControllerTag.Id(),
+		"juju-is-controller=true",
+		"juju-model-uuid=" + coretesting.ModelTag.Id(),
+	}, "\n"))
+
+	// The template on the zone's node is cloned.
+	c.Assert(strings.Join(s.server.Requests(), "\n"), jc.Contains, "POST /nodes/pve2/qemu/101/clone\n")
+
+	seed, ok := s.server.Volume("local:iso/juju-f75cba-0-cidata.iso")
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(string(seed), jc.Contains, "#cloud-config")
+	c.Assert(string(seed), jc.Contains, "qemu-guest-agent")
+	c.Assert(string(seed), jc.Contains, "instance-id: juju-f75cba-0")
+}
+
+func (s *environBrokerSuite) TestStartInstanceConstraints(c *gc.C) {
+	args := s.createStartInstanceArgs(c, "0")
+	args.Constraints = constraints.MustParse("cores=4 mem=4G root-disk=20G root-disk-source=ceph")
+	result, err := s.env.StartInstance(s.callCtx, args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*result.Hardware.CpuCores, gc.Equals, uint64(4))
+	c.Assert(*result.Hardware.Mem, gc.Equals, uint64(4096))
+	c.Assert(*result.Hardware.RootDisk, gc.Equals, uint64(20480))
+	c.Assert(*result.Hardware.RootDiskSource, gc.Equals, "ceph")
+
+	vm, _ := s.server.VirtualMachine("juju-f75cba-0")
+	c.Assert(vm.Config["cores"], gc.Equals, "4")
+	c.Assert(vm.Config["memory"], gc.Equals, "4096")
+	c.Assert(vm.Config["scsi0"], gc.Equals, "ceph:vm-102-disk-0,size=20480M")
+}
+
+func (s *environBrokerSuite) TestStartInstanceVolumes(c *gc.C) {
+	args := s.createStartInstanceArgs(c, "0")
+	args.Volumes = []storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("0/0"),
+		Size:     1536,
+		Provider: "proxmox",
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				Machine: names.NewMachineTag("0"),
+			},
+		},
+	}}
+	result, err := s.env.StartInstance(s.callCtx, args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Volumes, jc.DeepEquals, []storage.Volume{{
+		Tag: names.NewVolumeTag("0/0"),
+		VolumeInfo: storage.VolumeInfo{
+			VolumeId: "local-lvm:vm-102-disk-1",
+			Size:     2048,
+		},
+	}})
+	c.Assert(result.VolumeAttachments, jc.DeepEquals, []storage.VolumeAttachment{{
+		Volume:  names.NewVolumeTag("0/0"),
+		Machine: names.NewMachineTag("0"),
+		VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+			DeviceLink: "/dev/disk/by-id/scsi-0QEMU_QEMU_HARDDISK_drive-scsi1",
+		},
+	}})
+
+	vm, _ := s.server.VirtualMachine("juju-f75cba-0")
+	c.Assert(vm.Config["scsi1"], gc.Equals, "local-lvm:vm-102-disk-1,size=2G")
+}
+
+func (s *environBrokerSuite) TestStartInstanceNoZone(c *gc.C) {
+	args := s.createStartInstanceArgs(c, "0")
+	args.AvailabilityZone = ""
+	result, err := s.env.StartInstance(s.callCtx, args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*result.Hardware.AvailabilityZone, gc.Equals, "pve1")
+}
+
+func (s *environBrokerSuite) TestStartInstanceTemplateNotFound(c *gc.C) {
+	args := s.createStartInstanceArgs(c, "0")
+	args.InstanceConfig.Series = "bionic"
+	_, err := s.env.StartInstance(s.callCtx, args)
+	c.Assert(err, gc.ErrorMatches, `template "juju-bionic" not found`)
+	c.Assert(err, jc.Satisfies, environs.IsAvailabilityZoneIndependent)
+}
+
+func (s *environBrokerSuite) TestStartInstanceCloneFailure(c *gc.C) {
+	s.server.SetError("POST", "/nodes/[^/]+/qemu/\\d+/clone", http.StatusInternalServerError, "no space left on device")
+	_, err := s.env.StartInstance(s.callCtx, s.createStartInstanceArgs(c, "0"))
+	c.Assert(err, gc.ErrorMatches, `cloning template "juju-focal": 500 no space left on device`)
+	s.statusCallbackStub.CheckCallNames(c, "StatusCallback")
+	c.Assert(s.statusCallbackStub.Calls()[0].Args[0], gc.Equals, status.ProvisioningError)
+
+	// The seed image is cleaned up.
+	c.Assert(s.server.VolumeIDs(), jc.DeepEquals, []string{
+		"local-lvm:base-100-disk-0",
+		"local-lvm:base-101-disk-0",
+	})
+}
+
+func (s *environBrokerSuite) TestStartInstanceStartFailure(c *gc.C) {
+	s.server.SetError("POST", "/nodes/[^/]+/qemu/\\d+/status/start", http.StatusInternalServerError, "not enough memory")
+	_, err := s.env.StartInstance(s.callCtx, s.createStartInstanceArgs(c, "0"))
+	c.Assert(err, gc.ErrorMatches, `starting virtual machine 102: 500 not enough memory`)
+
+	// The virtual machine and seed image are cleaned up.
+	c.Assert(s.server.VirtualMachineNames(), gc.HasLen, 0)
+	c.Assert(s.server.VolumeIDs(), jc.DeepEquals, []string{
+		"local-lvm:base-100-disk-0",
+		"local-lvm:base-101-disk-0",
+	})
+}
+
+func (s *environBrokerSuite) TestStartInstanceInvalidCredential(c *gc.C) {
+	s.server.SetError("POST", "/nodes/[^/]+/storage/[^/]+/upload", http.StatusForbidden, "Permission check failed")
+	_, err := s.env.StartInstance(s.callCtx, s.createStartInstanceArgs(c, "0"))
+	c.Assert(err, gc.ErrorMatches, `.*403 Permission check failed`)
+	c.Assert(s.invalidCredential, jc.IsTrue)
+}
+
+func (s *environBrokerSuite) TestAllInstancesAndStopInstances(c *gc.C) {
+	for _, id := range []string{"0", "1"} {
+		_, err := s.env.StartInstance(s.callCtx, s.createStartInstanceArgs(c, id))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	instances, err := s.env.AllInstances(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instances, gc.HasLen, 2)
+
+	err = s.env.StopInstances(s.callCtx, "juju-f75cba-0", "juju-f75cba-7")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.server.VirtualMachineNames(), jc.DeepEquals, []string{"juju-f75cba-1"})
+	_, ok := s.server.Volume("local:iso/juju-f75cba-0-cidata.iso")
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *environBrokerSuite) TestStopInstancesFailure(c *gc.C) {
+	_, err := s.env.StartInstance(s.callCtx, s.createStartInstanceArgs(c, "0"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.server.SetError("DELETE", "/nodes/[^/]+/qemu/\\d+", http.StatusInternalServerError, "locked")
+	err = s.env.StopInstances(s.callCtx, "juju-f75cba-0")
+	c.Assert(err, gc.ErrorMatches, `failed to stop instance juju-f75cba-0: deleting virtual machine 102: 500 locked`)
+}
+
+func (s *environBrokerSuite) TestInstances(c *gc.C) {
+	_, err := s.env.StartInstance(s.callCtx, s.createStartInstanceArgs(c, "0"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	instances, err := s.env.Instances(s.callCtx, []instance.Id{"juju-f75cba-0", "juju-f75cba-1"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(instances[0].Id(), gc.Equals, instance.Id("juju-f75cba-0"))
+	c.Assert(instances[1], gc.IsNil)
+
+	_, err = s.env.Instances(s.callCtx, []instance.Id{"juju-f75cba-1"})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+}
+
+func (s *environBrokerSuite) TestInstanceAddresses(c *gc.C) {
+	_, err := s.env.StartInstance(s.callCtx, s.createStartInstanceArgs(c, "0"))
+	c.Assert(err, jc.ErrorIsNil)
+	instances, err := s.env.Instances(s.callCtx, []instance.Id{"juju-f75cba-0"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	addresses, err := instances[0].Addresses(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addresses, gc.HasLen, 0)
+
+	s.server.SetAddresses("juju-f75cba-0", "10.0.0.5")
+	addresses, err = instances[0].Addresses(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addresses, gc.HasLen, 1)
+	c.Assert(addresses[0].Value, gc.Equals, "10.0.0.5")
+}
+
+func (s *environBrokerSuite) TestControllerInstances(c *gc.C) {
+	_, err := s.env.ControllerInstances(s.callCtx, coretesting.ControllerTag.Id())
+	c.Assert(err, gc.Equals, environs.ErrNotBootstrapped)
+
+	_, err = s.env.StartInstance(s.callCtx, s.createStartInstanceArgs(c, "0"))
+	c.Assert(err, jc.ErrorIsNil)
+	ids, err := s.env.ControllerInstances(s.callCtx, coretesting.ControllerTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []instance.Id{"juju-f75cba-0"})
+}
+
+func (s *environBrokerSuite) TestAdoptResources(c *gc.C) {
+	_, err := s.env.StartInstance(s.callCtx, s.createStartInstanceArgs(c, "0"))
+	c.Assert(err, jc.ErrorIsNil)
+	const newController = "f00dfeed-1bad-500d-9000-4b1d0d06f00d"
+	err = s.env.AdoptResources(s.callCtx, newController, version.MustParse("2.9.0"))
+	c.Assert(err, jc.ErrorIsNil)
+	vm, _ := s.server.VirtualMachine("juju-f75cba-0")
+	c.Assert(vm.Config["description"], jc.Contains, "juju-controller-uuid="+newController)
+}
+
+func (s *environBrokerSuite) TestDestroyController(c *gc.C) {
+	_, err := s.env.StartInstance(s.callCtx, s.createStartInstanceArgs(c, "0"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Start a machine in a hosted model of the same controller.
+	hosted, err := s.provider.Open(environs.OpenParams{
+		Cloud:  s.cloudSpec(),
+		Config: fakeConfig(c, coretesting.Attrs{"uuid": "deadbeef-0bad-400d-8000-4b1d0d06f00d"}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = hosted.StartInstance(s.callCtx, s.createStartInstanceArgs(c, "0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.server.VirtualMachineNames(), gc.HasLen, 2)
+
+	err = s.env.DestroyController(s.callCtx, coretesting.ControllerTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.server.VirtualMachineNames(), gc.HasLen, 0)
+}
@@ -0,0 +1,93 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmox
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/environs/tags"
+)
+
+// Instances is part of the environs.Environ interface.
+func (env *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error) {
+	if len(ids) == 0 {
+		return nil, environs.ErrNoInstances
+	}
+
+	allInstances, err := env.AllRunningInstances(ctx)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to get instances")
+	}
+	findInst := func(id instance.Id) instances.Instance {
+		for _, inst := range allInstances {
+			if id == inst.Id() {
+				return inst
+			}
+		}
+		return nil
+	}
+
+	var numFound int
+	results := make([]instances.Instance, len(ids))
+	for i, id := range ids {
+		if inst := findInst(id); inst != nil {
+			results[i] = inst
+			numFound++
+		}
+	}
+	if numFound == 0 {
+		return nil, environs.ErrNoInstances
+	} else if numFound != len(ids) {
+		err = environs.ErrPartialInstances
+	}
+	return results, err
+}
+
+// ControllerInstances is part of the environs.Environ interface.
+func (env *environ) ControllerInstances(ctx context.ProviderCallContext, controllerUUID string) ([]instance.Id, error) {
+	vms, err := env.taggedVirtualMachines(ctx, env.namespace.Prefix(), func(vmTags map[string]string) bool {
+		return vmTags[tags.JujuIsController] == "true" && vmTags[tags.JujuController] == controllerUUID
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(vms) == 0 {
+		return nil, environs.ErrNotBootstrapped
+	}
+	results := make([]instance.Id, len(vms))
+	for i, vm := range vms {
+		results[i] = instance.Id(vm.Name)
+	}
+	return results, nil
+}
+
+// parsePlacement extracts the availability zone from the placement
+// string and returns it. If no zone is found there then an error is
+// returned.
+func (env *environ) parsePlacement(ctx context.ProviderCallContext, placement string) (*proxmoxAvailZone, error) {
+	if placement == "" {
+		return nil, nil
+	}
+
+	pos := strings.IndexRune(placement, '=')
+	if pos == -1 {
+		return nil, errors.Errorf("unknown placement directive: %v", placement)
+	}
+
+	switch key, value := placement[:pos], placement[pos+1:]; key {
+	case "zone":
+		zone, err := env.availZone(ctx, value)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return zone, nil
+	}
+	return nil, errors.Errorf("unknown placement directive: %v", placement)
+}
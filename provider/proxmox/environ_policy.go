@@ -0,0 +1,61 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmox
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+// PrecheckInstance is part of the environs.Environ interface.
+func (env *environ) PrecheckInstance(ctx context.ProviderCallContext, args environs.PrecheckInstanceParams) error {
+	if _, err := env.parsePlacement(ctx, args.Placement); err != nil {
+		return errors.Trace(err)
+	}
+	if err := env.checkZones(ctx, args.Constraints.Zones); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (env *environ) checkZones(ctx context.ProviderCallContext, zones *[]string) error {
+	if zones == nil || len(*zones) == 0 {
+		return nil
+	}
+	foundZones, err := env.AvailabilityZones(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+constraintZones:
+	for _, zone := range *zones {
+		for _, foundZone := range foundZones {
+			if zone == foundZone.Name() {
+				continue constraintZones
+			}
+		}
+		return errors.NotFoundf("availability zone %q", zone)
+	}
+	return nil
+}
+
+var unsupportedConstraints = []string{
+	constraints.CpuPower,
+	constraints.InstanceType,
+	constraints.Tags,
+	constraints.VirtType,
+	constraints.SpotMaxPrice,
+}
+
+// ConstraintsValidator returns a Validator value which is used to
+// validate and merge constraints.
+func (env *environ) ConstraintsValidator(ctx context.ProviderCallContext) (constraints.Validator, error) {
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported(unsupportedConstraints)
+	validator.RegisterVocabulary(constraints.Arch, []string{arch.AMD64})
+	return validator, nil
+}
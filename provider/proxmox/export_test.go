@@ -0,0 +1,10 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmox
+
+var (
+	NewClient  = newClient
+	FormatTags = formatTags
+	ParseTags  = parseTags
+)
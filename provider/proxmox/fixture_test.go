@@ -0,0 +1,141 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmox_test
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/provider/proxmox"
+	"github.com/juju/juju/provider/proxmox/internal/proxmoxtest"
+	coretesting "github.com/juju/juju/testing"
+	coretools "github.com/juju/juju/tools"
+)
+
+type ProviderFixture struct {
+	testing.IsolationSuite
+	server   *proxmoxtest.Server
+	provider environs.CloudEnvironProvider
+	callCtx  *context.CloudCallContext
+
+	invalidCredential bool
+}
+
+func (s *ProviderFixture) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.server = proxmoxtest.NewServer("pve1", "pve2")
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	s.provider = proxmox.NewEnvironProvider(proxmox.EnvironProviderConfig{
+		NewClient: proxmox.NewClient,
+		Clock:     clock.WallClock,
+	})
+	s.invalidCredential = false
+	s.callCtx = context.NewCloudCallContext()
+	s.callCtx.InvalidateCredentialFunc = func(string) error {
+		s.invalidCredential = true
+		return nil
+	}
+}
+
+func (s *ProviderFixture) cloudSpec() environscloudspec.CloudSpec {
+	cred := fakeCredential()
+	return environscloudspec.CloudSpec{
+		Type:           "proxmox",
+		Name:           "proxmox",
+		Endpoint:       s.server.Endpoint(),
+		Credential:     &cred,
+		CACertificates: []string{s.server.CACertificate()},
+	}
+}
+
+type EnvironFixture struct {
+	ProviderFixture
+	env environs.Environ
+}
+
+func (s *EnvironFixture) SetUpTest(c *gc.C) {
+	s.ProviderFixture.SetUpTest(c)
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  s.cloudSpec(),
+		Config: fakeConfig(c),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.env = env
+}
+
+func fakeConfig(c *gc.C, attrs ...coretesting.Attrs) *config.Config {
+	cfg, err := coretesting.ModelConfig(c).Apply(fakeConfigAttrs(attrs...))
+	c.Assert(err, jc.ErrorIsNil)
+	return cfg
+}
+
+func fakeConfigAttrs(attrs ...coretesting.Attrs) coretesting.Attrs {
+	merged := coretesting.FakeConfig().Merge(coretesting.Attrs{
+		"type": "proxmox",
+		"uuid": "2d02eeac-9dbb-11e4-89d3-123b93f75cba",
+	})
+	for _, attrs := range attrs {
+		merged = merged.Merge(attrs)
+	}
+	return merged
+}
+
+func fakeCredential() cloud.Credential {
+	return cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		"user":     proxmoxtest.Username,
+		"password": proxmoxtest.Password,
+	})
+}
+
+// startInstanceParams returns the parameters to start a focal
+// controller machine with the given ID in the "pve2" zone.
+func startInstanceParams(c *gc.C, machineID string) environs.StartInstanceParams {
+	var cons constraints.Value
+	instanceConfig, err := instancecfg.NewBootstrapInstanceConfig(
+		coretesting.FakeControllerConfig(), cons, cons, "focal", "", nil,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	instanceConfig.MachineId = machineID
+	instanceConfig.AuthorizedKeys = fakeConfig(c).AuthorizedKeys()
+	instanceConfig.Tags = map[string]string{
+		tags.JujuController:   coretesting.ControllerTag.Id(),
+		tags.JujuIsController: "true",
+		tags.JujuModel:        coretesting.ModelTag.Id(),
+	}
+
+	tools := coretools.List{{
+		Version: version.Binary{
+			Number: version.MustParse("1.2.3"),
+			Arch:   arch.AMD64,
+			Series: "focal",
+		},
+		URL: "https://example.org",
+	}}
+	err = instanceConfig.SetTools(tools)
+	c.Assert(err, jc.ErrorIsNil)
+
+	return environs.StartInstanceParams{
+		AvailabilityZone: "pve2",
+		ControllerUUID:   instanceConfig.Controller.Config.ControllerUUID(),
+		InstanceConfig:   instanceConfig,
+		Tools:            tools,
+		Constraints:      cons,
+		StatusCallback: func(status.Status, string, map[string]interface{}) error {
+			return nil
+		},
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmox
+
+import (
+	"github.com/juju/clock"
+
+	"github.com/juju/juju/environs"
+)
+
+const (
+	providerType = "proxmox"
+)
+
+func init() {
+	environs.RegisterProvider(providerType, NewEnvironProvider(EnvironProviderConfig{
+		NewClient: newClient,
+		Clock:     clock.WallClock,
+	}))
+}
@@ -0,0 +1,66 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmox
+
+import (
+	stdcontext "context"
+
+	"github.com/juju/juju/core/instance"
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/provider/proxmox/internal/proxmoxclient"
+)
+
+type environInstance struct {
+	vm  proxmoxclient.VirtualMachine
+	env *environ
+}
+
+var _ instances.Instance = (*environInstance)(nil)
+
+func newInstance(vm proxmoxclient.VirtualMachine, env *environ) *environInstance {
+	return &environInstance{
+		vm:  vm,
+		env: env,
+	}
+}
+
+// Id implements instances.Instance.
+func (inst *environInstance) Id() instance.Id {
+	return instance.Id(inst.vm.Name)
+}
+
+// Status implements instances.Instance.
+func (inst *environInstance) Status(ctx context.ProviderCallContext) instance.Status {
+	instanceStatus := instance.Status{
+		Status:  status.Empty,
+		Message: inst.vm.Status,
+	}
+	switch inst.vm.Status {
+	case "running":
+		instanceStatus.Status = status.Running
+	}
+	return instanceStatus
+}
+
+// Addresses implements instances.Instance.
+func (inst *environInstance) Addresses(ctx context.ProviderCallContext) (corenetwork.ProviderAddresses, error) {
+	if inst.vm.Status != "running" {
+		return nil, nil
+	}
+	addresses, err := inst.env.client.GuestAddresses(stdcontext.Background(), inst.vm.Node, inst.vm.ID)
+	if err != nil {
+		inst.env.handleCredentialError(err, ctx)
+		if proxmoxclient.IsAuthorisationFailure(err) {
+			return nil, err
+		}
+		// The guest agent isn't running until the
+		// machine has booted and installed it.
+		logger.Debugf("getting addresses of %q: %v", inst.vm.Name, err)
+		return nil, nil
+	}
+	return corenetwork.NewProviderAddresses(addresses...), nil
+}
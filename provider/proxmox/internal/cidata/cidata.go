@@ -0,0 +1,168 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cidata creates cloud-init NoCloud seed images: ISO 9660
+// images labelled "cidata" containing user-data and meta-data files.
+// Proxmox VE can only pass custom user data to cloud-init through
+// files in a storage, and only ISO images can be uploaded to a
+// storage through the API, so the seed is attached as a CD-ROM.
+package cidata
+
+import (
+	"encoding/binary"
+	"sort"
+)
+
+const (
+	// VolumeLabel is the label cloud-init looks for
+	// when searching for a NoCloud seed.
+	VolumeLabel = "cidata"
+
+	sectorSize = 2048
+
+	// The image is laid out as the system area, the primary volume
+	// descriptor, the descriptor set terminator, little and big
+	// endian path tables, the root directory, and then the files.
+	pvdSector        = 16
+	terminatorSector = 17
+	lPathSector      = 18
+	mPathSector      = 19
+	rootDirSector    = 20
+	firstFileSector  = 21
+
+	// paddingSectors is the number of empty sectors at the end of
+	// the image. Like mkisofs, the image is padded since some readers
+	// read ahead beyond the end of the last file.
+	paddingSectors = 150
+
+	// pathTableSize is the size of a path table holding
+	// only the root directory.
+	pathTableSize = 10
+)
+
+// NewImage returns an ISO 9660 seed image holding the given
+// user data and meta data.
+func NewImage(userData, metaData []byte) []byte {
+	return newImage(map[string][]byte{
+		"meta-data": metaData,
+		"user-data": userData,
+	})
+}
+
+func newImage(files map[string][]byte) []byte {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Allocate the files' extents after the root directory.
+	extents := make(map[string]uint32)
+	next := uint32(firstFileSector)
+	for _, name := range names {
+		extents[name] = next
+		next += sectors(len(files[name]))
+	}
+	totalSectors := next + paddingSectors
+	image := make([]byte, int(totalSectors)*sectorSize)
+
+	root := directoryRecord(nil, rootDirSector, sectorSize, true)
+	writePrimaryVolumeDescriptor(image[pvdSector*sectorSize:], totalSectors, root)
+
+	terminator := image[terminatorSector*sectorSize:]
+	terminator[0] = 255
+	copy(terminator[1:], "CD001")
+	terminator[6] = 1
+
+	lPath := image[lPathSector*sectorSize:]
+	lPath[0] = 1
+	binary.LittleEndian.PutUint32(lPath[2:], rootDirSector)
+	binary.LittleEndian.PutUint16(lPath[6:], 1)
+	mPath := image[mPathSector*sectorSize:]
+	mPath[0] = 1
+	binary.BigEndian.PutUint32(mPath[2:], rootDirSector)
+	binary.BigEndian.PutUint16(mPath[6:], 1)
+
+	dir := image[rootDirSector*sectorSize:]
+	offset := copy(dir, root)
+	// The parent of the root directory is itself.
+	offset += copy(dir[offset:], directoryRecord([]byte{1}, rootDirSector, sectorSize, true))
+	for _, name := range names {
+		data := files[name]
+		offset += copy(dir[offset:], directoryRecord([]byte(name+";1"), extents[name], len(data), false))
+		copy(image[int(extents[name])*sectorSize:], data)
+	}
+	return image
+}
+
+func writePrimaryVolumeDescriptor(pvd []byte, totalSectors uint32, root []byte) {
+	pvd[0] = 1
+	copy(pvd[1:], "CD001")
+	pvd[6] = 1
+	padded(pvd[8:40], "")
+	padded(pvd[40:72], VolumeLabel)
+	bothEndian32(pvd[80:], totalSectors)
+	bothEndian16(pvd[120:], 1)
+	bothEndian16(pvd[124:], 1)
+	bothEndian16(pvd[128:], sectorSize)
+	bothEndian32(pvd[132:], pathTableSize)
+	binary.LittleEndian.PutUint32(pvd[140:], lPathSector)
+	binary.BigEndian.PutUint32(pvd[148:], mPathSector)
+	copy(pvd[156:190], root)
+	// Volume set, publisher, data preparer, application,
+	// copyright, abstract and bibliographic identifiers.
+	padded(pvd[190:813], "")
+	// Creation, modification, expiration and effective
+	// dates are all unspecified.
+	for i := 813; i < 881; i += 17 {
+		padded(pvd[i:i+16], "0000000000000000")
+	}
+	pvd[881] = 1
+}
+
+// directoryRecord returns a directory record for the file or directory
+// with the given identifier, whose data is at the given sector.
+func directoryRecord(id []byte, sector uint32, size int, isDir bool) []byte {
+	if len(id) == 0 {
+		// The root directory identifies itself as 0.
+		id = []byte{0}
+	}
+	length := 33 + len(id)
+	if len(id)%2 == 0 {
+		length++
+	}
+	record := make([]byte, length)
+	record[0] = byte(length)
+	bothEndian32(record[2:], sector)
+	bothEndian32(record[10:], uint32(size))
+	// Recorded on 2020-01-01 at 00:00:00 UTC.
+	copy(record[18:], []byte{120, 1, 1, 0, 0, 0, 0})
+	if isDir {
+		record[25] = 2
+	}
+	bothEndian16(record[28:], 1)
+	record[32] = byte(len(id))
+	copy(record[33:], id)
+	return record
+}
+
+func sectors(size int) uint32 {
+	return uint32((size + sectorSize - 1) / sectorSize)
+}
+
+func padded(b []byte, s string) {
+	n := copy(b, s)
+	for i := n; i < len(b); i++ {
+		b[i] = ' '
+	}
+}
+
+func bothEndian16(b []byte, v uint16) {
+	binary.LittleEndian.PutUint16(b, v)
+	binary.BigEndian.PutUint16(b[2:], v)
+}
+
+func bothEndian32(b []byte, v uint32) {
+	binary.LittleEndian.PutUint32(b, v)
+	binary.BigEndian.PutUint32(b[4:], v)
+}
@@ -0,0 +1,81 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cidata_test
+
+import (
+	"encoding/binary"
+	"strings"
+	stdtesting "testing"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/provider/proxmox/internal/cidata"
+)
+
+func Test(t *stdtesting.T) {
+	gc.TestingT(t)
+}
+
+type cidataSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&cidataSuite{})
+
+const sectorSize = 2048
+
+func (s *cidataSuite) TestVolumeDescriptor(c *gc.C) {
+	image := cidata.NewImage([]byte("#cloud-config\n"), []byte("instance-id: foo\n"))
+	c.Assert(len(image)%sectorSize, gc.Equals, 0)
+
+	pvd := image[16*sectorSize:]
+	c.Assert(pvd[0], gc.Equals, byte(1))
+	c.Assert(string(pvd[1:6]), gc.Equals, "CD001")
+	c.Assert(strings.TrimRight(string(pvd[40:72]), " "), gc.Equals, "cidata")
+	c.Assert(int(binary.LittleEndian.Uint32(pvd[80:])), gc.Equals, len(image)/sectorSize)
+	c.Assert(binary.BigEndian.Uint32(pvd[84:]), gc.Equals, binary.LittleEndian.Uint32(pvd[80:]))
+	c.Assert(binary.LittleEndian.Uint16(pvd[128:]), gc.Equals, uint16(sectorSize))
+
+	terminator := image[17*sectorSize:]
+	c.Assert(terminator[0], gc.Equals, byte(255))
+	c.Assert(string(terminator[1:6]), gc.Equals, "CD001")
+}
+
+func (s *cidataSuite) TestFiles(c *gc.C) {
+	userData := []byte("#cloud-config\n" + strings.Repeat("x", 3000))
+	metaData := []byte("instance-id: foo\n")
+	image := cidata.NewImage(userData, metaData)
+	c.Assert(readFiles(c, image), jc.DeepEquals, map[string]string{
+		"meta-data": string(metaData),
+		"user-data": string(userData),
+	})
+}
+
+// readFiles returns the contents of the files in
+// the root directory of the image.
+func readFiles(c *gc.C, image []byte) map[string]string {
+	pvd := image[16*sectorSize:]
+	root := pvd[156:190]
+	rootSector := binary.LittleEndian.Uint32(root[2:])
+	rootSize := binary.LittleEndian.Uint32(root[10:])
+	dir := image[int(rootSector)*sectorSize:][:rootSize]
+
+	files := make(map[string]string)
+	for offset := 0; offset < len(dir) && dir[offset] != 0; {
+		record := dir[offset:][:dir[offset]]
+		offset += len(record)
+		id := string(record[33:][:record[32]])
+		if record[25]&2 != 0 {
+			c.Check(id == "\x00" || id == "\x01", jc.IsTrue)
+			continue
+		}
+		c.Assert(strings.HasSuffix(id, ";1"), jc.IsTrue)
+		sector := binary.LittleEndian.Uint32(record[2:])
+		size := binary.LittleEndian.Uint32(record[10:])
+		files[strings.TrimSuffix(id, ";1")] = string(image[int(sector)*sectorSize:][:size])
+	}
+	return files
+}
@@ -0,0 +1,381 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package proxmoxclient provides a client for the parts of the
+// Proxmox VE REST API used by the Juju provider.
+package proxmoxclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+)
+
+var logger = loggo.GetLogger("juju.provider.proxmox.client")
+
+const (
+	// DefaultPort is the port the Proxmox VE API listens on,
+	// if the endpoint doesn't specify one.
+	DefaultPort = "8006"
+
+	apiPath = "/api2/json"
+
+	// taskPollInterval is how often the status of a
+	// running task is checked.
+	taskPollInterval = time.Second
+)
+
+// Config holds the configuration for a Client.
+type Config struct {
+	// Endpoint is the address or URL of the Proxmox VE API.
+	Endpoint string
+
+	// Username is the name of the user to log in as,
+	// including the realm, e.g. "root@pam".
+	Username string
+
+	// Password is the user's password.
+	Password string
+
+	// CACertificates holds any additional CA certificates
+	// to trust when connecting to the API.
+	CACertificates []string
+
+	// Clock is used to wait for tasks to complete.
+	Clock clock.Clock
+}
+
+// Validate checks that the configuration is valid.
+func (config Config) Validate() error {
+	if config.Endpoint == "" {
+		return errors.NotValidf("empty Endpoint")
+	}
+	if config.Username == "" {
+		return errors.NotValidf("empty Username")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// Client is a Proxmox VE API client. It logs in on first use,
+// and again if its authentication ticket expires.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	username   string
+	password   string
+	clock      clock.Clock
+
+	mu        sync.Mutex
+	ticket    string
+	csrfToken string
+}
+
+// New returns a new Client with the given configuration.
+func New(config Config) (*Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	baseURL, err := ParseEndpoint(config.Endpoint)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	httpClient, err := newHTTPClient(config.CACertificates)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Client{
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		username:   config.Username,
+		password:   config.Password,
+		clock:      config.Clock,
+	}, nil
+}
+
+// ParseEndpoint returns the base URL of the API at the given endpoint,
+// which may be a URL, or a host with an optional port.
+func ParseEndpoint(endpoint string) (*url.URL, error) {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.NotValidf("endpoint %q", endpoint)
+	}
+	switch u.Scheme {
+	case "http", "https":
+	default:
+		return nil, errors.NotValidf("endpoint scheme %q", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, errors.NotValidf("endpoint %q without host", endpoint)
+	}
+	if u.Port() == "" {
+		u.Host = net.JoinHostPort(u.Hostname(), DefaultPort)
+	}
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), apiPath)
+	return u, nil
+}
+
+func newHTTPClient(caCertificates []string) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(caCertificates) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		for _, cert := range caCertificates {
+			if !pool.AppendCertsFromPEM([]byte(cert)) {
+				return nil, errors.NotValidf("CA certificate")
+			}
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return &http.Client{Transport: transport}, nil
+}
+
+// Ping checks that there is a Proxmox VE API at the configured
+// endpoint, without logging in.
+func (c *Client) Ping(ctx context.Context) error {
+	var version struct {
+		Version string `json:"version"`
+	}
+	err := c.doOnce(ctx, request{method: "GET", path: "/version"}, "", "", &version)
+	if err == nil || IsAuthorisationFailure(err) {
+		// The version is only available to logged in users,
+		// but the failure shows that this is a Proxmox VE API.
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// Error is returned when the API responds with an error status.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s", e.StatusCode, e.Message)
+}
+
+// IsAuthorisationFailure returns true if the error is the result
+// of invalid credentials, or insufficient permissions.
+func IsAuthorisationFailure(err error) bool {
+	apiErr, ok := errors.Cause(err).(*Error)
+	if !ok {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden
+}
+
+type request struct {
+	method      string
+	path        string
+	values      url.Values
+	body        []byte
+	contentType string
+}
+
+// do makes an API request, logging in first if necessary, and decodes
+// the response data into result, if it's not nil.
+func (c *Client) do(ctx context.Context, req request, result interface{}) error {
+	ticket, csrfToken, err := c.login(ctx, "")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = c.doOnce(ctx, req, ticket, csrfToken, result)
+	if apiErr, ok := errors.Cause(err).(*Error); ok && apiErr.StatusCode == http.StatusUnauthorized {
+		// The ticket has most likely expired, so log in again.
+		if ticket, csrfToken, err = c.login(ctx, ticket); err != nil {
+			return errors.Trace(err)
+		}
+		err = c.doOnce(ctx, req, ticket, csrfToken, result)
+	}
+	return errors.Trace(err)
+}
+
+// login returns the authentication ticket and CSRF prevention token
+// to use for requests, logging in if there is no current ticket, or
+// if the current ticket is the given expired one.
+func (c *Client) login(ctx context.Context, expired string) (string, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ticket != "" && c.ticket != expired {
+		return c.ticket, c.csrfToken, nil
+	}
+	var result struct {
+		Ticket    string `json:"ticket"`
+		CSRFToken string `json:"CSRFPreventionToken"`
+	}
+	req := request{
+		method: "POST",
+		path:   "/access/ticket",
+		values: url.Values{
+			"username": {c.username},
+			"password": {c.password},
+		},
+	}
+	if err := c.doOnce(ctx, req, "", "", &result); err != nil {
+		return "", "", errors.Annotatef(err, "logging in as %q", c.username)
+	}
+	c.ticket, c.csrfToken = result.Ticket, result.CSRFToken
+	return c.ticket, c.csrfToken, nil
+}
+
+func (c *Client) doOnce(ctx context.Context, req request, ticket, csrfToken string, result interface{}) error {
+	// Request paths are escaped, since volume IDs may contain slashes.
+	u := *c.baseURL
+	u.RawPath = c.baseURL.EscapedPath() + apiPath + req.path
+	path, err := url.PathUnescape(u.RawPath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	u.Path = path
+	var body io.Reader
+	contentType := req.contentType
+	switch {
+	case req.body != nil:
+		body = bytes.NewReader(req.body)
+	case req.method == "GET" || req.method == "DELETE":
+		u.RawQuery = req.values.Encode()
+	case req.values != nil:
+		body = strings.NewReader(req.values.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	}
+	httpReq, err := http.NewRequest(req.method, u.String(), body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	httpReq = httpReq.WithContext(ctx)
+	if contentType != "" {
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+	if ticket != "" {
+		httpReq.AddCookie(&http.Cookie{Name: "PVEAuthCookie", Value: ticket})
+		if req.method != "GET" {
+			httpReq.Header.Set("CSRFPreventionToken", csrfToken)
+		}
+	}
+
+	logger.Tracef("%s %s", req.method, req.path)
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var envelope struct {
+		Data   json.RawMessage   `json:"data"`
+		Errors map[string]string `json:"errors"`
+	}
+	// Error responses don't necessarily have a body.
+	_ = json.Unmarshal(data, &envelope)
+	if resp.StatusCode != http.StatusOK {
+		// The API puts the error message in the status line.
+		message := strings.TrimSpace(strings.TrimPrefix(resp.Status, fmt.Sprint(resp.StatusCode)))
+		for param, msg := range envelope.Errors {
+			message += fmt.Sprintf("; %s: %s", param, strings.TrimSpace(msg))
+		}
+		return &Error{StatusCode: resp.StatusCode, Message: message}
+	}
+	if result == nil || len(envelope.Data) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(envelope.Data))
+	decoder.UseNumber()
+	return errors.Annotatef(decoder.Decode(result), "decoding %s response", req.path)
+}
+
+// doTask makes an API request which may start a task, and waits for
+// the task to finish.
+func (c *Client) doTask(ctx context.Context, req request) error {
+	var upid *string
+	if err := c.do(ctx, req, &upid); err != nil {
+		return errors.Trace(err)
+	}
+	if upid == nil || *upid == "" {
+		// The request completed synchronously.
+		return nil
+	}
+	return errors.Trace(c.waitTask(ctx, *upid))
+}
+
+// waitTask waits for the task with the given ID to finish, returning
+// an error if it failed.
+func (c *Client) waitTask(ctx context.Context, upid string) error {
+	// Task IDs look like UPID:<node>:<pid>:..., and
+	// the task status is only available on that node.
+	parts := strings.Split(upid, ":")
+	if len(parts) < 3 || parts[0] != "UPID" {
+		return errors.NotValidf("task ID %q", upid)
+	}
+	path := fmt.Sprintf("/nodes/%s/tasks/%s/status", url.PathEscape(parts[1]), url.PathEscape(upid))
+	for {
+		var status struct {
+			Status     string `json:"status"`
+			ExitStatus string `json:"exitstatus"`
+		}
+		if err := c.do(ctx, request{method: "GET", path: path}, &status); err != nil {
+			return errors.Annotatef(err, "getting status of task %q", upid)
+		}
+		if status.Status == "stopped" {
+			if status.ExitStatus != "OK" {
+				return errors.Errorf("task %q failed: %s", upid, status.ExitStatus)
+			}
+			return nil
+		}
+		select {
+		case <-c.clock.After(taskPollInterval):
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		}
+	}
+}
+
+// multipartFile returns the multipart form body and content type to
+// upload a file with the given form fields.
+func multipartFile(fields map[string]string, field, filename string, data []byte) ([]byte, string, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for name, value := range fields {
+		if err := w.WriteField(name, value); err != nil {
+			return nil, "", errors.Trace(err)
+		}
+	}
+	part, err := w.CreateFormFile(field, filename)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	if _, err := part.Write(data); err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return buf.Bytes(), w.FormDataContentType(), nil
+}
@@ -0,0 +1,295 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmoxclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/juju/clock"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/provider/proxmox/internal/proxmoxclient"
+	"github.com/juju/juju/provider/proxmox/internal/proxmoxtest"
+)
+
+type clientSuite struct {
+	testing.IsolationSuite
+
+	server *proxmoxtest.Server
+	client *proxmoxclient.Client
+	ctx    context.Context
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.server = proxmoxtest.NewServer("pve1", "pve2")
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	s.client = s.newClient(c, proxmoxtest.Password)
+	s.ctx = context.Background()
+}
+
+func (s *clientSuite) newClient(c *gc.C, password string) *proxmoxclient.Client {
+	client, err := proxmoxclient.New(proxmoxclient.Config{
+		Endpoint:       s.server.Endpoint(),
+		Username:       proxmoxtest.Username,
+		Password:       password,
+		CACertificates: []string{s.server.CACertificate()},
+		Clock:          clock.WallClock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+func (s *clientSuite) TestConfigValidate(c *gc.C) {
+	valid := proxmoxclient.Config{
+		Endpoint: "pve.example.com",
+		Username: "root@pam",
+		Clock:    clock.WallClock,
+	}
+	c.Assert(valid.Validate(), jc.ErrorIsNil)
+
+	config := valid
+	config.Endpoint = ""
+	c.Check(config.Validate(), gc.ErrorMatches, "empty Endpoint not valid")
+	config = valid
+	config.Username = ""
+	c.Check(config.Validate(), gc.ErrorMatches, "empty Username not valid")
+	config = valid
+	config.Clock = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Clock not valid")
+}
+
+func (s *clientSuite) TestParseEndpoint(c *gc.C) {
+	for endpoint, expect := range map[string]string{
+		"pve.example.com":                               "https://pve.example.com:8006",
+		"10.0.0.1:443":                                  "https://10.0.0.1:443",
+		"http://pve.example.com":                        "http://pve.example.com:8006",
+		"https://pve.example.com:8006/api2/json":        "https://pve.example.com:8006",
+		"https://pve.example.com:8006/proxy/api2/json/": "https://pve.example.com:8006/proxy",
+	} {
+		u, err := proxmoxclient.ParseEndpoint(endpoint)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(u.String(), gc.Equals, expect, gc.Commentf("endpoint %q", endpoint))
+	}
+
+	_, err := proxmoxclient.ParseEndpoint("ftp://pve.example.com")
+	c.Assert(err, gc.ErrorMatches, `endpoint scheme "ftp" not valid`)
+}
+
+func (s *clientSuite) TestUntrustedCertificate(c *gc.C) {
+	client, err := proxmoxclient.New(proxmoxclient.Config{
+		Endpoint: s.server.Endpoint(),
+		Username: proxmoxtest.Username,
+		Password: proxmoxtest.Password,
+		Clock:    clock.WallClock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.Nodes(s.ctx)
+	c.Assert(err, gc.ErrorMatches, `(?s)listing nodes: logging in as "root@pam": .*certificate.*`)
+}
+
+func (s *clientSuite) TestPing(c *gc.C) {
+	err := s.client.Ping(s.ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.server.Requests(), jc.DeepEquals, []string{"GET /version"})
+}
+
+func (s *clientSuite) TestPingNotProxmox(c *gc.C) {
+	s.server.SetError("GET", "/version", http.StatusNotFound, "Not Found")
+	err := s.client.Ping(s.ctx)
+	c.Assert(err, gc.ErrorMatches, "404 Not Found")
+}
+
+func (s *clientSuite) TestLoginFailure(c *gc.C) {
+	client := s.newClient(c, "wrong")
+	_, err := client.Nodes(s.ctx)
+	c.Assert(err, gc.ErrorMatches, `listing nodes: logging in as "root@pam": 401 authentication failure`)
+	c.Assert(proxmoxclient.IsAuthorisationFailure(err), jc.IsTrue)
+}
+
+func (s *clientSuite) TestLoginOnce(c *gc.C) {
+	_, err := s.client.Nodes(s.ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.client.VirtualMachines(s.ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.server.Requests(), jc.DeepEquals, []string{
+		"POST /access/ticket",
+		"GET /nodes",
+		"GET /cluster/resources",
+	})
+}
+
+func (s *clientSuite) TestLoginAgainWhenTicketExpires(c *gc.C) {
+	_, err := s.client.Nodes(s.ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	s.server.ExpireTickets()
+	nodes, err := s.client.Nodes(s.ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(nodes, gc.HasLen, 2)
+	c.Assert(s.server.Requests(), jc.DeepEquals, []string{
+		"POST /access/ticket",
+		"GET /nodes",
+		"GET /nodes",
+		"POST /access/ticket",
+		"GET /nodes",
+	})
+}
+
+func (s *clientSuite) TestErrorMessage(c *gc.C) {
+	s.server.SetError("GET", "/nodes", http.StatusForbidden, "Permission check failed (/, Sys.Audit)")
+	_, err := s.client.Nodes(s.ctx)
+	c.Assert(err, gc.ErrorMatches, `listing nodes: 403 Permission check failed \(/, Sys.Audit\)`)
+	c.Assert(proxmoxclient.IsAuthorisationFailure(err), jc.IsTrue)
+}
+
+func (s *clientSuite) TestNodes(c *gc.C) {
+	s.server.SetNodeOnline("pve2", false)
+	nodes, err := s.client.Nodes(s.ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(nodes, jc.DeepEquals, []proxmoxclient.Node{
+		{Name: "pve1", Status: "online"},
+		{Name: "pve2", Status: "offline"},
+	})
+	c.Assert(nodes[0].Online(), jc.IsTrue)
+	c.Assert(nodes[1].Online(), jc.IsFalse)
+}
+
+func (s *clientSuite) TestVirtualMachinesOmitsContainers(c *gc.C) {
+	id := s.server.AddTemplate("pve1", "juju-focal")
+	vms, err := s.client.VirtualMachines(s.ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(vms, gc.HasLen, 1)
+	c.Assert(vms[0].ID, gc.Equals, id)
+	c.Assert(vms[0].Name, gc.Equals, "juju-focal")
+	c.Assert(vms[0].Node, gc.Equals, "pve1")
+	c.Assert(vms[0].IsTemplate(), jc.IsTrue)
+	c.Assert(vms[0].MaxMem, gc.Equals, uint64(512*1024*1024))
+}
+
+func (s *clientSuite) cloneTemplate(c *gc.C, name string) proxmoxclient.VirtualMachine {
+	s.server.AddTemplate("pve1", "juju-focal")
+	vms, err := s.client.VirtualMachines(s.ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := s.client.NextID(s.ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.client.CloneVirtualMachine(s.ctx, proxmoxclient.CloneParams{
+		Template:   vms[0],
+		ID:         id,
+		Name:       name,
+		TargetNode: "pve2",
+		Storage:    "ceph",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return proxmoxclient.VirtualMachine{ID: id, Name: name, Node: "pve2"}
+}
+
+func (s *clientSuite) TestCloneVirtualMachine(c *gc.C) {
+	vm := s.cloneTemplate(c, "juju-abcdef-0")
+	c.Assert(vm.ID, gc.Equals, 101)
+
+	config, err := s.client.VirtualMachineConfig(s.ctx, "pve2", vm.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config["name"], gc.Equals, "juju-abcdef-0")
+	c.Assert(config["scsi0"], gc.Equals, "ceph:vm-101-disk-0,size=2G")
+	c.Assert(config["memory"], gc.Equals, "512")
+}
+
+func (s *clientSuite) TestCloneVirtualMachineFailure(c *gc.C) {
+	s.server.AddTemplate("pve1", "juju-focal")
+	vms, err := s.client.VirtualMachines(s.ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.client.CloneVirtualMachine(s.ctx, proxmoxclient.CloneParams{
+		Template: vms[0],
+		ID:       vms[0].ID,
+		Name:     "juju-abcdef-0",
+	})
+	c.Assert(err, gc.ErrorMatches, `cloning template "juju-focal": 500 unable to create VM 100: config file already exists`)
+}
+
+func (s *clientSuite) TestSetVirtualMachineConfig(c *gc.C) {
+	vm := s.cloneTemplate(c, "juju-abcdef-0")
+	err := s.client.SetVirtualMachineConfig(s.ctx, vm.Node, vm.ID, url.Values{
+		"cores": {"4"},
+		"scsi1": {"ceph:10"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	config, err := s.client.VirtualMachineConfig(s.ctx, vm.Node, vm.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config["cores"], gc.Equals, "4")
+	c.Assert(config["scsi1"], gc.Equals, "ceph:vm-101-disk-1,size=10G")
+
+	err = s.client.SetVirtualMachineConfig(s.ctx, vm.Node, vm.ID, url.Values{"delete": {"scsi1"}})
+	c.Assert(err, jc.ErrorIsNil)
+	config, err = s.client.VirtualMachineConfig(s.ctx, vm.Node, vm.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config["scsi1"], gc.Equals, "")
+	c.Assert(config["unused0"], gc.Equals, "ceph:vm-101-disk-1")
+}
+
+func (s *clientSuite) TestResizeDisk(c *gc.C) {
+	vm := s.cloneTemplate(c, "juju-abcdef-0")
+	err := s.client.ResizeDisk(s.ctx, vm.Node, vm.ID, "scsi0", 8192)
+	c.Assert(err, jc.ErrorIsNil)
+	config, err := s.client.VirtualMachineConfig(s.ctx, vm.Node, vm.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config["scsi0"], gc.Equals, "ceph:vm-101-disk-0,size=8192M")
+}
+
+func (s *clientSuite) TestStartStopDelete(c *gc.C) {
+	vm := s.cloneTemplate(c, "juju-abcdef-0")
+	err := s.client.StartVirtualMachine(s.ctx, vm.Node, vm.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	fake, _ := s.server.VirtualMachine(vm.Name)
+	c.Assert(fake.Running, jc.IsTrue)
+
+	err = s.client.DeleteVirtualMachine(s.ctx, vm.Node, vm.ID)
+	c.Assert(err, gc.ErrorMatches, `deleting virtual machine 101: 500 VM 101 is running - destroy failed`)
+
+	err = s.client.StopVirtualMachine(s.ctx, vm.Node, vm.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.client.DeleteVirtualMachine(s.ctx, vm.Node, vm.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.server.VirtualMachineNames(), gc.HasLen, 0)
+	c.Assert(s.server.VolumeIDs(), jc.DeepEquals, []string{"local-lvm:base-100-disk-0"})
+}
+
+func (s *clientSuite) TestWaitsForTasks(c *gc.C) {
+	vm := s.cloneTemplate(c, "juju-abcdef-0")
+	requests := s.server.Requests()
+	c.Assert(requests[len(requests)-2], gc.Equals, "POST /nodes/pve1/qemu/100/clone")
+	c.Assert(requests[len(requests)-1], gc.Matches, "GET /nodes/pve1/tasks/UPID:pve1:.*:qmclone:100:root@pam:/status")
+	c.Assert(vm.ID, gc.Equals, 101)
+}
+
+func (s *clientSuite) TestUploadAndDeleteISO(c *gc.C) {
+	err := s.client.UploadISO(s.ctx, "pve1", "local", "seed.iso", []byte("iso data"))
+	c.Assert(err, jc.ErrorIsNil)
+	data, ok := s.server.Volume("local:iso/seed.iso")
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(string(data), gc.Equals, "iso data")
+
+	err = s.client.DeleteVolume(s.ctx, "pve1", "local:iso/seed.iso")
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.server.Volume("local:iso/seed.iso")
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *clientSuite) TestGuestAddresses(c *gc.C) {
+	vm := s.cloneTemplate(c, "juju-abcdef-0")
+	err := s.client.SetVirtualMachineConfig(s.ctx, vm.Node, vm.ID, url.Values{"agent": {"1"}})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.client.StartVirtualMachine(s.ctx, vm.Node, vm.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	s.server.SetAddresses(vm.Name, "10.0.0.5", "2001:db8::5")
+
+	addresses, err := s.client.GuestAddresses(s.ctx, vm.Node, vm.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addresses, jc.DeepEquals, []string{"10.0.0.5", "2001:db8::5"})
+}
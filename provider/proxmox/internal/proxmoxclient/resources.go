@@ -0,0 +1,269 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmoxclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// Node is a node of a Proxmox VE cluster.
+type Node struct {
+	Name   string `json:"node"`
+	Status string `json:"status"`
+}
+
+// Online returns true if the node is online.
+func (n Node) Online() bool {
+	return n.Status == "online"
+}
+
+// VirtualMachine is a QEMU virtual machine, or template.
+type VirtualMachine struct {
+	ID       int    `json:"vmid"`
+	Name     string `json:"name"`
+	Node     string `json:"node"`
+	Status   string `json:"status"`
+	Template int    `json:"template"`
+
+	// MaxCPU is the number of CPU cores.
+	MaxCPU int `json:"maxcpu"`
+
+	// MaxMem is the amount of memory in bytes.
+	MaxMem uint64 `json:"maxmem"`
+
+	// MaxDisk is the size of the boot disk in bytes.
+	MaxDisk uint64 `json:"maxdisk"`
+}
+
+// IsTemplate returns true if the virtual machine is a template.
+func (vm VirtualMachine) IsTemplate() bool {
+	return vm.Template != 0
+}
+
+// VirtualMachineConfig holds the configuration of a virtual machine,
+// such as its cores, memory, disks and description.
+type VirtualMachineConfig map[string]string
+
+// Nodes returns the nodes of the cluster.
+func (c *Client) Nodes(ctx context.Context) ([]Node, error) {
+	var nodes []Node
+	if err := c.do(ctx, request{method: "GET", path: "/nodes"}, &nodes); err != nil {
+		return nil, errors.Annotate(err, "listing nodes")
+	}
+	return nodes, nil
+}
+
+// VirtualMachines returns all of the QEMU virtual machines and
+// templates in the cluster.
+func (c *Client) VirtualMachines(ctx context.Context) ([]VirtualMachine, error) {
+	var resources []struct {
+		VirtualMachine
+		Type string `json:"type"`
+	}
+	req := request{
+		method: "GET",
+		path:   "/cluster/resources",
+		values: url.Values{"type": {"vm"}},
+	}
+	if err := c.do(ctx, req, &resources); err != nil {
+		return nil, errors.Annotate(err, "listing virtual machines")
+	}
+	var vms []VirtualMachine
+	for _, r := range resources {
+		// Containers are listed too.
+		if r.Type == "qemu" {
+			vms = append(vms, r.VirtualMachine)
+		}
+	}
+	return vms, nil
+}
+
+func vmPath(node string, id int, elem ...string) string {
+	path := fmt.Sprintf("/nodes/%s/qemu/%d", url.PathEscape(node), id)
+	if len(elem) > 0 {
+		path += "/" + strings.Join(elem, "/")
+	}
+	return path
+}
+
+// VirtualMachineConfig returns the configuration of a virtual machine.
+func (c *Client) VirtualMachineConfig(ctx context.Context, node string, id int) (VirtualMachineConfig, error) {
+	var values map[string]interface{}
+	if err := c.do(ctx, request{method: "GET", path: vmPath(node, id, "config")}, &values); err != nil {
+		return nil, errors.Annotatef(err, "getting config of virtual machine %d", id)
+	}
+	config := make(VirtualMachineConfig, len(values))
+	for k, v := range values {
+		config[k] = fmt.Sprint(v)
+	}
+	return config, nil
+}
+
+// SetVirtualMachineConfig updates the configuration of a virtual
+// machine. Disks given as "<storage>:<size in GiB>" are allocated, and
+// options given in the "delete" value are removed.
+func (c *Client) SetVirtualMachineConfig(ctx context.Context, node string, id int, config url.Values) error {
+	req := request{method: "POST", path: vmPath(node, id, "config"), values: config}
+	return errors.Annotatef(c.doTask(ctx, req), "configuring virtual machine %d", id)
+}
+
+// NextID returns the next free virtual machine ID.
+func (c *Client) NextID(ctx context.Context) (int, error) {
+	var id json.Number
+	if err := c.do(ctx, request{method: "GET", path: "/cluster/nextid"}, &id); err != nil {
+		return 0, errors.Annotate(err, "getting next virtual machine ID")
+	}
+	next, err := id.Int64()
+	if err != nil {
+		return 0, errors.Annotatef(err, "parsing virtual machine ID %q", id)
+	}
+	return int(next), nil
+}
+
+// CloneParams holds the parameters for cloning a template.
+type CloneParams struct {
+	// Template is the template to clone.
+	Template VirtualMachine
+
+	// ID is the ID of the new virtual machine.
+	ID int
+
+	// Name is the name of the new virtual machine.
+	Name string
+
+	// TargetNode is the node to create the virtual machine on.
+	// The template's node is used if it's empty.
+	TargetNode string
+
+	// Storage is the storage to create the virtual machine's
+	// disks on. The template's storage is used if it's empty.
+	Storage string
+}
+
+// CloneVirtualMachine creates a new virtual machine as a full clone
+// of a template, and waits for the clone to finish.
+func (c *Client) CloneVirtualMachine(ctx context.Context, args CloneParams) error {
+	values := url.Values{
+		"newid": {strconv.Itoa(args.ID)},
+		"name":  {args.Name},
+		"full":  {"1"},
+	}
+	if args.TargetNode != "" && args.TargetNode != args.Template.Node {
+		values.Set("target", args.TargetNode)
+	}
+	if args.Storage != "" {
+		values.Set("storage", args.Storage)
+	}
+	req := request{
+		method: "POST",
+		path:   vmPath(args.Template.Node, args.Template.ID, "clone"),
+		values: values,
+	}
+	return errors.Annotatef(c.doTask(ctx, req), "cloning template %q", args.Template.Name)
+}
+
+// ResizeDisk grows a virtual machine's disk to the given size in MiB.
+func (c *Client) ResizeDisk(ctx context.Context, node string, id int, disk string, sizeMiB uint64) error {
+	req := request{
+		method: "PUT",
+		path:   vmPath(node, id, "resize"),
+		values: url.Values{
+			"disk": {disk},
+			"size": {fmt.Sprintf("%dM", sizeMiB)},
+		},
+	}
+	return errors.Annotatef(c.doTask(ctx, req), "resizing disk %q of virtual machine %d", disk, id)
+}
+
+// StartVirtualMachine starts a virtual machine.
+func (c *Client) StartVirtualMachine(ctx context.Context, node string, id int) error {
+	req := request{method: "POST", path: vmPath(node, id, "status", "start"), values: url.Values{}}
+	return errors.Annotatef(c.doTask(ctx, req), "starting virtual machine %d", id)
+}
+
+// StopVirtualMachine stops a virtual machine immediately, without
+// shutting down its operating system.
+func (c *Client) StopVirtualMachine(ctx context.Context, node string, id int) error {
+	req := request{method: "POST", path: vmPath(node, id, "status", "stop"), values: url.Values{}}
+	return errors.Annotatef(c.doTask(ctx, req), "stopping virtual machine %d", id)
+}
+
+// DeleteVirtualMachine deletes a stopped virtual machine and its disks.
+func (c *Client) DeleteVirtualMachine(ctx context.Context, node string, id int) error {
+	req := request{
+		method: "DELETE",
+		path:   vmPath(node, id),
+		values: url.Values{"purge": {"1"}},
+	}
+	return errors.Annotatef(c.doTask(ctx, req), "deleting virtual machine %d", id)
+}
+
+// UploadISO uploads an ISO image to a storage on a node, with
+// the given file name.
+func (c *Client) UploadISO(ctx context.Context, node, storage, filename string, data []byte) error {
+	body, contentType, err := multipartFile(map[string]string{"content": "iso"}, "filename", filename, data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req := request{
+		method:      "POST",
+		path:        fmt.Sprintf("/nodes/%s/storage/%s/upload", url.PathEscape(node), url.PathEscape(storage)),
+		body:        body,
+		contentType: contentType,
+	}
+	return errors.Annotatef(c.doTask(ctx, req), "uploading %q to storage %q", filename, storage)
+}
+
+// DeleteVolume deletes a volume, given its volume ID, e.g.
+// "local:iso/seed.iso", from a storage on a node.
+func (c *Client) DeleteVolume(ctx context.Context, node, volumeID string) error {
+	storage := volumeID
+	if i := strings.Index(volumeID, ":"); i > 0 {
+		storage = volumeID[:i]
+	}
+	req := request{
+		method: "DELETE",
+		path: fmt.Sprintf("/nodes/%s/storage/%s/content/%s",
+			url.PathEscape(node), url.PathEscape(storage), url.PathEscape(volumeID),
+		),
+	}
+	return errors.Annotatef(c.doTask(ctx, req), "deleting volume %q", volumeID)
+}
+
+// GuestAddresses returns the IP addresses of a virtual machine, as
+// reported by the QEMU guest agent running in it. Loopback and
+// link-local addresses are omitted.
+func (c *Client) GuestAddresses(ctx context.Context, node string, id int) ([]string, error) {
+	var result struct {
+		Result []struct {
+			Name        string `json:"name"`
+			IPAddresses []struct {
+				Address string `json:"ip-address"`
+			} `json:"ip-addresses"`
+		} `json:"result"`
+	}
+	req := request{method: "GET", path: vmPath(node, id, "agent", "network-get-interfaces")}
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, errors.Annotatef(err, "getting addresses of virtual machine %d", id)
+	}
+	var addresses []string
+	for _, iface := range result.Result {
+		for _, addr := range iface.IPAddresses {
+			ip := net.ParseIP(addr.Address)
+			if ip == nil || ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+				continue
+			}
+			addresses = append(addresses, addr.Address)
+		}
+	}
+	return addresses, nil
+}
@@ -0,0 +1,670 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package proxmoxtest provides a fake Proxmox VE API server, holding
+// the cluster's nodes, virtual machines and storage in memory.
+package proxmoxtest
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// Username and Password are the credentials accepted by the server.
+	Username = "root@pam"
+	Password = "secret"
+
+	apiPath = "/api2/json"
+)
+
+// VirtualMachine is a virtual machine, or template, held by the server.
+type VirtualMachine struct {
+	ID       int
+	Name     string
+	Node     string
+	Running  bool
+	Template bool
+
+	// Config holds the virtual machine's configuration,
+	// excluding its name.
+	Config map[string]string
+
+	// Addresses holds the IP addresses reported
+	// by the guest agent when it's running.
+	Addresses []string
+}
+
+// Server is a fake Proxmox VE API server.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	nodes    map[string]bool
+	vms      map[int]*VirtualMachine
+	volumes  map[string][]byte
+	tickets  map[string]bool
+	nextTask int
+	errors   map[string]*apiError
+	requests []string
+}
+
+type apiError struct {
+	status  int
+	message string
+}
+
+// NewServer returns a new, started, Server whose cluster has the
+// given online nodes.
+func NewServer(nodes ...string) *Server {
+	s := &Server{
+		nodes:   make(map[string]bool),
+		vms:     make(map[int]*VirtualMachine),
+		volumes: make(map[string][]byte),
+		tickets: make(map[string]bool),
+		errors:  make(map[string]*apiError),
+	}
+	for _, node := range nodes {
+		s.nodes[node] = true
+	}
+	s.Server = httptest.NewUnstartedServer(http.HandlerFunc(s.serveHTTP))
+	// Don't log the handshake errors of clients which
+	// don't trust the server's certificate.
+	s.Server.Config.ErrorLog = log.New(ioutil.Discard, "", 0)
+	s.Server.StartTLS()
+	return s
+}
+
+// Endpoint returns the endpoint to use for the server.
+func (s *Server) Endpoint() string {
+	return s.URL
+}
+
+// CACertificate returns the PEM encoded CA certificate for the server.
+func (s *Server) CACertificate() string {
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: s.Certificate().Raw,
+	}))
+}
+
+// SetNodeOnline sets whether a node is online.
+func (s *Server) SetNodeOnline(node string, online bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nodes[node] = online
+}
+
+// AddTemplate adds a template with a 2GiB boot disk to the cluster,
+// returning its ID.
+func (s *Server) AddTemplate(node, name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.nextID()
+	volume := fmt.Sprintf("local-lvm:base-%d-disk-0", id)
+	s.volumes[volume] = nil
+	s.vms[id] = &VirtualMachine{
+		ID:       id,
+		Name:     name,
+		Node:     node,
+		Template: true,
+		Config: map[string]string{
+			"cores":    "1",
+			"memory":   "512",
+			"bootdisk": "scsi0",
+			"scsi0":    volume + ",size=2G",
+			"template": "1",
+		},
+	}
+	return id
+}
+
+// VirtualMachine returns a copy of the virtual machine with the given
+// name, and whether it exists.
+func (s *Server) VirtualMachine(name string) (VirtualMachine, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, vm := range s.vms {
+		if vm.Name == name {
+			result := *vm
+			result.Config = make(map[string]string)
+			for k, v := range vm.Config {
+				result.Config[k] = v
+			}
+			return result, true
+		}
+	}
+	return VirtualMachine{}, false
+}
+
+// VirtualMachineNames returns the sorted names of the virtual machines
+// which aren't templates.
+func (s *Server) VirtualMachineNames() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var names []string
+	for _, vm := range s.vms {
+		if !vm.Template {
+			names = append(names, vm.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetAddresses sets the addresses reported by the guest
+// agent of the virtual machine with the given name.
+func (s *Server) SetAddresses(name string, addresses ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, vm := range s.vms {
+		if vm.Name == name {
+			vm.Addresses = addresses
+		}
+	}
+}
+
+// Volume returns the contents of a storage volume,
+// and whether it exists.
+func (s *Server) Volume(volumeID string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.volumes[volumeID]
+	return data, ok
+}
+
+// VolumeIDs returns the sorted IDs of the volumes in all storages.
+func (s *Server) VolumeIDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []string
+	for id := range s.volumes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ExpireTickets invalidates all of the authentication tickets
+// issued by the server.
+func (s *Server) ExpireTickets() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tickets = make(map[string]bool)
+}
+
+// SetError makes requests with the given method, whose path matches
+// the given regular expression, fail with the status and message.
+// The path excludes the "/api2/json" prefix.
+func (s *Server) SetError(method, pathPattern string, status int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors[method+" "+pathPattern] = &apiError{status, message}
+}
+
+// Requests returns the method and path of each
+// request made to the server, in order.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Volume IDs in paths may contain escaped slashes.
+	path := strings.TrimPrefix(req.URL.EscapedPath(), apiPath)
+	s.requests = append(s.requests, req.Method+" "+path)
+	for pattern, apiErr := range s.errors {
+		parts := strings.SplitN(pattern, " ", 2)
+		if parts[0] == req.Method && regexp.MustCompile("^"+parts[1]+"$").MatchString(path) {
+			writeError(w, apiErr.status, apiErr.message)
+			return
+		}
+	}
+
+	if err := parseForm(req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if path == "/access/ticket" && req.Method == "POST" {
+		s.login(w, req)
+		return
+	}
+	if !s.authorised(req) {
+		writeError(w, http.StatusUnauthorized, "permission denied - invalid PVE ticket")
+		return
+	}
+
+	for _, route := range routes {
+		if route.method != req.Method {
+			continue
+		}
+		if match := route.path.FindStringSubmatch(path); match != nil {
+			args := match[1:]
+			for i, arg := range args {
+				args[i], _ = url.PathUnescape(arg)
+			}
+			result, apiErr := route.handler(s, req, args)
+			if apiErr != nil {
+				writeError(w, apiErr.status, apiErr.message)
+				return
+			}
+			writeData(w, result)
+			return
+		}
+	}
+	writeError(w, http.StatusNotImplemented, fmt.Sprintf("Method '%s %s' not implemented", req.Method, path))
+}
+
+func parseForm(req *http.Request) error {
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/form-data") {
+		return req.ParseMultipartForm(1 << 20)
+	}
+	return req.ParseForm()
+}
+
+func (s *Server) login(w http.ResponseWriter, req *http.Request) {
+	if req.Form.Get("username") != Username || req.Form.Get("password") != Password {
+		writeError(w, http.StatusUnauthorized, "authentication failure")
+		return
+	}
+	s.nextTask++
+	ticket := fmt.Sprintf("PVE:%s:%08X", Username, s.nextTask)
+	s.tickets[ticket] = true
+	writeData(w, map[string]string{
+		"username":            Username,
+		"ticket":              ticket,
+		"CSRFPreventionToken": "csrf-" + ticket,
+	})
+}
+
+func (s *Server) authorised(req *http.Request) bool {
+	cookie, err := req.Cookie("PVEAuthCookie")
+	if err != nil || !s.tickets[cookie.Value] {
+		return false
+	}
+	if req.Method != "GET" && req.Header.Get("CSRFPreventionToken") != "csrf-"+cookie.Value {
+		return false
+	}
+	return true
+}
+
+func writeData(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	// Like the real API, the message is in the status line, which
+	// can only be written by taking over the connection.
+	conn, buf, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		http.Error(w, message, status)
+		return
+	}
+	defer conn.Close()
+	body := `{"data":null}`
+	fmt.Fprintf(buf, "HTTP/1.1 %d %s\r\n", status, message)
+	fmt.Fprintf(buf, "Content-Type: application/json\r\nContent-Length: %d\r\nConnection: close\r\n\r\n", len(body))
+	buf.WriteString(body)
+	_ = buf.Flush()
+}
+
+type route struct {
+	method  string
+	path    *regexp.Regexp
+	handler func(s *Server, req *http.Request, args []string) (interface{}, *apiError)
+}
+
+var routes = []route{
+	{"GET", regexp.MustCompile(`^/version$`), (*Server).version},
+	{"GET", regexp.MustCompile(`^/nodes$`), (*Server).listNodes},
+	{"GET", regexp.MustCompile(`^/cluster/resources$`), (*Server).listResources},
+	{"GET", regexp.MustCompile(`^/cluster/nextid$`), (*Server).getNextID},
+	{"GET", regexp.MustCompile(`^/nodes/([^/]+)/tasks/([^/]+)/status$`), (*Server).taskStatus},
+	{"GET", regexp.MustCompile(`^/nodes/([^/]+)/qemu/(\d+)/config$`), (*Server).getConfig},
+	{"POST", regexp.MustCompile(`^/nodes/([^/]+)/qemu/(\d+)/config$`), (*Server).setConfig},
+	{"POST", regexp.MustCompile(`^/nodes/([^/]+)/qemu/(\d+)/clone$`), (*Server).clone},
+	{"PUT", regexp.MustCompile(`^/nodes/([^/]+)/qemu/(\d+)/resize$`), (*Server).resize},
+	{"POST", regexp.MustCompile(`^/nodes/([^/]+)/qemu/(\d+)/status/(start|stop)$`), (*Server).setStatus},
+	{"DELETE", regexp.MustCompile(`^/nodes/([^/]+)/qemu/(\d+)$`), (*Server).deleteVM},
+	{"GET", regexp.MustCompile(`^/nodes/([^/]+)/qemu/(\d+)/agent/network-get-interfaces$`), (*Server).guestInterfaces},
+	{"POST", regexp.MustCompile(`^/nodes/([^/]+)/storage/([^/]+)/upload$`), (*Server).upload},
+	{"DELETE", regexp.MustCompile(`^/nodes/([^/]+)/storage/([^/]+)/content/([^/]+)$`), (*Server).deleteVolume},
+}
+
+func (s *Server) version(req *http.Request, args []string) (interface{}, *apiError) {
+	return map[string]string{"version": "6.2-4", "release": "6.2"}, nil
+}
+
+func (s *Server) listNodes(req *http.Request, args []string) (interface{}, *apiError) {
+	var nodes []map[string]string
+	for name, online := range s.nodes {
+		status := "offline"
+		if online {
+			status = "online"
+		}
+		nodes = append(nodes, map[string]string{"node": name, "status": status})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i]["node"] < nodes[j]["node"]
+	})
+	return nodes, nil
+}
+
+func (s *Server) listResources(req *http.Request, args []string) (interface{}, *apiError) {
+	var resources []map[string]interface{}
+	for _, vm := range s.vms {
+		status := "stopped"
+		if vm.Running {
+			status = "running"
+		}
+		template := 0
+		if vm.Template {
+			template = 1
+		}
+		cores, _ := strconv.Atoi(vm.Config["cores"])
+		memory, _ := strconv.ParseUint(vm.Config["memory"], 10, 64)
+		resources = append(resources, map[string]interface{}{
+			"id":       fmt.Sprintf("qemu/%d", vm.ID),
+			"type":     "qemu",
+			"vmid":     vm.ID,
+			"name":     vm.Name,
+			"node":     vm.Node,
+			"status":   status,
+			"template": template,
+			"maxcpu":   cores,
+			"maxmem":   memory * 1024 * 1024,
+		})
+	}
+	// Containers are listed as well.
+	resources = append(resources, map[string]interface{}{
+		"id":     "lxc/999",
+		"type":   "lxc",
+		"vmid":   999,
+		"name":   "container",
+		"status": "running",
+	})
+	return resources, nil
+}
+
+func (s *Server) nextID() int {
+	id := 100
+	for s.vms[id] != nil {
+		id++
+	}
+	return id
+}
+
+func (s *Server) getNextID(req *http.Request, args []string) (interface{}, *apiError) {
+	return strconv.Itoa(s.nextID()), nil
+}
+
+// startTask returns the ID of a new task. Tasks finish immediately.
+func (s *Server) startTask(node, taskType string, id int) string {
+	s.nextTask++
+	return fmt.Sprintf("UPID:%s:%08X:00000000:5F000000:%s:%d:%s:", node, s.nextTask, taskType, id, Username)
+}
+
+func (s *Server) taskStatus(req *http.Request, args []string) (interface{}, *apiError) {
+	if !strings.HasPrefix(args[1], "UPID:"+args[0]+":") {
+		return nil, &apiError{http.StatusBadRequest, "invalid task ID"}
+	}
+	return map[string]string{"status": "stopped", "exitstatus": "OK"}, nil
+}
+
+func (s *Server) vm(node, id string) (*VirtualMachine, *apiError) {
+	vmid, _ := strconv.Atoi(id)
+	vm := s.vms[vmid]
+	if vm == nil || vm.Node != node {
+		return nil, &apiError{http.StatusInternalServerError, fmt.Sprintf("Configuration file 'nodes/%s/qemu-server/%s.conf' does not exist", node, id)}
+	}
+	return vm, nil
+}
+
+func (s *Server) getConfig(req *http.Request, args []string) (interface{}, *apiError) {
+	vm, apiErr := s.vm(args[0], args[1])
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	config := map[string]interface{}{"name": vm.Name}
+	for k, v := range vm.Config {
+		if n, err := strconv.Atoi(v); err == nil {
+			config[k] = n
+		} else {
+			config[k] = v
+		}
+	}
+	return config, nil
+}
+
+var diskKey = regexp.MustCompile(`^(ide|sata|scsi|virtio)\d+$`)
+var newDisk = regexp.MustCompile(`^([^:]+):(\d+)$`)
+
+func (s *Server) setConfig(req *http.Request, args []string) (interface{}, *apiError) {
+	vm, apiErr := s.vm(args[0], args[1])
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	force := req.Form.Get("force") == "1"
+	for _, key := range strings.Split(req.Form.Get("delete"), ",") {
+		value, ok := vm.Config[key]
+		if !ok {
+			continue
+		}
+		delete(vm.Config, key)
+		volume := strings.Split(value, ",")[0]
+		switch {
+		case strings.HasPrefix(key, "unused") || (force && diskKey.MatchString(key)):
+			delete(s.volumes, volume)
+		case diskKey.MatchString(key) && !strings.Contains(value, "media=cdrom"):
+			// Detached disks are kept as unused disks.
+			for i := 0; ; i++ {
+				unused := fmt.Sprintf("unused%d", i)
+				if _, ok := vm.Config[unused]; !ok {
+					vm.Config[unused] = volume
+					break
+				}
+			}
+		}
+	}
+	for key, values := range req.Form {
+		switch key {
+		case "delete", "force", "digest":
+			continue
+		}
+		value := values[0]
+		if match := newDisk.FindStringSubmatch(strings.Split(value, ",")[0]); diskKey.MatchString(key) && match != nil {
+			// Allocate a new disk.
+			volume := s.newVolumeName(match[1], vm.ID)
+			s.volumes[volume] = nil
+			value = fmt.Sprintf("%s,size=%sG", volume, match[2])
+		} else if diskKey.MatchString(key) && !strings.Contains(value, "media=cdrom") {
+			// Attaching an existing volume removes it from the unused disks.
+			volume := strings.Split(value, ",")[0]
+			if _, ok := s.volumes[volume]; !ok {
+				return nil, &apiError{http.StatusInternalServerError, fmt.Sprintf("volume '%s' does not exist", volume)}
+			}
+			for k, v := range vm.Config {
+				if strings.HasPrefix(k, "unused") && v == volume {
+					delete(vm.Config, k)
+				}
+			}
+		}
+		if key == "name" {
+			vm.Name = value
+			continue
+		}
+		vm.Config[key] = value
+	}
+	return s.startTask(vm.Node, "qmconfig", vm.ID), nil
+}
+
+func (s *Server) newVolumeName(storage string, id int) string {
+	for i := 0; ; i++ {
+		volume := fmt.Sprintf("%s:vm-%d-disk-%d", storage, id, i)
+		if _, ok := s.volumes[volume]; !ok {
+			return volume
+		}
+	}
+}
+
+func (s *Server) clone(req *http.Request, args []string) (interface{}, *apiError) {
+	template, apiErr := s.vm(args[0], args[1])
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	newID, err := strconv.Atoi(req.Form.Get("newid"))
+	if err != nil {
+		return nil, &apiError{http.StatusBadRequest, "Parameter verification failed."}
+	}
+	if s.vms[newID] != nil {
+		return nil, &apiError{http.StatusInternalServerError, fmt.Sprintf("unable to create VM %d: config file already exists", newID)}
+	}
+	node := template.Node
+	if target := req.Form.Get("target"); target != "" {
+		if _, ok := s.nodes[target]; !ok {
+			return nil, &apiError{http.StatusInternalServerError, fmt.Sprintf("no such cluster node '%s'", target)}
+		}
+		node = target
+	}
+	vm := &VirtualMachine{
+		ID:     newID,
+		Name:   req.Form.Get("name"),
+		Node:   node,
+		Config: make(map[string]string),
+	}
+	for k, v := range template.Config {
+		if k == "template" {
+			continue
+		}
+		if diskKey.MatchString(k) && !strings.Contains(v, "media=cdrom") {
+			parts := strings.SplitN(v, ",", 2)
+			storage := strings.Split(parts[0], ":")[0]
+			if target := req.Form.Get("storage"); target != "" {
+				storage = target
+			}
+			volume := s.newVolumeName(storage, newID)
+			s.volumes[volume] = nil
+			v = volume
+			if len(parts) > 1 {
+				v += "," + parts[1]
+			}
+		}
+		vm.Config[k] = v
+	}
+	s.vms[newID] = vm
+	return s.startTask(template.Node, "qmclone", template.ID), nil
+}
+
+var diskSize = regexp.MustCompile(`size=(\d+)([MG])`)
+
+func (s *Server) resize(req *http.Request, args []string) (interface{}, *apiError) {
+	vm, apiErr := s.vm(args[0], args[1])
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	disk := req.Form.Get("disk")
+	value, ok := vm.Config[disk]
+	if !ok {
+		return nil, &apiError{http.StatusInternalServerError, fmt.Sprintf("disk '%s' does not exist", disk)}
+	}
+	size := req.Form.Get("size")
+	if diskSize.MatchString(value) {
+		value = diskSize.ReplaceAllString(value, "size="+size)
+	} else {
+		value += ",size=" + size
+	}
+	vm.Config[disk] = value
+	return s.startTask(vm.Node, "resize", vm.ID), nil
+}
+
+func (s *Server) setStatus(req *http.Request, args []string) (interface{}, *apiError) {
+	vm, apiErr := s.vm(args[0], args[1])
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	vm.Running = args[2] == "start"
+	return s.startTask(vm.Node, "qm"+args[2], vm.ID), nil
+}
+
+func (s *Server) deleteVM(req *http.Request, args []string) (interface{}, *apiError) {
+	vm, apiErr := s.vm(args[0], args[1])
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if vm.Running {
+		return nil, &apiError{http.StatusInternalServerError, fmt.Sprintf("VM %d is running - destroy failed", vm.ID)}
+	}
+	for k, v := range vm.Config {
+		if (diskKey.MatchString(k) || strings.HasPrefix(k, "unused")) && !strings.Contains(v, "media=cdrom") {
+			delete(s.volumes, strings.Split(v, ",")[0])
+		}
+	}
+	delete(s.vms, vm.ID)
+	return s.startTask(vm.Node, "qmdestroy", vm.ID), nil
+}
+
+func (s *Server) guestInterfaces(req *http.Request, args []string) (interface{}, *apiError) {
+	vm, apiErr := s.vm(args[0], args[1])
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if !vm.Running || vm.Config["agent"] != "1" {
+		return nil, &apiError{http.StatusInternalServerError, "QEMU guest agent is not running"}
+	}
+	addresses := []map[string]string{{"ip-address": "127.0.0.1"}, {"ip-address": "fe80::1"}}
+	for _, addr := range vm.Addresses {
+		addresses = append(addresses, map[string]string{"ip-address": addr})
+	}
+	return map[string]interface{}{
+		"result": []map[string]interface{}{{
+			"name":         "eth0",
+			"ip-addresses": addresses,
+		}},
+	}, nil
+}
+
+func (s *Server) upload(req *http.Request, args []string) (interface{}, *apiError) {
+	if req.MultipartForm == nil || req.Form.Get("content") != "iso" {
+		return nil, &apiError{http.StatusBadRequest, "Parameter verification failed."}
+	}
+	files := req.MultipartForm.File["filename"]
+	if len(files) != 1 {
+		return nil, &apiError{http.StatusBadRequest, "Parameter verification failed."}
+	}
+	f, err := files[0].Open()
+	if err != nil {
+		return nil, &apiError{http.StatusInternalServerError, err.Error()}
+	}
+	defer f.Close()
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, &apiError{http.StatusInternalServerError, err.Error()}
+	}
+	s.volumes[fmt.Sprintf("%s:iso/%s", args[1], files[0].Filename)] = data
+	return s.startTask(args[0], "imgcopy", 0), nil
+}
+
+func (s *Server) deleteVolume(req *http.Request, args []string) (interface{}, *apiError) {
+	volume := args[2]
+	if _, ok := s.volumes[volume]; !ok {
+		return nil, &apiError{http.StatusInternalServerError, fmt.Sprintf("volume '%s' does not exist", volume)}
+	}
+	delete(s.volumes, volume)
+	return s.startTask(args[0], "imgdel", 0), nil
+}
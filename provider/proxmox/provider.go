@@ -0,0 +1,155 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmox
+
+import (
+	stdcontext "context"
+	"crypto/x509"
+	stderrors "errors"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/jsonschema"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	environscloudspec "github.com/juju/juju/environs/cloudspec"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/proxmox/internal/proxmoxclient"
+)
+
+var logger = loggo.GetLogger("juju.provider.proxmox")
+
+type environProvider struct {
+	environProviderCredentials
+	newClient NewClientFunc
+	clock     clock.Clock
+}
+
+// EnvironProviderConfig contains configuration for the EnvironProvider.
+type EnvironProviderConfig struct {
+	// NewClient is used to create clients for the Proxmox VE API.
+	NewClient NewClientFunc
+
+	// Clock is used by the clients to wait for tasks to complete.
+	Clock clock.Clock
+}
+
+// NewEnvironProvider returns a new environs.EnvironProvider that will
+// manage Proxmox VE clusters with clients from the given function.
+func NewEnvironProvider(config EnvironProviderConfig) environs.CloudEnvironProvider {
+	return &environProvider{
+		newClient: config.NewClient,
+		clock:     config.Clock,
+	}
+}
+
+// Version implements environs.EnvironProvider.
+func (p *environProvider) Version() int {
+	return 0
+}
+
+// Open implements environs.EnvironProvider.
+func (p *environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	env, err := newEnviron(p, args.Cloud, args.Config)
+	return env, errors.Trace(err)
+}
+
+var cloudSchema = &jsonschema.Schema{
+	Type:     []jsonschema.Type{jsonschema.ObjectType},
+	Required: []string{cloud.EndpointKey, cloud.AuthTypesKey},
+	Order:    []string{cloud.EndpointKey, cloud.AuthTypesKey},
+	Properties: map[string]*jsonschema.Schema{
+		cloud.EndpointKey: {
+			Singular: "the API endpoint url or address of a cluster node",
+			Type:     []jsonschema.Type{jsonschema.StringType},
+			Format:   jsonschema.FormatURI,
+		},
+		cloud.AuthTypesKey: {
+			// don't need a prompt, since there's only one choice.
+			Type: []jsonschema.Type{jsonschema.ArrayType},
+			Enum: []interface{}{[]string{string(cloud.UserPassAuthType)}},
+		},
+	},
+}
+
+// CloudSchema returns the schema for adding new clouds of this type.
+func (p *environProvider) CloudSchema() *jsonschema.Schema {
+	return cloudSchema
+}
+
+// Ping tests the connection to the cloud, to verify the endpoint is valid.
+func (p *environProvider) Ping(ctx context.ProviderCallContext, endpoint string) error {
+	client, err := p.newClient(endpoint, "juju@pve", "", nil, p.clock)
+	if err != nil {
+		return errors.Annotatef(err, "invalid endpoint %q", endpoint)
+	}
+	if err := client.Ping(stdcontext.Background()); err != nil {
+		if isUntrustedCertificate(err) {
+			// Proxmox VE uses self-signed certificates by default,
+			// which are trusted through the cloud's CA certificates.
+			logger.Warningf("the certificate of %s is not trusted, it may need adding to the cloud's ca-certificates", endpoint)
+			return nil
+		}
+		logger.Debugf("pinging %q: %v", endpoint, err)
+		return errors.Errorf("no Proxmox VE API available at %s", endpoint)
+	}
+	return nil
+}
+
+func isUntrustedCertificate(err error) bool {
+	var unknownAuthority x509.UnknownAuthorityError
+	return stderrors.As(errors.Cause(err), &unknownAuthority)
+}
+
+// PrepareConfig implements environs.EnvironProvider.
+func (p *environProvider) PrepareConfig(args environs.PrepareConfigParams) (*config.Config, error) {
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	return args.Config, nil
+}
+
+// Validate implements environs.EnvironProvider.
+func (*environProvider) Validate(cfg, old *config.Config) (valid *config.Config, err error) {
+	if old == nil {
+		ecfg, err := newValidConfig(cfg)
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid config")
+		}
+		return ecfg.Config, nil
+	}
+
+	ecfg, err := newValidConfig(old)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid base config")
+	}
+
+	if err := ecfg.update(cfg); err != nil {
+		return nil, errors.Annotate(err, "invalid config change")
+	}
+
+	return ecfg.Config, nil
+}
+
+func validateCloudSpec(spec environscloudspec.CloudSpec) error {
+	if err := spec.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := proxmoxclient.ParseEndpoint(spec.Endpoint); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Credential == nil {
+		return errors.NotValidf("missing credential")
+	}
+	if authType := spec.Credential.AuthType(); authType != cloud.UserPassAuthType {
+		return errors.NotSupportedf("%q auth-type", authType)
+	}
+	return nil
+}
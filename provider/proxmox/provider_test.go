@@ -0,0 +1,121 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmox_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+type providerSuite struct {
+	ProviderFixture
+}
+
+var _ = gc.Suite(&providerSuite{})
+
+func (s *providerSuite) TestRegistered(c *gc.C) {
+	provider, err := environs.Provider("proxmox")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider, gc.NotNil)
+}
+
+func (s *providerSuite) TestOpen(c *gc.C) {
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  s.cloudSpec(),
+		Config: fakeConfig(c),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env.Config().UnknownAttrs()["iso-storage"], gc.Equals, "local")
+}
+
+func (s *providerSuite) TestOpenInvalidCloudSpec(c *gc.C) {
+	spec := s.cloudSpec()
+	spec.Endpoint = "ftp://pve.example.com"
+	_, err := s.provider.Open(environs.OpenParams{
+		Cloud:  spec,
+		Config: fakeConfig(c),
+	})
+	c.Assert(err, gc.ErrorMatches, `validating cloud spec: endpoint scheme "ftp" not valid`)
+}
+
+func (s *providerSuite) TestOpenUnsupportedCredential(c *gc.C) {
+	spec := s.cloudSpec()
+	cred := cloud.NewCredential(cloud.OAuth1AuthType, map[string]string{})
+	spec.Credential = &cred
+	_, err := s.provider.Open(environs.OpenParams{
+		Cloud:  spec,
+		Config: fakeConfig(c),
+	})
+	c.Assert(err, gc.ErrorMatches, `validating cloud spec: "oauth1" auth-type not supported`)
+}
+
+func (s *providerSuite) TestCloudSchema(c *gc.C) {
+	y := []byte(`
+auth-types: [userpass]
+endpoint: https://pve.example.com:8006
+`[1:])
+	var v interface{}
+	err := yaml.Unmarshal(y, &v)
+	c.Assert(err, jc.ErrorIsNil)
+	v, err = utils.ConformYAML(v)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.provider.CloudSchema().Validate(v)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *providerSuite) TestPing(c *gc.C) {
+	// The server's self-signed certificate isn't trusted.
+	err := s.provider.Ping(s.callCtx, s.server.Endpoint())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *providerSuite) TestPingNotProxmox(c *gc.C) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	err := s.provider.Ping(s.callCtx, server.URL)
+	c.Assert(err, gc.ErrorMatches, "no Proxmox VE API available at "+server.URL)
+}
+
+func (s *providerSuite) TestPingInvalidEndpoint(c *gc.C) {
+	err := s.provider.Ping(s.callCtx, "ftp://pve.example.com")
+	c.Assert(err, gc.ErrorMatches, `invalid endpoint "ftp://pve.example.com": endpoint scheme "ftp" not valid`)
+}
+
+func (s *providerSuite) TestValidate(c *gc.C) {
+	cfg, err := s.provider.Validate(fakeConfig(c), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	attrs := cfg.UnknownAttrs()
+	c.Assert(attrs["template-prefix"], gc.Equals, "juju-")
+	c.Assert(attrs["iso-storage"], gc.Equals, "local")
+	c.Assert(attrs["disk-storage"], gc.IsNil)
+
+	_, err = s.provider.Validate(fakeConfig(c, map[string]interface{}{"iso-storage": ""}), nil)
+	c.Assert(err, gc.ErrorMatches, "invalid config: iso-storage: must not be empty")
+
+	cfg, err = s.provider.Validate(fakeConfig(c, map[string]interface{}{"disk-storage": "ceph"}), cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.UnknownAttrs()["disk-storage"], gc.Equals, "ceph")
+}
+
+func (s *providerSuite) TestCredentialSchemas(c *gc.C) {
+	schemas := s.provider.CredentialSchemas()
+	c.Assert(schemas, gc.HasLen, 1)
+	schema, ok := schemas[cloud.UserPassAuthType]
+	c.Assert(ok, jc.IsTrue)
+	_, err := schema.Finalize(map[string]string{"user": "root@pam", "password": "secret"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.provider.DetectCredentials()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
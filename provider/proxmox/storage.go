@@ -0,0 +1,486 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmox
+
+import (
+	stdcontext "context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/proxmox/internal/proxmoxclient"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	storageProviderType = storage.ProviderType("proxmox")
+
+	// storageAttr is the pool attribute naming the Proxmox VE storage
+	// to create volumes on.
+	storageAttr = "storage"
+
+	// maxSCSIDisks is the number of SCSI disks
+	// a virtual machine can have.
+	maxSCSIDisks = 31
+)
+
+var storageConfigFields = schema.Fields{
+	storageAttr: schema.String(),
+}
+
+var storageConfigChecker = schema.FieldMap(
+	storageConfigFields,
+	schema.Defaults{
+		storageAttr: schema.Omit,
+	},
+)
+
+// StorageProviderTypes implements storage.ProviderRegistry.
+func (env *environ) StorageProviderTypes() ([]storage.ProviderType, error) {
+	return []storage.ProviderType{storageProviderType}, nil
+}
+
+// StorageProvider implements storage.ProviderRegistry.
+func (env *environ) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+	if t == storageProviderType {
+		return &storageProvider{env}, nil
+	}
+	return nil, errors.NotFoundf("storage provider %q", t)
+}
+
+type storageProvider struct {
+	env *environ
+}
+
+var _ storage.Provider = (*storageProvider)(nil)
+
+// ValidateConfig is part of the storage.Provider interface.
+func (p *storageProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := storageConfigChecker.Coerce(cfg.Attrs(), nil)
+	return errors.Annotate(err, "validating proxmox storage config")
+}
+
+// Supports is part of the storage.Provider interface.
+func (p *storageProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is part of the storage.Provider interface.
+func (p *storageProvider) Scope() storage.Scope {
+	// Disks can't be moved between virtual machines,
+	// so volumes belong to the machine they're created for.
+	return storage.ScopeMachine
+}
+
+// Dynamic is part of the storage.Provider interface.
+func (p *storageProvider) Dynamic() bool {
+	// Volumes are created by StartInstance, since machine
+	// agents can't call the Proxmox VE API.
+	return false
+}
+
+// Releasable is part of the storage.Provider interface.
+func (p *storageProvider) Releasable() bool {
+	// Disks belong to a virtual machine, and
+	// are deleted along with it.
+	return false
+}
+
+// DefaultPools is part of the storage.Provider interface.
+func (p *storageProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// FilesystemSource is part of the storage.Provider interface.
+func (p *storageProvider) FilesystemSource(providerConfig *storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// VolumeSource is part of the storage.Provider interface.
+func (p *storageProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
+	return &volumeSource{env: p.env}, nil
+}
+
+// volumeSource manages volumes, which are the disks of virtual
+// machines. The ID of a volume is its Proxmox VE volume ID, e.g.
+// "local-lvm:vm-100-disk-1". Volumes are attached as SCSI disks.
+type volumeSource struct {
+	env *environ
+}
+
+var _ storage.VolumeSource = (*volumeSource)(nil)
+
+// virtualMachines returns the model's virtual machines, by instance ID.
+func (v *volumeSource) virtualMachines(ctx context.ProviderCallContext) (map[instance.Id]proxmoxclient.VirtualMachine, error) {
+	vms, err := v.env.virtualMachines(ctx, v.env.namespace.Prefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make(map[instance.Id]proxmoxclient.VirtualMachine)
+	for _, vm := range vms {
+		results[instance.Id(vm.Name)] = vm
+	}
+	return results, nil
+}
+
+func (v *volumeSource) config(ctx context.ProviderCallContext, vm proxmoxclient.VirtualMachine) (proxmoxclient.VirtualMachineConfig, error) {
+	config, err := v.env.client.VirtualMachineConfig(stdcontext.Background(), vm.Node, vm.ID)
+	if err != nil {
+		v.env.handleCredentialError(err, ctx)
+		return nil, errors.Trace(err)
+	}
+	return config, nil
+}
+
+func (v *volumeSource) setConfig(ctx context.ProviderCallContext, vm proxmoxclient.VirtualMachine, values map[string][]string) error {
+	err := v.env.client.SetVirtualMachineConfig(stdcontext.Background(), vm.Node, vm.ID, values)
+	v.env.handleCredentialError(err, ctx)
+	return errors.Trace(err)
+}
+
+// CreateVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) CreateVolumes(ctx context.ProviderCallContext, params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	vms, err := v.virtualMachines(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]storage.CreateVolumesResult, len(params))
+	for i, p := range params {
+		if err := v.ValidateVolumeParams(p); err != nil {
+			results[i].Error = err
+			continue
+		}
+		vm, ok := vms[p.Attachment.InstanceId]
+		if !ok {
+			results[i].Error = errors.NotFoundf("instance %q", p.Attachment.InstanceId)
+			continue
+		}
+		volume, attachment, err := v.createVolume(ctx, p, vm)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating volume %s", p.Tag.Id())
+			continue
+		}
+		results[i].Volume = volume
+		results[i].VolumeAttachment = attachment
+	}
+	return results, nil
+}
+
+func (v *volumeSource) createVolume(
+	ctx context.ProviderCallContext, p storage.VolumeParams, vm proxmoxclient.VirtualMachine,
+) (*storage.Volume, *storage.VolumeAttachment, error) {
+	config, err := v.config(ctx, vm)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	disk, err := freeSCSIDisk(config)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	storageName, _ := p.Attributes[storageAttr].(string)
+	if storageName == "" {
+		storageName = v.env.environConfig().diskStorage()
+	}
+	if storageName == "" {
+		// Use the same storage as the boot disk.
+		boot := bootDisk(config)
+		if boot == "" {
+			return nil, nil, errors.NotFoundf("boot disk of %q to choose storage", vm.Name)
+		}
+		storageName = strings.Split(volumeID(config[boot]), ":")[0]
+	}
+	sizeGiB := (p.Size + 1023) / 1024
+	if err := v.setConfig(ctx, vm, map[string][]string{
+		disk: {fmt.Sprintf("%s:%d", storageName, sizeGiB)},
+	}); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	// Find out the ID of the allocated volume.
+	config, err = v.config(ctx, vm)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	id := volumeID(config[disk])
+	if id == "" {
+		return nil, nil, errors.Errorf("disk %s of %q not created", disk, vm.Name)
+	}
+	volume := &storage.Volume{
+		Tag: p.Tag,
+		VolumeInfo: storage.VolumeInfo{
+			VolumeId: id,
+			Size:     sizeGiB * 1024,
+		},
+	}
+	attachment := &storage.VolumeAttachment{
+		Volume:  p.Tag,
+		Machine: p.Attachment.Machine,
+		VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+			DeviceLink: deviceLink(disk),
+		},
+	}
+	return volume, attachment, nil
+}
+
+// createInstanceVolumes creates the volumes requested when starting an
+// instance, as disks of its virtual machine.
+func (env *environ) createInstanceVolumes(
+	ctx context.ProviderCallContext, vm proxmoxclient.VirtualMachine, params []storage.VolumeParams,
+) ([]storage.Volume, []storage.VolumeAttachment, error) {
+	source := &volumeSource{env: env}
+	var volumes []storage.Volume
+	var attachments []storage.VolumeAttachment
+	for _, p := range params {
+		volume, attachment, err := source.createVolume(ctx, p, vm)
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "creating volume %s", p.Tag.Id())
+		}
+		volumes = append(volumes, *volume)
+		attachments = append(attachments, *attachment)
+	}
+	return volumes, attachments, nil
+}
+
+// freeSCSIDisk returns the first unused SCSI disk configuration key.
+// scsi0 is left for the boot disk.
+func freeSCSIDisk(config proxmoxclient.VirtualMachineConfig) (string, error) {
+	for i := 1; i < maxSCSIDisks; i++ {
+		disk := fmt.Sprintf("scsi%d", i)
+		if _, ok := config[disk]; !ok {
+			return disk, nil
+		}
+	}
+	return "", errors.New("no free SCSI disks")
+}
+
+// deviceLink returns the link to the block device of a SCSI
+// disk, which is named after the disk's configuration key.
+func deviceLink(disk string) string {
+	return "/dev/disk/by-id/scsi-0QEMU_QEMU_HARDDISK_drive-" + disk
+}
+
+// volumeKey returns the configuration key of the volume with the given
+// ID, which is either a disk, or an unused disk.
+func volumeKey(config proxmoxclient.VirtualMachineConfig, id string) string {
+	for key, value := range config {
+		if (isDisk(key, value) || strings.HasPrefix(key, "unused")) && volumeID(value) == id {
+			return key
+		}
+	}
+	return ""
+}
+
+// modelVolumes returns the configuration values of the volumes of the
+// model's virtual machines, excluding their boot disks, by volume ID.
+func (v *volumeSource) modelVolumes(ctx context.ProviderCallContext) (map[string]string, error) {
+	vms, err := v.env.taggedVirtualMachines(ctx, v.env.namespace.Prefix(), nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumes := make(map[string]string)
+	for _, vm := range vms {
+		boot := bootDisk(vm.config)
+		for key, value := range vm.config {
+			if key != boot && (isDisk(key, value) || strings.HasPrefix(key, "unused")) {
+				volumes[volumeID(value)] = value
+			}
+		}
+	}
+	return volumes, nil
+}
+
+// ListVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) ListVolumes(ctx context.ProviderCallContext) ([]string, error) {
+	volumes, err := v.modelVolumes(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ids := make([]string, 0, len(volumes))
+	for id := range volumes {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// DescribeVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DescribeVolumes(ctx context.ProviderCallContext, volIds []string) ([]storage.DescribeVolumesResult, error) {
+	volumes, err := v.modelVolumes(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]storage.DescribeVolumesResult, len(volIds))
+	for i, id := range volIds {
+		value, ok := volumes[id]
+		if !ok {
+			results[i].Error = errors.NotFoundf("volume %q", id)
+			continue
+		}
+		results[i].VolumeInfo = &storage.VolumeInfo{
+			VolumeId: id,
+			Size:     diskSizeMiB(value),
+		}
+	}
+	return results, nil
+}
+
+var volumeOwnerRE = regexp.MustCompile(`:vm-(\d+)-disk-\d+`)
+
+// volumeOwner returns the model virtual machine that owns the volume
+// with the given ID, and whether it exists.
+func (v *volumeSource) volumeOwner(ctx context.ProviderCallContext, id string) (proxmoxclient.VirtualMachine, bool, error) {
+	match := volumeOwnerRE.FindStringSubmatch(id)
+	if match == nil {
+		return proxmoxclient.VirtualMachine{}, false, errors.NotValidf("volume ID %q", id)
+	}
+	owner, _ := strconv.Atoi(match[1])
+	vms, err := v.env.virtualMachines(ctx, v.env.namespace.Prefix())
+	if err != nil {
+		return proxmoxclient.VirtualMachine{}, false, errors.Trace(err)
+	}
+	for _, vm := range vms {
+		if vm.ID == owner {
+			return vm, true, nil
+		}
+	}
+	return proxmoxclient.VirtualMachine{}, false, nil
+}
+
+// DestroyVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DestroyVolumes(ctx context.ProviderCallContext, volIds []string) ([]error, error) {
+	results := make([]error, len(volIds))
+	for i, id := range volIds {
+		results[i] = errors.Annotatef(v.destroyVolume(ctx, id), "destroying volume %q", id)
+	}
+	return results, nil
+}
+
+func (v *volumeSource) destroyVolume(ctx context.ProviderCallContext, id string) error {
+	vm, ok, err := v.volumeOwner(ctx, id)
+	if err != nil || !ok {
+		// The volume was deleted along with its virtual machine.
+		return errors.Trace(err)
+	}
+	config, err := v.config(ctx, vm)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	key := volumeKey(config, id)
+	if key == "" {
+		return nil
+	}
+	// Removing an unused disk, or forcing the removal of a disk,
+	// deletes the volume.
+	return errors.Trace(v.setConfig(ctx, vm, map[string][]string{
+		"delete": {key},
+		"force":  {"1"},
+	}))
+}
+
+// ReleaseVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) ReleaseVolumes(ctx context.ProviderCallContext, volIds []string) ([]error, error) {
+	return nil, errors.NotSupportedf("releasing volumes")
+}
+
+// ValidateVolumeParams is part of the storage.VolumeSource interface.
+func (v *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	if params.Attachment == nil || params.Attachment.InstanceId == "" {
+		// Volumes are disks of virtual machines, so
+		// they can only be created for a machine.
+		return errors.NotSupportedf("creating volume %s without an instance to attach it to", params.Tag.Id())
+	}
+	return nil
+}
+
+// AttachVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) AttachVolumes(ctx context.ProviderCallContext, attachParams []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	vms, err := v.virtualMachines(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]storage.AttachVolumesResult, len(attachParams))
+	for i, p := range attachParams {
+		vm, ok := vms[p.InstanceId]
+		if !ok {
+			results[i].Error = errors.NotFoundf("instance %q", p.InstanceId)
+			continue
+		}
+		disk, err := v.attachVolume(ctx, vm, p.VolumeId)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "attaching volume %q to %q", p.VolumeId, p.InstanceId)
+			continue
+		}
+		results[i].VolumeAttachment = &storage.VolumeAttachment{
+			Volume:  p.Volume,
+			Machine: p.Machine,
+			VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+				DeviceLink: deviceLink(disk),
+				ReadOnly:   p.ReadOnly,
+			},
+		}
+	}
+	return results, nil
+}
+
+// attachVolume attaches the volume to the virtual machine, if it's not
+// already attached, and returns the disk it's attached as.
+func (v *volumeSource) attachVolume(ctx context.ProviderCallContext, vm proxmoxclient.VirtualMachine, id string) (string, error) {
+	config, err := v.config(ctx, vm)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	key := volumeKey(config, id)
+	switch {
+	case key == "":
+		// Disks can't be moved between virtual machines.
+		return "", errors.NotFoundf("volume %q of %q", id, vm.Name)
+	case !strings.HasPrefix(key, "unused"):
+		return key, nil
+	}
+	disk, err := freeSCSIDisk(config)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := v.setConfig(ctx, vm, map[string][]string{disk: {id}}); err != nil {
+		return "", errors.Trace(err)
+	}
+	return disk, nil
+}
+
+// DetachVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DetachVolumes(ctx context.ProviderCallContext, attachParams []storage.VolumeAttachmentParams) ([]error, error) {
+	vms, err := v.virtualMachines(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]error, len(attachParams))
+	for i, p := range attachParams {
+		vm, ok := vms[p.InstanceId]
+		if !ok {
+			// The volume was detached when the instance was deleted.
+			continue
+		}
+		results[i] = errors.Annotatef(v.detachVolume(ctx, vm, p.VolumeId), "detaching volume %q from %q", p.VolumeId, p.InstanceId)
+	}
+	return results, nil
+}
+
+func (v *volumeSource) detachVolume(ctx context.ProviderCallContext, vm proxmoxclient.VirtualMachine, id string) error {
+	config, err := v.config(ctx, vm)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	key := volumeKey(config, id)
+	if key == "" || strings.HasPrefix(key, "unused") {
+		return nil
+	}
+	// Removing a disk without forcing it keeps
+	// the volume as an unused disk.
+	return errors.Trace(v.setConfig(ctx, vm, map[string][]string{"delete": {key}}))
+}
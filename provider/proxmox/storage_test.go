@@ -0,0 +1,159 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmox_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/storage"
+)
+
+type storageSuite struct {
+	EnvironFixture
+	source storage.VolumeSource
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+func (s *storageSuite) SetUpTest(c *gc.C) {
+	s.EnvironFixture.SetUpTest(c)
+	s.server.AddTemplate("pve2", "juju-focal")
+	_, err := s.env.StartInstance(s.callCtx, startInstanceParams(c, "0"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	registry, ok := s.env.(storage.ProviderRegistry)
+	c.Assert(ok, jc.IsTrue)
+	provider, err := registry.StorageProvider("proxmox")
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := storage.NewConfig("proxmox", "proxmox", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.source, err = provider.VolumeSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageSuite) TestProvider(c *gc.C) {
+	registry := s.env.(storage.ProviderRegistry)
+	provider, err := registry.StorageProvider("proxmox")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider.Scope(), gc.Equals, storage.ScopeMachine)
+	c.Assert(provider.Dynamic(), jc.IsFalse)
+	c.Assert(provider.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(provider.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+
+	_, err = registry.StorageProvider("ebs")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *storageSuite) TestValidateConfig(c *gc.C) {
+	provider, err := s.env.(storage.ProviderRegistry).StorageProvider("proxmox")
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := storage.NewConfig("fast", "proxmox", map[string]interface{}{"storage": 123})
+	c.Assert(err, jc.ErrorIsNil)
+	err = provider.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `validating proxmox storage config: storage: expected string, got int\(123\)`)
+}
+
+func (s *storageSuite) createVolume(c *gc.C, attrs map[string]interface{}) storage.CreateVolumesResult {
+	results, err := s.source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("0"),
+		Size:       1536,
+		Provider:   "proxmox",
+		Attributes: attrs,
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				Machine:    names.NewMachineTag("0"),
+				InstanceId: "juju-f75cba-0",
+			},
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	return results[0]
+}
+
+func (s *storageSuite) TestCreateVolumes(c *gc.C) {
+	result := s.createVolume(c, nil)
+	c.Assert(result.Error, jc.ErrorIsNil)
+	c.Assert(result.Volume.VolumeId, gc.Equals, "local-lvm:vm-101-disk-1")
+	c.Assert(result.Volume.Size, gc.Equals, uint64(2048))
+	c.Assert(result.VolumeAttachment.DeviceLink, gc.Equals, "/dev/disk/by-id/scsi-0QEMU_QEMU_HARDDISK_drive-scsi1")
+
+	vm, _ := s.server.VirtualMachine("juju-f75cba-0")
+	c.Assert(vm.Config["scsi1"], gc.Equals, "local-lvm:vm-101-disk-1,size=2G")
+
+	ids, err := s.source.ListVolumes(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []string{"local-lvm:vm-101-disk-1"})
+
+	described, err := s.source.DescribeVolumes(s.callCtx, []string{"local-lvm:vm-101-disk-1", "local-lvm:vm-101-disk-7"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(described[0].Error, jc.ErrorIsNil)
+	c.Assert(described[0].VolumeInfo.Size, gc.Equals, uint64(2048))
+	c.Assert(described[1].Error, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *storageSuite) TestCreateVolumesPoolStorage(c *gc.C) {
+	result := s.createVolume(c, map[string]interface{}{"storage": "ceph"})
+	c.Assert(result.Error, jc.ErrorIsNil)
+	c.Assert(result.Volume.VolumeId, gc.Equals, "ceph:vm-101-disk-0")
+}
+
+func (s *storageSuite) TestCreateVolumesUnknownInstance(c *gc.C) {
+	results, err := s.source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:        names.NewVolumeTag("0"),
+		Size:       1024,
+		Attachment: &storage.VolumeAttachmentParams{AttachmentParams: storage.AttachmentParams{InstanceId: "juju-f75cba-9"}},
+	}, {
+		Tag:  names.NewVolumeTag("1"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, `instance "juju-f75cba-9" not found`)
+	c.Assert(results[1].Error, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageSuite) TestDetachAttachVolumes(c *gc.C) {
+	result := s.createVolume(c, nil)
+	c.Assert(result.Error, jc.ErrorIsNil)
+	params := storage.VolumeAttachmentParams{
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: instance.Id("juju-f75cba-0"),
+		},
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: result.Volume.VolumeId,
+	}
+
+	errs, err := s.source.DetachVolumes(s.callCtx, []storage.VolumeAttachmentParams{params})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+	vm, _ := s.server.VirtualMachine("juju-f75cba-0")
+	c.Assert(vm.Config["scsi1"], gc.Equals, "")
+	c.Assert(vm.Config["unused0"], gc.Equals, "local-lvm:vm-101-disk-1")
+
+	attached, err := s.source.AttachVolumes(s.callCtx, []storage.VolumeAttachmentParams{params})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attached[0].Error, jc.ErrorIsNil)
+	c.Assert(attached[0].VolumeAttachment.DeviceLink, gc.Equals, "/dev/disk/by-id/scsi-0QEMU_QEMU_HARDDISK_drive-scsi1")
+	vm, _ = s.server.VirtualMachine("juju-f75cba-0")
+	c.Assert(vm.Config["scsi1"], gc.Equals, "local-lvm:vm-101-disk-1")
+	c.Assert(vm.Config["unused0"], gc.Equals, "")
+}
+
+func (s *storageSuite) TestDestroyVolumes(c *gc.C) {
+	result := s.createVolume(c, nil)
+	c.Assert(result.Error, jc.ErrorIsNil)
+
+	errs, err := s.source.DestroyVolumes(s.callCtx, []string{result.Volume.VolumeId, "local-lvm:vm-999-disk-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil, nil})
+	_, ok := s.server.Volume(result.Volume.VolumeId)
+	c.Assert(ok, jc.IsFalse)
+	vm, _ := s.server.VirtualMachine("juju-f75cba-0")
+	c.Assert(vm.Config["scsi1"], gc.Equals, "")
+}
@@ -0,0 +1,38 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmox
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// descriptionKey is the virtual machine configuration key holding
+// the description, in which the Juju tags are stored, since Proxmox
+// VE only supports tags without values.
+const descriptionKey = "description"
+
+// formatTags returns the tags as sorted "key=value" lines.
+func formatTags(tags map[string]string) string {
+	lines := make([]string, 0, len(tags))
+	for k, v := range tags {
+		lines = append(lines, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+// parseTags returns the tags in a description written by formatTags.
+// Lines which aren't tags are ignored.
+func parseTags(description string) map[string]string {
+	tags := make(map[string]string)
+	for _, line := range strings.Split(description, "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if len(parts) == 2 && parts[0] != "" {
+			tags[parts[0]] = parts[1]
+		}
+	}
+	return tags
+}
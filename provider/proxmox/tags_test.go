@@ -0,0 +1,34 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxmox_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/provider/proxmox"
+)
+
+type tagsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&tagsSuite{})
+
+func (s *tagsSuite) TestFormatTags(c *gc.C) {
+	description := proxmox.FormatTags(map[string]string{
+		"juju-model-uuid":      "deadbeef",
+		"juju-controller-uuid": "f00d",
+	})
+	c.Assert(description, gc.Equals, "juju-controller-uuid=f00d\njuju-model-uuid=deadbeef")
+}
+
+func (s *tagsSuite) TestParseTags(c *gc.C) {
+	tags := proxmox.ParseTags("juju-controller-uuid=f00d\n\nedited by hand\njuju-model-uuid=dead=beef\n")
+	c.Assert(tags, jc.DeepEquals, map[string]string{
+		"juju-controller-uuid": "f00d",
+		"juju-model-uuid":      "dead=beef",
+	})
+}
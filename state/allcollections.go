@@ -178,12 +178,18 @@ func allCollections() CollectionSchema {
 		// are inherited and then forked by new models.
 		globalSettingsC: {global: true},
 
+		// This collection holds the templates from which new models
+		// can be created.
+		modelTemplatesC: {global: true},
+
 		// This collection holds workload metrics reported by certain charms
 		// for passing onward to other tools.
 		metricsC: {
 			global: true,
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "sent"},
+			}, {
+				Key: []string{"model-uuid", "unit", "created"},
 			}},
 		},
 
@@ -415,6 +421,31 @@ func allCollections() CollectionSchema {
 			}},
 		},
 
+		// This collection holds the automatic charm upgrades made
+		// according to applications' charm update policies, keyed
+		// by the operation recording each upgrade.
+		charmUpgradesC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "application"},
+			}},
+		},
+
+		// This collection holds the changes made by the autoscaler to
+		// the number of units of applications.
+		autoscaleDecisionsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "application", "time"},
+			}},
+		},
+
+		// This collection records the idle machines kept in a model's
+		// machine pool.
+		machinePoolC: {},
+
+		// This collection records the tags last set on each model's
+		// cloud resources by the resource tagger.
+		resourceTagsC: {},
+
 		// -----
 
 		// This collection holds information associated with charm payloads.
@@ -499,6 +530,13 @@ func allCollections() CollectionSchema {
 				{Key: []string{"model-uuid", "offer-uuid"}},
 			},
 		},
+		// offerTokensC holds the invitation tokens that allow offers
+		// to be consumed by users unknown to this controller.
+		offerTokensC: {
+			indexes: []mgo.Index{
+				{Key: []string{"model-uuid", "offer-uuid"}},
+			},
+		},
 		remoteApplicationsC: {},
 		// remoteEntitiesC holds information about entities involved in
 		// cross-model relations.
@@ -515,6 +553,10 @@ func allCollections() CollectionSchema {
 		// relationNetworksC holds required ingress or egress cidrs for remote relations.
 		relationNetworksC: {},
 
+		// remoteRelationEventsC holds the last change received from the
+		// remote model for each cross-model relation.
+		remoteRelationEventsC: {},
+
 		// firewallRulesC holds firewall rules for defined service types.
 		firewallRulesC: {},
 
@@ -553,9 +595,11 @@ const (
 	autocertCacheC             = "autocertCache"
 	assignUnitC                = "assignUnits"
 	bakeryStorageItemsC        = "bakeryStorageItems"
+	autoscaleDecisionsC        = "autoscaleDecisions"
 	blockDevicesC              = "blockdevices"
 	blocksC                    = "blocks"
 	charmsC                    = "charms"
+	charmUpgradesC             = "charmUpgrades"
 	cleanupsC                  = "cleanups"
 	cloudimagemetadataC        = "cloudimagemetadata"
 	cloudsC                    = "clouds"
@@ -578,6 +622,7 @@ const (
 	instanceDataC              = "instanceData"
 	leaseHoldersC              = "leaseholders"
 	machinesC                  = "machines"
+	machinePoolC               = "machinePool"
 	machineRemovalsC           = "machineremovals"
 	machineUpgradeSeriesLocksC = "machineUpgradeSeriesLocks"
 	meterStatusC               = "meterStatus"
@@ -591,6 +636,7 @@ const (
 	modelUserLastConnectionC   = "modelUserLastConnection"
 	modelUsersC                = "modelusers"
 	modelsC                    = "models"
+	modelTemplatesC            = "modelTemplates"
 	modelEntityRefsC           = "modelEntityRefs"
 	openedPortsC               = "openedPorts"
 	operationsC                = "operations"
@@ -601,6 +647,7 @@ const (
 	rebootC                    = "reboot"
 	relationScopesC            = "relationscopes"
 	relationsC                 = "relations"
+	resourceTagsC              = "resourceTags"
 	restoreInfoC               = "restoreInfo"
 	sequenceC                  = "sequence"
 	applicationsC              = "applications"
@@ -636,11 +683,13 @@ const (
 	// "resources" (see resource/persistence/mongo.go)
 
 	// Cross model relations
-	applicationOffersC   = "applicationOffers"
-	remoteApplicationsC  = "remoteApplications"
-	offerConnectionsC    = "applicationOfferConnections"
-	remoteEntitiesC      = "remoteEntities"
-	externalControllersC = "externalControllers"
-	relationNetworksC    = "relationNetworks"
-	firewallRulesC       = "firewallRules"
+	applicationOffersC    = "applicationOffers"
+	remoteApplicationsC   = "remoteApplications"
+	offerConnectionsC     = "applicationOfferConnections"
+	offerTokensC          = "applicationOfferTokens"
+	remoteEntitiesC       = "remoteEntities"
+	externalControllersC  = "externalControllers"
+	relationNetworksC     = "relationNetworks"
+	remoteRelationEventsC = "remoteRelationEvents"
+	firewallRulesC        = "firewallRules"
 )
@@ -30,6 +30,7 @@ import (
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/core/status"
 	mgoutils "github.com/juju/juju/mongo/utils"
 	stateerrors "github.com/juju/juju/state/errors"
@@ -691,6 +692,28 @@ func (a *Application) CharmURL() (curl *charm.URL, force bool) {
 	return a.doc.CharmURL, a.doc.ForceCharm
 }
 
+// UnitCharmURL returns the URL of the charm that the unit of the
+// application with the input name should run, along with whether units
+// should upgrade to it even when in an error state.
+// This is the charm staged for the application under the branch that the
+// unit is tracking if there is one, and the application's charm otherwise.
+func (a *Application) UnitCharmURL(unitName string) (curl *charm.URL, force bool, err error) {
+	m, err := a.st.Model()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	branch, err := m.unitBranch(unitName)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if branch != nil {
+		if staged, ok := branch.doc.Charms[a.doc.Name]; ok {
+			return staged.CharmURL, staged.ForceUnits, nil
+		}
+	}
+	return a.doc.CharmURL, a.doc.ForceCharm, nil
+}
+
 // Channel identifies the charm store channel from which the application's
 // charm was deployed. It is only needed when interacting with the charm
 // store.
@@ -896,8 +919,20 @@ func (a *Application) checkStorageUpgrade(newMeta, oldMeta *charm.Meta, units []
 	return ops, nil
 }
 
+// branchCharmCommit describes a charm upgrade that was staged under a
+// branch, and is being applied to the whole application as the branch
+// is committed.
+type branchCharmCommit struct {
+	// configChanges are the branch's changes to the application's charm
+	// config, applied to the current settings before they are carried
+	// over to the new charm.
+	configChanges settings.ItemChanges
+}
+
 // changeCharmOps returns the operations necessary to set a application's
 // charm URL to a new value.
+// If branch is not nil, the references to the new charm held by the branch
+// are handed over to the application, rather than new ones being taken.
 func (a *Application) changeCharmOps(
 	ch *Charm,
 	channel string,
@@ -905,11 +940,15 @@ func (a *Application) changeCharmOps(
 	forceUnits bool,
 	resourceIDs map[string]string,
 	updatedStorageConstraints map[string]StorageConstraints,
+	branch *branchCharmCommit,
 ) ([]txn.Op, error) {
 	// Build the new application config from what can be used of the old one.
 	var newSettings charm.Settings
 	oldKey, err := readSettings(a.st.db(), settingsC, a.charmConfigKey())
 	if err == nil {
+		if branch != nil {
+			oldKey.applyChanges(branch.configChanges)
+		}
 		// Filter the old settings through to get the new settings.
 		newSettings = ch.Config().FilterSettings(oldKey.Map())
 		for k, v := range updatedSettings {
@@ -968,9 +1007,12 @@ func (a *Application) changeCharmOps(
 
 	// Add or create a reference to the new charm, settings,
 	// and storage constraints docs.
-	incOps, err := appCharmIncRefOps(a.st, a.doc.Name, ch.URL(), true)
-	if err != nil {
-		return nil, errors.Trace(err)
+	var incOps []txn.Op
+	if branch == nil {
+		incOps, err = appCharmIncRefOps(a.st, a.doc.Name, ch.URL(), true)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 	var decOps []txn.Op
 	// Drop the references to the old settings, storage constraints,
@@ -1278,11 +1320,9 @@ type SetCharmConfig struct {
 	EndpointBindings map[string]string
 }
 
-// SetCharm changes the charm for the application.
-func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
-	defer errors.DeferredAnnotatef(
-		&err, "cannot upgrade application %q to charm %q", a, cfg.Charm,
-	)
+// checkCharmUpgrade returns an error if the application can not be
+// upgraded to the charm in the input configuration.
+func (a *Application) checkCharmUpgrade(cfg SetCharmConfig) error {
 	if cfg.Charm.Meta().Subordinate != a.doc.Subordinate {
 		return errors.Errorf("cannot change an application's subordinacy")
 	}
@@ -1350,11 +1390,6 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 		}
 	}
 
-	updatedSettings, err := cfg.Charm.Config().ValidateSettings(cfg.ConfigSettings)
-	if err != nil {
-		return errors.Annotate(err, "validating config settings")
-	}
-
 	// we don't need to check that this is a charm.LXDProfiler, as we can
 	// state that the function exists.
 	if profile := cfg.Charm.LXDProfile(); profile != nil {
@@ -1365,8 +1400,24 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 		}
 	}
 
+	return nil
+}
+
+// SetCharm changes the charm for the application.
+func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
+	defer errors.DeferredAnnotatef(
+		&err, "cannot upgrade application %q to charm %q", a, cfg.Charm,
+	)
+	if err := a.checkCharmUpgrade(cfg); err != nil {
+		return errors.Trace(err)
+	}
+
+	updatedSettings, err := cfg.Charm.Config().ValidateSettings(cfg.ConfigSettings)
+	if err != nil {
+		return errors.Annotate(err, "validating config settings")
+	}
+
 	var newCharmModifiedVersion int
-	channel := string(cfg.Channel)
 	acopy := &Application{a.st, a.doc}
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		a := acopy
@@ -1375,89 +1426,11 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 				return nil, errors.Trace(err)
 			}
 		}
-
-		// NOTE: We're explicitly allowing SetCharm to succeed
-		// when the application is Dying, because application/charm
-		// upgrades should still be allowed to apply to dying
-		// applications and units, so that bugs in departed/broken
-		// hooks can be addressed at runtime.
-		if a.Life() == Dead {
-			return nil, stateerrors.ErrDead
-		}
-
-		// Record the current value of charmModifiedVersion, so we can
-		// set the value on the method receiver's in-memory document
-		// structure. We increment the version only when we change the
-		// charm URL.
-		newCharmModifiedVersion = a.doc.CharmModifiedVersion
-
-		ops := []txn.Op{{
-			C:  applicationsC,
-			Id: a.doc.DocID,
-			Assert: append(notDeadDoc, bson.DocElem{
-				"charmmodifiedversion", a.doc.CharmModifiedVersion,
-			}),
-		}}
-
-		if a.doc.CharmURL.String() == cfg.Charm.URL().String() {
-			// Charm URL already set; just update the force flag and channel.
-			ops = append(ops, txn.Op{
-				C:  applicationsC,
-				Id: a.doc.DocID,
-				Update: bson.D{{"$set", bson.D{
-					{"cs-channel", channel},
-					{"forcecharm", cfg.ForceUnits},
-				}}},
-			})
-		} else {
-			// Check if the new charm specifies a relation max limit
-			// that cannot be satisfied by the currently established
-			// relation count.
-			quotaErr := a.preUpgradeRelationLimitCheck(cfg.Charm)
-
-			// If the operator specified --force, we still allow
-			// the upgrade to continue with a warning.
-			if errors.IsQuotaLimitExceeded(quotaErr) && cfg.Force {
-				logger.Warningf("%v; allowing upgrade to proceed as the operator specified --force", quotaErr)
-			} else if quotaErr != nil {
-				return nil, errors.Trace(quotaErr)
-			}
-
-			chng, err := a.changeCharmOps(
-				cfg.Charm,
-				channel,
-				updatedSettings,
-				cfg.ForceUnits,
-				cfg.ResourceIDs,
-				cfg.StorageConstraints,
-			)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-			ops = append(ops, chng...)
-			newCharmModifiedVersion++
-		}
-
-		// Always update bindings regardless of whether we upgrade to a
-		// new version or stay at the previous version.
-		currentMap, txnRevno, err := readEndpointBindings(a.st, a.globalKey())
-		if err != nil && !errors.IsNotFound(err) {
-			return ops, errors.Trace(err)
-		}
-		b, err := a.bindingsForOps(currentMap)
+		ops, version, err := a.setCharmOps(cfg, updatedSettings, nil)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		endpointBindingsOps, err := b.updateOps(txnRevno, cfg.EndpointBindings, cfg.Charm.Meta(), cfg.Force)
-		if err == nil {
-			ops = append(ops, endpointBindingsOps...)
-		} else if !errors.IsNotFound(err) && err != jujutxn.ErrNoOperations {
-			// If endpoint bindings do not exist this most likely means the application
-			// itself no longer exists, which will be caught soon enough anyway.
-			// ErrNoOperations on the other hand means there's nothing to update.
-			return nil, errors.Trace(err)
-		}
-
+		newCharmModifiedVersion = version
 		return ops, nil
 	}
 
@@ -1465,12 +1438,106 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 		return err
 	}
 	a.doc.CharmURL = cfg.Charm.URL()
-	a.doc.Channel = channel
+	a.doc.Channel = string(cfg.Channel)
 	a.doc.ForceCharm = cfg.ForceUnits
 	a.doc.CharmModifiedVersion = newCharmModifiedVersion
 	return nil
 }
 
+// setCharmOps returns the operations necessary to change the charm for
+// the application, along with the resulting charm modified version.
+// The branch argument is as for changeCharmOps.
+func (a *Application) setCharmOps(
+	cfg SetCharmConfig, updatedSettings charm.Settings, branch *branchCharmCommit,
+) ([]txn.Op, int, error) {
+	channel := string(cfg.Channel)
+
+	// NOTE: We're explicitly allowing SetCharm to succeed
+	// when the application is Dying, because application/charm
+	// upgrades should still be allowed to apply to dying
+	// applications and units, so that bugs in departed/broken
+	// hooks can be addressed at runtime.
+	if a.Life() == Dead {
+		return nil, 0, stateerrors.ErrDead
+	}
+
+	// Record the current value of charmModifiedVersion, so we can
+	// set the value on the method receiver's in-memory document
+	// structure. We increment the version only when we change the
+	// charm URL.
+	newCharmModifiedVersion := a.doc.CharmModifiedVersion
+
+	ops := []txn.Op{{
+		C:  applicationsC,
+		Id: a.doc.DocID,
+		Assert: append(notDeadDoc, bson.DocElem{
+			"charmmodifiedversion", a.doc.CharmModifiedVersion,
+		}),
+	}}
+
+	if a.doc.CharmURL.String() == cfg.Charm.URL().String() {
+		// Charm URL already set; just update the force flag and channel.
+		ops = append(ops, txn.Op{
+			C:  applicationsC,
+			Id: a.doc.DocID,
+			Update: bson.D{{"$set", bson.D{
+				{"cs-channel", channel},
+				{"forcecharm", cfg.ForceUnits},
+			}}},
+		})
+	} else {
+		// Check if the new charm specifies a relation max limit
+		// that cannot be satisfied by the currently established
+		// relation count.
+		quotaErr := a.preUpgradeRelationLimitCheck(cfg.Charm)
+
+		// If the operator specified --force, we still allow
+		// the upgrade to continue with a warning.
+		if errors.IsQuotaLimitExceeded(quotaErr) && cfg.Force {
+			logger.Warningf("%v; allowing upgrade to proceed as the operator specified --force", quotaErr)
+		} else if quotaErr != nil {
+			return nil, 0, errors.Trace(quotaErr)
+		}
+
+		chng, err := a.changeCharmOps(
+			cfg.Charm,
+			channel,
+			updatedSettings,
+			cfg.ForceUnits,
+			cfg.ResourceIDs,
+			cfg.StorageConstraints,
+			branch,
+		)
+		if err != nil {
+			return nil, 0, errors.Trace(err)
+		}
+		ops = append(ops, chng...)
+		newCharmModifiedVersion++
+	}
+
+	// Always update bindings regardless of whether we upgrade to a
+	// new version or stay at the previous version.
+	currentMap, txnRevno, err := readEndpointBindings(a.st, a.globalKey())
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, 0, errors.Trace(err)
+	}
+	b, err := a.bindingsForOps(currentMap)
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	endpointBindingsOps, err := b.updateOps(txnRevno, cfg.EndpointBindings, cfg.Charm.Meta(), cfg.Force)
+	if err == nil {
+		ops = append(ops, endpointBindingsOps...)
+	} else if !errors.IsNotFound(err) && err != jujutxn.ErrNoOperations {
+		// If endpoint bindings do not exist this most likely means the application
+		// itself no longer exists, which will be caught soon enough anyway.
+		// ErrNoOperations on the other hand means there's nothing to update.
+		return nil, 0, errors.Trace(err)
+	}
+
+	return ops, newCharmModifiedVersion, nil
+}
+
 // preUpgradeRelationLimitCheck ensures that the already established relation
 // counts do not violate the max relation limits specified by the charm version
 // we are attempting to upgrade to.
@@ -2457,6 +2524,9 @@ func charmSettingsWithDefaults(st *State, cURL *charm.URL, appName, branchName s
 func branchCharmSettings(st *State, cURL *charm.URL, appName, branchName string) (*Settings, error) {
 	key := applicationCharmConfigKey(appName, cURL)
 	cfg, err := readSettings(st.db(), settingsC, key)
+	if errors.IsNotFound(err) {
+		cfg, err = stagedCharmSettings(st, cURL, appName)
+	}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -2472,6 +2542,30 @@ func branchCharmSettings(st *State, cURL *charm.URL, appName, branchName string)
 	return cfg, nil
 }
 
+// stagedCharmSettings returns the settings for the input charm staged for
+// the application under a branch. They are not written until the branch
+// is committed, so are derived from the application's current settings.
+func stagedCharmSettings(st *State, cURL *charm.URL, appName string) (*Settings, error) {
+	app, err := st.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if app.doc.CharmURL.String() == cURL.String() {
+		return nil, errors.NotFoundf("settings for application %q", appName)
+	}
+	master, err := readSettings(st.db(), settingsC, app.charmConfigKey())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ch, err := st.Charm(cURL)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg := newSettings(st.db(), settingsC, applicationCharmConfigKey(appName, cURL))
+	cfg.Update(ch.Config().FilterSettings(master.Map()))
+	return cfg, nil
+}
+
 // UpdateCharmConfig changes a application's charm config settings. Values set
 // to nil will be deleted; unknown and invalid values will return an error.
 func (a *Application) UpdateCharmConfig(branchName string, changes charm.Settings) error {
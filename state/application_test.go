@@ -3750,6 +3750,28 @@ func (s *ApplicationSuite) TestRenamePeerRelationOnUpgradeWithMoreThanOneUnit(c
 	c.Assert(s.mysql.CharmModifiedVersion() == obtainedV, jc.IsTrue)
 }
 
+func (s *ApplicationSuite) TestWatchApplicationConfig(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	w := app.WatchApplicationConfig()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	schema := environschema.Fields{
+		"trust": environschema.Attr{Type: environschema.Tbool},
+	}
+	err := app.UpdateApplicationConfig(application.ConfigAttributes{"trust": true}, nil, schema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Charm config changes are not reported.
+	err = app.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"blog-title": "sauceror central"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 func (s *ApplicationSuite) TestWatchCharmConfig(c *gc.C) {
 	oldCharm := s.AddTestingCharm(c, "wordpress")
 	app := s.AddTestingApplication(c, "wordpress", oldCharm)
@@ -3929,6 +3951,19 @@ func sampleApplicationConfigSchema() environschema.Fields {
 	return schema
 }
 
+func (s *ApplicationSuite) TestAllApplicationConfigs(c *gc.C) {
+	err := s.mysql.UpdateApplicationConfig(application.ConfigAttributes{"title": "foo"}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	configs, err := model.AllApplicationConfigs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(configs["mysql"], jc.DeepEquals, application.ConfigAttributes{"title": "foo"})
+	c.Check(configs["wordpress"], gc.HasLen, 0)
+}
+
 func (s *ApplicationSuite) TestUpdateApplicationConfigWithDyingApplication(c *gc.C) {
 	_, err := s.mysql.AddUnit(state.AddUnitParams{})
 	c.Assert(err, jc.ErrorIsNil)
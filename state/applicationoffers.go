@@ -303,6 +303,11 @@ func (op *RemoveOfferOperation) internalRemove(offer *crossmodel.ApplicationOffe
 			})
 		}
 	}
+	tokenOps, err := removeOfferTokensOps(op.offers.st, offer.OfferUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, tokenOps...)
 	decRefOp, err := decApplicationOffersRefOp(op.offers.st, offer.ApplicationName)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -377,6 +382,11 @@ func removeApplicationOffersOps(st *State, application string) ([]txn.Op, error)
 			Assert: txn.DocExists,
 			Remove: true,
 		})
+		tokenOps, err := removeOfferTokensOps(st, doc.OfferUUID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, tokenOps...)
 	}
 	offerRefCountKey := applicationOffersRefCountKey(application)
 	removeRefsOp := nsRefcounts.JustRemoveOp(refcountsC, offerRefCountKey, len(docs))
@@ -0,0 +1,378 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ApplicationRemovalPlan describes the entities which are affected by
+// removing an application, as worked out by ApplicationsRemovalPlan.
+type ApplicationRemovalPlan struct {
+	// Application is the name of the application being removed.
+	Application string
+
+	// Units holds the names of the application's units.
+	Units []string
+
+	// Subordinates holds the names of subordinate units, of applications
+	// which are not being removed, which are removed along with the
+	// application's units.
+	Subordinates []string
+
+	// Relations holds the keys of the relations which are removed.
+	Relations []string
+
+	// Offers holds the names of the application's offers, which are
+	// removed along with it.
+	Offers []string
+
+	// OfferConnections holds the keys of the relations made by consuming
+	// the application's offers. While there are any, the application can
+	// not be removed.
+	OfferConnections []string
+
+	// OrphanedApplications holds the names of subordinate applications
+	// which are left without any principal application to relate to.
+	OrphanedApplications []string
+
+	// Machines holds the ids of the machines which are removed once the
+	// units on them are gone.
+	Machines []string
+
+	// OrphanedMachines holds the ids of the machines which are left
+	// without any units or containers, but which are not removed.
+	OrphanedMachines []string
+}
+
+// ApplicationsRemovalPlan works out what removing the named applications
+// together affects, following the rules that the application and unit
+// removal operations and their cleanups apply. Each plan holds the entities
+// which would be affected by removing the corresponding application; an
+// entity affected by removing more than one of the applications is only
+// reported for the first of them.
+func (st *State) ApplicationsRemovalPlan(appNames ...string) ([]ApplicationRemovalPlan, error) {
+	removing := set.NewStrings(appNames...)
+	plans := make([]ApplicationRemovalPlan, len(appNames))
+	removedUnits := set.NewStrings()
+	seenRelations := set.NewStrings()
+	// machineOwners maps the id of each machine hosting a unit which is
+	// being removed to the index of the first plan it is reported in.
+	machineOwners := make(map[string]int)
+	for i, name := range appNames {
+		app, err := st.Application(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		plan := &plans[i]
+		plan.Application = name
+
+		units, err := app.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unit := range units {
+			removedUnits.Add(unit.Name())
+			plan.Units = append(plan.Units, unit.Name())
+			for _, subName := range unit.SubordinateNames() {
+				subApp, err := names.UnitApplication(subName)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				removedUnits.Add(subName)
+				if !removing.Contains(subApp) {
+					plan.Subordinates = append(plan.Subordinates, subName)
+				}
+			}
+			if !unit.IsPrincipal() {
+				continue
+			}
+			machineId, err := unit.AssignedMachineId()
+			if errors.IsNotAssigned(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if _, ok := machineOwners[machineId]; !ok {
+				machineOwners[machineId] = i
+			}
+		}
+
+		rels, err := app.Relations()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, rel := range rels {
+			if seenRelations.Contains(rel.String()) {
+				continue
+			}
+			seenRelations.Add(rel.String())
+			plan.Relations = append(plan.Relations, rel.String())
+		}
+
+		offers, err := applicationOffersDocs(st, name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, offer := range offers {
+			plan.Offers = append(plan.Offers, offer.OfferName)
+			conns, err := st.OfferConnections(offer.OfferUUID)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, conn := range conns {
+				plan.OfferConnections = append(plan.OfferConnections, conn.RelationKey())
+			}
+		}
+	}
+
+	if err := st.planOrphanedApplications(appNames, plans); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := st.planMachinesRemoval(removedUnits, machineOwners, plans); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return plans, nil
+}
+
+// planOrphanedApplications records the subordinate applications which are
+// only related to principal applications being removed in the plan of the
+// first of those principals.
+func (st *State) planOrphanedApplications(appNames []string, plans []ApplicationRemovalPlan) error {
+	apps, err := st.AllApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	principal := make(map[string]bool)
+	for _, app := range apps {
+		principal[app.Name()] = app.IsPrincipal()
+	}
+	removing := set.NewStrings(appNames...)
+	for _, app := range apps {
+		if app.IsPrincipal() || app.Life() != Alive || removing.Contains(app.Name()) {
+			continue
+		}
+		rels, err := app.Relations()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		principals := set.NewStrings()
+		for _, rel := range rels {
+			if !isContainerScoped(rel) {
+				continue
+			}
+			for _, ep := range rel.Endpoints() {
+				if principal[ep.ApplicationName] {
+					principals.Add(ep.ApplicationName)
+				}
+			}
+		}
+		if principals.IsEmpty() || !principals.Difference(removing).IsEmpty() {
+			continue
+		}
+		for i, name := range appNames {
+			if principals.Contains(name) {
+				plans[i].OrphanedApplications = append(plans[i].OrphanedApplications, app.Name())
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func isContainerScoped(rel *Relation) bool {
+	for _, ep := range rel.Endpoints() {
+		if ep.Scope == charm.ScopeContainer {
+			return true
+		}
+	}
+	return false
+}
+
+// planMachinesRemoval records the machines which are emptied by removing
+// the given units. Those hosting the units are removed along with them,
+// unless they also host containers; hosts which are left with nothing
+// but removed containers are orphaned.
+func (st *State) planMachinesRemoval(
+	removedUnits set.Strings, machineOwners map[string]int, plans []ApplicationRemovalPlan,
+) error {
+	emptied := make(map[string]bool)
+	machines := make(map[string]*Machine)
+	// isEmptied reports whether removing the units leaves the
+	// machine, and all of its containers, without any units.
+	var isEmptied func(id string) (bool, error)
+	isEmptied = func(id string) (bool, error) {
+		if result, ok := emptied[id]; ok {
+			return result, nil
+		}
+		m, err := st.Machine(id)
+		if errors.IsNotFound(err) {
+			emptied[id] = true
+			return true, nil
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		machines[id] = m
+		result := !m.IsManager()
+		for _, unitName := range m.Principals() {
+			if !removedUnits.Contains(unitName) {
+				result = false
+			}
+		}
+		containers, err := m.Containers()
+		if err != nil && !errors.IsNotFound(err) {
+			return false, errors.Trace(err)
+		}
+		for _, containerId := range containers {
+			containerEmptied, err := isEmptied(containerId)
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			result = result && containerEmptied
+		}
+		emptied[id] = result
+		return result, nil
+	}
+
+	ids := make([]string, 0, len(machineOwners))
+	for id := range machineOwners {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	orphaned := set.NewStrings()
+	for _, id := range ids {
+		owner := machineOwners[id]
+		result, err := isEmptied(id)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !result || machines[id] == nil {
+			continue
+		}
+		containers, err := machines[id].Containers()
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		if len(containers) == 0 {
+			plans[owner].Machines = append(plans[owner].Machines, id)
+		} else if !orphaned.Contains(id) {
+			orphaned.Add(id)
+			plans[owner].OrphanedMachines = append(plans[owner].OrphanedMachines, id)
+		}
+
+		// Hosts which are left with only removed containers
+		// are not removed along with them.
+		for parentId := ParentId(id); parentId != ""; parentId = ParentId(parentId) {
+			if _, ok := machineOwners[parentId]; ok || orphaned.Contains(parentId) {
+				break
+			}
+			parentEmptied, err := isEmptied(parentId)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if !parentEmptied {
+				break
+			}
+			orphaned.Add(parentId)
+			plans[owner].OrphanedMachines = append(plans[owner].OrphanedMachines, parentId)
+		}
+	}
+	return nil
+}
+
+// DestroyWhenUnused schedules the machine to be destroyed once it no longer
+// hosts any units or containers, as is the case for a machine whose units
+// and containers belong to applications that are being removed. If the
+// machine is put to use again before then, it is left alone.
+func (m *Machine) DestroyWhenUnused() error {
+	if m.IsManager() {
+		return errors.Errorf("machine %s is a controller machine", m.Id())
+	}
+	ops := []txn.Op{{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: isAliveDoc,
+	}, newCleanupOp(cleanupUnusedMachine, m.doc.Id)}
+	if err := m.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		// The machine is already going away.
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot schedule removal of machine %s", m.Id())
+	}
+	return nil
+}
+
+// cleanupUnusedMachine destroys the machine once it no longer hosts units
+// or containers. While the units and containers on it are being removed,
+// it fails so that it is tried again later.
+func (st *State) cleanupUnusedMachine(machineId string) error {
+	machine, err := st.Machine(machineId)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if machine.Life() != Alive {
+		return nil
+	}
+	inUse, err := st.machineInUse(machine)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if inUse {
+		logger.Debugf("machine %s is in use again, not destroying it", machineId)
+		return nil
+	}
+	containers, err := machine.Containers()
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if len(machine.Principals()) > 0 || len(containers) > 0 {
+		return errors.Errorf("machine %s still has units or containers being removed", machineId)
+	}
+	return errors.Trace(machine.Destroy())
+}
+
+// machineInUse reports whether the machine, or any of its containers,
+// hosts an alive unit of an alive application.
+func (st *State) machineInUse(m *Machine) (bool, error) {
+	for _, unitName := range m.Principals() {
+		unit, err := st.Unit(unitName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		app, err := unit.Application()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if unit.Life() == Alive && app.Life() == Alive {
+			return true, nil
+		}
+	}
+	containers, err := m.Containers()
+	if err != nil && !errors.IsNotFound(err) {
+		return false, errors.Trace(err)
+	}
+	for _, containerId := range containers {
+		container, err := st.Machine(containerId)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		inUse, err := st.machineInUse(container)
+		if err != nil || inUse {
+			return inUse, errors.Trace(err)
+		}
+	}
+	return false, nil
+}
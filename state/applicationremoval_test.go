@@ -0,0 +1,161 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/state"
+)
+
+type ApplicationRemovalSuite struct {
+	ConnSuite
+	wordpress *state.Application
+	unit      *state.Unit
+}
+
+var _ = gc.Suite(&ApplicationRemovalSuite{})
+
+func (s *ApplicationRemovalSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.wordpress = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	var err error
+	s.unit, err = s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationRemovalSuite) addLogging(c *gc.C) *state.Relation {
+	s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
+	eps, err := s.State.InferEndpoints("logging", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	return rel
+}
+
+func (s *ApplicationRemovalSuite) TestPlan(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	rel := s.addLogging(c)
+
+	plans, err := s.State.ApplicationsRemovalPlan("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plans, jc.DeepEquals, []state.ApplicationRemovalPlan{{
+		Application:          "wordpress",
+		Units:                []string{"wordpress/0"},
+		Subordinates:         []string{"logging/0"},
+		Relations:            []string{rel.String()},
+		OrphanedApplications: []string{"logging"},
+		Machines:             []string{machine.Id()},
+	}})
+}
+
+func (s *ApplicationRemovalSuite) TestPlanWithSubordinateApplication(c *gc.C) {
+	rel := s.addLogging(c)
+
+	plans, err := s.State.ApplicationsRemovalPlan("wordpress", "logging")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plans, jc.DeepEquals, []state.ApplicationRemovalPlan{{
+		Application: "wordpress",
+		Units:       []string{"wordpress/0"},
+		Relations:   []string{rel.String()},
+	}, {
+		Application: "logging",
+		Units:       []string{"logging/0"},
+	}})
+}
+
+func (s *ApplicationRemovalSuite) TestPlanSharedMachine(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	mysqlUnit, err := mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysqlUnit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	plans, err := s.State.ApplicationsRemovalPlan("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plans, gc.HasLen, 1)
+	c.Assert(plans[0].Machines, gc.HasLen, 0)
+	c.Assert(plans[0].OrphanedMachines, gc.HasLen, 0)
+
+	plans, err = s.State.ApplicationsRemovalPlan("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plans, gc.HasLen, 2)
+	c.Assert(plans[0].Machines, jc.DeepEquals, []string{machine.Id()})
+	c.Assert(plans[1].Machines, gc.HasLen, 0)
+}
+
+func (s *ApplicationRemovalSuite) TestPlanOrphanedHost(c *gc.C) {
+	template := state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}
+	container, err := s.State.AddMachineInsideNewMachine(template, template, instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(container)
+	c.Assert(err, jc.ErrorIsNil)
+	hostId, _ := container.ParentId()
+
+	plans, err := s.State.ApplicationsRemovalPlan("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plans, gc.HasLen, 1)
+	c.Assert(plans[0].Machines, jc.DeepEquals, []string{container.Id()})
+	c.Assert(plans[0].OrphanedMachines, jc.DeepEquals, []string{hostId})
+}
+
+func (s *ApplicationRemovalSuite) TestPlanNotFound(c *gc.C) {
+	_, err := s.State.ApplicationsRemovalPlan("wordpress", "unknown")
+	c.Assert(err, gc.ErrorMatches, `application "unknown" not found`)
+}
+
+func (s *ApplicationRemovalSuite) TestDestroyWhenUnused(c *gc.C) {
+	template := state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}
+	container, err := s.State.AddMachineInsideNewMachine(template, template, instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(container)
+	c.Assert(err, jc.ErrorIsNil)
+	hostId, _ := container.ParentId()
+	host, err := s.State.Machine(hostId)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = host.DestroyWhenUnused()
+	c.Assert(err, jc.ErrorIsNil)
+	// The unit is still in use, so the host is left alone.
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	assertLife(c, host, state.Alive)
+
+	err = host.DestroyWhenUnused()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	assertRemoved(c, s.unit)
+	// The host is destroyed once its container has been removed.
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	assertLife(c, host, state.Alive)
+
+	err = container.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = container.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	assertLife(c, host, state.Dying)
+}
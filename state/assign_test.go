@@ -15,7 +15,9 @@ import (
 	"github.com/juju/txn"
 	"github.com/juju/utils/arch"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
 
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/state"
@@ -307,6 +309,191 @@ func (s *AssignSuite) TestAssignMachinePrincipalsChange(c *gc.C) {
 	c.Assert(checkPrincipals(), gc.DeepEquals, []string{"wordpress/0"})
 }
 
+func (s *AssignSuite) setPlacementPolicy(c *gc.C, app *state.Application, attrs application.ConfigAttributes) {
+	schema := environschema.Fields{
+		application.PlacementSpreadOptionName:             {Type: environschema.Tstring},
+		application.PlacementMaxUnitsPerMachineOptionName: {Type: environschema.Tint},
+		application.PlacementAvoidOptionName:              {Type: environschema.Tstring},
+	}
+	err := app.UpdateApplicationConfig(attrs, nil, schema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AssignSuite) TestAssignUnitPlacementMaxUnitsPerMachine(c *gc.C) {
+	s.setPlacementPolicy(c, s.wordpress, application.ConfigAttributes{
+		application.PlacementMaxUnitsPerMachineOptionName: 2,
+	})
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	for i := 0; i < 2; i++ {
+		unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.AssignToMachine(machine)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "wordpress/2" to machine 0: machine 0 already hosts 2 unit\(s\) of application "wordpress"`)
+}
+
+func (s *AssignSuite) TestAssignUnitPlacementMaxUnitsPerMachineConcurrent(c *gc.C) {
+	s.setPlacementPolicy(c, s.wordpress, application.ConfigAttributes{
+		application.PlacementMaxUnitsPerMachineOptionName: 1,
+	})
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	unit0, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	unit1, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Another unit is assigned after the policy has been checked; the
+	// assignment must be retried and rejected rather than exceeding it.
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := unit0.AssignToMachine(machine)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err = unit1.AssignToMachine(machine)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "wordpress/1" to machine 0: machine 0 already hosts 1 unit\(s\) of application "wordpress"`)
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.Principals(), jc.DeepEquals, []string{"wordpress/0"})
+}
+
+func (s *AssignSuite) TestAssignUnitPlacementSpreadMachine(c *gc.C) {
+	s.setPlacementPolicy(c, s.wordpress, application.ConfigAttributes{
+		application.PlacementSpreadOptionName: "machine",
+	})
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	unit, err = s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "wordpress/1" to machine 0: machine 0 already hosts 1 unit\(s\) of application "wordpress"`)
+}
+
+func (s *AssignSuite) TestAssignUnitPlacementAvoid(c *gc.C) {
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.setPlacementPolicy(c, mysql, application.ConfigAttributes{
+		application.PlacementAvoidOptionName: "wordpress",
+	})
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The policy of the application being placed is honoured...
+	mysqlUnit, err := mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysqlUnit.AssignToMachine(machine)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "mysql/0" to machine 0: application "mysql" may not share machine 0 with application "wordpress"`)
+
+	// ...as is the policy of the applications already on the machine.
+	other, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysqlUnit.AssignToMachine(other)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err = s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(other)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "wordpress/1" to machine 1: application "wordpress" may not share machine 1 with application "mysql"`)
+}
+
+// addSpreadMachines adds a machine for each of the given keys, setting
+// each key with setKey unless it's empty, and assigns a unit of wordpress
+// to each of the first hosting machines.
+func (s *AssignSuite) addSpreadMachines(
+	c *gc.C, keys []string, hosting int, setKey func(*state.Machine, string),
+) []*state.Machine {
+	var machines []*state.Machine
+	for i, key := range keys {
+		m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+		c.Assert(err, jc.ErrorIsNil)
+		if key != "" {
+			setKey(m, key)
+		}
+		if i < hosting {
+			unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+			c.Assert(err, jc.ErrorIsNil)
+			err = unit.AssignToMachine(m)
+			c.Assert(err, jc.ErrorIsNil)
+		}
+		machines = append(machines, m)
+	}
+	return machines
+}
+
+func (s *AssignSuite) assertSpreadOrder(c *gc.C, candidates []*state.Machine, expect ...*state.Machine) {
+	unit, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	ordered, err := state.SpreadUnit(unit, candidates)
+	c.Assert(err, jc.ErrorIsNil)
+	var ids, expectIds []string
+	for _, m := range ordered {
+		ids = append(ids, m.Id())
+	}
+	for _, m := range expect {
+		expectIds = append(expectIds, m.Id())
+	}
+	c.Assert(ids, jc.DeepEquals, expectIds)
+}
+
+func (s *AssignSuite) TestSpreadUnitByZone(c *gc.C) {
+	s.setPlacementPolicy(c, s.wordpress, application.ConfigAttributes{
+		application.PlacementSpreadOptionName: "zone",
+	})
+	// Zone a hosts two units of wordpress, zone b one, and an
+	// unprovisioned machine another; zone c hosts none.
+	m := s.addSpreadMachines(c, []string{"a", "a", "b", "", "", "a", "b", "c", "c"}, 4,
+		func(m *state.Machine, zone string) {
+			hc := instance.HardwareCharacteristics{AvailabilityZone: &zone}
+			err := m.SetProvisioned(instance.Id("inst-"+m.Id()), "", "fake_nonce", &hc)
+			c.Assert(err, jc.ErrorIsNil)
+		},
+	)
+	// Machines in the least populated zones come first, keeping their
+	// order otherwise, and unprovisioned machines with no zone last.
+	s.assertSpreadOrder(c,
+		[]*state.Machine{m[4], m[5], m[7], m[6], m[8]},
+		m[7], m[8], m[6], m[5], m[4],
+	)
+}
+
+func (s *AssignSuite) TestSpreadUnitByLabel(c *gc.C) {
+	s.setPlacementPolicy(c, s.wordpress, application.ConfigAttributes{
+		application.PlacementSpreadOptionName: "label:rack",
+	})
+	// Rack r1 hosts two units of wordpress and rack r2 one; rack r3
+	// hosts none.
+	m := s.addSpreadMachines(c, []string{"r1", "r1", "r2", "", "r1", "r2", "r3", "r3"}, 3,
+		func(m *state.Machine, rack string) {
+			err := s.Model.SetAnnotations(m, map[string]string{"rack": rack})
+			c.Assert(err, jc.ErrorIsNil)
+		},
+	)
+	// Machines with the least populated labels come first, keeping
+	// their order otherwise, and machines without the label last.
+	s.assertSpreadOrder(c,
+		[]*state.Machine{m[3], m[4], m[6], m[5], m[7]},
+		m[6], m[7], m[5], m[4], m[3],
+	)
+}
+
+func (s *AssignSuite) TestSpreadUnitNotSpread(c *gc.C) {
+	m := s.addSpreadMachines(c, []string{"", "", ""}, 1, nil)
+	s.assertSpreadOrder(c, []*state.Machine{m[2], m[0], m[1]}, m[2], m[0], m[1])
+}
+
 func (s *AssignSuite) assertAssignedUnit(c *gc.C, unit *state.Unit) string {
 	// Check the machine on the unit is set.
 	machineId, err := unit.AssignedMachineId()
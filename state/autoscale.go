@@ -0,0 +1,284 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/status"
+)
+
+// AutoscaleDecision records a change made, or attempted, by the
+// autoscaler to the number of units of an application.
+type AutoscaleDecision struct {
+	doc autoscaleDecisionDoc
+}
+
+type autoscaleDecisionDoc struct {
+	DocId     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	Application string    `bson:"application"`
+	Time        time.Time `bson:"time"`
+	FromUnits   int       `bson:"from-units"`
+	ToUnits     int       `bson:"to-units"`
+
+	// Source and Value hold what the autoscaler measured, and the
+	// measurement that led to the decision.
+	Source string  `bson:"source"`
+	Value  float64 `bson:"value"`
+	Reason string  `bson:"reason"`
+
+	// Error holds the reason the scaling failed, if it did.
+	Error string `bson:"error,omitempty"`
+}
+
+// ApplicationName returns the name of the scaled application.
+func (d *AutoscaleDecision) ApplicationName() string {
+	return d.doc.Application
+}
+
+// Time returns the time of the decision.
+func (d *AutoscaleDecision) Time() time.Time {
+	return d.doc.Time
+}
+
+// FromUnits returns the number of units the application had.
+func (d *AutoscaleDecision) FromUnits() int {
+	return d.doc.FromUnits
+}
+
+// ToUnits returns the number of units the application was scaled to.
+func (d *AutoscaleDecision) ToUnits() int {
+	return d.doc.ToUnits
+}
+
+// Source returns what the autoscaler measured.
+func (d *AutoscaleDecision) Source() string {
+	return d.doc.Source
+}
+
+// Value returns the measurement that led to the decision.
+func (d *AutoscaleDecision) Value() float64 {
+	return d.doc.Value
+}
+
+// Reason returns a description of why the application was scaled.
+func (d *AutoscaleDecision) Reason() string {
+	return d.doc.Reason
+}
+
+// Error returns the reason the scaling failed, or the empty string if it
+// succeeded.
+func (d *AutoscaleDecision) Error() string {
+	return d.doc.Error
+}
+
+// AutoscaleDecisionArgs holds the details of a scaling decision to be
+// recorded with RecordAutoscaleDecision.
+type AutoscaleDecisionArgs struct {
+	Application string
+	FromUnits   int
+	ToUnits     int
+	Source      string
+	Value       float64
+	Reason      string
+
+	// Err holds the error that caused the scaling to fail, if any.
+	Err error
+}
+
+// RecordAutoscaleDecision records a change made, or attempted, by the
+// autoscaler to the number of units of an application.
+func (m *Model) RecordAutoscaleDecision(args AutoscaleDecisionArgs) (*AutoscaleDecision, error) {
+	doc := autoscaleDecisionDoc{
+		DocId:       bson.NewObjectId().Hex(),
+		ModelUUID:   m.UUID(),
+		Application: args.Application,
+		Time:        m.st.nowToTheSecond(),
+		FromUnits:   args.FromUnits,
+		ToUnits:     args.ToUnits,
+		Source:      args.Source,
+		Value:       args.Value,
+		Reason:      args.Reason,
+	}
+	if args.Err != nil {
+		doc.Error = args.Err.Error()
+	}
+	ops := []txn.Op{{
+		C:      autoscaleDecisionsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return nil, errors.Annotatef(err, "cannot record scaling of application %q", args.Application)
+	}
+	return &AutoscaleDecision{doc: doc}, nil
+}
+
+// AutoscaleDecisions returns the scaling decisions recorded for the named
+// application, oldest first.
+func (m *Model) AutoscaleDecisions(appName string) ([]*AutoscaleDecision, error) {
+	decisions, closer := m.st.db().GetCollection(autoscaleDecisionsC)
+	defer closer()
+
+	var docs []autoscaleDecisionDoc
+	err := decisions.Find(bson.D{{"application", appName}}).Sort("time", "_id").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get scaling decisions for application %q", appName)
+	}
+	results := make([]*AutoscaleDecision, len(docs))
+	for i, doc := range docs {
+		results[i] = &AutoscaleDecision{doc: doc}
+	}
+	return results, nil
+}
+
+// aliveUnits returns the application's alive units, in order of unit
+// number.
+func (a *Application) aliveUnits() ([]*Unit, error) {
+	units, err := a.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var alive []*Unit
+	for _, u := range units {
+		if u.Life() == Alive {
+			alive = append(alive, u)
+		}
+	}
+	sort.Slice(alive, func(i, j int) bool {
+		return alive[i].UnitTag().Number() < alive[j].UnitTag().Number()
+	})
+	return alive, nil
+}
+
+// MetricMean returns the mean, across the application's alive units, of
+// the latest value of the given metric reported by each unit within the
+// given window. It returns false if no unit has reported a value in that
+// time.
+func (a *Application) MetricMean(key string, window time.Duration) (float64, bool, error) {
+	units, err := a.aliveUnits()
+	if err != nil || len(units) == 0 {
+		return 0, false, errors.Trace(err)
+	}
+	unitNames := make([]string, len(units))
+	for i, u := range units {
+		unitNames[i] = u.Name()
+	}
+	batches, err := a.st.queryMetricBatches(bson.M{
+		"model-uuid": a.st.ModelUUID(),
+		"unit":       bson.M{"$in": unitNames},
+		"created":    bson.M{"$gt": a.st.clock().Now().Add(-window)},
+	})
+	if err != nil {
+		return 0, false, errors.Trace(err)
+	}
+	latest := make(map[string]Metric)
+	for _, batch := range batches {
+		for _, metric := range batch.Metrics() {
+			if metric.Key != key || len(metric.Labels) > 0 {
+				continue
+			}
+			if prev, ok := latest[batch.Unit()]; !ok || metric.Time.After(prev.Time) {
+				latest[batch.Unit()] = metric
+			}
+		}
+	}
+	var sum float64
+	var count int
+	for unitName, metric := range latest {
+		value, err := strconv.ParseFloat(metric.Value, 64)
+		if err != nil {
+			logger.Warningf("ignoring metric %q of unit %q: %v", key, unitName, err)
+			continue
+		}
+		sum += value
+		count++
+	}
+	if count == 0 {
+		return 0, false, nil
+	}
+	return sum / float64(count), true, nil
+}
+
+// WorkloadStatusCount returns the number of the application's alive units
+// with the given workload status.
+func (a *Application) WorkloadStatusCount(s status.Status) (int, error) {
+	units, err := a.aliveUnits()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	var count int
+	for _, u := range units {
+		info, err := u.Status()
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		if info.Status == s {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// AutoscaleUnits returns the number of units the autoscaler considers the
+// application to have: its scale for a CAAS application, and its number
+// of alive units otherwise.
+func (a *Application) AutoscaleUnits() (int, error) {
+	model, err := a.st.Model()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if model.Type() == ModelTypeCAAS {
+		return a.GetScale(), nil
+	}
+	units, err := a.aliveUnits()
+	return len(units), errors.Trace(err)
+}
+
+// Autoscale changes the number of units of the application. A CAAS
+// application has its scale set; otherwise units are added and assigned
+// to clean, empty machines where possible, or the newest units are
+// destroyed. A machine is destroyed along with the last unit on it, as
+// it is when a unit is removed by hand; machines that still host other
+// units or containers are kept.
+func (a *Application) Autoscale(units int) error {
+	if units < 0 {
+		return errors.NotValidf("negative number of units")
+	}
+	model, err := a.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if model.Type() == ModelTypeCAAS {
+		return errors.Trace(a.SetScale(units, 0, true))
+	}
+	alive, err := a.aliveUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for n := len(alive); n < units; n++ {
+		u, err := a.AddUnit(AddUnitParams{})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := a.st.AssignUnit(u, AssignCleanEmpty); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for n := len(alive); n > units; n-- {
+		if err := alive[n-1].Destroy(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
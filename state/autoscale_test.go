@@ -0,0 +1,156 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type AutoscaleSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&AutoscaleSuite{})
+
+func (s *AutoscaleSuite) TestRecordAutoscaleDecision(c *gc.C) {
+	clock := testclock.NewClock(coretesting.NonZeroTime().Round(time.Second))
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	decision, err := s.Model.RecordAutoscaleDecision(state.AutoscaleDecisionArgs{
+		Application: "wordpress",
+		FromUnits:   2,
+		ToUnits:     3,
+		Source:      "metric:load",
+		Value:       0.9,
+		Reason:      "metric:load 0.9 above 0.8",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decision.ApplicationName(), gc.Equals, "wordpress")
+	c.Assert(decision.Time(), gc.Equals, clock.Now())
+	c.Assert(decision.FromUnits(), gc.Equals, 2)
+	c.Assert(decision.ToUnits(), gc.Equals, 3)
+	c.Assert(decision.Source(), gc.Equals, "metric:load")
+	c.Assert(decision.Value(), gc.Equals, 0.9)
+	c.Assert(decision.Reason(), gc.Equals, "metric:load 0.9 above 0.8")
+	c.Assert(decision.Error(), gc.Equals, "")
+
+	clock.Advance(time.Minute)
+	_, err = s.Model.RecordAutoscaleDecision(state.AutoscaleDecisionArgs{
+		Application: "wordpress",
+		FromUnits:   3,
+		ToUnits:     4,
+		Err:         errors.New("boom"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.Model.RecordAutoscaleDecision(state.AutoscaleDecisionArgs{
+		Application: "mysql",
+		FromUnits:   1,
+		ToUnits:     2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	decisions, err := s.Model.AutoscaleDecisions("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decisions, gc.HasLen, 2)
+	c.Assert(decisions[0].ToUnits(), gc.Equals, 3)
+	c.Assert(decisions[1].ToUnits(), gc.Equals, 4)
+	c.Assert(decisions[1].Time(), gc.Equals, clock.Now())
+	c.Assert(decisions[1].Error(), gc.Equals, "boom")
+}
+
+func (s *AutoscaleSuite) TestAutoscaleUnits(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	for i := 0; i < 2; i++ {
+		s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	}
+	units, err := app.AutoscaleUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.Equals, 2)
+
+	err = app.Autoscale(3)
+	c.Assert(err, jc.ErrorIsNil)
+	units, err = app.AutoscaleUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.Equals, 3)
+	unit, err := s.State.Unit(app.Name() + "/2")
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The newest units are removed first.
+	err = app.Autoscale(1)
+	c.Assert(err, jc.ErrorIsNil)
+	units, err = app.AutoscaleUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.Equals, 1)
+	unit, err = s.State.Unit(app.Name() + "/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.Life(), gc.Equals, state.Alive)
+
+	// The machine of a removed unit is destroyed with it.
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.Life(), gc.Equals, state.Dying)
+}
+
+func (s *AutoscaleSuite) TestWorkloadStatusCount(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	for _, st := range []status.Status{status.Active, status.Blocked, status.Blocked} {
+		unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+		err := unit.SetStatus(status.StatusInfo{Status: st})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	count, err := app.WorkloadStatusCount(status.Blocked)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 2)
+}
+
+func (s *AutoscaleSuite) TestMetricMean(c *gc.C) {
+	meteredCharm := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "metered", URL: "cs:quantal/metered"})
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Charm: meteredCharm})
+
+	_, ok, err := app.MetricMean("pings", 10*time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsFalse)
+
+	now := coretesting.NonZeroTime().Round(time.Second)
+	earlier := now.Add(-time.Minute)
+	for _, values := range [][]string{{"1", "5"}, {"3"}} {
+		unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app, SetCharmURL: true})
+		for i, value := range values {
+			t := earlier
+			if i == len(values)-1 {
+				t = now
+			}
+			s.Factory.MakeMetric(c, &factory.MetricParams{
+				Unit:    unit,
+				Time:    &t,
+				Metrics: []state.Metric{{Key: "pings", Value: value, Time: t}},
+			})
+		}
+	}
+	// The latest value from the first unit is 5, and from the second 3.
+	mean, ok, err := app.MetricMean("pings", 10*time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(mean, gc.Equals, 4.0)
+
+	// Metrics reported before the window are ignored.
+	s.Clock.Advance(5 * time.Minute)
+	mean, ok, err = app.MetricMean("pings", 5*time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(mean, gc.Equals, 0.0)
+}
@@ -0,0 +1,185 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/charm/v7"
+	csparams "github.com/juju/charmrepo/v5/csclient/params"
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// CharmUpgrade records an automatic upgrade of an application's charm,
+// made according to the application's charm update policy. Each upgrade
+// is also recorded as an operation, and holds the information needed to
+// roll the upgrade back.
+type CharmUpgrade struct {
+	st  *State
+	doc charmUpgradeDoc
+}
+
+type charmUpgradeDoc struct {
+	DocId     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// Operation is the id of the operation recording the upgrade.
+	Operation string `bson:"operation"`
+
+	Application  string    `bson:"application"`
+	FromCharmURL string    `bson:"from-charm-url"`
+	ToCharmURL   string    `bson:"to-charm-url"`
+	Channel      string    `bson:"channel"`
+	Time         time.Time `bson:"time"`
+
+	// Error holds the reason for the failure of the upgrade, if any.
+	Error string `bson:"error,omitempty"`
+}
+
+// OperationId returns the id of the operation recording the upgrade.
+func (u *CharmUpgrade) OperationId() string {
+	return u.doc.Operation
+}
+
+// ApplicationName returns the name of the upgraded application.
+func (u *CharmUpgrade) ApplicationName() string {
+	return u.doc.Application
+}
+
+// From returns the URL of the charm used by the application before the
+// upgrade; upgrading the application back to it rolls the upgrade back.
+func (u *CharmUpgrade) From() *charm.URL {
+	return charm.MustParseURL(u.doc.FromCharmURL)
+}
+
+// To returns the URL of the charm the application was upgraded to.
+func (u *CharmUpgrade) To() *charm.URL {
+	return charm.MustParseURL(u.doc.ToCharmURL)
+}
+
+// Channel returns the channel the application was following at the time
+// of the upgrade.
+func (u *CharmUpgrade) Channel() csparams.Channel {
+	return csparams.Channel(u.doc.Channel)
+}
+
+// Time returns the time of the upgrade.
+func (u *CharmUpgrade) Time() time.Time {
+	return u.doc.Time
+}
+
+// Error returns the reason the upgrade failed, or the empty string if it
+// succeeded.
+func (u *CharmUpgrade) Error() string {
+	return u.doc.Error
+}
+
+// CharmUpgradeArgs holds the details of an automatic charm upgrade to
+// be recorded with RecordCharmUpgrade.
+type CharmUpgradeArgs struct {
+	// Application is the name of the upgraded application.
+	Application string
+
+	// From and To are the URLs of the charm used by the application
+	// before and after the upgrade.
+	From, To *charm.URL
+
+	// Channel is the channel the application is following.
+	Channel csparams.Channel
+
+	// Err holds the error that caused the upgrade to fail, if any.
+	Err error
+}
+
+// RecordCharmUpgrade records an automatic upgrade of an application's
+// charm as a completed, or failed, operation.
+func (m *Model) RecordCharmUpgrade(args CharmUpgradeArgs) (*CharmUpgrade, error) {
+	if args.From == nil || args.To == nil {
+		return nil, errors.NotValidf("charm upgrade without charm URLs")
+	}
+	summary := fmt.Sprintf("automatic upgrade of application %q from %s to %s", args.Application, args.From, args.To)
+	status := ActionCompleted
+	var errMessage string
+	if args.Err != nil {
+		status = ActionFailed
+		errMessage = args.Err.Error()
+	}
+
+	var doc charmUpgradeDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		opDoc, operationID, err := newOperationDoc(m.st, summary)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		now := opDoc.Enqueued
+		opDoc.Started = now
+		opDoc.Completed = now
+		opDoc.Status = status
+
+		doc = charmUpgradeDoc{
+			DocId:        opDoc.DocId,
+			ModelUUID:    m.UUID(),
+			Operation:    operationID,
+			Application:  args.Application,
+			FromCharmURL: args.From.String(),
+			ToCharmURL:   args.To.String(),
+			Channel:      string(args.Channel),
+			Time:         now,
+			Error:        errMessage,
+		}
+		return []txn.Op{{
+			C:      operationsC,
+			Id:     opDoc.DocId,
+			Assert: txn.DocMissing,
+			Insert: opDoc,
+		}, {
+			C:      charmUpgradesC,
+			Id:     doc.DocId,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot record charm upgrade of application %q", args.Application)
+	}
+	return &CharmUpgrade{st: m.st, doc: doc}, nil
+}
+
+// CharmUpgrade returns the automatic charm upgrade recorded by the
+// operation with the given id.
+func (m *Model) CharmUpgrade(operationID string) (*CharmUpgrade, error) {
+	upgrades, closer := m.st.db().GetCollection(charmUpgradesC)
+	defer closer()
+
+	var doc charmUpgradeDoc
+	err := upgrades.FindId(operationID).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("charm upgrade for operation %q", operationID)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get charm upgrade for operation %q", operationID)
+	}
+	return &CharmUpgrade{st: m.st, doc: doc}, nil
+}
+
+// CharmUpgrades returns the automatic charm upgrades recorded for the
+// named application, oldest first.
+func (m *Model) CharmUpgrades(appName string) ([]*CharmUpgrade, error) {
+	upgrades, closer := m.st.db().GetCollection(charmUpgradesC)
+	defer closer()
+
+	var docs []charmUpgradeDoc
+	err := upgrades.Find(bson.D{{"application", appName}}).Sort("time", "_id").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get charm upgrades for application %q", appName)
+	}
+	results := make([]*CharmUpgrade, len(docs))
+	for i, doc := range docs {
+		results[i] = &CharmUpgrade{st: m.st, doc: doc}
+	}
+	return results, nil
+}
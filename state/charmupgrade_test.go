@@ -0,0 +1,99 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type CharmUpgradeSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&CharmUpgradeSuite{})
+
+func (s *CharmUpgradeSuite) TestRecordCharmUpgrade(c *gc.C) {
+	clock := testclock.NewClock(coretesting.NonZeroTime().Round(time.Second))
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	upgrade, err := s.Model.RecordCharmUpgrade(state.CharmUpgradeArgs{
+		Application: "wordpress",
+		From:        charm.MustParseURL("cs:quantal/wordpress-3"),
+		To:          charm.MustParseURL("cs:quantal/wordpress-4"),
+		Channel:     "stable",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.ApplicationName(), gc.Equals, "wordpress")
+	c.Assert(upgrade.From(), gc.DeepEquals, charm.MustParseURL("cs:quantal/wordpress-3"))
+	c.Assert(upgrade.To(), gc.DeepEquals, charm.MustParseURL("cs:quantal/wordpress-4"))
+	c.Assert(string(upgrade.Channel()), gc.Equals, "stable")
+	c.Assert(upgrade.Time(), gc.Equals, clock.Now())
+	c.Assert(upgrade.Error(), gc.Equals, "")
+
+	operation, err := s.Model.Operation(upgrade.OperationId())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Status(), gc.Equals, state.ActionCompleted)
+	c.Assert(operation.Completed(), gc.Equals, clock.Now())
+	c.Assert(operation.Summary(), gc.Equals,
+		`automatic upgrade of application "wordpress" from cs:quantal/wordpress-3 to cs:quantal/wordpress-4`)
+
+	fetched, err := s.Model.CharmUpgrade(upgrade.OperationId())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fetched.To(), gc.DeepEquals, upgrade.To())
+}
+
+func (s *CharmUpgradeSuite) TestRecordFailedCharmUpgrade(c *gc.C) {
+	upgrade, err := s.Model.RecordCharmUpgrade(state.CharmUpgradeArgs{
+		Application: "wordpress",
+		From:        charm.MustParseURL("cs:quantal/wordpress-3"),
+		To:          charm.MustParseURL("cs:quantal/wordpress-4"),
+		Err:         errors.New("boom"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.Error(), gc.Equals, "boom")
+
+	operation, err := s.Model.Operation(upgrade.OperationId())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Status(), gc.Equals, state.ActionFailed)
+}
+
+func (s *CharmUpgradeSuite) TestCharmUpgrades(c *gc.C) {
+	for _, args := range []state.CharmUpgradeArgs{{
+		Application: "wordpress",
+		From:        charm.MustParseURL("cs:quantal/wordpress-3"),
+		To:          charm.MustParseURL("cs:quantal/wordpress-4"),
+	}, {
+		Application: "mysql",
+		From:        charm.MustParseURL("cs:quantal/mysql-1"),
+		To:          charm.MustParseURL("cs:quantal/mysql-2"),
+	}, {
+		Application: "wordpress",
+		From:        charm.MustParseURL("cs:quantal/wordpress-4"),
+		To:          charm.MustParseURL("cs:quantal/wordpress-5"),
+	}} {
+		_, err := s.Model.RecordCharmUpgrade(args)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	upgrades, err := s.Model.CharmUpgrades("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrades, gc.HasLen, 2)
+	c.Assert(upgrades[0].To().String(), gc.Equals, "cs:quantal/wordpress-4")
+	c.Assert(upgrades[1].To().String(), gc.Equals, "cs:quantal/wordpress-5")
+}
+
+func (s *CharmUpgradeSuite) TestCharmUpgradeNotFound(c *gc.C) {
+	_, err := s.Model.CharmUpgrade("42")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
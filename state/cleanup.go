@@ -46,6 +46,7 @@ const (
 	cleanupDyingMachine                  cleanupKind = "dyingMachine"
 	cleanupForceDestroyedMachine         cleanupKind = "machine"
 	cleanupForceRemoveMachine            cleanupKind = "forceRemoveMachine"
+	cleanupUnusedMachine                 cleanupKind = "unusedMachine"
 	cleanupAttachmentsForDyingStorage    cleanupKind = "storageAttachments"
 	cleanupAttachmentsForDyingVolume     cleanupKind = "volumeAttachments"
 	cleanupAttachmentsForDyingFilesystem cleanupKind = "filesystemAttachments"
@@ -188,6 +189,8 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupForceDestroyedMachine(doc.Prefix, args)
 		case cleanupForceRemoveMachine:
 			err = st.cleanupForceRemoveMachine(doc.Prefix, args)
+		case cleanupUnusedMachine:
+			err = st.cleanupUnusedMachine(doc.Prefix)
 		case cleanupAttachmentsForDyingStorage:
 			err = st.cleanupAttachmentsForDyingStorage(doc.Prefix, args)
 		case cleanupAttachmentsForDyingVolume:
@@ -29,6 +29,7 @@ type constraintsDoc struct {
 	Container      *instance.ContainerType
 	Tags           *[]string
 	Spaces         *[]string
+	SpotMaxPrice   *string
 	VirtType       *string
 	Zones          *[]string
 }
@@ -46,6 +47,7 @@ func newConstraintsDoc(cons constraints.Value, id string) constraintsDoc {
 		Container:      cons.Container,
 		Tags:           cons.Tags,
 		Spaces:         cons.Spaces,
+		SpotMaxPrice:   cons.SpotMaxPrice,
 		VirtType:       cons.VirtType,
 		Zones:          cons.Zones,
 	}
@@ -64,6 +66,7 @@ func (doc constraintsDoc) value() constraints.Value {
 		Container:      doc.Container,
 		Tags:           doc.Tags,
 		Spaces:         doc.Spaces,
+		SpotMaxPrice:   doc.SpotMaxPrice,
 		VirtType:       doc.VirtType,
 		Zones:          doc.Zones,
 	}
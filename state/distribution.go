@@ -5,10 +5,14 @@ package state
 
 import (
 	"fmt"
+	"math"
+	"sort"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
 
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/environs/context"
 )
@@ -96,3 +100,178 @@ func ApplicationMachines(st *State, application string) ([]string, error) {
 	}
 	return machineIds, nil
 }
+
+// placementPolicyError is returned when assigning a unit to a machine
+// would break the placement policy of the unit's application, or of an
+// application already on the machine.
+type placementPolicyError struct {
+	reason string
+}
+
+func (e *placementPolicyError) Error() string {
+	return e.reason
+}
+
+func isPlacementPolicyError(err error) bool {
+	_, ok := errors.Cause(err).(*placementPolicyError)
+	return ok
+}
+
+// applicationPlacementPolicy returns the placement policy held in the
+// named application's config.
+func applicationPlacementPolicy(st *State, appName string) (application.PlacementPolicy, error) {
+	app, err := st.Application(appName)
+	if err != nil {
+		return application.PlacementPolicy{}, errors.Trace(err)
+	}
+	cfg, err := app.ApplicationConfig()
+	if err != nil {
+		return application.PlacementPolicy{}, errors.Trace(err)
+	}
+	policy, err := cfg.PlacementPolicy()
+	return policy, errors.Trace(err)
+}
+
+// checkPlacementPolicy returns a placementPolicyError if the unit may not
+// be assigned to the machine, either because the machine already hosts
+// as many units of the unit's application as its policy allows, or
+// because the unit's application and one already on the machine must
+// never share a machine.
+func checkPlacementPolicy(u *Unit, m *Machine) error {
+	appName := u.ApplicationName()
+	policy, err := applicationPlacementPolicy(u.st, appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var hosted int
+	others := set.NewStrings()
+	for _, principal := range m.Principals() {
+		if principal == u.Name() {
+			continue
+		}
+		if name := unitAppName(principal); name == appName {
+			hosted++
+		} else {
+			others.Add(name)
+		}
+	}
+	if limit := policy.UnitsPerMachine(); limit > 0 && hosted >= limit {
+		return &placementPolicyError{fmt.Sprintf(
+			"machine %s already hosts %d unit(s) of application %q", m.Id(), hosted, appName,
+		)}
+	}
+	for _, other := range others.SortedValues() {
+		avoids := policy.Avoids(other)
+		if !avoids {
+			otherPolicy, err := applicationPlacementPolicy(u.st, other)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+			avoids = otherPolicy.Avoids(appName)
+		}
+		if avoids {
+			return &placementPolicyError{fmt.Sprintf(
+				"application %q may not share machine %s with application %q", appName, m.Id(), other,
+			)}
+		}
+	}
+	return nil
+}
+
+// principalsUnchangedAssert returns a txn assertion that the machine's
+// principal units are those in the input machine's document, so that
+// a placement policy check made against them still holds when a unit
+// assignment is applied.
+func principalsUnchangedAssert(m *Machine) bson.D {
+	if len(m.doc.Principals) == 0 {
+		// Matches a missing, null or empty principals field.
+		return bson.D{{"principals.0", bson.D{{"$exists", false}}}}
+	}
+	return bson.D{{"principals", m.doc.Principals}}
+}
+
+// spreadUnit orders the candidate machines for a unit so that those in
+// the zones, or with the label values, hosting the fewest units of the
+// unit's application come first. The order is otherwise preserved. If the
+// application is not spread by zone or label, the candidates are returned
+// unchanged.
+func spreadUnit(u *Unit, candidates []*Machine) ([]*Machine, error) {
+	if len(candidates) < 2 {
+		return candidates, nil
+	}
+	policy, err := applicationPlacementPolicy(u.st, u.ApplicationName())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var spreadKey func(*Machine) (string, error)
+	switch label := policy.Spread.Label(); {
+	case policy.Spread == application.PlacementSpreadZone:
+		spreadKey = machineZone
+	case label != "":
+		model, err := u.st.Model()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		spreadKey = func(m *Machine) (string, error) {
+			return model.Annotation(m, label)
+		}
+	default:
+		return candidates, nil
+	}
+
+	machineIds, err := ApplicationMachines(u.st, u.ApplicationName())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	population := make(map[string]int)
+	for _, id := range machineIds {
+		m, err := u.st.Machine(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		key, err := spreadKey(m)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		population[key]++
+	}
+	keys := make([]string, len(candidates))
+	for i, m := range candidates {
+		if keys[i], err = spreadKey(m); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	// Machines without a zone or label only have a population of
+	// their own if nothing else is available.
+	rank := func(key string) int {
+		if key == "" {
+			return math.MaxInt32
+		}
+		return population[key]
+	}
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return rank(keys[order[i]]) < rank(keys[order[j]])
+	})
+	result := make([]*Machine, len(candidates))
+	for i, index := range order {
+		result[i] = candidates[index]
+	}
+	return result, nil
+}
+
+// machineZone returns the availability zone of the machine, or "" if it
+// has not been provisioned.
+func machineZone(m *Machine) (string, error) {
+	zone, err := m.AvailabilityZone()
+	if errors.IsNotProvisioned(err) {
+		return "", nil
+	}
+	return zone, errors.Trace(err)
+}
@@ -1008,3 +1008,9 @@ func (st *State) SetClockForTesting(clock clock.Clock) error {
 	}
 	return nil
 }
+
+// SpreadUnit returns the candidate machines for the unit in the order
+// they're tried when the unit is assigned to a clean machine.
+func SpreadUnit(u *Unit, candidates []*Machine) ([]*Machine, error) {
+	return spreadUnit(u, candidates)
+}
@@ -330,6 +330,14 @@ func (st *State) modelSetupOps(controllerUUID string, args ModelArgs, inherited
 	modelUserOps := createModelUserOps(
 		modelUUID, args.Owner, args.Owner, args.Owner.Name(), st.nowToTheSecond(), permission.AdminAccess,
 	)
+	for _, user := range args.Users {
+		if userAccessID(user.User) == userAccessID(args.Owner) {
+			continue
+		}
+		modelUserOps = append(modelUserOps, createModelUserOps(
+			modelUUID, user.User, args.Owner, user.User.Name(), st.nowToTheSecond(), user.Access,
+		)...)
+	}
 	ops := []txn.Op{
 		createStatusOp(st, modelGlobalKey, modelStatusDoc),
 		createConstraintsOp(modelGlobalKey, args.Constraints),
@@ -341,7 +349,7 @@ func (st *State) modelSetupOps(controllerUUID string, args ModelArgs, inherited
 
 	// Create the default storage pools for the model.
 	if args.StorageProviderRegistry != nil {
-		defaultStoragePoolsOps, err := st.createDefaultStoragePoolsOps(args.StorageProviderRegistry)
+		defaultStoragePoolsOps, err := st.createDefaultStoragePoolsOps(args.StorageProviderRegistry, args.StoragePools)
 		if err != nil {
 			return nil, modelStatusDoc, errors.Trace(err)
 		}
@@ -401,11 +409,23 @@ func (st *State) modelSetupOps(controllerUUID string, args ModelArgs, inherited
 		createUniqueOwnerModelNameOp(args.Owner, args.Config.Name()),
 		st.createDefaultSpaceOp(),
 	)
+	for _, name := range args.Spaces {
+		spaceOps, err := st.addSpaceWithSubnetsTxnOps(name, "", nil, false)
+		if err != nil {
+			return nil, modelStatusDoc, errors.Annotatef(err, "adding space %q", name)
+		}
+		ops = append(ops, spaceOps...)
+	}
 	ops = append(ops, modelUserOps...)
 	return ops, modelStatusDoc, nil
 }
 
-func (st *State) createDefaultStoragePoolsOps(registry storage.ProviderRegistry) ([]txn.Op, error) {
+// createDefaultStoragePoolsOps returns the operations to create the
+// default storage pools of each provider, along with any extra pools.
+// An extra pool replaces a default pool with the same name.
+func (st *State) createDefaultStoragePoolsOps(
+	registry storage.ProviderRegistry, extraPools []ModelTemplateStoragePool,
+) ([]txn.Op, error) {
 	m := poolmanager.MemSettings{make(map[string]map[string]interface{})}
 	pm := poolmanager.New(m, registry)
 	providerTypes, err := registry.StorageProviderTypes()
@@ -423,6 +443,23 @@ func (st *State) createDefaultStoragePoolsOps(registry storage.ProviderRegistry)
 			)
 		}
 	}
+	for _, pool := range extraPools {
+		// The pool manager may modify the attributes it is given.
+		attrs := make(map[string]interface{})
+		for k, v := range pool.Attrs {
+			attrs[k] = v
+		}
+		if _, err := pm.Get(pool.Name); err == nil {
+			err = pm.Replace(pool.Name, pool.Provider, attrs)
+			if err != nil {
+				return nil, errors.Annotatef(err, "replacing storage pool %q", pool.Name)
+			}
+			continue
+		}
+		if _, err := pm.Create(pool.Name, storage.ProviderType(pool.Provider), attrs); err != nil {
+			return nil, errors.Annotatef(err, "adding storage pool %q", pool.Name)
+		}
+	}
 
 	var ops []txn.Op
 	for key, settings := range m.Settings {
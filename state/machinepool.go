@@ -0,0 +1,145 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// machinePoolDoc records that a machine was added to the model's machine
+// pool. The machine stays in the pool until a unit is assigned to it.
+type machinePoolDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	MachineId string `bson:"machine-id"`
+}
+
+// AddPoolMachine adds a new machine to the model's machine pool. The
+// machine is provisioned as usual, and then waits idle for a unit to be
+// assigned to it.
+func (st *State) AddPoolMachine(template MachineTemplate) (_ *Machine, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add a pool machine")
+	if template.Dirty || template.Placement != "" {
+		return nil, errors.NotValidf("pool machine with placement")
+	}
+	mdoc, ops, err := st.addMachineOps(template)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if isController(mdoc) {
+		return nil, errors.NotValidf("pool controller machine")
+	}
+	ops = append(ops, txn.Op{
+		C:      machinePoolC,
+		Id:     st.docID(mdoc.Id),
+		Assert: txn.DocMissing,
+		Insert: &machinePoolDoc{
+			DocID:     st.docID(mdoc.Id),
+			ModelUUID: st.ModelUUID(),
+			MachineId: mdoc.Id,
+		},
+	}, assertModelActiveOp(st.ModelUUID()))
+	if err := st.db().RunTransaction(ops); err != nil {
+		if errors.Cause(err) == txn.ErrAborted {
+			if err := checkModelActive(st); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		return nil, errors.Trace(err)
+	}
+	return newMachine(st, mdoc), nil
+}
+
+// MachinePool returns the machines in the model's machine pool, in order
+// of machine id. A pooled machine leaves the pool when a unit is assigned
+// to it, or when it is destroyed.
+func (st *State) MachinePool() ([]*Machine, error) {
+	machines, _, err := st.machinePool()
+	return machines, errors.Trace(err)
+}
+
+// machinePool returns the machines in the model's machine pool, and the
+// ids of the machines that have left it.
+func (st *State) machinePool() ([]*Machine, []string, error) {
+	pool, closer := st.db().GetCollection(machinePoolC)
+	defer closer()
+
+	var docs []machinePoolDoc
+	if err := pool.Find(nil).All(&docs); err != nil {
+		return nil, nil, errors.Annotate(err, "cannot get machine pool")
+	}
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.MachineId
+	}
+	machinesCollection, closer := st.db().GetCollection(machinesC)
+	defer closer()
+
+	var mdocs []machineDoc
+	if err := machinesCollection.Find(bson.D{{"machineid", bson.D{{"$in", ids}}}}).All(&mdocs); err != nil {
+		return nil, nil, errors.Annotate(err, "cannot get machine pool")
+	}
+	pooled := make(map[string]bool)
+	var machines []*Machine
+	for i := range mdocs {
+		mdoc := &mdocs[i]
+		if mdoc.Life != Alive || !mdoc.Clean || len(mdoc.Principals) > 0 {
+			continue
+		}
+		pooled[mdoc.Id] = true
+		machines = append(machines, newMachine(st, mdoc))
+	}
+	sort.Slice(machines, func(i, j int) bool {
+		return machineIdLessThan(machines[i].Id(), machines[j].Id())
+	})
+	var left []string
+	for _, id := range ids {
+		if !pooled[id] {
+			left = append(left, id)
+		}
+	}
+	return machines, left, nil
+}
+
+// PruneMachinePool forgets the machines that have left the model's
+// machine pool.
+func (st *State) PruneMachinePool() error {
+	_, left, err := st.machinePool()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, id := range left {
+		ops = append(ops, txn.Op{
+			C:      machinePoolC,
+			Id:     st.docID(id),
+			Remove: true,
+		})
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	return errors.Annotate(st.db().RunTransaction(ops), "cannot prune machine pool")
+}
+
+// machinePoolIds returns the ids of the machines that were added to the
+// model's machine pool.
+func (st *State) machinePoolIds() (map[string]bool, error) {
+	pool, closer := st.db().GetCollection(machinePoolC)
+	defer closer()
+
+	var docs []machinePoolDoc
+	if err := pool.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get machine pool")
+	}
+	ids := make(map[string]bool)
+	for _, doc := range docs {
+		ids[doc.MachineId] = true
+	}
+	return ids, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/state"
+)
+
+type MachinePoolSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&MachinePoolSuite{})
+
+func (s *MachinePoolSuite) addPoolMachine(c *gc.C, provisioned bool) *state.Machine {
+	m, err := s.State.AddPoolMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	if provisioned {
+		err = m.SetProvisioned(instance.Id("inst-"+m.Id()), "", "fake_nonce", nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return m
+}
+
+func machineIds(machines []*state.Machine) []string {
+	ids := make([]string, len(machines))
+	for i, m := range machines {
+		ids[i] = m.Id()
+	}
+	return ids
+}
+
+func (s *MachinePoolSuite) TestAddPoolMachine(c *gc.C) {
+	s.addPoolMachine(c, false)
+	s.addPoolMachine(c, true)
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	pool, err := s.State.MachinePool()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineIds(pool), jc.DeepEquals, []string{"0", "1"})
+}
+
+func (s *MachinePoolSuite) TestAddPoolMachineWithPlacement(c *gc.C) {
+	_, err := s.State.AddPoolMachine(state.MachineTemplate{
+		Series:    "quantal",
+		Jobs:      []state.MachineJob{state.JobHostUnits},
+		Placement: "zone=a",
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot add a pool machine: pool machine with placement not valid")
+}
+
+func (s *MachinePoolSuite) TestAssignNewUsesProvisionedPoolMachine(c *gc.C) {
+	s.addPoolMachine(c, false)
+	pooled := s.addPoolMachine(c, true)
+	unit, err := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress")).AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.AssignUnit(unit, state.AssignNew)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, pooled.Id())
+
+	// The machine has left the pool.
+	pool, err := s.State.MachinePool()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineIds(pool), jc.DeepEquals, []string{"0"})
+}
+
+func (s *MachinePoolSuite) TestAssignNewWithoutPoolMachine(c *gc.C) {
+	s.addPoolMachine(c, false)
+	unit, err := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress")).AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The unprovisioned pool machine is no quicker than a new one.
+	err = s.State.AssignUnit(unit, state.AssignNew)
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, "1")
+}
+
+func (s *MachinePoolSuite) TestPruneMachinePool(c *gc.C) {
+	m0 := s.addPoolMachine(c, true)
+	s.addPoolMachine(c, true)
+	unit, err := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress")).AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(m0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.PruneMachinePool()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Unassigning the unit doesn't return the machine to the pool,
+	// which has forgotten it.
+	err = unit.UnassignFromMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	pool, err := s.State.MachinePool()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineIds(pool), jc.DeepEquals, []string{"1"})
+}
@@ -113,6 +113,9 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		guimetadataC,
 		// This is controller global, not migrated.
 		guisettingsC,
+		// Model templates are controller global, and only used when
+		// models are created.
+		modelTemplatesC,
 		// Users aren't migrated.
 		usersC,
 		userLastLoginC,
@@ -210,6 +213,30 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// running within a unit. This is a new feature that is not
 		// backwards compatible with older controllers.
 		unitStatesC,
+
+		// Automatic charm upgrades are only kept to allow them to be
+		// rolled back, which is not possible across controllers.
+		charmUpgradesC,
+
+		// The autoscaler's decisions are a history kept for operators,
+		// and are not needed to run the model on another controller.
+		autoscaleDecisionsC,
+
+		// Pooled machines are migrated as ordinary clean machines,
+		// and the pool is replenished on the target controller.
+		machinePoolC,
+
+		// The resource tags are applied afresh by the target
+		// controller's resource tagger.
+		resourceTagsC,
+
+		// The last events received for cross-model relations are only
+		// kept for diagnosis, and are replaced by the next event.
+		remoteRelationEventsC,
+
+		// Offer tokens carry the addresses of the controller that
+		// minted them, so they can't be redeemed after migration.
+		offerTokensC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -582,6 +609,10 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"Spaces",
 		"VirtType",
 		"Zones",
+		// SpotMaxPrice isn't yet supported by the model
+		// description, so the migration prechecks refuse
+		// models that use it.
+		"SpotMaxPrice",
 	)
 	s.AssertExportedFields(c, constraintsDoc{}, fields)
 }
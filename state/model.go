@@ -19,6 +19,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/core/status"
@@ -287,6 +288,16 @@ type ModelArgs struct {
 
 	// PasswordHash is used by the caas model operator.
 	PasswordHash string
+
+	// StoragePools contains the storage pools to create in the model,
+	// in addition to the default storage pools.
+	StoragePools []ModelTemplateStoragePool
+
+	// Spaces contains the names of the spaces to create in the model.
+	Spaces []string
+
+	// Users contains the access to grant to users other than the owner.
+	Users []ModelTemplateUser
 }
 
 // Validate validates the ModelArgs.
@@ -311,6 +322,11 @@ func (m ModelArgs) Validate() error {
 	default:
 		return errors.NotValidf("initial migration mode %q", m.MigrationMode)
 	}
+	for _, user := range m.Users {
+		if err := permission.ValidateModelAccess(user.Access); err != nil {
+			return errors.Annotatef(err, "user %q", user.User.Id())
+		}
+	}
 	return nil
 }
 
@@ -376,6 +392,14 @@ func (ctlr *Controller) NewModel(args ModelArgs) (_ *Model, _ *State, err error)
 			return nil, nil, errors.Annotate(err, "cannot create model")
 		}
 	}
+	for _, user := range args.Users {
+		if !user.User.IsLocal() {
+			continue
+		}
+		if _, err := st.User(user.User); err != nil {
+			return nil, nil, errors.Annotatef(err, "cannot grant access to %q", user.User.Id())
+		}
+	}
 
 	uuid := args.Config.UUID()
 	session := st.session.Copy()
@@ -985,6 +1009,27 @@ func (m *Model) AllEndpointBindings() (map[string]*Bindings, error) {
 	return appEndpointBindings, nil
 }
 
+// AllApplicationConfigs returns the application config of every
+// application in the model, keyed by application name, reading them
+// all in a single query.
+func (m *Model) AllApplicationConfigs() (map[string]application.ConfigAttributes, error) {
+	settings, closer := m.st.db().GetRawCollection(settingsC)
+	defer closer()
+
+	selector := fmt.Sprintf("^%s:a#[^#]+#application$", m.st.modelUUID())
+	var docs []settingsDoc
+	if err := settings.Find(bson.M{"_id": bson.M{"$regex": selector}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get application configs")
+	}
+	result := make(map[string]application.ConfigAttributes, len(docs))
+	for _, doc := range docs {
+		key := m.localID(doc.DocID)
+		appName := strings.TrimSuffix(strings.TrimPrefix(key, "a#"), "#application")
+		result[appName] = application.ConfigAttributes(doc.Settings)
+	}
+	return result, nil
+}
+
 // AllEndpointBindingsSpaceNames returns a set of spaces names for all the
 // endpoint bindings.
 func (st *State) AllEndpointBindingsSpaceNames() (set.Strings, error) {
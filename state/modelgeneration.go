@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/juju/charm/v7"
+	csparams "github.com/juju/charmrepo/v5/csclient/params"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -18,6 +20,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/mongo/utils"
 	stateerrors "github.com/juju/juju/state/errors"
@@ -71,7 +74,11 @@ type generationDoc struct {
 	// Config is all changes made to charm configuration under this branch.
 	Config map[string][]itemChange `bson:"charm-config"`
 
-	// TODO (manadart 2019-04-02): CharmURLs, Resources.
+	// Charms holds the charm upgrades staged under this branch,
+	// keyed by application name.
+	// Units tracking the branch run the staged charm, which is
+	// set for the whole application when the branch is committed.
+	Charms map[string]branchCharmDoc `bson:"charms,omitempty"`
 
 	// Created is a Unix timestamp indicating when this generation was created.
 	Created int64 `bson:"created"`
@@ -87,6 +94,20 @@ type generationDoc struct {
 	CompletedBy string `bson:"completed-by"`
 }
 
+// branchCharmDoc represents a charm upgrade staged under a branch.
+type branchCharmDoc struct {
+	CharmURL    *charm.URL `bson:"charm-url"`
+	Channel     string     `bson:"cs-channel,omitempty"`
+	ForceUnits  bool       `bson:"force-units,omitempty"`
+	ForceSeries bool       `bson:"force-series,omitempty"`
+	Force       bool       `bson:"force,omitempty"`
+
+	// ResourceIDs maps resource names to the IDs of pending resources
+	// uploaded for the charm. They are resolved when the branch is
+	// committed and removed if it is aborted.
+	ResourceIDs map[string]string `bson:"resource-ids,omitempty"`
+}
+
 // Generation represents the state of a model generation.
 type Generation struct {
 	st  *State
@@ -129,6 +150,23 @@ func (g *Generation) Config() map[string]settings.ItemChanges {
 	return changes
 }
 
+// CharmURL returns the URL of the charm staged for the application with
+// the input name under this branch. False is returned if the branch has
+// no charm upgrade for the application.
+func (g *Generation) CharmURL(appName string) (*charm.URL, bool) {
+	ch, ok := g.doc.Charms[appName]
+	if !ok {
+		return nil, false
+	}
+	return ch.CharmURL, true
+}
+
+// ResourceIDs returns the pending resource IDs, keyed by resource name,
+// uploaded with the charm staged for the input application.
+func (g *Generation) ResourceIDs(appName string) map[string]string {
+	return g.doc.Charms[appName].ResourceIDs
+}
+
 // Created returns the Unix timestamp at generation creation.
 func (g *Generation) Created() int64 {
 	return g.doc.Created
@@ -254,6 +292,9 @@ func (g *Generation) AssignUnits(appName string, numUnits int) error {
 		if assigned == 0 {
 			return nil, jujutxn.ErrNoOperations
 		}
+		if _, ok := g.doc.Charms[appName]; ok {
+			ops = append(ops, touchApplicationOp(app))
+		}
 		return ops, nil
 	}
 	return errors.Trace(g.st.db().Run(buildTxn))
@@ -283,7 +324,15 @@ func (g *Generation) AssignUnit(unitName string) error {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		return assignGenerationUnitTxnOps(g.doc.DocId, appName, unit), nil
+		ops := assignGenerationUnitTxnOps(g.doc.DocId, appName, unit)
+		if _, ok := g.doc.Charms[appName]; ok {
+			app, err := g.st.Application(appName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, touchApplicationOp(app))
+		}
+		return ops, nil
 	}
 
 	return errors.Trace(g.st.db().Run(buildTxn))
@@ -314,6 +363,156 @@ func assignGenerationUnitTxnOps(id, appName string, unit *Unit) []txn.Op {
 	}
 }
 
+// touchApplicationOp returns an operation that leaves the input application
+// unchanged, but causes its watchers to fire. This is used to notify the
+// units of the application when the charm they should run changes because
+// of a charm upgrade staged under a branch.
+func touchApplicationOp(app *Application) txn.Op {
+	return txn.Op{
+		C:      applicationsC,
+		Id:     app.doc.DocID,
+		Assert: append(notDeadDoc, bson.DocElem{"charmurl", app.doc.CharmURL}),
+		Update: bson.D{{"$set", bson.D{{"charmurl", app.doc.CharmURL}}}},
+	}
+}
+
+// SetCharm stages an upgrade of the input application to the charm in the
+// input configuration under this branch. Units tracking the branch are
+// upgraded to the charm straight away; the rest of the application is only
+// upgraded when the branch is committed.
+// Config settings, storage constraints and endpoint bindings can not be
+// changed along with a charm upgrade staged under a branch.
+func (g *Generation) SetCharm(appName string, cfg SetCharmConfig) (err error) {
+	defer errors.DeferredAnnotatef(
+		&err, "cannot upgrade application %q to charm %q in branch %q", appName, cfg.Charm, g.BranchName(),
+	)
+	if len(cfg.ConfigSettings) > 0 {
+		return errors.NotSupportedf("changing config settings with a branch charm upgrade")
+	}
+	if len(cfg.StorageConstraints) > 0 {
+		return errors.NotSupportedf("changing storage constraints with a branch charm upgrade")
+	}
+	if len(cfg.EndpointBindings) > 0 {
+		return errors.NotSupportedf("changing endpoint bindings with a branch charm upgrade")
+	}
+
+	curl := cfg.Charm.URL()
+	staged := branchCharmDoc{
+		CharmURL:    curl,
+		Channel:     string(cfg.Channel),
+		ForceUnits:  cfg.ForceUnits,
+		ForceSeries: cfg.ForceSeries,
+		Force:       cfg.Force,
+		ResourceIDs: cfg.ResourceIDs,
+	}
+	var replaced *branchCharmDoc
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.doc.CharmURL.String() == curl.String() {
+			return nil, errors.Errorf("application already uses this charm")
+		}
+		if err := app.checkCharmUpgrade(cfg); err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		ops := []txn.Op{touchApplicationOp(app)}
+		replaced = nil
+		if current, ok := g.doc.Charms[appName]; ok {
+			replaced = &current
+		}
+
+		// Hold a reference to the staged charm so that it is available
+		// to units tracking the branch. Its settings are derived from
+		// master until the branch is committed.
+		if replaced == nil || replaced.CharmURL.String() != curl.String() {
+			incOps, err := appCharmIncRefOps(g.st, appName, curl, true)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, incOps...)
+
+			if replaced != nil {
+				decOps, err := g.releaseCharmOps(appName, replaced.CharmURL)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, decOps...)
+			}
+		}
+
+		update := bson.D{{"charms." + appName, staged}}
+		if _, ok := g.doc.AssignedUnits[appName]; !ok {
+			update = append(update, bson.DocElem{"assigned-units." + appName, []string{}})
+		}
+		ops = append(ops, txn.Op{
+			C:  generationsC,
+			Id: g.doc.DocId,
+			Assert: bson.D{{"$and", []bson.D{
+				{{"completed", 0}},
+				{{"txn-revno", g.doc.TxnRevno}},
+			}}},
+			Update: bson.D{{"$set", update}},
+		})
+		return ops, nil
+	}
+
+	if err := g.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	if replaced != nil {
+		g.removeReplacedResources(appName, replaced.ResourceIDs, cfg.ResourceIDs)
+	}
+	return nil
+}
+
+// releaseCharmOps returns the operations that drop the references held by
+// the branch to the input charm staged for the input application.
+func (g *Generation) releaseCharmOps(appName string, curl *charm.URL) ([]txn.Op, error) {
+	op := &ForcedOperation{Force: true}
+	ops, err := appCharmDecRefOps(g.st, appName, curl, true, op)
+	if err != nil {
+		return nil, errors.Annotatef(err, "releasing branch reference to charm %q", curl)
+	}
+	if len(op.Errors) != 0 {
+		logger.Errorf("could not release branch references to charm %q: %v", curl, op.Errors)
+	}
+	return ops, nil
+}
+
+// removeReplacedResources removes the pending resources for the application
+// that were staged under the branch, but are no longer in use by it.
+// Errors are logged rather than returned, as the removal is a tidy-up.
+func (g *Generation) removeReplacedResources(appName string, old, current map[string]string) {
+	unused := make(map[string]string)
+	for name, pendingID := range old {
+		if current[name] != pendingID {
+			unused[name] = pendingID
+		}
+	}
+	if len(unused) == 0 {
+		return
+	}
+	resources, err := g.st.Resources()
+	if err == nil {
+		err = resources.RemovePendingAppResources(appName, unused)
+	}
+	if err != nil {
+		logger.Errorf("could not remove pending resources for %q staged in branch %q: %v", appName, g.BranchName(), err)
+	}
+}
+
 // UpdateCharmConfig applies the input changes to the input application's
 // charm configuration under this branch.
 // the incoming charm settings are assumed to have been validated.
@@ -372,9 +571,51 @@ func (g *Generation) UpdateCharmConfig(appName string, master *Settings, validCh
 	return errors.Trace(g.st.db().Run(buildTxn))
 }
 
+// ConfigDiff returns a three-way comparison of each charm configuration
+// setting changed under the branch with its value in master when the branch
+// first changed it, and its current value in master.
+// The comparisons are keyed by application name.
+func (g *Generation) ConfigDiff() (map[string][]settings.ItemDiff, error) {
+	diffs := make(map[string][]settings.ItemDiff)
+	for appName, delta := range g.Config() {
+		if len(delta) == 0 {
+			continue
+		}
+		master, err := g.masterCharmConfig(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		diffs[appName] = delta.ThreeWayDiff(master.Map())
+	}
+	return diffs, nil
+}
+
+// masterCharmConfig returns the master charm configuration
+// settings for the application with the input name.
+func (g *Generation) masterCharmConfig(appName string) (*Settings, error) {
+	app, err := g.st.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := readSettings(g.st.db(), settingsC, app.charmConfigKey())
+	return cfg, errors.Trace(err)
+}
+
 // Commit marks the generation as completed and assigns it the next value from
 // the generation sequence. The new generation ID is returned.
+// Branch values overwrite any conflicting changes made to master since the
+// branch changed them.
 func (g *Generation) Commit(userName string) (int, error) {
+	return g.CommitWithResolution(userName, model.ConflictBranchWins)
+}
+
+// CommitWithResolution commits the generation as for Commit, but resolves
+// conflicts with changes made to master since the branch changed the same
+// settings according to the input resolution.
+func (g *Generation) CommitWithResolution(userName string, resolution model.BranchConflictResolution) (int, error) {
+	if err := resolution.Validate(); err != nil {
+		return 0, errors.Trace(err)
+	}
 	var newGenId int
 
 	buildTxn := func(attempt int) ([]txn.Op, error) {
@@ -399,10 +640,15 @@ func (g *Generation) Commit(userName string) (int, error) {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		ops, err := g.commitConfigTxnOps()
+		ops, charmConfigChanges, err := g.commitConfigTxnOps(resolution)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		charmOps, err := g.commitCharmsOps(charmConfigChanges)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		ops = append(ops, charmOps...)
 
 		// Get the new sequence as late as we can.
 		// If assigned is empty, indicating no changes under this branch,
@@ -441,6 +687,70 @@ func (g *Generation) Commit(userName string) (int, error) {
 	return newGenId, nil
 }
 
+// commitCharmsOps returns the operations that upgrade each application
+// with a charm staged under the branch to that charm, resolving any
+// resources uploaded with it. The branch's references to the staged
+// charms are handed over to the applications.
+// The input config changes, keyed by application name, are applied to
+// the application's current settings before they are carried over to
+// the staged charm.
+func (g *Generation) commitCharmsOps(configChanges map[string]settings.ItemChanges) ([]txn.Op, error) {
+	var ops []txn.Op
+	for _, appName := range g.stagedCharmApps() {
+		staged := g.doc.Charms[appName]
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, err := g.st.Charm(staged.CharmURL)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cfg := SetCharmConfig{
+			Charm:       ch,
+			Channel:     csparams.Channel(staged.Channel),
+			ForceUnits:  staged.ForceUnits,
+			ForceSeries: staged.ForceSeries,
+			Force:       staged.Force,
+			ResourceIDs: staged.ResourceIDs,
+		}
+		if err := app.checkCharmUpgrade(cfg); err != nil {
+			return nil, errors.Annotatef(err, "cannot upgrade application %q to charm %q", appName, staged.CharmURL)
+		}
+		appOps, _, err := app.setCharmOps(cfg, nil, &branchCharmCommit{configChanges: configChanges[appName]})
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot upgrade application %q to charm %q", appName, staged.CharmURL)
+		}
+		ops = append(ops, appOps...)
+	}
+	return ops, nil
+}
+
+// stagedCharmApps returns the sorted names of the
+// applications with a charm staged under the branch.
+func (g *Generation) stagedCharmApps() []string {
+	appNames := make([]string, 0, len(g.doc.Charms))
+	for appName := range g.doc.Charms {
+		appNames = append(appNames, appName)
+	}
+	sort.Strings(appNames)
+	return appNames
+}
+
+// releaseAllCharmsOps returns the operations that drop the references
+// held by the branch to all of the charms staged under it.
+func (g *Generation) releaseAllCharmsOps() ([]txn.Op, error) {
+	var ops []txn.Op
+	for _, appName := range g.stagedCharmApps() {
+		decOps, err := g.releaseCharmOps(appName, g.doc.Charms[appName].CharmURL)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, decOps...)
+	}
+	return ops, nil
+}
+
 // assignedWithAllUnits generates a new value for the branch's
 // AssignedUnits field, to indicate that all units of changed applications
 // are tracking the branch.
@@ -460,33 +770,94 @@ func (g *Generation) assignedWithAllUnits() (map[string][]string, error) {
 // deltas, determines their effective new settings, then gathers the
 // operations representing the changes so that they can all be applied in a
 // single transaction.
-func (g *Generation) commitConfigTxnOps() ([]txn.Op, error) {
+// Settings changed in master since the branch changed them are handled
+// according to the input conflict resolution.
+// The resolved deltas for applications with a charm staged under the branch
+// are returned instead of operations, keyed by application name, as they
+// are applied along with the charm upgrade.
+func (g *Generation) commitConfigTxnOps(
+	resolution model.BranchConflictResolution,
+) ([]txn.Op, map[string]settings.ItemChanges, error) {
 	var ops []txn.Op
+	charmChanges := make(map[string]settings.ItemChanges)
+	conflicts := make(map[string][]string)
 	for appName, delta := range g.Config() {
 		if len(delta) == 0 {
 			continue
 		}
-		app, err := g.st.Application(appName)
+		cfg, err := g.masterCharmConfig(appName)
 		if err != nil {
-			return nil, errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 
-		// Apply the branch delta to the application's charm config settings.
-		cfg, err := readSettings(g.st.db(), settingsC, app.charmConfigKey())
-		if err != nil {
-			return nil, errors.Trace(err)
+		if resolution != model.ConflictBranchWins {
+			var conflicted []string
+			for _, diff := range delta.ThreeWayDiff(cfg.Map()) {
+				if diff.Conflicted() {
+					conflicted = append(conflicted, diff.Key)
+				}
+			}
+			if len(conflicted) > 0 {
+				conflicts[appName] = conflicted
+				if resolution == model.ConflictMasterWins {
+					delta = withoutKeys(delta, conflicted)
+				}
+			}
+		}
+
+		if _, ok := g.doc.Charms[appName]; ok {
+			charmChanges[appName] = delta
+			continue
 		}
+
+		// Apply the branch delta to the application's charm config settings.
 		cfg.applyChanges(delta)
 
 		_, updates := cfg.settingsUpdateOps()
 		// Assert that the settings document has not changed underneath us
 		// in addition to appending the field changes.
-		if len(updates) > 0 {
+		// If conflicts were checked, the settings are asserted unchanged
+		// even when there are no updates.
+		if len(updates) > 0 || resolution != model.ConflictBranchWins {
 			ops = append(ops, cfg.assertUnchangedOp())
 			ops = append(ops, updates...)
 		}
 	}
-	return ops, nil
+	if resolution == model.ConflictAbort && len(conflicts) > 0 {
+		return nil, nil, newBranchConflictError(g.BranchName(), conflicts)
+	}
+	return ops, charmChanges, nil
+}
+
+// newBranchConflictError returns an error describing
+// the conflicting settings for each application.
+func newBranchConflictError(branchName string, conflicts map[string][]string) error {
+	appNames := make([]string, 0, len(conflicts))
+	for appName := range conflicts {
+		appNames = append(appNames, appName)
+	}
+	sort.Strings(appNames)
+
+	details := make([]string, len(appNames))
+	for i, appName := range appNames {
+		keys := conflicts[appName]
+		sort.Strings(keys)
+		details[i] = fmt.Sprintf("%s: %s", appName, strings.Join(keys, ", "))
+	}
+	return errors.Errorf("branch %q conflicts with changes made to %q since the branch changed them (%s)",
+		branchName, model.GenerationMaster, strings.Join(details, "; "))
+}
+
+// withoutKeys returns the input changes less those for the input keys.
+func withoutKeys(changes settings.ItemChanges, keys []string) settings.ItemChanges {
+	exclude := set.NewStrings(keys...)
+	var res settings.ItemChanges
+	for _, ch := range changes {
+		if !exclude.Contains(ch.Key) {
+			res = append(res, ch)
+		}
+	}
+	return res
 }
 
 // Abort marks the generation as completed however no value is assigned from
@@ -514,10 +885,12 @@ func (g *Generation) Abort(userName string) error {
 			}
 		}
 
-		// Must not have upgraded charm of tracked application.
-		// TODO (hml) 2019-06-26
-		// Implement cannot abort branch where tracked application has
-		// been upgraded.
+		// With no units tracking the branch, no unit runs a charm staged
+		// under it, so the branch's references to them can be dropped.
+		ops, err := g.releaseAllCharmsOps()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 
 		now, err := g.st.ControllerTimestamp()
 		if err != nil {
@@ -526,7 +899,7 @@ func (g *Generation) Abort(userName string) error {
 		// As a proxy for checking that the generation has not changed,
 		// Assert that the txn rev-no has not changed since we materialised
 		// this generation object.
-		ops := []txn.Op{{
+		ops = append(ops, txn.Op{
 			C:      generationsC,
 			Id:     g.doc.DocId,
 			Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
@@ -536,11 +909,17 @@ func (g *Generation) Abort(userName string) error {
 					{"completed-by", userName},
 				}},
 			},
-		}}
+		})
 		return ops, nil
 	}
 
-	return errors.Trace(g.st.db().Run(buildTxn))
+	if err := g.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	for appName, staged := range g.doc.Charms {
+		g.removeReplacedResources(appName, staged.ResourceIDs, nil)
+	}
+	return nil
 }
 
 // CheckNotComplete returns an error if this
@@ -618,6 +997,16 @@ func (g *Generation) unassignAppOps(appName string) []txn.Op {
 			{"$set", bson.D{{"assigned-units", assigned}}},
 		},
 	}}
+	if _, ok := g.doc.Charms[appName]; ok {
+		ops = append(ops, txn.Op{
+			C:      generationsC,
+			Id:     g.doc.DocId,
+			Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
+			Update: bson.D{
+				{"$unset", bson.D{{"charms." + appName, 1}}},
+			},
+		})
+	}
 	currentCfg := g.doc.Config
 	if _, ok := currentCfg[appName]; ok {
 		newCfg := map[string][]itemChange{}
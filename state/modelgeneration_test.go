@@ -284,6 +284,132 @@ func (s *generationSuite) TestCommitAppliesConfigDeltas(c *gc.C) {
 	c.Check(cfg, gc.DeepEquals, charm.Settings(newCfg))
 }
 
+func (s *generationSuite) TestConfigDiff(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(app.UpdateCharmConfig(newBranchName, map[string]interface{}{"http_port": int64(9999)}), jc.ErrorIsNil)
+	c.Assert(app.UpdateCharmConfig(model.GenerationMaster, map[string]interface{}{"http_port": int64(8000)}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	diffs, err := gen.ConfigDiff()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(diffs, gc.DeepEquals, map[string][]settings.ItemDiff{
+		"riak": {{Key: "http_port", Branch: int64(9999), Master: int64(8000)}},
+	})
+	c.Check(diffs["riak"][0].Conflicted(), jc.IsTrue)
+}
+
+func (s *generationSuite) TestCommitWithResolutionAbortsOnConflict(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(app.UpdateCharmConfig(newBranchName, map[string]interface{}{"http_port": int64(9999)}), jc.ErrorIsNil)
+	c.Assert(app.UpdateCharmConfig(model.GenerationMaster, map[string]interface{}{"http_port": int64(8000)}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	_, err = gen.CommitWithResolution(branchCommitter, model.ConflictAbort)
+	c.Assert(err, gc.ErrorMatches, `branch "new-branch" conflicts with changes made to "master" since the branch changed them \(riak: http_port\)`)
+
+	// The branch is still in-flight and master is unchanged.
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.IsCompleted(), jc.IsFalse)
+	cfg, err := app.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg["http_port"], gc.Equals, int64(8000))
+}
+
+func (s *generationSuite) TestCommitWithResolutionMasterWins(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(app.UpdateCharmConfig(newBranchName, map[string]interface{}{"http_port": int64(9999)}), jc.ErrorIsNil)
+	c.Assert(app.UpdateCharmConfig(model.GenerationMaster, map[string]interface{}{"http_port": int64(8000)}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	genId, err := gen.CommitWithResolution(branchCommitter, model.ConflictMasterWins)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(genId, gc.Not(gc.Equals), 0)
+
+	cfg, err := app.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg["http_port"], gc.Equals, int64(8000))
+}
+
+func (s *generationSuite) TestCommitWithResolutionNoConflict(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(app.UpdateCharmConfig(newBranchName, map[string]interface{}{"http_port": int64(9999)}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	_, err = gen.CommitWithResolution(branchCommitter, model.ConflictAbort)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := app.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg["http_port"], gc.Equals, int64(9999))
+}
+
+func (s *generationSuite) TestCommitStagedCharmMergesMasterConfig(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+	newCh := s.addStagedCharm(c)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gen.SetCharm("riak", state.SetCharmConfig{Charm: newCh}), jc.ErrorIsNil)
+
+	// Master config changed after the upgrade was staged is carried over.
+	c.Assert(app.UpdateCharmConfig(model.GenerationMaster, map[string]interface{}{"http_port": int64(8000)}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	_, err = gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(app.Refresh(), jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Check(curl.String(), gc.Equals, newCh.URL().String())
+	cfg, err := app.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg["http_port"], gc.Equals, int64(8000))
+	c.Check(cfg["https_port"], gc.Equals, int64(8090))
+}
+
+func (s *generationSuite) TestCommitStagedCharmAbortsOnConflict(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+	newCh := s.addStagedCharm(c)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gen.SetCharm("riak", state.SetCharmConfig{Charm: newCh}), jc.ErrorIsNil)
+
+	c.Assert(app.UpdateCharmConfig(newBranchName, map[string]interface{}{"http_port": int64(9999)}), jc.ErrorIsNil)
+	c.Assert(app.UpdateCharmConfig(model.GenerationMaster, map[string]interface{}{"http_port": int64(8000)}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	_, err = gen.CommitWithResolution(branchCommitter, model.ConflictAbort)
+	c.Assert(err, gc.ErrorMatches, `branch "new-branch" conflicts with .*`)
+
+	// The application was not upgraded.
+	c.Assert(app.Refresh(), jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Check(curl.String(), gc.Equals, s.ch.URL().String())
+}
+
 func (s *generationSuite) TestAbortSuccess(c *gc.C) {
 	s.setupTestingClock(c)
 
@@ -536,6 +662,15 @@ options:
 	return s.addBranch(c)
 }
 
+func (s *generationSuite) addStagedCharm(c *gc.C) *state.Charm {
+	var cfgYAML = `
+options:
+  http_port: {default: 8089, description: HTTP Port, type: int}
+  https_port: {default: 8090, description: HTTPS Port, type: int}
+`
+	return s.AddConfigCharm(c, "riak", cfgYAML, 667)
+}
+
 func (s *generationSuite) addBranch(c *gc.C) *state.Generation {
 	c.Assert(s.Model.AddBranch(newBranchName, newBranchCreator), jc.ErrorIsNil)
 	branch, err := s.Model.Branch(newBranchName)
@@ -0,0 +1,283 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/mongo/utils"
+)
+
+// ModelTemplate holds the settings which are applied to a model when
+// it is created from the template.
+type ModelTemplate struct {
+	// Name is the name of the template.
+	Name string
+
+	// Config holds model config attributes. Config specified when
+	// the model is created takes precedence over these.
+	Config map[string]interface{}
+
+	// Constraints holds the default constraints for the model.
+	Constraints constraints.Value
+
+	// StoragePools holds the storage pools to create in the model,
+	// in addition to the default storage pools. A pool with the same
+	// name as a default pool replaces it.
+	StoragePools []ModelTemplateStoragePool
+
+	// Spaces holds the names of the spaces to create in the model.
+	Spaces []string
+
+	// Users holds the access to grant to users other than the owner
+	// of the model.
+	Users []ModelTemplateUser
+
+	// Bundle is the bundle to deploy once the model has been created,
+	// if any.
+	Bundle string
+
+	// CreatedBy is the name of the user who created the template.
+	CreatedBy string
+
+	// DateUpdated is when the template was last created or updated.
+	DateUpdated time.Time
+}
+
+// ModelTemplateStoragePool describes a storage pool to be created in
+// models created from a template.
+type ModelTemplateStoragePool struct {
+	Name     string
+	Provider string
+	Attrs    map[string]interface{}
+}
+
+// ModelTemplateUser describes access to be granted to a user on models
+// created from a template.
+type ModelTemplateUser struct {
+	User   names.UserTag
+	Access permission.Access
+}
+
+// modelTemplateDoc represents the internal state of a model template
+// in MongoDB.
+type modelTemplateDoc struct {
+	Name         string                 `bson:"_id"`
+	Config       map[string]interface{} `bson:"config,omitempty"`
+	Constraints  string                 `bson:"constraints,omitempty"`
+	StoragePools []modelTemplatePoolDoc `bson:"storage-pools,omitempty"`
+	Spaces       []string               `bson:"spaces,omitempty"`
+	Users        []modelTemplateUserDoc `bson:"users,omitempty"`
+	Bundle       string                 `bson:"bundle,omitempty"`
+	CreatedBy    string                 `bson:"created-by"`
+	DateUpdated  time.Time              `bson:"date-updated"`
+}
+
+type modelTemplatePoolDoc struct {
+	Name     string                 `bson:"name"`
+	Provider string                 `bson:"provider"`
+	Attrs    map[string]interface{} `bson:"attrs,omitempty"`
+}
+
+type modelTemplateUserDoc struct {
+	User   string `bson:"user"`
+	Access string `bson:"access"`
+}
+
+func newModelTemplateDoc(t ModelTemplate) modelTemplateDoc {
+	doc := modelTemplateDoc{
+		Name:        t.Name,
+		Config:      utils.EscapeKeys(t.Config),
+		Spaces:      t.Spaces,
+		Bundle:      t.Bundle,
+		CreatedBy:   t.CreatedBy,
+		DateUpdated: t.DateUpdated,
+	}
+	if !constraints.IsEmpty(&t.Constraints) {
+		doc.Constraints = t.Constraints.String()
+	}
+	for _, pool := range t.StoragePools {
+		doc.StoragePools = append(doc.StoragePools, modelTemplatePoolDoc{
+			Name:     pool.Name,
+			Provider: pool.Provider,
+			Attrs:    utils.EscapeKeys(pool.Attrs),
+		})
+	}
+	for _, user := range t.Users {
+		doc.Users = append(doc.Users, modelTemplateUserDoc{
+			User:   user.User.Id(),
+			Access: string(user.Access),
+		})
+	}
+	return doc
+}
+
+func (doc modelTemplateDoc) template() (ModelTemplate, error) {
+	t := ModelTemplate{
+		Name:        doc.Name,
+		Config:      utils.UnescapeKeys(doc.Config),
+		Spaces:      doc.Spaces,
+		Bundle:      doc.Bundle,
+		CreatedBy:   doc.CreatedBy,
+		DateUpdated: doc.DateUpdated.UTC(),
+	}
+	if doc.Constraints != "" {
+		cons, err := constraints.Parse(doc.Constraints)
+		if err != nil {
+			return ModelTemplate{}, errors.Annotatef(err, "model template %q", doc.Name)
+		}
+		t.Constraints = cons
+	}
+	for _, pool := range doc.StoragePools {
+		t.StoragePools = append(t.StoragePools, ModelTemplateStoragePool{
+			Name:     pool.Name,
+			Provider: pool.Provider,
+			Attrs:    utils.UnescapeKeys(pool.Attrs),
+		})
+	}
+	for _, user := range doc.Users {
+		t.Users = append(t.Users, ModelTemplateUser{
+			User:   names.NewUserTag(user.User),
+			Access: permission.Access(user.Access),
+		})
+	}
+	return t, nil
+}
+
+// validateModelTemplate checks that the template is well formed. It
+// cannot check the storage pools, which depend on the storage providers
+// of the cloud the model is created in.
+func validateModelTemplate(t ModelTemplate) error {
+	if !names.IsValidModelName(t.Name) {
+		return errors.NotValidf("model template name %q", t.Name)
+	}
+	pools := make(map[string]bool)
+	for _, pool := range t.StoragePools {
+		if pool.Name == "" || pool.Provider == "" {
+			return errors.NotValidf("storage pool without a name or provider")
+		}
+		if pools[pool.Name] {
+			return errors.NotValidf("duplicate storage pool %q", pool.Name)
+		}
+		pools[pool.Name] = true
+	}
+	spaces := set.NewStrings(network.AlphaSpaceName)
+	for _, space := range t.Spaces {
+		if !names.IsValidSpace(space) {
+			return errors.NotValidf("space name %q", space)
+		}
+		if spaces.Contains(space) {
+			return errors.NotValidf("duplicate space %q", space)
+		}
+		spaces.Add(space)
+	}
+	users := make(map[string]bool)
+	for _, user := range t.Users {
+		if err := permission.ValidateModelAccess(user.Access); err != nil {
+			return errors.Annotatef(err, "user %q", user.User.Id())
+		}
+		if users[user.User.Id()] {
+			return errors.NotValidf("duplicate user %q", user.User.Id())
+		}
+		users[user.User.Id()] = true
+	}
+	return nil
+}
+
+// AddModelTemplate adds a model template to the controller.
+func (st *State) AddModelTemplate(t ModelTemplate) error {
+	if err := validateModelTemplate(t); err != nil {
+		return errors.Trace(err)
+	}
+	t.DateUpdated = st.nowToTheSecond()
+	ops := []txn.Op{{
+		C:      modelTemplatesC,
+		Id:     t.Name,
+		Assert: txn.DocMissing,
+		Insert: newModelTemplateDoc(t),
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			err = errors.AlreadyExistsf("model template %q", t.Name)
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// UpdateModelTemplate replaces the settings of an existing model
+// template. Models already created from the template are unaffected.
+func (st *State) UpdateModelTemplate(t ModelTemplate) error {
+	if err := validateModelTemplate(t); err != nil {
+		return errors.Trace(err)
+	}
+	t.DateUpdated = st.nowToTheSecond()
+	doc := newModelTemplateDoc(t)
+	ops := []txn.Op{{
+		C:      modelTemplatesC,
+		Id:     t.Name,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{
+			{"config", doc.Config},
+			{"constraints", doc.Constraints},
+			{"storage-pools", doc.StoragePools},
+			{"spaces", doc.Spaces},
+			{"users", doc.Users},
+			{"bundle", doc.Bundle},
+			{"date-updated", doc.DateUpdated},
+		}}},
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			err = errors.NotFoundf("model template %q", t.Name)
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// ModelTemplate returns the model template with the given name.
+func (st *State) ModelTemplate(name string) (ModelTemplate, error) {
+	templates, closer := st.db().GetCollection(modelTemplatesC)
+	defer closer()
+
+	var doc modelTemplateDoc
+	err := templates.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return ModelTemplate{}, errors.NotFoundf("model template %q", name)
+	} else if err != nil {
+		return ModelTemplate{}, errors.Annotatef(err, "cannot get model template %q", name)
+	}
+	return doc.template()
+}
+
+// ModelTemplates returns all the model templates, sorted by name.
+func (st *State) ModelTemplates() ([]ModelTemplate, error) {
+	templates, closer := st.db().GetCollection(modelTemplatesC)
+	defer closer()
+
+	var docs []modelTemplateDoc
+	if err := templates.Find(nil).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get model templates")
+	}
+	result := make([]ModelTemplate, len(docs))
+	for i, doc := range docs {
+		t, err := doc.template()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = t
+	}
+	return result, nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage/poolmanager"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ModelTemplateSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelTemplateSuite{})
+
+func (s *ModelTemplateSuite) template() state.ModelTemplate {
+	return state.ModelTemplate{
+		Name:        "web",
+		Config:      map[string]interface{}{"default-series": "focal"},
+		Constraints: constraints.MustParse("mem=4G"),
+		StoragePools: []state.ModelTemplateStoragePool{{
+			Name:     "fast",
+			Provider: "tmpfs",
+			Attrs:    map[string]interface{}{"foo.bar": "baz"},
+		}},
+		Spaces: []string{"db", "public"},
+		Users: []state.ModelTemplateUser{{
+			User:   names.NewUserTag("bob"),
+			Access: permission.WriteAccess,
+		}},
+		Bundle:    "cs:wordpress-simple",
+		CreatedBy: "admin",
+	}
+}
+
+func (s *ModelTemplateSuite) TestAddModelTemplate(c *gc.C) {
+	err := s.State.AddModelTemplate(s.template())
+	c.Assert(err, jc.ErrorIsNil)
+
+	t, err := s.State.ModelTemplate("web")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t.DateUpdated.IsZero(), jc.IsFalse)
+	t.DateUpdated = s.template().DateUpdated
+	c.Assert(t, jc.DeepEquals, s.template())
+}
+
+func (s *ModelTemplateSuite) TestAddModelTemplateAlreadyExists(c *gc.C) {
+	err := s.State.AddModelTemplate(s.template())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddModelTemplate(s.template())
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `model template "web" already exists`)
+}
+
+func (s *ModelTemplateSuite) TestAddModelTemplateInvalid(c *gc.C) {
+	t := s.template()
+	t.Spaces = []string{"alpha"}
+	err := s.State.AddModelTemplate(t)
+	c.Assert(err, gc.ErrorMatches, `duplicate space "alpha" not valid`)
+
+	t = s.template()
+	t.Users[0].Access = permission.SuperuserAccess
+	err = s.State.AddModelTemplate(t)
+	c.Assert(err, gc.ErrorMatches, `user "bob": "superuser" model access not valid`)
+
+	t = s.template()
+	t.Name = "Web"
+	err = s.State.AddModelTemplate(t)
+	c.Assert(err, gc.ErrorMatches, `model template name "Web" not valid`)
+}
+
+func (s *ModelTemplateSuite) TestUpdateModelTemplate(c *gc.C) {
+	err := s.State.AddModelTemplate(s.template())
+	c.Assert(err, jc.ErrorIsNil)
+
+	update := state.ModelTemplate{
+		Name:   "web",
+		Config: map[string]interface{}{"logging-config": "<root>=DEBUG"},
+	}
+	err = s.State.UpdateModelTemplate(update)
+	c.Assert(err, jc.ErrorIsNil)
+
+	t, err := s.State.ModelTemplate("web")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t.Config, jc.DeepEquals, update.Config)
+	c.Assert(t.Constraints, jc.DeepEquals, constraints.Value{})
+	c.Assert(t.StoragePools, gc.HasLen, 0)
+	c.Assert(t.Spaces, gc.HasLen, 0)
+	c.Assert(t.Users, gc.HasLen, 0)
+	c.Assert(t.Bundle, gc.Equals, "")
+	c.Assert(t.CreatedBy, gc.Equals, "admin")
+}
+
+func (s *ModelTemplateSuite) TestUpdateModelTemplateNotFound(c *gc.C) {
+	err := s.State.UpdateModelTemplate(s.template())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `model template "web" not found`)
+}
+
+func (s *ModelTemplateSuite) TestModelTemplates(c *gc.C) {
+	t := s.template()
+	err := s.State.AddModelTemplate(t)
+	c.Assert(err, jc.ErrorIsNil)
+	t.Name = "db"
+	err = s.State.AddModelTemplate(t)
+	c.Assert(err, jc.ErrorIsNil)
+
+	templates, err := s.State.ModelTemplates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(templates, gc.HasLen, 2)
+	c.Assert(templates[0].Name, gc.Equals, "db")
+	c.Assert(templates[1].Name, gc.Equals, "web")
+}
+
+func (s *ModelTemplateSuite) TestModelTemplateNotFound(c *gc.C) {
+	_, err := s.State.ModelTemplate("web")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelTemplateSuite) TestNewModelFromTemplate(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+	t := s.template()
+	cfg, uuid := createTestModelConfig(c, s.State.ControllerModelUUID())
+	owner := names.NewUserTag("test@remote")
+	registry := provider.CommonStorageProviders()
+
+	_, st, err := s.Controller.NewModel(state.ModelArgs{
+		Type:                    state.ModelTypeIAAS,
+		CloudName:               "dummy",
+		CloudRegion:             "dummy-region",
+		Config:                  cfg,
+		Constraints:             t.Constraints,
+		Owner:                   owner,
+		StorageProviderRegistry: registry,
+		StoragePools:            t.StoragePools,
+		Spaces:                  t.Spaces,
+		Users:                   t.Users,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	cons, err := st.ModelConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, t.Constraints)
+
+	pm := poolmanager.New(state.NewStateSettings(st), registry)
+	pool, err := pm.Get("fast")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(pool.Provider()), gc.Equals, "tmpfs")
+
+	for _, name := range t.Spaces {
+		_, err = st.SpaceByName(name)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	access, err := st.UserAccess(bob.UserTag(), names.NewModelTag(uuid))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access.Access, gc.Equals, permission.WriteAccess)
+}
+
+func (s *ModelTemplateSuite) TestNewModelFromTemplateUnknownUser(c *gc.C) {
+	cfg, _ := createTestModelConfig(c, s.State.ControllerModelUUID())
+	_, _, err := s.Controller.NewModel(state.ModelArgs{
+		Type:                    state.ModelTypeIAAS,
+		CloudName:               "dummy",
+		CloudRegion:             "dummy-region",
+		Config:                  cfg,
+		Owner:                   names.NewUserTag("test@remote"),
+		StorageProviderRegistry: provider.CommonStorageProviders(),
+		Users:                   s.template().Users,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot grant access to "bob": user "bob" not found`)
+}
@@ -0,0 +1,336 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/permission"
+)
+
+// OfferTokenUserDomain is the domain of the users that consume offers
+// using offer tokens. Each token has its own user, which is granted
+// consume access to the offer when the token is first redeemed.
+const OfferTokenUserDomain = "offer-token"
+
+// OfferToken represents an invitation to consume an offer, which allows
+// users unknown to this controller to consume the offer.
+type OfferToken struct {
+	st  *State
+	doc offerTokenDoc
+}
+
+// offerTokenDoc represents the internal state of an offer token in MongoDB.
+type offerTokenDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	Id        string `bson:"id"`
+	OfferUUID string `bson:"offer-uuid"`
+	OfferName string `bson:"offer-name"`
+
+	// SecretKeyHash holds the hash of the secret key that must be
+	// presented with the token id to redeem the token.
+	SecretKeyHash string `bson:"secret-key-hash"`
+
+	CreatedBy   string    `bson:"created-by"`
+	DateCreated time.Time `bson:"date-created"`
+	Expires     time.Time `bson:"expires"`
+
+	// MaxUses is the number of times the token may be redeemed,
+	// or 0 if it may be redeemed any number of times.
+	MaxUses int  `bson:"max-uses"`
+	Uses    int  `bson:"uses"`
+	Revoked bool `bson:"revoked"`
+}
+
+// Id returns the id of the token.
+func (t *OfferToken) Id() string {
+	return t.doc.Id
+}
+
+// OfferUUID returns the UUID of the offer the token allows to be consumed.
+func (t *OfferToken) OfferUUID() string {
+	return t.doc.OfferUUID
+}
+
+// OfferName returns the name of the offer the token allows to be consumed.
+func (t *OfferToken) OfferName() string {
+	return t.doc.OfferName
+}
+
+// CreatedBy returns the name of the user who created the token.
+func (t *OfferToken) CreatedBy() string {
+	return t.doc.CreatedBy
+}
+
+// DateCreated returns when the token was created.
+func (t *OfferToken) DateCreated() time.Time {
+	return t.doc.DateCreated.UTC()
+}
+
+// Expires returns when the token can no longer be redeemed.
+func (t *OfferToken) Expires() time.Time {
+	return t.doc.Expires.UTC()
+}
+
+// MaxUses returns the number of times the token may be redeemed,
+// or 0 if there is no limit.
+func (t *OfferToken) MaxUses() int {
+	return t.doc.MaxUses
+}
+
+// Uses returns the number of times the token has been redeemed.
+func (t *OfferToken) Uses() int {
+	return t.doc.Uses
+}
+
+// Revoked returns whether the token has been revoked.
+func (t *OfferToken) Revoked() bool {
+	return t.doc.Revoked
+}
+
+// UserTag returns the tag of the user that consumes the offer on behalf
+// of those redeeming the token.
+func (t *OfferToken) UserTag() names.UserTag {
+	return offerTokenUser(t.doc.Id)
+}
+
+func offerTokenUser(id string) names.UserTag {
+	return names.NewUserTag(fmt.Sprintf("token-%s@%s", id, OfferTokenUserDomain))
+}
+
+// AddOfferTokenArgs holds the parameters for creating an offer token.
+type AddOfferTokenArgs struct {
+	// OfferName is the name of the offer the token allows to be consumed.
+	OfferName string
+
+	// CreatedBy is the user creating the token.
+	CreatedBy names.UserTag
+
+	// Expires is when the token can no longer be redeemed.
+	Expires time.Time
+
+	// MaxUses is the number of times the token may be redeemed,
+	// or 0 if there is no limit.
+	MaxUses int
+}
+
+// AddOfferToken creates a token allowing the given offer to be consumed,
+// returning the token and the secret key which must be presented to
+// redeem it. The secret key is not stored, and cannot be retrieved later.
+func (st *State) AddOfferToken(args AddOfferTokenArgs) (*OfferToken, string, error) {
+	now := st.clock().Now()
+	if !args.Expires.After(now) {
+		return nil, "", errors.NotValidf("offer token expiry %v in the past", args.Expires)
+	}
+	if args.MaxUses < 0 {
+		return nil, "", errors.NotValidf("offer token maximum uses %d", args.MaxUses)
+	}
+	offerUUID, err := applicationOfferUUID(st, args.OfferName)
+	if err != nil {
+		return nil, "", errors.Annotate(err, "creating offer token")
+	}
+	idBytes, err := utils.RandomBytes(8)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	id := hex.EncodeToString(idBytes)
+	secretKey, err := utils.RandomPassword()
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	doc := offerTokenDoc{
+		DocID:         st.docID(id),
+		ModelUUID:     st.ModelUUID(),
+		Id:            id,
+		OfferUUID:     offerUUID,
+		OfferName:     args.OfferName,
+		SecretKeyHash: utils.AgentPasswordHash(secretKey),
+		CreatedBy:     args.CreatedBy.Id(),
+		DateCreated:   now,
+		Expires:       args.Expires,
+		MaxUses:       args.MaxUses,
+	}
+	ops := []txn.Op{{
+		C:      applicationOffersC,
+		Id:     args.OfferName,
+		Assert: bson.D{{"offer-uuid", offerUUID}},
+	}, {
+		C:      offerTokensC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return nil, "", errors.NotFoundf("application offer %q", args.OfferName)
+	} else if err != nil {
+		return nil, "", errors.Annotatef(err, "cannot create token for offer %q", args.OfferName)
+	}
+	return &OfferToken{st: st, doc: doc}, secretKey, nil
+}
+
+// OfferToken returns the offer token with the given id.
+func (st *State) OfferToken(id string) (*OfferToken, error) {
+	tokens, closer := st.db().GetCollection(offerTokensC)
+	defer closer()
+
+	var doc offerTokenDoc
+	err := tokens.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("offer token %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get offer token %q", id)
+	}
+	return &OfferToken{st: st, doc: doc}, nil
+}
+
+// OfferTokens returns the tokens for the named offer.
+func (st *State) OfferTokens(offerName string) ([]*OfferToken, error) {
+	offerUUID, err := applicationOfferUUID(st, offerName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	docs, err := offerTokenDocs(st, offerUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]*OfferToken, len(docs))
+	for i, doc := range docs {
+		result[i] = &OfferToken{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+func offerTokenDocs(st *State, offerUUID string) ([]offerTokenDoc, error) {
+	tokens, closer := st.db().GetCollection(offerTokensC)
+	defer closer()
+
+	var docs []offerTokenDoc
+	if err := tokens.Find(bson.D{{"offer-uuid", offerUUID}}).Sort("date-created").All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get tokens for offer %q", offerUUID)
+	}
+	return docs, nil
+}
+
+// RedeemOfferToken checks the given secret key against the offer token
+// with the given id, and that the token can still be used. If so, the
+// token's use is recorded and its user granted consume access to the
+// offer, if not already.
+func (st *State) RedeemOfferToken(id, secretKey string) (*OfferToken, error) {
+	var token *OfferToken
+	buildTxn := func(int) ([]txn.Op, error) {
+		var err error
+		token, err = st.OfferToken(id)
+		if errors.IsNotFound(err) {
+			return nil, errors.Unauthorizedf("invalid offer token")
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if utils.AgentPasswordHash(secretKey) != token.doc.SecretKeyHash {
+			return nil, errors.Unauthorizedf("invalid offer token")
+		}
+		if token.doc.Revoked {
+			return nil, errors.Forbiddenf("offer token %q has been revoked", id)
+		}
+		if !st.clock().Now().Before(token.doc.Expires) {
+			return nil, errors.Forbiddenf("offer token %q has expired", id)
+		}
+		if token.doc.MaxUses > 0 && token.doc.Uses >= token.doc.MaxUses {
+			return nil, errors.Forbiddenf("offer token %q has already been used %d times", id, token.doc.Uses)
+		}
+		ops := []txn.Op{{
+			C:      applicationOffersC,
+			Id:     token.doc.OfferName,
+			Assert: bson.D{{"offer-uuid", token.doc.OfferUUID}},
+		}, {
+			C:      offerTokensC,
+			Id:     token.doc.DocID,
+			Assert: bson.D{{"uses", token.doc.Uses}, {"revoked", false}},
+			Update: bson.D{{"$inc", bson.D{{"uses", 1}}}},
+		}}
+		offerKey := applicationOfferKey(token.doc.OfferUUID)
+		userKey := userGlobalKey(userAccessID(token.UserTag()))
+		access, err := st.GetOfferAccess(token.doc.OfferUUID, token.UserTag())
+		switch {
+		case errors.IsNotFound(err):
+			ops = append(ops, createPermissionOp(offerKey, userKey, permission.ConsumeAccess))
+		case err != nil:
+			return nil, errors.Trace(err)
+		case !access.EqualOrGreaterOfferAccessThan(permission.ConsumeAccess):
+			ops = append(ops, updatePermissionOp(offerKey, userKey, permission.ConsumeAccess))
+		}
+		return ops, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	token.doc.Uses++
+	return token, nil
+}
+
+// RevokeOfferToken revokes the offer token with the given id so it can
+// no longer be redeemed. The offer access of the token's user is removed,
+// suspending any relations made by consuming the offer with the token.
+func (st *State) RevokeOfferToken(id string) error {
+	buildTxn := func(int) ([]txn.Op, error) {
+		token, err := st.OfferToken(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if token.doc.Revoked {
+			return nil, jujutxn.ErrNoOperations
+		}
+		ops := []txn.Op{{
+			C:      offerTokensC,
+			Id:     token.doc.DocID,
+			Assert: bson.D{{"revoked", false}},
+			Update: bson.D{{"$set", bson.D{{"revoked", true}}}},
+		}}
+		_, err = st.GetOfferAccess(token.doc.OfferUUID, token.UserTag())
+		if errors.IsNotFound(err) {
+			return ops, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, removePermissionOp(
+			applicationOfferKey(token.doc.OfferUUID),
+			userGlobalKey(userAccessID(token.UserTag())),
+		))
+		suspendOps, err := st.suspendRevokedRelationsOps(token.doc.OfferUUID, token.UserTag().Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, suspendOps...), nil
+	}
+	err := st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot revoke offer token %q", id)
+}
+
+// removeOfferTokensOps returns the operations to remove the tokens for
+// the given offer.
+func removeOfferTokensOps(st *State, offerUUID string) ([]txn.Op, error) {
+	docs, err := offerTokenDocs(st, offerUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      offerTokensC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
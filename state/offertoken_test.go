@@ -0,0 +1,143 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/state"
+)
+
+type offerTokenSuite struct {
+	ConnSuite
+	offer *crossmodel.ApplicationOffer
+}
+
+var _ = gc.Suite(&offerTokenSuite{})
+
+func (s *offerTokenSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	offers := state.NewApplicationOffers(s.State)
+	var err error
+	s.offer, err = offers.AddOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"server": "server"},
+		Owner:           s.Owner.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *offerTokenSuite) addToken(c *gc.C, maxUses int) (*state.OfferToken, string) {
+	token, secretKey, err := s.State.AddOfferToken(state.AddOfferTokenArgs{
+		OfferName: "hosted-mysql",
+		CreatedBy: s.Owner,
+		Expires:   s.Clock.Now().Add(time.Hour),
+		MaxUses:   maxUses,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return token, secretKey
+}
+
+func (s *offerTokenSuite) TestAddOfferToken(c *gc.C) {
+	token, secretKey := s.addToken(c, 2)
+	c.Assert(secretKey, gc.Not(gc.Equals), "")
+	c.Assert(token.OfferUUID(), gc.Equals, s.offer.OfferUUID)
+	c.Assert(token.OfferName(), gc.Equals, "hosted-mysql")
+	c.Assert(token.CreatedBy(), gc.Equals, s.Owner.Id())
+	c.Assert(token.Expires(), gc.Equals, s.Clock.Now().Add(time.Hour).UTC())
+	c.Assert(token.MaxUses(), gc.Equals, 2)
+	c.Assert(token.UserTag(), gc.Equals, names.NewUserTag("token-"+token.Id()+"@offer-token"))
+
+	tokens, err := s.State.OfferTokens("hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tokens, gc.HasLen, 1)
+	c.Assert(tokens[0].Id(), gc.Equals, token.Id())
+	c.Assert(tokens[0].Uses(), gc.Equals, 0)
+	c.Assert(tokens[0].Revoked(), jc.IsFalse)
+}
+
+func (s *offerTokenSuite) TestAddOfferTokenInvalid(c *gc.C) {
+	_, _, err := s.State.AddOfferToken(state.AddOfferTokenArgs{
+		OfferName: "hosted-mysql",
+		CreatedBy: s.Owner,
+		Expires:   s.Clock.Now().Add(-time.Hour),
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	_, _, err = s.State.AddOfferToken(state.AddOfferTokenArgs{
+		OfferName: "missing",
+		CreatedBy: s.Owner,
+		Expires:   s.Clock.Now().Add(time.Hour),
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *offerTokenSuite) TestRedeemOfferToken(c *gc.C) {
+	token, secretKey := s.addToken(c, 2)
+
+	_, err := s.State.RedeemOfferToken(token.Id(), "wrong")
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+	_, err = s.State.RedeemOfferToken("missing", secretKey)
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+
+	redeemed, err := s.State.RedeemOfferToken(token.Id(), secretKey)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(redeemed.Uses(), gc.Equals, 1)
+	access, err := s.State.GetOfferAccess(s.offer.OfferUUID, token.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.ConsumeAccess)
+
+	redeemed, err = s.State.RedeemOfferToken(token.Id(), secretKey)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(redeemed.Uses(), gc.Equals, 2)
+
+	_, err = s.State.RedeemOfferToken(token.Id(), secretKey)
+	c.Assert(err, gc.ErrorMatches, `offer token ".*" has already been used 2 times`)
+	c.Assert(err, jc.Satisfies, errors.IsForbidden)
+}
+
+func (s *offerTokenSuite) TestRedeemOfferTokenExpired(c *gc.C) {
+	token, secretKey := s.addToken(c, 0)
+	s.Clock.Advance(time.Hour)
+	_, err := s.State.RedeemOfferToken(token.Id(), secretKey)
+	c.Assert(err, gc.ErrorMatches, `offer token ".*" has expired`)
+}
+
+func (s *offerTokenSuite) TestRevokeOfferToken(c *gc.C) {
+	token, secretKey := s.addToken(c, 0)
+	_, err := s.State.RedeemOfferToken(token.Id(), secretKey)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RevokeOfferToken(token.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	token, err = s.State.OfferToken(token.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token.Revoked(), jc.IsTrue)
+	_, err = s.State.GetOfferAccess(s.offer.OfferUUID, token.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = s.State.RedeemOfferToken(token.Id(), secretKey)
+	c.Assert(err, gc.ErrorMatches, `offer token ".*" has been revoked`)
+
+	// Revoking again is a no-op.
+	err = s.State.RevokeOfferToken(token.Id())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *offerTokenSuite) TestRemovedWithOffer(c *gc.C) {
+	token, _ := s.addToken(c, 0)
+	err := state.NewApplicationOffers(s.State).Remove("hosted-mysql", false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.OfferToken(token.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
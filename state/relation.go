@@ -490,6 +490,7 @@ func (r *Relation) removeOps(ignoreApplication string, departingUnitName string,
 	}
 	ops = append(ops, removeStatusOp(r.st, r.globalScope()))
 	ops = append(ops, removeRelationNetworksOps(r.st, r.doc.Key)...)
+	ops = append(ops, removeRemoteRelationEventOps(r.st, r.doc.Key)...)
 	re := r.st.RemoteEntities()
 	tokenOps := re.removeRemoteEntityOps(r.Tag())
 	ops = append(ops, tokenOps...)
@@ -0,0 +1,163 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/life"
+)
+
+// RemoteRelationEvent describes the last change to a cross model relation
+// received from the remote model, as kept to help diagnose relations that
+// are not progressing.
+type RemoteRelationEvent struct {
+	// Received is when the change was received. It is set by
+	// SetRemoteRelationEvent.
+	Received time.Time
+
+	// ApplicationToken is the token of the remote application that
+	// published the change.
+	ApplicationToken string
+
+	// Life is the life of the relation in the remote model, if given.
+	Life life.Value
+
+	// Suspended is whether the relation was suspended in the remote
+	// model, if given.
+	Suspended *bool
+
+	// ChangedUnits and DepartedUnits hold the ids of the remote units
+	// whose settings changed and which left the relation.
+	ChangedUnits  []int
+	DepartedUnits []int
+
+	// ApplicationSettingsChanged is whether the remote application's
+	// settings changed.
+	ApplicationSettingsChanged bool
+
+	// Error holds the reason the change could not be applied, if any.
+	Error string
+}
+
+type remoteRelationEventDoc struct {
+	DocID       string `bson:"_id"`
+	ModelUUID   string `bson:"model-uuid"`
+	RelationKey string `bson:"relation-key"`
+
+	Received                   time.Time `bson:"received"`
+	ApplicationToken           string    `bson:"application-token"`
+	Life                       string    `bson:"life,omitempty"`
+	Suspended                  *bool     `bson:"suspended,omitempty"`
+	ChangedUnits               []int     `bson:"changed-units,omitempty"`
+	DepartedUnits              []int     `bson:"departed-units,omitempty"`
+	ApplicationSettingsChanged bool      `bson:"application-settings-changed,omitempty"`
+	Error                      string    `bson:"error,omitempty"`
+}
+
+// SetRemoteRelationEvent records the last change received from the remote
+// model for the relation with the given key, replacing any recorded before,
+// with the time it was received taken from the state's clock. Every change
+// is recorded, including unit settings changes and departures, so that the
+// last event shown is always the latest one received. A NotFound error is
+// returned if the relation no longer exists.
+func (st *State) SetRemoteRelationEvent(relationKey string, event RemoteRelationEvent) error {
+	doc := remoteRelationEventDoc{
+		DocID:                      st.docID(relationKey),
+		ModelUUID:                  st.ModelUUID(),
+		RelationKey:                relationKey,
+		Received:                   st.clock().Now(),
+		ApplicationToken:           event.ApplicationToken,
+		Life:                       string(event.Life),
+		Suspended:                  event.Suspended,
+		ChangedUnits:               event.ChangedUnits,
+		DepartedUnits:              event.DepartedUnits,
+		ApplicationSettingsChanged: event.ApplicationSettingsChanged,
+		Error:                      event.Error,
+	}
+	events, closer := st.db().GetCollection(remoteRelationEventsC)
+	defer closer()
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		var existing remoteRelationEventDoc
+		err := events.FindId(doc.DocID).One(&existing)
+		if err != nil && err != mgo.ErrNotFound {
+			return nil, errors.Trace(err)
+		}
+		found := err == nil
+		if _, err := st.KeyRelation(relationKey); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      relationsC,
+			Id:     st.docID(relationKey),
+			Assert: txn.DocExists,
+		}}
+		if !found {
+			return append(ops, txn.Op{
+				C:      remoteRelationEventsC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      remoteRelationEventsC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"received", doc.Received},
+				{"application-token", doc.ApplicationToken},
+				{"life", doc.Life},
+				{"suspended", doc.Suspended},
+				{"changed-units", doc.ChangedUnits},
+				{"departed-units", doc.DepartedUnits},
+				{"application-settings-changed", doc.ApplicationSettingsChanged},
+				{"error", doc.Error},
+			}}},
+		}), nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot record remote event for relation %q", relationKey)
+	}
+	return nil
+}
+
+// RemoteRelationEvent returns the last change received from the remote
+// model for the relation with the given key.
+func (st *State) RemoteRelationEvent(relationKey string) (RemoteRelationEvent, error) {
+	events, closer := st.db().GetCollection(remoteRelationEventsC)
+	defer closer()
+
+	var doc remoteRelationEventDoc
+	err := events.FindId(relationKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return RemoteRelationEvent{}, errors.NotFoundf("remote event for relation %q", relationKey)
+	} else if err != nil {
+		return RemoteRelationEvent{}, errors.Annotatef(err, "cannot get remote event for relation %q", relationKey)
+	}
+	return RemoteRelationEvent{
+		Received:                   doc.Received,
+		ApplicationToken:           doc.ApplicationToken,
+		Life:                       life.Value(doc.Life),
+		Suspended:                  doc.Suspended,
+		ChangedUnits:               doc.ChangedUnits,
+		DepartedUnits:              doc.DepartedUnits,
+		ApplicationSettingsChanged: doc.ApplicationSettingsChanged,
+		Error:                      doc.Error,
+	}, nil
+}
+
+func removeRemoteRelationEventOps(st *State, relationKey string) []txn.Op {
+	return []txn.Op{{
+		C:      remoteRelationEventsC,
+		Id:     st.docID(relationKey),
+		Remove: true,
+	}}
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/state"
+)
+
+type remoteRelationEventSuite struct {
+	ConnSuite
+	relation *state.Relation
+}
+
+var _ = gc.Suite(&remoteRelationEventSuite{})
+
+func (s *remoteRelationEventSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	wordpressEP, err := wordpress.Endpoint("db")
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	mysqlEP, err := mysql.Endpoint("server")
+	c.Assert(err, jc.ErrorIsNil)
+	s.relation, err = s.State.AddRelation(wordpressEP, mysqlEP)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *remoteRelationEventSuite) TestSetRemoteRelationEvent(c *gc.C) {
+	_, err := s.State.RemoteRelationEvent(s.relation.String())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	suspended := true
+	received := s.Clock.Now().Truncate(time.Millisecond).UTC()
+	err = s.State.SetRemoteRelationEvent(s.relation.String(), state.RemoteRelationEvent{
+		ApplicationToken: "app-token",
+		Life:             life.Alive,
+		Suspended:        &suspended,
+		ChangedUnits:     []int{0, 1},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	event, err := s.State.RemoteRelationEvent(s.relation.String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(event.Received.Truncate(time.Millisecond).UTC(), gc.Equals, received)
+	c.Assert(event.ApplicationToken, gc.Equals, "app-token")
+	c.Assert(event.Life, gc.Equals, life.Alive)
+	c.Assert(event.Suspended, gc.NotNil)
+	c.Assert(*event.Suspended, jc.IsTrue)
+	c.Assert(event.ChangedUnits, jc.DeepEquals, []int{0, 1})
+
+	// A later event that changes the error replaces the earlier one.
+	s.Clock.Advance(time.Minute)
+	err = s.State.SetRemoteRelationEvent(s.relation.String(), state.RemoteRelationEvent{
+		ApplicationToken: "app-token",
+		DepartedUnits:    []int{1},
+		Error:            "boom",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	event, err = s.State.RemoteRelationEvent(s.relation.String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(event.Received.Truncate(time.Millisecond).UTC(), gc.Equals, received.Add(time.Minute))
+	c.Assert(event.Suspended, gc.IsNil)
+	c.Assert(event.ChangedUnits, gc.HasLen, 0)
+	c.Assert(event.DepartedUnits, jc.DeepEquals, []int{1})
+	c.Assert(event.Error, gc.Equals, "boom")
+}
+
+func (s *remoteRelationEventSuite) TestSetRemoteRelationEventUnitChanges(c *gc.C) {
+	received := s.Clock.Now().Truncate(time.Millisecond).UTC()
+	err := s.State.SetRemoteRelationEvent(s.relation.String(), state.RemoteRelationEvent{
+		ApplicationToken: "app-token",
+		Life:             life.Alive,
+		ChangedUnits:     []int{0},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// An event that leaves the relation's life, suspension and error as
+	// they were is still recorded, with the units it changed.
+	s.Clock.Advance(time.Minute)
+	err = s.State.SetRemoteRelationEvent(s.relation.String(), state.RemoteRelationEvent{
+		ApplicationToken: "app-token",
+		Life:             life.Alive,
+		ChangedUnits:     []int{1},
+		DepartedUnits:    []int{0},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	event, err := s.State.RemoteRelationEvent(s.relation.String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(event.Received.Truncate(time.Millisecond).UTC(), gc.Equals, received.Add(time.Minute))
+	c.Assert(event.ChangedUnits, jc.DeepEquals, []int{1})
+	c.Assert(event.DepartedUnits, jc.DeepEquals, []int{0})
+}
+
+func (s *remoteRelationEventSuite) TestSetRemoteRelationEventMissingRelation(c *gc.C) {
+	err := s.State.SetRemoteRelationEvent("wordpress:db something:database", state.RemoteRelationEvent{})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *remoteRelationEventSuite) TestRemovedWithRelation(c *gc.C) {
+	err := s.State.SetRemoteRelationEvent(s.relation.String(), state.RemoteRelationEvent{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.RemoteRelationEvent(s.relation.String())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
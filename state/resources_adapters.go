@@ -60,6 +60,22 @@ func (st rawState) Units(applicationID string) (tags []names.UnitTag, err error)
 	return tags, nil
 }
 
+// branchResourceID returns the pending ID of the named resource uploaded
+// with the charm staged for the application under the branch that the
+// unit with the input name is tracking. An empty string is returned if
+// there is no such resource.
+func (st rawState) branchResourceID(unitName, applicationID, name string) (string, error) {
+	m, err := st.base.Model()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	branch, err := m.unitBranch(unitName)
+	if err != nil || branch == nil {
+		return "", errors.Trace(err)
+	}
+	return branch.ResourceIDs(applicationID)[name], nil
+}
+
 // VerifyApplication implements resource/state.RawState.
 func (st rawState) VerifyApplication(id string) error {
 	app, err := st.base.Application(id)
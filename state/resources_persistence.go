@@ -150,6 +150,22 @@ func (p ResourcePersistence) GetResource(id string) (res resource.Resource, stor
 	return stored.Resource, stored.storagePath, nil
 }
 
+// GetPendingResource returns the extended, model-related info for the
+// pending resource with the given ID.
+func (p ResourcePersistence) GetPendingResource(id, pendingID string) (res resource.Resource, storagePath string, _ error) {
+	doc, err := p.getOnePending(id, pendingID)
+	if err != nil {
+		return res, "", errors.Trace(err)
+	}
+
+	stored, err := doc2resource(doc)
+	if err != nil {
+		return res, "", errors.Trace(err)
+	}
+
+	return stored.Resource, stored.storagePath, nil
+}
+
 // StageResource adds the resource in a separate staging area
 // if the resource isn't already staged. If it is then
 // errors.AlreadyExists is returned. A wrapper around the staged
@@ -34,6 +34,10 @@ type resourcePersistence interface {
 	// non-pending resource.
 	GetResource(id string) (res resource.Resource, storagePath string, _ error)
 
+	// GetPendingResource returns the extended, model-related info for
+	// the pending resource with the given ID.
+	GetPendingResource(id, pendingID string) (res resource.Resource, storagePath string, _ error)
+
 	// StageResource adds the resource in a separate staging area
 	// if the resource isn't already staged. If the resource already
 	// exists then it is treated as unavailable as long as the new one
@@ -329,13 +333,20 @@ func (st resourceState) OpenResource(applicationID, name string) (resource.Resou
 		}
 		return resource.Resource{}, nil, errors.Annotate(err, "while getting resource info")
 	}
+	return st.openResource(resourceInfo, storagePath)
+}
+
+// openResource returns a reader for the input resource,
+// the data for which is at the input storage path.
+func (st resourceState) openResource(resourceInfo resource.Resource, storagePath string) (resource.Resource, io.ReadCloser, error) {
 	if resourceInfo.IsPlaceholder() {
-		logger.Tracef("placeholder resource %q treated as not found", name)
-		return resource.Resource{}, nil, errors.NotFoundf("resource %q", name)
+		logger.Tracef("placeholder resource %q treated as not found", resourceInfo.Name)
+		return resource.Resource{}, nil, errors.NotFoundf("resource %q", resourceInfo.Name)
 	}
 
 	var resourceReader io.ReadCloser
 	var resSize int64
+	var err error
 	switch resourceInfo.Type {
 	case charmresource.TypeContainerImage:
 		resourceReader, resSize, err = st.dockerMetadataStorage.Get(resourceInfo.ID)
@@ -369,14 +380,12 @@ func (st resourceState) OpenResource(applicationID, name string) (resource.Resou
 // a reader for the resource. The resource is associated with
 // the unit once the reader is completely exhausted.
 func (st resourceState) OpenResourceForUniter(unit resource.Unit, name string) (resource.Resource, io.ReadCloser, error) {
-	applicationID := unit.ApplicationName()
-
 	pendingID, err := newPendingID()
 	if err != nil {
 		return resource.Resource{}, nil, errors.Trace(err)
 	}
 
-	resourceInfo, resourceReader, err := st.OpenResource(applicationID, name)
+	resourceInfo, resourceReader, err := st.openResourceForUnit(unit, name)
 	if err != nil {
 		return resource.Resource{}, nil, errors.Trace(err)
 	}
@@ -401,6 +410,28 @@ func (st resourceState) OpenResourceForUniter(unit resource.Unit, name string) (
 	return resourceInfo, resourceReader, nil
 }
 
+// openResourceForUnit returns metadata about the resource and a reader
+// for the resource as it should be seen by the input unit.
+// If the unit is tracking a branch under which a charm upgrade was staged
+// along with a new revision of the resource, that revision is returned.
+func (st resourceState) openResourceForUnit(unit resource.Unit, name string) (resource.Resource, io.ReadCloser, error) {
+	applicationID := unit.ApplicationName()
+	branchPendingID, err := st.raw.branchResourceID(unit.Name(), applicationID, name)
+	if err != nil {
+		return resource.Resource{}, nil, errors.Trace(err)
+	}
+	if branchPendingID == "" {
+		return st.OpenResource(applicationID, name)
+	}
+
+	id := newResourceID(applicationID, name)
+	resourceInfo, storagePath, err := st.persist.GetPendingResource(id, branchPendingID)
+	if err != nil {
+		return resource.Resource{}, nil, errors.Annotate(err, "while getting branch resource info")
+	}
+	return st.openResource(resourceInfo, storagePath)
+}
+
 // SetCharmStoreResources sets the "polled" resources for the
 // application to the provided values.
 func (st resourceState) SetCharmStoreResources(applicationID string, info []charmresource.Resource, lastPolled time.Time) error {
@@ -0,0 +1,90 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// resourceTagsKey is the key of the model's applied resource tags doc.
+const resourceTagsKey = "applied"
+
+// resourceTagsDoc records the tags last set on a model's cloud resources.
+// The tags are held as a list, since tag names may contain characters
+// that aren't allowed in field names.
+type resourceTagsDoc struct {
+	DocID     string        `bson:"_id"`
+	ModelUUID string        `bson:"model-uuid"`
+	Tags      []resourceTag `bson:"tags"`
+}
+
+type resourceTag struct {
+	Key   string `bson:"key"`
+	Value string `bson:"value"`
+}
+
+// AppliedResourceTags returns the tags last recorded as set on the
+// model's cloud resources, or nil if none have been recorded.
+func (m *Model) AppliedResourceTags() (map[string]string, error) {
+	coll, closer := m.st.db().GetCollection(resourceTagsC)
+	defer closer()
+
+	var doc resourceTagsDoc
+	err := coll.FindId(m.st.docID(resourceTagsKey)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot get applied resource tags")
+	}
+	tags := make(map[string]string)
+	for _, tag := range doc.Tags {
+		tags[tag.Key] = tag.Value
+	}
+	return tags, nil
+}
+
+// SetAppliedResourceTags records the tags set on the model's cloud
+// resources, replacing those recorded before.
+func (m *Model) SetAppliedResourceTags(tags map[string]string) error {
+	doc := resourceTagsDoc{
+		DocID:     m.st.docID(resourceTagsKey),
+		ModelUUID: m.UUID(),
+		Tags:      []resourceTag{},
+	}
+	for k, v := range tags {
+		doc.Tags = append(doc.Tags, resourceTag{Key: k, Value: v})
+	}
+	sort.Slice(doc.Tags, func(i, j int) bool {
+		return doc.Tags[i].Key < doc.Tags[j].Key
+	})
+	buildTxn := func(int) ([]txn.Op, error) {
+		coll, closer := m.st.db().GetCollection(resourceTagsC)
+		defer closer()
+
+		n, err := coll.FindId(doc.DocID).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n == 0 {
+			return []txn.Op{{
+				C:      resourceTagsC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      resourceTagsC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"tags", doc.Tags}}}},
+		}}, nil
+	}
+	return errors.Annotate(m.st.db().Run(buildTxn), "cannot set applied resource tags")
+}
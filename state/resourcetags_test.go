@@ -0,0 +1,51 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type ResourceTagsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ResourceTagsSuite{})
+
+func (s *ResourceTagsSuite) TestAppliedResourceTagsNone(c *gc.C) {
+	tags, err := s.Model.AppliedResourceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, gc.IsNil)
+}
+
+func (s *ResourceTagsSuite) TestSetAppliedResourceTags(c *gc.C) {
+	err := s.Model.SetAppliedResourceTags(map[string]string{
+		"juju-model-uuid":     s.Model.UUID(),
+		"kubernetes.io/owner": "research",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	tags, err := s.Model.AppliedResourceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, jc.DeepEquals, map[string]string{
+		"juju-model-uuid":     s.Model.UUID(),
+		"kubernetes.io/owner": "research",
+	})
+
+	// The tags recorded are replaced.
+	err = s.Model.SetAppliedResourceTags(map[string]string{"cost-centre": "ops"})
+	c.Assert(err, jc.ErrorIsNil)
+	tags, err = s.Model.AppliedResourceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, jc.DeepEquals, map[string]string{"cost-centre": "ops"})
+
+	// Other models' tags are kept apart.
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	tags, err = model.AppliedResourceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, gc.IsNil)
+}
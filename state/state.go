@@ -2300,6 +2300,11 @@ func (st *State) AssignUnit(u *Unit, policy AssignmentPolicy) (err error) {
 		}
 		return u.AssignToNewMachineOrContainer()
 	case AssignNew:
+		// A provisioned machine from the model's machine pool is as
+		// good as a new one, and quicker.
+		if _, err = u.assignToPoolMachine(); errors.Cause(err) != noCleanMachines {
+			return errors.Trace(err)
+		}
 		return errors.Trace(u.AssignToNewMachine())
 	}
 	return errors.Errorf("unknown unit assignment policy: %q", policy)
@@ -1661,6 +1661,7 @@ func (u *Unit) assignToMachine(m *Machine, unused bool) (err error) {
 // - unitNotAliveErr when the unit is not alive.
 // - alreadyAssignedErr when the unit has already been assigned
 // - inUseErr when the machine already has a unit assigned (if unused is true)
+// - placementPolicyError when the assignment breaks a placement policy
 func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 	if u.Life() != Alive {
 		return nil, unitNotAliveErr
@@ -1691,6 +1692,9 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 	); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := checkPlacementPolicy(u, m); err != nil {
+		return nil, errors.Trace(err)
+	}
 	storageOps, volumesAttached, filesystemsAttached, err := sb.hostStorageOps(m.doc.Id, storageParams)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -1717,7 +1721,9 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 			{{"machineid", m.Id()}},
 		},
 	}}...)
-	massert := isAliveDoc
+	// The machine's principals must not change either, so that the
+	// placement policy check above still holds.
+	massert := append(principalsUnchangedAssert(m), isAliveDoc...)
 	if unused {
 		massert = append(massert, bson.D{{"clean", bson.D{{"$ne", false}}}}...)
 	}
@@ -2553,7 +2559,37 @@ func (u *Unit) assignToCleanMaybeEmptyMachine(requireEmpty bool) (_ *Machine, er
 			}
 		}
 		var ops []txn.Op
-		m, ops, err = u.assignToCleanMaybeEmptyMachineOps(requireEmpty)
+		m, ops, err = u.assignToCleanMaybeEmptyMachineOps(requireEmpty, false)
+		return ops, err
+	}
+	if err := u.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	u.doc.MachineId = m.doc.Id
+	m.doc.Clean = false
+	return m, nil
+}
+
+// assignToPoolMachine assigns the unit to a provisioned machine from the
+// model's machine pool, so that it need not wait for a new machine to
+// start. It returns noCleanMachines if no pooled machine is suitable.
+func (u *Unit) assignToPoolMachine() (_ *Machine, err error) {
+	defer assignContextf(&err, u.Name(), "pool machine")
+	if u.doc.Principal != "" {
+		return nil, fmt.Errorf("unit is a subordinate")
+	}
+	var m *Machine
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		var err error
+		u := u // don't change outer var
+		if attempt > 0 {
+			u, err = u.st.Unit(u.Name())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		var ops []txn.Op
+		m, ops, err = u.assignToCleanMaybeEmptyMachineOps(true, true)
 		return ops, err
 	}
 	if err := u.st.db().Run(buildTxn); err != nil {
@@ -2564,7 +2600,10 @@ func (u *Unit) assignToCleanMaybeEmptyMachine(requireEmpty bool) (_ *Machine, er
 	return m, nil
 }
 
-func (u *Unit) assignToCleanMaybeEmptyMachineOps(requireEmpty bool) (_ *Machine, _ []txn.Op, err error) {
+// assignToCleanMaybeEmptyMachineOps returns the ops to assign the unit to
+// a clean, and maybe empty, machine. If poolOnly is true, only provisioned
+// machines from the model's machine pool are considered.
+func (u *Unit) assignToCleanMaybeEmptyMachineOps(requireEmpty, poolOnly bool) (_ *Machine, _ []txn.Op, err error) {
 	failure := func(err error) (*Machine, []txn.Op, error) {
 		return nil, nil, err
 	}
@@ -2611,13 +2650,27 @@ func (u *Unit) assignToCleanMaybeEmptyMachineOps(requireEmpty bool) (_ *Machine,
 	if err := machinesCollection.Find(query).All(&mdocs); err != nil {
 		return failure(err)
 	}
+	var poolIds map[string]bool
+	if poolOnly {
+		if poolIds, err = u.st.machinePoolIds(); err != nil {
+			return failure(err)
+		}
+	}
 	var unprovisioned []*Machine
 	var instances []instance.Id
 	instanceMachines := make(map[instance.Id]*Machine)
 	for _, mdoc := range mdocs {
+		if poolOnly && !poolIds[mdoc.Id] {
+			continue
+		}
 		m := newMachine(u.st, mdoc)
 		inst, err := m.InstanceId()
 		if errors.IsNotProvisioned(err) {
+			if poolOnly {
+				// A pooled machine that is still starting is
+				// no quicker than a new one.
+				continue
+			}
 			unprovisioned = append(unprovisioned, m)
 		} else if err != nil {
 			return failure(err)
@@ -2651,6 +2704,9 @@ func (u *Unit) assignToCleanMaybeEmptyMachineOps(requireEmpty bool) (_ *Machine,
 		machines[i] = m
 	}
 	machines = append(machines, unprovisioned...)
+	if machines, err = spreadUnit(u, machines); err != nil {
+		return failure(err)
+	}
 
 	// TODO(axw) 2014-05-30 #1253704
 	// We should not select a machine that is in the process
@@ -2672,6 +2728,9 @@ func (u *Unit) assignToCleanMaybeEmptyMachineOps(requireEmpty bool) (_ *Machine,
 		if err == nil {
 			return m, ops, nil
 		}
+		if isPlacementPolicyError(err) {
+			continue
+		}
 		switch errors.Cause(err) {
 		case inUseErr, machineNotAliveErr:
 		default:
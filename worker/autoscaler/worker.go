@@ -0,0 +1,175 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package autoscaler provides a worker that adjusts the number of units
+// of applications according to their autoscale policies.
+package autoscaler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/worker/v2"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/api/applicationscaler"
+)
+
+// DefaultPeriod is the default time between measurements of autoscaled
+// applications.
+const DefaultPeriod = time.Minute
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead pass one through as config to the worker.
+var logger interface{}
+
+// Logger represents the methods used by the worker to log information.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+// Facade exposes the controller methods used by the worker.
+type Facade interface {
+	// AutoscaleCandidates returns the applications whose autoscale
+	// policy is enabled, with the latest measurement of each.
+	AutoscaleCandidates() ([]applicationscaler.AutoscaleCandidate, error)
+
+	// ScaleApplication changes the number of units of an application,
+	// recording the decision in the application's scaling history.
+	ScaleApplication(applicationscaler.AutoscaleDecision) error
+}
+
+// Config defines the operation of an autoscaler worker.
+type Config struct {
+	// Facade is the worker's view of the controller.
+	Facade Facade
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// Period is the time between measurements.
+	Period time.Duration
+
+	// Logger is used to report the scaling decisions made.
+	Logger Logger
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that scales autoscaled applications by one
+// unit at a time, once when started and subsequently every Period,
+// whenever their measurement crosses a threshold of their policy and
+// their cooldown has passed.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &autoscalerWorker{
+		config:     config,
+		lastScaled: make(map[names.ApplicationTag]time.Time),
+	}
+	w.tomb.Go(w.loop)
+	return w, nil
+}
+
+type autoscalerWorker struct {
+	tomb   tomb.Tomb
+	config Config
+
+	// lastScaled records when the worker last scaled each application,
+	// in case the controller's history is behind.
+	lastScaled map[names.ApplicationTag]time.Time
+}
+
+func (w *autoscalerWorker) loop() error {
+	var delay time.Duration
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.config.Clock.After(delay):
+			if err := w.scaleApplications(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		delay = w.config.Period
+	}
+}
+
+func (w *autoscalerWorker) scaleApplications() error {
+	candidates, err := w.config.Facade.AutoscaleCandidates()
+	if err != nil {
+		return errors.Annotate(err, "getting autoscale candidates")
+	}
+	logger := w.config.Logger
+	now := w.config.Clock.Now()
+	for _, candidate := range candidates {
+		app, policy := candidate.Application, candidate.Policy
+		inBounds := candidate.Units >= policy.MinUnits && candidate.Units <= policy.MaxUnits
+		if !candidate.HasValue && inBounds {
+			logger.Debugf("not scaling %q: no measurement of %s", app.Id(), policy.Source)
+			continue
+		}
+		lastScaled := candidate.LastScaled
+		if last := w.lastScaled[app]; last.After(lastScaled) {
+			lastScaled = last
+		}
+		if now.Before(lastScaled.Add(policy.Cooldown)) {
+			logger.Debugf("not scaling %q within its cooldown of %v", app.Id(), policy.Cooldown)
+			continue
+		}
+		units, reason := policy.Decide(candidate.Units, candidate.Value)
+		if units == candidate.Units {
+			continue
+		}
+		w.lastScaled[app] = now
+		err := w.config.Facade.ScaleApplication(applicationscaler.AutoscaleDecision{
+			Application: app,
+			FromUnits:   candidate.Units,
+			ToUnits:     units,
+			Source:      policy.Source,
+			Value:       candidate.Value,
+			Reason:      reason,
+		})
+		if err != nil {
+			// The failure is recorded in the application's scaling
+			// history; carry on with the other applications.
+			logger.Errorf("scaling %q from %d to %d unit(s) failed: %v",
+				app.Id(), candidate.Units, units, err)
+			continue
+		}
+		logger.Infof("scaled %q from %d to %d unit(s): %s",
+			app.Id(), candidate.Units, units, reason)
+	}
+	return nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *autoscalerWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *autoscalerWorker) Wait() error {
+	return w.tomb.Wait()
+}
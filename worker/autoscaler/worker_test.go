@@ -0,0 +1,213 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscaler_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/applicationscaler"
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/autoscaler"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	facade *fakeFacade
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 7, 4, 3, 0, 0, 0, time.UTC))
+	s.facade = &fakeFacade{
+		calls: make(chan string, 10),
+		candidates: []applicationscaler.AutoscaleCandidate{{
+			Application: names.NewApplicationTag("mysql"),
+			Policy: application.AutoscalePolicy{
+				Source:             application.AutoscaleSource{Metric: "load"},
+				MinUnits:           1,
+				MaxUnits:           3,
+				ScaleUpThreshold:   0.8,
+				ScaleDownThreshold: 0.2,
+				Cooldown:           5 * time.Minute,
+			},
+			Units:    2,
+			Value:    0.9,
+			HasValue: true,
+		}},
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := autoscaler.NewWorker(autoscaler.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Period: time.Minute,
+		Logger: loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) waitCall(c *gc.C, expected string) {
+	select {
+	case call := <-s.facade.calls:
+		c.Assert(call, gc.Equals, expected)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for %s", expected)
+	}
+}
+
+func (s *WorkerSuite) waitNoCall(c *gc.C) {
+	select {
+	case call := <-s.facade.calls:
+		c.Fatalf("unexpected call %s", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) advance(c *gc.C) {
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := autoscaler.NewWorker(autoscaler.Config{
+		Clock:  s.clock,
+		Period: time.Minute,
+		Logger: loggo.GetLogger("test"),
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+	_, err = autoscaler.NewWorker(autoscaler.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Logger: loggo.GetLogger("test"),
+	})
+	c.Assert(err, gc.ErrorMatches, "non-positive Period not valid")
+}
+
+func (s *WorkerSuite) TestScalesUp(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c, "AutoscaleCandidates")
+	s.waitCall(c, "ScaleApplication mysql 2->3: metric:load 0.9 above 0.8")
+	s.waitNoCall(c)
+}
+
+func (s *WorkerSuite) TestScalesDown(c *gc.C) {
+	s.facade.candidates[0].Value = 0.1
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c, "AutoscaleCandidates")
+	s.waitCall(c, "ScaleApplication mysql 2->1: metric:load 0.1 below 0.2")
+}
+
+func (s *WorkerSuite) TestWithinThresholds(c *gc.C) {
+	s.facade.candidates[0].Value = 0.5
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c, "AutoscaleCandidates")
+	s.waitNoCall(c)
+	s.advance(c)
+	s.waitCall(c, "AutoscaleCandidates")
+}
+
+func (s *WorkerSuite) TestNoValue(c *gc.C) {
+	s.facade.candidates[0].HasValue = false
+	s.facade.candidates[0].Value = 0
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c, "AutoscaleCandidates")
+	s.waitNoCall(c)
+}
+
+func (s *WorkerSuite) TestNoValueOutsideBounds(c *gc.C) {
+	s.facade.candidates[0].HasValue = false
+	s.facade.candidates[0].Units = 0
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c, "AutoscaleCandidates")
+	s.waitCall(c, "ScaleApplication mysql 0->1: 0 unit(s) below minimum of 1")
+}
+
+func (s *WorkerSuite) TestCooldown(c *gc.C) {
+	s.facade.candidates[0].LastScaled = s.clock.Now().Add(-4 * time.Minute)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c, "AutoscaleCandidates")
+	s.waitNoCall(c)
+	s.advance(c)
+	s.waitCall(c, "AutoscaleCandidates")
+	s.waitCall(c, "ScaleApplication mysql 2->3: metric:load 0.9 above 0.8")
+}
+
+func (s *WorkerSuite) TestCooldownAfterScaling(c *gc.C) {
+	// The controller's history isn't updated by the fake facade, so
+	// the worker must remember the scaling itself.
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c, "AutoscaleCandidates")
+	s.waitCall(c, "ScaleApplication mysql 2->3: metric:load 0.9 above 0.8")
+	s.advance(c)
+	s.waitCall(c, "AutoscaleCandidates")
+	s.waitNoCall(c)
+}
+
+func (s *WorkerSuite) TestScaleErrorNotFatal(c *gc.C) {
+	s.facade.scaleErr = errors.New("boom")
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c, "AutoscaleCandidates")
+	s.waitCall(c, "ScaleApplication mysql 2->3: metric:load 0.9 above 0.8")
+	s.advance(c)
+	s.waitCall(c, "AutoscaleCandidates")
+	s.waitNoCall(c)
+}
+
+func (s *WorkerSuite) TestCandidatesError(c *gc.C) {
+	s.facade.candidatesErr = errors.New("boom")
+	w := s.startWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting autoscale candidates: boom")
+}
+
+type fakeFacade struct {
+	calls         chan string
+	candidates    []applicationscaler.AutoscaleCandidate
+	candidatesErr error
+	scaleErr      error
+}
+
+func (f *fakeFacade) AutoscaleCandidates() ([]applicationscaler.AutoscaleCandidate, error) {
+	f.calls <- "AutoscaleCandidates"
+	return f.candidates, f.candidatesErr
+}
+
+func (f *fakeFacade) ScaleApplication(d applicationscaler.AutoscaleDecision) error {
+	f.calls <- fmt.Sprintf("ScaleApplication %s %d->%d: %s", d.Application.Id(), d.FromUnits, d.ToUnits, d.Reason)
+	return f.scaleErr
+}
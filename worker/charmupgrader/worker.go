@@ -0,0 +1,165 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmupgrader provides a worker that upgrades applications'
+// charms automatically, according to their charm update policies.
+package charmupgrader
+
+import (
+	"time"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/worker/v2"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/api/charmrevisionupdater"
+)
+
+// DefaultPeriod is the default time between checks for charms to
+// upgrade. It is short compared to the charm revision update interval
+// so that short maintenance windows are not missed.
+const DefaultPeriod = 10 * time.Minute
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead pass one through as config to the worker.
+var logger interface{}
+
+// Logger represents the methods used by the worker to log information.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+// Facade exposes the controller methods used by the worker.
+type Facade interface {
+	// CharmUpgradeCandidates returns the applications whose charms are
+	// to be upgraded automatically, and for which a later revision is
+	// available.
+	CharmUpgradeCandidates() ([]charmrevisionupdater.CharmUpgradeCandidate, error)
+
+	// UpgradeCharm upgrades the application to the given charm,
+	// returning the id of the operation recording the upgrade.
+	UpgradeCharm(names.ApplicationTag, *charm.URL) (string, error)
+}
+
+// Config defines the operation of a charm upgrader worker.
+type Config struct {
+	// Facade is the worker's view of the controller.
+	Facade Facade
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// Period is the time between checks for charms to upgrade.
+	Period time.Duration
+
+	// Logger is used to report the upgrades made.
+	Logger Logger
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that upgrades the charms of applications
+// whose charm update policy is to upgrade automatically, once when
+// started and subsequently every Period, whenever their maintenance
+// window is open.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &upgraderWorker{
+		config: config,
+		failed: make(map[names.ApplicationTag]string),
+	}
+	w.tomb.Go(w.loop)
+	return w, nil
+}
+
+type upgraderWorker struct {
+	tomb   tomb.Tomb
+	config Config
+
+	// failed records the charm that each application last failed to
+	// be upgraded to, so that the upgrade isn't attempted repeatedly.
+	// An upgrade is attempted again when a later revision appears, or
+	// when the worker is restarted.
+	failed map[names.ApplicationTag]string
+}
+
+func (w *upgraderWorker) loop() error {
+	var delay time.Duration
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.config.Clock.After(delay):
+			if err := w.upgradeCharms(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		delay = w.config.Period
+	}
+}
+
+func (w *upgraderWorker) upgradeCharms() error {
+	candidates, err := w.config.Facade.CharmUpgradeCandidates()
+	if err != nil {
+		return errors.Annotate(err, "getting charm upgrade candidates")
+	}
+	logger := w.config.Logger
+	now := w.config.Clock.Now()
+	for _, candidate := range candidates {
+		app, curl := candidate.Application, candidate.LatestCharmURL
+		if w.failed[app] == curl.String() {
+			continue
+		}
+		if !candidate.MaintenanceWindow.Contains(now) {
+			logger.Debugf("not upgrading %q to %s outside its maintenance window %q",
+				app.Id(), curl, candidate.MaintenanceWindow)
+			continue
+		}
+		operationID, err := w.config.Facade.UpgradeCharm(app, curl)
+		if err != nil {
+			// The failure is recorded with the operation; carry on with
+			// the other applications.
+			logger.Errorf("automatic upgrade of %q from %s to %s failed (operation %s): %v",
+				app.Id(), candidate.CharmURL, curl, operationID, err)
+			w.failed[app] = curl.String()
+			continue
+		}
+		delete(w.failed, app)
+		logger.Infof("upgraded %q from %s to %s (operation %s)",
+			app.Id(), candidate.CharmURL, curl, operationID)
+	}
+	return nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *upgraderWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *upgraderWorker) Wait() error {
+	return w.tomb.Wait()
+}
@@ -0,0 +1,168 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrader_test
+
+import (
+	"time"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/charmrevisionupdater"
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/charmupgrader"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	facade *fakeFacade
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	// 2020-07-04 is a Saturday.
+	s.clock = testclock.NewClock(time.Date(2020, 7, 4, 3, 0, 0, 0, time.UTC))
+	s.facade = &fakeFacade{
+		calls: make(chan string, 10),
+		candidates: []charmrevisionupdater.CharmUpgradeCandidate{{
+			Application:    names.NewApplicationTag("mysql"),
+			CharmURL:       charm.MustParseURL("cs:quantal/mysql-22"),
+			LatestCharmURL: charm.MustParseURL("cs:quantal/mysql-23"),
+		}},
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := charmupgrader.NewWorker(charmupgrader.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Period: time.Minute,
+		Logger: loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) waitCall(c *gc.C, expected string) {
+	select {
+	case call := <-s.facade.calls:
+		c.Assert(call, gc.Equals, expected)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for %s", expected)
+	}
+}
+
+func (s *WorkerSuite) waitNoCall(c *gc.C) {
+	select {
+	case call := <-s.facade.calls:
+		c.Fatalf("unexpected call %s", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) advance(c *gc.C) {
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := charmupgrader.NewWorker(charmupgrader.Config{
+		Clock:  s.clock,
+		Period: time.Minute,
+		Logger: loggo.GetLogger("test"),
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+	_, err = charmupgrader.NewWorker(charmupgrader.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Logger: loggo.GetLogger("test"),
+	})
+	c.Assert(err, gc.ErrorMatches, "non-positive Period not valid")
+}
+
+func (s *WorkerSuite) TestUpgradesImmediately(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c, "CharmUpgradeCandidates")
+	s.waitCall(c, "UpgradeCharm mysql cs:quantal/mysql-23")
+	s.waitNoCall(c)
+}
+
+func (s *WorkerSuite) TestChecksAgainAfterPeriod(c *gc.C) {
+	s.facade.candidates = nil
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c, "CharmUpgradeCandidates")
+	s.waitNoCall(c)
+	s.advance(c)
+	s.waitCall(c, "CharmUpgradeCandidates")
+}
+
+func (s *WorkerSuite) TestMaintenanceWindow(c *gc.C) {
+	window, err := application.ParseMaintenanceWindow("sat 04:00-05:00")
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.candidates[0].MaintenanceWindow = window
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	// The window isn't open until 04:00.
+	s.waitCall(c, "CharmUpgradeCandidates")
+	s.waitNoCall(c)
+
+	err = s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCall(c, "CharmUpgradeCandidates")
+	s.waitCall(c, "UpgradeCharm mysql cs:quantal/mysql-23")
+}
+
+func (s *WorkerSuite) TestFailedUpgradeNotRepeated(c *gc.C) {
+	s.facade.upgradeErr = errors.New("boom")
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c, "CharmUpgradeCandidates")
+	s.waitCall(c, "UpgradeCharm mysql cs:quantal/mysql-23")
+	s.advance(c)
+	s.waitCall(c, "CharmUpgradeCandidates")
+	s.waitNoCall(c)
+}
+
+func (s *WorkerSuite) TestCandidatesError(c *gc.C) {
+	s.facade.candidatesErr = errors.New("boom")
+	w := s.startWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting charm upgrade candidates: boom")
+}
+
+type fakeFacade struct {
+	calls         chan string
+	candidates    []charmrevisionupdater.CharmUpgradeCandidate
+	candidatesErr error
+	upgradeErr    error
+}
+
+func (f *fakeFacade) CharmUpgradeCandidates() ([]charmrevisionupdater.CharmUpgradeCandidate, error) {
+	f.calls <- "CharmUpgradeCandidates"
+	return f.candidates, f.candidatesErr
+}
+
+func (f *fakeFacade) UpgradeCharm(app names.ApplicationTag, curl *charm.URL) (string, error) {
+	f.calls <- "UpgradeCharm " + app.Id() + " " + curl.String()
+	return "1", f.upgradeErr
+}
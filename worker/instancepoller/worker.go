@@ -36,7 +36,10 @@ import (
 // reached.
 //
 // When a machine has an address and is started LongPoll will be used to
-// check that the instance address or status has not changed.
+// check that the instance address or status has not changed, unless the
+// provider may reclaim its instance at short notice, in which case it
+// keeps being polled at ShortPollCap intervals so that interruptions are
+// reported promptly.
 var (
 	ShortPoll        = 3 * time.Second
 	ShortPollBackoff = 2.0
@@ -117,6 +120,10 @@ type pollGroupEntry struct {
 	tag        names.MachineTag
 	instanceID instance.Id
 
+	// interruptible is true if the provider may reclaim the
+	// machine's instance at short notice.
+	interruptible bool
+
 	shortPollInterval time.Duration
 	shortPollAt       time.Time
 }
@@ -380,6 +387,9 @@ func (u *updaterWorker) pollGroupMembers(groupType pollGroupType) error {
 		}
 
 		entry := u.instanceIDToGroupEntry[instList[idx]]
+		if inst, ok := info.(instances.InterruptibleInstance); ok {
+			entry.interruptible = inst.Interruptible()
+		}
 		providerStatus, providerAddrCount, err := u.processProviderInfo(entry, info, ifList)
 		if err != nil {
 			return errors.Trace(err)
@@ -526,6 +536,18 @@ func (u *updaterWorker) maybeSwitchPollGroup(curGroup pollGroupType, entry *poll
 		return
 	}
 
+	// Instances which may be reclaimed at short notice are kept in the
+	// short poll group so that interruptions are reported promptly.
+	if entry.interruptible {
+		if curGroup == longPollGroup {
+			u.moveEntryToPollGroup(shortPollGroup, entry)
+			u.config.Logger.Debugf("moving interruptible machine %q (instance ID %q) back to short poll group", entry.m, entry.instanceID)
+			return
+		}
+		entry.bumpShortPollInterval(u.config.Clock)
+		return
+	}
+
 	// The machine has started and we have at least one address; move to
 	// the long poll group
 	if providerAddrCount > 0 && curMachineStatus == status.Started {
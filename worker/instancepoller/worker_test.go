@@ -295,6 +295,38 @@ func (s *workerSuite) TestMoveMachineWithUnknownStatusBackToShortPollGroup(c *gc
 	c.Assert(entry.shortPollInterval, gc.Equals, ShortPoll)
 }
 
+func (s *workerSuite) TestInterruptibleMachineStaysInShortPollGroup(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	w, _ := s.startWorker(c, ctrl)
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	machineTag := names.NewMachineTag("0")
+	machine := mocks.NewMockMachine(ctrl)
+	updWorker.appendToShortPollGroup(machineTag, machine)
+	entry, _ := updWorker.lookupPolledMachine(machineTag)
+	entry.interruptible = true
+
+	// A started machine with addresses would normally be moved to the
+	// long poll group, but interruptible machines are polled at no more
+	// than ShortPollCap intervals.
+	for i := 0; i < 10; i++ {
+		updWorker.maybeSwitchPollGroup(shortPollGroup, entry, status.Running, status.Started, 1)
+	}
+	c.Assert(updWorker.pollGroup[shortPollGroup], gc.HasLen, 1)
+	c.Assert(updWorker.pollGroup[longPollGroup], gc.HasLen, 0)
+	c.Assert(entry.shortPollInterval, gc.Equals, ShortPollCap)
+
+	// If the machine was in the long poll group, it is moved back.
+	updWorker.moveEntryToPollGroup(longPollGroup, entry)
+	updWorker.maybeSwitchPollGroup(longPollGroup, entry, status.Running, status.Started, 1)
+	c.Assert(updWorker.pollGroup[shortPollGroup], gc.HasLen, 1)
+	c.Assert(updWorker.pollGroup[longPollGroup], gc.HasLen, 0)
+	c.Assert(entry.shortPollInterval, gc.Equals, ShortPoll)
+}
+
 func (s *workerSuite) TestSkipMachineIfShortPollTargetTimeNotElapsed(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -0,0 +1,127 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package leakdetector provides a worker that periodically looks for
+// cloud resources that have leaked from the controller's models, and
+// reports them in the controller's logs. It never releases anything
+// itself; that is left to an operator running juju detect-leaks.
+package leakdetector
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/api/leakdetector"
+)
+
+// DefaultPeriod is the default time between checks for leaked
+// resources.
+const DefaultPeriod = time.Hour
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead pass one through as config to the worker.
+var logger interface{}
+
+// Logger represents the methods used by the worker to log information.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+// Facade exposes the controller methods used by the worker.
+type Facade interface {
+	// DetectLeaks returns the cloud resources that have leaked from
+	// the controller's models, releasing them if cleanup is true.
+	DetectLeaks(cleanup bool) ([]leakdetector.Resource, error)
+}
+
+// Config defines the operation of a leak detector worker.
+type Config struct {
+	// Facade is the worker's view of the controller.
+	Facade Facade
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// Period is the time between checks for leaked resources.
+	Period time.Duration
+
+	// Logger is used to report the leaked resources found.
+	Logger Logger
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that checks for leaked cloud resources
+// every Period, starting one Period after it is started.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &leakWorker{config: config}
+	w.tomb.Go(w.loop)
+	return w, nil
+}
+
+type leakWorker struct {
+	tomb   tomb.Tomb
+	config Config
+}
+
+func (w *leakWorker) loop() error {
+	// Listing every model's cloud is expensive, so don't do it as
+	// soon as the controller starts.
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.config.Clock.After(w.config.Period):
+			if err := w.detect(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (w *leakWorker) detect() error {
+	resources, err := w.config.Facade.DetectLeaks(false)
+	if err != nil {
+		return errors.Annotate(err, "detecting leaked resources")
+	}
+	logger := w.config.Logger
+	for _, r := range resources {
+		logger.Warningf("leaked %s %q of model %q: %s", r.Kind, r.Id, r.ModelUUID, r.Reason)
+	}
+	logger.Debugf("found %d leaked resource(s)", len(resources))
+	return nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *leakWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *leakWorker) Wait() error {
+	return w.tomb.Wait()
+}
@@ -0,0 +1,122 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leakdetector_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	apileakdetector "github.com/juju/juju/api/leakdetector"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/leakdetector"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	facade *fakeFacade
+	logger *fakeLogger
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 7, 4, 3, 0, 0, 0, time.UTC))
+	s.facade = &fakeFacade{calls: make(chan bool, 10)}
+	s.logger = &fakeLogger{warnings: make(chan string, 10)}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := leakdetector.NewWorker(leakdetector.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Period: time.Hour,
+		Logger: s.logger,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := leakdetector.NewWorker(leakdetector.Config{
+		Clock:  s.clock,
+		Period: time.Hour,
+		Logger: s.logger,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+	_, err = leakdetector.NewWorker(leakdetector.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Logger: s.logger,
+	})
+	c.Assert(err, gc.ErrorMatches, "non-positive Period not valid")
+}
+
+func (s *WorkerSuite) TestReportsLeaksPeriodically(c *gc.C) {
+	s.facade.resources = []apileakdetector.Resource{{
+		Kind:      "instance",
+		Id:        "i-0",
+		ModelUUID: "some-uuid",
+		Reason:    "model no longer exists",
+	}}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	for i := 0; i < 2; i++ {
+		err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+		select {
+		case cleanup := <-s.facade.calls:
+			c.Check(cleanup, jc.IsFalse)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for DetectLeaks")
+		}
+		select {
+		case msg := <-s.logger.warnings:
+			c.Check(msg, gc.Equals, `leaked instance "i-0" of model "some-uuid": model no longer exists`)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for warning")
+		}
+	}
+}
+
+func (s *WorkerSuite) TestDetectLeaksError(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	w := s.startWorker(c)
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "detecting leaked resources: boom")
+}
+
+type fakeFacade struct {
+	calls     chan bool
+	resources []apileakdetector.Resource
+	err       error
+}
+
+func (f *fakeFacade) DetectLeaks(cleanup bool) ([]apileakdetector.Resource, error) {
+	f.calls <- cleanup
+	return f.resources, f.err
+}
+
+type fakeLogger struct {
+	warnings chan string
+}
+
+func (l *fakeLogger) Debugf(string, ...interface{}) {}
+
+func (l *fakeLogger) Warningf(format string, args ...interface{}) {
+	l.warnings <- fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,129 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package machinepool provides a worker that keeps a model's pool of
+// idle, pre-provisioned machines at its configured size. The machines
+// it adds are started by the provisioner like any other, and are
+// consumed by new units before new instances are started.
+package machinepool
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/api/machinepool"
+)
+
+// DefaultPeriod is the default time between checks of the machine pool.
+const DefaultPeriod = 30 * time.Second
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead pass one through as config to the worker.
+var logger interface{}
+
+// Logger represents the methods used by the worker to log information.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+}
+
+// Facade exposes the controller methods used by the worker.
+type Facade interface {
+	// MaintainMachinePool adds machines to, or removes them from, the
+	// model's machine pool so that it matches the model's config.
+	MaintainMachinePool() (machinepool.MaintainResult, error)
+}
+
+// Config defines the operation of a machine pool worker.
+type Config struct {
+	// Facade is the worker's view of the controller.
+	Facade Facade
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// Period is the time between checks of the pool.
+	Period time.Duration
+
+	// Logger is used to report the changes made to the pool.
+	Logger Logger
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that replenishes the model's machine pool,
+// and trims it to size, once when started and subsequently every Period.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &poolWorker{config: config}
+	w.tomb.Go(w.loop)
+	return w, nil
+}
+
+type poolWorker struct {
+	tomb   tomb.Tomb
+	config Config
+}
+
+func (w *poolWorker) loop() error {
+	var delay time.Duration
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.config.Clock.After(delay):
+			if err := w.maintain(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		delay = w.config.Period
+	}
+}
+
+func (w *poolWorker) maintain() error {
+	result, err := w.config.Facade.MaintainMachinePool()
+	if err != nil {
+		return errors.Annotate(err, "maintaining machine pool")
+	}
+	logger := w.config.Logger
+	if len(result.Added) > 0 {
+		logger.Infof("added machine(s) %v to pool", result.Added)
+	}
+	if len(result.Removed) > 0 {
+		logger.Infof("removed machine(s) %v from pool", result.Removed)
+	}
+	logger.Debugf("machine pool holds %d of %d machine(s)", len(result.Machines), result.Size)
+	return nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *poolWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *poolWorker) Wait() error {
+	return w.tomb.Wait()
+}
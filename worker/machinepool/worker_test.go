@@ -0,0 +1,110 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinepool_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	apimachinepool "github.com/juju/juju/api/machinepool"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/machinepool"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	facade *fakeFacade
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 7, 4, 3, 0, 0, 0, time.UTC))
+	s.facade = &fakeFacade{calls: make(chan string, 10)}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := machinepool.NewWorker(machinepool.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Period: time.Minute,
+		Logger: loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) waitCall(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for MaintainMachinePool")
+	}
+}
+
+func (s *WorkerSuite) waitNoCall(c *gc.C) {
+	select {
+	case call := <-s.facade.calls:
+		c.Fatalf("unexpected call %s", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := machinepool.NewWorker(machinepool.Config{
+		Clock:  s.clock,
+		Period: time.Minute,
+		Logger: loggo.GetLogger("test"),
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+	_, err = machinepool.NewWorker(machinepool.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Logger: loggo.GetLogger("test"),
+	})
+	c.Assert(err, gc.ErrorMatches, "non-positive Period not valid")
+}
+
+func (s *WorkerSuite) TestMaintainsPeriodically(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c)
+	s.waitNoCall(c)
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCall(c)
+}
+
+func (s *WorkerSuite) TestMaintainError(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	w := s.startWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "maintaining machine pool: boom")
+}
+
+type fakeFacade struct {
+	calls chan string
+	err   error
+}
+
+func (f *fakeFacade) MaintainMachinePool() (apimachinepool.MaintainResult, error) {
+	f.calls <- "MaintainMachinePool"
+	return apimachinepool.MaintainResult{
+		Size:     2,
+		Machines: []string{"1", "2"},
+		Added:    []string{"2"},
+	}, f.err
+}
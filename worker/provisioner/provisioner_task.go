@@ -1071,6 +1071,56 @@ func (task *provisionerTask) populateExcludedMachines(machineId string, startIns
 	return nil
 }
 
+// costCheckingBroker is an InstanceBroker which can report the model
+// config and the cost of instance types, such as an environ.
+type costCheckingBroker interface {
+	environs.ConfigGetter
+	environs.InstanceTypesFetcher
+}
+
+// checkInstanceCost returns an error if the cheapest instance type
+// matching the constraints costs more than the model's max-instance-cost.
+// Brokers which cannot report the cost of instance types are not checked.
+func (task *provisionerTask) checkInstanceCost(cons constraints.Value) error {
+	broker, ok := task.broker.(costCheckingBroker)
+	if !ok {
+		return nil
+	}
+	maxCost, ok := broker.Config().MaxInstanceCost()
+	if !ok {
+		return nil
+	}
+	itypes, err := broker.InstanceTypes(task.cloudCallCtx, cons)
+	if errors.IsNotSupported(err) || (err == nil && itypes.CostUnit == "") {
+		// Without a cost unit, costs are only useful
+		// for comparing instance types with each other.
+		task.logger.Warningf("cannot enforce %s: instance type costs are not available", config.MaxInstanceCostKey)
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "getting instance type costs")
+	}
+	if len(itypes.InstanceTypes) == 0 {
+		return nil
+	}
+	// Instance types are sorted by cost, and providers
+	// start the cheapest one matching the constraints.
+	cheapest := itypes.InstanceTypes[0]
+	cost, ok := itypes.UnitCost(cheapest)
+	if !ok {
+		return errors.Errorf(
+			"cost of instance type %q is not known, so cannot be checked against %s %v",
+			cheapest.Name, config.MaxInstanceCostKey, maxCost,
+		)
+	}
+	if cost > maxCost {
+		return errors.Errorf(
+			"cheapest instance type %q matching constraints costs %v %s, which exceeds %s %v",
+			cheapest.Name, cost, itypes.CostUnit, config.MaxInstanceCostKey, maxCost,
+		)
+	}
+	return nil
+}
+
 func (task *provisionerTask) startMachine(
 	machine apiprovisioner.MachineProvisioner,
 	distributionGroupMachineIds []string,
@@ -1089,6 +1139,10 @@ func (task *provisionerTask) startMachine(
 		return errors.Trace(task.setErrorStatus("%v", machine, err))
 	}
 
+	if err := task.checkInstanceCost(startInstanceParams.Constraints); err != nil {
+		return task.setErrorStatus("cannot start instance for machine %q: %v", machine, err)
+	}
+
 	// Figure out if the zones available to use for a new instance are
 	// restricted based on placement, and if so exclude those machines
 	// from being started in any other zone.
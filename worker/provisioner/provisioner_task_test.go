@@ -64,6 +64,8 @@ type ProvisionerTaskSuite struct {
 	invalidCredential bool
 
 	auth *testAuthenticationProvider
+
+	modelConfig *config.Config
 }
 
 var _ = gc.Suite(&ProvisionerTaskSuite{})
@@ -105,6 +107,7 @@ func (s *ProvisionerTaskSuite) SetUpTest(c *gc.C) {
 		},
 	}
 	s.auth = &testAuthenticationProvider{&testing.Stub{}}
+	s.modelConfig = coretesting.ModelConfig(c)
 }
 
 func (s *ProvisionerTaskSuite) TestStartStop(c *gc.C) {
@@ -205,6 +208,57 @@ func (s *ProvisionerTaskSuite) TestProvisionerRetries(c *gc.C) {
 	s.instanceBroker.CheckCallNames(c, "StartInstance", "StartInstance")
 }
 
+func (s *ProvisionerTaskSuite) TestMaxInstanceCostExceeded(c *gc.C) {
+	broker := s.newCostedInstanceBroker(c, "0.05")
+	task := s.newProvisionerTaskWithBroker(c, broker, nil)
+
+	m0 := &testMachine{
+		id:          "0",
+		constraints: "mem=4G",
+	}
+	s.machineStatusResults = []apiprovisioner.MachineStatusResult{{Machine: m0, Status: params.StatusResult{}}}
+	s.sendMachineErrorRetryChange(c)
+
+	// Wait for instance status to be set.
+	timeout := time.After(coretesting.LongWait)
+	for msg := ""; msg == "" || msg == "starting"; {
+		select {
+		case <-time.After(coretesting.ShortWait):
+			_, msg, _ = m0.InstanceStatus()
+		case <-timeout:
+			c.Fatalf("machine InstanceStatus was not set")
+		}
+	}
+
+	_, msg, err := m0.InstanceStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(msg, gc.Equals,
+		`cheapest instance type "m.medium" matching constraints costs 0.08 $USD/hour, which exceeds max-instance-cost 0.05`)
+
+	workertest.CleanKill(c, task)
+	c.Check(broker.constraints, jc.DeepEquals, []constraints.Value{constraints.MustParse("mem=4G")})
+	broker.CheckNoCalls(c)
+}
+
+func (s *ProvisionerTaskSuite) TestMaxInstanceCostNotExceeded(c *gc.C) {
+	s.instanceBroker.SetErrors(errors.New("no instance for you"))
+	broker := s.newCostedInstanceBroker(c, "0.1")
+	task := s.newProvisionerTaskWithBroker(c, broker, nil)
+
+	m0 := &testMachine{
+		id:          "0",
+		constraints: "mem=4G",
+	}
+	s.machineStatusResults = []apiprovisioner.MachineStatusResult{{Machine: m0, Status: params.StatusResult{}}}
+	s.sendMachineErrorRetryChange(c)
+
+	s.waitForTask(c, []string{"StartInstance"})
+
+	workertest.CleanKill(c, task)
+	close(s.instanceBroker.callsChan)
+	broker.CheckCallNames(c, "StartInstance")
+}
+
 func (s *ProvisionerTaskSuite) TestMultipleSpaceConstraints(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -596,6 +650,7 @@ func (s *ProvisionerTaskSuite) setUpZonedEnviron(ctrl *gomock.Controller) *mocks
 
 	broker := mocks.NewMockZonedEnviron(ctrl)
 	exp := broker.EXPECT()
+	exp.Config().Return(s.modelConfig).AnyTimes()
 	exp.AllRunningInstances(s.callCtx).Return(s.instances, nil)
 	exp.InstanceAvailabilityZoneNames(s.callCtx, instanceIds).Return([]string{}, nil)
 	exp.AvailabilityZones(s.callCtx).Return(zones, nil)
@@ -753,6 +808,45 @@ func (t *testInstanceBroker) MaintainInstance(ctx context.ProviderCallContext, a
 	return nil
 }
 
+// costedInstanceBroker is a testInstanceBroker which reports the
+// model config, and the cost of instance types.
+type costedInstanceBroker struct {
+	*testInstanceBroker
+
+	config        *config.Config
+	instanceTypes instances.InstanceTypesWithCostMetadata
+	constraints   []constraints.Value
+}
+
+func (s *ProvisionerTaskSuite) newCostedInstanceBroker(c *gc.C, maxCost string) *costedInstanceBroker {
+	cfg, err := s.modelConfig.Apply(map[string]interface{}{
+		config.MaxInstanceCostKey: maxCost,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return &costedInstanceBroker{
+		testInstanceBroker: s.instanceBroker,
+		config:             cfg,
+		instanceTypes: instances.InstanceTypesWithCostMetadata{
+			InstanceTypes: []instances.InstanceType{
+				{Name: "m.medium", Mem: 4096, Cost: 80},
+				{Name: "m.large", Mem: 8192, Cost: 160},
+			},
+			CostUnit:     "$USD/hour",
+			CostCurrency: "USD",
+			CostDivisor:  1000,
+		},
+	}
+}
+
+func (b *costedInstanceBroker) Config() *config.Config {
+	return b.config
+}
+
+func (b *costedInstanceBroker) InstanceTypes(_ context.ProviderCallContext, cons constraints.Value) (instances.InstanceTypesWithCostMetadata, error) {
+	b.constraints = append(b.constraints, cons)
+	return b.instanceTypes, nil
+}
+
 type testInstance struct {
 	instances.Instance
 	id string
@@ -0,0 +1,254 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package resourcetagger provides a worker that keeps the tags on a
+// model's cloud instances and volumes up to date with the model's
+// resource-tags config. Resources are tagged when they are created, so
+// the worker only needs to act when the tags computed from the config
+// change.
+//
+// Tags are set, and existing tags with the same names replaced. Tags
+// that the worker has set and that are then removed from the config are
+// removed from the resources, where the provider supports it. The tags
+// last set are recorded with the model, so that tags removed from the
+// config while the worker is not running are removed when it restarts.
+package resourcetagger
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+
+	"github.com/juju/juju/api/resourcetagger"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead pass one through as config to the worker.
+var logger interface{}
+
+// Logger represents the methods used by the worker to log information.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+// Facade exposes the controller methods used by the worker.
+type Facade interface {
+	// WatchForModelConfigChanges returns a watcher that notifies of
+	// changes to the model's config.
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+
+	// ResourceTagTargets returns the tags to set on the model's cloud
+	// resources, and the instances and volumes to set them on.
+	ResourceTagTargets() (resourcetagger.Targets, error)
+
+	// AppliedResourceTags returns the tags last recorded as set on the
+	// model's cloud resources, or nil if none have been recorded.
+	AppliedResourceTags() (map[string]string, error)
+
+	// SetAppliedResourceTags records the tags set on the model's
+	// cloud resources.
+	SetAppliedResourceTags(tags map[string]string) error
+}
+
+// Config defines the operation of a resource tagger worker.
+type Config struct {
+	// Facade is the worker's view of the controller.
+	Facade Facade
+
+	// InstanceTagger tags the model's instances. It is nil if the
+	// provider cannot tag instances. If it is also an
+	// environs.InstanceTagRemover, tags removed from the config are
+	// removed from the instances.
+	InstanceTagger environs.InstanceTagger
+
+	// VolumeTagger tags the model's volumes. It is nil if the provider
+	// cannot tag volumes. If it is also an environs.VolumeTagRemover,
+	// tags removed from the config are removed from the volumes.
+	VolumeTagger environs.VolumeTagger
+
+	// CallContext is passed to the provider when tagging.
+	CallContext context.ProviderCallContext
+
+	// Logger is used to report the resources tagged.
+	Logger Logger
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.InstanceTagger == nil && config.VolumeTagger == nil {
+		return errors.NotValidf("nil InstanceTagger and VolumeTagger")
+	}
+	if config.CallContext == nil {
+		return errors.NotValidf("nil CallContext")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that tags the model's instances and
+// volumes when started, and again whenever the tags computed from the
+// model's config change.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: &tagger{config: config},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// tagger implements watcher.NotifyHandler.
+type tagger struct {
+	config Config
+
+	// applied holds the tags last set on all of the model's resources.
+	// Its keys are the tags owned by the worker.
+	applied map[string]string
+}
+
+// SetUp is part of the watcher.NotifyHandler interface. It loads the
+// tags last set on the model's resources, so that those removed from the
+// config since are removed from the resources.
+func (t *tagger) SetUp() (watcher.NotifyWatcher, error) {
+	applied, err := t.config.Facade.AppliedResourceTags()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting applied resource tags")
+	}
+	t.applied = applied
+	return t.config.Facade.WatchForModelConfigChanges()
+}
+
+// Handle is part of the watcher.NotifyHandler interface. It tags the
+// model's resources if the tags have changed since they were last
+// applied, and removes the tags it last applied that are no longer
+// wanted. Resources that fail to be tagged cause an error, so that the
+// worker is restarted and tries again.
+func (t *tagger) Handle(<-chan struct{}) error {
+	targets, err := t.config.Facade.ResourceTagTargets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if t.applied != nil && reflect.DeepEqual(targets.Tags, t.applied) {
+		return nil
+	}
+	var removed []string
+	for k := range t.applied {
+		if _, ok := targets.Tags[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(removed)
+	failed := t.tagInstances(targets.InstanceIds, targets.Tags, removed) +
+		t.tagVolumes(targets.VolumeIds, targets.Tags, removed)
+	if failed > 0 {
+		return errors.Errorf("failed to tag %d resource(s)", failed)
+	}
+	t.config.Logger.Infof(
+		"tagged %d instance(s) and %d volume(s) with %v",
+		len(targets.InstanceIds), len(targets.VolumeIds), targets.Tags,
+	)
+	if len(removed) > 0 {
+		t.config.Logger.Infof("removed tags %v", removed)
+	}
+	if err := t.config.Facade.SetAppliedResourceTags(targets.Tags); err != nil {
+		return errors.Annotate(err, "recording applied resource tags")
+	}
+	t.applied = targets.Tags
+	return nil
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (t *tagger) TearDown() error {
+	return nil
+}
+
+// tagInstances tags the given instances, and removes the tags with the
+// given names from them. It returns the number of instances that could
+// not be tagged.
+func (t *tagger) tagInstances(ids []instance.Id, tags map[string]string, removed []string) int {
+	if t.config.InstanceTagger == nil {
+		return 0
+	}
+	remover, _ := t.config.InstanceTagger.(environs.InstanceTagRemover)
+	if len(removed) > 0 && remover == nil {
+		t.config.Logger.Debugf("not removing tags %v from instances: not supported", removed)
+	}
+	var failed int
+	for _, id := range ids {
+		err := t.config.InstanceTagger.TagInstance(t.config.CallContext, id, tags)
+		if err == nil && len(removed) > 0 && remover != nil {
+			err = remover.RemoveInstanceTags(t.config.CallContext, id, removed)
+		}
+		if skip, ok := skipped(err); ok {
+			t.config.Logger.Debugf("not tagging instance %q: %v", id, err)
+			if skip {
+				return failed
+			}
+		} else if err != nil {
+			t.config.Logger.Errorf("cannot tag instance %q: %v", id, err)
+			failed++
+		}
+	}
+	return failed
+}
+
+// tagVolumes tags the given volumes, and removes the tags with the
+// given names from them. It returns the number of volumes that could
+// not be tagged.
+func (t *tagger) tagVolumes(ids []string, tags map[string]string, removed []string) int {
+	if t.config.VolumeTagger == nil {
+		return 0
+	}
+	remover, _ := t.config.VolumeTagger.(environs.VolumeTagRemover)
+	if len(removed) > 0 && remover == nil {
+		t.config.Logger.Debugf("not removing tags %v from volumes: not supported", removed)
+	}
+	var failed int
+	for _, id := range ids {
+		err := t.config.VolumeTagger.TagVolume(t.config.CallContext, id, tags)
+		if err == nil && len(removed) > 0 && remover != nil {
+			err = remover.RemoveVolumeTags(t.config.CallContext, id, removed)
+		}
+		if skip, ok := skipped(err); ok {
+			t.config.Logger.Debugf("not tagging volume %q: %v", id, err)
+			if skip {
+				return failed
+			}
+		} else if err != nil {
+			t.config.Logger.Errorf("cannot tag volume %q: %v", id, err)
+			failed++
+		}
+	}
+	return failed
+}
+
+// skipped returns true in ok if the error means the resource is not to
+// be tagged: either it has gone, or the provider cannot tag resources
+// of its kind. In the latter case, it also returns true in skipAll.
+func skipped(err error) (skipAll bool, ok bool) {
+	switch {
+	case errors.IsNotSupported(err):
+		return true, true
+	case errors.IsNotFound(err):
+		return false, true
+	}
+	return false, false
+}
@@ -0,0 +1,363 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	apiresourcetagger "github.com/juju/juju/api/resourcetagger"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/environs/context"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/resourcetagger"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	changes chan struct{}
+	facade  *fakeFacade
+	tagger  *fakeTagger
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.changes = make(chan struct{}, 1)
+	s.facade = &fakeFacade{
+		changes: s.changes,
+		targets: apiresourcetagger.Targets{
+			Tags:        map[string]string{"cost-centre": "research"},
+			InstanceIds: []instance.Id{"i-0", "i-1"},
+			VolumeIds:   []string{"vol-0"},
+		},
+	}
+	s.tagger = &fakeTagger{
+		calls:  make(chan string, 10),
+		errors: make(map[string]error),
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	return s.startWorkerWithTagger(c, s.tagger)
+}
+
+func (s *WorkerSuite) startWorkerWithTagger(c *gc.C, tagger tagger) worker.Worker {
+	w, err := resourcetagger.NewWorker(resourcetagger.Config{
+		Facade:         s.facade,
+		InstanceTagger: tagger,
+		VolumeTagger:   tagger,
+		CallContext:    context.NewCloudCallContext(),
+		Logger:         loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) notify() {
+	s.changes <- struct{}{}
+}
+
+func (s *WorkerSuite) waitCalls(c *gc.C, expected ...string) {
+	for _, call := range expected {
+		select {
+		case actual := <-s.tagger.calls:
+			c.Check(actual, gc.Equals, call)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for %s", call)
+		}
+	}
+	select {
+	case call := <-s.tagger.calls:
+		c.Fatalf("unexpected call %s", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := resourcetagger.NewWorker(resourcetagger.Config{
+		InstanceTagger: s.tagger,
+		CallContext:    context.NewCloudCallContext(),
+		Logger:         loggo.GetLogger("test"),
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+	_, err = resourcetagger.NewWorker(resourcetagger.Config{
+		Facade:      s.facade,
+		CallContext: context.NewCloudCallContext(),
+		Logger:      loggo.GetLogger("test"),
+	})
+	c.Assert(err, gc.ErrorMatches, "nil InstanceTagger and VolumeTagger not valid")
+}
+
+func (s *WorkerSuite) TestTagsResourcesOnStart(c *gc.C) {
+	s.notify()
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCalls(c,
+		"TagInstance i-0 map[cost-centre:research]",
+		"TagInstance i-1 map[cost-centre:research]",
+		"TagVolume vol-0 map[cost-centre:research]",
+	)
+}
+
+func (s *WorkerSuite) TestTagsUnchanged(c *gc.C) {
+	s.notify()
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.waitCalls(c,
+		"TagInstance i-0 map[cost-centre:research]",
+		"TagInstance i-1 map[cost-centre:research]",
+		"TagVolume vol-0 map[cost-centre:research]",
+	)
+
+	s.notify()
+	s.waitCalls(c)
+}
+
+func (s *WorkerSuite) TestTagsChanged(c *gc.C) {
+	s.notify()
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.waitCalls(c,
+		"TagInstance i-0 map[cost-centre:research]",
+		"TagInstance i-1 map[cost-centre:research]",
+		"TagVolume vol-0 map[cost-centre:research]",
+	)
+
+	s.facade.setTags(map[string]string{"cost-centre": "accounts"})
+	s.notify()
+	s.waitCalls(c,
+		"TagInstance i-0 map[cost-centre:accounts]",
+		"TagInstance i-1 map[cost-centre:accounts]",
+		"TagVolume vol-0 map[cost-centre:accounts]",
+	)
+}
+
+func (s *WorkerSuite) TestTagsRemoved(c *gc.C) {
+	s.facade.setTags(map[string]string{"cost-centre": "research", "owner": "eve"})
+	s.notify()
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.waitCalls(c,
+		"TagInstance i-0 map[cost-centre:research owner:eve]",
+		"TagInstance i-1 map[cost-centre:research owner:eve]",
+		"TagVolume vol-0 map[cost-centre:research owner:eve]",
+	)
+
+	s.facade.setTags(map[string]string{"cost-centre": "research"})
+	s.notify()
+	s.waitCalls(c,
+		"TagInstance i-0 map[cost-centre:research]",
+		"RemoveInstanceTags i-0 [owner]",
+		"TagInstance i-1 map[cost-centre:research]",
+		"RemoveInstanceTags i-1 [owner]",
+		"TagVolume vol-0 map[cost-centre:research]",
+		"RemoveVolumeTags vol-0 [owner]",
+	)
+}
+
+func (s *WorkerSuite) TestTagsRemovedNotSupported(c *gc.C) {
+	s.facade.setTags(map[string]string{"cost-centre": "research", "owner": "eve"})
+	s.notify()
+	w := s.startWorkerWithTagger(c, tagOnly{s.tagger})
+	defer workertest.CleanKill(c, w)
+	s.waitCalls(c,
+		"TagInstance i-0 map[cost-centre:research owner:eve]",
+		"TagInstance i-1 map[cost-centre:research owner:eve]",
+		"TagVolume vol-0 map[cost-centre:research owner:eve]",
+	)
+
+	s.facade.setTags(map[string]string{"cost-centre": "research"})
+	s.notify()
+	s.waitCalls(c,
+		"TagInstance i-0 map[cost-centre:research]",
+		"TagInstance i-1 map[cost-centre:research]",
+		"TagVolume vol-0 map[cost-centre:research]",
+	)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestSkipsMissingAndUnsupported(c *gc.C) {
+	s.tagger.errors["i-0"] = errors.NotFoundf("instance i-0")
+	s.tagger.errors["vol-0"] = errors.NotSupportedf("tagging volumes")
+	s.facade.targets.VolumeIds = []string{"vol-0", "vol-1"}
+	s.notify()
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCalls(c,
+		"TagInstance i-0 map[cost-centre:research]",
+		"TagInstance i-1 map[cost-centre:research]",
+		"TagVolume vol-0 map[cost-centre:research]",
+	)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestTagError(c *gc.C) {
+	s.tagger.errors["i-1"] = errors.New("boom")
+	s.notify()
+	w := s.startWorker(c)
+
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, `failed to tag 1 resource\(s\)`)
+}
+
+func (s *WorkerSuite) TestTargetsError(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	s.notify()
+	w := s.startWorker(c)
+
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeFacade struct {
+	mu         sync.Mutex
+	changes    chan struct{}
+	targets    apiresourcetagger.Targets
+	applied    map[string]string
+	err        error
+	appliedErr error
+}
+
+func (f *fakeFacade) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
+
+func (f *fakeFacade) ResourceTagTargets() (apiresourcetagger.Targets, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.targets, f.err
+}
+
+func (f *fakeFacade) AppliedResourceTags() (map[string]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.applied, f.appliedErr
+}
+
+func (f *fakeFacade) SetAppliedResourceTags(tags map[string]string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.appliedErr != nil {
+		return f.appliedErr
+	}
+	f.applied = tags
+	return nil
+}
+
+func (f *fakeFacade) appliedTags() map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.applied
+}
+
+func (f *fakeFacade) setTags(tags map[string]string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.targets.Tags = tags
+}
+
+func (s *WorkerSuite) TestRecordsAppliedTags(c *gc.C) {
+	s.notify()
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.waitCalls(c,
+		"TagInstance i-0 map[cost-centre:research]",
+		"TagInstance i-1 map[cost-centre:research]",
+		"TagVolume vol-0 map[cost-centre:research]",
+	)
+	c.Assert(s.facade.appliedTags(), jc.DeepEquals, map[string]string{"cost-centre": "research"})
+}
+
+func (s *WorkerSuite) TestTagsUnchangedSinceApplied(c *gc.C) {
+	s.facade.applied = map[string]string{"cost-centre": "research"}
+	s.notify()
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.waitCalls(c)
+}
+
+func (s *WorkerSuite) TestTagsRemovedSinceApplied(c *gc.C) {
+	s.facade.applied = map[string]string{"cost-centre": "research", "owner": "eve"}
+	s.notify()
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.waitCalls(c,
+		"TagInstance i-0 map[cost-centre:research]",
+		"RemoveInstanceTags i-0 [owner]",
+		"TagInstance i-1 map[cost-centre:research]",
+		"RemoveInstanceTags i-1 [owner]",
+		"TagVolume vol-0 map[cost-centre:research]",
+		"RemoveVolumeTags vol-0 [owner]",
+	)
+	c.Assert(s.facade.appliedTags(), jc.DeepEquals, map[string]string{"cost-centre": "research"})
+}
+
+func (s *WorkerSuite) TestAppliedTagsError(c *gc.C) {
+	s.facade.appliedErr = errors.New("boom")
+	s.notify()
+	w := s.startWorker(c)
+
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting applied resource tags: boom")
+}
+
+type fakeTagger struct {
+	calls  chan string
+	errors map[string]error
+}
+
+func (t *fakeTagger) TagInstance(_ context.ProviderCallContext, id instance.Id, tags map[string]string) error {
+	t.calls <- fmt.Sprintf("TagInstance %s %v", id, tags)
+	return t.errors[string(id)]
+}
+
+func (t *fakeTagger) TagVolume(_ context.ProviderCallContext, id string, tags map[string]string) error {
+	t.calls <- fmt.Sprintf("TagVolume %s %v", id, tags)
+	return t.errors[id]
+}
+
+func (t *fakeTagger) RemoveInstanceTags(_ context.ProviderCallContext, id instance.Id, names []string) error {
+	t.calls <- fmt.Sprintf("RemoveInstanceTags %s %v", id, names)
+	return t.errors[string(id)]
+}
+
+func (t *fakeTagger) RemoveVolumeTags(_ context.ProviderCallContext, id string, names []string) error {
+	t.calls <- fmt.Sprintf("RemoveVolumeTags %s %v", id, names)
+	return t.errors[id]
+}
+
+// tagger is implemented by the taggers given to the worker in tests.
+type tagger interface {
+	TagInstance(context.ProviderCallContext, instance.Id, map[string]string) error
+	TagVolume(context.ProviderCallContext, string, map[string]string) error
+}
+
+// tagOnly is a tagger that cannot remove tags.
+type tagOnly struct {
+	t *fakeTagger
+}
+
+func (t tagOnly) TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error {
+	return t.t.TagInstance(ctx, id, tags)
+}
+
+func (t tagOnly) TagVolume(ctx context.ProviderCallContext, id string, tags map[string]string) error {
+	return t.t.TagVolume(ctx, id, tags)
+}
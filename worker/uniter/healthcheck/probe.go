@@ -0,0 +1,136 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"runtime"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/healthcheck"
+)
+
+// Prober runs a single probe of a health check.
+type Prober interface {
+	// Probe returns nil if the check passed, or an error describing
+	// why it failed. The probe is abandoned if abort is closed.
+	Probe(check healthcheck.Check, abort <-chan struct{}) error
+}
+
+// ExecFunc runs a shell command with dir as its working directory,
+// returning its combined output. The command is abandoned when ctx is
+// done.
+type ExecFunc func(ctx context.Context, command, dir string) ([]byte, error)
+
+// NewProber returns a Prober that runs exec checks on the local
+// machine with dir as their working directory.
+func NewProber(dir string) Prober {
+	return &prober{dir: dir, exec: execLocal}
+}
+
+// NewRemoteProber returns a Prober for a workload that does not run
+// alongside the unit agent, such as a CAAS workload pod. Exec checks
+// are run with the supplied ExecFunc, so that they probe the workload
+// rather than the agent; if exec is nil, exec checks are rejected.
+func NewRemoteProber(dir string, exec ExecFunc) Prober {
+	return &prober{dir: dir, exec: exec}
+}
+
+type prober struct {
+	dir  string
+	exec ExecFunc
+}
+
+// Probe is part of the Prober interface.
+func (p *prober) Probe(check healthcheck.Check, abort <-chan struct{}) error {
+	check = check.WithDefaults()
+	ctx, cancel := context.WithTimeout(context.Background(), check.Timeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-abort:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	switch check.Kind {
+	case healthcheck.Exec:
+		return p.probeExec(ctx, check)
+	case healthcheck.HTTP:
+		return probeHTTP(ctx, check)
+	case healthcheck.TCP:
+		return probeTCP(ctx, check)
+	}
+	return errors.NotSupportedf("health check kind %q", check.Kind)
+}
+
+func (p *prober) probeExec(ctx context.Context, check healthcheck.Check) error {
+	if p.exec == nil {
+		return errors.NotSupportedf("exec health checks for this workload")
+	}
+	out, err := p.exec(ctx, check.Command, p.dir)
+	if ctx.Err() == context.DeadlineExceeded {
+		return errors.Errorf("command timed out after %v", check.Timeout)
+	}
+	if err != nil {
+		if len(out) > 0 {
+			return errors.Errorf("%v: %s", err, truncate(string(out)))
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func execLocal(ctx context.Context, command, dir string) ([]byte, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	}
+	cmd.Dir = dir
+	return cmd.CombinedOutput()
+}
+
+func probeHTTP(ctx context.Context, check healthcheck.Check) error {
+	req, err := http.NewRequest("GET", check.URL, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("GET %s returned %s", check.URL, resp.Status)
+	}
+	return nil
+}
+
+func probeTCP(ctx context.Context, check healthcheck.Check) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", check.Address)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return conn.Close()
+}
+
+// maxOutput is the number of bytes of exec probe output kept in
+// failure messages.
+const maxOutput = 256
+
+func truncate(s string) string {
+	if len(s) <= maxOutput {
+		return s
+	}
+	return fmt.Sprintf("%s...", s[:maxOutput])
+}
@@ -0,0 +1,46 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck_test
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corehealthcheck "github.com/juju/juju/core/healthcheck"
+	"github.com/juju/juju/worker/uniter/healthcheck"
+)
+
+type proberSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&proberSuite{})
+
+var execCheck = corehealthcheck.Check{
+	Name:    "daemon",
+	Kind:    corehealthcheck.Exec,
+	Command: "pgrep daemon",
+}
+
+func (s *proberSuite) TestRemoteExec(c *gc.C) {
+	var gotCommand, gotDir string
+	prober := healthcheck.NewRemoteProber("/charm", func(_ context.Context, command, dir string) ([]byte, error) {
+		gotCommand, gotDir = command, dir
+		return []byte("no such process\n"), errors.New("exit status 1")
+	})
+	err := prober.Probe(execCheck, nil)
+	c.Assert(err, gc.ErrorMatches, "exit status 1: no such process\n")
+	c.Assert(gotCommand, gc.Equals, "pgrep daemon")
+	c.Assert(gotDir, gc.Equals, "/charm")
+}
+
+func (s *proberSuite) TestRemoteExecNotSupported(c *gc.C) {
+	prober := healthcheck.NewRemoteProber("/charm", nil)
+	err := prober.Probe(execCheck, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
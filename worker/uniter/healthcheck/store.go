@@ -0,0 +1,67 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck
+
+import (
+	"os"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/core/healthcheck"
+)
+
+// Store persists the health checks registered by a unit's charm.
+type Store interface {
+	// Read returns the registered health checks, keyed by name.
+	Read() (map[string]healthcheck.Check, error)
+
+	// Write replaces the registered health checks.
+	Write(map[string]healthcheck.Check) error
+}
+
+var _ Store = (*DiskStore)(nil)
+
+// DiskStore stores the unit's health checks in a yaml file on disk.
+type DiskStore struct {
+	path string
+}
+
+// NewDiskStore returns a DiskStore that uses path for reading and
+// writing the unit's health checks.
+func NewDiskStore(path string) *DiskStore {
+	return &DiskStore{path: path}
+}
+
+// Read is part of the Store interface. A missing file is treated as
+// having no registered checks.
+func (s *DiskStore) Read() (map[string]healthcheck.Check, error) {
+	var checks []healthcheck.Check
+	if err := utils.ReadYaml(s.path, &checks); err != nil {
+		if os.IsNotExist(err) {
+			return map[string]healthcheck.Check{}, nil
+		}
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]healthcheck.Check, len(checks))
+	for _, check := range checks {
+		result[check.Name] = check
+	}
+	return result, nil
+}
+
+// Write is part of the Store interface.
+func (s *DiskStore) Write(checks map[string]healthcheck.Check) error {
+	if len(checks) == 0 {
+		if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+			return errors.Trace(err)
+		}
+		return nil
+	}
+	out := make([]healthcheck.Check, 0, len(checks))
+	for _, name := range sortedNames(checks) {
+		out = append(out, checks[name])
+	}
+	return errors.Trace(utils.WriteYaml(s.path, out))
+}
@@ -0,0 +1,410 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package healthcheck provides a worker that periodically probes the
+// workload health checks registered by a unit's charm, updating the
+// unit's workload status and signalling the uniter when the overall
+// health of the workload changes.
+package healthcheck
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/healthcheck"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/watcher"
+)
+
+// Logger represents the logging methods used in this package.
+type Logger interface {
+	Errorf(string, ...interface{})
+	Warningf(string, ...interface{})
+	Infof(string, ...interface{})
+	Debugf(string, ...interface{})
+}
+
+// UnitStatus provides access to the workload status of the unit
+// whose health is being checked.
+type UnitStatus interface {
+	UnitStatus() (params.StatusResult, error)
+	SetUnitStatus(unitStatus status.Status, info string, data map[string]interface{}) error
+}
+
+// Config holds the dependencies of a health check worker.
+type Config struct {
+	Store  Store
+	Prober Prober
+	Unit   UnitStatus
+	Clock  clock.Clock
+	Logger Logger
+}
+
+// Validate returns an error if the config cannot be used to start a worker.
+func (config Config) Validate() error {
+	if config.Store == nil {
+		return errors.NotValidf("nil Store")
+	}
+	if config.Prober == nil {
+		return errors.NotValidf("nil Prober")
+	}
+	if config.Unit == nil {
+		return errors.NotValidf("nil Unit")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// checkState tracks the probing of a single health check.
+type checkState struct {
+	check      healthcheck.Check
+	generation int
+	next       time.Time
+	running    bool
+	result     healthcheck.Result
+}
+
+type probeResult struct {
+	name       string
+	generation int
+	err        error
+}
+
+// Worker probes the unit's health checks at their configured intervals.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	reload  chan struct{}
+	changes chan struct{}
+
+	// The following fields are only accessed from the loop goroutine.
+	generation  int
+	savedStatus *params.StatusResult
+	lastMessage string
+
+	mu     sync.Mutex
+	checks map[string]*checkState
+	report healthcheck.Report
+}
+
+// NewWorker returns a Worker that probes the health checks held in
+// the configured store.
+func NewWorker(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config: config,
+		// Both channels are buffered so that signals are coalesced
+		// rather than blocking the sender.
+		reload:  make(chan struct{}, 1),
+		changes: make(chan struct{}, 1),
+		checks:  make(map[string]*checkState),
+		report:  healthcheck.Report{Status: healthcheck.Unknown},
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+// Changes returns a channel that is signalled whenever the workload
+// becomes unhealthy, or recovers from being unhealthy.
+func (w *Worker) Changes() watcher.NotifyChannel {
+	return w.changes
+}
+
+// Report returns the current health of the workload.
+func (w *Worker) Report() healthcheck.Report {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	report := healthcheck.Report{
+		Status:  w.report.Status,
+		Results: make(map[string]healthcheck.Result, len(w.report.Results)),
+	}
+	for name, result := range w.report.Results {
+		report.Results[name] = result
+	}
+	return report
+}
+
+// HealthChecks returns the registered health checks, keyed by name.
+func (w *Worker) HealthChecks() (map[string]healthcheck.Check, error) {
+	checks, err := w.config.Store.Read()
+	return checks, errors.Trace(err)
+}
+
+// SetHealthChecks replaces the registered health checks, and causes the
+// worker to start probing them.
+func (w *Worker) SetHealthChecks(checks map[string]healthcheck.Check) error {
+	for _, check := range checks {
+		if err := check.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := w.config.Store.Write(checks); err != nil {
+		return errors.Annotate(err, "writing health checks")
+	}
+	select {
+	case w.reload <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (w *Worker) loop() error {
+	if err := w.loadChecks(); err != nil {
+		return errors.Trace(err)
+	}
+	results := make(chan probeResult)
+	var timer clock.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	for {
+		var due <-chan time.Time
+		if timer != nil {
+			timer.Stop()
+			timer = nil
+		}
+		if next, ok := w.nextProbe(); ok {
+			timer = w.config.Clock.NewTimer(next.Sub(w.config.Clock.Now()))
+			due = timer.Chan()
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.reload:
+			if err := w.loadChecks(); err != nil {
+				return errors.Trace(err)
+			}
+		case <-due:
+			w.startDueProbes(results)
+		case result := <-results:
+			w.recordResult(result)
+		}
+		if err := w.updateReport(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// loadChecks reads the health checks from the store, keeping the state
+// of any check whose definition is unchanged.
+func (w *Worker) loadChecks() error {
+	checks, err := w.config.Store.Read()
+	if err != nil {
+		return errors.Annotate(err, "reading health checks")
+	}
+	now := w.config.Clock.Now()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for name := range w.checks {
+		if _, ok := checks[name]; !ok {
+			w.config.Logger.Debugf("removed health check %q", name)
+			delete(w.checks, name)
+		}
+	}
+	for name, check := range checks {
+		check = check.WithDefaults()
+		if existing, ok := w.checks[name]; ok && existing.check == check {
+			continue
+		}
+		w.config.Logger.Debugf("probing health check %q every %v", name, check.Interval)
+		w.generation++
+		w.checks[name] = &checkState{
+			check:      check,
+			generation: w.generation,
+			next:       now,
+			result:     healthcheck.Result{Status: healthcheck.Unknown},
+		}
+	}
+	return nil
+}
+
+// nextProbe returns the earliest time at which an idle check is due.
+func (w *Worker) nextProbe() (time.Time, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var next time.Time
+	var found bool
+	for _, state := range w.checks {
+		if state.running {
+			continue
+		}
+		if !found || state.next.Before(next) {
+			next, found = state.next, true
+		}
+	}
+	return next, found
+}
+
+func (w *Worker) startDueProbes(results chan<- probeResult) {
+	now := w.config.Clock.Now()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for name, state := range w.checks {
+		if state.running || state.next.After(now) {
+			continue
+		}
+		state.running = true
+		go func(name string, check healthcheck.Check, generation int) {
+			err := w.config.Prober.Probe(check, w.catacomb.Dying())
+			select {
+			case results <- probeResult{name: name, generation: generation, err: err}:
+			case <-w.catacomb.Dying():
+			}
+		}(name, state.check, state.generation)
+	}
+}
+
+func (w *Worker) recordResult(result probeResult) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	state, ok := w.checks[result.name]
+	if !ok || state.generation != result.generation {
+		// The check was changed or removed while being probed.
+		return
+	}
+	state.running = false
+	state.next = w.config.Clock.Now().Add(state.check.Interval)
+	if result.err == nil {
+		state.result = healthcheck.Result{Status: healthcheck.Healthy}
+		return
+	}
+	w.config.Logger.Debugf("health check %q failed: %v", result.name, result.err)
+	state.result.Failures++
+	state.result.Message = result.err.Error()
+	if state.result.Failures >= state.check.Threshold {
+		state.result.Status = healthcheck.Unhealthy
+	}
+}
+
+// updateReport recomputes the overall health of the workload, and acts
+// on any change into or out of the unhealthy state.
+func (w *Worker) updateReport() error {
+	w.mu.Lock()
+	report := healthcheck.Report{
+		Status:  healthcheck.Unknown,
+		Results: make(map[string]healthcheck.Result, len(w.checks)),
+	}
+	healthy := 0
+	for name, state := range w.checks {
+		report.Results[name] = state.result
+		switch state.result.Status {
+		case healthcheck.Unhealthy:
+			report.Status = healthcheck.Unhealthy
+		case healthcheck.Healthy:
+			healthy++
+		}
+	}
+	if report.Status != healthcheck.Unhealthy && healthy > 0 && healthy == len(w.checks) {
+		report.Status = healthcheck.Healthy
+	}
+	previous := w.report
+	w.report = report
+	w.mu.Unlock()
+
+	wasUnhealthy := previous.Status == healthcheck.Unhealthy
+	isUnhealthy := report.Status == healthcheck.Unhealthy
+	switch {
+	case isUnhealthy:
+		if err := w.setUnhealthyStatus(report.Failing()); err != nil {
+			return errors.Trace(err)
+		}
+	case wasUnhealthy:
+		if err := w.restoreStatus(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if wasUnhealthy != isUnhealthy {
+		w.config.Logger.Infof("workload health changed from %s to %s", previous.Status, report.Status)
+		select {
+		case w.changes <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// setUnhealthyStatus sets the unit's workload status to blocked, naming
+// the failing checks, remembering the status set by the charm so that
+// it can be restored when the workload recovers.
+func (w *Worker) setUnhealthyStatus(failing []string) error {
+	message := fmt.Sprintf("health check failing: %s", strings.Join(failing, ", "))
+	if message == w.lastMessage {
+		return nil
+	}
+	if w.savedStatus == nil {
+		current, err := w.config.Unit.UnitStatus()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		w.savedStatus = &current
+	}
+	if err := w.config.Unit.SetUnitStatus(status.Blocked, message, nil); err != nil {
+		return errors.Annotate(err, "setting unhealthy workload status")
+	}
+	w.lastMessage = message
+	return nil
+}
+
+// restoreStatus puts back the workload status that was replaced when the
+// workload became unhealthy, unless the charm has set a status since.
+func (w *Worker) restoreStatus() error {
+	saved := w.savedStatus
+	lastMessage := w.lastMessage
+	w.savedStatus, w.lastMessage = nil, ""
+	if saved == nil {
+		return nil
+	}
+	current, err := w.config.Unit.UnitStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if current.Status != string(status.Blocked) || current.Info != lastMessage {
+		return nil
+	}
+	err = w.config.Unit.SetUnitStatus(status.Status(saved.Status), saved.Info, saved.Data)
+	return errors.Annotate(err, "restoring workload status")
+}
+
+func sortedNames(checks map[string]healthcheck.Check) []string {
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
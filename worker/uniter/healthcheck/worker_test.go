@@ -0,0 +1,234 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck_test
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	corehealthcheck "github.com/juju/juju/core/healthcheck"
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/healthcheck"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	store  *healthcheck.DiskStore
+	prober *fakeProber
+	unit   *fakeUnit
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.store = healthcheck.NewDiskStore(filepath.Join(c.MkDir(), "health-checks.yaml"))
+	s.prober = &fakeProber{
+		calls:   make(chan string, 10),
+		results: make(chan error),
+	}
+	s.unit = &fakeUnit{
+		current: params.StatusResult{Status: "active", Info: "ready"},
+	}
+}
+
+func (s *workerSuite) newWorker(c *gc.C) *healthcheck.Worker {
+	w, err := healthcheck.NewWorker(healthcheck.Config{
+		Store:  s.store,
+		Prober: s.prober,
+		Unit:   s.unit,
+		Clock:  s.clock,
+		Logger: loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	return w
+}
+
+func (s *workerSuite) TestValidateConfig(c *gc.C) {
+	_, err := healthcheck.NewWorker(healthcheck.Config{})
+	c.Assert(err, gc.ErrorMatches, "nil Store not valid")
+}
+
+func (s *workerSuite) TestStoreRoundTrip(c *gc.C) {
+	checks, err := s.store.Read()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, gc.HasLen, 0)
+
+	daemon := corehealthcheck.Check{Name: "daemon", Kind: corehealthcheck.Exec, Command: "pgrep foo"}
+	err = s.store.Write(map[string]corehealthcheck.Check{"daemon": daemon})
+	c.Assert(err, jc.ErrorIsNil)
+	checks, err = s.store.Read()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, jc.DeepEquals, map[string]corehealthcheck.Check{"daemon": daemon})
+
+	err = s.store.Write(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	checks, err = s.store.Read()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, gc.HasLen, 0)
+}
+
+func (s *workerSuite) TestSetHealthChecksValidates(c *gc.C) {
+	w := s.newWorker(c)
+	err := w.SetHealthChecks(map[string]corehealthcheck.Check{
+		"daemon": {Name: "daemon", Kind: corehealthcheck.Exec},
+	})
+	c.Assert(err, gc.ErrorMatches, `exec health check "daemon" without command not valid`)
+}
+
+func (s *workerSuite) TestUnhealthyAfterThreshold(c *gc.C) {
+	w := s.newWorker(c)
+	err := w.SetHealthChecks(map[string]corehealthcheck.Check{
+		"daemon": {
+			Name:      "daemon",
+			Kind:      corehealthcheck.Exec,
+			Command:   "pgrep foo",
+			Interval:  10 * time.Second,
+			Threshold: 2,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The first failure is below the threshold.
+	s.prober.expectProbe(c, "daemon", errors.New("exit status 1"))
+	s.advance(c, 10*time.Second)
+	s.assertNoChange(c, w)
+	c.Assert(w.Report().Status, gc.Equals, corehealthcheck.Unknown)
+
+	// The second failure reaches it.
+	s.prober.expectProbe(c, "daemon", errors.New("exit status 1"))
+	s.assertChange(c, w)
+	report := w.Report()
+	c.Assert(report.Status, gc.Equals, corehealthcheck.Unhealthy)
+	c.Assert(report.Results["daemon"], jc.DeepEquals, corehealthcheck.Result{
+		Status:   corehealthcheck.Unhealthy,
+		Failures: 2,
+		Message:  "exit status 1",
+	})
+	c.Assert(s.unit.current, jc.DeepEquals, params.StatusResult{
+		Status: "blocked",
+		Info:   "health check failing: daemon",
+	})
+
+	// Recovery restores the status set by the charm.
+	s.advance(c, 10*time.Second)
+	s.prober.expectProbe(c, "daemon", nil)
+	s.assertChange(c, w)
+	c.Assert(w.Report().Status, gc.Equals, corehealthcheck.Healthy)
+	c.Assert(s.unit.current, jc.DeepEquals, params.StatusResult{
+		Status: "active",
+		Info:   "ready",
+	})
+}
+
+func (s *workerSuite) TestRemovingFailingCheckRecovers(c *gc.C) {
+	w := s.newWorker(c)
+	check := corehealthcheck.Check{
+		Name:      "web",
+		Kind:      corehealthcheck.HTTP,
+		URL:       "http://localhost:8080/",
+		Threshold: 1,
+	}
+	err := w.SetHealthChecks(map[string]corehealthcheck.Check{"web": check})
+	c.Assert(err, jc.ErrorIsNil)
+	s.prober.expectProbe(c, "web", errors.New("connection refused"))
+	s.assertChange(c, w)
+
+	err = w.SetHealthChecks(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertChange(c, w)
+	c.Assert(w.Report().Status, gc.Equals, corehealthcheck.Unknown)
+	c.Assert(s.unit.current.Status, gc.Equals, "active")
+}
+
+func (s *workerSuite) TestCharmStatusNotOverwrittenOnRecovery(c *gc.C) {
+	w := s.newWorker(c)
+	err := w.SetHealthChecks(map[string]corehealthcheck.Check{
+		"port": {Name: "port", Kind: corehealthcheck.TCP, Address: "localhost:80", Threshold: 1, Interval: time.Minute},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.prober.expectProbe(c, "port", errors.New("connection refused"))
+	s.assertChange(c, w)
+
+	// The charm reacts by setting its own status.
+	s.unit.current = params.StatusResult{Status: "maintenance", Info: "restarting"}
+
+	s.advance(c, time.Minute)
+	s.prober.expectProbe(c, "port", nil)
+	s.assertChange(c, w)
+	c.Assert(s.unit.current.Status, gc.Equals, "maintenance")
+}
+
+func (s *workerSuite) advance(c *gc.C, d time.Duration) {
+	err := s.clock.WaitAdvance(d, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *workerSuite) assertChange(c *gc.C, w *healthcheck.Worker) {
+	select {
+	case <-w.Changes():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for health change")
+	}
+}
+
+func (s *workerSuite) assertNoChange(c *gc.C, w *healthcheck.Worker) {
+	select {
+	case <-w.Changes():
+		c.Fatalf("unexpected health change")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type fakeProber struct {
+	calls   chan string
+	results chan error
+}
+
+func (p *fakeProber) Probe(check corehealthcheck.Check, abort <-chan struct{}) error {
+	p.calls <- check.Name
+	select {
+	case err := <-p.results:
+		return err
+	case <-abort:
+		return errors.New("aborted")
+	}
+}
+
+func (p *fakeProber) expectProbe(c *gc.C, name string, result error) {
+	select {
+	case called := <-p.calls:
+		c.Assert(called, gc.Equals, name)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for probe of %q", name)
+	}
+	p.results <- result
+}
+
+type fakeUnit struct {
+	current params.StatusResult
+}
+
+func (u *fakeUnit) UnitStatus() (params.StatusResult, error) {
+	return u.current, nil
+}
+
+func (u *fakeUnit) SetUnitStatus(unitStatus status.Status, info string, data map[string]interface{}) error {
+	u.current = params.StatusResult{Status: unitStatus.String(), Info: info, Data: data}
+	return nil
+}
@@ -16,6 +16,10 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+
+	// WorkloadHealthChanged is run when the workload health checks
+	// registered by the charm become unhealthy, or recover.
+	WorkloadHealthChanged hooks.Kind = "workload-health-changed"
 )
 
 // Info holds details required to execute a hook. Not all fields are
@@ -78,7 +82,7 @@ func (hi Info) Validate() error {
 		}
 		return nil
 	// TODO(fwereade): define these in charm/hooks...
-	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
+	case LeaderElected, LeaderDeposed, LeaderSettingsChanged, WorkloadHealthChanged:
 		return nil
 	}
 	return fmt.Errorf("unknown hook kind %q", hi.Kind)
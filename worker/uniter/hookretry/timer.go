@@ -0,0 +1,126 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package hookretry provides the timer used by the uniter to schedule
+// the automatic retry of failed hooks.
+package hookretry
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Status describes the progress of the automatic retry of a failed hook.
+type Status struct {
+	// Attempt is the number of the next retry attempt, starting from 1.
+	// It is zero if no retry has been scheduled.
+	Attempt int
+
+	// MaxAttempts is the number of retries that will be attempted
+	// before giving up; zero means that there is no limit.
+	MaxAttempts int
+
+	// Next is the time of the next retry attempt.
+	Next time.Time
+
+	// Exhausted is true if no more retries will be attempted.
+	Exhausted bool
+}
+
+// Timer schedules the retry of a failed hook, backing off between
+// attempts according to a retry strategy, and giving up once the
+// strategy's maximum number of attempts has been made.
+type Timer struct {
+	strategy params.RetryStrategy
+	clock    clock.Clock
+	retry    func()
+
+	mu      sync.Mutex
+	timer   clock.Timer
+	delay   time.Duration
+	attempt int
+	next    time.Time
+}
+
+// NewTimer returns a Timer that calls retry whenever a retry is due.
+func NewTimer(strategy params.RetryStrategy, clock clock.Clock, retry func()) *Timer {
+	return &Timer{
+		strategy: strategy,
+		clock:    clock,
+		retry:    retry,
+		delay:    strategy.MinRetryTime,
+	}
+}
+
+// Start schedules the next retry, unless the maximum number of retries
+// has already been scheduled. Any retry already scheduled is replaced.
+func (t *Timer) Start() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+	if t.exhausted() {
+		return
+	}
+	t.attempt++
+	t.next = t.clock.Now().Add(t.delay)
+	t.timer = t.clock.AfterFunc(t.delay, t.retry)
+	t.delay = t.nextDelay()
+}
+
+// Reset stops any scheduled retry, and resets the backoff and the count
+// of retry attempts. Start must be called to schedule a retry again.
+func (t *Timer) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+	t.delay = t.strategy.MinRetryTime
+	t.attempt = 0
+	t.next = time.Time{}
+}
+
+// Status reports the progress of the retries.
+func (t *Timer) Status() Status {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return Status{
+		Attempt:     t.attempt,
+		MaxAttempts: t.strategy.MaxRetryAttempts,
+		Next:        t.next,
+		Exhausted:   t.exhausted() && t.timer == nil,
+	}
+}
+
+func (t *Timer) exhausted() bool {
+	return t.strategy.MaxRetryAttempts > 0 && t.attempt >= t.strategy.MaxRetryAttempts
+}
+
+// nextDelay returns the delay to use after the current one, multiplied
+// by the strategy's factor and with a small amount of jitter if
+// required, but never more than the strategy's maximum.
+func (t *Timer) nextDelay() time.Duration {
+	factor := t.strategy.RetryTimeFactor
+	if factor < 1 {
+		factor = 1
+	}
+	next := time.Duration(int64(t.delay) * factor)
+	if t.strategy.JitterRetryTime {
+		// Vary the delay by up to 3% either way.
+		jitter := float64(next) * ((rand.Float64() * 2) - 1) * 0.03
+		next += time.Duration(jitter)
+	}
+	if next > t.strategy.MaxRetryTime {
+		next = t.strategy.MaxRetryTime
+	}
+	return next
+}
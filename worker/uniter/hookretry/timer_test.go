@@ -0,0 +1,107 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package hookretry_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/hookretry"
+)
+
+type TimerSuite struct {
+	coretesting.BaseSuite
+
+	clock   *testclock.Clock
+	retries chan struct{}
+}
+
+var _ = gc.Suite(&TimerSuite{})
+
+func (s *TimerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC))
+	s.retries = make(chan struct{}, 10)
+}
+
+func (s *TimerSuite) newTimer(maxAttempts int) *hookretry.Timer {
+	return hookretry.NewTimer(params.RetryStrategy{
+		ShouldRetry:      true,
+		MinRetryTime:     5 * time.Second,
+		MaxRetryTime:     15 * time.Second,
+		RetryTimeFactor:  2,
+		MaxRetryAttempts: maxAttempts,
+	}, s.clock, func() { s.retries <- struct{}{} })
+}
+
+func (s *TimerSuite) waitRetry(c *gc.C, delay time.Duration) {
+	err := s.clock.WaitAdvance(delay, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-s.retries:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for retry")
+	}
+}
+
+func (s *TimerSuite) TestBackoff(c *gc.C) {
+	timer := s.newTimer(0)
+	c.Assert(timer.Status(), jc.DeepEquals, hookretry.Status{})
+
+	start := s.clock.Now()
+	for i, delay := range []time.Duration{5 * time.Second, 10 * time.Second, 15 * time.Second, 15 * time.Second} {
+		timer.Start()
+		c.Assert(timer.Status(), jc.DeepEquals, hookretry.Status{
+			Attempt: i + 1,
+			Next:    s.clock.Now().Add(delay),
+		})
+		s.waitRetry(c, delay)
+	}
+	c.Assert(s.clock.Now(), gc.Equals, start.Add(45*time.Second))
+}
+
+func (s *TimerSuite) TestMaxAttempts(c *gc.C) {
+	timer := s.newTimer(2)
+
+	timer.Start()
+	s.waitRetry(c, 5*time.Second)
+	timer.Start()
+	status := timer.Status()
+	c.Assert(status.Attempt, gc.Equals, 2)
+	c.Assert(status.Exhausted, jc.IsFalse)
+	s.waitRetry(c, 10*time.Second)
+
+	timer.Start()
+	c.Assert(timer.Status(), jc.DeepEquals, hookretry.Status{
+		Attempt:     2,
+		MaxAttempts: 2,
+		Next:        status.Next,
+		Exhausted:   true,
+	})
+	c.Assert(s.clock.WaitAdvance(time.Hour, coretesting.ShortWait, 0), jc.ErrorIsNil)
+	select {
+	case <-s.retries:
+		c.Fatalf("unexpected retry")
+	default:
+	}
+}
+
+func (s *TimerSuite) TestReset(c *gc.C) {
+	timer := s.newTimer(2)
+
+	timer.Start()
+	s.waitRetry(c, 5*time.Second)
+	timer.Start()
+	timer.Reset()
+	c.Assert(timer.Status(), jc.DeepEquals, hookretry.Status{MaxAttempts: 2})
+
+	// The backoff starts again from the beginning.
+	timer.Start()
+	s.waitRetry(c, 5*time.Second)
+}
@@ -124,6 +124,10 @@ type StatePaths struct {
 	// MetricsSpoolDir acts as temporary storage for metrics being sent from
 	// the uniter to state.
 	MetricsSpoolDir string
+
+	// HealthChecksFile holds the workload health checks registered by
+	// the charm.
+	HealthChecksFile string
 }
 
 // SocketConfig specifies information for remote sockets.
@@ -193,11 +197,12 @@ func NewWorkerPaths(dataDir string, unitTag names.UnitTag, worker string, socket
 			LocalJujucServerSocket:  newUnixSocket(baseDir, unitTag, worker, "agent", true),
 		},
 		State: StatePaths{
-			BaseDir:         baseDir,
-			CharmDir:        join(baseDir, "charm"),
-			BundlesDir:      join(stateDir, "bundles"),
-			DeployerDir:     join(stateDir, "deployer"),
-			MetricsSpoolDir: join(stateDir, "spool", "metrics"),
+			BaseDir:          baseDir,
+			CharmDir:         join(baseDir, "charm"),
+			BundlesDir:       join(stateDir, "bundles"),
+			DeployerDir:      join(stateDir, "deployer"),
+			MetricsSpoolDir:  join(stateDir, "spool", "metrics"),
+			HealthChecksFile: join(stateDir, "health-checks.yaml"),
 		},
 	}
 }
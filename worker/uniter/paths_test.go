@@ -48,11 +48,12 @@ func (s *PathsSuite) TestWindows(c *gc.C) {
 			LocalJujucServerSocket: uniter.SocketPair{localJujucSocket, localJujucSocket},
 		},
 		State: uniter.StatePaths{
-			BaseDir:         relAgent(),
-			CharmDir:        relAgent("charm"),
-			BundlesDir:      relAgent("state", "bundles"),
-			DeployerDir:     relAgent("state", "deployer"),
-			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			BaseDir:          relAgent(),
+			CharmDir:         relAgent("charm"),
+			BundlesDir:       relAgent("state", "bundles"),
+			DeployerDir:      relAgent("state", "deployer"),
+			MetricsSpoolDir:  relAgent("state", "spool", "metrics"),
+			HealthChecksFile: relAgent("state", "health-checks.yaml"),
 		},
 	})
 }
@@ -77,11 +78,12 @@ func (s *PathsSuite) TestWorkerPathsWindows(c *gc.C) {
 			LocalJujucServerSocket: uniter.SocketPair{localJujucSocket, localJujucSocket},
 		},
 		State: uniter.StatePaths{
-			BaseDir:         relAgent(),
-			CharmDir:        relAgent("charm"),
-			BundlesDir:      relAgent("state", "bundles"),
-			DeployerDir:     relAgent("state", "deployer"),
-			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			BaseDir:          relAgent(),
+			CharmDir:         relAgent("charm"),
+			BundlesDir:       relAgent("state", "bundles"),
+			DeployerDir:      relAgent("state", "deployer"),
+			MetricsSpoolDir:  relAgent("state", "spool", "metrics"),
+			HealthChecksFile: relAgent("state", "health-checks.yaml"),
 		},
 	})
 }
@@ -106,11 +108,12 @@ func (s *PathsSuite) TestOther(c *gc.C) {
 			LocalJujucServerSocket: uniter.SocketPair{localJujucSocket, localJujucSocket},
 		},
 		State: uniter.StatePaths{
-			BaseDir:         relAgent(),
-			CharmDir:        relAgent("charm"),
-			BundlesDir:      relAgent("state", "bundles"),
-			DeployerDir:     relAgent("state", "deployer"),
-			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			BaseDir:          relAgent(),
+			CharmDir:         relAgent("charm"),
+			BundlesDir:       relAgent("state", "bundles"),
+			DeployerDir:      relAgent("state", "deployer"),
+			MetricsSpoolDir:  relAgent("state", "spool", "metrics"),
+			HealthChecksFile: relAgent("state", "health-checks.yaml"),
 		},
 	})
 }
@@ -146,11 +149,12 @@ func (s *PathsSuite) TestTCPRemote(c *gc.C) {
 			RemoteJujucServerSocket: uniter.SocketPair{remoteJujucServerSocket, remoteJujucClientSocket},
 		},
 		State: uniter.StatePaths{
-			BaseDir:         relAgent(),
-			CharmDir:        relAgent("charm"),
-			BundlesDir:      relAgent("state", "bundles"),
-			DeployerDir:     relAgent("state", "deployer"),
-			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			BaseDir:          relAgent(),
+			CharmDir:         relAgent("charm"),
+			BundlesDir:       relAgent("state", "bundles"),
+			DeployerDir:      relAgent("state", "deployer"),
+			MetricsSpoolDir:  relAgent("state", "spool", "metrics"),
+			HealthChecksFile: relAgent("state", "health-checks.yaml"),
 		},
 	})
 }
@@ -174,11 +178,12 @@ func (s *PathsSuite) TestWorkerPaths(c *gc.C) {
 			LocalJujucServerSocket: uniter.SocketPair{localJujucSocket, localJujucSocket},
 		},
 		State: uniter.StatePaths{
-			BaseDir:         relAgent(),
-			CharmDir:        relAgent("charm"),
-			BundlesDir:      relAgent("state", "bundles"),
-			DeployerDir:     relAgent("state", "deployer"),
-			MetricsSpoolDir: relAgent("state", "spool", "metrics"),
+			BaseDir:          relAgent(),
+			CharmDir:         relAgent("charm"),
+			BundlesDir:       relAgent("state", "bundles"),
+			DeployerDir:      relAgent("state", "deployer"),
+			MetricsSpoolDir:  relAgent("state", "spool", "metrics"),
+			HealthChecksFile: relAgent("state", "health-checks.yaml"),
 		},
 	})
 }
@@ -303,13 +303,14 @@ func (u *mockUnit) SetUpgradeSeriesStatus(status model.UpgradeSeriesStatus) erro
 }
 
 type mockApplication struct {
-	tag                   names.ApplicationTag
-	life                  life.Value
-	curl                  *charm.URL
-	charmModifiedVersion  int
-	forceUpgrade          bool
-	applicationWatcher    *mockNotifyWatcher
-	leaderSettingsWatcher *mockNotifyWatcher
+	tag                      names.ApplicationTag
+	life                     life.Value
+	curl                     *charm.URL
+	charmModifiedVersion     int
+	forceUpgrade             bool
+	updateStatusHookInterval time.Duration
+	applicationWatcher       *mockNotifyWatcher
+	leaderSettingsWatcher    *mockNotifyWatcher
 }
 
 func (s *mockApplication) CharmModifiedVersion() (int, error) {
@@ -320,6 +321,10 @@ func (s *mockApplication) CharmURL() (*charm.URL, bool, error) {
 	return s.curl, s.forceUpgrade, nil
 }
 
+func (s *mockApplication) UpdateStatusHookInterval() (time.Duration, error) {
+	return s.updateStatusHookInterval, nil
+}
+
 func (s *mockApplication) Life() life.Value {
 	return s.life
 }
@@ -74,6 +74,11 @@ type Snapshot struct {
 	// update-status hook is supposed to run.
 	UpdateStatusVersion int
 
+	// WorkloadHealthVersion increments each time the workload
+	// becomes unhealthy or recovers, and a workload-health-changed
+	// hook is supposed to run.
+	WorkloadHealthVersion int
+
 	// ActionsPending is the list of pending actions to
 	// be performed by this unit.
 	ActionsPending []string
@@ -73,6 +73,10 @@ type Application interface {
 	// WatchLeadershipSettings returns a watcher that fires when the leadership
 	// settings for this application change.
 	WatchLeadershipSettings() (watcher.NotifyWatcher, error)
+	// UpdateStatusHookInterval returns the update status hook interval
+	// configured for this application, or zero if the model's interval
+	// is to be used.
+	UpdateStatusHookInterval() (time.Duration, error)
 }
 
 type Relation interface {
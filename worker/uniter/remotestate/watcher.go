@@ -51,6 +51,7 @@ type RemoteStateWatcher struct {
 	applicationChannel            watcher.NotifyChannel
 	containerRunningStatusChannel watcher.NotifyChannel
 	containerRunningStatusFunc    ContainerRunningStatusFunc
+	workloadHealthChannel         watcher.NotifyChannel
 	canApplyCharmProfile          bool
 
 	catacomb catacomb.Catacomb
@@ -83,6 +84,7 @@ type WatcherConfig struct {
 	ApplicationChannel            watcher.NotifyChannel
 	ContainerRunningStatusChannel watcher.NotifyChannel
 	ContainerRunningStatusFunc    ContainerRunningStatusFunc
+	WorkloadHealthChannel         watcher.NotifyChannel
 	UnitTag                       names.UnitTag
 	ModelType                     model.ModelType
 	Logger                        Logger
@@ -124,6 +126,7 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		applicationChannel:            config.ApplicationChannel,
 		containerRunningStatusChannel: config.ContainerRunningStatusChannel,
 		containerRunningStatusFunc:    config.ContainerRunningStatusFunc,
+		workloadHealthChannel:         config.WorkloadHealthChannel,
 		modelType:                     config.ModelType,
 		logger:                        config.Logger,
 		canApplyCharmProfile:          config.CanApplyCharmProfile,
@@ -447,11 +450,25 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 		observedEvent(&seenLeadershipChange)
 	}
 
+	// The application's update status hook interval, if set, overrides
+	// the model's interval.
+	var modelUpdateStatusInterval, appUpdateStatusInterval time.Duration
 	var updateStatusInterval time.Duration
 	var updateStatusTimer <-chan time.Time
 	resetUpdateStatusTimer := func() {
 		updateStatusTimer = w.updateStatusChannel(updateStatusInterval).After()
 	}
+	updateStatusIntervalChanged := func() bool {
+		interval := modelUpdateStatusInterval
+		if appUpdateStatusInterval > 0 {
+			interval = appUpdateStatusInterval
+		}
+		if interval == updateStatusInterval {
+			return false
+		}
+		updateStatusInterval = interval
+		return true
+	}
 
 	for {
 		select {
@@ -531,6 +548,18 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			w.trustHashChanged(hashes[0])
 			observedEvent(&seenTrustConfigChange)
 
+			// The application config holds the application's update
+			// status hook interval.
+			var err error
+			appUpdateStatusInterval, err = w.application.UpdateStatusHookInterval()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if updateStatusTimer != nil && updateStatusIntervalChanged() {
+				w.logger.Debugf("update status interval changed to %v", updateStatusInterval)
+				resetUpdateStatusTimer()
+			}
+
 		case _, ok := <-upgradeSeriesChanges:
 			w.logger.Debugf("got upgrade series change")
 			if !ok {
@@ -598,10 +627,11 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			observedEvent(&seenUpdateStatusIntervalChange)
 
 			var err error
-			updateStatusInterval, err = w.st.UpdateStatusHookInterval()
+			modelUpdateStatusInterval, err = w.st.UpdateStatusHookInterval()
 			if err != nil {
 				return errors.Trace(err)
 			}
+			updateStatusIntervalChanged()
 			wasActive := updateStatusTimer != nil
 			resetUpdateStatusTimer()
 			if wasActive {
@@ -651,6 +681,13 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			}
 			w.logger.Debugf("retry hook timer triggered")
 			w.retryHookTimerTriggered()
+
+		case _, ok := <-w.workloadHealthChannel:
+			if !ok {
+				return errors.New("workloadHealthChannel closed")
+			}
+			w.logger.Debugf("got workload health change")
+			w.workloadHealthChanged()
 		}
 
 		// Something changed.
@@ -711,6 +748,14 @@ func (w *RemoteStateWatcher) retryHookTimerTriggered() {
 	w.mu.Unlock()
 }
 
+// workloadHealthChanged is called when the workload becomes unhealthy,
+// or recovers.
+func (w *RemoteStateWatcher) workloadHealthChanged() {
+	w.mu.Lock()
+	w.current.WorkloadHealthVersion++
+	w.mu.Unlock()
+}
+
 // unitChanged responds to changes in the unit.
 func (w *RemoteStateWatcher) unitChanged() error {
 	if err := w.unit.Refresh(); err != nil {
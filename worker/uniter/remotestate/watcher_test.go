@@ -30,9 +30,10 @@ type WatcherSuite struct {
 	watcher    *remotestate.RemoteStateWatcher
 	clock      *testclock.Clock
 
-	applicationWatcher   *mockNotifyWatcher
-	runningStatusWatcher *mockNotifyWatcher
-	running              *remotestate.ContainerRunningStatus
+	applicationWatcher    *mockNotifyWatcher
+	runningStatusWatcher  *mockNotifyWatcher
+	workloadHealthWatcher *mockNotifyWatcher
+	running               *remotestate.ContainerRunningStatus
 }
 
 type WatcherSuiteIAAS struct {
@@ -85,6 +86,7 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 	}
 
 	s.clock = testclock.NewClock(time.Now())
+	s.workloadHealthWatcher = newMockNotifyWatcher()
 }
 
 func (s *WatcherSuiteIAAS) SetUpTest(c *gc.C) {
@@ -124,13 +126,14 @@ func (s *WatcherSuite) setupWatcherConfig() remotestate.WatcherConfig {
 		return dummyWaiter{s.clock.After(wait)}
 	}
 	return remotestate.WatcherConfig{
-		Logger:               loggo.GetLogger("test"),
-		State:                s.st,
-		ModelType:            s.modelType,
-		LeadershipTracker:    s.leadership,
-		UnitTag:              s.st.unit.tag,
-		UpdateStatusChannel:  statusTicker,
-		CanApplyCharmProfile: s.modelType == model.IAAS,
+		Logger:                loggo.GetLogger("test"),
+		State:                 s.st,
+		ModelType:             s.modelType,
+		LeadershipTracker:     s.leadership,
+		UnitTag:               s.st.unit.tag,
+		UpdateStatusChannel:   statusTicker,
+		WorkloadHealthChannel: s.workloadHealthWatcher.Changes(),
+		CanApplyCharmProfile:  s.modelType == model.IAAS,
 	}
 }
 
@@ -839,6 +842,51 @@ func (s *WatcherSuite) TestUpdateStatusIntervalChanges(c *gc.C) {
 	c.Assert(s.watcher.Snapshot().UpdateStatusVersion, gc.Equals, initial.UpdateStatusVersion+2)
 }
 
+func (s *WatcherSuite) TestApplicationUpdateStatusInterval(c *gc.C) {
+	s.signalAll()
+	initial := s.watcher.Snapshot()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	// Override the model's interval for the application.
+	s.st.unit.application.updateStatusHookInterval = 30 * time.Second
+	s.st.unit.applicationConfigSettingsWatcher.changes <- []string{"trusthash2"}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	s.waitAlarmsStable(c)
+	s.clock.Advance(30 * time.Second)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().UpdateStatusVersion, gc.Equals, initial.UpdateStatusVersion+1)
+
+	// A change to the model's interval does not affect the application.
+	s.st.updateStatusInterval = 10 * time.Minute
+	s.st.updateStatusIntervalWatcher.changes <- struct{}{}
+	s.waitAlarmsStable(c)
+	s.clock.Advance(30 * time.Second)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().UpdateStatusVersion, gc.Equals, initial.UpdateStatusVersion+2)
+
+	// Removing the override reverts to the model's interval.
+	s.st.unit.application.updateStatusHookInterval = 0
+	s.st.unit.applicationConfigSettingsWatcher.changes <- []string{"trusthash3"}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	s.waitAlarmsStable(c)
+	s.clock.Advance(30 * time.Second)
+	assertNoNotifyEvent(c, s.watcher.RemoteStateChanged(), "unexpected remote state change")
+	s.clock.Advance(10 * time.Minute)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().UpdateStatusVersion, gc.Equals, initial.UpdateStatusVersion+3)
+}
+
+func (s *WatcherSuite) TestWorkloadHealthChanged(c *gc.C) {
+	s.signalAll()
+	initial := s.watcher.Snapshot()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	s.workloadHealthWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().WorkloadHealthVersion, gc.Equals, initial.WorkloadHealthVersion+1)
+}
+
 // waitAlarmsStable is used to wait until the remote watcher's loop has
 // stopped churning (at least for testing.ShortWait), so that we can
 // then Advance the clock with some confidence that the SUT really is
@@ -250,6 +250,11 @@ func (s *uniterResolver) nextOpHookError(
 			// cleared so that we'll still start it again.
 			s.config.StartRetryHookTimer()
 			s.retryHookTimerStarted = true
+			// Report the error again so that the status shows
+			// when the hook will next be retried.
+			if err := s.config.ReportHookError(*localState.Hook); err != nil {
+				return nil, errors.Trace(err)
+			}
 		}
 		return nil, resolver.ErrNoOperation
 	case params.ResolvedRetryHooks:
@@ -347,6 +352,10 @@ func (s *uniterResolver) nextOp(
 		return op, err
 	}
 
+	if localState.WorkloadHealthVersion != remoteState.WorkloadHealthVersion {
+		return opFactory.NewRunHook(hook.Info{Kind: hook.WorkloadHealthChanged})
+	}
+
 	// UpdateStatus hook runs if nothing else needs to.
 	if localState.UpdateStatusVersion != remoteState.UpdateStatusVersion {
 		return opFactory.NewRunHook(hook.Info{Kind: hooks.UpdateStatus})
@@ -78,6 +78,11 @@ type LocalState struct {
 	// for which an update-status hook has been committed.
 	UpdateStatusVersion int
 
+	// WorkloadHealthVersion is the version of workload health from
+	// remotestate.Snapshot for which a workload-health-changed hook
+	// has been committed.
+	WorkloadHealthVersion int
+
 	// RetryHookVersion is the version of hook-retries from
 	// remotestate.Snapshot for which a hook has been retried.
 	RetryHookVersion int
@@ -176,6 +176,11 @@ func (s *resolverOpFactory) wrapHookOp(op operation.Operation, info hook.Info) o
 		op = onCommitWrapper{op, func(*operation.State) {
 			s.LocalState.LeaderSettingsVersion = v
 		}}
+	case hook.WorkloadHealthChanged:
+		v := s.RemoteState.WorkloadHealthVersion
+		op = onCommitWrapper{op, func(*operation.State) {
+			s.LocalState.WorkloadHealthVersion = v
+		}}
 	}
 
 	charmModifiedVersion := s.RemoteState.CharmModifiedVersion
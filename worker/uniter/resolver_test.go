@@ -193,6 +193,28 @@ func (s *iaasResolverSuite) TestPostSeriesUpgradeHookRunsWhenConditionsAreMet(c
 	c.Assert(op.String(), gc.Equals, "run post-series-upgrade hook")
 }
 
+func (s *resolverSuite) TestWorkloadHealthChangedHookRunsBeforeUpdateStatus(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmURL: s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.WorkloadHealthVersion = 1
+	s.remoteState.UpdateStatusVersion = 1
+
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run workload-health-changed hook")
+
+	localState.WorkloadHealthVersion = 1
+	op, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run update-status hook")
+}
+
 func (s *iaasResolverSuite) TestRunsOperationToResetLocalUpgradeSeriesStateWhenConditionsAreMet(c *gc.C) {
 	localState := resolver.LocalState{
 		CharmURL:            s.charmURL,
@@ -270,6 +292,30 @@ func (s *resolverSuite) TestHookErrorStartRetryTimer(c *gc.C) {
 	s.stub.CheckCallNames(c, "StartRetryHookTimer") // no change
 }
 
+func (s *resolverSuite) TestHookErrorReportedAgainAfterStartRetryTimer(c *gc.C) {
+	s.reportHookError = func(hook.Info) error {
+		s.stub.AddCall("ReportHookError")
+		return nil
+	}
+	localState := resolver.LocalState{
+		CharmURL: s.charmURL,
+		State: operation.State{
+			Kind:      operation.RunHook,
+			Step:      operation.Pending,
+			Installed: true,
+			Started:   true,
+			Hook: &hook.Info{
+				Kind: hooks.ConfigChanged,
+			},
+		},
+	}
+	// The error is reported again once the retry has been scheduled,
+	// so that the status shows when it will happen.
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCallNames(c, "ReportHookError", "StartRetryHookTimer", "ReportHookError")
+}
+
 func (s *resolverSuite) TestHookErrorStartRetryTimerAgain(c *gc.C) {
 	s.reportHookError = func(hook.Info) error { return nil }
 	localState := resolver.LocalState{
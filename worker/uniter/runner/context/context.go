@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/caas"
 	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
 	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/healthcheck"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/quota"
@@ -305,6 +306,20 @@ type HookContext struct {
 	// A flag that keeps track of whether the unit's state has been mutated.
 	charmStateCacheDirty bool
 
+	// healthChecks provides access to the unit's workload health checks.
+	healthChecks HealthCheckRegistry
+
+	// A cached view of the unit's health checks that gets registered with
+	// the health check worker once the context is flushed.
+	cachedHealthChecks map[string]healthcheck.Check
+
+	// A flag that keeps track of whether the health checks have been mutated.
+	healthChecksDirty bool
+
+	// workloadHealth is the health of the workload when running the
+	// workload-health-changed hook.
+	workloadHealth *healthcheck.Report
+
 	mu sync.Mutex
 }
 
@@ -1008,6 +1023,12 @@ func (ctx *HookContext) HookVars(paths Paths, remote bool, getEnv GetEnvFunc) ([
 		)
 
 	}
+	if ctx.workloadHealth != nil {
+		vars = append(vars,
+			"JUJU_WORKLOAD_HEALTH="+string(ctx.workloadHealth.Status),
+			"JUJU_HEALTH_CHECKS_FAILING="+strings.Join(ctx.workloadHealth.Failing(), " "),
+		)
+	}
 	if r, err := ctx.HookRelation(); err == nil {
 		vars = append(vars,
 			"JUJU_RELATION="+r.Name(),
@@ -1150,7 +1171,7 @@ func (ctx *HookContext) doFlush(process string) error {
 
 	// Call completed successfully; update local state
 	ctx.charmStateCacheDirty = false
-	return nil
+	return ctx.flushHealthChecks()
 }
 
 // If we're running the upgrade-charm hook and no podspec update was done,
@@ -81,6 +81,9 @@ type contextFactory struct {
 	zone       string
 	principal  string
 
+	// healthChecks provides access to the unit's workload health checks.
+	healthChecks HealthCheckRegistry
+
 	// Callback to get relation state snapshot.
 	getRelationInfos RelationsFunc
 	relationCaches   map[int]*RelationCache
@@ -97,6 +100,7 @@ type FactoryConfig struct {
 	Tracker          leadership.Tracker
 	GetRelationInfos RelationsFunc
 	Storage          StorageContextAccessor
+	HealthChecks     HealthCheckRegistry
 	Paths            Paths
 	Clock            Clock
 	Logger           loggo.Logger
@@ -143,6 +147,7 @@ func NewContextFactory(config FactoryConfig) (ContextFactory, error) {
 		getRelationInfos: config.GetRelationInfos,
 		relationCaches:   map[int]*RelationCache{},
 		storage:          config.Storage,
+		healthChecks:     config.HealthChecks,
 		rand:             rand.New(rand.NewSource(time.Now().Unix())),
 		clock:            config.Clock,
 		zone:             zone,
@@ -178,6 +183,7 @@ func (f *contextFactory) coreContext() (*HookContext, error) {
 		relationId:         -1,
 		pendingPorts:       make(map[PortRange]PortRangeInfo),
 		storage:            f.storage,
+		healthChecks:       f.healthChecks,
 		clock:              f.clock,
 		logger:             f.logger,
 		componentDir:       f.paths.ComponentDir,
@@ -242,6 +248,10 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", storageName, hookName)
 	}
+	if hookInfo.Kind == hook.WorkloadHealthChanged && f.healthChecks != nil {
+		report := f.healthChecks.Report()
+		ctx.workloadHealth = &report
+	}
 	ctx.id = f.newId(hookName)
 	ctx.hookName = hookName
 	return ctx, nil
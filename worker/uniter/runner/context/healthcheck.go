@@ -0,0 +1,112 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/healthcheck"
+)
+
+// HealthCheckRegistry provides access to the workload health checks
+// registered for the unit, and their current results.
+type HealthCheckRegistry interface {
+	// HealthChecks returns the registered health checks, keyed by name.
+	HealthChecks() (map[string]healthcheck.Check, error)
+
+	// SetHealthChecks replaces the registered health checks.
+	SetHealthChecks(map[string]healthcheck.Check) error
+
+	// Report returns the current health of the workload.
+	Report() healthcheck.Report
+}
+
+// HealthChecks returns a copy of the cached health checks.
+// Implements jujuc.HookContext.unitHealthCheckContext, part of runner.Context.
+func (ctx *HookContext) HealthChecks() (map[string]healthcheck.Check, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if err := ctx.ensureHealthChecksLoaded(); err != nil {
+		return nil, err
+	}
+
+	retVal := make(map[string]healthcheck.Check, len(ctx.cachedHealthChecks))
+	for k, v := range ctx.cachedHealthChecks {
+		retVal[k] = v
+	}
+	return retVal, nil
+}
+
+// SetHealthCheck adds or replaces the supplied health check in the cache.
+// Implements jujuc.HookContext.unitHealthCheckContext, part of runner.Context.
+func (ctx *HookContext) SetHealthCheck(check healthcheck.Check) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if err := ctx.ensureHealthChecksLoaded(); err != nil {
+		return err
+	}
+	if err := check.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if cur, exists := ctx.cachedHealthChecks[check.Name]; exists && cur == check {
+		return nil // no-op
+	}
+	ctx.cachedHealthChecks[check.Name] = check
+	ctx.healthChecksDirty = true
+	return nil
+}
+
+// DeleteHealthCheck removes the named health check from the cache.
+// Implements jujuc.HookContext.unitHealthCheckContext, part of runner.Context.
+func (ctx *HookContext) DeleteHealthCheck(name string) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if err := ctx.ensureHealthChecksLoaded(); err != nil {
+		return err
+	}
+
+	if _, exists := ctx.cachedHealthChecks[name]; !exists {
+		return nil // no-op
+	}
+	delete(ctx.cachedHealthChecks, name)
+	ctx.healthChecksDirty = true
+	return nil
+}
+
+// ensureHealthChecksLoaded retrieves and caches the unit's health checks.
+// The caller of this method must be holding the ctx mutex.
+func (ctx *HookContext) ensureHealthChecksLoaded() error {
+	// NOTE: Assuming lock to be held!
+	if ctx.cachedHealthChecks != nil {
+		return nil
+	}
+	if ctx.healthChecks == nil {
+		return errors.NotSupportedf("workload health checks")
+	}
+
+	checks, err := ctx.healthChecks.HealthChecks()
+	if err != nil {
+		return errors.Annotate(err, "loading health checks")
+	}
+	if checks == nil {
+		checks = make(map[string]healthcheck.Check)
+	}
+	ctx.cachedHealthChecks = checks
+	ctx.healthChecksDirty = false
+	return nil
+}
+
+// flushHealthChecks registers any changed health checks with the unit's
+// health check worker.
+func (ctx *HookContext) flushHealthChecks() error {
+	if !ctx.healthChecksDirty {
+		return nil
+	}
+	if err := ctx.healthChecks.SetHealthChecks(ctx.cachedHealthChecks); err != nil {
+		return errors.Annotate(err, "cannot register health checks")
+	}
+	ctx.healthChecksDirty = false
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/healthcheck"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/relation"
@@ -29,6 +30,7 @@ type Context interface {
 	relationHookContext
 	actionHookContext
 	unitCharmStateContext
+	unitHealthCheckContext
 }
 
 // HookContext represents the information and functionality that is
@@ -114,6 +116,21 @@ type unitCharmStateContext interface {
 	SetCharmStateValue(string, string) error
 }
 
+// unitHealthCheckContext provides helpers for registering the workload
+// health checks that are probed by the unit agent.
+type unitHealthCheckContext interface {
+	// HealthChecks returns the health checks registered for the unit,
+	// keyed by name.
+	HealthChecks() (map[string]healthcheck.Check, error)
+
+	// SetHealthCheck registers the supplied health check, replacing
+	// any existing check with the same name.
+	SetHealthCheck(healthcheck.Check) error
+
+	// DeleteHealthCheck removes the named health check.
+	DeleteHealthCheck(string) error
+}
+
 // ContextUnit is the part of a hook context related to the unit.
 type ContextUnit interface {
 	// UnitName returns the executing unit's name.
@@ -0,0 +1,59 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+)
+
+// HealthCheckDeleteCommand implements the health-check-delete command.
+type HealthCheckDeleteCommand struct {
+	cmd.CommandBase
+	ctx  Context
+	name string
+}
+
+// NewHealthCheckDeleteCommand returns a health-check-delete command.
+func NewHealthCheckDeleteCommand(ctx Context) (cmd.Command, error) {
+	return &HealthCheckDeleteCommand{ctx: ctx}, nil
+}
+
+// Info returns information about the Command.
+// Info implements part of the cmd.Command interface.
+func (c *HealthCheckDeleteCommand) Info() *cmd.Info {
+	doc := `
+health-check-delete removes the named workload health check. Deleting a
+check that does not exist is not an error.
+
+See also:
+    health-check-get
+    health-check-set
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "health-check-delete",
+		Args:    "<name>",
+		Purpose: "remove a workload health check",
+		Doc:     doc,
+	})
+}
+
+// Init initializes the Command before running.
+// Init implements part of the cmd.Command interface.
+func (c *HealthCheckDeleteCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no health check name specified")
+	}
+	c.name = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run will execute the Command as directed by the options and positional
+// arguments passed to Init.
+// Run implements part of the cmd.Command interface.
+func (c *HealthCheckDeleteCommand) Run(_ *cmd.Context) error {
+	return c.ctx.DeleteHealthCheck(c.name)
+}
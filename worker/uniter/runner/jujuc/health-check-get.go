@@ -0,0 +1,107 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/healthcheck"
+)
+
+// HealthCheckGetCommand implements the health-check-get command.
+type HealthCheckGetCommand struct {
+	cmd.CommandBase
+	ctx  Context
+	out  cmd.Output
+	name string
+}
+
+// NewHealthCheckGetCommand returns a health-check-get command.
+func NewHealthCheckGetCommand(ctx Context) (cmd.Command, error) {
+	return &HealthCheckGetCommand{ctx: ctx}, nil
+}
+
+// Info returns information about the Command.
+// Info implements part of the cmd.Command interface.
+func (c *HealthCheckGetCommand) Info() *cmd.Info {
+	doc := `
+health-check-get prints the definition of the named workload health check.
+If no name is given, all registered checks are printed.
+
+See also:
+    health-check-delete
+    health-check-set
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "health-check-get",
+		Args:    "[<name>]",
+		Purpose: "print workload health checks",
+		Doc:     doc,
+	})
+}
+
+// SetFlags adds command specific flags to the flag set.
+// SetFlags implements part of the cmd.Command interface.
+func (c *HealthCheckGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters.Formatters())
+}
+
+// Init initializes the Command before running.
+// Init implements part of the cmd.Command interface.
+func (c *HealthCheckGetCommand) Init(args []string) error {
+	if len(args) > 0 {
+		c.name = args[0]
+		args = args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// healthCheckInfo is the printed form of a health check.
+type healthCheckInfo struct {
+	Kind      string `yaml:"kind" json:"kind"`
+	Command   string `yaml:"command,omitempty" json:"command,omitempty"`
+	URL       string `yaml:"url,omitempty" json:"url,omitempty"`
+	Address   string `yaml:"address,omitempty" json:"address,omitempty"`
+	Interval  string `yaml:"interval" json:"interval"`
+	Timeout   string `yaml:"timeout" json:"timeout"`
+	Threshold int    `yaml:"threshold" json:"threshold"`
+}
+
+func newHealthCheckInfo(check healthcheck.Check) healthCheckInfo {
+	check = check.WithDefaults()
+	return healthCheckInfo{
+		Kind:      string(check.Kind),
+		Command:   check.Command,
+		URL:       check.URL,
+		Address:   check.Address,
+		Interval:  check.Interval.String(),
+		Timeout:   check.Timeout.String(),
+		Threshold: check.Threshold,
+	}
+}
+
+// Run will execute the Command as directed by the options and positional
+// arguments passed to Init.
+// Run implements part of the cmd.Command interface.
+func (c *HealthCheckGetCommand) Run(ctx *cmd.Context) error {
+	checks, err := c.ctx.HealthChecks()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.name != "" {
+		check, ok := checks[c.name]
+		if !ok {
+			return errors.NotFoundf("health check %q", c.name)
+		}
+		return c.out.Write(ctx, newHealthCheckInfo(check))
+	}
+	out := make(map[string]healthCheckInfo, len(checks))
+	for name, check := range checks {
+		out[name] = newHealthCheckInfo(check)
+	}
+	return c.out.Write(ctx, out)
+}
@@ -0,0 +1,142 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/healthcheck"
+)
+
+// HealthCheckSetCommand implements the health-check-set command.
+type HealthCheckSetCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	name      string
+	exec      string
+	http      string
+	tcp       string
+	interval  time.Duration
+	timeout   time.Duration
+	threshold int
+}
+
+// NewHealthCheckSetCommand returns a health-check-set command.
+func NewHealthCheckSetCommand(ctx Context) (cmd.Command, error) {
+	return &HealthCheckSetCommand{ctx: ctx}, nil
+}
+
+// Info returns information about the Command.
+// Info implements part of the cmd.Command interface.
+func (c *HealthCheckSetCommand) Info() *cmd.Info {
+	doc := `
+health-check-set registers a workload health check with the unit agent,
+replacing any existing check with the same name. Exactly one of --exec,
+--http or --tcp must be supplied.
+
+The unit agent probes each check every interval, without running a hook.
+A check becomes unhealthy after threshold consecutive failed probes, and
+healthy again after a single successful probe. While any check is
+unhealthy the unit's workload status is set to blocked; the previous
+status is restored when all checks recover, unless the charm has set a
+status in the meantime.
+
+The workload-health-changed hook is run whenever the workload becomes
+unhealthy or recovers. The JUJU_WORKLOAD_HEALTH environment variable
+holds the new health, and JUJU_HEALTH_CHECKS_FAILING holds a
+space-separated list of the failing checks.
+
+Changes take effect when the current hook completes successfully.
+
+Defaults:
+    --interval %v
+    --timeout %v
+    --threshold %d
+
+Examples:
+    health-check-set daemon --exec "systemctl is-active foo"
+    health-check-set web --http http://localhost:8080/health --interval 1m
+    health-check-set db --tcp localhost:5432 --threshold 1
+
+See also:
+    health-check-delete
+    health-check-get
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "health-check-set",
+		Args:    "<name> (--exec <command> | --http <url> | --tcp <host:port>)",
+		Purpose: "register a workload health check",
+		Doc: fmt.Sprintf(
+			doc,
+			healthcheck.DefaultInterval,
+			healthcheck.DefaultTimeout,
+			healthcheck.DefaultThreshold,
+		),
+	})
+}
+
+// SetFlags adds command specific flags to the flag set.
+// SetFlags implements part of the cmd.Command interface.
+func (c *HealthCheckSetCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.exec, "exec", "", "shell command that exits zero when healthy")
+	f.StringVar(&c.http, "http", "", "URL that responds to GET with a 2xx or 3xx status when healthy")
+	f.StringVar(&c.tcp, "tcp", "", "host:port that accepts connections when healthy")
+	f.DurationVar(&c.interval, "interval", 0, "time between probes")
+	f.DurationVar(&c.timeout, "timeout", 0, "time a probe may take before failing")
+	f.IntVar(&c.threshold, "threshold", 0, "consecutive failed probes before the check is unhealthy")
+}
+
+// Init initializes the Command before running.
+// Init implements part of the cmd.Command interface.
+func (c *HealthCheckSetCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no health check name specified")
+	}
+	c.name = args[0]
+	kinds := 0
+	for _, v := range []string{c.exec, c.http, c.tcp} {
+		if v != "" {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return errors.New("exactly one of --exec, --http or --tcp must be specified")
+	}
+	if err := c.check().Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *HealthCheckSetCommand) check() healthcheck.Check {
+	check := healthcheck.Check{
+		Name:      c.name,
+		Interval:  c.interval,
+		Timeout:   c.timeout,
+		Threshold: c.threshold,
+	}
+	switch {
+	case c.exec != "":
+		check.Kind, check.Command = healthcheck.Exec, c.exec
+	case c.http != "":
+		check.Kind, check.URL = healthcheck.HTTP, c.http
+	case c.tcp != "":
+		check.Kind, check.Address = healthcheck.TCP, c.tcp
+	}
+	return check
+}
+
+// Run will execute the Command as directed by the options and positional
+// arguments passed to Init.
+// Run implements part of the cmd.Command interface.
+func (c *HealthCheckSetCommand) Run(_ *cmd.Context) error {
+	return c.ctx.SetHealthCheck(c.check())
+}
@@ -0,0 +1,128 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/healthcheck"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type HealthCheckSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&HealthCheckSuite{})
+
+func (s *HealthCheckSuite) run(c *gc.C, hctx *Context, name string, args ...string) (int, *cmd.Context) {
+	com, err := jujuc.NewCommand(hctx, cmdString(name))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, args)
+	return code, ctx
+}
+
+func (s *HealthCheckSuite) TestSet(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	code, ctx := s.run(c, hctx, "health-check-set",
+		"web", "--http", "http://localhost:8080/health", "--interval", "1m", "--threshold", "2")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.HealthChecks.Checks, jc.DeepEquals, map[string]healthcheck.Check{
+		"web": {
+			Name:      "web",
+			Kind:      healthcheck.HTTP,
+			URL:       "http://localhost:8080/health",
+			Interval:  time.Minute,
+			Threshold: 2,
+		},
+	})
+}
+
+func (s *HealthCheckSuite) TestSetInvalid(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no health check name specified",
+	}, {
+		args: []string{"daemon"},
+		err:  "exactly one of --exec, --http or --tcp must be specified",
+	}, {
+		args: []string{"daemon", "--exec", "true", "--tcp", "localhost:80"},
+		err:  "exactly one of --exec, --http or --tcp must be specified",
+	}, {
+		args: []string{"daemon", "--tcp", "localhost"},
+		err:  `tcp health check "daemon" address "localhost" not valid`,
+	}, {
+		args: []string{"Daemon", "--exec", "true"},
+		err:  `health check name "Daemon" not valid`,
+	}, {
+		args: []string{"daemon", "--exec", "true", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		hctx := s.GetHookContext(c, -1, "")
+		code, ctx := s.run(c, hctx, "health-check-set", t.args...)
+		c.Check(code, gc.Equals, 2)
+		c.Check(bufferString(ctx.Stderr), gc.Matches, "ERROR "+t.err+"\n")
+		c.Check(hctx.info.HealthChecks.Checks, gc.HasLen, 0)
+	}
+}
+
+func (s *HealthCheckSuite) TestGet(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.HealthChecks.Checks = map[string]healthcheck.Check{
+		"daemon": {Name: "daemon", Kind: healthcheck.Exec, Command: "pgrep foo"},
+		"db":     {Name: "db", Kind: healthcheck.TCP, Address: "localhost:5432", Interval: time.Minute},
+	}
+
+	code, ctx := s.run(c, hctx, "health-check-get")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, `
+daemon:
+  kind: exec
+  command: pgrep foo
+  interval: 30s
+  timeout: 10s
+  threshold: 3
+db:
+  kind: tcp
+  address: localhost:5432
+  interval: 1m0s
+  timeout: 10s
+  threshold: 3
+`[1:])
+
+	code, ctx = s.run(c, hctx, "health-check-get", "db", "--format", "json")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals,
+		`{"kind":"tcp","address":"localhost:5432","interval":"1m0s","timeout":"10s","threshold":3}`+"\n")
+
+	code, ctx = s.run(c, hctx, "health-check-get", "web")
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR health check \"web\" not found\n")
+}
+
+func (s *HealthCheckSuite) TestDelete(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.HealthChecks.Checks = map[string]healthcheck.Check{
+		"daemon": {Name: "daemon", Kind: healthcheck.Exec, Command: "pgrep foo"},
+	}
+	code, ctx := s.run(c, hctx, "health-check-delete", "daemon")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.HealthChecks.Checks, gc.HasLen, 0)
+
+	code, ctx = s.run(c, hctx, "health-check-delete")
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR no health check name specified\n")
+}
@@ -13,6 +13,7 @@ import (
 type ContextInfo struct {
 	Unit
 	UnitCharmState
+	HealthChecks
 	Status
 	Instance
 	NetworkInterface
@@ -59,6 +60,7 @@ type contextBase struct {
 type Context struct {
 	ContextUnit
 	ContextUnitCharmState
+	ContextHealthChecks
 	ContextStatus
 	ContextInstance
 	ContextNetworking
@@ -101,5 +103,7 @@ func NewContext(stub *testing.Stub, info *ContextInfo) *Context {
 	ctx.ContextVersion.info = &info.Version
 	ctx.ContextUnitCharmState.stub = stub
 	ctx.ContextUnitCharmState.info = &info.UnitCharmState
+	ctx.ContextHealthChecks.stub = stub
+	ctx.ContextHealthChecks.info = &info.HealthChecks
 	return &ctx
 }
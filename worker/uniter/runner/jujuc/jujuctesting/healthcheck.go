@@ -0,0 +1,55 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuctesting
+
+import (
+	"github.com/juju/juju/core/healthcheck"
+)
+
+// HealthChecks holds the values for the hook context.
+type HealthChecks struct {
+	Checks map[string]healthcheck.Check
+}
+
+// ContextHealthChecks is a test double for jujuc.unitHealthCheckContext.
+type ContextHealthChecks struct {
+	contextBase
+	info *HealthChecks
+}
+
+// HealthChecks implements jujuc.unitHealthCheckContext.
+func (c *ContextHealthChecks) HealthChecks() (map[string]healthcheck.Check, error) {
+	c.stub.AddCall("HealthChecks")
+	if err := c.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	result := make(map[string]healthcheck.Check, len(c.info.Checks))
+	for name, check := range c.info.Checks {
+		result[name] = check
+	}
+	return result, nil
+}
+
+// SetHealthCheck implements jujuc.unitHealthCheckContext.
+func (c *ContextHealthChecks) SetHealthCheck(check healthcheck.Check) error {
+	c.stub.AddCall("SetHealthCheck", check)
+	if err := c.stub.NextErr(); err != nil {
+		return err
+	}
+	if c.info.Checks == nil {
+		c.info.Checks = make(map[string]healthcheck.Check)
+	}
+	c.info.Checks[check.Name] = check
+	return nil
+}
+
+// DeleteHealthCheck implements jujuc.unitHealthCheckContext.
+func (c *ContextHealthChecks) DeleteHealthCheck(name string) error {
+	c.stub.AddCall("DeleteHealthCheck", name)
+	if err := c.stub.NextErr(); err != nil {
+		return err
+	}
+	delete(c.info.Checks, name)
+	return nil
+}
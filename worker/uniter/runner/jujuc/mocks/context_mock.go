@@ -9,6 +9,7 @@ import (
 	charm "github.com/juju/charm/v7"
 	params "github.com/juju/juju/apiserver/params"
 	application "github.com/juju/juju/core/application"
+	healthcheck "github.com/juju/juju/core/healthcheck"
 	network "github.com/juju/juju/core/network"
 	jujuc "github.com/juju/juju/worker/uniter/runner/jujuc"
 	names "github.com/juju/names/v4"
@@ -199,6 +200,20 @@ func (mr *MockContextMockRecorder) DeleteCharmStateValue(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCharmStateValue", reflect.TypeOf((*MockContext)(nil).DeleteCharmStateValue), arg0)
 }
 
+// DeleteHealthCheck mocks base method
+func (m *MockContext) DeleteHealthCheck(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHealthCheck", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHealthCheck indicates an expected call of DeleteHealthCheck
+func (mr *MockContextMockRecorder) DeleteHealthCheck(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHealthCheck", reflect.TypeOf((*MockContext)(nil).DeleteHealthCheck), arg0)
+}
+
 // GetCharmState mocks base method
 func (m *MockContext) GetCharmState() (map[string]string, error) {
 	m.ctrl.T.Helper()
@@ -274,6 +289,21 @@ func (mr *MockContextMockRecorder) GoalState() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GoalState", reflect.TypeOf((*MockContext)(nil).GoalState))
 }
 
+// HealthChecks mocks base method
+func (m *MockContext) HealthChecks() (map[string]healthcheck.Check, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HealthChecks")
+	ret0, _ := ret[0].(map[string]healthcheck.Check)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HealthChecks indicates an expected call of HealthChecks
+func (mr *MockContextMockRecorder) HealthChecks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthChecks", reflect.TypeOf((*MockContext)(nil).HealthChecks))
+}
+
 // HookRelation mocks base method
 func (m *MockContext) HookRelation() (jujuc.ContextRelation, error) {
 	m.ctrl.T.Helper()
@@ -551,6 +581,20 @@ func (mr *MockContextMockRecorder) SetCharmStateValue(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCharmStateValue", reflect.TypeOf((*MockContext)(nil).SetCharmStateValue), arg0, arg1)
 }
 
+// SetHealthCheck mocks base method
+func (m *MockContext) SetHealthCheck(arg0 healthcheck.Check) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHealthCheck", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHealthCheck indicates an expected call of SetHealthCheck
+func (mr *MockContextMockRecorder) SetHealthCheck(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHealthCheck", reflect.TypeOf((*MockContext)(nil).SetHealthCheck), arg0)
+}
+
 // SetPodSpec mocks base method
 func (m *MockContext) SetPodSpec(arg0 string) error {
 	m.ctrl.T.Helper()
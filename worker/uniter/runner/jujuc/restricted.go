@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/healthcheck"
 	"github.com/juju/juju/core/network"
 )
 
@@ -51,6 +52,21 @@ func (*RestrictedContext) SetCharmStateValue(string, string) error {
 	return ErrRestrictedContext
 }
 
+// HealthChecks implements jujuc.unitHealthCheckContext.
+func (*RestrictedContext) HealthChecks() (map[string]healthcheck.Check, error) {
+	return nil, ErrRestrictedContext
+}
+
+// SetHealthCheck implements jujuc.unitHealthCheckContext.
+func (*RestrictedContext) SetHealthCheck(healthcheck.Check) error {
+	return ErrRestrictedContext
+}
+
+// DeleteHealthCheck implements jujuc.unitHealthCheckContext.
+func (*RestrictedContext) DeleteHealthCheck(string) error {
+	return ErrRestrictedContext
+}
+
 // UnitStatus implements hooks.Context.
 func (*RestrictedContext) UnitStatus() (*StatusInfo, error) {
 	return nil, ErrRestrictedContext
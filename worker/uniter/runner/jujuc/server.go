@@ -82,6 +82,10 @@ var baseCommands = map[string]creator{
 	"state-get" + cmdSuffix:    NewStateGetCommand,
 	"state-delete" + cmdSuffix: NewStateDeleteCommand,
 	"state-set" + cmdSuffix:    NewStateSetCommand,
+
+	"health-check-get" + cmdSuffix:    NewHealthCheckGetCommand,
+	"health-check-delete" + cmdSuffix: NewHealthCheckDeleteCommand,
+	"health-check-set" + cmdSuffix:    NewHealthCheckSetCommand,
 }
 
 type functionCmdCreator func(Context, string) (cmd.Command, error)
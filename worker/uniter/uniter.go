@@ -4,16 +4,18 @@
 package uniter
 
 import (
+	"bytes"
+	stdcontext "context"
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	corecharm "github.com/juju/charm/v7"
 	"github.com/juju/charm/v7/hooks"
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
-	"github.com/juju/utils"
 	"github.com/juju/utils/exec"
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/catacomb"
@@ -33,7 +35,9 @@ import (
 	"github.com/juju/juju/worker/uniter/actions"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/container"
+	"github.com/juju/juju/worker/uniter/healthcheck"
 	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/hookretry"
 	uniterleadership "github.com/juju/juju/worker/uniter/leadership"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/relation"
@@ -141,6 +145,9 @@ type Uniter struct {
 	// hookRetryStrategy represents configuration for hook retries
 	hookRetryStrategy params.RetryStrategy
 
+	// retryHookTimer schedules the retries of a failed hook.
+	retryHookTimer *hookretry.Timer
+
 	// downloader is the downloader that should be used to get the charm
 	// archive.
 	downloader charm.Downloader
@@ -149,6 +156,10 @@ type Uniter struct {
 	// rebooted so we can notify the charms accordingly.
 	rebootQuerier RebootQuerier
 	logger        Logger
+
+	// healthChecker probes the workload health checks registered by
+	// the charm.
+	healthChecker *healthcheck.Worker
 }
 
 // UniterParams hold all the necessary parameters for a new Uniter.
@@ -345,23 +356,16 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 
 	u.logger.Infof("hooks are retried %v", u.hookRetryStrategy.ShouldRetry)
 	retryHookChan := make(chan struct{}, 1)
-	// TODO(katco): 2016-08-09: This type is deprecated: lp:1611427
-	retryHookTimer := utils.NewBackoffTimer(utils.BackoffTimerConfig{
-		Min:    u.hookRetryStrategy.MinRetryTime,
-		Max:    u.hookRetryStrategy.MaxRetryTime,
-		Jitter: u.hookRetryStrategy.JitterRetryTime,
-		Factor: u.hookRetryStrategy.RetryTimeFactor,
-		Func: func() {
-			// Don't try to send on the channel if it's already full
-			// This can happen if the timer fires off before the event is consumed
-			// by the resolver loop
-			select {
-			case retryHookChan <- struct{}{}:
-			default:
-			}
-		},
-		Clock: u.clock,
+	retryHookTimer := hookretry.NewTimer(u.hookRetryStrategy, u.clock, func() {
+		// Don't try to send on the channel if it's already full
+		// This can happen if the timer fires off before the event is consumed
+		// by the resolver loop
+		select {
+		case retryHookChan <- struct{}{}:
+		default:
+		}
 	})
+	u.retryHookTimer = retryHookTimer
 	defer func() {
 		// Whenever we exit the uniter we want to stop a potentially
 		// running timer so it doesn't trigger for nothing.
@@ -385,6 +389,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				ApplicationChannel:            u.applicationChannel,
 				ContainerRunningStatusChannel: u.containerRunningStatusChannel,
 				ContainerRunningStatusFunc:    u.containerRunningStatusFunc,
+				WorkloadHealthChannel:         u.healthChecker.Changes(),
 				ModelType:                     u.modelType,
 				Logger:                        u.logger.Child("remotestate"),
 				CanApplyCharmProfile:          canApplyCharmProfile,
@@ -704,6 +709,31 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	}
 	u.storage = storageAttachments
 
+	prober := healthcheck.NewProber(u.paths.State.CharmDir)
+	if u.modelType == model.CAAS {
+		// The agent does not run alongside a CAAS workload, so exec
+		// checks must be run in the workload pod, or not at all.
+		var exec healthcheck.ExecFunc
+		if u.newRemoteRunnerExecutor != nil {
+			exec = remoteHealthCheckExec(u.newRemoteRunnerExecutor(u.unit, u.paths))
+		}
+		prober = healthcheck.NewRemoteProber(u.paths.State.CharmDir, exec)
+	}
+	healthChecker, err := healthcheck.NewWorker(healthcheck.Config{
+		Store:  healthcheck.NewDiskStore(u.paths.State.HealthChecksFile),
+		Prober: prober,
+		Unit:   u.unit,
+		Clock:  u.clock,
+		Logger: u.logger.Child("healthcheck"),
+	})
+	if err != nil {
+		return errors.Annotatef(err, "cannot create health check worker")
+	}
+	if err := u.catacomb.Add(healthChecker); err != nil {
+		return errors.Trace(err)
+	}
+	u.healthChecker = healthChecker
+
 	if err := charm.ClearDownloads(u.paths.State.BundlesDir); err != nil {
 		u.logger.Warningf(err.Error())
 	}
@@ -726,6 +756,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		Tracker:          u.leadershipTracker,
 		GetRelationInfos: u.relationStateTracker.GetInfo,
 		Storage:          u.storage,
+		HealthChecks:     u.healthChecker,
 		Paths:            u.paths,
 		Clock:            u.clock,
 		Logger:           u.logger.Child("context"),
@@ -866,5 +897,41 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 	}
 	statusData["hook"] = hookName
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
+	if u.retryHookTimer != nil && u.hookRetryStrategy.ShouldRetry {
+		retry := u.retryHookTimer.Status()
+		switch {
+		case retry.Exhausted:
+			statusData["retry-attempts"] = retry.Attempt
+			statusMessage += fmt.Sprintf(" (gave up after %d retries)", retry.Attempt)
+		case retry.Attempt > 0:
+			next := retry.Next.UTC().Format(time.RFC3339)
+			statusData["retry-attempt"] = retry.Attempt
+			statusData["next-retry"] = next
+			attempt := fmt.Sprint(retry.Attempt)
+			if retry.MaxAttempts > 0 {
+				attempt = fmt.Sprintf("%d/%d", retry.Attempt, retry.MaxAttempts)
+			}
+			statusMessage += fmt.Sprintf(" (retry %s at %s)", attempt, next)
+		}
+	}
 	return setAgentStatus(u, status.Error, statusMessage, statusData)
 }
+
+// remoteHealthCheckExec returns a healthcheck.ExecFunc that runs exec
+// health checks with the runner's remote executor.
+func remoteHealthCheckExec(execute runner.ExecFunc) healthcheck.ExecFunc {
+	return func(ctx stdcontext.Context, command, dir string) ([]byte, error) {
+		var stdout, stderr bytes.Buffer
+		resp, err := execute(runner.ExecParams{
+			Commands:   []string{command},
+			WorkingDir: dir,
+			Cancel:     ctx.Done(),
+			Stdout:     &stdout,
+			Stderr:     &stderr,
+		})
+		if resp == nil {
+			return nil, errors.Trace(err)
+		}
+		return append(resp.Stdout, resp.Stderr...), err
+	}
+}